@@ -94,6 +94,13 @@ type AddrManager struct {
 
 	// triedBucketSize is the maximum number of addresses in each tried bucket.
 	triedBucketSize int
+
+	// asmap is an optional IP-to-ASN map used to group addresses by the
+	// autonomous system that announces them.  It is nil when addresses are
+	// grouped by network prefix.  It is only set prior to starting the
+	// address manager, so it does not need to be protected for concurrent
+	// access.
+	asmap *ASMap
 }
 
 // serializedKnownAddress is used to represent the serializable state of a
@@ -111,11 +118,12 @@ type serializedKnownAddress struct {
 // serializedAddrManager is used to represent the serializable state of an
 // address manager instance.
 type serializedAddrManager struct {
-	Version      int
-	Key          [32]byte
-	Addresses    []*serializedKnownAddress
-	NewBuckets   [newBucketCount][]string
-	TriedBuckets [triedBucketCount][]string
+	Version       int
+	Key           [32]byte
+	Addresses     []*serializedKnownAddress
+	NewBuckets    [newBucketCount][]string
+	TriedBuckets  [triedBucketCount][]string
+	ASMapChecksum string
 }
 
 type localAddress struct {
@@ -356,12 +364,12 @@ func (a *AddrManager) getOldestAddressIndex(bucket int) int {
 }
 
 // getNewBucket returns a psuedorandom new bucket index for the provided
-// addresses.
-func getNewBucket(key [32]byte, netAddr, srcAddr *NetAddress) int {
+// addresses using the passed function to determine their network groups.
+func getNewBucket(key [32]byte, netAddr, srcAddr *NetAddress, groupKey func(*NetAddress) string) int {
 	data1 := []byte{}
 	data1 = append(data1, key[:]...)
-	data1 = append(data1, []byte(netAddr.GroupKey())...)
-	data1 = append(data1, []byte(srcAddr.GroupKey())...)
+	data1 = append(data1, []byte(groupKey(netAddr))...)
+	data1 = append(data1, []byte(groupKey(srcAddr))...)
 	hash1 := chainhash.HashB(data1)
 	hash64 := binary.LittleEndian.Uint64(hash1)
 	hash64 %= newBucketsPerGroup
@@ -369,7 +377,7 @@ func getNewBucket(key [32]byte, netAddr, srcAddr *NetAddress) int {
 	binary.LittleEndian.PutUint64(hashbuf[:], hash64)
 	data2 := []byte{}
 	data2 = append(data2, key[:]...)
-	data2 = append(data2, srcAddr.GroupKey()...)
+	data2 = append(data2, groupKey(srcAddr)...)
 	data2 = append(data2, hashbuf[:]...)
 
 	hash2 := chainhash.HashB(data2)
@@ -377,8 +385,8 @@ func getNewBucket(key [32]byte, netAddr, srcAddr *NetAddress) int {
 }
 
 // getTriedBucket returns a psuedorandom tried bucket index for the provided
-// address.
-func getTriedBucket(key [32]byte, netAddr *NetAddress) int {
+// address using the passed function to determine its network group.
+func getTriedBucket(key [32]byte, netAddr *NetAddress, groupKey func(*NetAddress) string) int {
 	data1 := []byte{}
 	data1 = append(data1, key[:]...)
 	data1 = append(data1, []byte(netAddr.Key())...)
@@ -389,7 +397,7 @@ func getTriedBucket(key [32]byte, netAddr *NetAddress) int {
 	binary.LittleEndian.PutUint64(hashbuf[:], hash64)
 	data2 := []byte{}
 	data2 = append(data2, key[:]...)
-	data2 = append(data2, netAddr.GroupKey()...)
+	data2 = append(data2, groupKey(netAddr)...)
 	data2 = append(data2, hashbuf[:]...)
 
 	hash2 := chainhash.HashB(data2)
@@ -431,6 +439,7 @@ func (a *AddrManager) savePeers() {
 	sam := new(serializedAddrManager)
 	sam.Version = serialisationVersion
 	copy(sam.Key[:], a.key[:])
+	sam.ASMapChecksum = a.asmapChecksum()
 
 	sam.Addresses = make([]*serializedKnownAddress, len(a.addrIndex))
 	i := 0
@@ -555,6 +564,24 @@ func (a *AddrManager) deserializePeers(filePath string) error {
 		a.addrIndex[ka.na.Key()] = ka
 	}
 
+	// Place all addresses in new buckets based on their current network
+	// groups when the AS map in use changed since the buckets were saved.
+	if sam.ASMapChecksum != a.asmapChecksum() {
+		log.Infof("Rebucketing %d addresses due to a change in the AS map",
+			len(a.addrIndex))
+		for k, ka := range a.addrIndex {
+			bucket := a.getNewBucket(ka.na, ka.srcAddr)
+			if len(a.addrNew[bucket]) >= newBucketSize {
+				a.expireNew(bucket)
+			}
+			ka.refs++
+			a.nNew++
+			a.addrNew[bucket][k] = ka
+		}
+		a.addrChanged = true
+		return nil
+	}
+
 	for i := range sam.NewBuckets {
 		for _, val := range sam.NewBuckets[i] {
 			ka, ok := a.addrIndex[val]
@@ -651,6 +678,52 @@ func (a *AddrManager) AddAddresses(addrs []*NetAddress, srcAddr *NetAddress) {
 	}
 }
 
+// BucketStats houses statistics about how full the new and tried address
+// buckets of the address manager are.
+type BucketStats struct {
+	// NumNew and NumTried are the total number of addresses in the new and
+	// tried buckets, respectively.
+	NumNew   int
+	NumTried int
+
+	// NewCapacity and TriedCapacity are the maximum number of addresses the
+	// new and tried buckets can hold, respectively.
+	NewCapacity   int
+	TriedCapacity int
+
+	// NewBucketsUsed and TriedBucketsUsed are the number of new and tried
+	// buckets that contain at least one address, respectively.
+	NewBucketsUsed   int
+	TriedBucketsUsed int
+}
+
+// BucketStats returns statistics about how full the new and tried address
+// buckets are.
+//
+// This function is safe for concurrent access.
+func (a *AddrManager) BucketStats() BucketStats {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	stats := BucketStats{
+		NumNew:        a.nNew,
+		NumTried:      a.nTried,
+		NewCapacity:   newBucketCount * newBucketSize,
+		TriedCapacity: triedBucketCount * a.triedBucketSize,
+	}
+	for i := range a.addrNew {
+		if len(a.addrNew[i]) > 0 {
+			stats.NewBucketsUsed++
+		}
+	}
+	for i := range a.addrTried {
+		if len(a.addrTried[i]) > 0 {
+			stats.TriedBucketsUsed++
+		}
+	}
+	return stats
+}
+
 // numAddresses returns the number of addresses known to the address manager.
 //
 // This function MUST be called with the address manager lock held (for reads).
@@ -733,10 +806,10 @@ func (a *AddrManager) reset() {
 	}
 	a.addrChanged = true
 	a.getNewBucket = func(netAddr, srcAddr *NetAddress) int {
-		return getNewBucket(a.key, netAddr, srcAddr)
+		return getNewBucket(a.key, netAddr, srcAddr, a.GroupKey)
 	}
 	a.getTriedBucket = func(netAddr *NetAddress) int {
-		return getTriedBucket(a.key, netAddr)
+		return getTriedBucket(a.key, netAddr, a.GroupKey)
 	}
 }
 
@@ -753,6 +826,11 @@ func EncodeHost(host string) (NetAddressType, []byte) {
 		return IPv6Address, ip
 	}
 
+	// Look for TORv3 onion addresses.
+	if pubKey, ok := decodeTORv3Host(host); ok {
+		return TORv3Address, pubKey
+	}
+
 	// The given host address could not be recognized
 	return UnknownAddressType, nil
 }
@@ -1083,6 +1161,9 @@ const (
 
 	// Ipv6Strong represents a connection state between two IPv6 addresses.
 	Ipv6Strong
+
+	// Private represents a connection state between two TORv3 addresses.
+	Private
 )
 
 // getRemoteReachabilityFromLocal returns the type of connection reachability
@@ -1094,6 +1175,17 @@ func getRemoteReachabilityFromLocal(localAddr, remoteAddr *NetAddress) NetAddres
 	case !remoteAddr.IsRoutable():
 		return Unreachable
 
+	case remoteAddr.Type == TORv3Address:
+		switch {
+		case localAddr.Type == TORv3Address:
+			return Private
+		case localAddr.IsRoutable() && localAddr.Type == IPv4Address:
+			// Tor users can reach IPv4 addresses as well.
+			return Ipv4
+		default:
+			return Default
+		}
+
 	case isRFC4380(remoteAddr.IP):
 		switch {
 		case !localAddr.IsRoutable():
@@ -1110,13 +1202,15 @@ func getRemoteReachabilityFromLocal(localAddr, remoteAddr *NetAddress) NetAddres
 		switch {
 		case localAddr.IsRoutable() && localAddr.Type == IPv4Address:
 			return Ipv4
+		case localAddr.Type == TORv3Address:
+			return Default
 		default:
 			return Unreachable
 		}
 
 	case remoteAddr.Type == IPv6Address:
 		switch {
-		case !localAddr.IsRoutable():
+		case !localAddr.IsRoutable() || localAddr.Type == TORv3Address:
 			return Default
 		case isRFC4380(localAddr.IP):
 			return Teredo
@@ -1213,6 +1307,59 @@ func (a *AddrManager) IsExternalAddrCandidate(localAddr, remoteAddr *NetAddress)
 	return goodReach, reach
 }
 
+// SetASMap sets the IP-to-ASN map used to group addresses by the autonomous
+// system that announces them instead of by network prefix.  Known addresses
+// loaded at startup are automatically rebucketed when the map differs from
+// the one in use when they were saved.
+//
+// This MUST be called prior to starting the address manager.
+func (a *AddrManager) SetASMap(asmap *ASMap) {
+	a.asmap = asmap
+}
+
+// asmapChecksum returns a string representation of the checksum of the AS map
+// in use or an empty string when there is none.
+func (a *AddrManager) asmapChecksum() string {
+	if a.asmap == nil {
+		return ""
+	}
+	checksum := a.asmap.Checksum()
+	return checksum.String()
+}
+
+// ASN returns the autonomous system number the provided address is mapped to
+// by the AS map in use.  Zero is returned when there is no AS map or the
+// address is not mapped.
+//
+// This function is safe for concurrent access.
+func (a *AddrManager) ASN(na *NetAddress) uint32 {
+	if a.asmap == nil {
+		return 0
+	}
+	if na.Type != IPv4Address && na.Type != IPv6Address {
+		return 0
+	}
+	netIP := net.IP(na.IP)
+	if !IsRoutable(netIP) {
+		return 0
+	}
+	return a.asmap.Lookup(netIP)
+}
+
+// GroupKey returns a string representing the network group the provided
+// address is part of for the purposes of bucketing and outbound connection
+// diversity.  Addresses that are mapped by the AS map in use are grouped by
+// their autonomous system number in the form "AS<number>".  Otherwise, it is
+// the network prefix group returned by NetAddress.GroupKey.
+//
+// This function is safe for concurrent access.
+func (a *AddrManager) GroupKey(na *NetAddress) string {
+	if asn := a.ASN(na); asn != 0 {
+		return fmt.Sprintf("AS%d", asn)
+	}
+	return na.GroupKey()
+}
+
 // New constructs a new address manager instance.
 // Use Start to begin processing asynchronous address updates.
 func New(dataDir string) *AddrManager {
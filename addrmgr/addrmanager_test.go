@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -249,6 +250,121 @@ func TestStartStop(t *testing.T) {
 	}
 }
 
+// TestASMapGroupKey ensures addresses are grouped by the autonomous system
+// that announces them when an AS map is set.
+func TestASMapGroupKey(t *testing.T) {
+	asmap, err := ParseASMap(strings.NewReader(testASMap))
+	if err != nil {
+		t.Fatalf("unexpected error parsing map: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		ip        string
+		wantASN   uint32
+		wantGroup string
+	}{{
+		name:      "mapped IPv4",
+		ip:        routableIPv4Addr,
+		wantASN:   64496,
+		wantGroup: "AS64496",
+	}, {
+		name:      "mapped IPv6",
+		ip:        routableIPv6Addr,
+		wantASN:   3320,
+		wantGroup: "AS3320",
+	}, {
+		name:      "unmapped IPv4",
+		ip:        "12.1.2.3",
+		wantGroup: "12.1.0.0",
+	}, {
+		name:      "unroutable",
+		ip:        nonRoutableIPv4Addr,
+		wantGroup: "unroutable",
+	}}
+
+	n := New("testasmapgroupkey")
+	n.SetASMap(asmap)
+	for _, test := range tests {
+		na := NewNetAddressFromIPPort(net.ParseIP(test.ip), 8333, 0)
+		if asn := n.ASN(na); asn != test.wantASN {
+			t.Errorf("%s: unexpected ASN -- got %d, want %d", test.name, asn,
+				test.wantASN)
+		}
+		if group := n.GroupKey(na); group != test.wantGroup {
+			t.Errorf("%s: unexpected group -- got %s, want %s", test.name,
+				group, test.wantGroup)
+		}
+	}
+
+	// Ensure addresses are grouped by network prefix without an AS map.
+	n = New("testasmapgroupkey")
+	na := NewNetAddressFromIPPort(net.ParseIP(routableIPv4Addr), 8333, 0)
+	if asn := n.ASN(na); asn != 0 {
+		t.Errorf("unexpected ASN without map -- got %d, want 0", asn)
+	}
+	if group, want := n.GroupKey(na), na.GroupKey(); group != want {
+		t.Errorf("unexpected group without map -- got %s, want %s", group,
+			want)
+	}
+}
+
+// TestASMapRebucket ensures known addresses are retained and placed in new
+// buckets when the AS map changes between runs.
+func TestASMapRebucket(t *testing.T) {
+	dir := t.TempDir()
+	asmap, err := ParseASMap(strings.NewReader(testASMap))
+	if err != nil {
+		t.Fatalf("unexpected error parsing map: %v", err)
+	}
+
+	// Add an address and mark it good so it is moved to a tried bucket.
+	amgr := New(dir)
+	amgr.Start()
+	amgr.addAddressByIP(routableIPv4Addr, 8333)
+	na := NewNetAddressFromIPPort(net.ParseIP(routableIPv4Addr), 8333, 0)
+	if err := amgr.Good(na); err != nil {
+		t.Fatalf("unexpected error marking address good: %v", err)
+	}
+	if err := amgr.Stop(); err != nil {
+		t.Fatalf("address manager failed to stop: %v", err)
+	}
+
+	// Start a new address manager with an AS map and ensure the address is
+	// rebucketed into a new bucket.
+	amgr = New(dir)
+	amgr.SetASMap(asmap)
+	amgr.Start()
+	stats := amgr.BucketStats()
+	if stats.NumNew != 1 || stats.NumTried != 0 {
+		t.Fatalf("unexpected stats after rebucketing: %+v", stats)
+	}
+	if err := amgr.Stop(); err != nil {
+		t.Fatalf("address manager failed to stop: %v", err)
+	}
+
+	// Ensure the buckets are loaded as is when the AS map is unchanged.
+	amgr = New(dir)
+	amgr.SetASMap(asmap)
+	amgr.Start()
+	if err := amgr.Good(na); err != nil {
+		t.Fatalf("unexpected error marking address good: %v", err)
+	}
+	if err := amgr.Stop(); err != nil {
+		t.Fatalf("address manager failed to stop: %v", err)
+	}
+	amgr = New(dir)
+	amgr.SetASMap(asmap)
+	amgr.Start()
+	stats = amgr.BucketStats()
+	if stats.NumNew != 0 || stats.NumTried != 1 {
+		t.Fatalf("unexpected stats with unchanged map: %+v", stats)
+	}
+	if err := amgr.Stop(); err != nil {
+		t.Fatalf("address manager failed to stop: %v", err)
+	}
+}
+
 // TestNeedMoreAddresses adds 1000 addresses and then checks to see if
 // NeedMoreAddresses correctly determines that no more addresses are needed.
 func TestNeedMoreAddresses(t *testing.T) {
@@ -280,6 +396,46 @@ func TestNeedMoreAddresses(t *testing.T) {
 	}
 }
 
+// TestBucketStats ensures the bucket statistics reflect addresses moving from
+// the new to the tried buckets.
+func TestBucketStats(t *testing.T) {
+	n := New("testbucketstats")
+	n.getNewBucket = func(netAddr, srcAddr *NetAddress) int {
+		return 0
+	}
+	n.getTriedBucket = func(netAddr *NetAddress) int {
+		return 0
+	}
+
+	stats := n.BucketStats()
+	want := BucketStats{
+		NewCapacity:   newBucketCount * newBucketSize,
+		TriedCapacity: triedBucketCount * defaultTriedBucketSize,
+	}
+	if stats != want {
+		t.Fatalf("unexpected empty stats -- got %+v, want %+v", stats, want)
+	}
+
+	addrA := NewNetAddressFromIPPort(net.ParseIP("173.144.173.1"), 8333, 0)
+	addrB := NewNetAddressFromIPPort(net.ParseIP("173.144.173.2"), 8333, 0)
+	srcAddr := NewNetAddressFromIPPort(net.ParseIP("173.144.173.111"), 8333, 0)
+	n.AddAddresses([]*NetAddress{addrA, addrB}, srcAddr)
+	want.NumNew, want.NewBucketsUsed = 2, 1
+	if stats := n.BucketStats(); stats != want {
+		t.Fatalf("unexpected stats after add -- got %+v, want %+v", stats,
+			want)
+	}
+
+	if err := n.Good(addrA); err != nil {
+		t.Fatalf("unexpected error marking address good: %v", err)
+	}
+	want.NumNew, want.NumTried, want.TriedBucketsUsed = 1, 1, 1
+	if stats := n.BucketStats(); stats != want {
+		t.Fatalf("unexpected stats after good -- got %+v, want %+v", stats,
+			want)
+	}
+}
+
 // TestAddressCache ensures that AddressCache doesn't return bad addresses,
 // never-attempted addresses, or addresses which don't match the given filter.
 func TestAddressCache(t *testing.T) {
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package addrmgr
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+)
+
+// ASMap is an immutable mapping of IP network prefixes to the autonomous system
+// numbers (ASNs) that announce them.  Lookups return the ASN of the most
+// specific prefix that contains an address.
+//
+// The textual format parsed by ParseASMap consists of one prefix per line in
+// CIDR notation followed by whitespace and the ASN with an optional "AS"
+// prefix.  Blank lines and anything following a '#' are ignored.  For example:
+//
+//	# prefix        asn
+//	1.0.0.0/24      13335
+//	2001:db8::/32   AS64496
+type ASMap struct {
+	// prefixes houses the ASNs keyed by the masked 16-byte form of each
+	// prefix for every prefix length that is in use.
+	prefixes map[int]map[[16]byte]uint32
+
+	// lengths houses the prefix lengths that are in use in descending order
+	// so the most specific prefix is found first.
+	lengths []int
+
+	// checksum is the hash of the data the map was parsed from.  It is used
+	// to detect when the map in use changes between runs.
+	checksum chainhash.Hash
+}
+
+// ParseASMap parses an IP-to-ASN map in the textual format described by the
+// documentation of ASMap from the passed reader.
+func ParseASMap(r io.Reader) (*ASMap, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &ASMap{
+		prefixes: make(map[int]map[[16]byte]uint32),
+		checksum: chainhash.HashH(data),
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			str := fmt.Sprintf("line %d: expected prefix and ASN", lineNum)
+			return nil, makeError(ErrInvalidASMap, str)
+		}
+
+		_, ipNet, err := net.ParseCIDR(fields[0])
+		if err != nil {
+			str := fmt.Sprintf("line %d: invalid prefix %q", lineNum,
+				fields[0])
+			return nil, makeError(ErrInvalidASMap, str)
+		}
+		asnStr := strings.TrimPrefix(strings.ToUpper(fields[1]), "AS")
+		asn, err := strconv.ParseUint(asnStr, 10, 32)
+		if err != nil || asn == 0 {
+			str := fmt.Sprintf("line %d: invalid ASN %q", lineNum, fields[1])
+			return nil, makeError(ErrInvalidASMap, str)
+		}
+
+		// Store all prefixes in their 16-byte form so IPv4 addresses can be
+		// looked up the same way regardless of their representation.
+		ones, _ := ipNet.Mask.Size()
+		if len(ipNet.IP) == net.IPv4len {
+			ones += 8 * (net.IPv6len - net.IPv4len)
+		}
+		var key [16]byte
+		copy(key[:], ipNet.IP.To16())
+
+		prefixes, ok := m.prefixes[ones]
+		if !ok {
+			prefixes = make(map[[16]byte]uint32)
+			m.prefixes[ones] = prefixes
+			m.lengths = append(m.lengths, ones)
+		}
+		if oldASN, ok := prefixes[key]; ok && oldASN != uint32(asn) {
+			str := fmt.Sprintf("line %d: prefix %s is mapped to both AS%d "+
+				"and AS%d", lineNum, fields[0], oldASN, asn)
+			return nil, makeError(ErrInvalidASMap, str)
+		}
+		prefixes[key] = uint32(asn)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(m.lengths)))
+	return m, nil
+}
+
+// LoadASMap loads an IP-to-ASN map in the textual format described by the
+// documentation of ASMap from the file at the passed path.
+func LoadASMap(filePath string) (*ASMap, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m, err := ParseASMap(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return m, nil
+}
+
+// Lookup returns the ASN of the most specific prefix in the map that contains
+// the passed IP address.  Zero is returned when the address is not mapped.
+func (m *ASMap) Lookup(ip net.IP) uint32 {
+	ip16 := ip.To16()
+	if ip16 == nil {
+		return 0
+	}
+	for _, ones := range m.lengths {
+		var key [16]byte
+		copy(key[:], ip16.Mask(net.CIDRMask(ones, 8*net.IPv6len)))
+		if asn, ok := m.prefixes[ones][key]; ok {
+			return asn
+		}
+	}
+	return 0
+}
+
+// NumPrefixes returns the number of prefixes in the map.
+func (m *ASMap) NumPrefixes() int {
+	var n int
+	for _, prefixes := range m.prefixes {
+		n += len(prefixes)
+	}
+	return n
+}
+
+// Checksum returns the hash of the data the map was parsed from.
+func (m *ASMap) Checksum() chainhash.Hash {
+	return m.checksum
+}
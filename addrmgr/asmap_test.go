@@ -0,0 +1,113 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package addrmgr
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testASMap is an IP-to-ASN map used throughout the tests.
+const testASMap = `# prefix        asn
+173.194.0.0/16  15169
+173.194.115.0/24 AS64496   # more specific prefix
+
+2003::/19       3320
+2001:db8::/32   as64497
+`
+
+// TestParseASMap ensures IP-to-ASN maps are parsed and looked up as expected.
+func TestParseASMap(t *testing.T) {
+	asmap, err := ParseASMap(strings.NewReader(testASMap))
+	if err != nil {
+		t.Fatalf("unexpected error parsing map: %v", err)
+	}
+	if n := asmap.NumPrefixes(); n != 4 {
+		t.Fatalf("unexpected number of prefixes -- got %d, want 4", n)
+	}
+
+	lookupTests := []struct {
+		ip   string
+		want uint32
+	}{
+		{ip: "173.194.1.1", want: 15169},
+		{ip: "173.194.115.66", want: 64496},
+		{ip: "::ffff:173.194.115.66", want: 64496},
+		{ip: "173.195.0.1", want: 0},
+		{ip: "2003::1", want: 3320},
+		{ip: "2003:1fff::1", want: 3320},
+		{ip: "2003:2000::1", want: 0},
+		{ip: "2001:db8:1::1", want: 64497},
+	}
+	for _, test := range lookupTests {
+		if got := asmap.Lookup(net.ParseIP(test.ip)); got != test.want {
+			t.Errorf("%s: unexpected ASN -- got %d, want %d", test.ip, got,
+				test.want)
+		}
+	}
+	if got := asmap.Lookup(nil); got != 0 {
+		t.Errorf("unexpected ASN for nil IP -- got %d, want 0", got)
+	}
+
+	// Ensure the checksum commits to the map data.
+	other, err := ParseASMap(strings.NewReader(testASMap + "10.0.0.0/8 1\n"))
+	if err != nil {
+		t.Fatalf("unexpected error parsing map: %v", err)
+	}
+	if asmap.Checksum() == other.Checksum() {
+		t.Fatal("checksums of different maps are identical")
+	}
+
+	// Ensure invalid maps are rejected.
+	invalidTests := []struct {
+		name string
+		data string
+	}{
+		{name: "missing ASN", data: "1.0.0.0/24\n"},
+		{name: "extra field", data: "1.0.0.0/24 1 2\n"},
+		{name: "invalid prefix", data: "1.0.0.0 1\n"},
+		{name: "invalid ASN", data: "1.0.0.0/24 ASX\n"},
+		{name: "zero ASN", data: "1.0.0.0/24 0\n"},
+		{name: "ASN overflow", data: "1.0.0.0/24 4294967296\n"},
+		{name: "conflicting ASNs", data: "1.0.0.0/24 1\n1.0.0.0/24 2\n"},
+	}
+	for _, test := range invalidTests {
+		_, err := ParseASMap(strings.NewReader(test.data))
+		if !errors.Is(err, ErrInvalidASMap) {
+			t.Errorf("%s: unexpected error -- got %v, want %v", test.name,
+				err, ErrInvalidASMap)
+		}
+	}
+}
+
+// TestLoadASMap ensures IP-to-ASN maps are loaded from local files.
+func TestLoadASMap(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "asmap.txt")
+	if _, err := LoadASMap(filePath); err == nil {
+		t.Fatal("loaded nonexistent map file")
+	}
+
+	if err := os.WriteFile(filePath, []byte(testASMap), 0600); err != nil {
+		t.Fatalf("unable to write map file: %v", err)
+	}
+	asmap, err := LoadASMap(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error loading map: %v", err)
+	}
+	if got := asmap.Lookup(net.ParseIP(routableIPv4Addr)); got != 64496 {
+		t.Fatalf("unexpected ASN -- got %d, want 64496", got)
+	}
+
+	if err := os.WriteFile(filePath, []byte("bad"), 0600); err != nil {
+		t.Fatalf("unable to write map file: %v", err)
+	}
+	if _, err := LoadASMap(filePath); !errors.Is(err, ErrInvalidASMap) {
+		t.Fatalf("unexpected error -- got %v, want %v", err, ErrInvalidASMap)
+	}
+}
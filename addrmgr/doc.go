@@ -31,6 +31,12 @@ generally helps provide greater peer diversity.  More importantly, it
 drastically reduces the chances of an attacker coercing your peer into
 connecting only to nodes they control.
 
+By default, addresses are grouped by network prefix.  Since a single hosting
+provider or network operator may announce many prefixes, the address manager
+may optionally be provided an IP-to-ASN map via SetASMap in order to group
+addresses by the autonomous system that announces them instead.  Known
+addresses are automatically rebucketed when the map changes between runs.
+
 The address manager also understands routability, and tries hard to only return
 routable addresses.  In addition, it uses the information provided by the caller
 about connected, known good, and attempted addresses to periodically purge peers
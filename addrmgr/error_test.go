@@ -30,6 +30,11 @@ func TestErrors(t *testing.T) {
 		errorKind:   ErrMismatchedAddressType,
 		description: "mismatched address type",
 		wantErr:     ErrMismatchedAddressType,
+	}, {
+		name:        "ErrInvalidASMap",
+		errorKind:   ErrInvalidASMap,
+		description: "invalid AS map",
+		wantErr:     ErrInvalidASMap,
 	}}
 
 	for _, test := range tests {
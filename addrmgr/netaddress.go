@@ -36,6 +36,10 @@ type NetAddress struct {
 // IsRoutable returns a boolean indicating whether the network address is
 // routable.
 func (netAddr *NetAddress) IsRoutable() bool {
+	// Onion services are always routable over the Tor network.
+	if netAddr.Type == TORv3Address {
+		return true
+	}
 	return IsRoutable(netAddr.IP)
 }
 
@@ -48,6 +52,8 @@ func (netAddr *NetAddress) ipString() string {
 		return net.IP(netIP).String()
 	case IPv4Address:
 		return net.IP(netIP).String()
+	case TORv3Address:
+		return encodeTORv3Host(netIP)
 	}
 
 	// If the netAddr.Type is not recognized in the switch:
@@ -90,6 +96,8 @@ func deriveNetAddressType(addrBytes []byte) (NetAddressType, error) {
 		return IPv4Address, nil
 	case len == 16:
 		return IPv6Address, nil
+	case len == torV3PubKeySize:
+		return TORv3Address, nil
 	}
 	str := fmt.Sprintf("unable to determine address type from raw network "+
 		"address bytes: %v", addrBytes)
@@ -169,9 +177,8 @@ func (a *AddrManager) newNetAddressFromString(addr string) (*NetAddress, error)
 
 // NewNetAddressFromIPPort creates a new network address given an ip, port, and
 // the supported service flags for the address.  The provided ip MUST be a valid
-// IPv4 or IPv6 address, since this method does not perform error checking on
-// the derived network address type.  Furthermore, other types of network
-// addresses (like Tor) will not be recognized.
+// IPv4 or IPv6 address or the public key of a TORv3 onion service, since this
+// method does not perform error checking on the derived network address type.
 func NewNetAddressFromIPPort(ip net.IP, port uint16, services wire.ServiceFlag) *NetAddress {
 	netAddressType, _ := deriveNetAddressType(ip)
 	timestamp := time.Unix(time.Now().Unix(), 0)
@@ -6,6 +6,7 @@
 package addrmgr
 
 import (
+	"fmt"
 	"net"
 )
 
@@ -105,6 +106,7 @@ const (
 	IPv4Address        NetAddressType = 1
 	IPv6Address        NetAddressType = 2
 	// TorV2Address       NetAddressType = 3  // No longer supported
+	TORv3Address NetAddressType = 4
 )
 
 // NetAddressTypeFilter represents a function that returns whether a particular
@@ -226,10 +228,14 @@ func IsRoutable(netIP net.IP) bool {
 }
 
 // GroupKey returns a string representing the network group an address is part
-// of.  This is the /16 for IPv4, the /32 (/36 for he.net) for IPv6, the string
+// of.  This is the /16 for IPv4, the /32 (/36 for he.net) for IPv6, the first
+// four bits of the public key prefixed with "tor:" for TORv3, the string
 // "local" for a local address, and the string "unroutable" for an unroutable
 // address.
 func (na *NetAddress) GroupKey() string {
+	if na.Type == TORv3Address {
+		return fmt.Sprintf("tor:%d", na.IP[0]>>4)
+	}
 	netIP := net.IP(na.IP)
 	if isLocal(netIP) {
 		return "local"
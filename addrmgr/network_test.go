@@ -8,6 +8,7 @@ package addrmgr
 import (
 	"net"
 	"testing"
+	"time"
 
 	"github.com/monetarium/monetarium-node/wire"
 )
@@ -197,4 +198,28 @@ func TestGroupKey(t *testing.T) {
 				"- got '%s', want '%s'", i, test.name, key, test.expected)
 		}
 	}
+
+	// TORv3 addresses are grouped by the first four bits of the public key.
+	torTests := []struct {
+		name     string
+		host     string
+		expected string
+	}{
+		{name: "torv3", host: "2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion", expected: "tor:13"},
+		{name: "torv3 2", host: "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion", expected: "tor:1"},
+	}
+	for i, test := range torTests {
+		addrType, addrBytes := EncodeHost(test.host)
+		na, err := NewNetAddressFromParams(addrType, addrBytes, 8333,
+			time.Now(), wire.SFNodeNetwork)
+		if err != nil {
+			t.Errorf("TestGroupKey tor #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if key := na.GroupKey(); key != test.expected {
+			t.Errorf("TestGroupKey tor #%d (%s): unexpected group key "+
+				"- got '%s', want '%s'", i, test.name, key, test.expected)
+		}
+	}
 }
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package addrmgr
+
+import (
+	"bytes"
+	"encoding/base32"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// torV3PubKeySize is the size of the ed25519 public key that identifies a
+	// TORv3 onion service and is used as its encoded network address.
+	torV3PubKeySize = 32
+
+	// torV3Version is the version byte included in TORv3 onion addresses.
+	torV3Version = 0x03
+
+	// torV3ChecksumSize is the size of the checksum included in TORv3 onion
+	// addresses.
+	torV3ChecksumSize = 2
+
+	// torV3HostLen is the length of the base32-encoded portion of TORv3
+	// onion addresses.
+	torV3HostLen = 56
+
+	// onionSuffix is the suffix of all onion service host names.
+	onionSuffix = ".onion"
+)
+
+// onionEncoding is the base32 encoding used by onion service addresses.
+var onionEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// torV3Checksum returns the checksum of a TORv3 onion address for the provided
+// public key as defined by the Tor rendezvous specification:
+//
+//	CHECKSUM = SHA3-256(".onion checksum" | PUBKEY | VERSION)[:2]
+func torV3Checksum(pubKey []byte) []byte {
+	h := sha3.New256()
+	h.Write([]byte(".onion checksum"))
+	h.Write(pubKey)
+	h.Write([]byte{torV3Version})
+	return h.Sum(nil)[:torV3ChecksumSize]
+}
+
+// decodeTORv3Host attempts to decode the provided host as a TORv3 onion address
+// and returns the public key of the onion service when successful.  False is
+// returned when the host is not a valid TORv3 onion address.
+func decodeTORv3Host(host string) ([]byte, bool) {
+	host = strings.ToLower(host)
+	if !strings.HasSuffix(host, onionSuffix) {
+		return nil, false
+	}
+	encoded := strings.TrimSuffix(host, onionSuffix)
+	if len(encoded) != torV3HostLen {
+		return nil, false
+	}
+	data, err := onionEncoding.DecodeString(strings.ToUpper(encoded))
+	if err != nil {
+		return nil, false
+	}
+
+	// The decoded data is PUBKEY | CHECKSUM | VERSION.
+	pubKey := data[:torV3PubKeySize]
+	checksum := data[torV3PubKeySize : torV3PubKeySize+torV3ChecksumSize]
+	version := data[torV3PubKeySize+torV3ChecksumSize]
+	if version != torV3Version || !bytes.Equal(checksum, torV3Checksum(pubKey)) {
+		return nil, false
+	}
+	return pubKey, true
+}
+
+// encodeTORv3Host returns the TORv3 onion address host name for the onion
+// service identified by the provided public key.
+func encodeTORv3Host(pubKey []byte) string {
+	data := make([]byte, 0, torV3PubKeySize+torV3ChecksumSize+1)
+	data = append(data, pubKey...)
+	data = append(data, torV3Checksum(pubKey)...)
+	data = append(data, torV3Version)
+	return strings.ToLower(onionEncoding.EncodeToString(data)) + onionSuffix
+}
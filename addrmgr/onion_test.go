@@ -0,0 +1,123 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package addrmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// TestTORv3Host ensures TORv3 onion addresses are decoded and encoded as
+// expected including rejecting those that are malformed.
+func TestTORv3Host(t *testing.T) {
+	tests := []struct {
+		name  string
+		host  string
+		valid bool
+	}{{
+		name:  "valid",
+		host:  "2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion",
+		valid: true,
+	}, {
+		name:  "valid 2",
+		host:  "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion",
+		valid: true,
+	}, {
+		name:  "valid uppercase",
+		host:  "DUCKDUCKGOGG42XJOC72X3SJASOWOARFBGCMVFIMAFTT6TWAGSWZCZAD.ONION",
+		valid: true,
+	}, {
+		name:  "bad checksum",
+		host:  "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczbd.onion",
+		valid: false,
+	}, {
+		name:  "bad version",
+		host:  "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczae.onion",
+		valid: false,
+	}, {
+		name:  "too short",
+		host:  "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzcza.onion",
+		valid: false,
+	}, {
+		name:  "invalid base32",
+		host:  "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzcz!d.onion",
+		valid: false,
+	}, {
+		name:  "missing suffix",
+		host:  "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad",
+		valid: false,
+	}, {
+		name:  "torv2",
+		host:  "expyuzz4wqqyqhjn.onion",
+		valid: false,
+	}}
+
+	for _, test := range tests {
+		addrType, pubKey := EncodeHost(test.host)
+		if !test.valid {
+			if addrType != UnknownAddressType {
+				t.Errorf("%q: unexpected address type %d", test.name, addrType)
+			}
+			continue
+		}
+		if addrType != TORv3Address {
+			t.Errorf("%q: unexpected address type - got %d, want %d",
+				test.name, addrType, TORv3Address)
+			continue
+		}
+
+		na, err := NewNetAddressFromParams(addrType, pubKey, 9108, time.Now(),
+			wire.SFNodeNetwork)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !na.IsRoutable() {
+			t.Errorf("%q: onion address is not routable", test.name)
+		}
+		wantKey := encodeTORv3Host(pubKey) + ":9108"
+		if key := na.Key(); key != wantKey {
+			t.Errorf("%q: unexpected key - got %q, want %q", test.name, key,
+				wantKey)
+		}
+		if got, ok := decodeTORv3Host(encodeTORv3Host(pubKey)); !ok ||
+			string(got) != string(pubKey) {
+
+			t.Errorf("%q: public key does not round trip", test.name)
+		}
+	}
+}
+
+// TestTORv3Reachability ensures the reachability between TORv3 addresses and
+// other address types is as expected.
+func TestTORv3Reachability(t *testing.T) {
+	_, pubKey := EncodeHost("2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion")
+	onion := &NetAddress{Type: TORv3Address, IP: pubKey, Port: 9108}
+	ipv4 := NewNetAddressFromIPPort([]byte{12, 1, 2, 3}, 9108, 0)
+	ipv6 := NewNetAddressFromIPPort([]byte{0x26, 0x02, 0x01, 0x00, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 1}, 9108, 0)
+
+	tests := []struct {
+		name   string
+		local  *NetAddress
+		remote *NetAddress
+		want   NetAddressReach
+	}{
+		{"onion to onion", onion, onion, Private},
+		{"ipv4 to onion", ipv4, onion, Ipv4},
+		{"ipv6 to onion", ipv6, onion, Default},
+		{"onion to ipv4", onion, ipv4, Default},
+		{"onion to ipv6", onion, ipv6, Default},
+	}
+	for _, test := range tests {
+		got := getRemoteReachabilityFromLocal(test.local, test.remote)
+		if got != test.want {
+			t.Errorf("%q: unexpected reachability - got %d, want %d",
+				test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+const (
+	// anchorsFilename is the name of the file in the data directory that
+	// houses the outbound peers to reconnect to first on the next start.
+	anchorsFilename = "anchors.json"
+
+	// anchorsVersion is the current version of the serialized anchors.
+	anchorsVersion = 1
+
+	// maxAnchors is the maximum number of outbound peers that are saved as
+	// anchors on shutdown.
+	maxAnchors = 2
+
+	// maxAnchorAge is the maximum amount of time after they were saved that
+	// anchors are still used.  Older anchors are discarded since the peers are
+	// increasingly likely to have gone away or changed addresses.
+	maxAnchorAge = 24 * time.Hour
+)
+
+// serializedAnchors is the serialized form of the anchors file.
+type serializedAnchors struct {
+	Version int      `json:"version"`
+	Network string   `json:"network"`
+	Saved   int64    `json:"saved"`
+	Anchors []string `json:"anchors"`
+}
+
+// anchorState houses the state used to reconnect to the outbound peers the
+// node was connected to prior to its last clean shutdown.
+//
+// Anchors make it harder for an attacker to eclipse the node by exploiting the
+// fact that the outbound peers are otherwise selected from scratch on every
+// start.
+type anchorState struct {
+	// path is the path of the anchors file.  It is empty when anchors are
+	// disabled.
+	path string
+
+	// The following fields are protected by the mutex.
+	mtx     sync.Mutex
+	pending []string
+}
+
+// writeAnchors writes the provided anchor addresses for the provided network
+// to the file at the provided path along with the provided time they were
+// saved.
+func writeAnchors(filePath, network string, saved time.Time, addrs []string) error {
+	sa := serializedAnchors{
+		Version: anchorsVersion,
+		Network: network,
+		Saved:   saved.Unix(),
+		Anchors: addrs,
+	}
+
+	// Write temporary anchors file and then move it into place.
+	tmpfile := filePath + ".new"
+	w, err := os.Create(tmpfile)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(&sa); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to encode file %s: %w", tmpfile, err)
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpfile, filePath)
+}
+
+// readAnchors reads the anchor addresses from the file at the provided path
+// and removes the file so the anchors are only ever used for a single start.
+// This prevents a node that repeatedly crashes from continually reconnecting
+// to the same peers.
+//
+// No anchors are returned without an error when the file does not exist, the
+// anchors were saved for a different network, or they are older than the max
+// allowed age as of the provided time.
+func readAnchors(filePath, network string, now time.Time) ([]string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := os.Remove(filePath); err != nil {
+		return nil, err
+	}
+
+	var sa serializedAnchors
+	if err := json.Unmarshal(data, &sa); err != nil {
+		return nil, fmt.Errorf("failed to decode file %s: %w", filePath, err)
+	}
+	if sa.Version != anchorsVersion {
+		return nil, fmt.Errorf("unknown version %d in serialized anchors %s",
+			sa.Version, filePath)
+	}
+	if sa.Network != network {
+		return nil, nil
+	}
+	if age := now.Sub(time.Unix(sa.Saved, 0)); age < 0 || age > maxAnchorAge {
+		return nil, nil
+	}
+	if len(sa.Anchors) > maxAnchors {
+		sa.Anchors = sa.Anchors[:maxAnchors]
+	}
+	return sa.Anchors, nil
+}
+
+// loadAnchors restores the anchors saved by the previous clean shutdown so
+// they are connected to first.  Failing to do so is not fatal since normal
+// address selection is used in that case.
+func (s *server) loadAnchors() {
+	a := &s.anchors
+	addrs, err := readAnchors(a.path, s.chainParams.Name, time.Now())
+	if err != nil {
+		srvrLog.Warnf("Unable to load anchors: %v", err)
+		return
+	}
+	if len(addrs) > 0 {
+		srvrLog.Infof("Loaded %d anchor %s", len(addrs),
+			pickNoun(uint64(len(addrs)), "peer", "peers"))
+	}
+
+	a.mtx.Lock()
+	a.pending = addrs
+	a.mtx.Unlock()
+}
+
+// nextAnchor removes and returns the next anchor address to connect to.  An
+// empty string is returned when there are no more anchors.
+func (s *server) nextAnchor() string {
+	a := &s.anchors
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if len(a.pending) == 0 {
+		return ""
+	}
+	addr := a.pending[0]
+	a.pending = a.pending[1:]
+	return addr
+}
+
+// saveAnchors saves the longest connected outbound peers that serve the full
+// block history as anchors to reconnect to on the next start.  Persistent
+// peers are excluded since they are always reconnected to anyway.
+//
+// This must be called on shutdown prior to disconnecting the peers.
+func (s *server) saveAnchors() {
+	a := &s.anchors
+	if a.path == "" {
+		return
+	}
+
+	var candidates []*serverPeer
+	s.peerState.Lock()
+	s.peerState.forAllOutboundPeers(func(sp *serverPeer) {
+		if !sp.persistent && sp.Connected() && sp.VersionKnown() &&
+			hasServices(sp.Services(), wire.SFNodeNetwork) {
+
+			candidates = append(candidates, sp)
+		}
+	})
+	s.peerState.Unlock()
+	if len(candidates) == 0 {
+		return
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].TimeConnected().Before(candidates[j].TimeConnected())
+	})
+	if len(candidates) > maxAnchors {
+		candidates = candidates[:maxAnchors]
+	}
+	addrs := make([]string, 0, len(candidates))
+	for _, sp := range candidates {
+		addrs = append(addrs, sp.Addr())
+	}
+
+	err := writeAnchors(a.path, s.chainParams.Name, time.Now(), addrs)
+	if err != nil {
+		srvrLog.Warnf("Unable to save anchors: %v", err)
+		return
+	}
+	srvrLog.Debugf("Saved anchor peers %v", addrs)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestAnchors ensures anchors are saved and restored as expected including
+// discarding those that are expired or were saved for a different network and
+// only restoring them a single time.
+func TestAnchors(t *testing.T) {
+	const network = "mainnet"
+	saved := time.Unix(1700000000, 0)
+	addrs := []string{"192.0.2.1:9108", "[2001:db8::1]:9108"}
+
+	tests := []struct {
+		name    string
+		addrs   []string
+		network string
+		now     time.Time
+		want    []string
+	}{{
+		name:    "restored",
+		addrs:   addrs,
+		network: network,
+		now:     saved.Add(time.Hour),
+		want:    addrs,
+	}, {
+		name:    "restored at max age",
+		addrs:   addrs,
+		network: network,
+		now:     saved.Add(maxAnchorAge),
+		want:    addrs,
+	}, {
+		name:    "too many anchors are truncated",
+		addrs:   append(addrs, "192.0.2.2:9108"),
+		network: network,
+		now:     saved,
+		want:    addrs,
+	}, {
+		name:    "expired",
+		addrs:   addrs,
+		network: network,
+		now:     saved.Add(maxAnchorAge + time.Second),
+		want:    nil,
+	}, {
+		name:    "saved in the future",
+		addrs:   addrs,
+		network: network,
+		now:     saved.Add(-time.Second),
+		want:    nil,
+	}, {
+		name:    "different network",
+		addrs:   addrs,
+		network: "testnet3",
+		now:     saved,
+		want:    nil,
+	}}
+
+	for _, test := range tests {
+		filePath := filepath.Join(t.TempDir(), anchorsFilename)
+		err := writeAnchors(filePath, network, saved, test.addrs)
+		if err != nil {
+			t.Fatalf("%q: unexpected write error: %v", test.name, err)
+		}
+
+		got, err := readAnchors(filePath, test.network, test.now)
+		if err != nil {
+			t.Fatalf("%q: unexpected read error: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Fatalf("%q: mismatched anchors: got %v, want %v", test.name,
+				got, test.want)
+		}
+
+		// Ensure the anchors file is removed so the anchors are not restored
+		// again.
+		if _, err := os.Stat(filePath); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("%q: anchors file was not removed: %v", test.name, err)
+		}
+		got, err = readAnchors(filePath, test.network, test.now)
+		if err != nil {
+			t.Fatalf("%q: unexpected read error: %v", test.name, err)
+		}
+		if got != nil {
+			t.Fatalf("%q: unexpected anchors on second read: %v", test.name,
+				got)
+		}
+	}
+}
+
+// TestAnchorsInvalid ensures reading an anchors file with invalid contents
+// returns an error and still removes the file.
+func TestAnchorsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{{
+		name: "malformed json",
+		data: "{",
+	}, {
+		name: "unknown version",
+		data: `{"version":2,"network":"mainnet","saved":0,"anchors":[]}`,
+	}}
+
+	for _, test := range tests {
+		filePath := filepath.Join(t.TempDir(), anchorsFilename)
+		if err := os.WriteFile(filePath, []byte(test.data), 0644); err != nil {
+			t.Fatalf("%q: unexpected write error: %v", test.name, err)
+		}
+		_, err := readAnchors(filePath, "mainnet", time.Now())
+		if err == nil {
+			t.Fatalf("%q: did not receive expected error", test.name)
+		}
+		if _, err := os.Stat(filePath); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("%q: anchors file was not removed: %v", test.name, err)
+		}
+	}
+}
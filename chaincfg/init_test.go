@@ -262,6 +262,7 @@ func TestRequiredUnique(t *testing.T) {
 		pkhEdwardsAddrIDs = make(map[[2]byte]struct{})
 		pkhSchnorrAddrIDs = make(map[[2]byte]struct{})
 		scriptHashAddrIDs = make(map[[2]byte]struct{})
+		coinTypedAddrHRPs = make(map[string]struct{})
 	)
 
 	for _, params := range allDefaultNetParams() {
@@ -306,5 +307,11 @@ func TestRequiredUnique(t *testing.T) {
 				params.ScriptHashAddrID)
 		}
 		scriptHashAddrIDs[params.ScriptHashAddrID] = struct{}{}
+
+		if _, ok := coinTypedAddrHRPs[params.CoinTypedAddrHRP]; ok {
+			t.Fatalf("%q: duplicate coin-typed addr HRP %s", params.Name,
+				params.CoinTypedAddrHRP)
+		}
+		coinTypedAddrHRPs[params.CoinTypedAddrHRP] = struct{}{}
 	}
 }
@@ -484,6 +484,118 @@ func MainNetParams() *Params {
 				StartTime:  1682294400, // Apr 24th, 2023
 				ExpireTime: 1745452800, // Apr 24th, 2025
 			}},
+			11: {{
+				Vote: Vote{
+					Id:          VoteIDCoinTypeSigHash,
+					Description: "Enable signatures that commit to the coin type and amount of spent outputs",
+					Mask:        0x0006, // Bits 1 and 2
+					Choices: []Choice{{
+						Id:          "abstain",
+						Description: "abstain voting for change",
+						Bits:        0x0000,
+						IsAbstain:   true,
+						IsNo:        false,
+					}, {
+						Id:          "no",
+						Description: "keep the existing consensus rules",
+						Bits:        0x0002, // Bit 1
+						IsAbstain:   false,
+						IsNo:        true,
+					}, {
+						Id:          "yes",
+						Description: "change to the new consensus rules",
+						Bits:        0x0004, // Bit 2
+						IsAbstain:   false,
+						IsNo:        false,
+					}},
+				},
+				StartTime:  1798761600, // Jan 1st, 2027
+				ExpireTime: 1861920000, // Jan 1st, 2029
+			}},
+			12: {{
+				Vote: Vote{
+					Id:          VoteIDIntrospection,
+					Description: "Enable script version 1 with opcodes that introspect the coin type and amount of inputs and outputs",
+					Mask:        0x0006, // Bits 1 and 2
+					Choices: []Choice{{
+						Id:          "abstain",
+						Description: "abstain voting for change",
+						Bits:        0x0000,
+						IsAbstain:   true,
+						IsNo:        false,
+					}, {
+						Id:          "no",
+						Description: "keep the existing consensus rules",
+						Bits:        0x0002, // Bit 1
+						IsAbstain:   false,
+						IsNo:        true,
+					}, {
+						Id:          "yes",
+						Description: "change to the new consensus rules",
+						Bits:        0x0004, // Bit 2
+						IsAbstain:   false,
+						IsNo:        false,
+					}},
+				},
+				StartTime:  1798761600, // Jan 1st, 2027
+				ExpireTime: 1861920000, // Jan 1st, 2029
+			}},
+			13: {{
+				Vote: Vote{
+					Id:          VoteIDSKASupplyCommitment,
+					Description: "Commit to the emitted and burned supply of each SKA coin type in the block header",
+					Mask:        0x0006, // Bits 1 and 2
+					Choices: []Choice{{
+						Id:          "abstain",
+						Description: "abstain voting for change",
+						Bits:        0x0000,
+						IsAbstain:   true,
+						IsNo:        false,
+					}, {
+						Id:          "no",
+						Description: "keep the existing consensus rules",
+						Bits:        0x0002, // Bit 1
+						IsAbstain:   false,
+						IsNo:        true,
+					}, {
+						Id:          "yes",
+						Description: "change to the new consensus rules",
+						Bits:        0x0004, // Bit 2
+						IsAbstain:   false,
+						IsNo:        false,
+					}},
+				},
+				StartTime:  1798761600, // Jan 1st, 2027
+				ExpireTime: 1861920000, // Jan 1st, 2029
+			}},
+			14: {{
+				Vote: Vote{
+					Id:          VoteIDSchnorrEmissionAuth,
+					Description: "Require Schnorr signatures for the emission authorizations of SKA coin types configured for them",
+					Mask:        0x0006, // Bits 1 and 2
+					Choices: []Choice{{
+						Id:          "abstain",
+						Description: "abstain voting for change",
+						Bits:        0x0000,
+						IsAbstain:   true,
+						IsNo:        false,
+					}, {
+						Id:          "no",
+						Description: "keep the existing consensus rules",
+						Bits:        0x0002, // Bit 1
+						IsAbstain:   false,
+						IsNo:        true,
+					}, {
+						Id:          "yes",
+						Description: "change to the new consensus rules",
+						Bits:        0x0004, // Bit 2
+						IsAbstain:   false,
+						IsNo:        false,
+					}},
+				},
+				StartTime:  1798761600, // Jan 1st, 2027
+				ExpireTime: 1861920000, // Jan 1st, 2029
+			}},
 		},
 
 		// Enforce current block version once majority of the network has
@@ -509,6 +621,7 @@ func MainNetParams() *Params {
 		PKHSchnorrAddrID:     [2]byte{0x0b, 0x81}, // starts with MS
 		ScriptHashAddrID:     [2]byte{0x0b, 0x9a}, // starts with Mc
 		PrivateKeyID:         [2]byte{0x22, 0xdc}, // starts with Pm
+		CoinTypedAddrHRP:     "mon",
 
 		// BIP32 hierarchical deterministic extended key magics
 		HDPrivateKeyID: [4]byte{0x02, 0xfd, 0xa4, 0xe8}, // starts with dprv
@@ -204,6 +204,26 @@ const (
 	// VoteIDActivateSKA2 is the vote ID for activating SKA-2 coin type for use
 	// in transactions.
 	VoteIDActivateSKA2 = "activateska2"
+
+	// VoteIDCoinTypeSigHash is the vote ID for the agenda that enables the
+	// signature hash type which commits to the coin type, amount, and script
+	// version of every output spent by a transaction.
+	VoteIDCoinTypeSigHash = "cointypesighash"
+
+	// VoteIDIntrospection is the vote ID for the agenda that enables version 1
+	// scripts along with the opcodes they provide to introspect the coin type
+	// and amount of the inputs and outputs of a transaction.
+	VoteIDIntrospection = "introspection"
+
+	// VoteIDSKASupplyCommitment is the vote ID for the agenda that extends the
+	// block header commitments to commit to the emission nonce, cumulative
+	// emitted amount, and cumulative burned amount of every SKA coin type.
+	VoteIDSKASupplyCommitment = "skasupplycmt"
+
+	// VoteIDSchnorrEmissionAuth is the vote ID for the agenda that requires
+	// the emission authorizations of SKA coin types configured for Schnorr
+	// authorization to carry EC-Schnorr-DCRv0 signatures.
+	VoteIDSchnorrEmissionAuth = "schnorremissionauth"
 )
 
 // ConsensusDeployment defines details related to a specific consensus rule
@@ -295,6 +315,16 @@ type SKACoinConfig struct {
 	// private key are valid emissions.
 	EmissionKey *secp256k1.PublicKey
 
+	// SchnorrEmissionAuth indicates emission authorizations for this coin type
+	// must carry an EC-Schnorr-DCRv0 signature rather than an ECDSA signature.
+	// This allows EmissionKey to be a MuSig2 aggregate of the keys of several
+	// parties so that no single party holds the full emission key.
+	//
+	// It only takes effect once the vote for the agenda identified by
+	// VoteIDSchnorrEmissionAuth is active.  Emission authorizations require an
+	// ECDSA signature prior to that point.
+	SchnorrEmissionAuth bool
+
 	// MinRelayTxFee is the minimum fee rate for this SKA coin type (atoms/KB).
 	// Uses *big.Int to support fees > 9.22 SKA without int64 overflow.
 	MinRelayTxFee *big.Int
@@ -343,7 +373,9 @@ type SKAEmissionAuth struct {
 	// EmissionKey is the master public key authorized for this coin type emission
 	EmissionKey *secp256k1.PublicKey
 
-	// Signature is the ECDSA signature proving authorization
+	// Signature is the signature proving authorization.  It is an ECDSA
+	// signature unless the coin type is configured for Schnorr authorization,
+	// in which case it is an EC-Schnorr-DCRv0 signature.
 	Signature []byte
 
 	// Nonce provides replay protection - must be unique per coin type
@@ -604,6 +636,10 @@ type Params struct {
 	ScriptHashAddrID [2]byte // First 2 bytes of a P2SH address
 	PrivateKeyID     [2]byte // First 2 bytes of a WIF private key
 
+	// CoinTypedAddrHRP is the human-readable part of the bech32 encoding of
+	// addresses that commit to the coin type the recipient expects.
+	CoinTypedAddrHRP string
+
 	// BIP32 hierarchical deterministic extended key magics
 	HDPrivateKeyID [4]byte
 	HDPublicKeyID  [4]byte
@@ -809,6 +845,12 @@ func (p *Params) AddrIDScriptHashV0() [2]byte {
 	return p.ScriptHashAddrID
 }
 
+// AddrHRPCoinTyped returns the human-readable part of the bech32 encoding of
+// coin-typed addresses.
+func (p *Params) AddrHRPCoinTyped() string {
+	return p.CoinTypedAddrHRP
+}
+
 // BaseSubsidyValue returns the starting base max potential subsidy amount for
 // mined blocks.  This value is reduced over time and then split proportionally
 // between PoW, PoS, and the Treasury.  The reduction is controlled by the
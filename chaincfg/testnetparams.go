@@ -477,6 +477,118 @@ func TestNet3Params() *Params {
 				StartTime:  1682294400, // Apr 24th, 2023
 				ExpireTime: 1745452800, // Apr 24th, 2025
 			}},
+			11: {{
+				Vote: Vote{
+					Id:          VoteIDCoinTypeSigHash,
+					Description: "Enable signatures that commit to the coin type and amount of spent outputs",
+					Mask:        0x0006, // Bits 1 and 2
+					Choices: []Choice{{
+						Id:          "abstain",
+						Description: "abstain voting for change",
+						Bits:        0x0000,
+						IsAbstain:   true,
+						IsNo:        false,
+					}, {
+						Id:          "no",
+						Description: "keep the existing consensus rules",
+						Bits:        0x0002, // Bit 1
+						IsAbstain:   false,
+						IsNo:        true,
+					}, {
+						Id:          "yes",
+						Description: "change to the new consensus rules",
+						Bits:        0x0004, // Bit 2
+						IsAbstain:   false,
+						IsNo:        false,
+					}},
+				},
+				StartTime:  1798761600, // Jan 1st, 2027
+				ExpireTime: 1861920000, // Jan 1st, 2029
+			}},
+			12: {{
+				Vote: Vote{
+					Id:          VoteIDIntrospection,
+					Description: "Enable script version 1 with opcodes that introspect the coin type and amount of inputs and outputs",
+					Mask:        0x0006, // Bits 1 and 2
+					Choices: []Choice{{
+						Id:          "abstain",
+						Description: "abstain voting for change",
+						Bits:        0x0000,
+						IsAbstain:   true,
+						IsNo:        false,
+					}, {
+						Id:          "no",
+						Description: "keep the existing consensus rules",
+						Bits:        0x0002, // Bit 1
+						IsAbstain:   false,
+						IsNo:        true,
+					}, {
+						Id:          "yes",
+						Description: "change to the new consensus rules",
+						Bits:        0x0004, // Bit 2
+						IsAbstain:   false,
+						IsNo:        false,
+					}},
+				},
+				StartTime:  1798761600, // Jan 1st, 2027
+				ExpireTime: 1861920000, // Jan 1st, 2029
+			}},
+			13: {{
+				Vote: Vote{
+					Id:          VoteIDSKASupplyCommitment,
+					Description: "Commit to the emitted and burned supply of each SKA coin type in the block header",
+					Mask:        0x0006, // Bits 1 and 2
+					Choices: []Choice{{
+						Id:          "abstain",
+						Description: "abstain voting for change",
+						Bits:        0x0000,
+						IsAbstain:   true,
+						IsNo:        false,
+					}, {
+						Id:          "no",
+						Description: "keep the existing consensus rules",
+						Bits:        0x0002, // Bit 1
+						IsAbstain:   false,
+						IsNo:        true,
+					}, {
+						Id:          "yes",
+						Description: "change to the new consensus rules",
+						Bits:        0x0004, // Bit 2
+						IsAbstain:   false,
+						IsNo:        false,
+					}},
+				},
+				StartTime:  1798761600, // Jan 1st, 2027
+				ExpireTime: 1861920000, // Jan 1st, 2029
+			}},
+			14: {{
+				Vote: Vote{
+					Id:          VoteIDSchnorrEmissionAuth,
+					Description: "Require Schnorr signatures for the emission authorizations of SKA coin types configured for them",
+					Mask:        0x0006, // Bits 1 and 2
+					Choices: []Choice{{
+						Id:          "abstain",
+						Description: "abstain voting for change",
+						Bits:        0x0000,
+						IsAbstain:   true,
+						IsNo:        false,
+					}, {
+						Id:          "no",
+						Description: "keep the existing consensus rules",
+						Bits:        0x0002, // Bit 1
+						IsAbstain:   false,
+						IsNo:        true,
+					}, {
+						Id:          "yes",
+						Description: "change to the new consensus rules",
+						Bits:        0x0004, // Bit 2
+						IsAbstain:   false,
+						IsNo:        false,
+					}},
+				},
+				StartTime:  1798761600, // Jan 1st, 2027
+				ExpireTime: 1861920000, // Jan 1st, 2029
+			}},
 		},
 
 		// Enforce current block version once majority of the network has
@@ -501,6 +613,7 @@ func TestNet3Params() *Params {
 		PKHSchnorrAddrID:     [2]byte{0x0e, 0xe3}, // starts with TS
 		ScriptHashAddrID:     [2]byte{0x0e, 0xfc}, // starts with Tc
 		PrivateKeyID:         [2]byte{0x23, 0x0e}, // starts with Pt
+		CoinTypedAddrHRP:     "tmon",
 
 		// BIP32 hierarchical deterministic extended key magics
 		HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x97}, // starts with tprv
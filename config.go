@@ -10,6 +10,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
@@ -26,10 +27,13 @@ import (
 	"github.com/decred/go-socks/socks"
 	"github.com/decred/slog"
 	flags "github.com/jessevdk/go-flags"
+	"github.com/monetarium/monetarium-node/addrmgr"
 	"github.com/monetarium/monetarium-node/connmgr"
 	"github.com/monetarium/monetarium-node/database"
 	_ "github.com/monetarium/monetarium-node/database/ffldb"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
 	"github.com/monetarium/monetarium-node/dcrutil"
+	"github.com/monetarium/monetarium-node/internal/blockchain"
 	"github.com/monetarium/monetarium-node/internal/mempool"
 	"github.com/monetarium/monetarium-node/internal/version"
 	"github.com/monetarium/monetarium-node/rpc/jsonrpc/types"
@@ -57,11 +61,15 @@ const (
 	defaultMaxRPCWebsockets     = 25
 	defaultMaxRPCConcurrentReqs = 20
 
+	// Defaults for REST server options.
+	defaultRESTMaxRequests = 20
+
 	// Defaults for P2P network options.
 	defaultMaxSameIP       = 5
 	defaultMaxPeers        = 125
 	defaultDialTimeout     = time.Second * 30
 	defaultPeerIdleTimeout = time.Second * 120
+	defaultTorControlPort  = "9051"
 
 	// Defaults for banning options.
 	defaultBanDuration  = time.Hour * 24
@@ -122,6 +130,7 @@ type config struct {
 	Profile          string `long:"profile" description:"Enable HTTP profiling on given [addr:]port -- NOTE port must be between 1024 and 65536"`
 	CPUProfile       string `long:"cpuprofile" description:"Write CPU profile to the specified file"`
 	MemProfile       string `long:"memprofile" description:"Write mem profile to the specified file"`
+	Metrics          string `long:"metrics" description:"Enable Prometheus metrics at /metrics on given [addr:]port -- NOTE port must be between 1024 and 65536"`
 	TestNet          bool   `long:"testnet" description:"Use the test network"`
 	SimNet           bool   `long:"simnet" description:"Use the simulation test network"`
 	RegNet           bool   `long:"regnet" description:"Use the regression test network"`
@@ -147,6 +156,11 @@ type config struct {
 	RPCMaxWebsockets     int      `long:"rpcmaxwebsockets" description:"Max number of RPC websocket connections"`
 	RPCMaxConcurrentReqs int      `long:"rpcmaxconcurrentreqs" description:"Max number of concurrent RPC requests that may be processed concurrently"`
 
+	// REST server options.
+	EnableREST      bool     `long:"rest" description:"Enable the unauthenticated read-only REST interface"`
+	RESTListeners   []string `long:"restlisten" description:"Add an interface/port to listen for REST connections (default port: 9510, testnet: 19510)"`
+	RESTMaxRequests int      `long:"restmaxrequests" description:"Max number of REST requests per second that are served for each client host"`
+
 	// P2P proxy and Tor settings.
 	Proxy          string `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
 	ProxyUser      string `long:"proxyuser" description:"Username for proxy server"`
@@ -156,16 +170,25 @@ type config struct {
 	OnionProxyPass string `long:"onionpass" default-mask:"-" description:"Password for onion proxy server"`
 	NoOnion        bool   `long:"noonion" description:"Disable connecting to tor hidden services"`
 	TorIsolation   bool   `long:"torisolation" description:"Enable Tor stream isolation by randomizing user credentials for each connection"`
+	TorControl     string `long:"torcontrol" description:"Create an onion service to accept incoming connections over Tor via the Tor control port at this address (eg. 127.0.0.1:9051)"`
+	TorPassword    string `long:"torpassword" default-mask:"-" description:"Password for the Tor control port when not using cookie authentication"`
 
 	// P2P network options.
-	AddPeers        []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
-	ConnectPeers    []string      `long:"connect" description:"Connect only to the specified peers at startup"`
-	DisableListen   bool          `long:"nolisten" description:"Disable listening for incoming connections -- NOTE: Listening is automatically disabled if the --connect or --proxy options are used without also specifying listen interfaces via --listen"`
-	Listeners       []string      `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 9108, testnet: 19108)"`
-	MaxSameIP       int           `long:"maxsameip" description:"Max number of connections with the same IP -- 0 to disable"`
-	MaxPeers        int           `long:"maxpeers" description:"Max number of inbound and outbound peers"`
-	DialTimeout     time.Duration `long:"dialtimeout" description:"How long to wait for TCP connection completion.  Valid time units are {s, m, h}.  Minimum 1 second"`
-	PeerIdleTimeout time.Duration `long:"peeridletimeout" description:"The duration of inactivity before a peer is timed out.  Valid time units are {s,m,h}.  Minimum 15 seconds"`
+	AddPeers          []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
+	ConnectPeers      []string      `long:"connect" description:"Connect only to the specified peers at startup"`
+	DisableListen     bool          `long:"nolisten" description:"Disable listening for incoming connections -- NOTE: Listening is automatically disabled if the --connect or --proxy options are used without also specifying listen interfaces via --listen"`
+	Listeners         []string      `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 9108, testnet: 19108)"`
+	MaxSameIP         int           `long:"maxsameip" description:"Max number of connections with the same IP -- 0 to disable"`
+	MaxPeers          int           `long:"maxpeers" description:"Max number of inbound and outbound peers"`
+	DialTimeout       time.Duration `long:"dialtimeout" description:"How long to wait for TCP connection completion.  Valid time units are {s, m, h}.  Minimum 1 second"`
+	PeerIdleTimeout   time.Duration `long:"peeridletimeout" description:"The duration of inactivity before a peer is timed out.  Valid time units are {s,m,h}.  Minimum 15 seconds"`
+	NoV2Transport     bool          `long:"nov2transport" description:"Disable the encrypted v2 transport and only use the plaintext v1 transport to communicate with peers"`
+	TransportKey      string        `long:"transportkey" description:"Hex-encoded secp256k1 private key used to authenticate to peers over the v2 transport"`
+	AuthPeerKeys      []string      `long:"authpeerkey" description:"Add a hex-encoded secp256k1 public key that whitelisted peers may authenticate with over the v2 transport -- NOTE: Whitelisted peers are required to authenticate with one of the keys when any are specified"`
+	MaxUploadRate     uint64        `long:"maxuploadrate" description:"Max combined rate of data sent to all peers in KiB/s -- 0 for unlimited"`
+	MaxPeerUploadRate uint64        `long:"maxpeeruploadrate" description:"Max rate of data sent to each peer in KiB/s -- 0 for unlimited"`
+	MaxUploadTarget   uint64        `long:"maxuploadtarget" description:"Max data sent to peers per 24 hour cycle in MiB before historical blocks are no longer served to peers that are not whitelisted -- 0 for unlimited"`
+	ASMap             string        `long:"asmap" description:"Path to an IP-to-ASN map file used to group peer addresses by autonomous system instead of network prefix for address bucketing and outbound connection diversity"`
 
 	// P2P network discovery options.
 	DisableSeeders bool     `long:"noseeders" description:"Disable seeding for peer discovery"`
@@ -184,6 +207,7 @@ type config struct {
 	AllowOldForks  bool   `long:"allowoldforks" description:"Process forks deep in history.  Don't do this unless you know what you're doing"`
 	DumpBlockchain string `long:"dumpblockchain" description:"Write blockchain as a flat file of blocks for use with addblock, to the specified filename"`
 	AssumeValid    string `long:"assumevalid" description:"Hash of an assumed valid block.  Defaults to the hard-coded assumed valid block that is updated periodically with new releases.  Don't use a different hash unless you understand the implications.  Set to 0 to disable"`
+	Prune          uint64 `long:"prune" description:"Reduce disk usage by deleting old block data to keep the stored blocks at roughly the specified size in MiB while always retaining recent blocks.  The node no longer serves historical blocks to peers when enabled.  Incompatible with --txindex and requires --noexistsaddrindex.  Set to 0 to disable (minimum 1024)"`
 
 	// Relay and mempool policy.
 	MinRelayTxFee    float64 `long:"minrelaytxfee" description:"The minimum transaction fee in VAR/kB to be considered a non-zero fee"`
@@ -191,6 +215,7 @@ type config struct {
 	NoRelayPriority  bool    `long:"norelaypriority" description:"DEPRECATED: This behavior is no longer available and this option will be removed in a future version of the software"`
 	MaxOrphanTxs     int     `long:"maxorphantx" description:"Max number of orphan transactions to keep in memory"`
 	BlocksOnly       bool    `long:"blocksonly" description:"Do not accept transactions from remote peers"`
+	Dandelion        bool    `long:"dandelion" description:"Relay new transactions privately to a single peer for a random number of hops before they are broadcast to the network"`
 	AcceptNonStd     bool    `long:"acceptnonstd" description:"Accept and relay non-standard transactions to the network regardless of the default settings for the active network"`
 	RejectNonStd     bool    `long:"rejectnonstd" description:"Reject non-standard transactions regardless of the default settings for the active network"`
 	AllowOldVotes    bool    `long:"allowoldvotes" description:"Enable the addition of very old votes to the mempool"`
@@ -226,6 +251,9 @@ type config struct {
 	miningAddrs   []stdaddr.Address
 	minRelayTxFee dcrutil.Amount
 	whitelists    []*net.IPNet
+	transportKey  *secp256k1.PrivateKey
+	authPeerKeys  []*secp256k1.PublicKey
+	asmap         *addrmgr.ASMap
 	ipv4NetInfo   types.NetworksResult
 	ipv6NetInfo   types.NetworksResult
 	onionNetInfo  types.NetworksResult
@@ -616,6 +644,9 @@ func loadConfig(appName string) (*config, []string, error) {
 		RPCMaxWebsockets:     defaultMaxRPCWebsockets,
 		RPCMaxConcurrentReqs: defaultMaxRPCConcurrentReqs,
 
+		// REST server options.
+		RESTMaxRequests: defaultRESTMaxRequests,
+
 		// P2P network options.
 		MaxSameIP:       defaultMaxSameIP,
 		MaxPeers:        defaultMaxPeers,
@@ -942,6 +973,17 @@ func loadConfig(appName string) (*config, []string, error) {
 		}
 	}
 
+	// Validate format of metrics address.  It may either be an address:port or
+	// just a port.  The port also must be between 1024 and 65535.
+	if cfg.Metrics != "" {
+		cfg.Metrics = portToLocalHostAddr(cfg.Metrics)
+		if err := validateProfileAddr(cfg.Metrics); err != nil {
+			str := "%s: metrics: %w"
+			err := fmt.Errorf(str, funcName, err)
+			return nil, nil, err
+		}
+	}
+
 	// Don't allow ban durations that are too short.
 	if cfg.BanDuration < time.Second {
 		str := "%s: the banduration option may not be less than 1s -- parsed [%v]"
@@ -994,6 +1036,57 @@ func loadConfig(appName string) (*config, []string, error) {
 		}
 	}
 
+	// Parse the static key used to authenticate over the v2 transport.
+	if cfg.TransportKey != "" {
+		keyBytes, err := hex.DecodeString(cfg.TransportKey)
+		if err != nil || len(keyBytes) != secp256k1.PrivKeyBytesLen {
+			str := "%s: the transportkey option must be a hex-encoded " +
+				"%d-byte private key"
+			err := fmt.Errorf(str, funcName, secp256k1.PrivKeyBytesLen)
+			return nil, nil, err
+		}
+		cfg.transportKey = secp256k1.PrivKeyFromBytes(keyBytes)
+	}
+
+	// Parse the static keys whitelisted peers may authenticate with.
+	if len(cfg.AuthPeerKeys) > 0 {
+		if cfg.NoV2Transport {
+			str := "%s: the --authpeerkey and --nov2transport options can " +
+				"not be used together"
+			err := fmt.Errorf(str, funcName)
+			return nil, nil, err
+		}
+		cfg.authPeerKeys = make([]*secp256k1.PublicKey, 0,
+			len(cfg.AuthPeerKeys))
+		for _, keyStr := range cfg.AuthPeerKeys {
+			keyBytes, err := hex.DecodeString(keyStr)
+			if err != nil {
+				str := "%s: the authpeerkey value of '%s' is invalid"
+				err := fmt.Errorf(str, funcName, keyStr)
+				return nil, nil, err
+			}
+			key, err := secp256k1.ParsePubKey(keyBytes)
+			if err != nil {
+				str := "%s: the authpeerkey value of '%s' is invalid: %w"
+				err := fmt.Errorf(str, funcName, keyStr, err)
+				return nil, nil, err
+			}
+			cfg.authPeerKeys = append(cfg.authPeerKeys, key)
+		}
+	}
+
+	// Load the IP-to-ASN map used to group peer addresses.
+	if cfg.ASMap != "" {
+		cfg.ASMap = cleanAndExpandPath(cfg.ASMap)
+		asmap, err := addrmgr.LoadASMap(cfg.ASMap)
+		if err != nil {
+			str := "%s: unable to load AS map: %w"
+			err := fmt.Errorf(str, funcName, err)
+			return nil, nil, err
+		}
+		cfg.asmap = asmap
+	}
+
 	// --addPeer and --connect do not mix.
 	if len(cfg.AddPeers) > 0 && len(cfg.ConnectPeers) > 0 {
 		str := "%s: the --addpeer and --connect options can not be " +
@@ -1079,6 +1172,26 @@ func loadConfig(appName string) (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Default REST to listen on localhost only when it is enabled.
+	if cfg.EnableREST && len(cfg.RESTListeners) == 0 {
+		addrs, err := net.LookupHost("localhost")
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.RESTListeners = make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			addr = net.JoinHostPort(addr, cfg.params.restPort)
+			cfg.RESTListeners = append(cfg.RESTListeners, addr)
+		}
+	}
+
+	if cfg.RESTMaxRequests < 1 {
+		str := "%s: the restmaxrequests option may not be less than 1 " +
+			"-- parsed [%d]"
+		err := fmt.Errorf(str, funcName, cfg.RESTMaxRequests)
+		return nil, nil, err
+	}
+
 	// Validate the minrelaytxfee.
 	cfg.minRelayTxFee, err = dcrutil.NewAmount(cfg.MinRelayTxFee)
 	if err != nil {
@@ -1132,6 +1245,32 @@ func loadConfig(appName string) (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// --prune must be at least the minimum target.
+	const bytesPerMiB = 1024 * 1024
+	if cfg.Prune != 0 && cfg.Prune < blockchain.MinPruneTarget/bytesPerMiB {
+		err := fmt.Errorf("%s: the prune option may not be less than %d "+
+			"MiB -- parsed [%d]", funcName,
+			blockchain.MinPruneTarget/bytesPerMiB, cfg.Prune)
+		return nil, nil, err
+	}
+
+	// --prune and --txindex do not mix since the transaction index requires
+	// the full block history.
+	if cfg.Prune != 0 && cfg.TxIndex {
+		err := fmt.Errorf("%s: the --prune and --txindex options may not be "+
+			"activated at the same time", funcName)
+		return nil, nil, err
+	}
+
+	// --prune and !--noexistsaddrindex do not mix since the exists address
+	// index also requires the full block history to catch up.
+	if cfg.Prune != 0 && !cfg.NoExistsAddrIndex {
+		err := fmt.Errorf("%s: the --prune option may not be activated "+
+			"when the exists address index is on (try setting "+
+			"--noexistsaddrindex)", funcName)
+		return nil, nil, err
+	}
+
 	// !--noexistsaddrindex and --dropexistsaddrindex do not mix.
 	if !cfg.NoExistsAddrIndex && cfg.DropExistsAddrIndex {
 		err := fmt.Errorf("dropexistsaddrindex cannot be activated when " +
@@ -1182,6 +1321,11 @@ func loadConfig(appName string) (*config, []string, error) {
 	cfg.RPCListeners = normalizeAddresses(cfg.RPCListeners,
 		cfg.params.rpcPort, normalizeInterfaceAddrs)
 
+	// Add default port to all rest listener addresses if needed and remove
+	// duplicate addresses.
+	cfg.RESTListeners = normalizeAddresses(cfg.RESTListeners,
+		cfg.params.restPort, normalizeInterfaceAddrs)
+
 	// The authtype config must be one of "basic" or "clientcert".
 	switch cfg.RPCAuthType {
 	case authTypeBasic, authTypeClientCert:
@@ -1238,6 +1382,20 @@ func loadConfig(appName string) (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Creating an onion service via the Tor control port requires listening
+	// for incoming connections for the service to forward them to.
+	if cfg.TorControl != "" {
+		if cfg.DisableListen {
+			str := "%s: the --torcontrol option requires listening for " +
+				"incoming connections -- specify listen interfaces via " +
+				"--listen when using --proxy or --connect"
+			err := fmt.Errorf(str, funcName)
+			return nil, nil, err
+		}
+		cfg.TorControl = normalizeAddresses([]string{cfg.TorControl},
+			defaultTorControlPort, normalizeInterfaceFirstAddr)[0]
+	}
+
 	// Setup dial and DNS resolution (lookup) functions depending on the
 	// specified options.  The default is to use the standard net.Dial
 	// function as well as the system DNS resolver.  When a proxy is
@@ -165,6 +165,12 @@ type Config struct {
 	// Timeout specifies the amount of time to wait for a connection
 	// to complete before giving up.
 	Timeout time.Duration
+
+	// IsBanned is an optional callback used to determine whether or not the
+	// provided address is banned.  Inbound connections from banned addresses
+	// are closed immediately without invoking OnAccept and outbound
+	// connections to banned addresses fail without being dialed.
+	IsBanned func(net.Addr) bool
 }
 
 // registerPending is used to register a pending connection attempt. By
@@ -572,6 +578,16 @@ func (cm *ConnManager) Connect(ctx context.Context, c *ConnReq) {
 		}
 	}
 
+	// Refuse to connect to banned addresses.
+	if cm.cfg.IsBanned != nil && cm.cfg.IsBanned(c.Addr) {
+		str := fmt.Sprintf("address %v is banned", c.Addr)
+		select {
+		case cm.requests <- handleFailed{c, MakeError(ErrAddressBanned, str)}:
+		case <-cm.quit:
+		}
+		return
+	}
+
 	log.Debugf("Attempting to connect to %v", c)
 
 	if cm.cfg.Timeout != 0 {
@@ -681,6 +697,12 @@ func (cm *ConnManager) listenHandler(ctx context.Context, listener net.Listener)
 			}
 			continue
 		}
+		if cm.cfg.IsBanned != nil && cm.cfg.IsBanned(conn.RemoteAddr()) {
+			log.Debugf("Rejecting inbound connection from banned address %v",
+				conn.RemoteAddr())
+			conn.Close()
+			continue
+		}
 		go cm.cfg.OnAccept(conn)
 	}
 
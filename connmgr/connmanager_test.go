@@ -1023,3 +1023,67 @@ out:
 	shutdown()
 	wg.Wait()
 }
+
+// TestBannedAddresses ensures inbound connections from and outbound connections
+// to banned addresses are refused.
+func TestBannedAddresses(t *testing.T) {
+	const bannedIP = "10.0.0.1"
+	isBanned := func(addr net.Addr) bool {
+		host, _, err := net.SplitHostPort(addr.String())
+		return err == nil && host == bannedIP
+	}
+
+	receivedConns := make(chan net.Conn)
+	var dialCount atomic.Int32
+	listener := newMockListener("127.0.0.1:8333")
+	cmgr, err := New(&Config{
+		Listeners: []net.Listener{listener},
+		OnAccept: func(conn net.Conn) {
+			receivedConns <- conn
+		},
+		Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialCount.Add(1)
+			return mockDialer(ctx, network, addr)
+		},
+		IsBanned: isBanned,
+	})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	ctx, shutdown, wg := runConnMgrAsync(context.Background(), cmgr)
+
+	// Ensure an inbound connection from a banned address is rejected while one
+	// from an address that is not banned is accepted.
+	go func() {
+		listener.Connect(bannedIP, 10000)
+		listener.Connect("10.0.0.2", 10001)
+	}()
+	select {
+	case conn := <-receivedConns:
+		if isBanned(conn.RemoteAddr()) {
+			t.Fatalf("accepted connection from banned address %v",
+				conn.RemoteAddr())
+		}
+	case <-time.After(time.Millisecond * 50):
+		t.Fatal("timeout waiting for connection")
+	}
+
+	// Ensure an outbound connection to a banned address is not dialed and is
+	// marked as failed.
+	cr := &ConnReq{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP(bannedIP),
+			Port: 18555,
+		},
+	}
+	cmgr.Connect(ctx, cr)
+	time.Sleep(10 * time.Millisecond)
+	assertConnReqState(t, cr, ConnFailed)
+	if n := dialCount.Load(); n != 0 {
+		t.Fatalf("unexpected number of dials -- got %d, want 0", n)
+	}
+
+	// Ensure clean shutdown of connection manager.
+	shutdown()
+	wg.Wait()
+}
@@ -18,6 +18,10 @@ const (
 	// cannot both be specified in the configuration.
 	ErrBothDialsFilled = ErrorKind("ErrBothDialsFilled")
 
+	// ErrAddressBanned is used to indicate that a connection to an address
+	// was refused because the address is banned.
+	ErrAddressBanned = ErrorKind("ErrAddressBanned")
+
 	// ErrTorInvalidAddressResponse indicates an invalid address was
 	// returned by the Tor DNS resolver.
 	ErrTorInvalidAddressResponse = ErrorKind("ErrTorInvalidAddressResponse")
@@ -53,6 +57,26 @@ const (
 
 	// ErrTorAddrNotSupported indicates the tor address type is not supported.
 	ErrTorAddrNotSupported = ErrorKind("ErrTorAddrNotSupported")
+
+	// ErrTorControlCmdFailed indicates the Tor control port responded to a
+	// command with an error.
+	ErrTorControlCmdFailed = ErrorKind("ErrTorControlCmdFailed")
+
+	// ErrTorControlInvalidResponse indicates the Tor control port returned a
+	// response in an unexpected format.
+	ErrTorControlInvalidResponse = ErrorKind("ErrTorControlInvalidResponse")
+
+	// ErrTorControlUnsupportedAuth indicates the Tor control port does not
+	// support any of the available authentication methods.
+	ErrTorControlUnsupportedAuth = ErrorKind("ErrTorControlUnsupportedAuth")
+
+	// ErrTorControlAuthFailed indicates the Tor control port failed to prove
+	// it has access to the authentication cookie.
+	ErrTorControlAuthFailed = ErrorKind("ErrTorControlAuthFailed")
+
+	// ErrTorControlInvalidKey indicates an onion service private key or
+	// target is invalid.
+	ErrTorControlInvalidKey = ErrorKind("ErrTorControlInvalidKey")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
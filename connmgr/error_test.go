@@ -18,6 +18,7 @@ func TestErrorKindStringer(t *testing.T) {
 	}{
 		{ErrDialNil, "ErrDialNil"},
 		{ErrBothDialsFilled, "ErrBothDialsFilled"},
+		{ErrAddressBanned, "ErrAddressBanned"},
 		{ErrTorInvalidAddressResponse, "ErrTorInvalidAddressResponse"},
 		{ErrTorInvalidProxyResponse, "ErrTorInvalidProxyResponse"},
 		{ErrTorUnrecognizedAuthMethod, "ErrTorUnrecognizedAuthMethod"},
@@ -29,6 +30,11 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrTorTTLExpired, "ErrTorTTLExpired"},
 		{ErrTorCmdNotSupported, "ErrTorCmdNotSupported"},
 		{ErrTorAddrNotSupported, "ErrTorAddrNotSupported"},
+		{ErrTorControlCmdFailed, "ErrTorControlCmdFailed"},
+		{ErrTorControlInvalidResponse, "ErrTorControlInvalidResponse"},
+		{ErrTorControlUnsupportedAuth, "ErrTorControlUnsupportedAuth"},
+		{ErrTorControlAuthFailed, "ErrTorControlAuthFailed"},
+		{ErrTorControlInvalidKey, "ErrTorControlInvalidKey"},
 	}
 
 	for i, test := range tests {
@@ -0,0 +1,369 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package connmgr
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/monetarium/monetarium-node/crypto/rand"
+)
+
+const (
+	// torControlTimeout is the maximum amount of time to wait for the Tor
+	// control port to respond to a command.
+	torControlTimeout = 30 * time.Second
+
+	// torControlOK is the status code of successful Tor control replies.
+	torControlOK = 250
+
+	// torSafeCookieNonceSize is the size of the nonces used in SAFECOOKIE
+	// authentication.
+	torSafeCookieNonceSize = 32
+
+	// torCookieSize is the size of the authentication cookie.
+	torCookieSize = 32
+
+	// torSafeCookieServerKey and torSafeCookieClientKey are the HMAC keys
+	// used to compute the server and client hashes during SAFECOOKIE
+	// authentication.
+	torSafeCookieServerKey = "Tor safe cookie authentication server-to-controller hash"
+	torSafeCookieClientKey = "Tor safe cookie authentication controller-to-server hash"
+
+	// torV3KeyType is the key type of TORv3 onion services.
+	torV3KeyType = "ED25519-V3"
+)
+
+// OnionService describes an onion service created via the Tor control port.
+type OnionService struct {
+	// ServiceID is the onion address of the service without the .onion
+	// suffix.
+	ServiceID string
+
+	// PrivateKey is the private key of the service in the form returned by
+	// Tor, for example "ED25519-V3:<base64 key>".  It may be provided when
+	// creating the service again to reuse the same onion address.
+	PrivateKey string
+}
+
+// TorControl is a client for the Tor control protocol.  It is used to create
+// onion services that make the node reachable over the Tor network.
+//
+// Onion services created via the control port are ephemeral and are removed by
+// Tor when the control connection is closed, so the connection must remain
+// open for as long as the services are needed.
+type TorControl struct {
+	conn net.Conn
+	text *textproto.Conn
+}
+
+// DialTorControl connects to the Tor control port at the provided address.
+// The returned connection must be authenticated via Authenticate before any
+// other commands are issued.
+func DialTorControl(ctx context.Context, addr string) (*TorControl, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &TorControl{conn: conn, text: textproto.NewConn(conn)}, nil
+}
+
+// Close closes the connection to the Tor control port which also removes any
+// onion services created by it.
+func (c *TorControl) Close() error {
+	return c.text.Close()
+}
+
+// Wait blocks until the connection to the Tor control port is closed either
+// locally or by Tor.  No commands may be issued while waiting.
+func (c *TorControl) Wait() {
+	for {
+		if _, err := c.text.ReadLine(); err != nil {
+			return
+		}
+	}
+}
+
+// cmd sends the provided command to the Tor control port and returns the lines
+// of the successful reply without the status code.  An error is returned when
+// Tor does not respond with a successful reply.
+func (c *TorControl) cmd(format string, args ...interface{}) ([]string, error) {
+	c.conn.SetDeadline(time.Now().Add(torControlTimeout))
+	defer c.conn.SetDeadline(time.Time{})
+
+	id, err := c.text.Cmd(format, args...)
+	if err != nil {
+		return nil, err
+	}
+	c.text.StartResponse(id)
+	defer c.text.EndResponse(id)
+
+	_, msg, err := c.text.ReadResponse(torControlOK)
+	if err != nil {
+		var protoErr *textproto.Error
+		if errors.As(err, &protoErr) {
+			str := fmt.Sprintf("tor control command failed: %d %s",
+				protoErr.Code, protoErr.Msg)
+			return nil, MakeError(ErrTorControlCmdFailed, str)
+		}
+		return nil, err
+	}
+	return strings.Split(msg, "\n"), nil
+}
+
+// parseTorReplyLine parses the keyword arguments of a Tor control reply line
+// such as `AUTH METHODS=COOKIE COOKIEFILE="/path"` into a map.  Quoted values
+// are unquoted.
+func parseTorReplyLine(line string) (map[string]string, error) {
+	args := make(map[string]string)
+	for line != "" {
+		line = strings.TrimLeft(line, " ")
+		eq := strings.IndexByte(line, '=')
+		sp := strings.IndexByte(line, ' ')
+		if eq < 0 || (sp >= 0 && sp < eq) {
+			// Skip positional arguments.
+			if sp < 0 {
+				break
+			}
+			line = line[sp+1:]
+			continue
+		}
+
+		key, rest := line[:eq], line[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, "\"") {
+			// Find the closing quote while skipping escaped characters.
+			end := 1
+			for ; end < len(rest) && rest[end] != '"'; end++ {
+				if rest[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(rest) {
+				str := fmt.Sprintf("unterminated quoted value in tor "+
+					"control reply %q", line)
+				return nil, MakeError(ErrTorControlInvalidResponse, str)
+			}
+			unquoted, err := strconv.Unquote(rest[:end+1])
+			if err != nil {
+				str := fmt.Sprintf("invalid quoted value in tor control "+
+					"reply %q", line)
+				return nil, MakeError(ErrTorControlInvalidResponse, str)
+			}
+			value, line = unquoted, rest[end+1:]
+		} else {
+			end := strings.IndexByte(rest, ' ')
+			if end < 0 {
+				end = len(rest)
+			}
+			value, line = rest[:end], rest[end:]
+		}
+		args[key] = value
+	}
+	return args, nil
+}
+
+// findTorReplyLine returns the keyword arguments of the first line of the
+// provided reply lines that starts with the provided keyword.
+func findTorReplyLine(lines []string, keyword string) (map[string]string, error) {
+	for _, line := range lines {
+		if line == keyword || strings.HasPrefix(line, keyword+" ") {
+			return parseTorReplyLine(strings.TrimPrefix(line, keyword))
+		}
+	}
+	str := fmt.Sprintf("tor control reply is missing %s", keyword)
+	return nil, MakeError(ErrTorControlInvalidResponse, str)
+}
+
+// quoteTorString returns the provided string as a quoted string suitable for
+// use as a Tor control command argument.
+func quoteTorString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// Authenticate authenticates the connection with the Tor control port.  The
+// provided password is used when it is not empty and Tor supports password
+// authentication.  Otherwise, cookie authentication is used, preferring the
+// SAFECOOKIE method when it is available.
+func (c *TorControl) Authenticate(password string) error {
+	lines, err := c.cmd("PROTOCOLINFO 1")
+	if err != nil {
+		return err
+	}
+	auth, err := findTorReplyLine(lines, "AUTH")
+	if err != nil {
+		return err
+	}
+	methods := make(map[string]bool)
+	for _, method := range strings.Split(auth["METHODS"], ",") {
+		methods[method] = true
+	}
+	cookieFile := auth["COOKIEFILE"]
+
+	switch {
+	case methods["NULL"]:
+		_, err = c.cmd("AUTHENTICATE")
+
+	case methods["HASHEDPASSWORD"] && password != "":
+		_, err = c.cmd("AUTHENTICATE %s", quoteTorString(password))
+
+	case methods["SAFECOOKIE"] && cookieFile != "":
+		err = c.authenticateSafeCookie(cookieFile)
+
+	case methods["COOKIE"] && cookieFile != "":
+		var cookie []byte
+		cookie, err = readTorCookie(cookieFile)
+		if err != nil {
+			return err
+		}
+		_, err = c.cmd("AUTHENTICATE %x", cookie)
+
+	case methods["HASHEDPASSWORD"]:
+		str := "tor control port requires a password"
+		return MakeError(ErrTorControlUnsupportedAuth, str)
+
+	default:
+		str := fmt.Sprintf("no supported tor control authentication method "+
+			"in %q", auth["METHODS"])
+		return MakeError(ErrTorControlUnsupportedAuth, str)
+	}
+	return err
+}
+
+// readTorCookie reads the authentication cookie from the provided file.
+func readTorCookie(cookieFile string) ([]byte, error) {
+	cookie, err := os.ReadFile(cookieFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(cookie) != torCookieSize {
+		str := fmt.Sprintf("tor authentication cookie %s is %d bytes "+
+			"instead of %d", cookieFile, len(cookie), torCookieSize)
+		return nil, MakeError(ErrTorControlInvalidResponse, str)
+	}
+	return cookie, nil
+}
+
+// torSafeCookieHash returns the HMAC-SHA256 of the cookie and nonces used
+// during SAFECOOKIE authentication using the provided key.
+func torSafeCookieHash(key string, cookie, clientNonce, serverNonce []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(cookie)
+	mac.Write(clientNonce)
+	mac.Write(serverNonce)
+	return mac.Sum(nil)
+}
+
+// authenticateSafeCookie authenticates using the SAFECOOKIE method which, unlike
+// the COOKIE method, proves that the control port has access to the cookie
+// before revealing any information about it.
+func (c *TorControl) authenticateSafeCookie(cookieFile string) error {
+	cookie, err := readTorCookie(cookieFile)
+	if err != nil {
+		return err
+	}
+
+	var clientNonce [torSafeCookieNonceSize]byte
+	rand.Read(clientNonce[:])
+	lines, err := c.cmd("AUTHCHALLENGE SAFECOOKIE %x", clientNonce[:])
+	if err != nil {
+		return err
+	}
+	challenge, err := findTorReplyLine(lines, "AUTHCHALLENGE")
+	if err != nil {
+		return err
+	}
+	serverHash, err := hex.DecodeString(challenge["SERVERHASH"])
+	if err != nil {
+		str := "invalid tor control server hash"
+		return MakeError(ErrTorControlInvalidResponse, str)
+	}
+	serverNonce, err := hex.DecodeString(challenge["SERVERNONCE"])
+	if err != nil {
+		str := "invalid tor control server nonce"
+		return MakeError(ErrTorControlInvalidResponse, str)
+	}
+
+	wantServerHash := torSafeCookieHash(torSafeCookieServerKey, cookie,
+		clientNonce[:], serverNonce)
+	if !hmac.Equal(serverHash, wantServerHash) {
+		str := "tor control server hash does not match the cookie"
+		return MakeError(ErrTorControlAuthFailed, str)
+	}
+
+	clientHash := torSafeCookieHash(torSafeCookieClientKey, cookie,
+		clientNonce[:], serverNonce)
+	_, err = c.cmd("AUTHENTICATE %x", clientHash)
+	return err
+}
+
+// AddOnion creates a TORv3 onion service that forwards connections to the
+// provided virtual port to the provided target address.  A new key is
+// generated when the provided private key is empty.  Otherwise, the private
+// key must be one previously returned by AddOnion so the onion service has the
+// same address.
+func (c *TorControl) AddOnion(privateKey string, virtPort uint16, target string) (*OnionService, error) {
+	keySpec := privateKey
+	if keySpec == "" {
+		keySpec = "NEW:" + torV3KeyType
+	} else if !strings.HasPrefix(keySpec, torV3KeyType+":") {
+		str := fmt.Sprintf("onion service private key is not a %s key",
+			torV3KeyType)
+		return nil, MakeError(ErrTorControlInvalidKey, str)
+	}
+	if strings.ContainsAny(keySpec, " \r\n") ||
+		strings.ContainsAny(target, " \r\n") {
+
+		str := "onion service key and target must not contain whitespace"
+		return nil, MakeError(ErrTorControlInvalidKey, str)
+	}
+
+	lines, err := c.cmd("ADD_ONION %s Port=%d,%s", keySpec, virtPort, target)
+	if err != nil {
+		return nil, err
+	}
+
+	service := OnionService{PrivateKey: privateKey}
+	for _, line := range lines {
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "ServiceID":
+			service.ServiceID = value
+		case "PrivateKey":
+			service.PrivateKey = value
+		}
+	}
+	if service.ServiceID == "" {
+		str := "tor control reply is missing ServiceID"
+		return nil, MakeError(ErrTorControlInvalidResponse, str)
+	}
+	if service.PrivateKey == "" {
+		str := "tor control reply is missing PrivateKey"
+		return nil, MakeError(ErrTorControlInvalidResponse, str)
+	}
+	return &service, nil
+}
+
+// DelOnion removes the onion service with the provided service ID.
+func (c *TorControl) DelOnion(serviceID string) error {
+	_, err := c.cmd("DEL_ONION %s", serviceID)
+	return err
+}
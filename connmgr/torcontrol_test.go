@@ -0,0 +1,407 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package connmgr
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	// fakeServiceID and fakePrivateKey are the service ID and private key the
+	// fake Tor control port returns for newly created onion services.
+	fakeServiceID  = "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad"
+	fakePrivateKey = "ED25519-V3:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+)
+
+// fakeTorControl is a fake Tor control port that implements enough of the
+// control protocol to test the Tor control client.
+type fakeTorControl struct {
+	listener      net.Listener
+	methods       string
+	cookieFile    string
+	cookie        []byte
+	password      string
+	badServerHash bool
+
+	// addOnionArgs houses the arguments of each ADD_ONION command received.
+	addOnionArgs chan string
+}
+
+// newFakeTorControl starts a fake Tor control port that supports the provided
+// authentication methods and returns it along with its address.  A cookie file
+// is created for cookie authentication.
+func newFakeTorControl(t *testing.T, methods string) *fakeTorControl {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	cookie := bytes.Repeat([]byte{0x5a}, torCookieSize)
+	cookieFile := filepath.Join(t.TempDir(), "control_auth_cookie")
+	if err := os.WriteFile(cookieFile, cookie, 0600); err != nil {
+		t.Fatalf("unable to write cookie: %v", err)
+	}
+
+	f := &fakeTorControl{
+		listener:     listener,
+		methods:      methods,
+		cookieFile:   cookieFile,
+		cookie:       cookie,
+		password:     "secret \"pass\"",
+		addOnionArgs: make(chan string, 10),
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+// addr returns the address of the fake Tor control port.
+func (f *fakeTorControl) addr() string {
+	return f.listener.Addr().String()
+}
+
+// serve handles the commands received on the provided connection.
+func (f *fakeTorControl) serve(conn net.Conn) {
+	text := textproto.NewConn(conn)
+	defer text.Close()
+
+	var authenticated bool
+	var clientNonce, serverNonce []byte
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, args, _ := strings.Cut(line, " ")
+		switch cmd {
+		case "PROTOCOLINFO":
+			text.PrintfLine("250-PROTOCOLINFO 1")
+			text.PrintfLine("250-AUTH METHODS=%s COOKIEFILE=%q", f.methods,
+				f.cookieFile)
+			text.PrintfLine("250-VERSION Tor=\"0.4.8.10\"")
+			text.PrintfLine("250 OK")
+
+		case "AUTHCHALLENGE":
+			method, nonceHex, _ := strings.Cut(args, " ")
+			clientNonce, err = hex.DecodeString(nonceHex)
+			if method != "SAFECOOKIE" || err != nil {
+				text.PrintfLine("513 Invalid AUTHCHALLENGE request")
+				continue
+			}
+			serverNonce = bytes.Repeat([]byte{0x11}, torSafeCookieNonceSize)
+			serverHash := torSafeCookieHash(torSafeCookieServerKey, f.cookie,
+				clientNonce, serverNonce)
+			if f.badServerHash {
+				serverHash[0] ^= 0xff
+			}
+			text.PrintfLine("250 AUTHCHALLENGE SERVERHASH=%X SERVERNONCE=%X",
+				serverHash, serverNonce)
+
+		case "AUTHENTICATE":
+			var valid bool
+			switch {
+			case strings.Contains(f.methods, "NULL"):
+				valid = args == ""
+			case strings.HasPrefix(args, "\""):
+				valid = args == quoteTorString(f.password)
+			case serverNonce != nil:
+				clientHash := torSafeCookieHash(torSafeCookieClientKey,
+					f.cookie, clientNonce, serverNonce)
+				valid = strings.EqualFold(args, hex.EncodeToString(clientHash))
+			default:
+				valid = strings.EqualFold(args, hex.EncodeToString(f.cookie))
+			}
+			if !valid {
+				text.PrintfLine("515 Authentication failed")
+				continue
+			}
+			authenticated = true
+			text.PrintfLine("250 OK")
+
+		case "ADD_ONION":
+			if !authenticated {
+				text.PrintfLine("514 Authentication required.")
+				continue
+			}
+			f.addOnionArgs <- args
+			text.PrintfLine("250-ServiceID=%s", fakeServiceID)
+			if strings.HasPrefix(args, "NEW:") {
+				text.PrintfLine("250-PrivateKey=%s", fakePrivateKey)
+			}
+			text.PrintfLine("250 OK")
+
+		case "DEL_ONION":
+			if args != fakeServiceID {
+				text.PrintfLine("552 Unknown Onion Service id")
+				continue
+			}
+			text.PrintfLine("250 OK")
+
+		default:
+			text.PrintfLine("510 Unrecognized command \"%s\"", cmd)
+		}
+	}
+}
+
+// TestTorControl ensures the Tor control client authenticates and creates
+// onion services as expected against a fake Tor control port.
+func TestTorControl(t *testing.T) {
+	tests := []struct {
+		name          string
+		methods       string
+		password      string
+		badServerHash bool
+		wantAuthErr   error
+	}{{
+		name:    "null",
+		methods: "NULL",
+	}, {
+		name:    "safe cookie",
+		methods: "COOKIE,SAFECOOKIE",
+	}, {
+		name:    "cookie",
+		methods: "COOKIE",
+	}, {
+		name:     "password",
+		methods:  "HASHEDPASSWORD",
+		password: "secret \"pass\"",
+	}, {
+		name:     "password preferred over cookie",
+		methods:  "COOKIE,SAFECOOKIE,HASHEDPASSWORD",
+		password: "secret \"pass\"",
+	}, {
+		name:        "wrong password",
+		methods:     "HASHEDPASSWORD",
+		password:    "wrong",
+		wantAuthErr: ErrTorControlCmdFailed,
+	}, {
+		name:        "missing password",
+		methods:     "HASHEDPASSWORD",
+		wantAuthErr: ErrTorControlUnsupportedAuth,
+	}, {
+		name:        "unsupported method",
+		methods:     "UNKNOWN",
+		wantAuthErr: ErrTorControlUnsupportedAuth,
+	}, {
+		name:          "bad server hash",
+		methods:       "SAFECOOKIE",
+		badServerHash: true,
+		wantAuthErr:   ErrTorControlAuthFailed,
+	}}
+
+	for _, test := range tests {
+		f := newFakeTorControl(t, test.methods)
+		f.badServerHash = test.badServerHash
+
+		c, err := DialTorControl(context.Background(), f.addr())
+		if err != nil {
+			t.Fatalf("%q: unexpected dial error: %v", test.name, err)
+		}
+		err = c.Authenticate(test.password)
+		if !errors.Is(err, test.wantAuthErr) {
+			t.Fatalf("%q: mismatched auth error: got %v, want %v", test.name,
+				err, test.wantAuthErr)
+		}
+		if err != nil {
+			c.Close()
+			continue
+		}
+
+		// Create a new onion service and ensure the expected command was sent
+		// and the service ID and generated private key are returned.
+		service, err := c.AddOnion("", 9108, "127.0.0.1:19108")
+		if err != nil {
+			t.Fatalf("%q: unexpected add onion error: %v", test.name, err)
+		}
+		want := &OnionService{
+			ServiceID:  fakeServiceID,
+			PrivateKey: fakePrivateKey,
+		}
+		if !reflect.DeepEqual(service, want) {
+			t.Fatalf("%q: mismatched service: got %+v, want %+v", test.name,
+				service, want)
+		}
+		wantArgs := "NEW:ED25519-V3 Port=9108,127.0.0.1:19108"
+		if args := <-f.addOnionArgs; args != wantArgs {
+			t.Fatalf("%q: mismatched add onion args: got %q, want %q",
+				test.name, args, wantArgs)
+		}
+
+		// Recreate the onion service with the existing private key.
+		service, err = c.AddOnion(fakePrivateKey, 9108, "127.0.0.1:19108")
+		if err != nil {
+			t.Fatalf("%q: unexpected add onion error: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(service, want) {
+			t.Fatalf("%q: mismatched service: got %+v, want %+v", test.name,
+				service, want)
+		}
+		wantArgs = fmt.Sprintf("%s Port=9108,127.0.0.1:19108", fakePrivateKey)
+		if args := <-f.addOnionArgs; args != wantArgs {
+			t.Fatalf("%q: mismatched add onion args: got %q, want %q",
+				test.name, args, wantArgs)
+		}
+
+		if err := c.DelOnion(fakeServiceID); err != nil {
+			t.Fatalf("%q: unexpected del onion error: %v", test.name, err)
+		}
+		err = c.DelOnion("unknown")
+		if !errors.Is(err, ErrTorControlCmdFailed) {
+			t.Fatalf("%q: mismatched del onion error: got %v, want %v",
+				test.name, err, ErrTorControlCmdFailed)
+		}
+		c.Close()
+	}
+}
+
+// TestTorControlAddOnionErrors ensures creating onion services with invalid
+// parameters or without authenticating fails as expected.
+func TestTorControlAddOnionErrors(t *testing.T) {
+	f := newFakeTorControl(t, "NULL")
+	c, err := DialTorControl(context.Background(), f.addr())
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+	defer c.Close()
+
+	tests := []struct {
+		name    string
+		key     string
+		target  string
+		wantErr error
+	}{{
+		name:    "not authenticated",
+		target:  "127.0.0.1:19108",
+		wantErr: ErrTorControlCmdFailed,
+	}, {
+		name:    "wrong key type",
+		key:     "RSA1024:AAAA",
+		target:  "127.0.0.1:19108",
+		wantErr: ErrTorControlInvalidKey,
+	}, {
+		name:    "key with whitespace",
+		key:     "ED25519-V3:AA AA",
+		target:  "127.0.0.1:19108",
+		wantErr: ErrTorControlInvalidKey,
+	}, {
+		name:    "target with newline",
+		target:  "127.0.0.1:19108\r\nDEL_ONION x",
+		wantErr: ErrTorControlInvalidKey,
+	}}
+	for _, test := range tests {
+		_, err := c.AddOnion(test.key, 9108, test.target)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%q: mismatched error: got %v, want %v", test.name, err,
+				test.wantErr)
+		}
+	}
+}
+
+// TestTorControlWait ensures waiting on the Tor control connection returns once
+// the connection is closed by Tor.
+func TestTorControlWait(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		conn.Write([]byte("650 STATUS_GENERAL NOTICE\r\n"))
+		conn.Close()
+	}()
+
+	c, err := DialTorControl(context.Background(), listener.Addr().String())
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+	defer c.Close()
+
+	done := make(chan struct{})
+	go func() {
+		c.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("wait did not return after the connection was closed")
+	}
+}
+
+// TestParseTorReplyLine ensures Tor control reply lines are parsed as
+// expected.
+func TestParseTorReplyLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    map[string]string
+		wantErr error
+	}{{
+		name: "empty",
+		line: "",
+		want: map[string]string{},
+	}, {
+		name: "unquoted and quoted values",
+		line: ` METHODS=COOKIE,SAFECOOKIE COOKIEFILE="/var/lib/tor/control_auth_cookie"`,
+		want: map[string]string{
+			"METHODS":    "COOKIE,SAFECOOKIE",
+			"COOKIEFILE": "/var/lib/tor/control_auth_cookie",
+		},
+	}, {
+		name: "escaped quote",
+		line: `PATH="a \"b\" c" X=1`,
+		want: map[string]string{"PATH": `a "b" c`, "X": "1"},
+	}, {
+		name: "positional arguments are skipped",
+		line: `1 VERSION=2 extra`,
+		want: map[string]string{"VERSION": "2"},
+	}, {
+		name:    "unterminated quote",
+		line:    `PATH="abc`,
+		wantErr: ErrTorControlInvalidResponse,
+	}}
+
+	for _, test := range tests {
+		got, err := parseTorReplyLine(test.line)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%q: mismatched error: got %v, want %v", test.name, err,
+				test.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: mismatched result: got %v, want %v", test.name, got,
+				test.want)
+		}
+	}
+}
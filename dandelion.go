@@ -0,0 +1,224 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/monetarium/monetarium-node/blockchain/stake"
+	"github.com/monetarium/monetarium-node/crypto/rand"
+	"github.com/monetarium/monetarium-node/dcrutil"
+	"github.com/monetarium/monetarium-node/internal/mempool"
+	"github.com/monetarium/monetarium-node/peer"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+const (
+	// stemFluffPercent is the percentage chance that a transaction received
+	// from a peer during the stem phase of relay transitions to the fluff
+	// phase at this node instead of being relayed to the next stem peer.
+	stemFluffPercent = 10
+
+	// stemEmbargoBase is the minimum amount of time a transaction remains in
+	// the stem pool before it is fluffed by this node because it has not been
+	// seen in the fluff phase.
+	stemEmbargoBase = 30 * time.Second
+
+	// stemEmbargoJitter is the maximum random amount of time that is added to
+	// the minimum embargo time of each transaction so nodes along the stem do
+	// not fluff transactions in lockstep.
+	stemEmbargoJitter = 30 * time.Second
+
+	// stemEmbargoCheckInterval is the interval at which the stem pool is
+	// checked for transactions with expired embargo timers.
+	stemEmbargoCheckInterval = 5 * time.Second
+
+	// stemPeerEpoch is the amount of time the same stem peer is used before a
+	// new one is randomly selected.
+	stemPeerEpoch = 10 * time.Minute
+
+	// maxStemPoolTxns is the maximum number of transactions that are kept in
+	// the stem pool.
+	maxStemPoolTxns = 1000
+)
+
+// stemRouter houses the state used to route transactions during the stem phase
+// of private transaction relay.
+type stemRouter struct {
+	// pool houses the transactions in the stem phase.  It is nil when private
+	// transaction relay is disabled.
+	pool *mempool.StemPool
+
+	// The following fields are protected by the mutex.
+	mtx         sync.Mutex
+	stemPeer    *serverPeer
+	stemExpires time.Time
+}
+
+// stemPeer returns the peer transactions in the stem phase are relayed to.  A
+// new stem peer is randomly selected from the outbound peers that support stem
+// relay once the current one disconnects or the stem epoch ends.  Nil is
+// returned when there is no suitable peer.
+func (s *server) stemPeer() *serverPeer {
+	r := &s.stemRouter
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	now := time.Now()
+	if r.stemPeer != nil && r.stemPeer.Connected() && now.Before(r.stemExpires) {
+		return r.stemPeer
+	}
+
+	var candidates []*serverPeer
+	s.peerState.Lock()
+	s.peerState.forAllOutboundPeers(func(sp *serverPeer) {
+		if sp.Connected() && !sp.disableRelayTx.Load() &&
+			sp.ProtocolVersion() >= wire.StemTxVersion {
+
+			candidates = append(candidates, sp)
+		}
+	})
+	s.peerState.Unlock()
+
+	r.stemPeer = nil
+	if len(candidates) > 0 {
+		r.stemPeer = candidates[rand.IntN(len(candidates))]
+		r.stemExpires = now.Add(stemPeerEpoch)
+		srvrLog.Debugf("Selected stem peer %v", r.stemPeer)
+	}
+	return r.stemPeer
+}
+
+// stemTransaction attempts to relay the passed transaction to the stem peer
+// after ensuring it is acceptable to the mempool and adding it to the stem pool
+// with a random embargo time.  The source is the peer the transaction was
+// received from and is nil for locally submitted transactions.
+//
+// False is returned without an error when the transaction should instead be
+// processed and relayed normally, such as when private transaction relay is
+// disabled or there is no stem peer to relay it to.
+func (s *server) stemTransaction(tx *dcrutil.Tx, source *serverPeer, allowHighFees bool) (bool, error) {
+	pool := s.stemRouter.pool
+	if pool == nil {
+		return false, nil
+	}
+
+	// Stake transactions are time sensitive, so they are always relayed
+	// normally.
+	if stake.DetermineTxType(tx.MsgTx()) != stake.TxTypeRegular {
+		return false, nil
+	}
+
+	// Ensure the transaction is acceptable to the mempool without adding it.
+	// Orphans are relayed normally so they are handled by the orphan pool.
+	missingParents, err := s.txMemPool.CheckTransaction(tx, allowHighFees)
+	if err != nil {
+		return false, err
+	}
+	if len(missingParents) > 0 {
+		return false, nil
+	}
+
+	// Fall back to normal relay when there is no stem peer or the stem peer is
+	// the peer that sent the transaction.
+	stemPeer := s.stemPeer()
+	if stemPeer == nil || stemPeer == source {
+		return false, nil
+	}
+
+	embargo := time.Now().Add(stemEmbargoBase + rand.Duration(stemEmbargoJitter))
+	if err := pool.Add(tx, embargo, source == nil, allowHighFees); err != nil {
+		if errors.Is(err, mempool.ErrDuplicate) {
+			return false, err
+		}
+		srvrLog.Debugf("Relaying transaction %v normally: %v", tx.Hash(), err)
+		return false, nil
+	}
+
+	srvrLog.Tracef("Relaying stem transaction %v to %v", tx.Hash(), stemPeer)
+	stemPeer.QueueMessage(wire.NewMsgStemTx(tx.MsgTx()), nil)
+	return true, nil
+}
+
+// OnStemTx is invoked when a peer receives a stemtx wire message.  The
+// transaction is either relayed to the next stem peer or, with a small random
+// probability or when that is not possible, transitions to the fluff phase by
+// being processed and relayed like any other transaction.
+func (sp *serverPeer) OnStemTx(_ *peer.Peer, msg *wire.MsgStemTx) {
+	if cfg.BlocksOnly {
+		peerLog.Tracef("Ignoring stem tx %v from %v - blocksonly enabled",
+			msg.Tx.TxHash(), sp)
+		return
+	}
+
+	if rand.IntN(100) >= stemFluffPercent {
+		tx := dcrutil.NewTx(&msg.Tx)
+		stemmed, err := sp.server.stemTransaction(tx, sp, false)
+		if stemmed {
+			return
+		}
+		if err != nil {
+			peerLog.Debugf("Rejected stem tx %v from %v: %v", tx.Hash(), sp,
+				err)
+			return
+		}
+	}
+
+	sp.OnTx(nil, &msg.Tx)
+}
+
+// fluffStemTransaction transitions the passed transaction from the stem phase
+// to the fluff phase by adding it to the mempool and announcing it to all
+// peers.
+func (s *server) fluffStemTransaction(desc *mempool.StemTxDesc) {
+	// Apply the same high fee policy the transaction was checked with when it
+	// was stemmed.
+	acceptedTxs, err := s.txMemPool.ProcessTransaction(desc.Tx, false,
+		desc.AllowHighFees, 0)
+	if err != nil {
+		srvrLog.Debugf("Unable to fluff stem transaction %v: %v",
+			desc.Tx.Hash(), err)
+		return
+	}
+	srvrLog.Debugf("Embargo expired for stem transaction %v", desc.Tx.Hash())
+	s.AnnounceNewTransactions(acceptedTxs)
+	s.maybeRebroadcastStemTransaction(desc)
+}
+
+// maybeRebroadcastStemTransaction adds the transaction associated with the
+// passed descriptor to the rebroadcast logic when it was submitted locally.
+func (s *server) maybeRebroadcastStemTransaction(desc *mempool.StemTxDesc) {
+	if desc.Local && s.rpcServer != nil {
+		iv := wire.NewInvVect(wire.InvTypeTx, desc.Tx.Hash())
+		s.AddRebroadcastInventory(iv, desc.Tx)
+	}
+}
+
+// stemEmbargoHandler periodically fluffs transactions in the stem pool whose
+// embargo timers expired without them being seen in the fluff phase.  This
+// ensures transactions still propagate when a node along the stem fails to
+// relay them.
+//
+// It must be run as a goroutine.
+func (s *server) stemEmbargoHandler(ctx context.Context) {
+	ticker := time.NewTicker(stemEmbargoCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			expired := s.stemRouter.pool.ExpiredTransactions(time.Now())
+			for _, desc := range expired {
+				s.fluffStemTransaction(desc)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
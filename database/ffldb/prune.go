@@ -0,0 +1,228 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package ffldb
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/database"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// storedBlock identifies a block stored in a flat block file.
+type storedBlock struct {
+	hash   chainhash.Hash
+	height uint32
+}
+
+// firstBlockFileNum returns the number of the oldest flat block file in the
+// provided database directory along with whether or not any block files exist.
+// The oldest file is not necessarily the first one since old files are removed
+// when the database is pruned.
+func firstBlockFileNum(dbPath string) (uint32, bool) {
+	entries, err := os.ReadDir(dbPath)
+	if err != nil {
+		return 0, false
+	}
+
+	var firstFileNum uint32
+	var found bool
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || len(name) != 13 || !strings.HasSuffix(name, ".fdb") {
+			continue
+		}
+		fileNum, err := strconv.ParseUint(name[:9], 10, 32)
+		if err != nil {
+			continue
+		}
+		if !found || uint32(fileNum) < firstFileNum {
+			firstFileNum = uint32(fileNum)
+			found = true
+		}
+	}
+	return firstFileNum, found
+}
+
+// scanFileBlocks returns the hash and height of every block stored in the
+// passed flat block file number in the order they were written.
+//
+// Format: <network><block length><serialized block><checksum>
+func (s *blockStore) scanFileBlocks(fileNum uint32) ([]storedBlock, error) {
+	filePath := blockFilePath(s.basePath, fileNum)
+	file, err := os.Open(filePath)
+	if err != nil {
+		str := fmt.Sprintf("failed to open read-only file %q: %v",
+			filePath, err)
+		return nil, makeDbErr(database.ErrDriverSpecific, str)
+	}
+	defer file.Close()
+	st, err := file.Stat()
+	if err != nil {
+		str := fmt.Sprintf("failed to stat file %q: %v", filePath, err)
+		return nil, makeDbErr(database.ErrDriverSpecific, str)
+	}
+
+	var blocks []storedBlock
+	var record [8 + wire.MaxBlockHeaderPayload]byte
+	fileSize := st.Size()
+	for offset := int64(0); offset < fileSize; {
+		if _, err := file.ReadAt(record[:], offset); err != nil {
+			str := fmt.Sprintf("failed to read block record from file "+
+				"%d, offset %d: %v", fileNum, offset, err)
+			return nil, makeDbErr(database.ErrDriverSpecific, str)
+		}
+		serializedNet := byteOrder.Uint32(record[0:4])
+		if serializedNet != uint32(s.network) {
+			str := fmt.Sprintf("block record in file %d, offset %d is "+
+				"for the wrong network - got %d, want %d", fileNum, offset,
+				serializedNet, uint32(s.network))
+			return nil, makeDbErr(database.ErrCorruption, str)
+		}
+		var header wire.BlockHeader
+		if err := header.FromBytes(record[8:]); err != nil {
+			str := fmt.Sprintf("failed to decode block header in file %d, "+
+				"offset %d: %v", fileNum, offset, err)
+			return nil, makeDbErr(database.ErrCorruption, str)
+		}
+		blocks = append(blocks, storedBlock{
+			hash:   header.BlockHash(),
+			height: header.Height,
+		})
+
+		// Skip the network, block length, block, and checksum.
+		offset += int64(byteOrder.Uint32(record[4:8])) + 12
+	}
+
+	return blocks, nil
+}
+
+// removeFile closes the passed flat block file number if it is open and then
+// deletes it.
+func (s *blockStore) removeFile(fileNum uint32) error {
+	s.obfMutex.Lock()
+	if blockFile, ok := s.openBlockFiles[fileNum]; ok {
+		s.lruMutex.Lock()
+		s.openBlocksLRU.Remove(s.fileNumToLRUElem[fileNum])
+		delete(s.fileNumToLRUElem, fileNum)
+		s.lruMutex.Unlock()
+
+		// Close the file under the write lock for the file in case any
+		// readers are currently reading from it.
+		blockFile.Lock()
+		_ = blockFile.file.Close()
+		blockFile.Unlock()
+		delete(s.openBlockFiles, fileNum)
+	}
+	s.obfMutex.Unlock()
+
+	return s.deleteFileFunc(fileNum)
+}
+
+// PruneBlocks removes the oldest flat block files, along with the block index
+// entries for the blocks they contain, until the total size of the remaining
+// block files is no more than the provided target size in bytes.
+//
+// Only files that exclusively contain blocks with heights less than the
+// provided height are removed and the file that is currently being written to
+// is never removed, so the total size may remain above the target.
+//
+// The hashes of the removed blocks are returned.
+//
+// This function is safe for concurrent access.
+func (db *db) PruneBlocks(targetSize uint64, belowHeight uint32) ([]chainhash.Hash, error) {
+	var pruned []chainhash.Hash
+	var prunedFiles []uint32
+	err := db.Update(func(dbTx database.Tx) error {
+		tx := dbTx.(*transaction)
+		store := db.store
+		firstFileNum, ok := firstBlockFileNum(store.basePath)
+		if !ok {
+			return nil
+		}
+
+		// Determine the size of every block file.  Note that the write
+		// cursor can't change while the write transaction is held.
+		curFileNum := store.writeCursor.curFileNum
+		var totalSize uint64
+		fileSizes := make([]uint64, 0, curFileNum-firstFileNum+1)
+		for fileNum := firstFileNum; fileNum <= curFileNum; fileNum++ {
+			var fileSize uint64
+			st, err := os.Stat(blockFilePath(store.basePath, fileNum))
+			if err == nil {
+				fileSize = uint64(st.Size())
+			}
+			fileSizes = append(fileSizes, fileSize)
+			totalSize += fileSize
+		}
+
+		// Remove the block index entries for the blocks in the oldest files
+		// until the target is reached or a file that contains blocks that
+		// must be retained is found.
+		for i, fileNum := 0, firstFileNum; fileNum < curFileNum &&
+			totalSize > targetSize; i, fileNum = i+1, fileNum+1 {
+
+			blocks, err := store.scanFileBlocks(fileNum)
+			if err != nil {
+				return err
+			}
+			var mustKeep bool
+			for _, block := range blocks {
+				if block.height >= belowHeight {
+					mustKeep = true
+					break
+				}
+			}
+			if mustKeep {
+				break
+			}
+
+			for _, block := range blocks {
+				// Ignore blocks whose data is not referenced by the index
+				// such as those left behind by a rollback.
+				blockRow := tx.blockIdxBucket.Get(block.hash[:])
+				if blockRow == nil {
+					continue
+				}
+				if deserializeBlockLoc(blockRow).blockFileNum != fileNum {
+					continue
+				}
+
+				if err := tx.blockIdxBucket.Delete(block.hash[:]); err != nil {
+					return err
+				}
+				pruned = append(pruned, block.hash)
+			}
+			prunedFiles = append(prunedFiles, fileNum)
+			totalSize -= fileSizes[i]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(prunedFiles) == 0 {
+		return nil, nil
+	}
+
+	// Ensure the removal of the block index entries is persisted before
+	// deleting the files so the index never references missing files.
+	if err := db.Flush(); err != nil {
+		return nil, err
+	}
+	for _, fileNum := range prunedFiles {
+		if err := db.store.removeFile(fileNum); err != nil {
+			return pruned, err
+		}
+	}
+
+	log.Debugf("Pruned %d block files containing %d blocks", len(prunedFiles),
+		len(pruned))
+	return pruned, nil
+}
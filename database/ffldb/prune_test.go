@@ -0,0 +1,129 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package ffldb
+
+import (
+	"os"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/database"
+)
+
+// TestPruneBlocks ensures pruning removes the oldest block files along with
+// the index entries for the blocks they contain, retains files with blocks at
+// or above the provided height, and that the database can be reopened after
+// pruning.
+func TestPruneBlocks(t *testing.T) {
+	blocks, err := loadBlocks(t, blockDataFile, blockDataNet)
+	if err != nil {
+		t.Fatalf("unable to load blocks: %v", err)
+	}
+
+	// Create a new database with a small maximum file size to force multiple
+	// flat files with the test data set.
+	dbPath := t.TempDir()
+	idb, err := database.Create(dbType, dbPath, blockDataNet)
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() {
+		if idb != nil {
+			idb.Close()
+		}
+	}()
+	pdb := idb.(*db)
+	pdb.store.maxBlockFileSize = 8 * 1024
+	for _, block := range blocks {
+		err := idb.Update(func(tx database.Tx) error {
+			return tx.StoreBlock(block)
+		})
+		if err != nil {
+			t.Fatalf("failed to store block %v: %v", block.Hash(), err)
+		}
+	}
+	firstFileNum, _ := firstBlockFileNum(dbPath)
+	if firstFileNum != 0 {
+		t.Fatalf("unexpected first block file %d", firstFileNum)
+	}
+	numFiles := pdb.store.writeCursor.curFileNum + 1
+	if numFiles < 4 {
+		t.Fatalf("expected multiple block files, got %d", numFiles)
+	}
+
+	// Ensure nothing is pruned when the total size is below the target.
+	pruned, err := pdb.PruneBlocks(^uint64(0), ^uint32(0))
+	if err != nil {
+		t.Fatalf("unexpected error pruning: %v", err)
+	}
+	if len(pruned) != 0 {
+		t.Fatalf("unexpectedly pruned %d blocks", len(pruned))
+	}
+
+	// Prune as much as possible while retaining the blocks from height 100.
+	const retainHeight = 100
+	pruned, err = pdb.PruneBlocks(0, retainHeight)
+	if err != nil {
+		t.Fatalf("unexpected error pruning: %v", err)
+	}
+	if len(pruned) == 0 {
+		t.Fatal("no blocks were pruned")
+	}
+	firstFileNum, _ = firstBlockFileNum(dbPath)
+	if firstFileNum == 0 {
+		t.Fatal("no block files were removed")
+	}
+	if _, err := os.Stat(blockFilePath(dbPath, 0)); !os.IsNotExist(err) {
+		t.Fatalf("first block file still exists: %v", err)
+	}
+
+	// Ensure the pruned blocks are no longer available while the remaining
+	// blocks still are.
+	prunedSet := make(map[chainhash.Hash]struct{}, len(pruned))
+	for _, hash := range pruned {
+		prunedSet[hash] = struct{}{}
+	}
+	checkBlocks := func(idb database.DB) {
+		t.Helper()
+		err := idb.View(func(tx database.Tx) error {
+			for _, block := range blocks {
+				hash := block.Hash()
+				height := block.Height()
+				_, isPruned := prunedSet[*hash]
+				if isPruned && height >= retainHeight {
+					t.Fatalf("block %d at or above retain height pruned",
+						height)
+				}
+				has, err := tx.HasBlock(hash)
+				if err != nil {
+					return err
+				}
+				if has == isPruned {
+					t.Fatalf("block %d: unexpected has block %v", height, has)
+				}
+				if !isPruned {
+					if _, err := tx.FetchBlock(hash); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error checking blocks: %v", err)
+		}
+	}
+	checkBlocks(idb)
+
+	// Ensure the pruned database can be reopened.
+	if err := idb.Close(); err != nil {
+		t.Fatalf("failed to close database: %v", err)
+	}
+	idb, err = database.Open(dbType, dbPath, blockDataNet)
+	if err != nil {
+		t.Fatalf("failed to reopen pruned database: %v", err)
+	}
+	checkBlocks(idb)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+/*
+Package musig2 provides multi-party Schnorr signing via secp256k1.
+
+This package implements a MuSig2-style key aggregation and two-round signing
+protocol that produces standard EC-Schnorr-DCRv0 signatures as defined by the
+schnorr package.  A group of participants aggregates their individual public
+keys into a single public key, and a signature for that aggregate key can only
+be produced when every participant cooperates.  No participant ever holds, or
+is able to reconstruct, the private key of the aggregate.
+
+Since the resulting signatures are indistinguishable from single-signer
+EC-Schnorr-DCRv0 signatures, an aggregate key may be used anywhere a Schnorr
+public key is accepted, such as treasury spend keys or SKA emission keys that
+are configured for Schnorr authorization.
+
+# Protocol Overview
+
+Key aggregation:
+
+ 1. Each participant shares its public key X_i
+ 2. Every participant calls AggregateKeys with all of the public keys to
+    obtain the aggregate key Q = sum(a_i * X_i) where each coefficient a_i
+    commits to the entire key set
+
+Signing round 1:
+
+ 1. Each participant calls GenerateNonce to obtain a fresh secret nonce and
+    the corresponding public nonce (R1_i, R2_i) for the message
+ 2. Each participant shares its public nonce with the others
+
+Signing round 2:
+
+ 1. Every participant calls AggregateNonces with all of the public nonces and
+    creates the Session for the message with NewSession
+ 2. Each participant calls Session.Sign with its secret nonce and private key
+    and shares the resulting partial signature
+ 3. Any party may verify each partial signature with Session.VerifyPartial and
+    combine them with Session.Combine to obtain the final signature
+
+# Security Considerations
+
+A secret nonce must never be used to sign more than once.  Reusing a secret
+nonce across two sessions reveals the participant's private key.  Session.Sign
+zeroes the secret nonce to help prevent accidental reuse, but callers must also
+ensure they never persist and restore secret nonces.
+
+The EC-Schnorr-DCRv0 challenge does not commit to the public key, so the
+aggregate key is instead committed to by the nonce coefficient.  All
+participants must therefore agree on the exact key set before signing.
+
+Note that the current implementation has a few remaining variable time aspects
+which make use of the private key and the secret nonces, which can expose the
+signer to constant time attacks.  As a result, it should not be used in
+situations where there is the possibility of someone having EM field/cache/etc
+access.
+*/
+package musig2
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package musig2
+
+// ErrorKind identifies a kind of error.  It has full support for errors.Is
+// and errors.As, so the caller can directly check against an error kind
+// when determining the reason for an error.
+type ErrorKind string
+
+// These constants are used to identify a specific Error.
+const (
+	// ErrNoKeys indicates an attempt to aggregate an empty set of public
+	// keys.
+	ErrNoKeys = ErrorKind("ErrNoKeys")
+
+	// ErrDuplicateKey indicates the same public key was provided more than
+	// once when aggregating keys.
+	ErrDuplicateKey = ErrorKind("ErrDuplicateKey")
+
+	// ErrKeyNotInSet indicates a public key that is not one of the keys that
+	// make up an aggregate key was used to sign or verify a partial signature.
+	ErrKeyNotInSet = ErrorKind("ErrKeyNotInSet")
+
+	// ErrAggregateKeyInfinity indicates the aggregate public key is the point
+	// at infinity.
+	ErrAggregateKeyInfinity = ErrorKind("ErrAggregateKeyInfinity")
+
+	// ErrInvalidHashLen indicates that the message to sign or verify is not
+	// the required length.
+	ErrInvalidHashLen = ErrorKind("ErrInvalidHashLen")
+
+	// ErrNoNonces indicates an attempt to aggregate an empty set of public
+	// nonces.
+	ErrNoNonces = ErrorKind("ErrNoNonces")
+
+	// ErrInvalidPubNonce indicates a public nonce does not encode two valid
+	// compressed points on the curve.
+	ErrInvalidPubNonce = ErrorKind("ErrInvalidPubNonce")
+
+	// ErrNonceInfinity indicates an aggregate or effective nonce is the point
+	// at infinity.
+	ErrNonceInfinity = ErrorKind("ErrNonceInfinity")
+
+	// ErrSchnorrHashValue indicates that the hash of (R || m) was too large
+	// and so the session must be restarted with fresh nonces.
+	ErrSchnorrHashValue = ErrorKind("ErrSchnorrHashValue")
+
+	// ErrNonceReused indicates an attempt to sign with a secret nonce that
+	// has already been used to produce a partial signature.
+	ErrNonceReused = ErrorKind("ErrNonceReused")
+
+	// ErrNonceKeyMismatch indicates a secret nonce was generated for a
+	// different private key than the one used to sign.
+	ErrNonceKeyMismatch = ErrorKind("ErrNonceKeyMismatch")
+
+	// ErrPartialSigTooBig indicates an encoded partial signature is greater
+	// than or equal to the group order.
+	ErrPartialSigTooBig = ErrorKind("ErrPartialSigTooBig")
+
+	// ErrInvalidPartialSigLen indicates an encoded partial signature is not
+	// the required length.
+	ErrInvalidPartialSigLen = ErrorKind("ErrInvalidPartialSigLen")
+
+	// ErrPartialSigInvalid indicates a partial signature failed verification.
+	ErrPartialSigInvalid = ErrorKind("ErrPartialSigInvalid")
+
+	// ErrFinalSigInvalid indicates the signature produced by combining the
+	// partial signatures does not verify against the aggregate key.
+	ErrFinalSigInvalid = ErrorKind("ErrFinalSigInvalid")
+)
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e ErrorKind) Error() string {
+	return string(e)
+}
+
+// Error identifies an error related to multi-party signing.  It has full
+// support for errors.Is and errors.As, so the caller can ascertain the specific
+// reason for the error by checking the underlying error.
+type Error struct {
+	Err         error
+	Description string
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e Error) Error() string {
+	return e.Description
+}
+
+// Unwrap returns the underlying wrapped error.
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// makeError creates an Error given a set of arguments.
+func makeError(kind ErrorKind, desc string) Error {
+	return Error{Err: kind, Description: desc}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package musig2_test
+
+import (
+	"fmt"
+
+	"github.com/monetarium/monetarium-node/crypto/blake256"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1/schnorr/musig2"
+)
+
+// This example demonstrates three participants producing a single
+// EC-Schnorr-DCRv0 signature for their aggregate public key without any of
+// them holding the private key for it.
+func Example() {
+	// Each participant holds its own private key and shares the public key.
+	var privKeys []*secp256k1.PrivateKey
+	var pubKeys []*secp256k1.PublicKey
+	for i := 0; i < 3; i++ {
+		privKey, err := secp256k1.GeneratePrivateKey()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		privKeys = append(privKeys, privKey)
+		pubKeys = append(pubKeys, privKey.PubKey())
+	}
+
+	// All participants derive the same aggregate key.
+	aggKey, err := musig2.AggregateKeys(pubKeys)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Round 1: every participant generates a nonce for the message and
+	// shares the public nonce.
+	msg := blake256.Sum256([]byte("test message"))
+	secNonces := make([]*musig2.SecretNonce, len(privKeys))
+	pubNonces := make([]*musig2.PublicNonce, len(privKeys))
+	for i, privKey := range privKeys {
+		secNonces[i], pubNonces[i], err = musig2.GenerateNonce(privKey,
+			aggKey, msg[:])
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	// Round 2: every participant aggregates the public nonces and produces
+	// its partial signature.
+	aggNonce, err := musig2.AggregateNonces(pubNonces)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	session, err := musig2.NewSession(aggKey, aggNonce, msg[:])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	partialSigs := make([]*musig2.PartialSignature, len(privKeys))
+	for i, privKey := range privKeys {
+		partialSigs[i], err = session.Sign(secNonces[i], privKey)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	// Combine the partial signatures and verify the final signature.
+	sig, err := session.Combine(partialSigs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	verified := sig.Verify(msg[:], aggKey.PubKey())
+	fmt.Printf("Signature Verified? %v\n", verified)
+
+	// Output:
+	// Signature Verified? true
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package musig2
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/monetarium/monetarium-node/crypto/blake256"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+)
+
+const (
+	// pubKeySize is the size of a serialized compressed public key.
+	pubKeySize = 33
+
+	// scalarSize is the size of an encoded big endian scalar.
+	scalarSize = 32
+)
+
+var (
+	// tagKeyAggList, tagKeyAggCoef, tagNonceCoef, and tagNonce are the tags
+	// used to domain separate the hashes used throughout the protocol so that
+	// no hash computed for one purpose can be reused for another.
+	tagKeyAggList = []byte("MuSig2-DCRv0/KeyAgg list")
+	tagKeyAggCoef = []byte("MuSig2-DCRv0/KeyAgg coefficient")
+	tagNonceCoef  = []byte("MuSig2-DCRv0/noncecoef")
+	tagNonce      = []byte("MuSig2-DCRv0/nonce")
+)
+
+// taggedHash returns the BLAKE-256 hash of the passed data prefixed by the
+// BLAKE-256 hash of the tag twice.  The double prefix fills an entire block
+// which allows the tag state to be precomputed when desired.
+func taggedHash(tag []byte, data ...[]byte) [32]byte {
+	tagHash := blake256.Sum256(tag)
+	h := blake256.NewHasher256()
+	h.WriteBytes(tagHash[:])
+	h.WriteBytes(tagHash[:])
+	for _, d := range data {
+		h.WriteBytes(d)
+	}
+	return h.Sum256()
+}
+
+// AggregateKey is a public key that is the MuSig2 aggregate of a set of
+// participant public keys.  A signature that verifies against the aggregate
+// public key can only be produced with the cooperation of every participant.
+type AggregateKey struct {
+	pubKey *secp256k1.PublicKey
+	keys   [][pubKeySize]byte
+	coeffs []secp256k1.ModNScalar
+}
+
+// AggregateKeys returns the aggregate of the passed participant public keys.
+//
+// The keys are sorted by their compressed serialization prior to aggregation,
+// so the resulting aggregate key does not depend on the order in which the
+// participants are provided.  Each key is weighted by a coefficient that
+// commits to the entire key set which prevents a participant from choosing
+// its key as a function of the others' keys to take control of the aggregate.
+func AggregateKeys(pubKeys []*secp256k1.PublicKey) (*AggregateKey, error) {
+	if len(pubKeys) == 0 {
+		str := "no public keys to aggregate"
+		return nil, makeError(ErrNoKeys, str)
+	}
+
+	// Sort the serialized keys and reject any duplicates.
+	keys := make([][pubKeySize]byte, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		copy(keys[i][:], pubKey.SerializeCompressed())
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+	for i := 1; i < len(keys); i++ {
+		if keys[i] == keys[i-1] {
+			str := fmt.Sprintf("duplicate public key %x", keys[i][:])
+			return nil, makeError(ErrDuplicateKey, str)
+		}
+	}
+
+	// L = H_list(X_1 || ... || X_n)
+	serializedKeys := make([]byte, 0, len(keys)*pubKeySize)
+	for i := range keys {
+		serializedKeys = append(serializedKeys, keys[i][:]...)
+	}
+	keyList := taggedHash(tagKeyAggList, serializedKeys)
+
+	// a_i = H_coef(L || X_i)
+	// Q = sum(a_i * X_i)
+	coeffs := make([]secp256k1.ModNScalar, len(keys))
+	var q secp256k1.JacobianPoint
+	for i := range keys {
+		coeffHash := taggedHash(tagKeyAggCoef, keyList[:], keys[i][:])
+		coeffs[i].SetBytes(&coeffHash)
+
+		pubKey, err := secp256k1.ParsePubKey(keys[i][:])
+		if err != nil {
+			return nil, err
+		}
+		var x, ax, sum secp256k1.JacobianPoint
+		pubKey.AsJacobian(&x)
+		secp256k1.ScalarMultNonConst(&coeffs[i], &x, &ax)
+		secp256k1.AddNonConst(&q, &ax, &sum)
+		q.Set(&sum)
+	}
+	if isInfinity(&q) {
+		str := "aggregate public key is the point at infinity"
+		return nil, makeError(ErrAggregateKeyInfinity, str)
+	}
+	q.ToAffine()
+
+	return &AggregateKey{
+		pubKey: secp256k1.NewPublicKey(&q.X, &q.Y),
+		keys:   keys,
+		coeffs: coeffs,
+	}, nil
+}
+
+// PubKey returns the aggregate public key.
+func (k *AggregateKey) PubKey() *secp256k1.PublicKey {
+	return k.pubKey
+}
+
+// NumKeys returns the number of participant keys that make up the aggregate.
+func (k *AggregateKey) NumKeys() int {
+	return len(k.keys)
+}
+
+// HasKey returns whether the passed public key is one of the participant keys
+// that make up the aggregate.
+func (k *AggregateKey) HasKey(pubKey *secp256k1.PublicKey) bool {
+	_, ok := k.coefficient(pubKey)
+	return ok
+}
+
+// coefficient returns the key aggregation coefficient for the passed
+// participant public key along with whether or not the key is part of the
+// aggregate.
+func (k *AggregateKey) coefficient(pubKey *secp256k1.PublicKey) (*secp256k1.ModNScalar, bool) {
+	var key [pubKeySize]byte
+	copy(key[:], pubKey.SerializeCompressed())
+	i := sort.Search(len(k.keys), func(i int) bool {
+		return bytes.Compare(k.keys[i][:], key[:]) >= 0
+	})
+	if i == len(k.keys) || k.keys[i] != key {
+		return nil, false
+	}
+	return &k.coeffs[i], true
+}
+
+// isInfinity returns whether the passed point is the point at infinity.
+func isInfinity(p *secp256k1.JacobianPoint) bool {
+	return (p.X.IsZero() && p.Y.IsZero()) || p.Z.IsZero()
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package musig2
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/crypto/blake256"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+)
+
+// testPrivKeys returns the requested number of deterministic private keys.
+func testPrivKeys(n int) []*secp256k1.PrivateKey {
+	privKeys := make([]*secp256k1.PrivateKey, n)
+	for i := range privKeys {
+		seed := blake256.Sum256([]byte{'k', 'e', 'y', byte(i)})
+		privKeys[i] = secp256k1.PrivKeyFromBytes(seed[:])
+	}
+	return privKeys
+}
+
+// testPubKeys returns the public keys for the passed private keys.
+func testPubKeys(privKeys []*secp256k1.PrivateKey) []*secp256k1.PublicKey {
+	pubKeys := make([]*secp256k1.PublicKey, len(privKeys))
+	for i, privKey := range privKeys {
+		pubKeys[i] = privKey.PubKey()
+	}
+	return pubKeys
+}
+
+// testRand returns a deterministic source of randomness for nonce generation.
+func testRand(seed byte) *bytes.Reader {
+	return bytes.NewReader(bytes.Repeat([]byte{seed}, 32))
+}
+
+// TestAggregateKeys ensures key aggregation is independent of the order of
+// the participant keys and rejects invalid key sets.
+func TestAggregateKeys(t *testing.T) {
+	pubKeys := testPubKeys(testPrivKeys(3))
+
+	aggKey, err := AggregateKeys(pubKeys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reversed := []*secp256k1.PublicKey{pubKeys[2], pubKeys[1], pubKeys[0]}
+	aggKey2, err := AggregateKeys(reversed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !aggKey.PubKey().IsEqual(aggKey2.PubKey()) {
+		t.Fatal("aggregate key depends on participant order")
+	}
+	if aggKey.NumKeys() != 3 {
+		t.Fatalf("unexpected number of keys -- got %d, want 3",
+			aggKey.NumKeys())
+	}
+	for i, pubKey := range pubKeys {
+		if !aggKey.HasKey(pubKey) {
+			t.Fatalf("aggregate key is missing participant key %d", i)
+		}
+		if aggKey.PubKey().IsEqual(pubKey) {
+			t.Fatalf("aggregate key is equal to participant key %d", i)
+		}
+	}
+	if aggKey.HasKey(testPubKeys(testPrivKeys(4))[3]) {
+		t.Fatal("aggregate key reports a non-participant key")
+	}
+
+	// Ensure a subset of the keys results in a different aggregate key.
+	subset, err := AggregateKeys(pubKeys[:2])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if subset.PubKey().IsEqual(aggKey.PubKey()) {
+		t.Fatal("aggregate key of subset is equal to the full set")
+	}
+
+	// Ensure empty and duplicate key sets are rejected.
+	if _, err := AggregateKeys(nil); !errors.Is(err, ErrNoKeys) {
+		t.Fatalf("unexpected error -- got %v, want %v", err, ErrNoKeys)
+	}
+	dups := []*secp256k1.PublicKey{pubKeys[0], pubKeys[1], pubKeys[0]}
+	if _, err := AggregateKeys(dups); !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrDuplicateKey)
+	}
+}
+
+// TestSign ensures signing with aggregate keys of various sizes produces
+// partial signatures that verify and combine into a valid Schnorr signature
+// for the aggregate key.
+func TestSign(t *testing.T) {
+	for _, numSigners := range []int{1, 2, 3, 7} {
+		privKeys := testPrivKeys(numSigners)
+		pubKeys := testPubKeys(privKeys)
+		aggKey, err := AggregateKeys(pubKeys)
+		if err != nil {
+			t.Fatalf("%d signers: unexpected error: %v", numSigners, err)
+		}
+
+		// Sign several messages so that both even and odd final nonces are
+		// exercised.
+		for m := 0; m < 8; m++ {
+			msg := blake256.Sum256([]byte{'m', 's', 'g', byte(m)})
+
+			secNonces := make([]*SecretNonce, numSigners)
+			pubNonces := make([]*PublicNonce, numSigners)
+			for i, privKey := range privKeys {
+				secNonces[i], pubNonces[i], err = generateNonce(
+					testRand(byte(m)), privKey, aggKey, msg[:])
+				if err != nil {
+					t.Fatalf("%d signers: unexpected error: %v",
+						numSigners, err)
+				}
+			}
+			aggNonce, err := AggregateNonces(pubNonces)
+			if err != nil {
+				t.Fatalf("%d signers: unexpected error: %v", numSigners,
+					err)
+			}
+			session, err := NewSession(aggKey, aggNonce, msg[:])
+			if err != nil {
+				t.Fatalf("%d signers: unexpected error: %v", numSigners,
+					err)
+			}
+
+			partialSigs := make([]*PartialSignature, numSigners)
+			for i, privKey := range privKeys {
+				partialSigs[i], err = session.Sign(secNonces[i], privKey)
+				if err != nil {
+					t.Fatalf("%d signers: unexpected error: %v",
+						numSigners, err)
+				}
+
+				// Round trip the partial signature through its
+				// serialization prior to verifying it.
+				sig, err := ParsePartialSignature(partialSigs[i].Serialize())
+				if err != nil {
+					t.Fatalf("%d signers: unexpected error: %v",
+						numSigners, err)
+				}
+				err = session.VerifyPartial(sig, pubNonces[i], pubKeys[i])
+				if err != nil {
+					t.Fatalf("%d signers: partial signature %d: %v",
+						numSigners, i, err)
+				}
+			}
+
+			sig, err := session.Combine(partialSigs)
+			if err != nil {
+				t.Fatalf("%d signers: unexpected error: %v", numSigners,
+					err)
+			}
+			if !sig.Verify(msg[:], aggKey.PubKey()) {
+				t.Fatalf("%d signers: signature does not verify",
+					numSigners)
+			}
+		}
+	}
+}
+
+// TestSignErrors ensures misuse of the signing session and invalid partial
+// signatures are detected.
+func TestSignErrors(t *testing.T) {
+	privKeys := testPrivKeys(4)
+	pubKeys := testPubKeys(privKeys)
+	aggKey, err := AggregateKeys(pubKeys[:3])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg := blake256.Sum256([]byte("message"))
+
+	secNonces := make([]*SecretNonce, 4)
+	pubNonces := make([]*PublicNonce, 4)
+	for i, privKey := range privKeys {
+		secNonces[i], pubNonces[i], err = generateNonce(testRand(byte(i)),
+			privKey, aggKey, msg[:])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	aggNonce, err := AggregateNonces(pubNonces[:3])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	session, err := NewSession(aggKey, aggNonce, msg[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Ensure a key that is not part of the aggregate can not sign.
+	_, err = session.Sign(secNonces[3], privKeys[3])
+	if !errors.Is(err, ErrKeyNotInSet) {
+		t.Fatalf("unexpected error -- got %v, want %v", err, ErrKeyNotInSet)
+	}
+
+	// Ensure a nonce generated for another key is rejected.
+	otherNonce, _, err := generateNonce(testRand(0xff), privKeys[1], aggKey,
+		msg[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = session.Sign(otherNonce, privKeys[0])
+	if !errors.Is(err, ErrNonceKeyMismatch) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrNonceKeyMismatch)
+	}
+
+	// Ensure a secret nonce can only be used once.
+	sig0, err := session.Sign(secNonces[0], privKeys[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = session.Sign(secNonces[0], privKeys[0])
+	if !errors.Is(err, ErrNonceReused) {
+		t.Fatalf("unexpected error -- got %v, want %v", err, ErrNonceReused)
+	}
+
+	// Ensure partial signatures that do not match the signer or its nonce
+	// are rejected.
+	err = session.VerifyPartial(sig0, pubNonces[1], pubKeys[0])
+	if !errors.Is(err, ErrPartialSigInvalid) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrPartialSigInvalid)
+	}
+	err = session.VerifyPartial(sig0, pubNonces[0], pubKeys[1])
+	if !errors.Is(err, ErrPartialSigInvalid) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrPartialSigInvalid)
+	}
+	err = session.VerifyPartial(sig0, pubNonces[0], pubKeys[3])
+	if !errors.Is(err, ErrKeyNotInSet) {
+		t.Fatalf("unexpected error -- got %v, want %v", err, ErrKeyNotInSet)
+	}
+
+	// Ensure a combined signature missing a participant is rejected.
+	sig1, err := session.Sign(secNonces[1], privKeys[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = session.Combine([]*PartialSignature{sig0, sig1})
+	if !errors.Is(err, ErrFinalSigInvalid) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrFinalSigInvalid)
+	}
+
+	// Ensure messages of the wrong size are rejected.
+	_, err = NewSession(aggKey, aggNonce, msg[:31])
+	if !errors.Is(err, ErrInvalidHashLen) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrInvalidHashLen)
+	}
+	_, _, err = GenerateNonce(privKeys[0], aggKey, msg[:31])
+	if !errors.Is(err, ErrInvalidHashLen) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrInvalidHashLen)
+	}
+
+	// Ensure an empty nonce set is rejected.
+	if _, err := AggregateNonces(nil); !errors.Is(err, ErrNoNonces) {
+		t.Fatalf("unexpected error -- got %v, want %v", err, ErrNoNonces)
+	}
+}
+
+// TestParse ensures public nonces and partial signatures are parsed and
+// validated as expected.
+func TestParse(t *testing.T) {
+	privKeys := testPrivKeys(1)
+	aggKey, err := AggregateKeys(testPubKeys(privKeys))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg := blake256.Sum256([]byte("message"))
+	_, pubNonce, err := GenerateNonce(privKeys[0], aggKey, msg[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		b    []byte
+		err  error
+	}{{
+		name: "valid",
+		b:    pubNonce[:],
+	}, {
+		name: "short",
+		b:    pubNonce[:PublicNonceSize-1],
+		err:  ErrInvalidPubNonce,
+	}, {
+		name: "uncompressed first point",
+		b:    append([]byte{0x04}, pubNonce[1:]...),
+		err:  ErrInvalidPubNonce,
+	}, {
+		name: "invalid second point",
+		b:    append(pubNonce[:pubKeySize:pubKeySize], make([]byte, pubKeySize)...),
+		err:  ErrInvalidPubNonce,
+	}}
+	for _, test := range tests {
+		_, err := ParsePublicNonce(test.b)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: unexpected error -- got %v, want %v", test.name,
+				err, test.err)
+		}
+	}
+
+	sigTests := []struct {
+		name string
+		b    []byte
+		err  error
+	}{{
+		name: "valid",
+		b:    bytes.Repeat([]byte{0x01}, PartialSignatureSize),
+	}, {
+		name: "long",
+		b:    make([]byte, PartialSignatureSize+1),
+		err:  ErrInvalidPartialSigLen,
+	}, {
+		name: "group order",
+		b: []byte{
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe,
+			0xba, 0xae, 0xdc, 0xe6, 0xaf, 0x48, 0xa0, 0x3b,
+			0xbf, 0xd2, 0x5e, 0x8c, 0xd0, 0x36, 0x41, 0x41,
+		},
+		err: ErrPartialSigTooBig,
+	}}
+	for _, test := range sigTests {
+		sig, err := ParsePartialSignature(test.b)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: unexpected error -- got %v, want %v", test.name,
+				err, test.err)
+			continue
+		}
+		if err == nil && !bytes.Equal(sig.Serialize(), test.b) {
+			t.Errorf("%s: mismatched serialization -- got %x, want %x",
+				test.name, sig.Serialize(), test.b)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package musig2
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+)
+
+// PublicNonceSize is the size of a serialized public nonce.
+const PublicNonceSize = 2 * pubKeySize
+
+// PublicNonce is the pair of compressed points R1 || R2 that a participant
+// shares with the other participants during the first round of signing.  The
+// aggregate of all participants' public nonces is encoded the same way.
+type PublicNonce [PublicNonceSize]byte
+
+// points parses and returns the two points encoded by the public nonce.
+func (n *PublicNonce) points() (r1, r2 secp256k1.JacobianPoint, err error) {
+	p1, err := secp256k1.ParsePubKey(n[:pubKeySize])
+	if err != nil {
+		str := fmt.Sprintf("invalid first nonce point: %v", err)
+		return r1, r2, makeError(ErrInvalidPubNonce, str)
+	}
+	p2, err := secp256k1.ParsePubKey(n[pubKeySize:])
+	if err != nil {
+		str := fmt.Sprintf("invalid second nonce point: %v", err)
+		return r1, r2, makeError(ErrInvalidPubNonce, str)
+	}
+	p1.AsJacobian(&r1)
+	p2.AsJacobian(&r2)
+	return r1, r2, nil
+}
+
+// ParsePublicNonce parses a serialized public nonce and ensures both points it
+// encodes are valid.
+func ParsePublicNonce(b []byte) (*PublicNonce, error) {
+	if len(b) != PublicNonceSize {
+		str := fmt.Sprintf("malformed public nonce: wrong size %d, want %d",
+			len(b), PublicNonceSize)
+		return nil, makeError(ErrInvalidPubNonce, str)
+	}
+	var n PublicNonce
+	copy(n[:], b)
+	if _, _, err := n.points(); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// SecretNonce is the secret counterpart of a public nonce.  It must never be
+// shared and must only ever be used to produce a single partial signature.
+// Signing with it zeroes it so it can not be reused.
+type SecretNonce struct {
+	k1, k2 secp256k1.ModNScalar
+	pubKey [pubKeySize]byte
+	used   bool
+}
+
+// GenerateNonce generates a fresh secret nonce and the corresponding public
+// nonce for the participant with the passed private key to use when signing
+// the passed message with the aggregate key.
+//
+// The nonce is derived from fresh randomness in addition to the private key,
+// aggregate key, and message, so a weak source of randomness alone does not
+// cause nonces to repeat across different messages.
+func GenerateNonce(privKey *secp256k1.PrivateKey, aggKey *AggregateKey,
+	msg []byte) (*SecretNonce, *PublicNonce, error) {
+
+	return generateNonce(rand.Reader, privKey, aggKey, msg)
+}
+
+// generateNonce is the implementation of GenerateNonce with a configurable
+// source of randomness to allow deterministic tests.
+func generateNonce(randSource io.Reader, privKey *secp256k1.PrivateKey,
+	aggKey *AggregateKey, msg []byte) (*SecretNonce, *PublicNonce, error) {
+
+	if len(msg) != scalarSize {
+		str := fmt.Sprintf("wrong size for message (got %v, want %v)",
+			len(msg), scalarSize)
+		return nil, nil, makeError(ErrInvalidHashLen, str)
+	}
+
+	var randBytes [32]byte
+	if _, err := io.ReadFull(randSource, randBytes[:]); err != nil {
+		return nil, nil, err
+	}
+	privKeyBytes := privKey.Key.Bytes()
+	aggPubKey := aggKey.PubKey().SerializeCompressed()
+
+	// k_i = H_nonce(rand || d || Q || m || i) for i in {1, 2}
+	var secNonce SecretNonce
+	for i, k := range []*secp256k1.ModNScalar{&secNonce.k1, &secNonce.k2} {
+		hash := taggedHash(tagNonce, randBytes[:], privKeyBytes[:],
+			aggPubKey, msg, []byte{byte(i + 1)})
+		k.SetBytes(&hash)
+		zeroArray(&hash)
+		if k.IsZero() {
+			zeroArray(&privKeyBytes)
+			str := "generated nonce is zero"
+			return nil, nil, makeError(ErrNonceInfinity, str)
+		}
+	}
+	zeroArray(&randBytes)
+	zeroArray(&privKeyBytes)
+	copy(secNonce.pubKey[:], privKey.PubKey().SerializeCompressed())
+
+	// R_i = k_i*G for i in {1, 2}
+	var pubNonce PublicNonce
+	var r1, r2 secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&secNonce.k1, &r1)
+	secp256k1.ScalarBaseMultNonConst(&secNonce.k2, &r2)
+	putPoint(pubNonce[:pubKeySize], &r1)
+	putPoint(pubNonce[pubKeySize:], &r2)
+
+	return &secNonce, &pubNonce, nil
+}
+
+// AggregateNonces returns the aggregate of the passed public nonces from every
+// participant.
+func AggregateNonces(pubNonces []*PublicNonce) (*PublicNonce, error) {
+	if len(pubNonces) == 0 {
+		str := "no public nonces to aggregate"
+		return nil, makeError(ErrNoNonces, str)
+	}
+
+	// R1 = sum(R1_i), R2 = sum(R2_i)
+	var r1, r2 secp256k1.JacobianPoint
+	for _, pubNonce := range pubNonces {
+		p1, p2, err := pubNonce.points()
+		if err != nil {
+			return nil, err
+		}
+		var sum secp256k1.JacobianPoint
+		secp256k1.AddNonConst(&r1, &p1, &sum)
+		r1.Set(&sum)
+		secp256k1.AddNonConst(&r2, &p2, &sum)
+		r2.Set(&sum)
+	}
+	if isInfinity(&r1) || isInfinity(&r2) {
+		str := "aggregate nonce is the point at infinity"
+		return nil, makeError(ErrNonceInfinity, str)
+	}
+
+	var aggNonce PublicNonce
+	putPoint(aggNonce[:pubKeySize], &r1)
+	putPoint(aggNonce[pubKeySize:], &r2)
+	return &aggNonce, nil
+}
+
+// putPoint serializes the passed point into the passed slice in compressed
+// format.  The slice must be at least pubKeySize bytes.
+func putPoint(b []byte, p *secp256k1.JacobianPoint) {
+	p.ToAffine()
+	copy(b, secp256k1.NewPublicKey(&p.X, &p.Y).SerializeCompressed())
+}
+
+// zeroArray zeroes the memory of a scalar array.
+func zeroArray(a *[scalarSize]byte) {
+	for i := 0; i < scalarSize; i++ {
+		a[i] = 0x00
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package musig2
+
+import (
+	"fmt"
+
+	"github.com/monetarium/monetarium-node/crypto/blake256"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1/schnorr"
+)
+
+// PartialSignatureSize is the size of a serialized partial signature.
+const PartialSignatureSize = scalarSize
+
+// PartialSignature is a single participant's share of a signature.  The
+// partial signatures of every participant are combined to produce the final
+// signature.
+type PartialSignature struct {
+	s secp256k1.ModNScalar
+}
+
+// Serialize returns the partial signature as a 32-byte big endian scalar.
+func (sig *PartialSignature) Serialize() []byte {
+	b := sig.s.Bytes()
+	return b[:]
+}
+
+// ParsePartialSignature parses a serialized partial signature.
+func ParsePartialSignature(b []byte) (*PartialSignature, error) {
+	if len(b) != PartialSignatureSize {
+		str := fmt.Sprintf("malformed partial signature: wrong size %d, "+
+			"want %d", len(b), PartialSignatureSize)
+		return nil, makeError(ErrInvalidPartialSigLen, str)
+	}
+	var sig PartialSignature
+	if overflow := sig.s.SetByteSlice(b); overflow {
+		str := "invalid partial signature: s >= group order"
+		return nil, makeError(ErrPartialSigTooBig, str)
+	}
+	return &sig, nil
+}
+
+// Session holds the state shared by all participants for the second round of
+// signing a specific message with an aggregate key once every participant's
+// public nonce has been aggregated.
+type Session struct {
+	aggKey      *AggregateKey
+	msg         [scalarSize]byte
+	b           secp256k1.ModNScalar
+	e           secp256k1.ModNScalar
+	r           secp256k1.FieldVal
+	negateNonce bool
+}
+
+// NewSession creates the signing session for the passed message, aggregate
+// key, and aggregate nonce.  Every participant arrives at the same session
+// given the same inputs.
+//
+// An error with ErrSchnorrHashValue is returned in the extremely unlikely
+// event the resulting signature challenge does not fit in a scalar, in which
+// case all participants must generate fresh nonces and start over.
+func NewSession(aggKey *AggregateKey, aggNonce *PublicNonce, msg []byte) (*Session, error) {
+	if len(msg) != scalarSize {
+		str := fmt.Sprintf("wrong size for message (got %v, want %v)",
+			len(msg), scalarSize)
+		return nil, makeError(ErrInvalidHashLen, str)
+	}
+	r1, r2, err := aggNonce.points()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Session{aggKey: aggKey}
+	copy(s.msg[:], msg)
+
+	// b = H_noncecoef(R1 || R2 || Q || m)
+	bHash := taggedHash(tagNonceCoef, aggNonce[:],
+		aggKey.PubKey().SerializeCompressed(), msg)
+	s.b.SetBytes(&bHash)
+
+	// R = R1 + b*R2
+	var bR2, R secp256k1.JacobianPoint
+	secp256k1.ScalarMultNonConst(&s.b, &r2, &bR2)
+	secp256k1.AddNonConst(&r1, &bR2, &R)
+	if isInfinity(&R) {
+		str := "final nonce is the point at infinity"
+		return nil, makeError(ErrNonceInfinity, str)
+	}
+
+	// The EC-Schnorr-DCRv0 scheme requires R.y to be even, so every
+	// participant negates their effective nonce when it is odd.
+	R.ToAffine()
+	s.negateNonce = R.Y.IsOdd()
+	s.r.Set(&R.X).Normalize()
+
+	// e = BLAKE-256(r || m)
+	var commitmentInput [scalarSize * 2]byte
+	s.r.PutBytesUnchecked(commitmentInput[0:scalarSize])
+	copy(commitmentInput[scalarSize:], msg)
+	commitment := blake256.Sum256(commitmentInput[:])
+	if overflow := s.e.SetBytes(&commitment); overflow != 0 {
+		str := "hash of (R || m) too big"
+		return nil, makeError(ErrSchnorrHashValue, str)
+	}
+
+	return s, nil
+}
+
+// Sign produces the partial signature of the participant with the passed
+// private key using the secret nonce it generated for the session.  The secret
+// nonce is zeroed and may not be used again regardless of the result.
+func (s *Session) Sign(secNonce *SecretNonce, privKey *secp256k1.PrivateKey) (*PartialSignature, error) {
+	if secNonce.used {
+		str := "secret nonce has already been used"
+		return nil, makeError(ErrNonceReused, str)
+	}
+	k1, k2 := secNonce.k1, secNonce.k2
+	secNonce.k1.Zero()
+	secNonce.k2.Zero()
+	secNonce.used = true
+	defer k1.Zero()
+	defer k2.Zero()
+
+	pubKey := privKey.PubKey()
+	var pubKeyBytes [pubKeySize]byte
+	copy(pubKeyBytes[:], pubKey.SerializeCompressed())
+	if pubKeyBytes != secNonce.pubKey {
+		str := "secret nonce was generated for a different key"
+		return nil, makeError(ErrNonceKeyMismatch, str)
+	}
+	a, ok := s.aggKey.coefficient(pubKey)
+	if !ok {
+		str := fmt.Sprintf("public key %x is not part of the aggregate key",
+			pubKeyBytes[:])
+		return nil, makeError(ErrKeyNotInSet, str)
+	}
+
+	// k = k1 + b*k2, negated when R.y is odd
+	k := new(secp256k1.ModNScalar).Mul2(&s.b, &k2).Add(&k1)
+	defer k.Zero()
+	if s.negateNonce {
+		k.Negate()
+	}
+
+	// s_i = k - e*a_i*d
+	var sig PartialSignature
+	sig.s.Mul2(&s.e, a).Mul(&privKey.Key).Negate().Add(k)
+	return &sig, nil
+}
+
+// VerifyPartial verifies the partial signature produced by the participant
+// with the passed public key and public nonce.  It allows a misbehaving
+// participant to be identified when the combined signature is invalid.
+func (s *Session) VerifyPartial(sig *PartialSignature, pubNonce *PublicNonce,
+	pubKey *secp256k1.PublicKey) error {
+
+	a, ok := s.aggKey.coefficient(pubKey)
+	if !ok {
+		str := fmt.Sprintf("public key %x is not part of the aggregate key",
+			pubKey.SerializeCompressed())
+		return makeError(ErrKeyNotInSet, str)
+	}
+	r1, r2, err := pubNonce.points()
+	if err != nil {
+		return err
+	}
+
+	// R_i = R1_i + b*R2_i, negated when R.y is odd
+	var bR2, Ri secp256k1.JacobianPoint
+	secp256k1.ScalarMultNonConst(&s.b, &r2, &bR2)
+	secp256k1.AddNonConst(&r1, &bR2, &Ri)
+	if isInfinity(&Ri) {
+		str := "effective nonce is the point at infinity"
+		return makeError(ErrNonceInfinity, str)
+	}
+	if s.negateNonce {
+		Ri.ToAffine()
+		Ri.Y.Negate(1).Normalize()
+	}
+
+	// s_i*G + e*a_i*X_i == R_i
+	var X, eaX, sG, result secp256k1.JacobianPoint
+	pubKey.AsJacobian(&X)
+	ea := new(secp256k1.ModNScalar).Mul2(&s.e, a)
+	secp256k1.ScalarMultNonConst(ea, &X, &eaX)
+	secp256k1.ScalarBaseMultNonConst(&sig.s, &sG)
+	secp256k1.AddNonConst(&sG, &eaX, &result)
+	if !result.EquivalentNonConst(&Ri) {
+		str := fmt.Sprintf("partial signature by %x does not verify",
+			pubKey.SerializeCompressed())
+		return makeError(ErrPartialSigInvalid, str)
+	}
+	return nil
+}
+
+// Combine combines the partial signatures of every participant into the final
+// EC-Schnorr-DCRv0 signature and ensures it is valid for the aggregate key.
+func (s *Session) Combine(sigs []*PartialSignature) (*schnorr.Signature, error) {
+	// s = sum(s_i)
+	var sum secp256k1.ModNScalar
+	for _, sig := range sigs {
+		sum.Add(&sig.s)
+	}
+	sig := schnorr.NewSignature(&s.r, &sum)
+	if !sig.Verify(s.msg[:], s.aggKey.PubKey()) {
+		str := "combined signature does not verify against the aggregate key"
+		return nil, makeError(ErrFinalSigInvalid, str)
+	}
+	return sig, nil
+}
@@ -21,8 +21,10 @@ The sort order for transaction inputs is defined as follows:
   - Previous output index in ascending order
 
 The sort order for transaction outputs is defined as follows:
-- Amount in ascending order
-- Public key script version in ascending order
-- Raw public key script bytes lexicographically in ascending order
+  - Coin type in ascending order (VAR first, followed by each SKA coin type)
+  - Amount in ascending order, where SKA amounts are compared as arbitrary
+    precision integers
+  - Public key script version in ascending order
+  - Raw public key script bytes lexicographically in ascending order
 */
 package txsort
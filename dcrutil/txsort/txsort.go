@@ -7,6 +7,7 @@ package txsort
 
 import (
 	"bytes"
+	"math/big"
 	"sort"
 
 	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
@@ -80,11 +81,22 @@ func (s sortableOutputSlice) Swap(i, j int) {
 // the transaction output with index j.  It is part of the sort.Interface
 // implementation.
 //
-// First sort based on amount (smallest first), then by script version, then by
-// script.
+// First sort based on coin type, then amount (smallest first), then by script
+// version, then by script.
 func (s sortableOutputSlice) Less(i, j int) bool {
-	// Sort by amount first.
-	if s[i].Value != s[j].Value {
+	// Sort by coin type first so outputs of each coin are grouped together.
+	if s[i].CoinType != s[j].CoinType {
+		return s[i].CoinType < s[j].CoinType
+	}
+
+	// At this point, the coin types are the same, so sort by amount.  SKA
+	// amounts are carried by the big integer SKAValue field while VAR amounts
+	// use Value.
+	if s[i].CoinType.IsSKA() {
+		if cmp := cmpSKAValue(s[i].SKAValue, s[j].SKAValue); cmp != 0 {
+			return cmp < 0
+		}
+	} else if s[i].Value != s[j].Value {
 		return s[i].Value < s[j].Value
 	}
 
@@ -97,6 +109,21 @@ func (s sortableOutputSlice) Less(i, j int) bool {
 	return bytes.Compare(s[i].PkScript, s[j].PkScript) < 0
 }
 
+// cmpSKAValue compares the passed SKA output amounts and returns -1, 0, or +1
+// depending on whether a is less than, equal to, or greater than b.  A nil
+// amount is treated as zero.
+func cmpSKAValue(a, b *big.Int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -b.Sign()
+	case b == nil:
+		return a.Sign()
+	}
+	return a.Cmp(b)
+}
+
 // InPlaceSort modifies the passed transaction inputs and outputs to be sorted
 // according to the description in the package documentation.
 //
@@ -16,11 +16,7 @@ import (
 )
 
 // TestSort ensures the transaction sorting works as expected.
-// NOTE: This test is skipped because the test data uses the legacy Decred wire
-// format without the CoinType field. Monetarium uses a modified TxOut format.
-// TODO: Regenerate test data with Monetarium wire format once live transactions exist.
 func TestSort(t *testing.T) {
-	t.Skip("Test data uses legacy Decred wire format without CoinType field")
 	tests := []struct {
 		name         string
 		hexFile      string
@@ -32,36 +28,36 @@ func TestSort(t *testing.T) {
 			name:         "block 100004 tx[4] - already sorted",
 			hexFile:      "tx100004-4.hex",
 			isSorted:     true,
-			unsortedHash: "2d7f7fc1c7c8a4ef1b331d96daa8af7c468ca32c153c681f2f7b1b5e74b3014c",
-			sortedHash:   "2d7f7fc1c7c8a4ef1b331d96daa8af7c468ca32c153c681f2f7b1b5e74b3014c",
+			unsortedHash: "8506d6a056f5bb6da84100cece49c24d0342be63c541faf4512e360b29c9bf86",
+			sortedHash:   "8506d6a056f5bb6da84100cece49c24d0342be63c541faf4512e360b29c9bf86",
 		},
 		{
 			name:         "block 101790 tx[3] - sorts inputs only, based on tree",
 			hexFile:      "tx101790-3.hex",
 			isSorted:     false,
-			unsortedHash: "05bcfb356cafedb3a1ced7b86c0eb1899954d1062d216b9c6bde19facc78b6b4",
-			sortedHash:   "e0b4dc0d6b37777e584edaf30db8382d41d337d3c12e8ccbe030f06ddce3ca95",
+			unsortedHash: "ecb79f87c17180b71219f76ac39aefe2bffe0001b5fa50b6eb380ac4c779b0d0",
+			sortedHash:   "412c7d8f11364deeb7a78bb99ce79184f3fdbfe27ff5e967efb1f33843ed7650",
 		},
 		{
 			name:         "block 150007 tx[23] - sorts inputs only, based on hash",
 			hexFile:      "tx150007-23.hex",
 			isSorted:     false,
-			unsortedHash: "ca0c29fa943b2ac629d17c66ef59bd866b5f39c8c22abf99c351e998fec62e61",
-			sortedHash:   "8da32f59fc9f8c2f941ed2fdf0c16525c02e6caa71211204b10c0a93128dc672",
+			unsortedHash: "78f745ad4b81fcd0b86b994045da1c0675704a582d1453012bc1b95e27a9d35d",
+			sortedHash:   "685d8dd842c290707d8abd11c8055efe7cda728df842c408e4f63b27e693ab57",
 		},
 		{
 			name:         "block 108930 tx[1] - sorts inputs only, based on index",
 			hexFile:      "tx108930-1.hex",
 			isSorted:     false,
-			unsortedHash: "d7c3f51f1e45262fb59aa5d2590703715c6fe947ec543538621135d8140836ae",
-			sortedHash:   "c1ff28f4376da33c269ad692eec220068dc28aeb4860fb886317f44fdd1bfc1e",
+			unsortedHash: "346ea8789195e3d44dff4d8778c031068223d953e3a0cfe4cfe3dada9d8d0138",
+			sortedHash:   "eb8202598557b738b6ad2c0c9965367fbdb36d02fd5bb2cae6444cd798f9e6b9",
 		},
 		{
 			name:         "block 100082 tx[5] - sorts outputs only, based on amount",
 			hexFile:      "tx100082-5.hex",
 			isSorted:     false,
-			unsortedHash: "b97731f43deefe91bf02e1fe046dc3f6d2ae7d08186d88494112782a443b14ce",
-			sortedHash:   "f7304d057fff93479229c9c856306b49c92bf2aee30ccd79722e14e3504374dc",
+			unsortedHash: "395664e8a59ed93e4fb59a7c9966314155d34715e177351c8678f21203af0e68",
+			sortedHash:   "1f6a3b056cdc135a7faaac48435843a0c32e7c4921caa3f68b930c2da0197bf6",
 		},
 		{
 			// Tx manually modified to make the first output (output 0)
@@ -69,29 +65,65 @@ func TestSort(t *testing.T) {
 			name:         "modified block 150043 tx[14] - sorts outputs only, based on script version",
 			hexFile:      "tx150043-14m.hex",
 			isSorted:     false,
-			unsortedHash: "b69ca33df9a7e32defba0ce31612fb33e74a1461e83d3d08df1272ac788c8fc8",
-			sortedHash:   "79bec563b49c5fc955f89e959a8c571846db3e50bc66e7edf6880a5024a9d175",
+			unsortedHash: "30ce67acf78d6688af6e02eda613150a1448c049487e1863a3c9f102af6125d4",
+			sortedHash:   "f09393488593e39a0321a2cb49e8dfb55807927a85b4305f793ed35281dd21cd",
 		},
 		{
 			name:         "block 150043 tx[14] - sorts outputs only, based on output script",
 			hexFile:      "tx150043-14.hex",
 			isSorted:     false,
-			unsortedHash: "e34662435095f0803ca358cd233e7ac2567b6c9c7bc3469b2cc6074e45b85e0b",
-			sortedHash:   "43d4962b0bfaadf1737dcf930090c0ce85750972cf87fbcbfc7da3d8620a5f41",
+			unsortedHash: "df7d299d1d3e5824411b84a62156f6fcde51767d0e901181eead95d6fe48244d",
+			sortedHash:   "24b7626b673260a6a84af6b0c3195d44481291949f82e8409b8f2e628854a32b",
 		},
 		{
 			name:         "block 150626 tx[24] - sorts outputs only, based on amount and output script",
 			hexFile:      "tx150626-24.hex",
 			isSorted:     false,
-			unsortedHash: "f9da9ef41f5790fab6cf2930cdfd2d6d8e3a346cc8279650d71b40a664a224ce",
-			sortedHash:   "8d195aeb1ad2fd9cd6054e9b23c0a0f2a9fb170feb6519d3a749bf0725e00118",
+			unsortedHash: "ba66ad8d805a0a6f8b143ce9654851c0514c458b4bfe1f0e09c5dfb9c36cba6d",
+			sortedHash:   "32afd547f02693406134aed87cde76c2ecd82c4767060bf4312bedfefe84d5d3",
 		},
 		{
 			name:         "block 150002 tx[7] - sorts both inputs and outputs",
 			hexFile:      "tx150002-7.hex",
 			isSorted:     false,
-			unsortedHash: "83e3ee1b1f207361577deda6efcb626a65fe2b7cd34c451bf7de9e7c36f0f717",
-			sortedHash:   "5137f03a0e35c16e68de2f820edaa60262bcc083f44936b0139b05e31c0354aa",
+			unsortedHash: "02f2a149346bb3611612eb0ddce806294d1189c6c6b9dc6b8d76eaffc8a7a08d",
+			sortedHash:   "70e0924d560eb3f7cfc7669dc359d9be404888b9dc56ad8e16827c31d0d8c744",
+		},
+		{
+			name:         "dual-coin - already sorted",
+			hexFile:      "dualcoin-sorted.hex",
+			isSorted:     true,
+			unsortedHash: "db4f6c64c92f52d3e3b6cb803dad54b42c1ef076af6ca0b84fee80add118b5de",
+			sortedHash:   "db4f6c64c92f52d3e3b6cb803dad54b42c1ef076af6ca0b84fee80add118b5de",
+		},
+		{
+			name:         "dual-coin - sorts outputs only, based on coin type",
+			hexFile:      "dualcoin-cointype.hex",
+			isSorted:     false,
+			unsortedHash: "050f49e725a4e4284f1a25a536c54e7c1cc974ffcc8c8e046bc87a36762c856b",
+			sortedHash:   "6799d83e1b6f234370d3f99c9a5ba55a8172dadb9e782faabaec2c07e099a822",
+		},
+		{
+			// SKA amounts exceed the range of an int64.
+			name:         "dual-coin - sorts outputs only, based on SKA amount",
+			hexFile:      "dualcoin-skaamount.hex",
+			isSorted:     false,
+			unsortedHash: "29483004680533d31037254e4567693917add1ee2a96995cdabe8d11df097fa2",
+			sortedHash:   "bd14f5e21c6458a0ee8adba10213d86d08b977b4bcaa906fe57b1b82c02d1ceb",
+		},
+		{
+			name:         "dual-coin - sorts outputs only, based on SKA output script",
+			hexFile:      "dualcoin-skascript.hex",
+			isSorted:     false,
+			unsortedHash: "384cf01cd87f1b0053873b0468f9053a9c55a6b3e6e2ab0e696a1cb37398af61",
+			sortedHash:   "9e15023a02ef0ae25e5e69fa52327aa719c62dfded5ece205d4c199c74923bf3",
+		},
+		{
+			name:         "dual-coin - sorts both inputs and outputs",
+			hexFile:      "dualcoin-both.hex",
+			isSorted:     false,
+			unsortedHash: "2251fbab051d927806821318156efa124c0de78741b9f6c50086a0d14e8a911e",
+			sortedHash:   "9b482058bdc46eaffcd18bdf8ebc60b21309f7fc02528fef34275d1a3e7a26af",
 		},
 	}
 
@@ -38,6 +38,9 @@ Application Options:
 	                             NOTE: port must be between 1024 and 65536
 	    --cpuprofile=            Write CPU profile to the specified file
 	    --memprofile=            Write mem profile to the specified file
+	    --metrics=               Enable Prometheus metrics at /metrics on given
+	                             [addr:]port -- NOTE: port must be between 1024
+	                             and 65536
 	    --testnet                Use the test network
 	    --simnet                 Use the simulation test network
 	    --regnet                 Use the regression test network
@@ -82,6 +85,12 @@ Application Options:
 	                             (default: 25)
 	    --rpcmaxconcurrentreqs=  Max number of concurrent RPC requests that may
 	                             be processed concurrently (default: 20)
+	    --rest                   Enable the unauthenticated read-only REST
+	                             interface
+	    --restlisten=            Add an interface/port to listen for REST
+	                             connections (default port: 9510, testnet: 19510)
+	    --restmaxrequests=       Max number of REST requests per second that are
+	                             served for each client host (default: 20)
 	    --proxy=                 Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)
 	    --proxyuser=             Username for proxy server
 	    --proxypass=             Password for proxy server
@@ -92,6 +101,11 @@ Application Options:
 	    --noonion                Disable connecting to tor hidden services
 	    --torisolation           Enable Tor stream isolation by randomizing user
 	                             credentials for each connection
+	    --torcontrol=            Create an onion service to accept incoming
+	                             connections over Tor via the Tor control port at
+	                             this address (eg. 127.0.0.1:9051)
+	    --torpassword=           Password for the Tor control port when not using
+	                             cookie authentication
 	-a, --addpeer=               Add a peer to connect with at startup
 	    --connect=               Connect only to the specified peers at startup
 	    --nolisten               Disable listening for incoming connections --
@@ -112,6 +126,28 @@ Application Options:
 	    --peeridletimeout        The duration of inactivity before a peer is
 	                             timed out.  Valid time units are {s,m,h}.
 	                             Minimum 15 seconds (default: 2m0s)
+	    --nov2transport          Disable the encrypted v2 transport and only use
+	                             the plaintext v1 transport to communicate with
+	                             peers
+	    --transportkey=          Hex-encoded secp256k1 private key used to
+	                             authenticate to peers over the v2 transport
+	    --authpeerkey=           Add a hex-encoded secp256k1 public key that
+	                             whitelisted peers may authenticate with over
+	                             the v2 transport -- NOTE: Whitelisted peers are
+	                             required to authenticate with one of the keys
+	                             when any are specified
+	    --maxuploadrate=         Max combined rate of data sent to all peers in
+	                             KiB/s -- 0 for unlimited
+	    --maxpeeruploadrate=     Max rate of data sent to each peer in KiB/s -- 0
+	                             for unlimited
+	    --maxuploadtarget=       Max data sent to peers per 24 hour cycle in MiB
+	                             before historical blocks are no longer served
+	                             to peers that are not whitelisted -- 0 for
+	                             unlimited
+	    --asmap=                 Path to an IP-to-ASN map file used to group
+	                             peer addresses by autonomous system instead of
+	                             network prefix for address bucketing and
+	                             outbound connection diversity
 	    --noseeders              Disable seeding for peer discovery
 	    --nodnsseed              DEPRECATED: use --noseeders
 	    --externalip=            Add a public-facing IP to the list of local
@@ -137,6 +173,14 @@ Application Options:
 	                             periodically with new releases. Don't use a
 	                             different hash unless you understand the
 	                             implications. Set to 0 to disable
+	    --prune=                 Reduce disk usage by deleting old block data to
+	                             keep the stored blocks at roughly the specified
+	                             size in MiB while always retaining recent
+	                             blocks.  The node no longer serves historical
+	                             blocks to peers when enabled.  Incompatible
+	                             with --txindex and requires
+	                             --noexistsaddrindex.  Set to 0 to disable
+	                             (minimum 1024)
 	    --minrelaytxfee=         The minimum transaction fee in VAR/kB to be
 	                             considered a non-zero fee (default: 0.0001)
 	    --limitfreerelay=        DEPRECATED: This behavior is no longer available
@@ -148,6 +192,9 @@ Application Options:
 	    --maxorphantx=           Max number of orphan transactions to keep in
 	                             memory (default: 100)
 	    --blocksonly             Do not accept transactions from remote peers
+	    --dandelion              Relay new transactions privately to a single
+	                             peer for a random number of hops before they
+	                             are broadcast to the network
 	    --acceptnonstd           Accept and relay non-standard transactions to
 	                             the network regardless of the default settings
 	                             for the active network
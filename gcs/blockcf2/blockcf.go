@@ -333,6 +333,10 @@ func Regular(block *wire.MsgBlock, prevScripts PrevScripter) (*gcs.FilterV2, err
 	// - Treasury spends:
 	//   - Output scripts that make payments
 	//
+	// Notice that fee distributions (SSFee) are intentionally not committed to.
+	// The filters are committed to by the block header commitments, so adding
+	// any data for them would be a consensus change.
+	//
 	// Output scripts are handled specially for stake transactions by slicing
 	// off the stake opcode tag (OP_SS*).  This tag always appears as the first
 	// byte of the script and removing it allows users of the filter to only
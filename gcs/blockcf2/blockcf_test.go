@@ -0,0 +1,100 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockcf2
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/blockchain/stake"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// noPrevScripts implements the PrevScripter interface without any previous
+// output scripts.
+type noPrevScripts struct{}
+
+// PrevScript always returns false since there are no previous output scripts.
+//
+// This is part of the PrevScripter interface.
+func (noPrevScripts) PrevScript(*wire.OutPoint) (uint16, []byte, bool) {
+	return 0, nil, false
+}
+
+// TestRegularSSFee ensures fee distribution transactions do not change the
+// contents of version 2 filters since the filters are committed to by the block
+// header commitments.
+func TestRegularSSFee(t *testing.T) {
+	p2pkh := func(b byte) []byte {
+		return append(append([]byte{0x76, 0xa9, 0x14}, bytes.Repeat([]byte{b},
+			20)...), 0x88, 0xac)
+	}
+	ssFeeTx := func(prevOut wire.OutPoint, ska bool, marker []byte) *wire.MsgTx {
+		tx := wire.NewMsgTx()
+		tx.Version = 3
+		tx.AddTxIn(wire.NewTxIn(&prevOut, 0, nil))
+		tx.AddTxOut(wire.NewTxOut(1000, p2pkh(0x02)))
+		tx.AddTxOut(wire.NewTxOut(0, marker))
+		if ska {
+			for _, txOut := range tx.TxOut {
+				txOut.CoinType = 1
+			}
+		}
+		return tx
+	}
+	nullOut := wire.OutPoint{Index: wire.MaxPrevOutIndex}
+	realOut := wire.OutPoint{Hash: chainhash.Hash{0x01}, Tree: wire.TxTreeStake}
+
+	tests := []struct {
+		name string
+		tx   *wire.MsgTx
+	}{{
+		name: "staker fee distribution with null input",
+		tx: ssFeeTx(nullOut, false,
+			stake.CreateStakerSSFeeMarker(100, 0)),
+	}, {
+		name: "staker fee distribution augmenting an existing output",
+		tx: ssFeeTx(realOut, false,
+			stake.CreateStakerSSFeeMarker(100, 1)),
+	}, {
+		name: "miner fee distribution with null input",
+		tx: ssFeeTx(nullOut, true,
+			stake.CreateMinerSSFeeMarker(100)),
+	}}
+
+	coinbase := wire.NewMsgTx()
+	coinbase.AddTxIn(wire.NewTxIn(&nullOut, 0, nil))
+	coinbase.AddTxOut(wire.NewTxOut(5000, p2pkh(0x01)))
+	block := &wire.MsgBlock{
+		Header:       wire.BlockHeader{MerkleRoot: chainhash.Hash{0x02}},
+		Transactions: []*wire.MsgTx{coinbase},
+	}
+	wantFilter, err := Regular(block, noPrevScripts{})
+	if err != nil {
+		t.Fatalf("unexpected error creating filter: %v", err)
+	}
+	wantBytes := wantFilter.Bytes()
+
+	for _, test := range tests {
+		if txType := stake.DetermineTxType(test.tx); txType != stake.TxTypeSSFee {
+			t.Errorf("%q: unexpected tx type -- got %v, want %v", test.name,
+				txType, stake.TxTypeSSFee)
+			continue
+		}
+
+		ssFeeBlock := *block
+		ssFeeBlock.STransactions = []*wire.MsgTx{test.tx}
+		filter, err := Regular(&ssFeeBlock, noPrevScripts{})
+		if err != nil {
+			t.Errorf("%q: unexpected error creating filter: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(filter.Bytes(), wantBytes) {
+			t.Errorf("%q: fee distribution changed the filter -- got %x, "+
+				"want %x", test.name, filter.Bytes(), wantBytes)
+		}
+	}
+}
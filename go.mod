@@ -32,7 +32,7 @@ require (
 	github.com/monetarium/monetarium-node/dcrec v1.0.14
 	github.com/monetarium/monetarium-node/dcrec/secp256k1 v1.0.14
 	github.com/monetarium/monetarium-node/dcrjson v1.0.14
-	github.com/monetarium/monetarium-node/dcrutil v1.0.14
+	github.com/monetarium/monetarium-node/dcrutil v1.0.15
 	github.com/monetarium/monetarium-node/gcs v1.0.14
 	github.com/monetarium/monetarium-node/math/uint256 v1.0.14
 	github.com/monetarium/monetarium-node/mixing v1.0.14
@@ -63,3 +63,17 @@ require (
 	golang.org/x/sync v0.11.0 // indirect
 	golang.org/x/text v0.22.0 // indirect
 )
+
+replace (
+	github.com/monetarium/monetarium-node/addrmgr => ./addrmgr
+	github.com/monetarium/monetarium-node/chaincfg => ./chaincfg
+	github.com/monetarium/monetarium-node/connmgr => ./connmgr
+	github.com/monetarium/monetarium-node/database => ./database
+	github.com/monetarium/monetarium-node/dcrec/secp256k1 => ./dcrec/secp256k1
+	github.com/monetarium/monetarium-node/dcrutil => ./dcrutil
+	github.com/monetarium/monetarium-node/mixing => ./mixing
+	github.com/monetarium/monetarium-node/peer => ./peer
+	github.com/monetarium/monetarium-node/rpc/jsonrpc/types => ./rpc/jsonrpc/types
+	github.com/monetarium/monetarium-node/txscript => ./txscript
+	github.com/monetarium/monetarium-node/wire => ./wire
+)
@@ -0,0 +1,176 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/database"
+)
+
+const (
+	// MinPruneTarget is the minimum allowed target size in bytes of the stored
+	// block data when pruning is enabled.
+	MinPruneTarget = 1024 * 1024 * 1024 // 1 GiB
+
+	// minRetainedBlocks is the number of the most recent blocks that are
+	// always retained when pruning so reorganizations can still be handled.
+	minRetainedBlocks = 4096
+
+	// blockPruneInterval is the number of blocks the main chain must advance
+	// between attempts to prune block data.
+	blockPruneInterval = 100
+)
+
+var (
+	// prunedHeightKeyName is the name of the db key used to store the height
+	// of the most recent main chain block whose data was pruned.
+	prunedHeightKeyName = []byte("prunedheight")
+)
+
+// blockPruner is implemented by databases that support removing old block
+// data.
+type blockPruner interface {
+	// PruneBlocks removes the oldest block data until the total size of the
+	// remaining block data is no more than the provided target size in bytes
+	// while retaining all blocks with heights at or above the provided height.
+	// The hashes of the removed blocks are returned.
+	PruneBlocks(targetSize uint64, belowHeight uint32) ([]chainhash.Hash, error)
+}
+
+// dbFetchPrunedHeight uses an existing database transaction to fetch the height
+// of the most recent main chain block whose data was pruned.  Zero is returned
+// when no blocks have been pruned.
+func dbFetchPrunedHeight(dbTx database.Tx) int64 {
+	serialized := dbTx.Metadata().Get(prunedHeightKeyName)
+	if len(serialized) < 8 {
+		return 0
+	}
+	return int64(byteOrder.Uint64(serialized))
+}
+
+// dbPutPrunedHeight uses an existing database transaction to store the height
+// of the most recent main chain block whose data was pruned.
+func dbPutPrunedHeight(dbTx database.Tx, height int64) error {
+	var serialized [8]byte
+	byteOrder.PutUint64(serialized[:], uint64(height))
+	return dbTx.Metadata().Put(prunedHeightKeyName, serialized[:])
+}
+
+// PrunedHeight returns the height of the most recent main chain block whose
+// data was pruned.  Zero is returned when no blocks have been pruned.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) PrunedHeight() int64 {
+	return b.prunedHeight.Load()
+}
+
+// prunedBlockError returns an error with the kind ErrBlockPruned when the
+// provided error indicates the data for the provided node was not found in the
+// database because it was pruned.  Otherwise, the provided error is returned
+// unmodified.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) prunedBlockError(node *blockNode, err error) error {
+	if !errors.Is(err, database.ErrBlockNotFound) ||
+		node.height > b.prunedHeight.Load() {
+
+		return err
+	}
+
+	str := fmt.Sprintf("block %s (height %d) is not available because it has "+
+		"been pruned", node.hash, node.height)
+	return contextError(ErrBlockPruned, str)
+}
+
+// maybePruneBlocks removes the oldest block data from the database when
+// pruning is enabled, the main chain has advanced enough since the last
+// attempt, and the stored block data exceeds the target size.  The most recent
+// blocks are always retained so reorganizations can still be handled.
+//
+// Blocks that have not been flushed to the UTXO backend yet are also retained
+// since they are replayed to catch the UTXO set up to the tip when the UTXO
+// cache is initialized after an unclean shutdown.
+//
+// The spend journal entries for the removed blocks are removed as well since
+// they are only needed to disconnect the blocks.
+//
+// This function MUST be called with the chain lock held (for writes).
+func (b *BlockChain) maybePruneBlocks() error {
+	if b.pruneTarget == 0 {
+		return nil
+	}
+	tip := b.bestChain.Tip()
+	if tip.height-b.lastBlockPruneHeight < blockPruneInterval {
+		return nil
+	}
+	b.lastBlockPruneHeight = tip.height
+	belowHeight := tip.height - minRetainedBlocks
+	if belowHeight <= 0 {
+		return nil
+	}
+
+	// Limit the pruning to the blocks before the point the last block flushed
+	// to the UTXO backend forks from the main chain.  The UTXO cache
+	// initialization disconnects all blocks after that point from the UTXO
+	// backend and connects the main chain blocks after it, both of which
+	// require the data for the blocks as well as the fork block itself.
+	utxoState, err := b.utxoCache.FetchBackendState()
+	if err != nil {
+		return err
+	}
+	if utxoState == nil {
+		return nil
+	}
+	lastFlushedNode := b.index.LookupNode(&utxoState.lastFlushHash)
+	if lastFlushedNode == nil {
+		return nil
+	}
+	fork := b.bestChain.FindFork(lastFlushedNode)
+	if fork == nil {
+		return nil
+	}
+	if fork.height < belowHeight {
+		belowHeight = fork.height
+	}
+	if belowHeight <= 0 {
+		return nil
+	}
+
+	pruner := b.db.(blockPruner)
+	pruned, err := pruner.PruneBlocks(b.pruneTarget, uint32(belowHeight))
+	if err != nil {
+		return err
+	}
+	if len(pruned) == 0 {
+		return nil
+	}
+
+	prunedHeight := b.prunedHeight.Load()
+	err = b.db.Update(func(dbTx database.Tx) error {
+		for i := range pruned {
+			hash := &pruned[i]
+			node := b.index.LookupNode(hash)
+			if node != nil && node.height > prunedHeight &&
+				b.bestChain.Contains(node) {
+
+				prunedHeight = node.height
+			}
+			if err := dbRemoveSpendJournalEntry(dbTx, hash); err != nil {
+				return err
+			}
+		}
+		return dbPutPrunedHeight(dbTx, prunedHeight)
+	})
+	if err != nil {
+		return err
+	}
+	b.prunedHeight.Store(prunedHeight)
+
+	log.Infof("Pruned %d blocks (pruned height %d)", len(pruned), prunedHeight)
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/monetarium/monetarium-node/chaincfg"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/database"
+)
+
+// TestPrunedBlockError ensures errors for blocks that are not found in the
+// database are only converted to pruned block errors when the block is at or
+// below the pruned height.
+func TestPrunedBlockError(t *testing.T) {
+	t.Parallel()
+
+	bc := newFakeChain(chaincfg.RegNetParams())
+	node := bc.bestChain.Tip()
+	for i := 0; i < 10; i++ {
+		node = newFakeNode(node, 1, 1, 0, time.Now())
+		bc.index.AddNode(node)
+		bc.bestChain.SetTip(node)
+	}
+	notFoundErr := database.Error{Err: database.ErrBlockNotFound}
+	otherErr := errors.New("other error")
+
+	tests := []struct {
+		name         string
+		prunedHeight int64
+		err          error
+		want         error
+	}{{
+		name: "no error",
+		want: nil,
+	}, {
+		name:         "not found at pruned height",
+		prunedHeight: node.height,
+		err:          notFoundErr,
+		want:         ErrBlockPruned,
+	}, {
+		name:         "not found above pruned height",
+		prunedHeight: node.height - 1,
+		err:          notFoundErr,
+		want:         database.ErrBlockNotFound,
+	}, {
+		name:         "other error at pruned height",
+		prunedHeight: node.height,
+		err:          otherErr,
+		want:         otherErr,
+	}}
+	for _, test := range tests {
+		bc.prunedHeight.Store(test.prunedHeight)
+		err := bc.prunedBlockError(node, test.err)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%q: unexpected error -- got %v, want %v", test.name,
+				err, test.want)
+		}
+	}
+}
+
+// TestPrunedHeightSerialization ensures the pruned height round trips through
+// the database.
+func TestPrunedHeightSerialization(t *testing.T) {
+	t.Parallel()
+
+	bc, err := chainSetup(t, chaincfg.RegNetParams())
+	if err != nil {
+		t.Fatalf("failed to setup chain instance: %v", err)
+	}
+	err = bc.db.Update(func(dbTx database.Tx) error {
+		if height := dbFetchPrunedHeight(dbTx); height != 0 {
+			t.Fatalf("unexpected initial pruned height %d", height)
+		}
+		if err := dbPutPrunedHeight(dbTx, 123456); err != nil {
+			return err
+		}
+		if height := dbFetchPrunedHeight(dbTx); height != 123456 {
+			t.Fatalf("unexpected pruned height %d", height)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected database error: %v", err)
+	}
+}
+
+// pruneTestUtxoCache provides a mock utxo cache that reports a fixed state for
+// the UTXO backend.
+type pruneTestUtxoCache struct {
+	UtxoCacher
+	state *UtxoSetState
+}
+
+// FetchBackendState returns the mocked state of the UTXO backend.
+func (c *pruneTestUtxoCache) FetchBackendState() (*UtxoSetState, error) {
+	return c.state, nil
+}
+
+// pruneTestDB provides a mock database that records the requested height below
+// which blocks may be pruned without actually pruning any blocks.
+type pruneTestDB struct {
+	database.DB
+	pruned      bool
+	belowHeight uint32
+}
+
+// PruneBlocks records the provided height and reports that no blocks were
+// pruned.
+func (db *pruneTestDB) PruneBlocks(targetSize uint64, belowHeight uint32) ([]chainhash.Hash, error) {
+	db.pruned = true
+	db.belowHeight = belowHeight
+	return nil, nil
+}
+
+// TestMaybePruneBlocksUtxoFlush ensures blocks that are required to catch the
+// UTXO backend up to the main chain tip are never pruned.
+func TestMaybePruneBlocksUtxoFlush(t *testing.T) {
+	t.Parallel()
+
+	// Create a main chain that is long enough for blocks to be pruned along
+	// with a side chain that forks from it.
+	bc := newFakeChain(chaincfg.RegNetParams())
+	node := bc.bestChain.Tip()
+	var forkNode, mainNode *blockNode
+	for i := 0; i < minRetainedBlocks+blockPruneInterval+500; i++ {
+		node = newFakeNode(node, 1, 1, 0, time.Now())
+		bc.index.AddNode(node)
+		bc.bestChain.SetTip(node)
+		switch node.height {
+		case 200:
+			forkNode = node
+		case 300:
+			mainNode = node
+		}
+	}
+	tip := node
+	sideNode := forkNode
+	for i := 0; i < 5; i++ {
+		sideNode = newFakeNode(sideNode, 1, 1, 1, time.Now())
+		bc.index.AddNode(sideNode)
+	}
+	unknownNode := newFakeNode(tip, 1, 1, 0, time.Now())
+
+	tests := []struct {
+		name      string     // test description
+		lastFlush *blockNode // last block flushed to the utxo backend
+		wantPrune bool       // whether blocks are expected to be pruned
+		wantBelow int64      // expected height to prune below
+	}{{
+		name:      "utxo backend flushed to the tip",
+		lastFlush: tip,
+		wantPrune: true,
+		wantBelow: tip.height - minRetainedBlocks,
+	}, {
+		name:      "utxo backend behind the retained blocks",
+		lastFlush: mainNode,
+		wantPrune: true,
+		wantBelow: mainNode.height,
+	}, {
+		name:      "utxo backend flushed to a side chain",
+		lastFlush: sideNode,
+		wantPrune: true,
+		wantBelow: forkNode.height,
+	}, {
+		name:      "utxo backend without state",
+		wantPrune: false,
+	}, {
+		name:      "utxo backend flushed to an unknown block",
+		lastFlush: unknownNode,
+		wantPrune: false,
+	}}
+	for _, test := range tests {
+		var state *UtxoSetState
+		if test.lastFlush != nil {
+			state = &UtxoSetState{
+				lastFlushHeight: uint32(test.lastFlush.height),
+				lastFlushHash:   test.lastFlush.hash,
+			}
+		}
+		db := &pruneTestDB{}
+		bc.db = db
+		bc.utxoCache = &pruneTestUtxoCache{state: state}
+		bc.pruneTarget = MinPruneTarget
+		bc.lastBlockPruneHeight = 0
+
+		if err := bc.maybePruneBlocks(); err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if db.pruned != test.wantPrune {
+			t.Errorf("%q: unexpected prune attempt -- got %v, want %v",
+				test.name, db.pruned, test.wantPrune)
+			continue
+		}
+		if test.wantPrune && int64(db.belowHeight) != test.wantBelow {
+			t.Errorf("%q: unexpected prune height -- got %d, want %d",
+				test.name, db.belowHeight, test.wantBelow)
+		}
+	}
+}
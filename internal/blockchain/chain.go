@@ -179,6 +179,7 @@ type BlockChain struct {
 	indexSubscriber          *indexers.IndexSubscriber
 	interrupt                <-chan struct{}
 	utxoCache                UtxoCacher
+	utxoBackend              UtxoBackend
 
 	// subsidyCache is the cache that provides quick lookup of subsidy
 	// values.
@@ -270,6 +271,26 @@ type BlockChain struct {
 	// it is unlikely to be referenced in the future.
 	pruner *chainPruner
 
+	// pruneTarget is the target size in bytes of the stored block data when
+	// pruning is enabled and zero otherwise.
+	//
+	// lastBlockPruneHeight is the height of the main chain tip as of the last
+	// attempt to prune block data.  It is protected by the chain lock.
+	//
+	// prunedHeight is the height of the most recent main chain block whose
+	// data was pruned.
+	pruneTarget          uint64
+	lastBlockPruneHeight int64
+	prunedHeight         atomic.Int64
+
+	// The following fields track the total number of blocks that were fully
+	// validated and connected to the main chain along with the total time
+	// spent in nanoseconds doing so.
+	numValidated  atomic.Uint64
+	validateNanos atomic.Uint64
+	numConnected  atomic.Uint64
+	connectNanos  atomic.Uint64
+
 	// The following maps are various caches for the stake version/voting
 	// system.  The goal of these is to reduce disk access to load blocks
 	// from disk.  Measurements indicate that it is slightly more expensive
@@ -512,7 +533,7 @@ func (b *BlockChain) fetchMainChainBlockByNode(node *blockNode) (*dcrutil.Block,
 		block, err = dbFetchBlockByNode(dbTx, node)
 		return err
 	})
-	return block, err
+	return block, b.prunedBlockError(node, err)
 }
 
 // fetchBlockByNode returns the block associated with the given node all known
@@ -533,7 +554,7 @@ func (b *BlockChain) fetchBlockByNode(node *blockNode) (*dcrutil.Block, error) {
 		block, err = dbFetchBlockByNode(dbTx, node)
 		return err
 	})
-	return block, err
+	return block, b.prunedBlockError(node, err)
 }
 
 // pruneStakeNodes removes references to old stake nodes which should no
@@ -660,6 +681,15 @@ func (b *BlockChain) connectBlock(node *blockNode, block, parent *dcrutil.Block,
 	}
 	if hdrCommitmentsActive {
 		hdrCommitmentLeaves = hdrCommitments.v1Leaves()
+
+		skaSupplyCmtActive, err := b.isSKASupplyCommitmentAgendaActive(
+			node.parent)
+		if err != nil {
+			return err
+		}
+		if skaSupplyCmtActive {
+			hdrCommitmentLeaves = hdrCommitments.v2Leaves()
+		}
 	}
 
 	// Generate a new best state snapshot that will be used to update the
@@ -1287,6 +1317,19 @@ func (b *BlockChain) reorganizeChainInternal(target *blockNode) error {
 			}
 			hdrCommitments.filter = filter
 			hdrCommitments.filterHash = filter.Hash()
+
+			skaSupplyCmtActive, err := b.isSKASupplyCommitmentAgendaActive(
+				n.parent)
+			if err != nil {
+				return err
+			}
+			if skaSupplyCmtActive {
+				hdrCommitments.skaSupplyRoot, err = b.calcSKASupplyRoot(
+					n.parent, block)
+				if err != nil {
+					return err
+				}
+			}
 		} else {
 			// The block must pass all of the validation rules which depend on
 			// having the full block data for all of its ancestors available.
@@ -1305,6 +1348,7 @@ func (b *BlockChain) reorganizeChainInternal(target *blockNode) error {
 			// In the case the block is determined to be invalid due to a rule
 			// violation, mark it as invalid and mark all of its descendants as
 			// having an invalid ancestor.
+			validateStart := time.Now()
 			err = b.checkConnectBlock(n, block, parent, view, &stxos,
 				&hdrCommitments)
 			if err != nil {
@@ -1314,14 +1358,19 @@ func (b *BlockChain) reorganizeChainInternal(target *blockNode) error {
 				}
 				return err
 			}
+			b.numValidated.Add(1)
+			b.validateNanos.Add(uint64(time.Since(validateStart)))
 			b.index.SetStatusFlags(n, statusValidated)
 		}
 
 		// Update the database and chain state.
+		connectStart := time.Now()
 		err = b.connectBlock(n, block, parent, view, stxos, &hdrCommitments)
 		if err != nil {
 			return err
 		}
+		b.numConnected.Add(1)
+		b.connectNanos.Add(uint64(time.Since(connectStart)))
 
 		log.Tracef("Connected block %s (height %d) to main chain", n.hash,
 			n.height)
@@ -1693,6 +1742,37 @@ func (b *BlockChain) BestSnapshot() *BestState {
 	return snapshot
 }
 
+// BlockProcessingStats houses statistics about the blocks that have been
+// validated and connected to the main chain.
+type BlockProcessingStats struct {
+	// BlocksValidated is the total number of blocks that were fully validated
+	// and ValidateDuration is the total time spent doing so.  Blocks that are
+	// ancestors of the assumed valid block are connected without full
+	// validation, so they are not included.
+	BlocksValidated  uint64
+	ValidateDuration time.Duration
+
+	// BlocksConnected is the total number of blocks that were connected to the
+	// main chain and ConnectDuration is the total time spent updating the
+	// database and chain state to do so.
+	BlocksConnected uint64
+	ConnectDuration time.Duration
+}
+
+// BlockProcessingStats returns statistics about the blocks that have been
+// validated and connected to the main chain since the chain instance was
+// created.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) BlockProcessingStats() BlockProcessingStats {
+	return BlockProcessingStats{
+		BlocksValidated:  b.numValidated.Load(),
+		ValidateDuration: time.Duration(b.validateNanos.Load()),
+		BlocksConnected:  b.numConnected.Load(),
+		ConnectDuration:  time.Duration(b.connectNanos.Load()),
+	}
+}
+
 // GetSKAEmissionNonce returns the last used nonce for the specified coin type.
 // This replaces the old chainParams-based nonce storage with proper blockchain
 // state management for security and reorg handling.
@@ -1716,6 +1796,18 @@ func (b *BlockChain) HasSKAEmissionOccurred(coinType cointype.CoinType) bool {
 	return b.skaEmissionState.IsEmitted(coinType)
 }
 
+// GetAllSKAEmittedAmounts returns a map of all SKA coin types to their
+// cumulative emitted amounts.  Only coin types that have been emitted are
+// included in the result.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) GetAllSKAEmittedAmounts() map[cointype.CoinType]*big.Int {
+	if b.skaEmissionState == nil {
+		return make(map[cointype.CoinType]*big.Int)
+	}
+	return b.skaEmissionState.GetAllEmittedAmounts()
+}
+
 // GetSKABurnedAmount returns the total amount burned for the specified SKA coin type.
 // Returns nil if no burns have occurred for this coin type.
 //
@@ -2590,6 +2682,15 @@ type Config struct {
 	//
 	// This field is required.
 	UtxoCache UtxoCacher
+
+	// PruneTarget is the target size in bytes of the stored block data.  When
+	// it is nonzero, the oldest block data is removed from the database as
+	// needed to remain under the target while always retaining the most recent
+	// blocks so reorganizations can still be handled.  It must be at least
+	// MinPruneTarget and the database must support pruning.
+	//
+	// This field can be zero to disable pruning.
+	PruneTarget uint64
 }
 
 // newRecentBlocksCache returns a new LRU map for more efficient access to
@@ -2616,6 +2717,17 @@ func New(ctx context.Context, config *Config) (*BlockChain, error) {
 	if config.ChainParams == nil {
 		return nil, AssertError("blockchain.New chain parameters nil")
 	}
+	if config.PruneTarget != 0 {
+		if config.PruneTarget < MinPruneTarget {
+			str := fmt.Sprintf("blockchain.New prune target %d is less "+
+				"than the minimum %d", config.PruneTarget, MinPruneTarget)
+			return nil, AssertError(str)
+		}
+		if _, ok := config.DB.(blockPruner); !ok {
+			return nil, AssertError("blockchain.New database does not " +
+				"support pruning")
+		}
+	}
 
 	// Generate a deployment ID map from the provided params while validating
 	// they conform to the required semantics.
@@ -2692,6 +2804,8 @@ func New(ctx context.Context, config *Config) (*BlockChain, error) {
 		calcVoterVersionIntervalCache: make(map[[chainhash.HashSize]byte]uint32),
 		calcStakeVersionCache:         make(map[[chainhash.HashSize]byte]uint32),
 		utxoCache:                     config.UtxoCache,
+		utxoBackend:                   config.UtxoBackend,
+		pruneTarget:                   config.PruneTarget,
 	}
 	b.pruner = newChainPruner(&b)
 
@@ -2711,6 +2825,7 @@ func New(ctx context.Context, config *Config) (*BlockChain, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize SKA emission state: %w", err)
 	}
+	skaState.backfillEmittedAmounts(params)
 	b.skaEmissionState = skaState
 
 	// Initialize the SKA burn state for tracking total burned amounts per coin type.
@@ -2729,6 +2844,16 @@ func New(ctx context.Context, config *Config) (*BlockChain, error) {
 		return nil, err
 	}
 
+	// Load the height of the most recent pruned block, if any.
+	err = b.db.View(func(dbTx database.Tx) error {
+		b.prunedHeight.Store(dbFetchPrunedHeight(dbTx))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.lastBlockPruneHeight = b.bestChain.Tip().height - blockPruneInterval
+
 	// Initialize the UTXO state.  This entails running any database migrations
 	// as necessary as well as initializing the UTXO cache.
 	if err := b.utxoCache.Initialize(ctx, &b); err != nil {
@@ -602,10 +602,22 @@ const (
 	// ErrNoFilter indicates a filter for a given block hash does not exist.
 	ErrNoFilter = ErrorKind("ErrNoFilter")
 
+	// ErrBlockPruned indicates the data for a requested block is no longer
+	// available because it was pruned.
+	ErrBlockPruned = ErrorKind("ErrBlockPruned")
+
 	// ErrNoTreasuryBalance indicates the treasury balance for a given block
 	// hash does not exist.
 	ErrNoTreasuryBalance = ErrorKind("ErrNoTreasuryBalance")
 
+	// ErrNoSKASupplyCommitment indicates the block header does not commit to
+	// the SKA supply state because the agenda is not active.
+	ErrNoSKASupplyCommitment = ErrorKind("ErrNoSKASupplyCommitment")
+
+	// ErrNoSKASupply indicates there is no supply state for a given SKA coin
+	// type because it has never been emitted or burned.
+	ErrNoSKASupply = ErrorKind("ErrNoSKASupply")
+
 	// ErrInvalidateGenesisBlock indicates an attempt to invalidate the genesis
 	// block which is not allowed.
 	ErrInvalidateGenesisBlock = ErrorKind("ErrInvalidateGenesisBlock")
@@ -642,6 +654,10 @@ const (
 	// performed.
 	ErrUtxoBackendTxClosed = ErrorKind("ErrUtxoBackendTxClosed")
 
+	// ErrMalformedUtxoSnapshot indicates a UTXO set snapshot is malformed or
+	// does not match the hashes it commits to.
+	ErrMalformedUtxoSnapshot = ErrorKind("ErrMalformedUtxoSnapshot")
+
 	// -----------------------------------------------------------------
 	// Errors related to the automatic ticket revocations agenda.
 	// -----------------------------------------------------------------
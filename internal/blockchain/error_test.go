@@ -150,7 +150,10 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrDBTooOldToUpgrade, "ErrDBTooOldToUpgrade"},
 		{ErrUnknownBlock, "ErrUnknownBlock"},
 		{ErrNoFilter, "ErrNoFilter"},
+		{ErrBlockPruned, "ErrBlockPruned"},
 		{ErrNoTreasuryBalance, "ErrNoTreasuryBalance"},
+		{ErrNoSKASupplyCommitment, "ErrNoSKASupplyCommitment"},
+		{ErrNoSKASupply, "ErrNoSKASupply"},
 		{ErrInvalidateGenesisBlock, "ErrInvalidateGenesisBlock"},
 		{ErrSerializeHeader, "ErrSerializeHeader"},
 		{ErrNotAnAncestor, "ErrNotAnAncestor"},
@@ -159,6 +162,7 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrUtxoBackendCorruption, "ErrUtxoBackendCorruption"},
 		{ErrUtxoBackendNotOpen, "ErrUtxoBackendNotOpen"},
 		{ErrUtxoBackendTxClosed, "ErrUtxoBackendTxClosed"},
+		{ErrMalformedUtxoSnapshot, "ErrMalformedUtxoSnapshot"},
 		{ErrInvalidRevocationTxVersion, "ErrInvalidRevocationTxVersion"},
 		{ErrNoExpiredTicketRevocation, "ErrNoExpiredTicketRevocation"},
 		{ErrNoMissedTicketRevocation, "ErrNoMissedTicketRevocation"},
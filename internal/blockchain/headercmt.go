@@ -18,13 +18,18 @@ import (
 const (
 	// HeaderCmtFilterIndex is the proof index for the filter header commitment.
 	HeaderCmtFilterIndex = 0
+
+	// HeaderCmtSKASupplyIndex is the proof index for the SKA supply header
+	// commitment.
+	HeaderCmtSKASupplyIndex = 1
 )
 
 // headerCommitmentData houses information the block header commits to via the
 // commitment root.
 type headerCommitmentData struct {
-	filter     *gcs.FilterV2
-	filterHash chainhash.Hash
+	filter        *gcs.FilterV2
+	filterHash    chainhash.Hash
+	skaSupplyRoot chainhash.Hash
 }
 
 // v1Leaves returns the individual commitment hashes that comprise the leaves of
@@ -33,6 +38,12 @@ func (c *headerCommitmentData) v1Leaves() []chainhash.Hash {
 	return []chainhash.Hash{c.filterHash}
 }
 
+// v2Leaves returns the individual commitment hashes that comprise the leaves of
+// the merkle tree for a v2 header commitment.
+func (c *headerCommitmentData) v2Leaves() []chainhash.Hash {
+	return []chainhash.Hash{c.filterHash, c.skaSupplyRoot}
+}
+
 // CalcCommitmentRootV1 calculates and returns the required v1 block commitment
 // root from the filter hash it commits to.
 //
@@ -50,6 +61,14 @@ func CalcCommitmentRootV1(filterHash chainhash.Hash) chainhash.Hash {
 	return filterHash
 }
 
+// CalcCommitmentRootV2 calculates and returns the required v2 block commitment
+// root from the filter hash and SKA supply root it commits to.
+//
+// This function is safe for concurrent access.
+func CalcCommitmentRootV2(filterHash, skaSupplyRoot chainhash.Hash) chainhash.Hash {
+	return standalone.CalcMerkleRoot([]chainhash.Hash{filterHash, skaSupplyRoot})
+}
+
 // FetchUtxoViewParentTemplate loads utxo details from the point of view of just
 // having connected the given block, which must be a block template that
 // connects to the parent of the tip of the main chain.  In other words, the
@@ -319,6 +319,12 @@ func (b *BlockChain) maybeAcceptBlockData(node *blockNode, block *dcrutil.Block,
 	// Prune stake nodes that are no longer needed.
 	b.pruner.pruneChainIfNeeded()
 
+	// Prune old block data as needed when pruning is enabled.  Failure to
+	// prune is not fatal since it only affects disk usage.
+	if err := b.maybePruneBlocks(); err != nil {
+		log.Warnf("Unable to prune block data: %v", err)
+	}
+
 	// Insert the block into the database if it's not already there.  Even
 	// though it is possible the block will ultimately fail to connect, it has
 	// already passed all proof-of-work and validity tests which means it would
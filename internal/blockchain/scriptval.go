@@ -24,11 +24,21 @@ type PrevScripter interface {
 	PrevScript(*wire.OutPoint) (uint16, []byte, bool)
 }
 
+// PrevOutputer defines an interface that provides access to scripts along with
+// the coin type, amount, and script version committed to by signatures with the
+// SigHashCoinType flag keyed by an outpoint.  The boolean return indicates
+// whether or not the details for the provided outpoint were found.
+type PrevOutputer interface {
+	PrevScripter
+	PrevOutput(*wire.OutPoint) (txscript.PrevOutput, bool)
+}
+
 // txValidateItem holds a transaction along with which input to validate.
 type txValidateItem struct {
 	txInIndex int
 	txIn      *wire.TxIn
 	tx        *dcrutil.Tx
+	prevOuts  []txscript.PrevOutput
 }
 
 // txValidator provides a type which asynchronously validates transaction
@@ -37,7 +47,7 @@ type txValidateItem struct {
 type txValidator struct {
 	validateChan chan *txValidateItem
 	resultChan   chan error
-	prevScripts  PrevScripter
+	prevScripts  PrevOutputer
 	flags        txscript.ScriptFlags
 	sigCache     *txscript.SigCache
 }
@@ -78,8 +88,9 @@ out:
 			}
 			// Create a new script engine for the script pair.
 			sigScript := txIn.SignatureScript
-			vm, err := txscript.NewEngine(pkScript, txVI.tx.MsgTx(),
-				txVI.txInIndex, v.flags, scriptVersion, v.sigCache)
+			vm, err := txscript.NewEngineWithPrevOuts(pkScript,
+				txVI.tx.MsgTx(), txVI.txInIndex, v.flags, scriptVersion,
+				v.sigCache, txVI.prevOuts)
 			if err != nil {
 				str := fmt.Sprintf("failed to parse input %s:%d which "+
 					"references output %v - %v (input script bytes %x, prev "+
@@ -166,9 +177,38 @@ func (v *txValidator) Validate(items []*txValidateItem) error {
 	return nil
 }
 
+// txPrevOutputs returns the details of the outputs spent by every input of the
+// passed transaction as required by signatures with the SigHashCoinType flag
+// set and the input introspection opcodes of version 1 scripts.  Inputs that
+// do not spend a previous output, such as stakebase inputs, are represented by
+// the zero value.  Nil is returned when neither the coin type signature hash
+// agenda nor the introspection agenda is active or any of the spent outputs are
+// not available, which causes all such signatures and opcodes to fail.
+func txPrevOutputs(msgTx *wire.MsgTx, prevOutputs PrevOutputer, flags txscript.ScriptFlags) []txscript.PrevOutput {
+	const prevOutFlags = txscript.ScriptVerifyCoinTypeSigHash |
+		txscript.ScriptVerifyIntrospection
+	if flags&prevOutFlags == 0 {
+		return nil
+	}
+
+	prevOuts := make([]txscript.PrevOutput, len(msgTx.TxIn))
+	for txInIdx, txIn := range msgTx.TxIn {
+		if txIn.PreviousOutPoint.Index == math.MaxUint32 {
+			continue
+		}
+
+		prevOut, ok := prevOutputs.PrevOutput(&txIn.PreviousOutPoint)
+		if !ok {
+			return nil
+		}
+		prevOuts[txInIdx] = prevOut
+	}
+	return prevOuts
+}
+
 // newTxValidator returns a new instance of txValidator to be used for
 // validating transaction scripts asynchronously.
-func newTxValidator(prevScripts PrevScripter, flags txscript.ScriptFlags, sigCache *txscript.SigCache) *txValidator {
+func newTxValidator(prevScripts PrevOutputer, flags txscript.ScriptFlags, sigCache *txscript.SigCache) *txValidator {
 	return &txValidator{
 		validateChan: make(chan *txValidateItem),
 		resultChan:   make(chan error),
@@ -180,7 +220,7 @@ func newTxValidator(prevScripts PrevScripter, flags txscript.ScriptFlags, sigCac
 
 // ValidateTransactionScripts validates the scripts for the passed transaction
 // using multiple goroutines.
-func ValidateTransactionScripts(tx *dcrutil.Tx, prevScripts PrevScripter,
+func ValidateTransactionScripts(tx *dcrutil.Tx, prevScripts PrevOutputer,
 	flags txscript.ScriptFlags, sigCache *txscript.SigCache,
 	isAutoRevocationsEnabled bool) error {
 
@@ -200,6 +240,7 @@ func ValidateTransactionScripts(tx *dcrutil.Tx, prevScripts PrevScripter,
 	// validation.
 	txIns := msgTx.TxIn
 	txValItems := make([]*txValidateItem, 0, len(txIns))
+	prevOuts := txPrevOutputs(msgTx, prevScripts, flags)
 	for txInIdx, txIn := range txIns {
 		// Skip coinbases.
 		if txIn.PreviousOutPoint.Index == math.MaxUint32 {
@@ -210,6 +251,7 @@ func ValidateTransactionScripts(tx *dcrutil.Tx, prevScripts PrevScripter,
 			txInIndex: txInIdx,
 			txIn:      txIn,
 			tx:        tx,
+			prevOuts:  prevOuts,
 		}
 		txValItems = append(txValItems, txVI)
 	}
@@ -274,6 +316,7 @@ func checkBlockScripts(block *dcrutil.Block, utxoView *UtxoViewpoint, txTree boo
 			continue
 		}
 
+		prevOuts := txPrevOutputs(msgTx, utxoView, scriptFlags)
 		for txInIdx, txIn := range msgTx.TxIn {
 			// Skip coinbases.
 			if txIn.PreviousOutPoint.Index == math.MaxUint32 {
@@ -284,6 +327,7 @@ func checkBlockScripts(block *dcrutil.Block, utxoView *UtxoViewpoint, txTree boo
 				txInIndex: txInIdx,
 				txIn:      txIn,
 				tx:        tx,
+				prevOuts:  prevOuts,
 			}
 			txValItems = append(txValItems, txVI)
 		}
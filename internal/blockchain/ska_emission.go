@@ -16,6 +16,7 @@ import (
 	"github.com/monetarium/monetarium-node/cointype"
 	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
 	"github.com/monetarium/monetarium-node/dcrec/secp256k1/ecdsa"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1/schnorr"
 	"github.com/monetarium/monetarium-node/dcrutil"
 	"github.com/monetarium/monetarium-node/txscript/stdaddr"
 	"github.com/monetarium/monetarium-node/wire"
@@ -305,8 +306,13 @@ func createEmissionAuthScript(auth *chaincfg.SKAEmissionAuth) ([]byte, error) {
 // Note: Stakeholder vote activation (for SKA-2+) is checked at block validation level
 // in CheckSKAEmissionInBlock, not here, to allow mempool to accept transactions before
 // vote passes.
+//
+// The isSchnorrAuthEnabled flag indicates whether or not the agenda to require
+// Schnorr signatures for coin types configured for Schnorr authorization is
+// active.
 func ValidateAuthorizedSKAEmissionTransaction(tx *wire.MsgTx, blockHeight int64,
-	chain ChainStateProvider, chainParams *chaincfg.Params) error {
+	chain ChainStateProvider, chainParams *chaincfg.Params,
+	isSchnorrAuthEnabled bool) error {
 
 	// Check if this is within a valid emission window for any SKA coin type
 	// We need to check the transaction outputs to determine the coin type
@@ -359,7 +365,9 @@ func ValidateAuthorizedSKAEmissionTransaction(tx *wire.MsgTx, blockHeight int64,
 
 	// CRITICAL Verify the cryptographic signature
 	// This binds the signature to the exact transaction being validated
-	if err := verifyEmissionSignature(tx, auth, blockHeight, chainParams); err != nil {
+	if err := verifyEmissionSignature(tx, auth, blockHeight, chainParams,
+		isSchnorrAuthEnabled); err != nil {
+
 		return fmt.Errorf("emission signature verification failed: %w", err)
 	}
 
@@ -481,25 +489,22 @@ func ValidateAuthorizedSKAEmissionTransaction(tx *wire.MsgTx, blockHeight int64,
 	return nil
 }
 
-// verifyEmissionSignature verifies the cryptographic signature of an emission transaction.
-// This is a CRITICAL security function that prevents:
-// - Miner redirect attacks (changing outputs)
-// - Signature tampering
-// - Cross-network replay attacks
+// calcEmissionSignatureHash returns the hash that must be signed by the
+// emission key to authorize the passed emission transaction.
 //
-// The signature binds to:
+// The hash binds to:
 // - The exact transaction outputs (via no-witness serialization hash)
 // - The network ID (preventing cross-network replay)
 // - The coin type, nonce, and authorization height (for window-based validation)
-func verifyEmissionSignature(tx *wire.MsgTx, auth *chaincfg.SKAEmissionAuth,
-	_ int64, chainParams *chaincfg.Params) error {
+func calcEmissionSignatureHash(tx *wire.MsgTx, auth *chaincfg.SKAEmissionAuth,
+	chainParams *chaincfg.Params) ([]byte, error) {
 
 	// Compute the transaction hash using explicit no-witness serialization
 	// This ensures the signature binds to the exact outputs without witness data
 	// BytesPrefix() is explicitly documented to use TxSerializeNoWitness
 	txBytes, err := tx.BytesPrefix() // Uses wire.TxSerializeNoWitness internally
 	if err != nil {
-		return fmt.Errorf("failed to serialize transaction (no-witness): %w", err)
+		return nil, fmt.Errorf("failed to serialize transaction (no-witness): %w", err)
 	}
 	txHash := sha256.Sum256(txBytes)
 
@@ -512,7 +517,7 @@ func verifyEmissionSignature(tx *wire.MsgTx, auth *chaincfg.SKAEmissionAuth,
 
 	// Network ID for replay protection across networks
 	if err := binary.Write(&msgBuf, binary.LittleEndian, uint32(chainParams.Net)); err != nil {
-		return fmt.Errorf("failed to write network ID: %w", err)
+		return nil, fmt.Errorf("failed to write network ID: %w", err)
 	}
 
 	// Coin type
@@ -520,13 +525,13 @@ func verifyEmissionSignature(tx *wire.MsgTx, auth *chaincfg.SKAEmissionAuth,
 
 	// Nonce for replay protection within network
 	if err := binary.Write(&msgBuf, binary.LittleEndian, auth.Nonce); err != nil {
-		return fmt.Errorf("failed to write nonce: %w", err)
+		return nil, fmt.Errorf("failed to write nonce: %w", err)
 	}
 
 	// Use auth.Height (signed by emitter) instead of current blockHeight
 	// This allows broadcasting to mempool and inclusion at any valid height within window
 	if err := binary.Write(&msgBuf, binary.LittleEndian, uint64(auth.Height)); err != nil {
-		return fmt.Errorf("failed to write authorization height: %w", err)
+		return nil, fmt.Errorf("failed to write authorization height: %w", err)
 	}
 
 	// Transaction hash - this binds the signature to exact outputs
@@ -534,6 +539,41 @@ func verifyEmissionSignature(tx *wire.MsgTx, auth *chaincfg.SKAEmissionAuth,
 
 	// Create the final message hash
 	msgHash := sha256.Sum256(msgBuf.Bytes())
+	return msgHash[:], nil
+}
+
+// verifyEmissionSignature verifies the cryptographic signature of an emission transaction.
+// This is a CRITICAL security function that prevents:
+// - Miner redirect attacks (changing outputs)
+// - Signature tampering
+// - Cross-network replay attacks
+//
+// The signature is over the hash returned by calcEmissionSignatureHash.  It is
+// an EC-Schnorr-DCRv0 signature when the coin type is configured for Schnorr
+// authorization and the agenda that requires it is active as indicated by the
+// isSchnorrAuthEnabled flag, which allows the emission key to be a MuSig2
+// aggregate key, and a strict DER encoded low-S ECDSA signature otherwise.
+func verifyEmissionSignature(tx *wire.MsgTx, auth *chaincfg.SKAEmissionAuth,
+	_ int64, chainParams *chaincfg.Params, isSchnorrAuthEnabled bool) error {
+
+	msgHash, err := calcEmissionSignatureHash(tx, auth, chainParams)
+	if err != nil {
+		return err
+	}
+
+	skaConfig := chainParams.GetSKACoinConfig(auth.CoinType)
+	if isSchnorrAuthEnabled && skaConfig != nil && skaConfig.SchnorrEmissionAuth {
+		// Schnorr signatures have a single fixed-size canonical encoding, so
+		// parsing is sufficient to rule out malleability.
+		sig, err := schnorr.ParseSignature(auth.Signature)
+		if err != nil {
+			return fmt.Errorf("invalid Schnorr signature format: %w", err)
+		}
+		if !sig.Verify(msgHash, auth.EmissionKey) {
+			return fmt.Errorf("signature verification failed - unauthorized emission attempt")
+		}
+		return nil
+	}
 
 	// Parse the signature with strict DER validation
 	sig, err := ecdsa.ParseDERSignature(auth.Signature)
@@ -555,7 +595,7 @@ func verifyEmissionSignature(tx *wire.MsgTx, auth *chaincfg.SKAEmissionAuth,
 	}
 
 	// Verify the signature against the message and public key
-	if !sig.Verify(msgHash[:], auth.EmissionKey) {
+	if !sig.Verify(msgHash, auth.EmissionKey) {
 		return fmt.Errorf("signature verification failed - unauthorized emission attempt")
 	}
 
@@ -729,8 +769,24 @@ func CheckSKAEmissionInBlock(block *dcrutil.Block, prevNode *blockNode,
 		if wire.IsSKAEmissionTransaction(msgTx) {
 			emissionTxCount++
 
+			// Schnorr authorization only applies to coin types configured for
+			// it once the vote for the agenda that requires it is active, so
+			// only determine the agenda state when it is relevant.
+			var isSchnorrAuthEnabled bool
+			skaConfig := chainParams.GetSKACoinConfig(msgTx.TxOut[0].CoinType)
+			if skaConfig != nil && skaConfig.SchnorrEmissionAuth {
+				var err error
+				isSchnorrAuthEnabled, err = chain.isSchnorrEmissionAuthAgendaActive(
+					prevNode)
+				if err != nil {
+					return err
+				}
+			}
+
 			// Validate the emission transaction with full cryptographic authorization
-			if err := ValidateAuthorizedSKAEmissionTransaction(msgTx, blockHeight, chain, chainParams); err != nil {
+			if err := ValidateAuthorizedSKAEmissionTransaction(msgTx, blockHeight,
+				chain, chainParams, isSchnorrAuthEnabled); err != nil {
+
 				return fmt.Errorf("invalid SKA emission transaction at index %d: %w", i, err)
 			}
 
@@ -853,9 +909,18 @@ func extractSKAEmissionsFromBlock(block *dcrutil.Block, blockHeight int64) []SKA
 						}
 					}
 
+					// Sum the amount paid to the coin type by the emission
+					amount := new(big.Int)
+					for _, out := range msgTx.TxOut {
+						if out.CoinType == txOut.CoinType && out.SKAValue != nil {
+							amount.Add(amount, out.SKAValue)
+						}
+					}
+
 					emissions = append(emissions, SKAEmissionRecord{
 						CoinType: txOut.CoinType,
 						Nonce:    nonce,
+						Amount:   amount,
 						Height:   blockHeight,
 						TxHash:   *tx.Hash(),
 					})
@@ -12,11 +12,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/monetarium/monetarium-node/blockchain/stake"
 	"github.com/monetarium/monetarium-node/chaincfg"
 	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
 	"github.com/monetarium/monetarium-node/cointype"
 	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
 	"github.com/monetarium/monetarium-node/dcrec/secp256k1/ecdsa"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1/schnorr"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1/schnorr/musig2"
+	"github.com/monetarium/monetarium-node/dcrutil"
 	"github.com/monetarium/monetarium-node/wire"
 )
 
@@ -284,3 +288,248 @@ func TestUnauthorizedEmissionPrevention(t *testing.T) {
 		t.Errorf("Should succeed with next valid nonce: %v", err)
 	}
 }
+
+// TestSchnorrEmissionSignature ensures emission authorizations for coin types
+// configured for Schnorr authorization are verified against an aggregate
+// emission key jointly signed by several parties.
+func TestSchnorrEmissionSignature(t *testing.T) {
+	// Aggregate the keys of the parties that jointly control emission.
+	var privKeys []*secp256k1.PrivateKey
+	var pubKeys []*secp256k1.PublicKey
+	for i := 0; i < 3; i++ {
+		privKey, err := secp256k1.GeneratePrivateKey()
+		if err != nil {
+			t.Fatalf("Failed to generate private key: %v", err)
+		}
+		privKeys = append(privKeys, privKey)
+		pubKeys = append(pubKeys, privKey.PubKey())
+	}
+	aggKey, err := musig2.AggregateKeys(pubKeys)
+	if err != nil {
+		t.Fatalf("Failed to aggregate keys: %v", err)
+	}
+
+	params := &chaincfg.Params{
+		Net: wire.MainNet,
+		SKACoins: map[cointype.CoinType]*chaincfg.SKACoinConfig{
+			1: {
+				CoinType:            1,
+				Active:              true,
+				EmissionKey:         aggKey.PubKey(),
+				SchnorrEmissionAuth: true,
+			},
+		},
+	}
+
+	tx := wire.NewMsgTx()
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: wire.MaxPrevOutIndex},
+		wire.NullValueIn, nil))
+	tx.AddTxOut(wire.NewTxOutSKA(big.NewInt(1000000), 1, []byte{0x51}))
+	auth := &chaincfg.SKAEmissionAuth{
+		EmissionKey: aggKey.PubKey(),
+		Nonce:       1,
+		CoinType:    1,
+		Amount:      big.NewInt(1000000),
+		Height:      100,
+	}
+	msgHash, err := calcEmissionSignatureHash(tx, auth, params)
+	if err != nil {
+		t.Fatalf("Failed to calculate signature hash: %v", err)
+	}
+
+	// Jointly sign the emission authorization.
+	secNonces := make([]*musig2.SecretNonce, len(privKeys))
+	pubNonces := make([]*musig2.PublicNonce, len(privKeys))
+	for i, privKey := range privKeys {
+		secNonces[i], pubNonces[i], err = musig2.GenerateNonce(privKey,
+			aggKey, msgHash)
+		if err != nil {
+			t.Fatalf("Failed to generate nonce: %v", err)
+		}
+	}
+	aggNonce, err := musig2.AggregateNonces(pubNonces)
+	if err != nil {
+		t.Fatalf("Failed to aggregate nonces: %v", err)
+	}
+	session, err := musig2.NewSession(aggKey, aggNonce, msgHash)
+	if err != nil {
+		t.Fatalf("Failed to create signing session: %v", err)
+	}
+	partialSigs := make([]*musig2.PartialSignature, len(privKeys))
+	for i, privKey := range privKeys {
+		partialSigs[i], err = session.Sign(secNonces[i], privKey)
+		if err != nil {
+			t.Fatalf("Failed to sign: %v", err)
+		}
+	}
+	sig, err := session.Combine(partialSigs)
+	if err != nil {
+		t.Fatalf("Failed to combine partial signatures: %v", err)
+	}
+	auth.Signature = sig.Serialize()
+
+	if err := verifyEmissionSignature(tx, auth, 100, params, true); err != nil {
+		t.Fatalf("Valid aggregate signature rejected: %v", err)
+	}
+
+	// The signature must not verify for a different nonce.
+	auth.Nonce = 2
+	if err := verifyEmissionSignature(tx, auth, 100, params, true); err == nil {
+		t.Error("Signature should not verify for a different nonce")
+	}
+	auth.Nonce = 1
+
+	// An ECDSA signature is rejected when Schnorr authorization is required.
+	ecdsaAuth := *auth
+	ecdsaAuth.Signature = ecdsa.Sign(privKeys[0], msgHash).Serialize()
+	if err := verifyEmissionSignature(tx, &ecdsaAuth, 100, params, true); err == nil {
+		t.Error("ECDSA signature should be rejected for Schnorr authorization")
+	}
+
+	// A Schnorr signature is rejected and an ECDSA signature is required when
+	// the agenda that requires Schnorr authorization is not active.
+	if err := verifyEmissionSignature(tx, auth, 100, params, false); err == nil {
+		t.Error("Schnorr signature should be rejected prior to the agenda")
+	}
+	ecdsaAuth.EmissionKey = privKeys[0].PubKey()
+	if err := verifyEmissionSignature(tx, &ecdsaAuth, 100, params, false); err != nil {
+		t.Errorf("ECDSA signature rejected prior to the agenda: %v", err)
+	}
+
+	// A Schnorr signature is rejected when the coin type is not configured
+	// for Schnorr authorization.
+	params.SKACoins[1].SchnorrEmissionAuth = false
+	if err := verifyEmissionSignature(tx, auth, 100, params, true); err == nil {
+		t.Error("Schnorr signature should be rejected for ECDSA authorization")
+	}
+}
+
+// TestSchnorrEmissionAuthActivation ensures emission authorizations for coin
+// types configured for Schnorr authorization require an ECDSA signature prior
+// to the activation of the agenda that requires Schnorr signatures and a
+// Schnorr signature once it is active.
+func TestSchnorrEmissionAuthActivation(t *testing.T) {
+	// Clone the parameters so they can be mutated, find the correct deployment
+	// for the agenda as well as the yes vote choice within it, and, finally,
+	// ensure it is always available to vote by removing the time constraints to
+	// prevent test failures when the real expiration time passes.
+	const voteID = chaincfg.VoteIDSchnorrEmissionAuth
+	params := cloneParams(chaincfg.RegNetParams())
+	deploymentVer, deployment := findDeployment(t, params, voteID)
+	yesChoice := findDeploymentChoice(t, deployment, "yes")
+	removeDeploymentTimeConstraints(deployment)
+
+	// Shorter versions of params for convenience.
+	stakeValidationHeight := uint32(params.StakeValidationHeight)
+	ruleChangeActivationInterval := params.RuleChangeActivationInterval
+
+	// Configure the first SKA coin type for Schnorr authorization with an
+	// emission window that spans the activation of the agenda.
+	privKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate private key: %v", err)
+	}
+	emissionAmount := big.NewInt(1000000000)
+	params.SKACoins = map[cointype.CoinType]*chaincfg.SKACoinConfig{
+		1: {
+			CoinType:            1,
+			Active:              true,
+			EmissionHeight:      int32(stakeValidationHeight),
+			EmissionWindow:      int32(ruleChangeActivationInterval * 4),
+			EmissionAddresses:   []string{"RsWKp7wtdTZYabYFYSc9cnxhwFEjA5g4pFc"},
+			EmissionAmounts:     []*big.Int{emissionAmount},
+			EmissionKey:         privKey.PubKey(),
+			SchnorrEmissionAuth: true,
+		},
+	}
+
+	// emissionBlock returns a block at the provided height that contains an
+	// emission of the first SKA coin type authorized by the signature the
+	// provided function creates for the signature hash.
+	emissionBlock := func(height int64, sign func(msgHash []byte) []byte) *dcrutil.Block {
+		tx := createTestEmissionTx(t, params.SKACoins[1].EmissionAddresses,
+			[]*big.Int{emissionAmount}, 1, params)
+		auth := &chaincfg.SKAEmissionAuth{
+			EmissionKey: privKey.PubKey(),
+			CoinType:    1,
+			Nonce:       1,
+			Amount:      emissionAmount,
+			Height:      height,
+		}
+		msgHash, err := calcEmissionSignatureHash(tx, auth, params)
+		if err != nil {
+			t.Fatalf("Failed to calculate signature hash: %v", err)
+		}
+		auth.Signature = sign(msgHash)
+		embedAuth(tx, auth)
+		return dcrutil.NewBlock(&wire.MsgBlock{
+			Transactions: []*wire.MsgTx{tx},
+		})
+	}
+	signECDSA := func(msgHash []byte) []byte {
+		return ecdsa.Sign(privKey, msgHash).Serialize()
+	}
+	signSchnorr := func(msgHash []byte) []byte {
+		sig, err := schnorr.Sign(privKey, msgHash)
+		if err != nil {
+			t.Fatalf("Failed to sign: %v", err)
+		}
+		return sig.Serialize()
+	}
+
+	tests := []struct {
+		name     string
+		numNodes uint32 // num fake nodes to create
+		active   bool   // whether agenda active for the emission block
+	}{{
+		name:     "one before activation",
+		numNodes: stakeValidationHeight + ruleChangeActivationInterval*3 - 2,
+		active:   false,
+	}, {
+		name:     "exactly active",
+		numNodes: 1,
+		active:   true,
+	}}
+
+	curTimestamp := time.Now()
+	bc := newFakeChain(params)
+	bc.skaEmissionState = &SKAEmissionState{
+		nonces:  make(map[cointype.CoinType]uint64),
+		emitted: make(map[cointype.CoinType]bool),
+	}
+	node := bc.bestChain.Tip()
+	for _, test := range tests {
+		for i := uint32(0); i < test.numNodes; i++ {
+			node = newFakeNode(node, int32(deploymentVer), deploymentVer, 0,
+				curTimestamp)
+
+			// Create fake votes that vote yes on the agenda to ensure it is
+			// activated.
+			for j := uint16(0); j < params.TicketsPerBlock; j++ {
+				node.votes = append(node.votes, stake.VoteVersionTuple{
+					Version: deploymentVer,
+					Bits:    yesChoice.Bits | 0x01,
+				})
+			}
+			bc.index.AddNode(node)
+			bc.bestChain.SetTip(node)
+			curTimestamp = curTimestamp.Add(time.Second)
+		}
+
+		// Ensure an ECDSA signature is only accepted prior to activation and a
+		// Schnorr signature is only accepted once the agenda is active.
+		height := node.height + 1
+		err := CheckSKAEmissionInBlock(emissionBlock(height, signECDSA), node,
+			bc, params)
+		if gotOK := err == nil; gotOK == test.active {
+			t.Errorf("%s: unexpected ECDSA authorization result -- got err "+
+				"%v, active %v", test.name, err, test.active)
+		}
+		err = CheckSKAEmissionInBlock(emissionBlock(height, signSchnorr), node,
+			bc, params)
+		if gotOK := err == nil; gotOK != test.active {
+			t.Errorf("%s: unexpected Schnorr authorization result -- got err "+
+				"%v, active %v", test.name, err, test.active)
+		}
+	}
+}
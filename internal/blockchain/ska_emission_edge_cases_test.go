@@ -64,7 +64,7 @@ func TestSKAEmissionBasicValidation(t *testing.T) {
 		signEmissionTx(t, tx, auth, privKey, params)
 
 		// Test at the correct emission height using secure validation
-		err = ValidateAuthorizedSKAEmissionTransaction(tx, int64(config.EmissionHeight), chain, params)
+		err = ValidateAuthorizedSKAEmissionTransaction(tx, int64(config.EmissionHeight), chain, params, false)
 		if err != nil {
 			t.Errorf("Valid emission transaction should pass: %v", err)
 		}
@@ -136,7 +136,7 @@ func TestSKAEmissionBasicValidation(t *testing.T) {
 		}
 		signEmissionTx(t, tx, auth, privKey, params)
 
-		err = ValidateAuthorizedSKAEmissionTransaction(tx, int64(config.EmissionHeight)+1000, chain, params)
+		err = ValidateAuthorizedSKAEmissionTransaction(tx, int64(config.EmissionHeight)+1000, chain, params, false)
 		if err == nil {
 			t.Error("Emission at wrong height should fail")
 		}
@@ -176,7 +176,7 @@ func TestSKAEmissionBasicValidation(t *testing.T) {
 		// Modify to use VAR output (should fail validation)
 		tx.TxOut[0].CoinType = cointype.CoinTypeVAR
 
-		err = ValidateAuthorizedSKAEmissionTransaction(tx, int64(config.EmissionHeight), chain, params)
+		err = ValidateAuthorizedSKAEmissionTransaction(tx, int64(config.EmissionHeight), chain, params, false)
 		if err == nil {
 			t.Error("Emission with VAR output should fail")
 		}
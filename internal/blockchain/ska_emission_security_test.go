@@ -78,7 +78,7 @@ func TestSKAEmissionSignatureVerification(t *testing.T) {
 	chain := createMockChain(t, params)
 
 	// Test 1: Valid signature should pass
-	err = ValidateAuthorizedSKAEmissionTransaction(tx, 150, chain, params)
+	err = ValidateAuthorizedSKAEmissionTransaction(tx, 150, chain, params, false)
 	if err != nil {
 		t.Errorf("Valid emission failed validation: %v", err)
 	}
@@ -89,7 +89,7 @@ func TestSKAEmissionSignatureVerification(t *testing.T) {
 	tamperedTx := createTestEmissionTx(t, addresses, amounts, 1, params)
 	embedAuth(tamperedTx, &tamperedAuth)
 
-	err = ValidateAuthorizedSKAEmissionTransaction(tamperedTx, 150, chain, params)
+	err = ValidateAuthorizedSKAEmissionTransaction(tamperedTx, 150, chain, params, false)
 	if err == nil {
 		t.Error("Tampered signature passed validation - CRITICAL SECURITY FAILURE")
 	}
@@ -101,7 +101,7 @@ func TestSKAEmissionSignatureVerification(t *testing.T) {
 	wrongTx := createTestEmissionTx(t, addresses, amounts, 1, params)
 	signEmissionTx(t, wrongTx, &wrongAuth, wrongKey, params)
 
-	err = ValidateAuthorizedSKAEmissionTransaction(wrongTx, 150, chain, params)
+	err = ValidateAuthorizedSKAEmissionTransaction(wrongTx, 150, chain, params, false)
 	if err == nil {
 		t.Error("Wrong key passed validation - CRITICAL SECURITY FAILURE")
 	}
@@ -169,7 +169,7 @@ func TestSKAEmissionMinerRedirectProtection(t *testing.T) {
 	chain := createMockChain(t, params)
 
 	// This MUST fail - signature doesn't match transaction
-	err = ValidateAuthorizedSKAEmissionTransaction(redirectedTx, 150, chain, params)
+	err = ValidateAuthorizedSKAEmissionTransaction(redirectedTx, 150, chain, params, false)
 	if err == nil {
 		t.Fatal("CRITICAL: Miner redirect attack succeeded! Outputs were changed but validation passed")
 	}
@@ -241,7 +241,7 @@ func TestSKAEmissionNetworkReplayProtection(t *testing.T) {
 	testnetChain := createMockChain(t, testnetParams)
 
 	// This MUST fail due to network ID mismatch
-	err := ValidateAuthorizedSKAEmissionTransaction(mainnetTx, 150, testnetChain, testnetParams)
+	err := ValidateAuthorizedSKAEmissionTransaction(mainnetTx, 150, testnetChain, testnetParams, false)
 	if err == nil {
 		t.Fatal("CRITICAL: Network replay attack succeeded! Transaction from mainnet accepted on testnet")
 	}
@@ -350,7 +350,7 @@ func TestSKAEmissionNonceValidation(t *testing.T) {
 	}
 	signEmissionTx(t, tx0, auth0, privKey, params)
 
-	err := ValidateAuthorizedSKAEmissionTransaction(tx0, 150, chain, params)
+	err := ValidateAuthorizedSKAEmissionTransaction(tx0, 150, chain, params, false)
 	if err == nil {
 		t.Error("Nonce 0 accepted - should require nonce 1")
 	}
@@ -366,7 +366,7 @@ func TestSKAEmissionNonceValidation(t *testing.T) {
 	}
 	signEmissionTx(t, tx1, auth1, privKey, params)
 
-	err = ValidateAuthorizedSKAEmissionTransaction(tx1, 150, chain, params)
+	err = ValidateAuthorizedSKAEmissionTransaction(tx1, 150, chain, params, false)
 	if err != nil {
 		t.Errorf("Valid nonce 1 rejected: %v", err)
 	}
@@ -382,7 +382,7 @@ func TestSKAEmissionNonceValidation(t *testing.T) {
 	}
 	signEmissionTx(t, tx2, auth2, privKey, params)
 
-	err = ValidateAuthorizedSKAEmissionTransaction(tx2, 150, chain, params)
+	err = ValidateAuthorizedSKAEmissionTransaction(tx2, 150, chain, params, false)
 	if err == nil {
 		t.Error("Nonce skip accepted - should require sequential nonces")
 	}
@@ -436,7 +436,7 @@ func TestSKAEmissionWindowValidation(t *testing.T) {
 			}
 			signEmissionTx(t, tx, auth, privKey, params)
 
-			err := ValidateAuthorizedSKAEmissionTransaction(tx, test.blockHeight, chain, params)
+			err := ValidateAuthorizedSKAEmissionTransaction(tx, test.blockHeight, chain, params, false)
 
 			if test.shouldPass && err != nil {
 				t.Errorf("Valid emission at height %d rejected: %v", test.blockHeight, err)
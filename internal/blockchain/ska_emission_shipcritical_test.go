@@ -134,7 +134,7 @@ func TestShipCriticalSecurityFixes(t *testing.T) {
 		}
 
 		// This should fail validation (either for signature issues or mixed coin types)
-		err := ValidateAuthorizedSKAEmissionTransaction(tx, 100, chain, params)
+		err := ValidateAuthorizedSKAEmissionTransaction(tx, 100, chain, params, false)
 		if err == nil {
 			t.Error("Expected validation to fail for invalid transaction")
 		}
@@ -198,7 +198,7 @@ func TestShipCriticalSecurityFixes(t *testing.T) {
 		}
 
 		// The full validation should catch the governance amount mismatch
-		err = ValidateAuthorizedSKAEmissionTransaction(tx, 100, chain, params)
+		err = ValidateAuthorizedSKAEmissionTransaction(tx, 100, chain, params, false)
 		if err == nil {
 			t.Error("Expected validation to fail for wrong governance amount")
 		}
@@ -122,7 +122,7 @@ func TestSKAEmissionStakeValidationHeight(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			err := ValidateAuthorizedSKAEmissionTransaction(tx, test.blockHeight, chainState, params)
+			err := ValidateAuthorizedSKAEmissionTransaction(tx, test.blockHeight, chainState, params, false)
 
 			if test.expectError {
 				if err == nil {
@@ -8,8 +8,10 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math/big"
 	"sync"
 
+	"github.com/monetarium/monetarium-node/chaincfg"
 	"github.com/monetarium/monetarium-node/cointype"
 	"github.com/monetarium/monetarium-node/database"
 )
@@ -18,6 +20,7 @@ import (
 // This file manages the persistent state for SKA emissions including:
 // - Nonces for replay protection
 // - Emission flags to prevent duplicate emissions
+// - Cumulative emitted amounts for the SKA supply commitment
 // - Proper handling of chain reorganizations
 
 const (
@@ -26,7 +29,7 @@ const (
 	skaStateBucketName = "skaemissionstate"
 
 	// Current version of the on-disk format
-	skaStateFormatVersion = 1
+	skaStateFormatVersion = 2
 
 	// Meta key for format version
 	skaStateVersionKey = "__meta_version__"
@@ -49,6 +52,9 @@ type SKAEmissionState struct {
 	// Tracks which coin types have been emitted
 	emitted map[cointype.CoinType]bool
 
+	// Cumulative amount emitted for each coin type
+	amounts map[cointype.CoinType]*big.Int
+
 	// Database handle for persistence
 	db database.DB
 }
@@ -58,6 +64,7 @@ func NewSKAEmissionState(db database.DB) (*SKAEmissionState, error) {
 	state := &SKAEmissionState{
 		nonces:  make(map[cointype.CoinType]uint64),
 		emitted: make(map[cointype.CoinType]bool),
+		amounts: make(map[cointype.CoinType]*big.Int),
 		db:      db,
 	}
 
@@ -84,6 +91,58 @@ func (s *SKAEmissionState) IsEmitted(coinType cointype.CoinType) bool {
 	return s.emitted[coinType]
 }
 
+// GetEmittedAmount returns the cumulative amount emitted for the specified
+// coin type.  Returns nil if no emissions have occurred yet.
+func (s *SKAEmissionState) GetEmittedAmount(coinType cointype.CoinType) *big.Int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if amount, ok := s.amounts[coinType]; ok {
+		return new(big.Int).Set(amount)
+	}
+	return nil
+}
+
+// GetAllEmittedAmounts returns a copy of all cumulative emitted amounts.
+func (s *SKAEmissionState) GetAllEmittedAmounts() map[cointype.CoinType]*big.Int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	result := make(map[cointype.CoinType]*big.Int, len(s.amounts))
+	for coinType, amount := range s.amounts {
+		result[coinType] = new(big.Int).Set(amount)
+	}
+	return result
+}
+
+// backfillEmittedAmounts sets the emitted amount of any coin type that was
+// emitted prior to amounts being tracked to the total emission amount required
+// by the chain parameters.  Since each coin type may only be emitted once and
+// the emission must pay exactly the configured amounts, this recovers the
+// amounts for state loaded from the version 1 format.
+func (s *SKAEmissionState) backfillEmittedAmounts(params *chaincfg.Params) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for coinType, emitted := range s.emitted {
+		if !emitted || s.amounts[coinType] != nil {
+			continue
+		}
+		total := new(big.Int)
+		if config := params.GetSKACoinConfig(coinType); config != nil {
+			for _, amount := range config.EmissionAmounts {
+				if amount != nil {
+					total.Add(total, amount)
+				}
+			}
+		}
+		if total.Sign() == 0 {
+			log.Warnf("Unable to determine emitted amount for SKA coin type %d",
+				coinType)
+		}
+		s.amounts[coinType] = total
+	}
+}
+
 // DisconnectSKAEmissionsTx updates the SKA emission state when a block is disconnected,
 // using the provided database transaction for atomicity with block updates.
 func (s *SKAEmissionState) DisconnectSKAEmissionsTx(dbTx database.Tx, emissions []SKAEmissionRecord) error {
@@ -100,6 +159,7 @@ func (s *SKAEmissionState) DisconnectSKAEmissionsTx(dbTx database.Tx, emissions
 		if currentNonce, exists := s.nonces[emission.CoinType]; exists && currentNonce == emission.Nonce {
 			delete(s.nonces, emission.CoinType)
 			delete(s.emitted, emission.CoinType)
+			delete(s.amounts, emission.CoinType)
 
 			log.Debugf("Disconnected SKA emission: coin type %d, nonce %d at height %d",
 				emission.CoinType, emission.Nonce, emission.Height)
@@ -154,8 +214,9 @@ func (s *SKAEmissionState) load() error {
 
 			coinType := cointype.CoinType(k[0])
 
-			// Value format: [nonce:8 bytes][emitted:1 byte]
-			if len(v) != 9 {
+			// V1 format: [nonce:8 bytes][emitted:1 byte]
+			// V2 format: [nonce:8 bytes][emitted:1 byte][amount:N bytes] (big.Int, big-endian)
+			if len(v) < 9 || (version == 1 && len(v) != 9) {
 				return fmt.Errorf("invalid value length for coin type %d: %d", coinType, len(v))
 			}
 
@@ -168,6 +229,11 @@ func (s *SKAEmissionState) load() error {
 				s.emitted[coinType] = true
 			}
 
+			// Parse emitted amount (not available in v1 format)
+			if version > 1 && s.emitted[coinType] {
+				s.amounts[coinType] = new(big.Int).SetBytes(v[9:])
+			}
+
 			return nil
 		})
 	})
@@ -222,12 +288,15 @@ func (s *SKAEmissionState) saveWithTx(dbTx database.Tx) error {
 		// Create key (1 byte coin type)
 		key := []byte{byte(coinType)}
 
-		// Create value (8 bytes nonce + 1 byte emitted flag)
+		// Create value (8 bytes nonce + 1 byte emitted flag + emitted amount)
 		value := make([]byte, 9)
 		binary.LittleEndian.PutUint64(value[:8], nonce)
 		if isEmitted {
 			value[8] = 1
 		}
+		if amount := s.amounts[coinType]; amount != nil {
+			value = append(value, amount.Bytes()...)
+		}
 
 		// Store in bucket
 		if err := bucket.Put(key, value); err != nil {
@@ -247,6 +316,7 @@ func (s *SKAEmissionState) Clear() error {
 	// Clear in-memory state
 	s.nonces = make(map[cointype.CoinType]uint64)
 	s.emitted = make(map[cointype.CoinType]bool)
+	s.amounts = make(map[cointype.CoinType]*big.Int)
 
 	// Clear database state
 	return s.db.Update(func(dbTx database.Tx) error {
@@ -288,6 +358,7 @@ func (s *SKAEmissionState) GetEmissionStateSnapshot() (map[cointype.CoinType]uin
 type SKAEmissionRecord struct {
 	CoinType cointype.CoinType
 	Nonce    uint64
+	Amount   *big.Int // Total amount of the coin type paid by the emission
 	Height   int64
 	TxHash   [32]byte
 }
@@ -306,6 +377,13 @@ func (s *SKAEmissionState) ConnectSKAEmissionsTx(dbTx database.Tx, emissions []S
 	for _, emission := range emissions {
 		s.nonces[emission.CoinType] = emission.Nonce
 		s.emitted[emission.CoinType] = true
+		if emission.Amount != nil {
+			amount := s.amounts[emission.CoinType]
+			if amount == nil {
+				amount = new(big.Int)
+			}
+			s.amounts[emission.CoinType] = amount.Add(amount, emission.Amount)
+		}
 
 		log.Debugf("Connected SKA emission: coin type %d, nonce %d at height %d",
 			emission.CoinType, emission.Nonce, emission.Height)
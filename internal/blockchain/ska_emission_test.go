@@ -399,7 +399,7 @@ func TestValidateAuthorizedSKAEmissionTransactionBasic(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			err := ValidateAuthorizedSKAEmissionTransaction(test.tx, test.blockHeight, chain, params)
+			err := ValidateAuthorizedSKAEmissionTransaction(test.tx, test.blockHeight, chain, params, false)
 
 			if test.expectError {
 				if err == nil {
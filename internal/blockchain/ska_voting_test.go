@@ -187,7 +187,7 @@ func TestSKA2EmissionTransactionValidation(t *testing.T) {
 
 	// Transaction validation should succeed (mempool accepts before vote)
 	// Vote check happens at block validation level in CheckSKAEmissionInBlock
-	err = ValidateAuthorizedSKAEmissionTransaction(tx, emissionHeight, chain, params)
+	err = ValidateAuthorizedSKAEmissionTransaction(tx, emissionHeight, chain, params, false)
 	if err != nil {
 		t.Errorf("Transaction validation should succeed (vote check at block level), got: %v", err)
 	}
@@ -284,7 +284,7 @@ func TestSKA1EmissionWithoutVote(t *testing.T) {
 	chain := newFakeChain(params)
 
 	// Attempt emission - should succeed because SKA-1 doesn't require voting
-	err = ValidateAuthorizedSKAEmissionTransaction(tx, emissionHeight, chain, params)
+	err = ValidateAuthorizedSKAEmissionTransaction(tx, emissionHeight, chain, params, false)
 	if err != nil {
 		t.Errorf("SKA-1 emission should succeed without vote, but got error: %v", err)
 	}
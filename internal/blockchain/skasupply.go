@@ -0,0 +1,337 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/monetarium/monetarium-node/blockchain/standalone"
+	"github.com/monetarium/monetarium-node/chaincfg"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/database"
+	"github.com/monetarium/monetarium-node/dcrutil"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// SKASupply houses the supply state of a single SKA coin type as of a given
+// block.  The block header commits to the supply state of every SKA coin type
+// that has been emitted or burned once the SKA supply commitment agenda is
+// active.
+type SKASupply struct {
+	// CoinType is the SKA coin type the supply state is for.
+	CoinType cointype.CoinType
+
+	// Nonce is the last emission nonce used for the coin type.
+	Nonce uint64
+
+	// Emitted is the cumulative amount of atoms emitted for the coin type.
+	Emitted *big.Int
+
+	// Burned is the cumulative amount of atoms burned for the coin type.
+	Burned *big.Int
+}
+
+// Circulating returns the circulating supply of the coin type, which is the
+// cumulative emitted amount less the cumulative burned amount.
+func (s *SKASupply) Circulating() *big.Int {
+	return new(big.Int).Sub(s.Emitted, s.Burned)
+}
+
+// Serialize returns the serialized supply state that is hashed to produce the
+// leaf of the SKA supply merkle tree.
+//
+// The serialized format is:
+//
+//	<coin type><nonce><emitted len><emitted><burned len><burned>
+//
+//	Field          Type     Size
+//	coin type      uint8    1 byte
+//	nonce          uint64   8 bytes (little endian)
+//	emitted len    VLQ      variable
+//	emitted        []byte   variable (big endian, no leading zeros)
+//	burned len     VLQ      variable
+//	burned         []byte   variable (big endian, no leading zeros)
+func (s *SKASupply) Serialize() []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(byte(s.CoinType))
+	var nonce [8]byte
+	binary.LittleEndian.PutUint64(nonce[:], s.Nonce)
+	buf.Write(nonce[:])
+	_ = wire.WriteVarBytes(&buf, 0, s.Emitted.Bytes())
+	_ = wire.WriteVarBytes(&buf, 0, s.Burned.Bytes())
+	return buf.Bytes()
+}
+
+// Hash returns the leaf hash of the supply state in the SKA supply merkle tree.
+func (s *SKASupply) Hash() chainhash.Hash {
+	return chainhash.HashH(s.Serialize())
+}
+
+// CalcSKASupplyRoot calculates and returns the merkle root of the provided SKA
+// supply states, which must be sorted by coin type in ascending order.  The
+// leaves of the tree are the hashes of the individual supply states.
+//
+// The root is all zero when there are no supply states.
+func CalcSKASupplyRoot(supplies []SKASupply) chainhash.Hash {
+	if len(supplies) == 0 {
+		return chainhash.Hash{}
+	}
+	leaves := make([]chainhash.Hash, 0, len(supplies)+len(supplies)&1)
+	for i := range supplies {
+		leaves = append(leaves, supplies[i].Hash())
+	}
+	return standalone.CalcMerkleRootInPlace(leaves)
+}
+
+// skaSupplyState houses the supply state of every SKA coin type keyed by the
+// coin type.
+type skaSupplyState map[cointype.CoinType]*SKASupply
+
+// entry returns the supply state for the passed coin type, creating an empty
+// one when it does not already exist.
+func (s skaSupplyState) entry(coinType cointype.CoinType) *SKASupply {
+	supply, ok := s[coinType]
+	if !ok {
+		supply = &SKASupply{
+			CoinType: coinType,
+			Emitted:  new(big.Int),
+			Burned:   new(big.Int),
+		}
+		s[coinType] = supply
+	}
+	return supply
+}
+
+// connectBlock updates the supply state for the emissions and burns in the
+// passed block being connected.  This mirrors the updates made to the SKA
+// emission and burn states when a block is connected.
+func (s skaSupplyState) connectBlock(block *dcrutil.Block, params *chaincfg.Params) {
+	height := block.Height()
+	for _, emission := range extractSKAEmissionsFromBlock(block, height) {
+		supply := s.entry(emission.CoinType)
+		supply.Nonce = emission.Nonce
+		supply.Emitted.Add(supply.Emitted, emission.Amount)
+	}
+	for _, burn := range extractSKABurnsFromBlock(block, height, params) {
+		supply := s.entry(burn.CoinType)
+		supply.Burned.Add(supply.Burned, burn.Amount)
+	}
+}
+
+// disconnectBlock updates the supply state for the emissions and burns in the
+// passed block being disconnected.  This mirrors the updates made to the SKA
+// emission and burn states when a block is disconnected.
+func (s skaSupplyState) disconnectBlock(block *dcrutil.Block, params *chaincfg.Params) {
+	height := block.Height()
+	for _, emission := range extractSKAEmissionsFromBlock(block, height) {
+		supply := s.entry(emission.CoinType)
+		if supply.Nonce == emission.Nonce {
+			supply.Nonce = 0
+			supply.Emitted.SetInt64(0)
+		}
+	}
+	for _, burn := range extractSKABurnsFromBlock(block, height, params) {
+		supply := s.entry(burn.CoinType)
+		supply.Burned.Sub(supply.Burned, burn.Amount)
+		if supply.Burned.Sign() < 0 {
+			supply.Burned.SetInt64(0)
+		}
+	}
+}
+
+// supplies returns the supply state of every coin type that has been emitted
+// or burned sorted by coin type in ascending order.
+func (s skaSupplyState) supplies() []SKASupply {
+	supplies := make([]SKASupply, 0, len(s))
+	for _, supply := range s {
+		if supply.Nonce == 0 && supply.Emitted.Sign() == 0 &&
+			supply.Burned.Sign() == 0 {
+
+			continue
+		}
+		supplies = append(supplies, SKASupply{
+			CoinType: supply.CoinType,
+			Nonce:    supply.Nonce,
+			Emitted:  new(big.Int).Set(supply.Emitted),
+			Burned:   new(big.Int).Set(supply.Burned),
+		})
+	}
+	sort.Slice(supplies, func(i, j int) bool {
+		return supplies[i].CoinType < supplies[j].CoinType
+	})
+	return supplies
+}
+
+// tipSKASupplyState returns the supply state of every SKA coin type as of the
+// current tip of the main chain.
+//
+// This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) tipSKASupplyState() skaSupplyState {
+	state := make(skaSupplyState)
+	if b.skaEmissionState != nil {
+		nonces, _ := b.skaEmissionState.GetEmissionStateSnapshot()
+		for coinType, nonce := range nonces {
+			state.entry(coinType).Nonce = nonce
+		}
+		for coinType, amount := range b.skaEmissionState.GetAllEmittedAmounts() {
+			state.entry(coinType).Emitted.Set(amount)
+		}
+	}
+	if b.skaBurnState != nil {
+		for coinType, amount := range b.skaBurnState.GetAllBurnedAmounts() {
+			state.entry(coinType).Burned.Set(amount)
+		}
+	}
+	return state
+}
+
+// calcSKASupplyRoot calculates and returns the root of the SKA supply merkle
+// tree from the point of view of just having connected the passed block to the
+// passed parent node.
+//
+// The parent node must either be the current tip of the main chain or its
+// parent since the supply state is only available as of the current tip.  The
+// latter is supported for block templates that are siblings of the tip.
+//
+// This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) calcSKASupplyRoot(prevNode *blockNode, block *dcrutil.Block) (chainhash.Hash, error) {
+	state := b.tipSKASupplyState()
+	tip := b.bestChain.Tip()
+	switch {
+	case prevNode == tip:
+	case prevNode == tip.parent:
+		tipBlock, err := b.fetchMainChainBlockByNode(tip)
+		if err != nil {
+			return chainhash.Hash{}, err
+		}
+		state.disconnectBlock(tipBlock, b.chainParams)
+	default:
+		str := fmt.Sprintf("unable to calculate SKA supply state for block "+
+			"%s since it does not extend the current tip %s or its parent",
+			block.Hash(), tip.hash)
+		return chainhash.Hash{}, AssertError(str)
+	}
+	state.connectBlock(block, b.chainParams)
+	return CalcSKASupplyRoot(state.supplies()), nil
+}
+
+// CalcSKASupplyRoot calculates and returns the root of the SKA supply merkle
+// tree from the point of view of just having connected the passed block, which
+// must be a block template that extends either the current tip of the main
+// chain or its parent.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) CalcSKASupplyRoot(block *wire.MsgBlock) (chainhash.Hash, error) {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+
+	prevNode := b.index.LookupNode(&block.Header.PrevBlock)
+	if prevNode == nil {
+		return chainhash.Hash{}, unknownBlockError(&block.Header.PrevBlock)
+	}
+	utilBlock := dcrutil.NewBlock(block)
+	return b.calcSKASupplyRoot(prevNode, utilBlock)
+}
+
+// SKASupplyProof houses the supply state of an SKA coin type as of the current
+// tip of the main chain along with the proofs needed to prove the state is
+// committed to by the header of the tip block.
+//
+// The supply state is proven in two steps.  First, the leaf proof proves the
+// hash of the supply state is a member of the SKA supply merkle tree whose
+// root is the supply root.  Second, the header proof proves the supply root is
+// committed to by the commitment root in the block header.
+type SKASupplyProof struct {
+	BlockHash   chainhash.Hash
+	Height      int64
+	Supply      SKASupply
+	LeafIndex   uint32
+	LeafProof   []chainhash.Hash
+	SupplyRoot  chainhash.Hash
+	HeaderProof HeaderProof
+}
+
+// SKASupplyProof returns the supply state of the passed SKA coin type as of the
+// current tip of the main chain along with the proofs that it is committed to
+// by the header of the tip block.
+//
+// An error that wraps ErrNoSKASupplyCommitment is returned when the header of
+// the tip block does not commit to the SKA supply state, and an error that
+// wraps ErrNoSKASupply is returned when the coin type has never been emitted
+// or burned.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) SKASupplyProof(coinType cointype.CoinType) (*SKASupplyProof, error) {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+
+	tip := b.bestChain.Tip()
+	isActive := false
+	if tip.parent != nil {
+		var err error
+		isActive, err = b.isSKASupplyCommitmentAgendaActive(tip.parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !isActive {
+		str := fmt.Sprintf("block %s does not commit to the SKA supply state",
+			tip.hash)
+		return nil, contextError(ErrNoSKASupplyCommitment, str)
+	}
+
+	// Locate the supply state of the coin type.
+	supplies := b.tipSKASupplyState().supplies()
+	leafIndex := sort.Search(len(supplies), func(i int) bool {
+		return supplies[i].CoinType >= coinType
+	})
+	if leafIndex == len(supplies) || supplies[leafIndex].CoinType != coinType {
+		str := fmt.Sprintf("no supply state for SKA coin type %d", coinType)
+		return nil, contextError(ErrNoSKASupply, str)
+	}
+
+	// Load the header commitments of the tip block and ensure the commitment
+	// to the supply state matches the current state.
+	var hdrCommitmentLeaves []chainhash.Hash
+	err := b.db.View(func(dbTx database.Tx) error {
+		var err error
+		hdrCommitmentLeaves, err = dbFetchHeaderCommitments(dbTx, &tip.hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	supplyRoot := CalcSKASupplyRoot(supplies)
+	if len(hdrCommitmentLeaves) <= HeaderCmtSKASupplyIndex ||
+		hdrCommitmentLeaves[HeaderCmtSKASupplyIndex] != supplyRoot {
+
+		str := fmt.Sprintf("SKA supply root %s does not match the header "+
+			"commitment for block %s", supplyRoot, tip.hash)
+		return nil, AssertError(str)
+	}
+
+	leaves := make([]chainhash.Hash, 0, len(supplies))
+	for i := range supplies {
+		leaves = append(leaves, supplies[i].Hash())
+	}
+	const proofIndex = HeaderCmtSKASupplyIndex
+	return &SKASupplyProof{
+		BlockHash:  tip.hash,
+		Height:     tip.height,
+		Supply:     supplies[leafIndex],
+		LeafIndex:  uint32(leafIndex),
+		LeafProof:  standalone.GenerateInclusionProof(leaves, uint32(leafIndex)),
+		SupplyRoot: supplyRoot,
+		HeaderProof: HeaderProof{
+			ProofIndex:  proofIndex,
+			ProofHashes: standalone.GenerateInclusionProof(hdrCommitmentLeaves, proofIndex),
+		},
+	}, nil
+}
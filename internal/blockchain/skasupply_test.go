@@ -0,0 +1,237 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/blockchain/standalone"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/database"
+)
+
+// TestSKASupplySerialize ensures the supply state serializes to the expected
+// bytes and that the circulating supply is calculated as expected.
+func TestSKASupplySerialize(t *testing.T) {
+	t.Parallel()
+
+	emitted, _ := new(big.Int).SetString("900000000000000000000000", 10)
+	tests := []struct {
+		name        string    // test description
+		supply      SKASupply // supply state to serialize
+		want        string    // expected serialized hex
+		circulating string    // expected circulating supply
+	}{{
+		name: "zero amounts",
+		supply: SKASupply{
+			CoinType: 1,
+			Nonce:    0,
+			Emitted:  new(big.Int),
+			Burned:   new(big.Int),
+		},
+		want:        "01" + "0000000000000000" + "00" + "00",
+		circulating: "0",
+	}, {
+		name: "emitted and burned",
+		supply: SKASupply{
+			CoinType: 255,
+			Nonce:    0x0102,
+			Emitted:  emitted,
+			Burned:   big.NewInt(0x1234),
+		},
+		want: "ff" + "0201000000000000" + "0a" + "be951906eba2aa800000" +
+			"02" + "1234",
+		circulating: "899999999999999999995340",
+	}}
+
+	for _, test := range tests {
+		want, err := hex.DecodeString(test.want)
+		if err != nil {
+			t.Errorf("%q: unexpected err parsing want hex: %v", test.name, err)
+			continue
+		}
+		got := test.supply.Serialize()
+		if !bytes.Equal(got, want) {
+			t.Errorf("%q: mismatched serialization -- got %x, want %x",
+				test.name, got, want)
+			continue
+		}
+		if hash := test.supply.Hash(); hash != chainhash.HashH(want) {
+			t.Errorf("%q: mismatched hash -- got %v, want %v", test.name,
+				hash, chainhash.HashH(want))
+			continue
+		}
+		if got := test.supply.Circulating().String(); got != test.circulating {
+			t.Errorf("%q: mismatched circulating supply -- got %s, want %s",
+				test.name, got, test.circulating)
+			continue
+		}
+	}
+}
+
+// TestCalcSKASupplyRoot ensures the SKA supply root commits to the hashes of
+// the individual supply states.
+func TestCalcSKASupplyRoot(t *testing.T) {
+	t.Parallel()
+
+	supplies := []SKASupply{{
+		CoinType: 1,
+		Nonce:    1,
+		Emitted:  big.NewInt(1000),
+		Burned:   big.NewInt(10),
+	}, {
+		CoinType: 2,
+		Nonce:    1,
+		Emitted:  big.NewInt(2000),
+		Burned:   new(big.Int),
+	}, {
+		CoinType: 3,
+		Nonce:    2,
+		Emitted:  big.NewInt(3000),
+		Burned:   big.NewInt(30),
+	}}
+
+	// The root of no supply states is all zero.
+	if root := CalcSKASupplyRoot(nil); root != (chainhash.Hash{}) {
+		t.Fatalf("mismatched empty root -- got %v, want %v", root,
+			chainhash.Hash{})
+	}
+
+	// The root of a single supply state is the hash of the state.
+	if root := CalcSKASupplyRoot(supplies[:1]); root != supplies[0].Hash() {
+		t.Fatalf("mismatched single root -- got %v, want %v", root,
+			supplies[0].Hash())
+	}
+
+	// The root of multiple supply states is the merkle root of their hashes
+	// and every leaf must be provable against it.
+	leaves := make([]chainhash.Hash, 0, len(supplies))
+	for i := range supplies {
+		leaves = append(leaves, supplies[i].Hash())
+	}
+	want := standalone.CalcMerkleRoot(leaves)
+	root := CalcSKASupplyRoot(supplies)
+	if root != want {
+		t.Fatalf("mismatched root -- got %v, want %v", root, want)
+	}
+	for i := range leaves {
+		proof := standalone.GenerateInclusionProof(leaves, uint32(i))
+		if !standalone.VerifyInclusionProof(&root, &leaves[i], uint32(i), proof) {
+			t.Fatalf("leaf %d is not provable against root %v", i, root)
+		}
+	}
+}
+
+// TestCalcCommitmentRootV2 ensures the version 2 commitment root commits to
+// both the filter hash and the SKA supply root.
+func TestCalcCommitmentRootV2(t *testing.T) {
+	t.Parallel()
+
+	filterHash := chainhash.Hash{0x01}
+	skaSupplyRoot := chainhash.Hash{0x02}
+	leaves := []chainhash.Hash{filterHash, skaSupplyRoot}
+	root := CalcCommitmentRootV2(filterHash, skaSupplyRoot)
+	if want := standalone.CalcMerkleRoot(leaves); root != want {
+		t.Fatalf("mismatched root -- got %v, want %v", root, want)
+	}
+	proof := standalone.GenerateInclusionProof(leaves, HeaderCmtSKASupplyIndex)
+	if !standalone.VerifyInclusionProof(&root, &skaSupplyRoot,
+		HeaderCmtSKASupplyIndex, proof) {
+
+		t.Fatalf("SKA supply root is not provable against root %v", root)
+	}
+}
+
+// TestSKASupplyStateSupplies ensures the supply states are returned sorted by
+// coin type and that coin types without any emissions or burns are skipped.
+func TestSKASupplyStateSupplies(t *testing.T) {
+	t.Parallel()
+
+	state := make(skaSupplyState)
+	state.entry(3).Burned.SetInt64(30)
+	state.entry(2)
+	state.entry(1).Nonce = 1
+	state.entry(1).Emitted.SetInt64(1000)
+
+	supplies := state.supplies()
+	if len(supplies) != 2 {
+		t.Fatalf("mismatched number of supplies -- got %d, want 2",
+			len(supplies))
+	}
+	if supplies[0].CoinType != 1 || supplies[1].CoinType != 3 {
+		t.Fatalf("mismatched coin types -- got %d and %d, want 1 and 3",
+			supplies[0].CoinType, supplies[1].CoinType)
+	}
+
+	// Ensure the returned supplies are copies that do not alias the state.
+	supplies[0].Emitted.SetInt64(0)
+	if got := state.entry(1).Emitted.Int64(); got != 1000 {
+		t.Fatalf("state modified via returned supply -- got %d, want 1000",
+			got)
+	}
+}
+
+// TestSKAEmissionStateAmounts ensures the cumulative emitted amounts are
+// tracked, persisted, and removed on disconnect.
+func TestSKAEmissionStateAmounts(t *testing.T) {
+	t.Parallel()
+
+	db, teardown := createTestDB(t, "emissionstate_amounts")
+	defer teardown()
+
+	state, err := NewSKAEmissionState(db)
+	if err != nil {
+		t.Fatalf("NewSKAEmissionState failed: %v", err)
+	}
+
+	const coinType = cointype.CoinType(1)
+	amount, _ := new(big.Int).SetString("900000000000000000000000", 10)
+	emissions := []SKAEmissionRecord{{
+		CoinType: coinType,
+		Nonce:    1,
+		Amount:   amount,
+		Height:   100,
+	}}
+	err = db.Update(func(dbTx database.Tx) error {
+		return state.ConnectSKAEmissionsTx(dbTx, emissions)
+	})
+	if err != nil {
+		t.Fatalf("ConnectSKAEmissionsTx failed: %v", err)
+	}
+	if got := state.GetEmittedAmount(coinType); got == nil || got.Cmp(amount) != 0 {
+		t.Fatalf("mismatched emitted amount -- got %v, want %v", got, amount)
+	}
+
+	// Ensure the amount survives reloading the state from the database.
+	reloaded, err := NewSKAEmissionState(db)
+	if err != nil {
+		t.Fatalf("NewSKAEmissionState failed: %v", err)
+	}
+	if got := reloaded.GetEmittedAmount(coinType); got == nil || got.Cmp(amount) != 0 {
+		t.Fatalf("mismatched reloaded emitted amount -- got %v, want %v", got,
+			amount)
+	}
+	if got := reloaded.GetNonce(coinType); got != 1 {
+		t.Fatalf("mismatched reloaded nonce -- got %d, want 1", got)
+	}
+
+	// Ensure disconnecting the emission removes the amount.
+	err = db.Update(func(dbTx database.Tx) error {
+		return reloaded.DisconnectSKAEmissionsTx(dbTx, emissions)
+	})
+	if err != nil {
+		t.Fatalf("DisconnectSKAEmissionsTx failed: %v", err)
+	}
+	if got := reloaded.GetEmittedAmount(coinType); got != nil {
+		t.Fatalf("unexpected emitted amount after disconnect -- got %v", got)
+	}
+	if got := len(reloaded.GetAllEmittedAmounts()); got != 0 {
+		t.Fatalf("unexpected emitted amounts after disconnect -- got %d", got)
+	}
+}
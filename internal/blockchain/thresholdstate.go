@@ -907,6 +907,156 @@ func (b *BlockChain) IsSubsidySplitR2AgendaActive(prevHash *chainhash.Hash) (boo
 	return isActive, err
 }
 
+// isCoinTypeSigHashAgendaActive returns whether or not the agenda to enable
+// signatures that commit to the coin type, amount, and script version of the
+// outputs being spent has passed and is now active from the point of view of
+// the passed block node.
+//
+// It is important to note that, as the variable name indicates, this function
+// expects the block node prior to the block for which the deployment state is
+// desired.  In other words, the returned deployment state is for the block
+// AFTER the passed node.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) isCoinTypeSigHashAgendaActive(prevNode *blockNode) (bool, error) {
+	// Determine the correct deployment details for the coin type signature
+	// hash consensus vote.
+	const deploymentID = chaincfg.VoteIDCoinTypeSigHash
+	deployment, ok := b.deploymentData[deploymentID]
+	if !ok {
+		str := fmt.Sprintf("deployment ID %s does not exist", deploymentID)
+		return false, contextError(ErrUnknownDeploymentID, str)
+	}
+
+	// NOTE: The choice field of the return threshold state is not examined
+	// here because there is only one possible choice that can be active for
+	// the agenda, which is yes, so there is no need to check it.
+	state := b.deploymentState(prevNode, &deployment)
+	return state.State == ThresholdActive, nil
+}
+
+// IsCoinTypeSigHashAgendaActive returns whether or not the agenda to enable
+// signatures that commit to the coin type, amount, and script version of the
+// outputs being spent has passed and is now active for the block AFTER the
+// given block.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) IsCoinTypeSigHashAgendaActive(prevHash *chainhash.Hash) (bool, error) {
+	return b.isAgendaActiveByHash(prevHash, b.isCoinTypeSigHashAgendaActive)
+}
+
+// isIntrospectionAgendaActive returns whether or not the agenda to enable
+// version 1 scripts along with the opcodes they provide to introspect the coin
+// type and amount of the inputs and outputs of a transaction has passed and is
+// now active from the point of view of the passed block node.
+//
+// It is important to note that, as the variable name indicates, this function
+// expects the block node prior to the block for which the deployment state is
+// desired.  In other words, the returned deployment state is for the block
+// AFTER the passed node.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) isIntrospectionAgendaActive(prevNode *blockNode) (bool, error) {
+	// Determine the correct deployment details for the introspection
+	// consensus vote.
+	const deploymentID = chaincfg.VoteIDIntrospection
+	deployment, ok := b.deploymentData[deploymentID]
+	if !ok {
+		str := fmt.Sprintf("deployment ID %s does not exist", deploymentID)
+		return false, contextError(ErrUnknownDeploymentID, str)
+	}
+
+	// NOTE: The choice field of the return threshold state is not examined
+	// here because there is only one possible choice that can be active for
+	// the agenda, which is yes, so there is no need to check it.
+	state := b.deploymentState(prevNode, &deployment)
+	return state.State == ThresholdActive, nil
+}
+
+// IsIntrospectionAgendaActive returns whether or not the agenda to enable
+// version 1 scripts along with the opcodes they provide to introspect the coin
+// type and amount of the inputs and outputs of a transaction has passed and is
+// now active for the block AFTER the given block.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) IsIntrospectionAgendaActive(prevHash *chainhash.Hash) (bool, error) {
+	return b.isAgendaActiveByHash(prevHash, b.isIntrospectionAgendaActive)
+}
+
+// isSKASupplyCommitmentAgendaActive returns whether or not the agenda to
+// commit to the supply state of every SKA coin type in the block header has
+// passed and is now active from the point of view of the passed block node.
+//
+// It is important to note that, as the variable name indicates, this function
+// expects the block node prior to the block for which the deployment state is
+// desired.  In other words, the returned deployment state is for the block
+// AFTER the passed node.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) isSKASupplyCommitmentAgendaActive(prevNode *blockNode) (bool, error) {
+	// Determine the correct deployment details for the SKA supply commitment
+	// consensus vote.
+	const deploymentID = chaincfg.VoteIDSKASupplyCommitment
+	deployment, ok := b.deploymentData[deploymentID]
+	if !ok {
+		str := fmt.Sprintf("deployment ID %s does not exist", deploymentID)
+		return false, contextError(ErrUnknownDeploymentID, str)
+	}
+
+	// NOTE: The choice field of the return threshold state is not examined
+	// here because there is only one possible choice that can be active for
+	// the agenda, which is yes, so there is no need to check it.
+	state := b.deploymentState(prevNode, &deployment)
+	return state.State == ThresholdActive, nil
+}
+
+// IsSKASupplyCommitmentAgendaActive returns whether or not the agenda to commit
+// to the supply state of every SKA coin type in the block header has passed
+// and is now active for the block AFTER the given block.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) IsSKASupplyCommitmentAgendaActive(prevHash *chainhash.Hash) (bool, error) {
+	return b.isAgendaActiveByHash(prevHash, b.isSKASupplyCommitmentAgendaActive)
+}
+
+// isSchnorrEmissionAuthAgendaActive returns whether or not the agenda to
+// require Schnorr signatures for the emission authorizations of SKA coin types
+// configured for them has passed and is now active from the point of view of
+// the passed block node.
+//
+// It is important to note that, as the variable name indicates, this function
+// expects the block node prior to the block for which the deployment state is
+// desired.  In other words, the returned deployment state is for the block
+// AFTER the passed node.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) isSchnorrEmissionAuthAgendaActive(prevNode *blockNode) (bool, error) {
+	// Determine the correct deployment details for the Schnorr emission
+	// authorization consensus vote.
+	const deploymentID = chaincfg.VoteIDSchnorrEmissionAuth
+	deployment, ok := b.deploymentData[deploymentID]
+	if !ok {
+		str := fmt.Sprintf("deployment ID %s does not exist", deploymentID)
+		return false, contextError(ErrUnknownDeploymentID, str)
+	}
+
+	// NOTE: The choice field of the return threshold state is not examined
+	// here because there is only one possible choice that can be active for
+	// the agenda, which is yes, so there is no need to check it.
+	state := b.deploymentState(prevNode, &deployment)
+	return state.State == ThresholdActive, nil
+}
+
+// IsSchnorrEmissionAuthAgendaActive returns whether or not the agenda to
+// require Schnorr signatures for the emission authorizations of SKA coin types
+// configured for them has passed and is now active for the block AFTER the
+// given block.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) IsSchnorrEmissionAuthAgendaActive(prevHash *chainhash.Hash) (bool, error) {
+	return b.isAgendaActiveByHash(prevHash, b.isSchnorrEmissionAuthAgendaActive)
+}
+
 // VoteCounts is a compacted struct that is used to message vote counts.
 type VoteCounts struct {
 	Total        uint32
@@ -219,6 +219,12 @@ type UtxoBackend interface {
 	// The iterator must be released after use, by calling the Release method.
 	NewIterator(prefix []byte) UtxoBackendIterator
 
+	// NewSnapshot returns a read-only snapshot of the current state of the
+	// UTXO backend.
+	//
+	// The snapshot must be released after use, by calling the Release method.
+	NewSnapshot() (UtxoBackendSnapshot, error)
+
 	// PutInfo sets the versioning and creation information for the UTXO
 	// backend.
 	PutInfo(info *UtxoBackendInfo) error
@@ -453,6 +459,18 @@ func (l *levelDbUtxoBackend) NewIterator(prefix []byte) UtxoBackendIterator {
 	return l.db.NewIterator(slice, nil)
 }
 
+// NewSnapshot returns a read-only snapshot of the current state of the UTXO
+// backend.
+//
+// The snapshot must be released after use, by calling the Release method.
+func (l *levelDbUtxoBackend) NewSnapshot() (UtxoBackendSnapshot, error) {
+	snap, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, convertLdbErr(err, "failed to create leveldb snapshot")
+	}
+	return &levelDbUtxoBackendSnapshot{snap}, nil
+}
+
 // dbFetchUtxoEntry fetches the specified transaction output from the utxo set.
 //
 // When there is no entry for the provided output, nil will be returned for both
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// UtxoBackendSnapshot represents a read-only view of a UtxoBackend as of the
+// time the snapshot was created.  Changes made to the underlying UtxoBackend
+// after that point are not visible through the snapshot.
+//
+// Unlike a transaction, a snapshot does not prevent updates to the underlying
+// UtxoBackend, so it is suitable for long running read operations.
+//
+// The interface contract requires that these methods are safe for concurrent
+// access.
+type UtxoBackendSnapshot interface {
+	// NewIterator returns an iterator over the key/value pairs in the
+	// snapshot.  The returned iterator is NOT safe for concurrent use, but it
+	// is safe to use multiple iterators concurrently, with each in a dedicated
+	// goroutine.
+	//
+	// The prefix parameter allows for slicing the iterator to only contain keys
+	// with the given prefix.  A nil prefix is treated as a key BEFORE all keys.
+	//
+	// NOTE: The contents of any slice returned by the iterator should NOT be
+	// modified unless noted otherwise.
+	//
+	// The iterator must be released after use, by calling the Release method.
+	NewIterator(prefix []byte) UtxoBackendIterator
+
+	// Release releases the snapshot.  A snapshot must always be released after
+	// use.  Other methods should not be called after the snapshot has been
+	// released.
+	Release()
+}
+
+// levelDbUtxoBackendSnapshot represents a UtxoBackend snapshot.  It wraps an
+// underlying leveldb snapshot and implements the UtxoBackendSnapshot
+// interface.
+type levelDbUtxoBackendSnapshot struct {
+	*leveldb.Snapshot
+}
+
+// Ensure levelDbUtxoBackendSnapshot implements the UtxoBackendSnapshot
+// interface.
+var _ UtxoBackendSnapshot = (*levelDbUtxoBackendSnapshot)(nil)
+
+// NewIterator returns an iterator over the key/value pairs in the snapshot.
+// The returned iterator is NOT safe for concurrent use, but it is safe to use
+// multiple iterators concurrently, with each in a dedicated goroutine.
+//
+// The prefix parameter allows for slicing the iterator to only contain keys
+// with the given prefix.  A nil prefix is treated as a key BEFORE all keys.
+//
+// NOTE: The contents of any slice returned by the iterator should NOT be
+// modified unless noted otherwise.
+//
+// The iterator must be released after use, by calling the Release method.
+func (s *levelDbUtxoBackendSnapshot) NewIterator(prefix []byte) UtxoBackendIterator {
+	var slice *util.Range
+	if prefix != nil {
+		slice = util.BytesPrefix(prefix)
+	}
+	return s.Snapshot.NewIterator(slice, nil)
+}
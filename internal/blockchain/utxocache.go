@@ -182,6 +182,13 @@ type UtxoCache struct {
 	hits   uint64
 	misses uint64
 
+	// The following fields track the total number of flushes to the backend,
+	// the total number of entries evicted during those flushes, and the total
+	// time spent flushing.
+	flushes       uint64
+	evictions     uint64
+	flushDuration time.Duration
+
 	// timeNow defines the function to use to get the current local time.  It
 	// defaults to time.Now but an alternative function can be provided for
 	// testing purposes.
@@ -679,6 +686,8 @@ func (c *UtxoCache) shouldFlush(bestHash *chainhash.Hash) bool {
 //
 // This function MUST be called with the cache lock held.
 func (c *UtxoCache) flush(bestHash *chainhash.Hash, bestHeight uint32, logFlush bool) error {
+	flushStart := time.Now()
+
 	// If the maximum allowed size of the cache has been reached, determine the
 	// eviction height.
 	var evictionHeight uint32
@@ -744,6 +753,7 @@ func (c *UtxoCache) flush(bestHash *chainhash.Hash, bestHeight uint32, logFlush
 			if entry != nil {
 				c.totalEntrySize -= entry.size()
 			}
+			c.evictions++
 
 			continue
 		}
@@ -758,6 +768,8 @@ func (c *UtxoCache) flush(bestHash *chainhash.Hash, bestHeight uint32, logFlush
 	// completed.
 	c.lastFlushHash = *bestHash
 	c.lastFlushTime = c.timeNow()
+	c.flushes++
+	c.flushDuration += time.Since(flushStart)
 
 	// Update the last eviction height on the cache instance if we evicted just
 	// now.
@@ -781,6 +793,49 @@ func (c *UtxoCache) flush(bestHash *chainhash.Hash, bestHeight uint32, logFlush
 	return nil
 }
 
+// UtxoCacheStats houses statistics about the utxo cache.
+type UtxoCacheStats struct {
+	// Entries is the number of entries in the cache.
+	Entries uint64
+
+	// Size and MaxSize are the current and maximum allowed size of the cache,
+	// in bytes.
+	Size    uint64
+	MaxSize uint64
+
+	// Hits and Misses are the total number of lookups that were and were not
+	// served from the cache, respectively.
+	Hits   uint64
+	Misses uint64
+
+	// Flushes is the total number of flushes to the backend and Evictions is
+	// the total number of entries evicted from the cache by them.
+	Flushes   uint64
+	Evictions uint64
+
+	// FlushDuration is the total time spent flushing to the backend.
+	FlushDuration time.Duration
+}
+
+// Stats returns statistics about the cache since it was created.
+//
+// This function is safe for concurrent access.
+func (c *UtxoCache) Stats() UtxoCacheStats {
+	c.cacheLock.Lock()
+	stats := UtxoCacheStats{
+		Entries:       uint64(len(c.entries)),
+		Size:          c.totalSize(),
+		MaxSize:       c.maxSize,
+		Hits:          c.hits,
+		Misses:        c.misses,
+		Flushes:       c.flushes,
+		Evictions:     c.evictions,
+		FlushDuration: c.flushDuration,
+	}
+	c.cacheLock.Unlock()
+	return stats
+}
+
 // MaybeFlush conditionally flushes the cache to the backend.
 //
 // If the maximum size of the cache has been reached, or if the periodic flush
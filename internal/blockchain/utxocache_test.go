@@ -1138,6 +1138,8 @@ func TestMaybeFlush(t *testing.T) {
 		wantLastEvictionHeight   uint32
 		wantLastFlushHash        *chainhash.Hash
 		wantUpdatedLastFlushTime bool
+		wantFlushes              uint64
+		wantEvictions            uint64
 	}{{
 		name:               "flush not required",
 		maxSize:            1000,
@@ -1166,6 +1168,8 @@ func TestMaybeFlush(t *testing.T) {
 		wantLastEvictionHeight:   0,
 		wantLastFlushHash:        block1000Hash,
 		wantUpdatedLastFlushTime: false,
+		wantFlushes:              0,
+		wantEvictions:            0,
 	}, {
 		name:               "all entries flushed, some entries evicted",
 		maxSize:            0,
@@ -1199,6 +1203,8 @@ func TestMaybeFlush(t *testing.T) {
 		wantLastEvictionHeight:   300,
 		wantLastFlushHash:        block2000Hash,
 		wantUpdatedLastFlushTime: true,
+		wantFlushes:              1,
+		wantEvictions:            2,
 	}}
 
 	for _, test := range tests {
@@ -1293,6 +1299,21 @@ func TestMaybeFlush(t *testing.T) {
 			t.Fatalf("%q: unexpected total entry size -- got %v, want %v",
 				test.name, utxoCache.totalEntrySize, wantTotalEntrySize)
 		}
+
+		// Validate the flush statistics.
+		stats := utxoCache.Stats()
+		if stats.Flushes != test.wantFlushes {
+			t.Fatalf("%q: unexpected number of flushes -- got %d, want %d",
+				test.name, stats.Flushes, test.wantFlushes)
+		}
+		if stats.Evictions != test.wantEvictions {
+			t.Fatalf("%q: unexpected number of evictions -- got %d, want %d",
+				test.name, stats.Evictions, test.wantEvictions)
+		}
+		if stats.Entries != uint64(len(test.wantCachedEntries)) {
+			t.Fatalf("%q: unexpected number of entries -- got %d, want %d",
+				test.name, stats.Entries, len(test.wantCachedEntries))
+		}
 	}
 }
 
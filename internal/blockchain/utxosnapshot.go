@@ -0,0 +1,618 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/monetarium/monetarium-node/blockchain/standalone"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+const (
+	// utxoSnapshotMagic is the magic number that identifies a UTXO set
+	// snapshot file.  It is the ASCII string "musn" encoded as a little-endian
+	// uint32.
+	utxoSnapshotMagic = 0x6e73756d
+
+	// utxoSnapshotVersion is the current version of the UTXO set snapshot
+	// format.
+	utxoSnapshotVersion = 1
+
+	// maxUtxoSnapshotEntrySize is the maximum allowed size of a single
+	// serialized UTXO entry in a snapshot.  It is well above the size of any
+	// valid entry and only exists to prevent malformed snapshots from causing
+	// excessive allocations.
+	maxUtxoSnapshotEntrySize = 1 << 20
+
+	// maxUtxoSnapshotStateSize is the maximum allowed size of the serialized
+	// chain state in a snapshot.
+	maxUtxoSnapshotStateSize = 1 << 28
+)
+
+// -----------------------------------------------------------------------------
+// A UTXO set snapshot consists of a header followed by every unspent
+// transaction output in the UTXO set, the chain state that is required to
+// continue validating blocks after the snapshot block, and a trailer that
+// commits to both.
+//
+// The serialized format is:
+//
+//   <header><utxo entries><terminator><state><trailer>
+//
+//   Field          Type            Size
+//   magic          uint32          4 bytes
+//   version        uint32          4 bytes
+//   network        uint32          4 bytes
+//   block hash     chainhash.Hash  32 bytes
+//   block height   uint32          4 bytes
+//   utxo entries   []utxo entry    variable
+//   terminator     varint          1 byte (always 0)
+//   state          varbytes        variable
+//   utxo hash      chainhash.Hash  32 bytes
+//   state hash     chainhash.Hash  32 bytes
+//
+// Each UTXO entry is serialized as:
+//
+//   Field          Type            Size
+//   entry          varbytes        variable (the UTXO backend serialization)
+//   tx hash        chainhash.Hash  32 bytes
+//   tree           int8            1 byte
+//   output index   varint          variable
+//
+// Since the entries use the same serialization as the UTXO backend, they
+// include the coin type and full precision SKA amounts.  The utxo hash is the
+// merkle root of a leaf hash for each entry in the order they appear, which is
+// the same order the UTXO backend stores them in.  Each leaf is the hash of:
+//
+//   Field          Type            Size
+//   tx hash        chainhash.Hash  32 bytes
+//   tree           int8            1 byte
+//   output index   uint32          4 bytes
+//   entry          []byte          variable (the UTXO backend serialization)
+//
+// Committing to the outpoint in every leaf ensures the entries can't be
+// reassigned to different outpoints without changing the utxo hash.
+//
+// The state is serialized as:
+//
+//   Field            Type              Size
+//   treasury balance int64             8 bytes
+//   live tickets     []chainhash.Hash  varint count + 32 bytes each
+//   missed tickets   []chainhash.Hash  varint count + 32 bytes each
+//   revoked tickets  []chainhash.Hash  varint count + 32 bytes each
+//   emissions        varint count, then for each coin type in ascending order:
+//                      coin type uint8, nonce uint64, emitted uint8,
+//                      emitted amount varbytes (big endian)
+//   burns            varint count, then for each coin type in ascending order:
+//                      coin type uint8, amount varbytes (big endian)
+//
+// The state hash is the hash of the serialized state.
+//
+// The emission and burn entries carry the full supply state of every SKA coin
+// type, so the root of the SKA supply merkle tree as of the snapshot block can
+// be calculated from the state alone.
+//
+// NOTE: Snapshots are currently only dumped and verified.  Bootstrapping a node
+// from a snapshot requires the snapshot block hashes to be committed to by the
+// chain parameters and the snapshot to be validated in the background, neither
+// of which is supported yet.
+// -----------------------------------------------------------------------------
+
+// UtxoSnapshotInfo describes a UTXO set snapshot.
+type UtxoSnapshotInfo struct {
+	// Height and BlockHash identify the block the snapshot was taken at.
+	Height    int64
+	BlockHash chainhash.Hash
+
+	// NumUtxos is the number of unspent transaction outputs in the snapshot.
+	NumUtxos int64
+
+	// UtxoHash commits to the unspent transaction outputs in the snapshot.
+	UtxoHash chainhash.Hash
+
+	// StateHash commits to the stake and SKA state in the snapshot.
+	StateHash chainhash.Hash
+
+	// SKASupplyRoot is the root of the SKA supply merkle tree calculated from
+	// the SKA state in the snapshot.  It matches the SKA supply root the
+	// snapshot block commits to when the SKA supply commitment agenda is
+	// active.
+	SKASupplyRoot chainhash.Hash
+}
+
+// utxoSnapshotState houses the chain state other than the UTXO set that is
+// required to continue validating blocks after a snapshot block.
+type utxoSnapshotState struct {
+	treasuryBalance int64
+	liveTickets     []chainhash.Hash
+	missedTickets   []chainhash.Hash
+	revokedTickets  []chainhash.Hash
+	emissionNonces  map[cointype.CoinType]uint64
+	emitted         map[cointype.CoinType]bool
+	emittedAmounts  map[cointype.CoinType]*big.Int
+	burned          map[cointype.CoinType]*big.Int
+}
+
+// emissionCoinTypes returns the coin types that have emission state in
+// ascending order without duplicates.
+func (s *utxoSnapshotState) emissionCoinTypes() []cointype.CoinType {
+	seen := make(map[cointype.CoinType]struct{}, len(s.emissionNonces))
+	coinTypes := make([]cointype.CoinType, 0, len(s.emissionNonces))
+	add := func(coinType cointype.CoinType) {
+		if _, ok := seen[coinType]; !ok {
+			seen[coinType] = struct{}{}
+			coinTypes = append(coinTypes, coinType)
+		}
+	}
+	for coinType := range s.emissionNonces {
+		add(coinType)
+	}
+	for coinType := range s.emitted {
+		add(coinType)
+	}
+	for coinType := range s.emittedAmounts {
+		add(coinType)
+	}
+	sort.Slice(coinTypes, func(i, j int) bool {
+		return coinTypes[i] < coinTypes[j]
+	})
+	return coinTypes
+}
+
+// serialize returns the state serialized as described above.
+func (s *utxoSnapshotState) serialize() []byte {
+	var buf bytes.Buffer
+	writeHashes := func(hashes []chainhash.Hash) {
+		_ = wire.WriteVarInt(&buf, 0, uint64(len(hashes)))
+		for i := range hashes {
+			buf.Write(hashes[i][:])
+		}
+	}
+
+	var scratch [8]byte
+	binary.LittleEndian.PutUint64(scratch[:], uint64(s.treasuryBalance))
+	buf.Write(scratch[:])
+	writeHashes(s.liveTickets)
+	writeHashes(s.missedTickets)
+	writeHashes(s.revokedTickets)
+
+	coinTypes := s.emissionCoinTypes()
+	_ = wire.WriteVarInt(&buf, 0, uint64(len(coinTypes)))
+	for _, coinType := range coinTypes {
+		buf.WriteByte(byte(coinType))
+		binary.LittleEndian.PutUint64(scratch[:], s.emissionNonces[coinType])
+		buf.Write(scratch[:])
+		var emitted byte
+		if s.emitted[coinType] {
+			emitted = 1
+		}
+		buf.WriteByte(emitted)
+		var amount []byte
+		if emittedAmount := s.emittedAmounts[coinType]; emittedAmount != nil {
+			amount = emittedAmount.Bytes()
+		}
+		_ = wire.WriteVarBytes(&buf, 0, amount)
+	}
+
+	burnCoinTypes := make([]cointype.CoinType, 0, len(s.burned))
+	for coinType := range s.burned {
+		burnCoinTypes = append(burnCoinTypes, coinType)
+	}
+	sort.Slice(burnCoinTypes, func(i, j int) bool {
+		return burnCoinTypes[i] < burnCoinTypes[j]
+	})
+	_ = wire.WriteVarInt(&buf, 0, uint64(len(burnCoinTypes)))
+	for _, coinType := range burnCoinTypes {
+		buf.WriteByte(byte(coinType))
+		_ = wire.WriteVarBytes(&buf, 0, s.burned[coinType].Bytes())
+	}
+
+	return buf.Bytes()
+}
+
+// deserializeUtxoSnapshotState decodes the passed serialized state as
+// described above.
+func deserializeUtxoSnapshotState(serialized []byte) (*utxoSnapshotState, error) {
+	r := bytes.NewReader(serialized)
+	readHashes := func() ([]chainhash.Hash, error) {
+		count, err := wire.ReadVarInt(r, 0)
+		if err != nil {
+			return nil, err
+		}
+		if count > uint64(r.Len())/chainhash.HashSize {
+			return nil, io.ErrUnexpectedEOF
+		}
+		hashes := make([]chainhash.Hash, count)
+		for i := range hashes {
+			if _, err := io.ReadFull(r, hashes[i][:]); err != nil {
+				return nil, err
+			}
+		}
+		return hashes, nil
+	}
+
+	var s utxoSnapshotState
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	s.treasuryBalance = int64(binary.LittleEndian.Uint64(scratch[:]))
+	var err error
+	if s.liveTickets, err = readHashes(); err != nil {
+		return nil, err
+	}
+	if s.missedTickets, err = readHashes(); err != nil {
+		return nil, err
+	}
+	if s.revokedTickets, err = readHashes(); err != nil {
+		return nil, err
+	}
+
+	numEmissions, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if numEmissions > 256 {
+		return nil, fmt.Errorf("too many emission entries (%d)", numEmissions)
+	}
+	s.emissionNonces = make(map[cointype.CoinType]uint64, numEmissions)
+	s.emitted = make(map[cointype.CoinType]bool, numEmissions)
+	s.emittedAmounts = make(map[cointype.CoinType]*big.Int, numEmissions)
+	for i := uint64(0); i < numEmissions; i++ {
+		var entry [10]byte
+		if _, err := io.ReadFull(r, entry[:]); err != nil {
+			return nil, err
+		}
+		amount, err := wire.ReadVarBytes(r, 0, 64, "emitted amount")
+		if err != nil {
+			return nil, err
+		}
+		coinType := cointype.CoinType(entry[0])
+		s.emissionNonces[coinType] = binary.LittleEndian.Uint64(entry[1:9])
+		s.emitted[coinType] = entry[9] != 0
+		s.emittedAmounts[coinType] = new(big.Int).SetBytes(amount)
+	}
+
+	numBurns, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if numBurns > 256 {
+		return nil, fmt.Errorf("too many burn entries (%d)", numBurns)
+	}
+	s.burned = make(map[cointype.CoinType]*big.Int, numBurns)
+	for i := uint64(0); i < numBurns; i++ {
+		coinType, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		amount, err := wire.ReadVarBytes(r, 0, 64, "burned amount")
+		if err != nil {
+			return nil, err
+		}
+		s.burned[cointype.CoinType(coinType)] = new(big.Int).SetBytes(amount)
+	}
+
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%d unexpected trailing bytes", r.Len())
+	}
+	return &s, nil
+}
+
+// skaSupplies returns the supply state of every SKA coin type that has been
+// emitted or burned as of the snapshot block sorted by coin type in ascending
+// order.
+func (s *utxoSnapshotState) skaSupplies() []SKASupply {
+	state := make(skaSupplyState)
+	for coinType, nonce := range s.emissionNonces {
+		state.entry(coinType).Nonce = nonce
+	}
+	for coinType, amount := range s.emittedAmounts {
+		state.entry(coinType).Emitted.Set(amount)
+	}
+	for coinType, amount := range s.burned {
+		state.entry(coinType).Burned.Set(amount)
+	}
+	return state.supplies()
+}
+
+// utxoSnapshotLeaf returns the leaf hash that commits to the provided outpoint
+// and its serialized UTXO entry.  See the UTXO set snapshot format description
+// for details.
+func utxoSnapshotLeaf(outpoint *wire.OutPoint, serializedUtxo []byte) chainhash.Hash {
+	const outpointSize = chainhash.HashSize + 1 + 4
+	buf := make([]byte, outpointSize+len(serializedUtxo))
+	copy(buf, outpoint.Hash[:])
+	buf[chainhash.HashSize] = byte(outpoint.Tree)
+	binary.LittleEndian.PutUint32(buf[chainhash.HashSize+1:], outpoint.Index)
+	copy(buf[outpointSize:], serializedUtxo)
+	return chainhash.HashH(buf)
+}
+
+// prepareUtxoSnapshot flushes the UTXO cache and returns the chain state along
+// with a snapshot of the UTXO backend as of the provided block, which must be
+// the current best chain tip.  The returned backend snapshot must be released
+// by the caller.
+//
+// This function MUST be called with the chain lock held (for writes).
+func (b *BlockChain) prepareUtxoSnapshot(tip *blockNode) (*utxoSnapshotState, UtxoBackendSnapshot, error) {
+	// Force a UTXO cache flush so the backend contains the full UTXO set as of
+	// the current tip.
+	err := b.utxoCache.MaybeFlush(&tip.hash, uint32(tip.height), true, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Gather the chain state.
+	stakeNode, err := b.fetchStakeNode(tip)
+	if err != nil {
+		return nil, nil, err
+	}
+	var treasuryBalance int64
+	if tip.parent != nil {
+		isTreasuryEnabled, err := b.isTreasuryAgendaActive(tip.parent)
+		if err != nil {
+			return nil, nil, err
+		}
+		if isTreasuryEnabled {
+			ts, err := b.dbFetchTreasurySingle(tip.hash)
+			if err != nil {
+				return nil, nil, err
+			}
+			treasuryBalance = ts.balance
+		}
+	}
+	state := &utxoSnapshotState{
+		treasuryBalance: treasuryBalance,
+		liveTickets:     stakeNode.LiveTickets(),
+		missedTickets:   stakeNode.MissedTickets(),
+		burned:          make(map[cointype.CoinType]*big.Int),
+	}
+	for _, hash := range stakeNode.RevokedTickets() {
+		state.revokedTickets = append(state.revokedTickets, *hash)
+	}
+	if b.skaEmissionState != nil {
+		state.emissionNonces, state.emitted =
+			b.skaEmissionState.GetEmissionStateSnapshot()
+		state.emittedAmounts = b.skaEmissionState.GetAllEmittedAmounts()
+	}
+	if b.skaBurnState != nil {
+		state.burned = b.skaBurnState.GetAllBurnedAmounts()
+	}
+
+	utxoSnap, err := b.utxoBackend.NewSnapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	return state, utxoSnap, nil
+}
+
+// DumpUtxoSnapshot writes a snapshot of the UTXO set and the associated chain
+// state as of the current best chain tip to the provided writer.
+//
+// The chain lock is only held while the UTXO cache is flushed and the chain
+// state is gathered.  The UTXO set is then read from a snapshot of the UTXO
+// backend, so blocks may continue to be processed while the dump is in
+// progress.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) DumpUtxoSnapshot(w io.Writer) (*UtxoSnapshotInfo, error) {
+	b.chainLock.Lock()
+	tip := b.bestChain.Tip()
+	state, utxoSnap, err := b.prepareUtxoSnapshot(tip)
+	b.chainLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	defer utxoSnap.Release()
+
+	// Write the header.
+	bw := bufio.NewWriter(w)
+	var header [4 + 4 + 4 + chainhash.HashSize + 4]byte
+	binary.LittleEndian.PutUint32(header[0:4], utxoSnapshotMagic)
+	binary.LittleEndian.PutUint32(header[4:8], utxoSnapshotVersion)
+	binary.LittleEndian.PutUint32(header[8:12], uint32(b.chainParams.Net))
+	copy(header[12:44], tip.hash[:])
+	binary.LittleEndian.PutUint32(header[44:48], uint32(tip.height))
+	if _, err := bw.Write(header[:]); err != nil {
+		return nil, err
+	}
+
+	// Write every entry in the UTXO set.
+	info := UtxoSnapshotInfo{
+		Height:        tip.height,
+		BlockHash:     tip.hash,
+		SKASupplyRoot: CalcSKASupplyRoot(state.skaSupplies()),
+	}
+	var leaves []chainhash.Hash
+	iter := utxoSnap.NewIterator(utxoPrefixUtxoSet)
+	defer iter.Release()
+	for iter.Next() {
+		select {
+		case <-b.interrupt:
+			return nil, errInterruptRequested
+		default:
+		}
+
+		key := iter.Key()
+		var outpoint wire.OutPoint
+		if err := decodeOutpointKey(key, &outpoint); err != nil {
+			str := fmt.Sprintf("corrupt outpoint for key %x: %v", key, err)
+			return nil, contextError(ErrUtxoBackendCorruption, str)
+		}
+		serializedUtxo := iter.Value()
+		if len(serializedUtxo) == 0 {
+			return nil, AssertError(fmt.Sprintf("database contains entry "+
+				"for spent tx output %v", outpoint))
+		}
+
+		if err := wire.WriteVarBytes(bw, 0, serializedUtxo); err != nil {
+			return nil, err
+		}
+		if _, err := bw.Write(outpoint.Hash[:]); err != nil {
+			return nil, err
+		}
+		if err := bw.WriteByte(byte(outpoint.Tree)); err != nil {
+			return nil, err
+		}
+		if err := wire.WriteVarInt(bw, 0, uint64(outpoint.Index)); err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, utxoSnapshotLeaf(&outpoint, serializedUtxo))
+		info.NumUtxos++
+	}
+	if err := iter.Error(); err != nil {
+		return nil, convertLdbErr(err, "failed to iterate utxo set")
+	}
+
+	// Write the terminator, state, and trailer.
+	if err := wire.WriteVarInt(bw, 0, 0); err != nil {
+		return nil, err
+	}
+	serializedState := state.serialize()
+	if err := wire.WriteVarBytes(bw, 0, serializedState); err != nil {
+		return nil, err
+	}
+	info.UtxoHash = standalone.CalcMerkleRootInPlace(leaves)
+	info.StateHash = chainhash.HashH(serializedState)
+	if _, err := bw.Write(info.UtxoHash[:]); err != nil {
+		return nil, err
+	}
+	if _, err := bw.Write(info.StateHash[:]); err != nil {
+		return nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+
+	log.Infof("Wrote UTXO set snapshot with %d entries at height %d (hash %v)",
+		info.NumUtxos, info.Height, info.BlockHash)
+	return &info, nil
+}
+
+// ReadUtxoSnapshot reads a UTXO set snapshot for the provided network from the
+// provided reader and ensures it is well formed and matches the hashes it
+// commits to.  The provided function, when not nil, is invoked with each
+// unspent transaction output in the snapshot.
+//
+// Note that this only ensures the snapshot is internally consistent.  Callers
+// must independently ensure that the returned hashes are trustworthy, such as
+// by comparing them to the hashes of a snapshot dumped by a trusted node.
+func ReadUtxoSnapshot(r io.Reader, net wire.CurrencyNet, fn func(outpoint wire.OutPoint, entry *UtxoEntry) error) (*UtxoSnapshotInfo, error) {
+	malformed := func(format string, args ...interface{}) error {
+		str := fmt.Sprintf(format, args...)
+		return contextError(ErrMalformedUtxoSnapshot, str)
+	}
+
+	// Read and validate the header.
+	br := bufio.NewReader(r)
+	var header [4 + 4 + 4 + chainhash.HashSize + 4]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, malformed("unable to read snapshot header: %v", err)
+	}
+	if magic := binary.LittleEndian.Uint32(header[0:4]); magic != utxoSnapshotMagic {
+		return nil, malformed("invalid snapshot magic %08x", magic)
+	}
+	version := binary.LittleEndian.Uint32(header[4:8])
+	if version != utxoSnapshotVersion {
+		return nil, malformed("unsupported snapshot version %d", version)
+	}
+	snapshotNet := wire.CurrencyNet(binary.LittleEndian.Uint32(header[8:12]))
+	if snapshotNet != net {
+		return nil, malformed("snapshot is for network %v instead of %v",
+			snapshotNet, net)
+	}
+	var info UtxoSnapshotInfo
+	copy(info.BlockHash[:], header[12:44])
+	info.Height = int64(binary.LittleEndian.Uint32(header[44:48]))
+
+	// Read the UTXO set entries.
+	var leaves []chainhash.Hash
+	for {
+		serializedUtxo, err := wire.ReadVarBytes(br, 0,
+			maxUtxoSnapshotEntrySize, "utxo entry")
+		if err != nil {
+			return nil, malformed("unable to read utxo entry %d: %v",
+				info.NumUtxos, err)
+		}
+		if len(serializedUtxo) == 0 {
+			break
+		}
+
+		var outpoint wire.OutPoint
+		if _, err := io.ReadFull(br, outpoint.Hash[:]); err != nil {
+			return nil, malformed("unable to read utxo entry %d: %v",
+				info.NumUtxos, err)
+		}
+		tree, err := br.ReadByte()
+		if err != nil {
+			return nil, malformed("unable to read utxo entry %d: %v",
+				info.NumUtxos, err)
+		}
+		index, err := wire.ReadVarInt(br, 0)
+		if err != nil || index > uint64(^uint32(0)) {
+			return nil, malformed("unable to read utxo entry %d: invalid "+
+				"output index", info.NumUtxos)
+		}
+		outpoint.Tree = int8(tree)
+		outpoint.Index = uint32(index)
+
+		entry, err := deserializeUtxoEntry(serializedUtxo, outpoint.Index)
+		if err != nil {
+			return nil, malformed("corrupt utxo entry for %v: %v", outpoint,
+				err)
+		}
+		if fn != nil {
+			if err := fn(outpoint, entry); err != nil {
+				return nil, err
+			}
+		}
+		leaves = append(leaves, utxoSnapshotLeaf(&outpoint, serializedUtxo))
+		info.NumUtxos++
+	}
+
+	// Read and validate the state.
+	serializedState, err := wire.ReadVarBytes(br, 0, maxUtxoSnapshotStateSize,
+		"snapshot state")
+	if err != nil {
+		return nil, malformed("unable to read snapshot state: %v", err)
+	}
+	state, err := deserializeUtxoSnapshotState(serializedState)
+	if err != nil {
+		return nil, malformed("corrupt snapshot state: %v", err)
+	}
+	info.SKASupplyRoot = CalcSKASupplyRoot(state.skaSupplies())
+
+	// Ensure the snapshot matches the hashes it commits to.
+	var trailer [chainhash.HashSize * 2]byte
+	if _, err := io.ReadFull(br, trailer[:]); err != nil {
+		return nil, malformed("unable to read snapshot trailer: %v", err)
+	}
+	info.UtxoHash = standalone.CalcMerkleRootInPlace(leaves)
+	info.StateHash = chainhash.HashH(serializedState)
+	if !bytes.Equal(info.UtxoHash[:], trailer[:chainhash.HashSize]) {
+		return nil, malformed("snapshot utxo hash %v does not match the "+
+			"committed hash", info.UtxoHash)
+	}
+	if !bytes.Equal(info.StateHash[:], trailer[chainhash.HashSize:]) {
+		return nil, malformed("snapshot state hash %v does not match the "+
+			"committed hash", info.StateHash)
+	}
+	if _, err := br.ReadByte(); !errors.Is(err, io.EOF) {
+		return nil, malformed("unexpected data after snapshot trailer")
+	}
+
+	return &info, nil
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/chaincfg"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// swapUtxoSnapshotOutpoints returns a copy of the provided serialized UTXO set
+// snapshot with the outpoints of the first entry and the first subsequent entry
+// that is for a different transaction swapped.  All entries remain well formed.
+func swapUtxoSnapshotOutpoints(t *testing.T, snapshot []byte) []byte {
+	t.Helper()
+
+	// Split the entries into their serialized UTXO and outpoint portions.
+	const headerLen = 4 + 4 + 4 + chainhash.HashSize + 4
+	type snapshotEntry struct {
+		serializedUtxo []byte
+		outpoint       []byte
+	}
+	var entries []snapshotEntry
+	r := bytes.NewReader(snapshot[headerLen:])
+	for {
+		serializedUtxo, err := wire.ReadVarBytes(r, 0, 1<<20, "utxo entry")
+		if err != nil {
+			t.Fatalf("unable to read utxo entry: %v", err)
+		}
+		if len(serializedUtxo) == 0 {
+			break
+		}
+		start := len(snapshot) - r.Len()
+		if _, err := r.Seek(chainhash.HashSize+1, io.SeekCurrent); err != nil {
+			t.Fatalf("unable to read utxo entry: %v", err)
+		}
+		if _, err := wire.ReadVarInt(r, 0); err != nil {
+			t.Fatalf("unable to read utxo entry: %v", err)
+		}
+		end := len(snapshot) - r.Len()
+		entries = append(entries, snapshotEntry{serializedUtxo,
+			snapshot[start:end]})
+	}
+	rest := snapshot[len(snapshot)-r.Len()-1:]
+
+	// Swap the outpoints.
+	swapped := false
+	for i := 1; i < len(entries); i++ {
+		firstHash := entries[0].outpoint[:chainhash.HashSize]
+		if !bytes.Equal(entries[i].outpoint[:chainhash.HashSize], firstHash) {
+			entries[0].outpoint, entries[i].outpoint = entries[i].outpoint,
+				entries[0].outpoint
+			swapped = true
+			break
+		}
+	}
+	if !swapped {
+		t.Fatal("snapshot does not contain entries for multiple transactions")
+	}
+
+	// Reassemble the snapshot.
+	var buf bytes.Buffer
+	buf.Write(snapshot[:headerLen])
+	for _, entry := range entries {
+		if err := wire.WriteVarBytes(&buf, 0, entry.serializedUtxo); err != nil {
+			t.Fatalf("unable to write utxo entry: %v", err)
+		}
+		buf.Write(entry.outpoint)
+	}
+	buf.Write(rest)
+	return buf.Bytes()
+}
+
+// TestUtxoSnapshot ensures UTXO set snapshots can be dumped and read back and
+// that malformed snapshots are rejected.
+func TestUtxoSnapshot(t *testing.T) {
+	params := chaincfg.RegNetParams()
+	g := newChaingenHarness(t, params)
+	g.AdvanceToStakeValidationHeight()
+
+	// Dump a snapshot and ensure it contains the same UTXO set as the
+	// statistics.
+	var buf bytes.Buffer
+	info, err := g.chain.DumpUtxoSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error dumping snapshot: %v", err)
+	}
+	stats, err := g.chain.FetchUtxoStats()
+	if err != nil {
+		t.Fatalf("unexpected error fetching utxo stats: %v", err)
+	}
+	tip := g.chain.BestSnapshot()
+	if info.Height != tip.Height || info.BlockHash != tip.Hash {
+		t.Fatalf("unexpected snapshot block -- got %v (%d), want %v (%d)",
+			info.BlockHash, info.Height, tip.Hash, tip.Height)
+	}
+	if info.NumUtxos != stats.Utxos {
+		t.Fatalf("unexpected number of utxos -- got %d, want %d",
+			info.NumUtxos, stats.Utxos)
+	}
+	g.chain.chainLock.RLock()
+	wantSupplyRoot := CalcSKASupplyRoot(g.chain.tipSKASupplyState().supplies())
+	g.chain.chainLock.RUnlock()
+	if info.SKASupplyRoot != wantSupplyRoot {
+		t.Fatalf("unexpected SKA supply root -- got %v, want %v",
+			info.SKASupplyRoot, wantSupplyRoot)
+	}
+	snapshot := buf.Bytes()
+
+	// Ensure reading the snapshot back visits every utxo and produces the same
+	// info.
+	var numVisited int64
+	readInfo, err := ReadUtxoSnapshot(bytes.NewReader(snapshot), params.Net,
+		func(outpoint wire.OutPoint, entry *UtxoEntry) error {
+			numVisited++
+			if entry.IsSpent() {
+				t.Errorf("snapshot contains spent output %v", outpoint)
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error reading snapshot: %v", err)
+	}
+	if *readInfo != *info {
+		t.Fatalf("mismatched snapshot info -- got %+v, want %+v", readInfo,
+			info)
+	}
+	if numVisited != info.NumUtxos {
+		t.Fatalf("unexpected number of visited utxos -- got %d, want %d",
+			numVisited, info.NumUtxos)
+	}
+
+	// Ensure malformed snapshots are rejected.
+	tests := []struct {
+		name     string
+		snapshot func() []byte
+	}{{
+		name: "truncated",
+		snapshot: func() []byte {
+			return snapshot[:len(snapshot)-1]
+		},
+	}, {
+		name: "trailing data",
+		snapshot: func() []byte {
+			return append(append([]byte(nil), snapshot...), 0x00)
+		},
+	}, {
+		name: "bad magic",
+		snapshot: func() []byte {
+			s := append([]byte(nil), snapshot...)
+			s[0] ^= 0xff
+			return s
+		},
+	}, {
+		name: "wrong network",
+		snapshot: func() []byte {
+			s := append([]byte(nil), snapshot...)
+			s[8] ^= 0xff
+			return s
+		},
+	}, {
+		name: "swapped outpoints",
+		snapshot: func() []byte {
+			return swapUtxoSnapshotOutpoints(t, snapshot)
+		},
+	}, {
+		name: "modified utxo",
+		snapshot: func() []byte {
+			// The first entry begins with its length followed by the
+			// serialized entry, the first byte of which is the block
+			// height, so modifying it retains a well-formed entry.
+			s := append([]byte(nil), snapshot...)
+			s[49] ^= 0x01
+			return s
+		},
+	}}
+	for _, test := range tests {
+		_, err := ReadUtxoSnapshot(bytes.NewReader(test.snapshot()),
+			params.Net, nil)
+		if !errors.Is(err, ErrMalformedUtxoSnapshot) {
+			t.Errorf("%q: unexpected error -- got %v, want %v", test.name, err,
+				ErrMalformedUtxoSnapshot)
+		}
+	}
+}
+
+// TestUtxoSnapshotState ensures the chain state in UTXO set snapshots
+// round trips and retains the SKA supply state required to calculate the SKA
+// supply root.
+func TestUtxoSnapshotState(t *testing.T) {
+	hexToBigInt := func(s string) *big.Int {
+		t.Helper()
+		n, ok := new(big.Int).SetString(s, 16)
+		if !ok {
+			t.Fatalf("invalid big int %q", s)
+		}
+		return n
+	}
+
+	// The emitted amount of coin type 1 exceeds the range of an int64 and coin
+	// type 3 has only been burned.
+	state := &utxoSnapshotState{
+		treasuryBalance: 12345,
+		liveTickets:     []chainhash.Hash{{0x01}, {0x02}},
+		missedTickets:   []chainhash.Hash{{0x03}},
+		emissionNonces:  map[cointype.CoinType]uint64{1: 1, 2: 1},
+		emitted:         map[cointype.CoinType]bool{1: true, 2: true},
+		emittedAmounts: map[cointype.CoinType]*big.Int{
+			1: hexToBigInt("d3c21bcecceda1000000"),
+			2: big.NewInt(5000),
+		},
+		burned: map[cointype.CoinType]*big.Int{
+			1: hexToBigInt("de0b6b3a7640000"),
+			3: big.NewInt(7),
+		},
+	}
+	wantSupplies := []SKASupply{{
+		CoinType: 1,
+		Nonce:    1,
+		Emitted:  hexToBigInt("d3c21bcecceda1000000"),
+		Burned:   hexToBigInt("de0b6b3a7640000"),
+	}, {
+		CoinType: 2,
+		Nonce:    1,
+		Emitted:  big.NewInt(5000),
+		Burned:   new(big.Int),
+	}, {
+		CoinType: 3,
+		Emitted:  new(big.Int),
+		Burned:   big.NewInt(7),
+	}}
+	wantRoot := CalcSKASupplyRoot(wantSupplies)
+
+	serialized := state.serialize()
+	got, err := deserializeUtxoSnapshotState(serialized)
+	if err != nil {
+		t.Fatalf("unexpected error deserializing state: %v", err)
+	}
+	if !bytes.Equal(got.serialize(), serialized) {
+		t.Fatalf("state does not round trip -- got %x, want %x",
+			got.serialize(), serialized)
+	}
+	for coinType, want := range state.emittedAmounts {
+		if amount := got.emittedAmounts[coinType]; amount.Cmp(want) != 0 {
+			t.Fatalf("unexpected emitted amount for coin type %d -- got %v, "+
+				"want %v", coinType, amount, want)
+		}
+	}
+	if root := CalcSKASupplyRoot(got.skaSupplies()); root != wantRoot {
+		t.Fatalf("unexpected SKA supply root -- got %v, want %v", root,
+			wantRoot)
+	}
+
+	// Ensure truncated state is rejected.
+	for i := 0; i < len(serialized); i++ {
+		if _, err := deserializeUtxoSnapshotState(serialized[:i]); err == nil {
+			t.Fatalf("truncated state of length %d was not rejected", i)
+		}
+	}
+}
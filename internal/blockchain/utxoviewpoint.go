@@ -239,6 +239,23 @@ func (view *UtxoViewpoint) PrevScript(prevOut *wire.OutPoint) (uint16, []byte, b
 	return version, pkScript, true
 }
 
+// PrevOutput returns the coin type, amount, and script version associated with
+// the provided previous outpoint along with a bool that indicates whether or
+// not the requested entry exists.
+func (view *UtxoViewpoint) PrevOutput(prevOut *wire.OutPoint) (txscript.PrevOutput, bool) {
+	entry := view.LookupEntry(*prevOut)
+	if entry == nil {
+		return txscript.PrevOutput{}, false
+	}
+
+	return txscript.PrevOutput{
+		CoinType: entry.CoinType(),
+		Value:    entry.Amount(),
+		SKAValue: entry.SKAAmount(),
+		Version:  entry.ScriptVersion(),
+	}, true
+}
+
 // PriorityInput returns the block height and amount associated with the
 // provided previous outpoint along with a bool that indicates whether or not
 // the requested entry exists.  This ensures the caller is able to distinguish
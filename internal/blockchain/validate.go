@@ -5090,6 +5090,26 @@ func (b *BlockChain) consensusScriptVerifyFlags(node *blockNode) (txscript.Scrip
 		scriptFlags |= txscript.ScriptVerifyTreasury
 	}
 
+	// Enable signatures that commit to the spent outputs if the stake vote
+	// for the agenda is active.
+	isCoinTypeSigHashEnabled, err := b.isCoinTypeSigHashAgendaActive(node.parent)
+	if err != nil {
+		return 0, err
+	}
+	if isCoinTypeSigHashEnabled {
+		scriptFlags |= txscript.ScriptVerifyCoinTypeSigHash
+	}
+
+	// Enable version 1 scripts and the introspection opcodes they provide if
+	// the stake vote for the agenda is active.
+	isIntrospectionEnabled, err := b.isIntrospectionAgendaActive(node.parent)
+	if err != nil {
+		return 0, err
+	}
+	if isIntrospectionEnabled {
+		scriptFlags |= txscript.ScriptVerifyIntrospection
+	}
+
 	return scriptFlags, err
 }
 
@@ -5512,6 +5532,26 @@ func (b *BlockChain) checkConnectBlock(node *blockNode, block, parent *dcrutil.B
 	}
 	if hdrCommitmentsActive {
 		wantCommitmentRoot := CalcCommitmentRootV1(filterHash)
+
+		// The commitment root also commits to the supply state of every SKA
+		// coin type once the vote for the SKA supply commitment agenda is
+		// active.
+		skaSupplyCmtActive, err := b.isSKASupplyCommitmentAgendaActive(
+			node.parent)
+		if err != nil {
+			return err
+		}
+		if skaSupplyCmtActive {
+			skaSupplyRoot, err := b.calcSKASupplyRoot(node.parent, block)
+			if err != nil {
+				return err
+			}
+			if hdrCommitments != nil {
+				hdrCommitments.skaSupplyRoot = skaSupplyRoot
+			}
+			wantCommitmentRoot = CalcCommitmentRootV2(filterHash, skaSupplyRoot)
+		}
+
 		header := &block.MsgBlock().Header
 		if header.StakeRoot != wantCommitmentRoot {
 			str := fmt.Sprintf("block commitment root is invalid - block "+
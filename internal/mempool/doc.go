@@ -56,6 +56,7 @@ be an exhaustive list.
   - Additional metadata tracking for each transaction
   - Manual control of transaction removal
   - Recursive removal of all dependent transactions
+  - Separate stem pool for transactions in the stem phase of private relay
 
 # Configurable Transaction Acceptance Policy
 
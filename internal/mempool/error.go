@@ -97,6 +97,10 @@ const (
 
 	// ErrTSpendInvalidExpiry indicates a treasury spend expiry is invalid.
 	ErrTSpendInvalidExpiry = ErrorKind("ErrTSpendInvalidExpiry")
+
+	// ErrInvalidPackage indicates a package of transactions is malformed
+	// or otherwise violates the package acceptance policy.
+	ErrInvalidPackage = ErrorKind("ErrInvalidPackage")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
@@ -0,0 +1,229 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"errors"
+	"time"
+
+	"github.com/monetarium/monetarium-node/blockchain/stake"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/dcrutil"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+const (
+	// maxHistoryEntries is the maximum number of rejected and evicted
+	// transactions that are retained in the history.  Once the limit is
+	// reached, the oldest entries are overwritten.
+	maxHistoryEntries = 1000
+)
+
+// HistoryReason identifies the reason a transaction was rejected from or
+// evicted out of the mempool.
+type HistoryReason string
+
+const (
+	// HistoryRejected indicates a transaction was rejected on entry to the
+	// mempool.  The detail of the associated entry houses the rule that was
+	// violated.
+	HistoryRejected = HistoryReason("rejected")
+
+	// HistoryExpired indicates a transaction was evicted because it expired
+	// and can therefore no longer be included in a block.
+	HistoryExpired = HistoryReason("expired")
+
+	// HistoryDoubleSpent indicates a transaction was evicted because a
+	// transaction that spends at least one of the same outputs was included in
+	// a block.  The conflict of the associated entry houses the hash of the
+	// transaction from the block.
+	HistoryDoubleSpent = HistoryReason("doublespent")
+
+	// HistoryStakePruned indicates a stake transaction was evicted because
+	// it either became too old or no longer satisfies the current stake
+	// difficulty.
+	HistoryStakePruned = HistoryReason("stakepruned")
+
+	// HistoryInvalidated indicates a transaction that was added back to the
+	// mempool due to a block being disconnected was evicted because it is no
+	// longer valid.
+	HistoryInvalidated = HistoryReason("invalidated")
+
+	// HistoryParentEvicted indicates a transaction was evicted because a
+	// transaction it spends was evicted.  The conflict of the associated entry
+	// houses the hash of the evicted parent.
+	HistoryParentEvicted = HistoryReason("parentevicted")
+)
+
+// TxHistoryEntry describes a transaction that was either rejected from or
+// evicted out of the mempool.
+type TxHistoryEntry struct {
+	// Hash is the hash of the rejected or evicted transaction.
+	Hash chainhash.Hash
+
+	// CoinType is the primary coin type of the transaction.
+	CoinType cointype.CoinType
+
+	// Reason identifies why the transaction was rejected or evicted.
+	Reason HistoryReason
+
+	// Detail provides additional human-readable details about the reason,
+	// such as the specific rule a rejected transaction violated.  It may be
+	// empty.
+	Detail string
+
+	// Conflict is the hash of the transaction that caused the eviction when
+	// there is one.  It is nil otherwise.
+	Conflict *chainhash.Hash
+
+	// Time is the time the transaction was rejected or evicted.
+	Time time.Time
+}
+
+// txHistory houses a bounded record of recently rejected and evicted
+// transactions.  It is implemented as a ring buffer so the oldest entries are
+// overwritten once it is full.
+type txHistory struct {
+	entries []*TxHistoryEntry
+	next    int
+}
+
+// add adds the provided entry to the history, overwriting the oldest entry when
+// the history is full.
+func (h *txHistory) add(entry *TxHistoryEntry) {
+	if len(h.entries) < maxHistoryEntries {
+		h.entries = append(h.entries, entry)
+		return
+	}
+	h.entries[h.next] = entry
+	h.next = (h.next + 1) % maxHistoryEntries
+}
+
+// newestFirst returns a copy of the entries in the history ordered from newest
+// to oldest.
+func (h *txHistory) newestFirst() []*TxHistoryEntry {
+	numEntries := len(h.entries)
+	result := make([]*TxHistoryEntry, 0, numEntries)
+	for i := 0; i < numEntries; i++ {
+		idx := (h.next - 1 - i + 2*numEntries) % numEntries
+		result = append(result, h.entries[idx])
+	}
+	return result
+}
+
+// recordHistory adds an entry for the provided transaction to the history of
+// rejected and evicted transactions and queues it for delivery to the
+// caller-provided callback, if any, once the mempool lock is released.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) recordHistory(tx *dcrutil.Tx, reason HistoryReason, detail string, conflict *chainhash.Hash) {
+	entry := &TxHistoryEntry{
+		Hash:     *tx.Hash(),
+		CoinType: mp.determinePrimaryCoinType(tx.MsgTx()),
+		Reason:   reason,
+		Detail:   detail,
+		Conflict: conflict,
+		Time:     time.Now(),
+	}
+	mp.history.add(entry)
+
+	log.Debugf("Transaction %v %s (cointype: %v)", tx.Hash(), reason,
+		entry.CoinType)
+
+	if mp.cfg.OnTxHistoryEntry != nil {
+		mp.pendingHistory = append(mp.pendingHistory, entry)
+	}
+}
+
+// unlockAndNotifyHistory releases the mempool lock and then invokes the
+// caller-provided callback, if any, for every history entry that was recorded
+// while the lock was held.  Invoking the callback without the lock held
+// ensures slow callbacks do not stall the mempool and allows them to safely
+// call back into it.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) unlockAndNotifyHistory() {
+	entries := mp.pendingHistory
+	mp.pendingHistory = nil
+	mp.mtx.Unlock()
+
+	for _, entry := range entries {
+		mp.cfg.OnTxHistoryEntry(entry)
+	}
+}
+
+// recordRejection adds an entry for the provided transaction that was rejected
+// with the provided error to the history.  Only rule errors are recorded and
+// attempts to add duplicate transactions are ignored since they are expected
+// as a part of normal operation.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) recordRejection(tx *dcrutil.Tx, err error) {
+	var rErr RuleError
+	if !errors.As(err, &rErr) || errors.Is(err, ErrDuplicate) {
+		return
+	}
+	mp.recordHistory(tx, HistoryRejected, err.Error(), nil)
+}
+
+// recordEviction adds entries for the provided transaction that is about to be
+// evicted from the main or stage pool along with all transactions in the pool
+// that spend it, recursively, to the history.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) recordEviction(tx *dcrutil.Tx, reason HistoryReason, conflict *chainhash.Hash) {
+	mp.recordHistory(tx, reason, "", conflict)
+
+	// Record any transactions which rely on this one since they will also be
+	// evicted.  This mirrors the logic used by removeTransaction.
+	tree := wire.TxTreeRegular
+	if stake.DetermineTxType(tx.MsgTx()) != stake.TxTypeRegular {
+		tree = wire.TxTreeStake
+	}
+	txHash := tx.Hash()
+	outpoint := wire.OutPoint{Hash: *txHash, Tree: tree}
+	for i := uint32(0); i < uint32(len(tx.MsgTx().TxOut)); i++ {
+		outpoint.Index = i
+		if txRedeemerDesc, exists := mp.outpoints[outpoint]; exists {
+			mp.recordEviction(txRedeemerDesc.Tx, HistoryParentEvicted, txHash)
+			continue
+		}
+		if txRedeemerDesc, exists := mp.stagedOutpoints[outpoint]; exists {
+			mp.recordHistory(txRedeemerDesc.Tx, HistoryParentEvicted, "",
+				txHash)
+		}
+	}
+}
+
+// evictTransaction records the provided transaction along with all
+// transactions that spend it in the history with the given reason and removes
+// them from the main pool.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) evictTransaction(tx *dcrutil.Tx, reason HistoryReason, conflict *chainhash.Hash) {
+	mp.recordEviction(tx, reason, conflict)
+	mp.removeTransaction(tx, true)
+}
+
+// evictStagedTransaction records the provided transaction in the history with
+// the given reason and removes it from the stage pool.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) evictStagedTransaction(tx *dcrutil.Tx, reason HistoryReason, conflict *chainhash.Hash) {
+	mp.recordHistory(tx, reason, "", conflict)
+	mp.removeStagedTransaction(tx)
+}
+
+// TxHistory returns the recently rejected and evicted transactions ordered
+// from newest to oldest.  The entries must be treated as read only.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) TxHistory() []*TxHistoryEntry {
+	mp.mtx.RLock()
+	entries := mp.history.newestFirst()
+	mp.mtx.RUnlock()
+	return entries
+}
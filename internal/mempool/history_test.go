@@ -0,0 +1,193 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/chaincfg"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// isInHistory returns whether the provided entry is in the mempool history.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) isInHistory(entry *TxHistoryEntry) bool {
+	for _, e := range mp.TxHistory() {
+		if e == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// TestTxHistory ensures rejected and evicted transactions are recorded in the
+// mempool history along with the reason and any conflicting transaction and
+// that the associated callback is invoked.
+func TestTxHistory(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.RegNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	txPool := harness.txPool
+	var notified []*TxHistoryEntry
+	txPool.cfg.OnTxHistoryEntry = func(entry *TxHistoryEntry) {
+		// The callback is invoked after the mempool lock is released, so it
+		// must be able to call back into the mempool without deadlocking.
+		if !txPool.isInHistory(entry) {
+			t.Errorf("notified entry for %v is not in the history",
+				entry.Hash)
+		}
+		notified = append(notified, entry)
+	}
+
+	// Split the spendable output so there are several independent outputs to
+	// work with.
+	splitTx, err := harness.CreateSignedTx(spendableOuts, 3)
+	if err != nil {
+		t.Fatalf("unable to create split transaction: %v", err)
+	}
+	harness.AddFakeUTXO(splitTx, harness.chain.BestHeight(), 0)
+	outs := make([]spendableOutput, 3)
+	for i := range outs {
+		outs[i] = txOutToSpendableOut(splitTx, uint32(i), wire.TxTreeRegular)
+	}
+
+	// Ensure a transaction that pays insufficient fees is recorded as
+	// rejected.
+	lowFeeTx, err := harness.CreateSignedTx([]spendableOutput{outs[0]}, 1,
+		func(tx *wire.MsgTx) {
+			tx.TxOut[0].Value = int64(outs[0].amount)
+		})
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = txPool.ProcessTransaction(lowFeeTx, false, false, 0)
+	if !errors.Is(err, ErrInsufficientFee) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrInsufficientFee)
+	}
+
+	// Ensure attempting to add a duplicate transaction is not recorded.
+	tx, err := harness.CreateSignedTx([]spendableOutput{outs[1]}, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	if _, err := txPool.ProcessTransaction(tx, false, false, 0); err != nil {
+		t.Fatalf("failed to accept valid transaction: %v", err)
+	}
+	_, err = txPool.ProcessTransaction(tx, false, false, 0)
+	if !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("unexpected error -- got %v, want %v", err, ErrDuplicate)
+	}
+
+	// Ensure a transaction that is double spent by a transaction in a block
+	// is recorded along with the conflicting transaction and that any
+	// transactions that spend it are recorded as well.
+	child, err := harness.CreateSignedTx([]spendableOutput{
+		txOutToSpendableOut(tx, 0, wire.TxTreeRegular),
+	}, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	if _, err := txPool.ProcessTransaction(child, false, false, 0); err != nil {
+		t.Fatalf("failed to accept valid transaction: %v", err)
+	}
+	doubleSpend, err := harness.CreateSignedTx([]spendableOutput{outs[1]}, 2)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	txPool.RemoveDoubleSpends(doubleSpend)
+
+	// Ensure an expired transaction is recorded.
+	nextBlockHeight := harness.chain.BestHeight() + 1
+	expiringTx, err := harness.CreateSignedTx([]spendableOutput{outs[2]}, 1,
+		func(tx *wire.MsgTx) {
+			tx.Expiry = uint32(nextBlockHeight + 1)
+		})
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = txPool.ProcessTransaction(expiringTx, false, false, 0)
+	if err != nil {
+		t.Fatalf("failed to accept valid transaction: %v", err)
+	}
+	harness.chain.SetHeight(nextBlockHeight)
+	txPool.PruneExpiredTx(nextBlockHeight)
+
+	// Ensure the history contains the expected entries from newest to oldest.
+	tests := []struct {
+		hash     *chainhash.Hash
+		reason   HistoryReason
+		conflict *chainhash.Hash
+	}{
+		{expiringTx.Hash(), HistoryExpired, nil},
+		{child.Hash(), HistoryParentEvicted, tx.Hash()},
+		{tx.Hash(), HistoryDoubleSpent, doubleSpend.Hash()},
+		{lowFeeTx.Hash(), HistoryRejected, nil},
+	}
+	entries := txPool.TxHistory()
+	if len(entries) != len(tests) {
+		t.Fatalf("unexpected number of history entries -- got %d, want %d",
+			len(entries), len(tests))
+	}
+	if len(notified) != len(tests) {
+		t.Fatalf("unexpected number of notified entries -- got %d, want %d",
+			len(notified), len(tests))
+	}
+	for i, test := range tests {
+		entry := entries[i]
+		if entry.Hash != *test.hash {
+			t.Errorf("entry #%d: unexpected hash -- got %v, want %v", i,
+				entry.Hash, test.hash)
+		}
+		if entry.Reason != test.reason {
+			t.Errorf("entry #%d: unexpected reason -- got %v, want %v", i,
+				entry.Reason, test.reason)
+		}
+		if (entry.Conflict == nil) != (test.conflict == nil) ||
+			(entry.Conflict != nil && *entry.Conflict != *test.conflict) {
+
+			t.Errorf("entry #%d: unexpected conflict -- got %v, want %v", i,
+				entry.Conflict, test.conflict)
+		}
+		if entry != notified[len(notified)-1-i] {
+			t.Errorf("entry #%d: history entry does not match notified entry",
+				i)
+		}
+	}
+	if entries[len(entries)-1].Detail == "" {
+		t.Errorf("rejected entry does not contain details")
+	}
+}
+
+// TestTxHistoryLimit ensures the mempool history retains the most recent
+// entries once it reaches its maximum size.
+func TestTxHistoryLimit(t *testing.T) {
+	t.Parallel()
+
+	var h txHistory
+	const numEntries = maxHistoryEntries + 10
+	for i := 0; i < numEntries; i++ {
+		h.add(&TxHistoryEntry{Hash: chainhash.Hash{byte(i), byte(i >> 8)}})
+	}
+	entries := h.newestFirst()
+	if len(entries) != maxHistoryEntries {
+		t.Fatalf("unexpected number of entries -- got %d, want %d",
+			len(entries), maxHistoryEntries)
+	}
+	for i, entry := range entries {
+		n := numEntries - 1 - i
+		want := chainhash.Hash{byte(n), byte(n >> 8)}
+		if entry.Hash != want {
+			t.Fatalf("entry #%d: unexpected hash -- got %v, want %v", i,
+				entry.Hash, want)
+		}
+	}
+}
@@ -167,6 +167,11 @@ type Config struct {
 	// 2 agenda is active or not.
 	IsSubsidySplitR2AgendaActive func() (bool, error)
 
+	// IsSchnorrEmissionAuthAgendaActive returns if the agenda to require
+	// Schnorr signatures for the emission authorizations of SKA coin types
+	// configured for them is active or not.
+	IsSchnorrEmissionAuthAgendaActive func() (bool, error)
+
 	// OnTSpendReceived defines the function used to signal receiving a new
 	// tspend in the mempool.
 	OnTSpendReceived func(voteTx *dcrutil.Tx)
@@ -174,6 +179,11 @@ type Config struct {
 	// TSpendMinedOnAncestor returns an error if the provided tspend has
 	// been mined in an ancestor block.
 	TSpendMinedOnAncestor func(tspend chainhash.Hash) error
+
+	// OnTxHistoryEntry defines an optional function to be called whenever a
+	// transaction is rejected from or evicted out of the mempool.  It is
+	// called after the mempool lock is released.
+	OnTxHistoryEntry func(entry *TxHistoryEntry)
 }
 
 // Policy houses the policy (configuration parameters) which is used to
@@ -305,6 +315,25 @@ type TxPool struct {
 
 	transient map[chainhash.Hash]*dcrutil.Tx
 
+	// packaged tracks the transactions of a package that is in the process
+	// of being accepted.  The fees of these transactions have already been
+	// validated for the package as a whole, so the individual fee checks are
+	// skipped for them.
+	packaged map[chainhash.Hash]struct{}
+
+	// lowFeeParents houses recently rejected transactions that did not pay
+	// enough fees on their own so they may be accepted later as part of a
+	// package with a child that pays for them.
+	lowFeeParents map[chainhash.Hash]*orphanTx
+
+	// history houses a bounded record of recently rejected and evicted
+	// transactions.
+	//
+	// pendingHistory houses the history entries recorded while the lock is
+	// held that have not yet been delivered to the notification callback.
+	history        txHistory
+	pendingHistory []*TxHistoryEntry
+
 	// Votes on blocks.
 	votesMtx sync.RWMutex
 	votes    map[chainhash.Hash][]mining.VoteDesc
@@ -943,18 +972,20 @@ func (mp *TxPool) RemoveDoubleSpends(tx *dcrutil.Tx) {
 	for _, txIn := range tx.MsgTx().TxIn {
 		if txRedeemerDesc, ok := mp.outpoints[txIn.PreviousOutPoint]; ok {
 			if txRedeemerDesc.Tx.Hash() != tx.Hash() {
-				mp.removeTransaction(txRedeemerDesc.Tx, true)
+				mp.evictTransaction(txRedeemerDesc.Tx, HistoryDoubleSpent,
+					tx.Hash())
 			}
 		}
 		if txRedeemerDesc, ok := mp.stagedOutpoints[txIn.PreviousOutPoint]; ok {
 			if txRedeemerDesc.Tx.Hash() != tx.Hash() {
 				log.Debugf("Removing double spend transaction %v from stage "+
 					"pool", tx.Hash())
-				mp.removeStagedTransaction(txRedeemerDesc.Tx)
+				mp.evictStagedTransaction(txRedeemerDesc.Tx,
+					HistoryDoubleSpent, tx.Hash())
 			}
 		}
 	}
-	mp.mtx.Unlock()
+	mp.unlockAndNotifyHistory()
 }
 
 // findTx returns a transaction from the mempool by hash.  If it does not exist
@@ -1308,6 +1339,9 @@ func (mp *TxPool) MaybeAcceptDependents(tx *dcrutil.Tx, isTreasuryEnabled bool)
 // MaybeAcceptTransaction.  See the comment for MaybeAcceptTransaction for
 // more details.
 //
+// When the dry run flag is set, all of the checks are performed without adding
+// the transaction to the pool.
+//
 // This function MUST be called with the mempool lock held (for writes).
 //
 // DECRED - TODO
@@ -1316,8 +1350,8 @@ func (mp *TxPool) MaybeAcceptDependents(tx *dcrutil.Tx, isTreasuryEnabled bool)
 // This should probably be done at the bottom using "IsSStx" etc functions.
 // It should also set the dcrutil tree type for the tx as well.
 func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
-	rejectDupOrphans bool,
-	checkTxFlags blockchain.AgendaFlags) ([]wire.OutPoint, error) {
+	rejectDupOrphans bool, checkTxFlags blockchain.AgendaFlags,
+	dryRun bool) ([]wire.OutPoint, error) {
 
 	msgTx := tx.MsgTx()
 	txHash := tx.Hash()
@@ -1434,9 +1468,23 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 			getEmissionNonce:    mp.cfg.GetSKAEmissionNonce,
 		}
 
+		// Schnorr authorization only applies to coin types configured for it
+		// once the agenda that requires it is active.
+		var isSchnorrAuthEnabled bool
+		skaConfig := mp.cfg.ChainParams.GetSKACoinConfig(msgTx.TxOut[0].CoinType)
+		if skaConfig != nil && skaConfig.SchnorrEmissionAuth {
+			isSchnorrAuthEnabled, err = mp.cfg.IsSchnorrEmissionAuthAgendaActive()
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// Perform full cryptographic validation including signature verification
 		// This ensures invalid emission transactions cannot enter the mempool
-		if err := blockchain.ValidateAuthorizedSKAEmissionTransaction(msgTx, nextBlockHeight, chainAdapter, mp.cfg.ChainParams); err != nil {
+		if err := blockchain.ValidateAuthorizedSKAEmissionTransaction(msgTx,
+			nextBlockHeight, chainAdapter, mp.cfg.ChainParams,
+			isSchnorrAuthEnabled); err != nil {
+
 			str := fmt.Sprintf("transaction %v is an invalid authorized SKA emission transaction: %v", txHash, err)
 			return nil, txRuleError(ErrInvalid, str)
 		}
@@ -1858,7 +1906,10 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 
 	// Validate fees for transactions that require them
 	// Note: TSpend transactions are feeless, so we exclude them from fee validation
-	if !isSKAEmission && !isTSpend && (txType == stake.TxTypeRegular || isTicket || isTreasuryAdd) {
+	// Note: Package transactions have their fees validated in aggregate
+	_, isPackaged := mp.packaged[*txHash]
+	if !isSKAEmission && !isTSpend && !isPackaged &&
+		(txType == stake.TxTypeRegular || isTicket || isTreasuryAdd) {
 		var txTypeStr string
 		switch {
 		case txType == stake.TxTypeRegular:
@@ -1994,6 +2045,9 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	}
 	txDesc := mp.newTxDesc(utxoView, tx, txType, bestHeight, feeInt64, totalSigOps,
 		serializedSize, txFeeResult)
+	if dryRun {
+		return nil, nil
+	}
 
 	// Tickets cannot be included in a block until all inputs have
 	// been approved by stakeholders. Consensus rules dictate that stake
@@ -2114,7 +2168,34 @@ func (mp *TxPool) MaybeAcceptTransaction(tx *dcrutil.Tx, isNew bool) ([]wire.Out
 	// Protect concurrent access.
 	mp.mtx.Lock()
 	missingInputs, err := mp.maybeAcceptTransaction(tx, isNew, true, true,
-		checkTxFlags)
+		checkTxFlags, false)
+	mp.mtx.Unlock()
+
+	return missingInputs, err
+}
+
+// CheckTransaction performs all of the checks that are required to accept the
+// passed transaction into the main pool without actually adding it.  This
+// allows callers, such as the stem phase of transaction relay, to validate
+// transactions that are intentionally withheld from the pool.  A slice of the
+// missing parent outpoints is returned when the transaction is an orphan.
+//
+// Rejections are not recorded in the transaction history since the
+// transactions being checked are not intended to be observable yet.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) CheckTransaction(tx *dcrutil.Tx, allowHighFees bool) ([]wire.OutPoint, error) {
+	// Create agenda flags for checking transactions based on which ones are
+	// active or should otherwise always be enforced.
+	checkTxFlags, err := mp.determineCheckTxFlags()
+	if err != nil {
+		return nil, err
+	}
+
+	// Protect concurrent access.
+	mp.mtx.Lock()
+	missingInputs, err := mp.maybeAcceptTransaction(tx, true, allowHighFees,
+		true, checkTxFlags, true)
 	mp.mtx.Unlock()
 
 	return missingInputs, err
@@ -2164,16 +2245,17 @@ func (mp *TxPool) MaybeAcceptTransactions(txns []*dcrutil.Tx) error {
 	for i := len(txns) - 1; i >= 0; i-- {
 		tx := txns[i]
 		delete(transientPool, *tx.Hash())
-		_, err := mp.maybeAcceptTransaction(tx, false, true, true, checkTxFlags)
+		_, err := mp.maybeAcceptTransaction(tx, false, true, true, checkTxFlags,
+			false)
 		if err != nil && !isDoubleSpendOrDuplicateError(err) {
-			mp.removeTransaction(tx, true)
+			mp.evictTransaction(tx, HistoryInvalidated, nil)
 			continue
 		}
 		if err != nil {
 			errors = append(errors, err)
 		}
 	}
-	mp.mtx.Unlock()
+	mp.unlockAndNotifyHistory()
 
 	var finalErr error
 	switch {
@@ -2228,7 +2310,7 @@ func (mp *TxPool) processOrphans(acceptedTx *dcrutil.Tx, checkTxFlags blockchain
 			// Potentially accept an orphan into the tx pool.
 			for _, tx := range orphans {
 				missing, err := mp.maybeAcceptTransaction(tx, true, true, false,
-					checkTxFlags)
+					checkTxFlags, false)
 				if err != nil {
 					// The orphan is now invalid, so there
 					// is no way any other orphans which
@@ -2283,17 +2365,17 @@ func (mp *TxPool) pruneStakeTx(requiredStakeDifficulty, height int64, isAutoRevo
 		txType := txDesc.Type
 		if txType == stake.TxTypeSStx &&
 			txDesc.Height+int64(heightDiffToPruneTicket) < height {
-			mp.removeTransaction(txDesc.Tx, true)
+			mp.evictTransaction(txDesc.Tx, HistoryStakePruned, nil)
 			continue
 		}
 		if txType == stake.TxTypeSStx &&
 			txDesc.Tx.MsgTx().TxOut[0].Value < requiredStakeDifficulty {
-			mp.removeTransaction(txDesc.Tx, true)
+			mp.evictTransaction(txDesc.Tx, HistoryStakePruned, nil)
 			continue
 		}
 		if (txType == stake.TxTypeSSRtx || txType == stake.TxTypeSSGen) &&
 			txDesc.Height+int64(heightDiffToPruneVotes) < height {
-			mp.removeTransaction(txDesc.Tx, true)
+			mp.evictTransaction(txDesc.Tx, HistoryStakePruned, nil)
 			continue
 		}
 		if isAutoRevocationsEnabled && txType == stake.TxTypeSSRtx {
@@ -2302,7 +2384,7 @@ func (mp *TxPool) pruneStakeTx(requiredStakeDifficulty, height int64, isAutoRevo
 			// longer valid and should be removed since they require using the header
 			// of the previous block in order to properly calculate the return
 			// amounts.
-			mp.removeTransaction(txDesc.Tx, true)
+			mp.evictTransaction(txDesc.Tx, HistoryStakePruned, nil)
 			continue
 		}
 	}
@@ -2312,14 +2394,14 @@ func (mp *TxPool) pruneStakeTx(requiredStakeDifficulty, height int64, isAutoRevo
 			txDesc.Tx.MsgTx().TxOut[0].Value < requiredStakeDifficulty {
 			log.Debugf("Pruning ticket %v with insufficient stake difficulty "+
 				"from stage pool", txDesc.Tx.Hash())
-			mp.removeStagedTransaction(txDesc.Tx)
+			mp.evictStagedTransaction(txDesc.Tx, HistoryStakePruned, nil)
 			continue
 		}
 		if txType == stake.TxTypeSStx &&
 			txDesc.Height+int64(heightDiffToPruneTicket) < height {
 			log.Debugf("Pruning old ticket %v added at height %v "+
 				"from stage pool", txDesc.Tx.Hash(), txDesc.Height)
-			mp.removeStagedTransaction(txDesc.Tx)
+			mp.evictStagedTransaction(txDesc.Tx, HistoryStakePruned, nil)
 			continue
 		}
 		if isAutoRevocationsEnabled && txType == stake.TxTypeSSRtx {
@@ -2349,7 +2431,7 @@ func (mp *TxPool) PruneStakeTx(requiredStakeDifficulty, height int64) {
 	// Protect concurrent access.
 	mp.mtx.Lock()
 	mp.pruneStakeTx(requiredStakeDifficulty, height, isAutoRevocationsEnabled)
-	mp.mtx.Unlock()
+	mp.unlockAndNotifyHistory()
 }
 
 // pruneExpiredTx prunes expired transactions that are no longer able to be
@@ -2365,7 +2447,7 @@ func (mp *TxPool) pruneExpiredTx(height int64) {
 		if blockchain.IsExpired(tx, nextBlockHeight) {
 			log.Debugf("Pruning expired transaction %v from the mempool",
 				tx.Hash())
-			mp.removeTransaction(tx, true)
+			mp.evictTransaction(tx, HistoryExpired, nil)
 		}
 	}
 
@@ -2374,7 +2456,7 @@ func (mp *TxPool) pruneExpiredTx(height int64) {
 		if blockchain.IsExpired(tx, nextBlockHeight) {
 			log.Debugf("Pruning expired transaction %v from the stage pool",
 				tx.Hash())
-			mp.removeStagedTransaction(tx)
+			mp.evictStagedTransaction(tx, HistoryExpired, nil)
 		}
 	}
 }
@@ -2388,7 +2470,7 @@ func (mp *TxPool) PruneExpiredTx(height int64) {
 	// Protect concurrent access.
 	mp.mtx.Lock()
 	mp.pruneExpiredTx(height)
-	mp.mtx.Unlock()
+	mp.unlockAndNotifyHistory()
 }
 
 // ProcessOrphans determines if there are any orphans which depend on the passed
@@ -2419,7 +2501,7 @@ func (mp *TxPool) ProcessOrphans(acceptedTx *dcrutil.Tx, checkTxFlags blockchain
 // passed one being accepted.
 //
 // This function is safe for concurrent access.
-func (mp *TxPool) ProcessTransaction(tx *dcrutil.Tx, allowOrphan, allowHighFees bool, tag Tag) ([]*dcrutil.Tx, error) {
+func (mp *TxPool) ProcessTransaction(tx *dcrutil.Tx, allowOrphan, allowHighFees bool, tag Tag) (_ []*dcrutil.Tx, err error) {
 	// Create agenda flags for checking transactions based on which ones are
 	// active or should otherwise always be enforced.
 	checkTxFlags, err := mp.determineCheckTxFlags()
@@ -2429,18 +2511,32 @@ func (mp *TxPool) ProcessTransaction(tx *dcrutil.Tx, allowOrphan, allowHighFees
 
 	// Protect concurrent access.
 	mp.mtx.Lock()
-	defer mp.mtx.Unlock()
+	defer mp.unlockAndNotifyHistory()
 	defer func() {
 		if err != nil {
 			log.Tracef("Failed to process transaction %v: %s",
 				tx.Hash(), err.Error())
+			mp.recordRejection(tx, err)
 		}
 	}()
 
 	// Potentially accept the transaction to the memory pool.
 	missingParents, err := mp.maybeAcceptTransaction(tx, true, allowHighFees,
-		true, checkTxFlags)
+		true, checkTxFlags, false)
 	if err != nil {
+		// Attempt to accept a regular transaction that does not pay enough
+		// fees on its own as a package with any orphans that spend it since
+		// they may pay enough to cover it.  Otherwise, keep track of it so a
+		// child that arrives later has the opportunity to pay for it.
+		if errors.Is(err, ErrInsufficientFee) &&
+			stake.DetermineTxType(tx.MsgTx()) == stake.TxTypeRegular {
+
+			acceptedTxs := mp.maybeAcceptOrphanPackage(tx, checkTxFlags)
+			if acceptedTxs != nil {
+				return acceptedTxs, nil
+			}
+			mp.addLowFeeParent(tx, tag)
+		}
 		return nil, err
 	}
 
@@ -2461,8 +2557,16 @@ func (mp *TxPool) ProcessTransaction(tx *dcrutil.Tx, allowOrphan, allowHighFees
 		return acceptedTxs, nil
 	}
 
-	// The transaction is an orphan (has inputs missing).  Reject
-	// it if the flag to allow orphans is not set.
+	// The transaction is an orphan (has inputs missing).  Attempt to accept
+	// it as a package along with its missing parents when they were all
+	// previously rejected for paying insufficient fees.
+	if acceptedTxs := mp.maybeAcceptLowFeePackage(tx, missingParents,
+		checkTxFlags); acceptedTxs != nil {
+
+		return acceptedTxs, nil
+	}
+
+	// Reject the orphan if the flag to allow orphans is not set.
 	if !allowOrphan {
 		// Only use the first missing parent transaction in
 		// the error message.
@@ -2635,6 +2739,8 @@ func New(cfg *Config) *TxPool {
 		staged:          make(map[chainhash.Hash]*TxDesc),
 		stagedOutpoints: make(map[wire.OutPoint]*TxDesc),
 		transient:       make(map[chainhash.Hash]*dcrutil.Tx),
+		packaged:        make(map[chainhash.Hash]struct{}),
+		lowFeeParents:   make(map[chainhash.Hash]*orphanTx),
 	}
 
 	// for a given transaction, scan the mempool to find which transactions
@@ -2773,6 +2879,14 @@ func (mp *TxPool) determinePrimaryCoinType(msgTx *wire.MsgTx) cointype.CoinType
 	return primaryCoinType
 }
 
+// PrimaryCoinType returns the primary coin type of the passed transaction in
+// the same way the mempool policy attributes transactions to coin types.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) PrimaryCoinType(msgTx *wire.MsgTx) cointype.CoinType {
+	return mp.determinePrimaryCoinType(msgTx)
+}
+
 // GetFeeCalculator returns the fee calculator for external use (e.g., RPC)
 func (mp *TxPool) GetFeeCalculator() *fees.CoinTypeFeeCalculator {
 	mp.mtx.RLock()
@@ -3443,3 +3443,55 @@ func TestMaybeAcceptTransactions(t *testing.T) {
 
 	testExpectedAncestorFee(txC, txAFee+txBFee)
 }
+
+// TestCheckTransaction ensures checking a transaction performs the same
+// validation as accepting it without modifying the pool.
+func TestCheckTransaction(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.RegNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+
+	tx, err := harness.CreateTx(spendableOuts[0])
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	child, err := harness.CreateTx(txOutToSpendableOut(tx, 0, wire.TxTreeRegular))
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+
+	// Ensure a valid transaction passes the checks without being added.
+	missing, err := harness.txPool.CheckTransaction(tx, false)
+	if err != nil {
+		t.Fatalf("CheckTransaction: unexpected error: %v", err)
+	}
+	if len(missing) != 0 {
+		t.Fatalf("CheckTransaction: unexpected missing parents: %v", missing)
+	}
+	testPoolMembership(tc, tx, false, false)
+
+	// Ensure the missing parents of an orphan are reported without the orphan
+	// being added to the orphan pool.
+	missing, err = harness.txPool.CheckTransaction(child, false)
+	if err != nil {
+		t.Fatalf("CheckTransaction: unexpected error: %v", err)
+	}
+	if len(missing) != 1 || missing[0].Hash != *tx.Hash() {
+		t.Fatalf("CheckTransaction: unexpected missing parents: %v", missing)
+	}
+	testPoolMembership(tc, child, false, false)
+
+	// Ensure transactions that are already in the pool are rejected.
+	if _, err := harness.txPool.ProcessTransaction(tx, false, false, 0); err != nil {
+		t.Fatalf("ProcessTransaction: unexpected error: %v", err)
+	}
+	_, err = harness.txPool.CheckTransaction(tx, false)
+	if !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("CheckTransaction: unexpected error -- got %v, want %v", err,
+			ErrDuplicate)
+	}
+}
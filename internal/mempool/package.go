@@ -0,0 +1,476 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/monetarium/monetarium-node/blockchain/stake"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/dcrutil"
+	"github.com/monetarium/monetarium-node/internal/blockchain"
+	"github.com/monetarium/monetarium-node/internal/mining"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+const (
+	// MaxPackageCount is the maximum number of transactions allowed in a
+	// single package.
+	MaxPackageCount = 25
+
+	// MaxPackageSize is the maximum total serialized size in bytes of all of
+	// the transactions in a single package.
+	MaxPackageSize = MaxStandardTxSize + 1000
+)
+
+// packageFee houses the aggregated fee and serialized size of all new
+// transactions in a package that pay fees in a given coin type.
+type packageFee struct {
+	fee  *big.Int
+	size int64
+}
+
+// newPackageView returns a mining view that relates the passed package
+// transactions to one another by way of the same transaction graph that is
+// used to track the dependencies of transactions in the pool.  Only the
+// transactions in the package are considered, so any parents outside of the
+// package are not part of the view.
+func newPackageView(txns []*dcrutil.Tx) *mining.TxMiningView {
+	txDescs := make(map[chainhash.Hash]*mining.TxDesc, len(txns))
+	redeemers := make(map[chainhash.Hash][]*mining.TxDesc, len(txns))
+	for _, tx := range txns {
+		txDesc := &mining.TxDesc{
+			Tx:     tx,
+			Type:   stake.TxTypeRegular,
+			TxSize: int64(tx.MsgTx().SerializeSize()),
+		}
+		txDescs[*tx.Hash()] = txDesc
+		for _, txIn := range tx.MsgTx().TxIn {
+			prevHash := txIn.PreviousOutPoint.Hash
+			redeemers[prevHash] = append(redeemers[prevHash], txDesc)
+		}
+	}
+
+	forEachRedeemer := func(tx *dcrutil.Tx, f func(redeemerTx *mining.TxDesc)) {
+		for _, redeemer := range redeemers[*tx.Hash()] {
+			f(redeemer)
+		}
+	}
+	findTx := func(txHash *chainhash.Hash) *mining.TxDesc {
+		return txDescs[*txHash]
+	}
+	view := mining.NewTxMiningView(false, forEachRedeemer)
+	for _, tx := range txns {
+		view.AddTransaction(txDescs[*tx.Hash()], findTx)
+	}
+	return view
+}
+
+// checkPackageSanity performs context-free checks on the passed package to
+// ensure it is well formed.  In particular, it ensures the package is within
+// the count and size limits, only contains regular transactions, does not
+// contain duplicates or conflicting transactions, is sorted topologically such
+// that every parent comes before any of its children, and that every
+// transaction other than the final one is an ancestor of the final one.
+func checkPackageSanity(txns []*dcrutil.Tx) error {
+	if len(txns) < 2 {
+		str := fmt.Sprintf("package must contain at least 2 transactions "+
+			"(got %d)", len(txns))
+		return txRuleError(ErrInvalidPackage, str)
+	}
+	if len(txns) > MaxPackageCount {
+		str := fmt.Sprintf("package contains %d transactions which exceeds "+
+			"the maximum allowed of %d", len(txns), MaxPackageCount)
+		return txRuleError(ErrInvalidPackage, str)
+	}
+
+	var totalSize int
+	positions := make(map[chainhash.Hash]int, len(txns))
+	spent := make(map[wire.OutPoint]struct{})
+	for i, tx := range txns {
+		msgTx := tx.MsgTx()
+		txHash := tx.Hash()
+		totalSize += msgTx.SerializeSize()
+		if totalSize > MaxPackageSize {
+			str := fmt.Sprintf("package size exceeds the maximum allowed "+
+				"size of %d bytes", MaxPackageSize)
+			return txRuleError(ErrInvalidPackage, str)
+		}
+
+		// Only regular transactions are supported since stake transactions
+		// either do not pay fees or are subject to their own dedicated
+		// acceptance rules.
+		if txType := stake.DetermineTxType(msgTx); txType != stake.TxTypeRegular {
+			str := fmt.Sprintf("package transaction %v is a %v transaction "+
+				"instead of a regular transaction", txHash, txType)
+			return txRuleError(ErrInvalidPackage, str)
+		}
+		if wire.IsSKAEmissionTransaction(msgTx) {
+			str := fmt.Sprintf("package transaction %v is an SKA emission",
+				txHash)
+			return txRuleError(ErrInvalidPackage, str)
+		}
+
+		if _, ok := positions[*txHash]; ok {
+			str := fmt.Sprintf("package contains duplicate transaction %v",
+				txHash)
+			return txRuleError(ErrInvalidPackage, str)
+		}
+
+		for _, txIn := range msgTx.TxIn {
+			prevOut := txIn.PreviousOutPoint
+			if _, ok := spent[prevOut]; ok {
+				str := fmt.Sprintf("package transaction %v double spends "+
+					"output %v", txHash, prevOut)
+				return txRuleError(ErrMempoolDoubleSpend, str)
+			}
+			spent[prevOut] = struct{}{}
+		}
+		positions[*txHash] = i
+	}
+
+	// Ensure no transaction depends on a transaction that appears later in
+	// the package.
+	view := newPackageView(txns)
+	for i, tx := range txns {
+		for _, ancestor := range view.OrderedAncestors(tx.Hash()) {
+			ancestorHash := ancestor.Tx.Hash()
+			if positions[*ancestorHash] > i {
+				str := fmt.Sprintf("package is not sorted topologically: "+
+					"transaction %v depends on later transaction %v",
+					tx.Hash(), ancestorHash)
+				return txRuleError(ErrInvalidPackage, str)
+			}
+		}
+	}
+
+	// Ensure the package is connected such that every transaction other than
+	// the final one is an ancestor of the final one.  This prevents unrelated
+	// high-fee transactions from subsidizing low-fee ones.
+	finalTx := txns[len(txns)-1]
+	ancestors := view.OrderedAncestors(finalTx.Hash())
+	if len(ancestors) != len(txns)-1 {
+		str := fmt.Sprintf("package contains %d transactions that are not "+
+			"ancestors of the final transaction %v",
+			len(txns)-1-len(ancestors), finalTx.Hash())
+		return txRuleError(ErrInvalidPackage, str)
+	}
+
+	return nil
+}
+
+// calcPackageFees returns the aggregated fees and sizes, keyed by coin type,
+// of the passed transactions which are not already in the pool.  The
+// transactions are temporarily added to the transient pool so that the inputs
+// of children that spend outputs of their package parents can be resolved.
+//
+// Any inputs that are unknown result in an orphan error since packages are
+// required to contain all of their unconfirmed ancestors that are not already
+// in the pool.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) calcPackageFees(txns []*dcrutil.Tx, isTreasuryEnabled bool) (map[cointype.CoinType]*packageFee, error) {
+	for _, tx := range txns {
+		mp.transient[*tx.Hash()] = tx
+	}
+	defer func() {
+		for _, tx := range txns {
+			delete(mp.transient, *tx.Hash())
+		}
+	}()
+
+	fees := make(map[cointype.CoinType]*packageFee)
+	for _, tx := range txns {
+		msgTx := tx.MsgTx()
+		utxoView, err := mp.fetchInputUtxos(tx, isTreasuryEnabled)
+		if err != nil {
+			return nil, err
+		}
+		for _, txIn := range msgTx.TxIn {
+			entry := utxoView.LookupEntry(txIn.PreviousOutPoint)
+			if entry == nil || entry.IsSpent() {
+				str := fmt.Sprintf("package transaction %v references "+
+					"output %v of unknown or fully-spent transaction",
+					tx.Hash(), txIn.PreviousOutPoint)
+				return nil, txRuleError(ErrOrphan, str)
+			}
+		}
+		if err := mp.validateCoinTypeConsistency(tx, utxoView); err != nil {
+			return nil, err
+		}
+
+		txFee, err := mp.computeFeesByType(utxoView, msgTx,
+			stake.TxTypeRegular)
+		if err != nil {
+			str := fmt.Sprintf("fee calculation error: %v", err)
+			return nil, txRuleError(ErrInvalid, str)
+		}
+
+		pkgFee, ok := fees[txFee.CoinType]
+		if !ok {
+			pkgFee = &packageFee{fee: new(big.Int)}
+			fees[txFee.CoinType] = pkgFee
+		}
+		if txFee.CoinType.IsSKA() {
+			pkgFee.fee.Add(pkgFee.fee, txFee.SKAFee)
+		} else {
+			pkgFee.fee.Add(pkgFee.fee, big.NewInt(txFee.VARFee))
+		}
+		pkgFee.size += int64(msgTx.SerializeSize())
+	}
+
+	return fees, nil
+}
+
+// processPackage is the internal function which implements the public
+// ProcessPackage.  See the comment for ProcessPackage for more details.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) processPackage(txns []*dcrutil.Tx, allowHighFees bool,
+	checkTxFlags blockchain.AgendaFlags) ([]*dcrutil.Tx, error) {
+
+	if err := checkPackageSanity(txns); err != nil {
+		return nil, err
+	}
+
+	// Transactions in the package that are already in the pool have already
+	// paid for themselves, so they are excluded from the package fee
+	// calculation and are not accepted again.
+	newTxns := make([]*dcrutil.Tx, 0, len(txns))
+	for _, tx := range txns {
+		if mp.isTransactionInPool(tx.Hash()) {
+			continue
+		}
+		if mp.isTransactionStaged(tx.Hash()) {
+			str := fmt.Sprintf("package transaction %v is already staged",
+				tx.Hash())
+			return nil, txRuleError(ErrDuplicate, str)
+		}
+		newTxns = append(newTxns, tx)
+	}
+	if len(newTxns) == 0 {
+		str := "all transactions in the package are already in the pool"
+		return nil, txRuleError(ErrDuplicate, str)
+	}
+
+	// Ensure the combined fees of the new transactions in the package meet
+	// the fee requirements for each coin type.  Since a transaction may only
+	// involve a single coin type, this means a child may only pay for parents
+	// of the same coin type.
+	pkgFees, err := mp.calcPackageFees(newTxns, checkTxFlags.IsTreasuryEnabled())
+	if err != nil {
+		return nil, err
+	}
+	for coinType, pkgFee := range pkgFees {
+		err := mp.feeCalculator.ValidateTransactionFees(pkgFee.fee,
+			pkgFee.size, coinType, allowHighFees)
+		if err != nil {
+			str := fmt.Sprintf("package fee validation failed for coin type "+
+				"%d: %v", coinType, err)
+			return nil, txRuleError(ErrInsufficientFee, str)
+		}
+	}
+
+	// Accept the transactions in order while skipping the individual fee
+	// checks since the fees were already validated for the package as a
+	// whole above.  Any failure results in the removal of all previously
+	// accepted transactions from the package since the parents would
+	// otherwise remain in the pool without paying the required fees.
+	for _, tx := range newTxns {
+		mp.packaged[*tx.Hash()] = struct{}{}
+	}
+	defer func() {
+		for _, tx := range newTxns {
+			delete(mp.packaged, *tx.Hash())
+		}
+	}()
+	acceptedTxns := make([]*dcrutil.Tx, 0, len(newTxns))
+	for _, tx := range newTxns {
+		missingParents, err := mp.maybeAcceptTransaction(tx, true,
+			allowHighFees, false, checkTxFlags, false)
+		if err == nil && len(missingParents) > 0 {
+			str := fmt.Sprintf("package transaction %v references output "+
+				"%v of unknown or fully-spent transaction", tx.Hash(),
+				missingParents[0])
+			err = txRuleError(ErrOrphan, str)
+		}
+		if err == nil && !mp.isTransactionInPool(tx.Hash()) {
+			str := fmt.Sprintf("package transaction %v was not added to the "+
+				"main pool", tx.Hash())
+			err = txRuleError(ErrInvalidPackage, str)
+		}
+		if err != nil {
+			for i := len(acceptedTxns) - 1; i >= 0; i-- {
+				mp.removeTransaction(acceptedTxns[i], true)
+			}
+			return nil, err
+		}
+		acceptedTxns = append(acceptedTxns, tx)
+		mp.removeOrphan(tx, false)
+		delete(mp.lowFeeParents, *tx.Hash())
+	}
+
+	// Accept any orphans that depend on the newly accepted transactions.
+	for _, tx := range newTxns {
+		acceptedTxns = append(acceptedTxns, mp.processOrphans(tx,
+			checkTxFlags)...)
+	}
+
+	log.Debugf("Accepted package of %d transactions (%d new)", len(txns),
+		len(newTxns))
+
+	return acceptedTxns, nil
+}
+
+// ProcessPackage handles insertion of a package of related transactions into
+// the memory pool such that the fee requirements are checked against the
+// combined fees of the package per coin type as opposed to each transaction
+// individually.  This allows a child transaction to pay for one or more
+// parents that would otherwise not meet the minimum relay fee on their own.
+//
+// The package MUST be sorted such that parents come before their children and
+// all transactions except the final one must be ancestors of the final one.
+// Transactions in the package that are already in the pool are skipped.
+// Either all of the remaining transactions are accepted or none of them are.
+//
+// It returns a slice of transactions added to the mempool which includes the
+// new package transactions in the order they were provided followed by any
+// additional orphan transactions that were added as a result.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) ProcessPackage(txns []*dcrutil.Tx, allowHighFees bool) ([]*dcrutil.Tx, error) {
+	// Create agenda flags for checking transactions based on which ones are
+	// active or should otherwise always be enforced.
+	checkTxFlags, err := mp.determineCheckTxFlags()
+	if err != nil {
+		return nil, err
+	}
+
+	// Protect concurrent access.
+	mp.mtx.Lock()
+	acceptedTxns, err := mp.processPackage(txns, allowHighFees, checkTxFlags)
+	mp.mtx.Unlock()
+	if err != nil {
+		log.Tracef("Failed to process package: %v", err)
+	}
+
+	return acceptedTxns, err
+}
+
+// addLowFeeParent stores the passed transaction, which was rejected for not
+// paying enough fees on its own, so that it may later be accepted as part of a
+// package with a child that pays for it.  The number of stored transactions is
+// limited by the same policy that limits orphans.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) addLowFeeParent(tx *dcrutil.Tx, tag Tag) {
+	if mp.cfg.Policy.MaxOrphanTxs <= 0 {
+		return
+	}
+	if tx.MsgTx().SerializeSize() > mp.cfg.Policy.MaxOrphanTxSize {
+		return
+	}
+
+	// Remove any expired entries and evict a random one if adding another
+	// would exceed the limit.
+	now := time.Now()
+	for txHash, ltx := range mp.lowFeeParents {
+		if now.After(ltx.expiration) {
+			delete(mp.lowFeeParents, txHash)
+		}
+	}
+	if len(mp.lowFeeParents)+1 > mp.cfg.Policy.MaxOrphanTxs {
+		for txHash := range mp.lowFeeParents {
+			delete(mp.lowFeeParents, txHash)
+			break
+		}
+	}
+
+	mp.lowFeeParents[*tx.Hash()] = &orphanTx{
+		tx:         tx,
+		tag:        tag,
+		expiration: now.Add(orphanTTL),
+	}
+
+	log.Debugf("Stored low fee transaction %v as a potential package parent "+
+		"(total: %d)", tx.Hash(), len(mp.lowFeeParents))
+}
+
+// maybeAcceptLowFeePackage attempts to accept the passed orphan transaction
+// along with its missing parents as a package when all of the missing parents
+// were previously rejected for paying insufficient fees.  This allows children
+// relayed by peers to pay for their parents without any additional protocol
+// support.
+//
+// It returns the accepted transactions or nil when the transaction does not
+// complete a package or the package was rejected.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) maybeAcceptLowFeePackage(tx *dcrutil.Tx, missingParents []wire.OutPoint, checkTxFlags blockchain.AgendaFlags) []*dcrutil.Tx {
+	if len(mp.lowFeeParents) == 0 {
+		return nil
+	}
+
+	seen := make(map[chainhash.Hash]struct{}, len(missingParents))
+	parents := make([]*dcrutil.Tx, 0, len(missingParents)+1)
+	for _, prevOut := range missingParents {
+		if _, ok := seen[prevOut.Hash]; ok {
+			continue
+		}
+		seen[prevOut.Hash] = struct{}{}
+		ltx, ok := mp.lowFeeParents[prevOut.Hash]
+		if !ok {
+			return nil
+		}
+		parents = append(parents, ltx.tx)
+	}
+
+	// Order the parents such that any that depend on one another come after
+	// the parents they spend.
+	view := newPackageView(append(parents, tx))
+	ancestors := view.OrderedAncestors(tx.Hash())
+	pkg := make([]*dcrutil.Tx, 0, len(ancestors)+1)
+	for _, ancestor := range ancestors {
+		pkg = append(pkg, ancestor.Tx)
+	}
+	pkg = append(pkg, tx)
+
+	acceptedTxns, err := mp.processPackage(pkg, false, checkTxFlags)
+	if err != nil {
+		log.Debugf("Unable to accept transaction %v as a package with its "+
+			"low fee parents: %v", tx.Hash(), err)
+		return nil
+	}
+	return acceptedTxns
+}
+
+// maybeAcceptOrphanPackage attempts to accept the passed transaction, which
+// does not pay enough fees on its own, as a package along with an orphan that
+// spends it since the orphan may pay enough to cover both of them.
+//
+// It returns the accepted transactions or nil when no such package could be
+// accepted.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) maybeAcceptOrphanPackage(tx *dcrutil.Tx, checkTxFlags blockchain.AgendaFlags) []*dcrutil.Tx {
+	outpoint := wire.OutPoint{Hash: *tx.Hash(), Tree: wire.TxTreeRegular}
+	for txOutIdx := range tx.MsgTx().TxOut {
+		outpoint.Index = uint32(txOutIdx)
+		for _, orphan := range mp.orphansByPrev[outpoint] {
+			pkg := []*dcrutil.Tx{tx, orphan}
+			acceptedTxns, err := mp.processPackage(pkg, false, checkTxFlags)
+			if err != nil {
+				log.Debugf("Unable to accept transaction %v as a package with "+
+					"orphan %v: %v", tx.Hash(), orphan.Hash(), err)
+				continue
+			}
+			return acceptedTxns
+		}
+	}
+	return nil
+}
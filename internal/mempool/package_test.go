@@ -0,0 +1,354 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/chaincfg"
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/dcrec"
+	"github.com/monetarium/monetarium-node/dcrutil"
+	"github.com/monetarium/monetarium-node/txscript"
+	"github.com/monetarium/monetarium-node/txscript/sign"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// createLowFeeParentAndChild creates a parent transaction that pays no fee
+// which spends the provided output and a child that spends it and pays the
+// provided additional fee on top of the minimum it would otherwise pay.
+func createLowFeeParentAndChild(t *testing.T, harness *poolHarness, out spendableOutput, childExtraFee int64) (*dcrutil.Tx, *dcrutil.Tx) {
+	t.Helper()
+
+	parent, err := harness.CreateSignedTx([]spendableOutput{out}, 1,
+		func(tx *wire.MsgTx) {
+			tx.TxOut[0].Value = int64(out.amount)
+		})
+	if err != nil {
+		t.Fatalf("unable to create parent transaction: %v", err)
+	}
+	child, err := harness.CreateSignedTx([]spendableOutput{
+		txOutToSpendableOut(parent, 0, wire.TxTreeRegular),
+	}, 1, func(tx *wire.MsgTx) {
+		tx.TxOut[0].Value -= childExtraFee
+	})
+	if err != nil {
+		t.Fatalf("unable to create child transaction: %v", err)
+	}
+	return parent, child
+}
+
+// createSignedSKATx creates a new signed transaction that spends the provided
+// outputs of the given SKA coin type and pays their total amount less the
+// provided fee to a single output of the same coin type.  The amounts of the
+// outputs are specified by the passed SKA amounts.
+func createSignedSKATx(t *testing.T, harness *poolHarness, coinType cointype.CoinType, inputs []wire.OutPoint, amounts []*big.Int, fee *big.Int) *dcrutil.Tx {
+	t.Helper()
+
+	tx := wire.NewMsgTx()
+	tx.Expiry = wire.NoExpiryValue
+	outAmount := new(big.Int).Neg(fee)
+	for i := range inputs {
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: inputs[i],
+			Sequence:         wire.MaxTxInSequenceNum,
+			SKAValueIn:       amounts[i],
+		})
+		outAmount.Add(outAmount, amounts[i])
+	}
+	tx.AddTxOut(&wire.TxOut{
+		CoinType: coinType,
+		SKAValue: outAmount,
+		Version:  harness.payScriptVer,
+		PkScript: harness.payScript,
+	})
+	for i := range tx.TxIn {
+		sigScript, err := sign.SignatureScript(tx, i, harness.payScript,
+			txscript.SigHashAll, harness.signKey, dcrec.STEcdsaSecp256k1, true)
+		if err != nil {
+			t.Fatalf("unable to sign SKA transaction: %v", err)
+		}
+		tx.TxIn[i].SignatureScript = sigScript
+	}
+	return dcrutil.NewTx(tx)
+}
+
+// TestProcessPackage ensures that packages of transactions are accepted or
+// rejected based on the combined fees of the package and that malformed
+// packages are rejected.
+func TestProcessPackage(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.RegNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+	txPool := harness.txPool
+
+	// Split the spendable output so there are several independent outputs to
+	// build packages from.
+	splitTx, err := harness.CreateSignedTx(spendableOuts, 4)
+	if err != nil {
+		t.Fatalf("unable to create split transaction: %v", err)
+	}
+	harness.AddFakeUTXO(splitTx, harness.chain.BestHeight(), 0)
+	outs := make([]spendableOutput, 4)
+	for i := range outs {
+		outs[i] = txOutToSpendableOut(splitTx, uint32(i), wire.TxTreeRegular)
+	}
+
+	// Ensure the parent is rejected on its own due to insufficient fees.
+	parent, child := createLowFeeParentAndChild(t, harness, outs[0], 10000)
+	_, err = txPool.ProcessTransaction(parent, false, false, 0)
+	if !errors.Is(err, ErrInsufficientFee) {
+		t.Fatalf("unexpected error for low fee parent -- got %v, want %v",
+			err, ErrInsufficientFee)
+	}
+	testPoolMembership(tc, parent, false, false)
+
+	// Ensure the package is accepted since the child pays for both.
+	acceptedTxns, err := txPool.ProcessPackage([]*dcrutil.Tx{parent, child},
+		false)
+	if err != nil {
+		t.Fatalf("failed to accept valid package: %v", err)
+	}
+	if len(acceptedTxns) != 2 {
+		t.Fatalf("unexpected number of accepted transactions -- got %d, "+
+			"want 2", len(acceptedTxns))
+	}
+	testPoolMembership(tc, parent, false, true)
+	testPoolMembership(tc, child, false, true)
+
+	// Ensure a package whose combined fees are insufficient is rejected and
+	// none of its transactions are added to the pool.
+	parent2, child2 := createLowFeeParentAndChild(t, harness, outs[1], 0)
+	_, err = txPool.ProcessPackage([]*dcrutil.Tx{parent2, child2}, false)
+	if !errors.Is(err, ErrInsufficientFee) {
+		t.Fatalf("unexpected error for low fee package -- got %v, want %v",
+			err, ErrInsufficientFee)
+	}
+	testPoolMembership(tc, parent2, false, false)
+	testPoolMembership(tc, child2, false, false)
+
+	// Ensure malformed packages are rejected.
+	unrelated, err := harness.CreateSignedTx([]spendableOutput{outs[2]}, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	parent3, child3 := createLowFeeParentAndChild(t, harness, outs[3], 10000)
+	grandchild3, err := harness.CreateSignedTx([]spendableOutput{
+		txOutToSpendableOut(child3, 0, wire.TxTreeRegular),
+	}, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	malformed := []struct {
+		name string
+		txns []*dcrutil.Tx
+		want error
+	}{{
+		name: "single transaction",
+		txns: []*dcrutil.Tx{parent3},
+		want: ErrInvalidPackage,
+	}, {
+		name: "not topologically sorted",
+		txns: []*dcrutil.Tx{child3, parent3},
+		want: ErrInvalidPackage,
+	}, {
+		name: "unrelated transaction",
+		txns: []*dcrutil.Tx{unrelated, parent3, child3},
+		want: ErrInvalidPackage,
+	}, {
+		name: "duplicate transaction",
+		txns: []*dcrutil.Tx{parent3, parent3, child3},
+		want: ErrInvalidPackage,
+	}, {
+		name: "unconnected transactions",
+		txns: []*dcrutil.Tx{child2, child3},
+		want: ErrInvalidPackage,
+	}, {
+		name: "missing parent",
+		txns: []*dcrutil.Tx{child3, grandchild3},
+		want: ErrOrphan,
+	}}
+	for _, test := range malformed {
+		_, err := txPool.ProcessPackage(test.txns, false)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%q: unexpected error -- got %v, want %v", test.name,
+				err, test.want)
+		}
+	}
+	testPoolMembership(tc, parent3, false, false)
+	testPoolMembership(tc, child3, false, false)
+}
+
+// TestLowFeeParentRelay ensures that a transaction that does not pay enough
+// fees on its own is accepted once a child that pays for it is processed,
+// regardless of the order the transactions are received in.
+func TestLowFeeParentRelay(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.RegNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+	txPool := harness.txPool
+
+	splitTx, err := harness.CreateSignedTx(spendableOuts, 2)
+	if err != nil {
+		t.Fatalf("unable to create split transaction: %v", err)
+	}
+	harness.AddFakeUTXO(splitTx, harness.chain.BestHeight(), 0)
+
+	// Process the low fee parent followed by the child.
+	out := txOutToSpendableOut(splitTx, 0, wire.TxTreeRegular)
+	parent, child := createLowFeeParentAndChild(t, harness, out, 10000)
+	_, err = txPool.ProcessTransaction(parent, true, false, 1)
+	if !errors.Is(err, ErrInsufficientFee) {
+		t.Fatalf("unexpected error for low fee parent -- got %v, want %v",
+			err, ErrInsufficientFee)
+	}
+	acceptedTxns, err := txPool.ProcessTransaction(child, true, false, 1)
+	if err != nil {
+		t.Fatalf("failed to accept child: %v", err)
+	}
+	if len(acceptedTxns) != 2 || acceptedTxns[0] != parent {
+		t.Fatalf("unexpected accepted transactions: %v", acceptedTxns)
+	}
+	testPoolMembership(tc, parent, false, true)
+	testPoolMembership(tc, child, false, true)
+
+	// Process the child first so that it becomes an orphan followed by the
+	// low fee parent.
+	out = txOutToSpendableOut(splitTx, 1, wire.TxTreeRegular)
+	parent, child = createLowFeeParentAndChild(t, harness, out, 10000)
+	acceptedTxns, err = txPool.ProcessTransaction(child, true, false, 1)
+	if err != nil {
+		t.Fatalf("failed to accept orphan child: %v", err)
+	}
+	if len(acceptedTxns) != 0 {
+		t.Fatalf("unexpected accepted transactions: %v", acceptedTxns)
+	}
+	testPoolMembership(tc, child, true, false)
+	acceptedTxns, err = txPool.ProcessTransaction(parent, true, false, 1)
+	if err != nil {
+		t.Fatalf("failed to accept low fee parent: %v", err)
+	}
+	if len(acceptedTxns) != 2 || acceptedTxns[0] != parent {
+		t.Fatalf("unexpected accepted transactions: %v", acceptedTxns)
+	}
+	testPoolMembership(tc, parent, false, true)
+	testPoolMembership(tc, child, false, true)
+}
+
+// TestProcessPackageCoinTypes ensures that package fees are validated per coin
+// type such that a child may pay for a parent of the same SKA coin type while a
+// package that mixes coin types is rejected.
+func TestProcessPackageCoinTypes(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.SimNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+	txPool := harness.txPool
+
+	// Create several SKA outputs to build packages from.
+	const coinType = cointype.CoinType(1)
+	skaAmount := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	fundingTx := wire.NewMsgTx()
+	fundingTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: 1},
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+	for i := 0; i < 2; i++ {
+		fundingTx.AddTxOut(&wire.TxOut{
+			CoinType: coinType,
+			SKAValue: skaAmount,
+			Version:  harness.payScriptVer,
+			PkScript: harness.payScript,
+		})
+	}
+	funding := dcrutil.NewTx(fundingTx)
+	harness.AddFakeUTXO(funding, harness.chain.BestHeight(), 0)
+	skaOut := func(i uint32) wire.OutPoint {
+		return wire.OutPoint{Hash: *funding.Hash(), Index: i}
+	}
+	minFee := big.NewInt(cointype.MinSKATransactionFeeAtoms)
+
+	// Ensure an SKA parent that only pays the minimum fee allowed by consensus
+	// is rejected on its own.
+	parent := createSignedSKATx(t, harness, coinType,
+		[]wire.OutPoint{skaOut(0)}, []*big.Int{skaAmount}, minFee)
+	_, err = txPool.ProcessTransaction(parent, false, false, 0)
+	if !errors.Is(err, ErrInsufficientFee) {
+		t.Fatalf("unexpected error for low fee SKA parent -- got %v, want %v",
+			err, ErrInsufficientFee)
+	}
+	testPoolMembership(tc, parent, false, false)
+
+	// Ensure a package with a child that does not pay enough SKA fees for
+	// both transactions is rejected.
+	parentOut := parent.MsgTx().TxOut[0].SKAValue
+	lowFeeChild := createSignedSKATx(t, harness, coinType,
+		[]wire.OutPoint{{Hash: *parent.Hash()}}, []*big.Int{parentOut},
+		minFee)
+	_, err = txPool.ProcessPackage([]*dcrutil.Tx{parent, lowFeeChild}, false)
+	if !errors.Is(err, ErrInsufficientFee) {
+		t.Fatalf("unexpected error for low fee SKA package -- got %v, want %v",
+			err, ErrInsufficientFee)
+	}
+	testPoolMembership(tc, parent, false, false)
+	testPoolMembership(tc, lowFeeChild, false, false)
+
+	// Ensure the package is accepted when the child pays enough SKA fees for
+	// both transactions.
+	childFee := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+	child := createSignedSKATx(t, harness, coinType,
+		[]wire.OutPoint{{Hash: *parent.Hash()}}, []*big.Int{parentOut},
+		childFee)
+	acceptedTxns, err := txPool.ProcessPackage([]*dcrutil.Tx{parent, child},
+		false)
+	if err != nil {
+		t.Fatalf("failed to accept valid SKA package: %v", err)
+	}
+	if len(acceptedTxns) != 2 {
+		t.Fatalf("unexpected number of accepted transactions -- got %d, "+
+			"want 2", len(acceptedTxns))
+	}
+	testPoolMembership(tc, parent, false, true)
+	testPoolMembership(tc, child, false, true)
+
+	// Ensure a package with a child that spends both a VAR parent and an SKA
+	// parent is rejected since a child may only pay for parents of its own
+	// coin type and none of its transactions are added to the pool.
+	varParent, err := harness.CreateSignedTx(spendableOuts, 1,
+		func(tx *wire.MsgTx) {
+			tx.TxOut[0].Value = int64(spendableOuts[0].amount)
+		})
+	if err != nil {
+		t.Fatalf("unable to create VAR parent transaction: %v", err)
+	}
+	skaParent := createSignedSKATx(t, harness, coinType,
+		[]wire.OutPoint{skaOut(1)}, []*big.Int{skaAmount}, minFee)
+	mixedChild := createSignedSKATx(t, harness, coinType,
+		[]wire.OutPoint{{Hash: *varParent.Hash()}, {Hash: *skaParent.Hash()}},
+		[]*big.Int{new(big.Int), skaParent.MsgTx().TxOut[0].SKAValue},
+		childFee)
+	_, err = txPool.ProcessPackage([]*dcrutil.Tx{varParent, skaParent,
+		mixedChild}, false)
+	if !errors.Is(err, ErrMixedCoinTypes) {
+		t.Fatalf("unexpected error for mixed coin type package -- got %v, "+
+			"want %v", err, ErrMixedCoinTypes)
+	}
+	testPoolMembership(tc, varParent, false, false)
+	testPoolMembership(tc, skaParent, false, false)
+	testPoolMembership(tc, mixedChild, false, false)
+}
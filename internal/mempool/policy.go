@@ -420,8 +420,12 @@ func checkTransactionStandard(tx *dcrutil.Tx, txType stake.TxType, height int64,
 
 		// Accumulate the number of outputs which only carry data.  For
 		// all other script types, ensure the output value is not
-		// "dust".
-		if scriptType == stdscript.STNullData {
+		// "dust".  Note that fee distribution markers are a specific form
+		// of null data.
+		if scriptType == stdscript.STNullData ||
+			scriptType == stdscript.STSSFeeStakerMarker ||
+			scriptType == stdscript.STSSFeeMinerMarker {
+
 			numNullDataOutputs++
 		} else if txType == stake.TxTypeRegular && isDust(txOut, minRelayTxFee) {
 			str := fmt.Sprintf("transaction output %d: payment "+
@@ -7,6 +7,7 @@ package mempool
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
 	"math/big"
 	"testing"
@@ -109,6 +110,28 @@ func TestCheckPkScriptStandard(t *testing.T) {
 		pubKeys = append(pubKeys, pk.PubKey().SerializeCompressed())
 	}
 
+	// Create standard hash-time-locked contracts with both an absolute and a
+	// relative lock time along with a variant with a relative lock time that
+	// has the disable flag set.
+	secretHash := bytes.Repeat([]byte{0x01}, 32)
+	recipientHash := stdaddr.Hash160(pubKeys[0])
+	refundHash := stdaddr.Hash160(pubKeys[1])
+	htlcAbsolute, err := stdscript.HTLCScriptV0(secretHash, recipientHash,
+		refundHash, 300000, false)
+	if err != nil {
+		t.Fatalf("HTLCScriptV0: unexpected error: %v", err)
+	}
+	htlcRelative, err := stdscript.HTLCScriptV0(secretHash, recipientHash,
+		refundHash, 144, true)
+	if err != nil {
+		t.Fatalf("HTLCScriptV0: unexpected error: %v", err)
+	}
+	const htlcLockOffset, htlcRelativeSuffixOffset = 64, 67
+	htlcRelativeDisabled := txscript.NewScriptBuilder().
+		AddOps(htlcRelative[:htlcLockOffset]).
+		AddInt64(int64(wire.SequenceLockTimeDisabled) | 144).
+		AddOps(htlcRelative[htlcRelativeSuffixOffset:])
+
 	tests := []struct {
 		name       string // test description.
 		script     *txscript.ScriptBuilder
@@ -185,6 +208,21 @@ func TestCheckPkScriptStandard(t *testing.T) {
 				AddData(pubKeys[0]).AddData(pubKeys[1]),
 			false,
 		},
+		{
+			"htlc absolute lock time",
+			txscript.NewScriptBuilder().AddOps(htlcAbsolute),
+			true,
+		},
+		{
+			"htlc relative lock time",
+			txscript.NewScriptBuilder().AddOps(htlcRelative),
+			true,
+		},
+		{
+			"htlc relative lock time with disable flag",
+			htlcRelativeDisabled,
+			false,
+		},
 	}
 
 	for _, test := range tests {
@@ -386,6 +424,29 @@ func TestCheckTransactionStandard(t *testing.T) {
 		CoinType: cointype.CoinTypeVAR,
 	}
 
+	// Create a hash-time-locked contract along with a signature script that
+	// claims it with the secret.
+	htlcSecret := bytes.Repeat([]byte{0x02}, 32)
+	htlcSecretHash := sha256.Sum256(htlcSecret)
+	htlcScript, err := stdscript.HTLCScriptV0(htlcSecretHash[:], addrHash[:],
+		addrHash[:], 300000, false)
+	if err != nil {
+		t.Fatalf("HTLCScriptV0: unexpected error: %v", err)
+	}
+	htlcPubKey := secp256k1.NewPrivateKey(new(secp256k1.ModNScalar).SetInt(1)).
+		PubKey().SerializeCompressed()
+	htlcClaimSigScript, err := stdscript.HTLCClaimSigScriptV0(dummySigScript,
+		htlcPubKey, htlcSecret, htlcScript)
+	if err != nil {
+		t.Fatalf("HTLCClaimSigScriptV0: unexpected error: %v", err)
+	}
+
+	// Create fee distribution staker and miner marker scripts.
+	ssFeeStakerMarker := []byte{txscript.OP_RETURN, txscript.OP_DATA_8, 'S',
+		'F', 0xe8, 0x03, 0x00, 0x00, 0x03, 0x00}
+	ssFeeMinerMarker := []byte{txscript.OP_RETURN, txscript.OP_DATA_6, 'M',
+		'F', 0xe8, 0x03, 0x00, 0x00}
+
 	tests := []struct {
 		name       string
 		tx         wire.MsgTx
@@ -405,6 +466,38 @@ func TestCheckTransactionStandard(t *testing.T) {
 			height:     300000,
 			isStandard: true,
 		},
+		{
+			name: "Pay-to-htlc transaction",
+			tx: wire.MsgTx{
+				SerType: wire.TxSerializeFull,
+				Version: 1,
+				TxIn:    []*wire.TxIn{&dummyTxIn},
+				TxOut: []*wire.TxOut{{
+					Value:    100000000,
+					PkScript: htlcScript,
+					CoinType: cointype.CoinTypeVAR,
+				}},
+				LockTime: 0,
+			},
+			height:     300000,
+			isStandard: true,
+		},
+		{
+			name: "Transaction that claims a p2sh htlc",
+			tx: wire.MsgTx{
+				SerType: wire.TxSerializeFull,
+				Version: 1,
+				TxIn: []*wire.TxIn{{
+					PreviousOutPoint: dummyPrevOut,
+					SignatureScript:  htlcClaimSigScript,
+					Sequence:         wire.MaxTxInSequenceNum,
+				}},
+				TxOut:    []*wire.TxOut{&dummyTxOut},
+				LockTime: 0,
+			},
+			height:     300000,
+			isStandard: true,
+		},
 		{
 			name: "Transaction serialize type not full",
 			tx: wire.MsgTx{
@@ -569,6 +662,92 @@ func TestCheckTransactionStandard(t *testing.T) {
 			height:     300000,
 			isStandard: true,
 		},
+		{
+			name: "One fee distribution staker marker output (standard)",
+			tx: wire.MsgTx{
+				SerType: wire.TxSerializeFull,
+				Version: 1,
+				TxIn:    []*wire.TxIn{&dummyTxIn},
+				TxOut: []*wire.TxOut{&dummyTxOut, {
+					Value:    0,
+					PkScript: ssFeeStakerMarker,
+				}},
+				LockTime: 0,
+			},
+			height:     300000,
+			isStandard: true,
+		},
+		{
+			name: "One fee distribution miner marker output (standard)",
+			tx: wire.MsgTx{
+				SerType: wire.TxSerializeFull,
+				Version: 1,
+				TxIn:    []*wire.TxIn{&dummyTxIn},
+				TxOut: []*wire.TxOut{&dummyTxOut, {
+					Value:    0,
+					PkScript: ssFeeMinerMarker,
+				}},
+				LockTime: 0,
+			},
+			height:     300000,
+			isStandard: true,
+		},
+		{
+			name: "Four nulldata outputs and a fee distribution marker output",
+			tx: wire.MsgTx{
+				SerType: wire.TxSerializeFull,
+				Version: 1,
+				TxIn:    []*wire.TxIn{&dummyTxIn},
+				TxOut: []*wire.TxOut{{
+					Value:    0,
+					PkScript: []byte{txscript.OP_RETURN},
+				}, {
+					Value:    0,
+					PkScript: []byte{txscript.OP_RETURN},
+				}, {
+					Value:    0,
+					PkScript: []byte{txscript.OP_RETURN},
+				}, {
+					Value:    0,
+					PkScript: []byte{txscript.OP_RETURN},
+				}, {
+					Value:    0,
+					PkScript: ssFeeStakerMarker,
+				}},
+				LockTime: 0,
+			},
+			height:     300000,
+			isStandard: false,
+			err:        ErrNonStandard,
+		},
+		{
+			name: "More than four fee distribution marker outputs",
+			tx: wire.MsgTx{
+				SerType: wire.TxSerializeFull,
+				Version: 1,
+				TxIn:    []*wire.TxIn{&dummyTxIn},
+				TxOut: []*wire.TxOut{{
+					Value:    0,
+					PkScript: ssFeeStakerMarker,
+				}, {
+					Value:    0,
+					PkScript: ssFeeMinerMarker,
+				}, {
+					Value:    0,
+					PkScript: ssFeeStakerMarker,
+				}, {
+					Value:    0,
+					PkScript: ssFeeMinerMarker,
+				}, {
+					Value:    0,
+					PkScript: ssFeeStakerMarker,
+				}},
+				LockTime: 0,
+			},
+			height:     300000,
+			isStandard: false,
+			err:        ErrNonStandard,
+		},
 	}
 
 	medianTime := time.Now()
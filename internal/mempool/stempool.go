@@ -0,0 +1,170 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/dcrutil"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// StemTxDesc is a descriptor for a transaction in the stem pool.
+type StemTxDesc struct {
+	// Tx is the transaction in the stem phase of relay.
+	Tx *dcrutil.Tx
+
+	// Embargo is the time at which the transaction is fluffed when it has not
+	// been seen in the normal diffusion phase of relay by then.
+	Embargo time.Time
+
+	// Local indicates whether or not the transaction was submitted locally as
+	// opposed to being received from a peer.
+	Local bool
+
+	// AllowHighFees indicates whether or not the transaction was checked with
+	// high fees allowed so the same policy is applied when it is added to the
+	// main pool.
+	AllowHighFees bool
+}
+
+// StemPool houses transactions that are in the stem phase of relay.  They are
+// intentionally kept separate from the main pool so they are not served to
+// other peers and can't be used to probe which node originated them.
+//
+// The transactions in the stem pool are expected to have been validated via
+// CheckTransaction prior to being added.
+type StemPool struct {
+	mtx     sync.Mutex
+	pool    map[chainhash.Hash]*StemTxDesc
+	outpts  map[wire.OutPoint]*dcrutil.Tx
+	maxTxns int
+}
+
+// NewStemPool returns a new stem pool that holds up to the provided maximum
+// number of transactions.
+func NewStemPool(maxTxns int) *StemPool {
+	return &StemPool{
+		pool:    make(map[chainhash.Hash]*StemTxDesc),
+		outpts:  make(map[wire.OutPoint]*dcrutil.Tx),
+		maxTxns: maxTxns,
+	}
+}
+
+// Add adds the passed transaction to the stem pool with the provided embargo
+// time along with whether or not it was submitted locally and checked with
+// high fees allowed.  An error is returned when the transaction already exists in the pool,
+// conflicts with another transaction in the pool, or the pool is full.
+//
+// This function is safe for concurrent access.
+func (sp *StemPool) Add(tx *dcrutil.Tx, embargo time.Time, local, allowHighFees bool) error {
+	sp.mtx.Lock()
+	defer sp.mtx.Unlock()
+
+	txHash := tx.Hash()
+	if _, ok := sp.pool[*txHash]; ok {
+		str := fmt.Sprintf("already have stem transaction %v", txHash)
+		return txRuleError(ErrDuplicate, str)
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		if conflict, ok := sp.outpts[txIn.PreviousOutPoint]; ok {
+			str := fmt.Sprintf("output %v already spent by stem transaction "+
+				"%v", txIn.PreviousOutPoint, conflict.Hash())
+			return txRuleError(ErrMempoolDoubleSpend, str)
+		}
+	}
+	if len(sp.pool) >= sp.maxTxns {
+		return fmt.Errorf("stem pool is full (%d transactions)", sp.maxTxns)
+	}
+
+	sp.pool[*txHash] = &StemTxDesc{
+		Tx:            tx,
+		Embargo:       embargo,
+		Local:         local,
+		AllowHighFees: allowHighFees,
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		sp.outpts[txIn.PreviousOutPoint] = tx
+	}
+	return nil
+}
+
+// removeTransaction removes the passed transaction from the stem pool.
+//
+// This function MUST be called with the stem pool lock held (for writes).
+func (sp *StemPool) removeTransaction(tx *dcrutil.Tx) {
+	if _, ok := sp.pool[*tx.Hash()]; !ok {
+		return
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		delete(sp.outpts, txIn.PreviousOutPoint)
+	}
+	delete(sp.pool, *tx.Hash())
+}
+
+// RemoveTransaction removes the passed transaction from the stem pool along
+// with any transactions that spend the same outputs.  This is typically called
+// once a transaction has been accepted into the main pool or included in a
+// block.  The descriptor of the removed transaction is returned when it was in
+// the stem pool.  Otherwise, nil is returned.
+//
+// This function is safe for concurrent access.
+func (sp *StemPool) RemoveTransaction(tx *dcrutil.Tx) *StemTxDesc {
+	sp.mtx.Lock()
+	defer sp.mtx.Unlock()
+
+	desc := sp.pool[*tx.Hash()]
+	for _, txIn := range tx.MsgTx().TxIn {
+		if conflict, ok := sp.outpts[txIn.PreviousOutPoint]; ok {
+			sp.removeTransaction(conflict)
+		}
+	}
+	sp.removeTransaction(tx)
+	return desc
+}
+
+// HaveTransaction returns whether or not the passed transaction hash exists in
+// the stem pool.
+//
+// This function is safe for concurrent access.
+func (sp *StemPool) HaveTransaction(hash *chainhash.Hash) bool {
+	sp.mtx.Lock()
+	_, ok := sp.pool[*hash]
+	sp.mtx.Unlock()
+	return ok
+}
+
+// ExpiredTransactions removes and returns all transactions in the stem pool
+// with an embargo time that is not after the provided time.
+//
+// This function is safe for concurrent access.
+func (sp *StemPool) ExpiredTransactions(now time.Time) []*StemTxDesc {
+	sp.mtx.Lock()
+	defer sp.mtx.Unlock()
+
+	var expired []*StemTxDesc
+	for _, desc := range sp.pool {
+		if !desc.Embargo.After(now) {
+			expired = append(expired, desc)
+		}
+	}
+	for _, desc := range expired {
+		sp.removeTransaction(desc.Tx)
+	}
+	return expired
+}
+
+// Count returns the number of transactions in the stem pool.
+//
+// This function is safe for concurrent access.
+func (sp *StemPool) Count() int {
+	sp.mtx.Lock()
+	count := len(sp.pool)
+	sp.mtx.Unlock()
+	return count
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/monetarium/monetarium-node/chaincfg"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// TestStemPool ensures the stem pool tracks transactions, rejects duplicates
+// and conflicts, enforces its limit, and releases transactions once their
+// embargo expires.
+func TestStemPool(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.RegNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	parent, err := harness.CreateSignedTx(spendableOuts, 3)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	var outs []spendableOutput
+	for i := uint32(0); i < 3; i++ {
+		outs = append(outs, txOutToSpendableOut(parent, i, wire.TxTreeRegular))
+	}
+	tx1, err := harness.CreateSignedTx(outs[0:1], 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	conflict, err := harness.CreateSignedTx(outs[0:1], 2)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	tx2, err := harness.CreateSignedTx(outs[1:2], 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	tx3, err := harness.CreateSignedTx(outs[2:3], 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+
+	now := time.Now()
+	sp := NewStemPool(2)
+	if err := sp.Add(tx1, now.Add(time.Minute), true, true); err != nil {
+		t.Fatalf("unexpected error adding transaction: %v", err)
+	}
+	if !sp.HaveTransaction(tx1.Hash()) {
+		t.Fatal("stem pool does not have added transaction")
+	}
+
+	// Ensure the released descriptor retains whether the transaction was
+	// submitted locally and checked with high fees allowed and then add it
+	// back.
+	if desc := sp.ExpiredTransactions(now.Add(time.Minute)); len(desc) != 1 ||
+		!desc[0].Local || !desc[0].AllowHighFees {
+
+		t.Fatalf("unexpected expired transactions: %v", desc)
+	}
+	if err := sp.Add(tx1, now.Add(time.Minute), true, true); err != nil {
+		t.Fatalf("unexpected error adding transaction: %v", err)
+	}
+
+	// Ensure duplicates and conflicting transactions are rejected.
+	err = sp.Add(tx1, now.Add(time.Minute), true, true)
+	if !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("unexpected error for duplicate -- got %v, want %v", err,
+			ErrDuplicate)
+	}
+	err = sp.Add(conflict, now.Add(time.Minute), false, false)
+	if !errors.Is(err, ErrMempoolDoubleSpend) {
+		t.Fatalf("unexpected error for conflict -- got %v, want %v", err,
+			ErrMempoolDoubleSpend)
+	}
+
+	// Ensure the pool limit is enforced.
+	if err := sp.Add(tx2, now, false, false); err != nil {
+		t.Fatalf("unexpected error adding transaction: %v", err)
+	}
+	if err := sp.Add(tx3, now, false, false); err == nil {
+		t.Fatal("transaction added to full stem pool")
+	}
+	if count := sp.Count(); count != 2 {
+		t.Fatalf("unexpected count -- got %d, want 2", count)
+	}
+
+	// Ensure only the transaction with an expired embargo is released.
+	expired := sp.ExpiredTransactions(now)
+	if len(expired) != 1 || expired[0].Tx != tx2 || expired[0].Local ||
+		expired[0].AllowHighFees {
+
+		t.Fatalf("unexpected expired transactions: %v", expired)
+	}
+	if sp.HaveTransaction(tx2.Hash()) {
+		t.Fatal("stem pool still has expired transaction")
+	}
+
+	// Ensure removing a transaction that conflicts with one in the pool also
+	// removes the conflicting transaction.
+	if desc := sp.RemoveTransaction(conflict); desc != nil {
+		t.Fatalf("unexpected descriptor for removed conflict: %v", desc)
+	}
+	if sp.HaveTransaction(tx1.Hash()) {
+		t.Fatal("stem pool still has conflicting transaction")
+	}
+	if count := sp.Count(); count != 0 {
+		t.Fatalf("unexpected count -- got %d, want 0", count)
+	}
+	if err := sp.Add(conflict, now, false, false); err != nil {
+		t.Fatalf("unexpected error adding transaction: %v", err)
+	}
+	desc := sp.RemoveTransaction(conflict)
+	if desc == nil || desc.Tx != conflict {
+		t.Fatalf("unexpected descriptor for removed transaction: %v", desc)
+	}
+}
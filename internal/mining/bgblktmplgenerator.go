@@ -361,6 +361,15 @@ func (g *BgBlkTmplGenerator) UpdateBlockTime(header *wire.BlockHeader) {
 	g.tg.UpdateBlockTime(header)
 }
 
+// SSFeeConsolidationStats returns the number of SSFee outputs created by the
+// underlying template generator that augmented an existing SSFee UTXO and the
+// number that required a new UTXO, respectively.
+//
+// This function is safe for concurrent access.
+func (g *BgBlkTmplGenerator) SSFeeConsolidationStats() (hits, misses uint64) {
+	return g.tg.SSFeeConsolidationStats()
+}
+
 // sendQueueRegenEvent sends the provided regen event on the internal queue
 // regen event channel while respecting the quit channel.  The allows orderly
 // shutdown when the generator is shutdown.
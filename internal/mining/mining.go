@@ -14,6 +14,7 @@ import (
 	"math/big"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/monetarium/monetarium-node/blockchain/stake"
@@ -90,6 +91,12 @@ type Config struct {
 	// stake version for the block AFTER the provided block hash.
 	CalcStakeVersionByHash func(hash *chainhash.Hash) (uint32, error)
 
+	// CalcSKASupplyRoot defines the function to use to calculate the root of
+	// the SKA supply merkle tree from the point of view of just having
+	// connected the given block, which must be a block template that extends
+	// either the current tip of the main chain or its parent.
+	CalcSKASupplyRoot func(block *wire.MsgBlock) (chainhash.Hash, error)
+
 	// CheckConnectBlockTemplate defines the function to use to fully validate that
 	// connecting the passed block to either the tip of the main chain or its
 	// parent does not violate any consensus rules, aside from the proof of work
@@ -177,6 +184,11 @@ type Config struct {
 	// AFTER the given block.
 	IsHeaderCommitmentsAgendaActive func(prevHash *chainhash.Hash) (bool, error)
 
+	// IsSKASupplyCommitmentAgendaActive defines the function to use to
+	// determine whether or not the SKA supply commitment agenda is active or not
+	// for the block AFTER the given block.
+	IsSKASupplyCommitmentAgendaActive func(prevHash *chainhash.Hash) (bool, error)
+
 	// IsTreasuryAgendaActive defines the function to use to determine if the
 	// treasury agenda is active or not for the block AFTER the given block.
 	IsTreasuryAgendaActive func(prevHash *chainhash.Hash) (bool, error)
@@ -563,6 +575,37 @@ func calcBlockCommitmentRootV1(block *wire.MsgBlock, prevScripts blockcf2.PrevSc
 	return blockchain.CalcCommitmentRootV1(filter.Hash()), nil
 }
 
+// calcBlockCommitmentRootV2 calculates and returns the required v2 block
+// commitment root from the filter created for the block and the previous
+// output scripts it references as inputs along with the provided SKA supply
+// root.
+func calcBlockCommitmentRootV2(block *wire.MsgBlock, prevScripts blockcf2.PrevScripter, skaSupplyRoot chainhash.Hash) (chainhash.Hash, error) {
+	filter, err := blockcf2.Regular(block, prevScripts)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+	return blockchain.CalcCommitmentRootV2(filter.Hash(), skaSupplyRoot), nil
+}
+
+// calcBlockCommitmentRoot calculates and returns the required block commitment
+// root for the block depending on the result of the SKA supply commitment
+// agenda vote.
+func (g *BlkTmplGenerator) calcBlockCommitmentRoot(block *wire.MsgBlock, prevScripts blockcf2.PrevScripter) (chainhash.Hash, error) {
+	skaSupplyCmtActive, err := g.cfg.IsSKASupplyCommitmentAgendaActive(
+		&block.Header.PrevBlock)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+	if !skaSupplyCmtActive {
+		return calcBlockCommitmentRootV1(block, prevScripts)
+	}
+	skaSupplyRoot, err := g.cfg.CalcSKASupplyRoot(block)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+	return calcBlockCommitmentRootV2(block, prevScripts, skaSupplyRoot)
+}
+
 // createCoinbaseTx returns a coinbase transaction paying an appropriate subsidy
 // based on the passed block height to the provided address.  When the address
 // is nil, the coinbase transaction will instead be redeemable by anyone.
@@ -916,6 +959,10 @@ func createSSFeeTxBatched(coinType cointype.CoinType, totalFee *big.Int,
 			}
 		}
 
+		if ssfeeIndex != nil {
+			generator.recordSSFeeConsolidation(existingOutpoint != nil)
+		}
+
 		// Step 4: Create the batched SSFee transaction
 		tx := wire.NewMsgTx()
 		tx.Version = wire.TxVersionTreasury // Version 3+ required for coin type
@@ -1126,6 +1173,7 @@ func createMinerSSFeeTx(coinType cointype.CoinType, totalFee *big.Int,
 			log.Debugf("No existing miner SSFee UTXO found in SSFeeIndex for hash160 %x (will create new UTXO)",
 				minerHash160)
 		}
+		generator.recordSSFeeConsolidation(inputOutpoint != nil)
 	}
 
 	// Create the miner SSFee transaction
@@ -1456,7 +1504,7 @@ func (g *BlkTmplGenerator) handleTooFewVoters(nextHeight int64,
 				return nil, makeError(ErrFetchTxStore, str)
 			}
 
-			cmtRoot, err = calcBlockCommitmentRootV1(&block, blockUtxos)
+			cmtRoot, err = g.calcBlockCommitmentRoot(&block, blockUtxos)
 			if err != nil {
 				str := fmt.Sprintf("failed to calculate commitment root for "+
 					"block when making new block template: %v", err)
@@ -1628,6 +1676,12 @@ type BlkTmplGenerator struct {
 	// Key: OutPoint being spent, Value: block height the template was created for
 	inFlightSSFeeUTXOs map[wire.OutPoint]int64
 	inFlightMtx        sync.Mutex
+
+	// ssfeeConsolidationHits and ssfeeConsolidationMisses track the number of
+	// SSFee outputs that were created by augmenting an existing SSFee UTXO
+	// and that required a new UTXO, respectively.
+	ssfeeConsolidationHits   atomic.Uint64
+	ssfeeConsolidationMisses atomic.Uint64
 }
 
 // NewBlkTmplGenerator returns a new block template generator for the given
@@ -1660,6 +1714,29 @@ func (g *BlkTmplGenerator) isSSFeeUTXOInFlight(outpoint wire.OutPoint, currentHe
 	return exists && height >= currentHeight-1
 }
 
+// recordSSFeeConsolidation records whether or not an SSFee output was able to
+// augment an existing SSFee UTXO.  It has no effect when the generator is nil.
+func (g *BlkTmplGenerator) recordSSFeeConsolidation(hit bool) {
+	if g == nil {
+		return
+	}
+	if hit {
+		g.ssfeeConsolidationHits.Add(1)
+		return
+	}
+	g.ssfeeConsolidationMisses.Add(1)
+}
+
+// SSFeeConsolidationStats returns the number of SSFee outputs created by the
+// generator that augmented an existing SSFee UTXO and the number that required
+// a new UTXO, respectively.  Only outputs created while the SSFee index is
+// available are counted.
+//
+// This function is safe for concurrent access.
+func (g *BlkTmplGenerator) SSFeeConsolidationStats() (hits, misses uint64) {
+	return g.ssfeeConsolidationHits.Load(), g.ssfeeConsolidationMisses.Load()
+}
+
 // markSSFeeUTXOInFlight marks a UTXO as being used in a pending block template.
 func (g *BlkTmplGenerator) markSSFeeUTXOInFlight(outpoint wire.OutPoint, blockHeight int64) {
 	g.inFlightMtx.Lock()
@@ -3307,7 +3384,7 @@ nextPriorityQueueItem:
 	// the header commitments agenda vote.
 	var cmtRoot chainhash.Hash
 	if hdrCmtActive {
-		cmtRoot, err = calcBlockCommitmentRootV1(&msgBlock, blockUtxos)
+		cmtRoot, err = g.calcBlockCommitmentRoot(&msgBlock, blockUtxos)
 		if err != nil {
 			str := fmt.Sprintf("failed to calculate commitment root for block "+
 				"when making new block template: %v", err)
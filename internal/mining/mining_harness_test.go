@@ -54,34 +54,38 @@ func txOutToSpendableOut(tx *dcrutil.Tx, outputNum uint32, tree int8) spendableO
 // a faked chain state.  It also allows for mocking the return values of the
 // chain related functions that mining depends on.
 type fakeChain struct {
-	blocks                             map[chainhash.Hash]*dcrutil.Block
-	bestState                          blockchain.BestState
-	calcNextRequiredDifficulty         uint32
-	calcNextRequiredDifficultyErr      error
-	calcStakeVersionByHash             uint32
-	calcStakeVersionByHashErr          error
-	checkConnectBlockTemplateErr       error
-	checkTicketExhaustionErr           error
-	checkTSpendHasVotesErr             error
-	fetchUtxoEntryErr                  error
-	fetchUtxoViewErr                   error
-	fetchUtxoViewParentTemplateErr     error
-	forceHeadReorganizationErr         error
-	isHeaderCommitmentsAgendaActive    bool
-	isHeaderCommitmentsAgendaActiveErr error
-	isTreasuryAgendaActive             bool
-	isTreasuryAgendaActiveErr          error
-	isAutoRevocationsAgendaActive      bool
-	isAutoRevocationsAgendaActiveErr   error
-	isSubsidySplitAgendaActive         bool
-	isSubsidySplitAgendaActiveErr      error
-	isSubsidySplitR2AgendaActive       bool
-	isSubsidySplitR2AgendaActiveErr    error
-	maxTreasuryExpenditure             int64
-	maxTreasuryExpenditureErr          error
-	parentUtxos                        *blockchain.UtxoViewpoint
-	tipGeneration                      []chainhash.Hash
-	utxos                              *blockchain.UtxoViewpoint
+	blocks                               map[chainhash.Hash]*dcrutil.Block
+	bestState                            blockchain.BestState
+	calcNextRequiredDifficulty           uint32
+	calcNextRequiredDifficultyErr        error
+	calcStakeVersionByHash               uint32
+	calcStakeVersionByHashErr            error
+	checkConnectBlockTemplateErr         error
+	checkTicketExhaustionErr             error
+	checkTSpendHasVotesErr               error
+	fetchUtxoEntryErr                    error
+	fetchUtxoViewErr                     error
+	fetchUtxoViewParentTemplateErr       error
+	forceHeadReorganizationErr           error
+	isHeaderCommitmentsAgendaActive      bool
+	isHeaderCommitmentsAgendaActiveErr   error
+	isSKASupplyCommitmentAgendaActive    bool
+	isSKASupplyCommitmentAgendaActiveErr error
+	skaSupplyRoot                        chainhash.Hash
+	skaSupplyRootErr                     error
+	isTreasuryAgendaActive               bool
+	isTreasuryAgendaActiveErr            error
+	isAutoRevocationsAgendaActive        bool
+	isAutoRevocationsAgendaActiveErr     error
+	isSubsidySplitAgendaActive           bool
+	isSubsidySplitAgendaActiveErr        error
+	isSubsidySplitR2AgendaActive         bool
+	isSubsidySplitR2AgendaActiveErr      error
+	maxTreasuryExpenditure               int64
+	maxTreasuryExpenditureErr            error
+	parentUtxos                          *blockchain.UtxoViewpoint
+	tipGeneration                        []chainhash.Hash
+	utxos                                *blockchain.UtxoViewpoint
 }
 
 // determineSubsidySplitVariant returns the subsidy split variant to use based
@@ -214,6 +218,18 @@ func (c *fakeChain) IsHeaderCommitmentsAgendaActive(prevHash *chainhash.Hash) (b
 	return c.isHeaderCommitmentsAgendaActive, c.isHeaderCommitmentsAgendaActiveErr
 }
 
+// IsSKASupplyCommitmentAgendaActive returns a mocked bool representing whether
+// the SKA supply commitment agenda is active or not for the block AFTER the
+// given block.
+func (c *fakeChain) IsSKASupplyCommitmentAgendaActive(prevHash *chainhash.Hash) (bool, error) {
+	return c.isSKASupplyCommitmentAgendaActive, c.isSKASupplyCommitmentAgendaActiveErr
+}
+
+// CalcSKASupplyRoot returns a mocked SKA supply root for the given block.
+func (c *fakeChain) CalcSKASupplyRoot(block *wire.MsgBlock) (chainhash.Hash, error) {
+	return c.skaSupplyRoot, c.skaSupplyRootErr
+}
+
 // IsTreasuryAgendaActive returns a mocked bool representing whether the
 // treasury agenda is active or not for the block AFTER the given block.
 func (c *fakeChain) IsTreasuryAgendaActive(prevHash *chainhash.Hash) (bool, error) {
@@ -1464,6 +1480,7 @@ func newMiningHarness(chainParams *chaincfg.Params) (*miningHarness, []spendable
 			BlockByHash:                chain.BlockByHash,
 			CalcNextRequiredDifficulty: chain.CalcNextRequiredDifficulty,
 			CalcStakeVersionByHash:     chain.CalcStakeVersionByHash,
+			CalcSKASupplyRoot:          chain.CalcSKASupplyRoot,
 			CheckConnectBlockTemplate:  chain.CheckConnectBlockTemplate,
 			CheckTicketExhaustion:      chain.CheckTicketExhaustion,
 			CheckTransactionInputs: func(tx *dcrutil.Tx, txHeight int64,
@@ -1476,22 +1493,23 @@ func newMiningHarness(chainParams *chaincfg.Params) (*miningHarness, []spendable
 					view, checkFraudProof, chainParams, prevHeader, isTreasuryEnabled,
 					isAutoRevocationsEnabled, subsidySplitVariant)
 			},
-			CheckTSpendHasVotes:             chain.CheckTSpendHasVotes,
-			CountSigOps:                     blockchain.CountSigOps,
-			FetchUtxoEntry:                  chain.FetchUtxoEntry,
-			FetchUtxoView:                   chain.FetchUtxoView,
-			FetchUtxoViewParentTemplate:     chain.FetchUtxoViewParentTemplate,
-			ForceHeadReorganization:         chain.ForceHeadReorganization,
-			HeaderByHash:                    chain.HeaderByHash,
-			IsFinalizedTransaction:          blockchain.IsFinalizedTransaction,
-			IsHeaderCommitmentsAgendaActive: chain.IsHeaderCommitmentsAgendaActive,
-			IsTreasuryAgendaActive:          chain.IsTreasuryAgendaActive,
-			IsAutoRevocationsAgendaActive:   chain.IsAutoRevocationsAgendaActive,
-			IsSubsidySplitAgendaActive:      chain.IsSubsidySplitAgendaActive,
-			IsSubsidySplitR2AgendaActive:    chain.IsSubsidySplitR2AgendaActive,
-			MaxTreasuryExpenditure:          chain.MaxTreasuryExpenditure,
-			NewUtxoViewpoint:                chain.NewUtxoViewpoint,
-			TipGeneration:                   chain.TipGeneration,
+			CheckTSpendHasVotes:               chain.CheckTSpendHasVotes,
+			CountSigOps:                       blockchain.CountSigOps,
+			FetchUtxoEntry:                    chain.FetchUtxoEntry,
+			FetchUtxoView:                     chain.FetchUtxoView,
+			FetchUtxoViewParentTemplate:       chain.FetchUtxoViewParentTemplate,
+			ForceHeadReorganization:           chain.ForceHeadReorganization,
+			HeaderByHash:                      chain.HeaderByHash,
+			IsFinalizedTransaction:            blockchain.IsFinalizedTransaction,
+			IsHeaderCommitmentsAgendaActive:   chain.IsHeaderCommitmentsAgendaActive,
+			IsSKASupplyCommitmentAgendaActive: chain.IsSKASupplyCommitmentAgendaActive,
+			IsTreasuryAgendaActive:            chain.IsTreasuryAgendaActive,
+			IsAutoRevocationsAgendaActive:     chain.IsAutoRevocationsAgendaActive,
+			IsSubsidySplitAgendaActive:        chain.IsSubsidySplitAgendaActive,
+			IsSubsidySplitR2AgendaActive:      chain.IsSubsidySplitR2AgendaActive,
+			MaxTreasuryExpenditure:            chain.MaxTreasuryExpenditure,
+			NewUtxoViewpoint:                  chain.NewUtxoViewpoint,
+			TipGeneration:                     chain.TipGeneration,
 			ValidateTransactionScripts: func(tx *dcrutil.Tx,
 				utxoView *blockchain.UtxoViewpoint, flags txscript.ScriptFlags,
 				isAutoRevocationsEnabled bool) error {
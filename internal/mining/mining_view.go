@@ -130,6 +130,21 @@ func (mv *TxMiningView) AncestorStats(txHash *chainhash.Hash) (*TxAncestorStats,
 	return &defaultAncestorStats, false
 }
 
+// OrderedAncestors returns all transactions in the view that the provided
+// transaction hash depends on, either directly or indirectly, sorted such that
+// every transaction comes after all of its own ancestors.  Unlike the ancestor
+// stats, the result is not subject to the ancestor tracking limit.
+//
+// This function is NOT safe for concurrent access.
+func (mv *TxMiningView) OrderedAncestors(txHash *chainhash.Hash) []*TxDesc {
+	var ancestors []*TxDesc
+	seen := make(map[chainhash.Hash]struct{})
+	mv.txGraph.forEachAncestor(txHash, seen, func(txDesc *TxDesc) {
+		ancestors = append(ancestors, txDesc)
+	})
+	return ancestors
+}
+
 // children returns a set of transactions in the graph that spend from the
 // provided transaction hash. The order of elements returned is not guaranteed.
 //
@@ -259,6 +259,32 @@ func TestMiningView(t *testing.T) {
 				test.name)
 		}
 
+		// Ensure the ordered ancestors are in a valid order as well.
+		orderedAncestors := miningView.OrderedAncestors(txHash)
+		if len(test.ancestors) != len(orderedAncestors) {
+			t.Fatalf("%v: expected subject txn to have %v ordered ancestors, "+
+				"got %v", test.name, len(test.ancestors),
+				len(orderedAncestors))
+		}
+		exactMatch = len(test.orderedAncestors) == 0
+		for _, ancestorGroups := range test.orderedAncestors {
+			exactMatch = true
+			for index, ancestor := range ancestorGroups {
+				if *orderedAncestors[index].Tx.Hash() != *ancestor.Hash() {
+					exactMatch = false
+					break
+				}
+			}
+
+			if exactMatch {
+				break
+			}
+		}
+		if !exactMatch {
+			t.Fatalf("%v: subject txn ordered ancestors returned out of order",
+				test.name)
+		}
+
 		if miningView.hasParents(txHash) && len(test.ancestors) == 0 {
 			t.Fatalf("%v: expected subject txn to not have 0 parents, got %v",
 				test.name, len(test.ancestors))
@@ -0,0 +1,204 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package netsync
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/monetarium/monetarium-node/blockchain/stake"
+	"github.com/monetarium/monetarium-node/blockchain/standalone"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/dcrutil"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// isPrefilledStakeTx returns whether or not the provided stake transaction is
+// always sent in full as part of a compact block.
+//
+// Tickets, votes, treasury adds, and treasury spends are relayed through the
+// network prior to being included in a block, so they are very likely to
+// already be known to the receiver.  On the other hand, the remaining stake
+// transactions, such as the treasurybase, automatic revocations, and the SSFee
+// transactions which distribute the fees of non-VAR coin types, are generated
+// by the miner when creating the block and are therefore not known to anyone
+// else prior to receiving the block.
+func isPrefilledStakeTx(tx *wire.MsgTx) bool {
+	switch stake.DetermineTxType(tx) {
+	case stake.TxTypeSStx, stake.TxTypeSSGen, stake.TxTypeTAdd,
+		stake.TxTypeTSpend:
+
+		return false
+	}
+	return true
+}
+
+// NewCmpctBlock returns a compact block for the provided block that uses the
+// provided nonce to calculate the short transaction IDs.
+//
+// The coinbase and all stake transactions that are generated by the miner are
+// prefilled while all other transactions in both the regular and stake trees
+// are sent as short transaction IDs.
+func NewCmpctBlock(block *dcrutil.Block, nonce uint64) *wire.MsgCmpctBlock {
+	msgBlock := block.MsgBlock()
+	msg := wire.NewMsgCmpctBlock(&msgBlock.Header, nonce)
+	key := msg.ShortTxIDKey()
+	for i, tx := range block.Transactions() {
+		if i == 0 {
+			ptx := wire.PrefilledTx{Index: uint32(i), Tx: tx.MsgTx()}
+			msg.PrefilledTxs = append(msg.PrefilledTxs, ptx)
+			continue
+		}
+		shortID := wire.CmpctBlockShortTxID(&key, tx.Hash())
+		msg.ShortTxIDs = append(msg.ShortTxIDs, shortID)
+	}
+	for i, stx := range block.STransactions() {
+		if isPrefilledStakeTx(stx.MsgTx()) {
+			ptx := wire.PrefilledTx{Index: uint32(i), Tx: stx.MsgTx()}
+			msg.PrefilledSTxs = append(msg.PrefilledSTxs, ptx)
+			continue
+		}
+		shortID := wire.CmpctBlockShortTxID(&key, stx.Hash())
+		msg.ShortSTxIDs = append(msg.ShortSTxIDs, shortID)
+	}
+	return msg
+}
+
+// partialBlock houses a block that is being reconstructed from a compact
+// block.  Transactions that are not yet known are nil and their indexes are
+// tracked so they can be requested from the peer that sent the compact block.
+type partialBlock struct {
+	hash         chainhash.Hash
+	msgBlock     *wire.MsgBlock
+	missingTxns  []uint32
+	missingSTxns []uint32
+}
+
+// fillTxTree returns the transactions of a transaction tree reconstructed from
+// the provided short transaction IDs and prefilled transactions using the
+// provided known transactions keyed by their short transaction ID along with
+// the indexes of the transactions that are not known.  Short transaction IDs
+// that map to a nil transaction are treated as unknown.
+func fillTxTree(shortIDs []uint64, prefilled []wire.PrefilledTx, known map[uint64]*wire.MsgTx) ([]*wire.MsgTx, []uint32, error) {
+	txns := make([]*wire.MsgTx, len(shortIDs)+len(prefilled))
+	var missing []uint32
+	var nextPrefilled, nextShortID int
+	for i := range txns {
+		if nextPrefilled < len(prefilled) &&
+			prefilled[nextPrefilled].Index == uint32(i) {
+
+			txns[i] = prefilled[nextPrefilled].Tx
+			nextPrefilled++
+			continue
+		}
+		if nextShortID >= len(shortIDs) {
+			return nil, nil, errors.New("prefilled transaction indexes are " +
+				"not in ascending order")
+		}
+		tx := known[shortIDs[nextShortID]]
+		nextShortID++
+		if tx == nil {
+			missing = append(missing, uint32(i))
+		}
+		txns[i] = tx
+	}
+	return txns, missing, nil
+}
+
+// newPartialBlock returns a partial block reconstructed from the provided
+// compact block and known transactions.
+//
+// Known transactions that share a short transaction ID are ambiguous and
+// therefore treated as unknown so they are requested from the peer instead.
+func newPartialBlock(msg *wire.MsgCmpctBlock, knownTxns []*dcrutil.Tx) (*partialBlock, error) {
+	key := msg.ShortTxIDKey()
+	known := make(map[uint64]*wire.MsgTx, len(knownTxns))
+	for _, tx := range knownTxns {
+		shortID := wire.CmpctBlockShortTxID(&key, tx.Hash())
+		if _, ok := known[shortID]; ok {
+			known[shortID] = nil
+			continue
+		}
+		known[shortID] = tx.MsgTx()
+	}
+
+	msgBlock := &wire.MsgBlock{Header: msg.Header}
+	txns, missingTxns, err := fillTxTree(msg.ShortTxIDs, msg.PrefilledTxs,
+		known)
+	if err != nil {
+		return nil, err
+	}
+	stxns, missingSTxns, err := fillTxTree(msg.ShortSTxIDs,
+		msg.PrefilledSTxs, known)
+	if err != nil {
+		return nil, err
+	}
+	msgBlock.Transactions = txns
+	msgBlock.STransactions = stxns
+
+	return &partialBlock{
+		hash:         msg.Header.BlockHash(),
+		msgBlock:     msgBlock,
+		missingTxns:  missingTxns,
+		missingSTxns: missingSTxns,
+	}, nil
+}
+
+// isComplete returns whether or not all transactions of the partial block are
+// known.
+func (pb *partialBlock) isComplete() bool {
+	return len(pb.missingTxns) == 0 && len(pb.missingSTxns) == 0
+}
+
+// getBlockTxnsMsg returns a getblocktxns message that requests the missing
+// transactions of the partial block.
+func (pb *partialBlock) getBlockTxnsMsg() *wire.MsgGetBlockTxns {
+	return wire.NewMsgGetBlockTxns(&pb.hash, pb.missingTxns, pb.missingSTxns)
+}
+
+// fill fills in the missing transactions of the partial block with the
+// transactions in the provided blocktxns message.
+func (pb *partialBlock) fill(msg *wire.MsgBlockTxns) error {
+	if len(msg.Transactions) != len(pb.missingTxns) ||
+		len(msg.STransactions) != len(pb.missingSTxns) {
+
+		return fmt.Errorf("received %d regular and %d stake transactions "+
+			"instead of the requested %d and %d", len(msg.Transactions),
+			len(msg.STransactions), len(pb.missingTxns),
+			len(pb.missingSTxns))
+	}
+
+	for i, txIdx := range pb.missingTxns {
+		pb.msgBlock.Transactions[txIdx] = msg.Transactions[i]
+	}
+	for i, txIdx := range pb.missingSTxns {
+		pb.msgBlock.STransactions[txIdx] = msg.STransactions[i]
+	}
+	pb.missingTxns = nil
+	pb.missingSTxns = nil
+	return nil
+}
+
+// hasValidMerkleRoots returns whether or not the transactions of the provided
+// block commit to the merkle roots in its header.  Either the combined merkle
+// root of both transaction trees or the individual merkle roots of each tree
+// are accepted since the header commitments agenda determines which one
+// applies.
+//
+// Checking the merkle roots prior to processing blocks reconstructed from
+// compact blocks is important because a reconstructed block that contains the
+// wrong transactions due to short transaction ID collisions would otherwise be
+// marked as invalid by the chain even though the actual block might be valid.
+func hasValidMerkleRoots(msgBlock *wire.MsgBlock) bool {
+	header := &msgBlock.Header
+	combinedRoot := standalone.CalcCombinedTxTreeMerkleRoot(
+		msgBlock.Transactions, msgBlock.STransactions)
+	if header.MerkleRoot == combinedRoot {
+		return true
+	}
+	merkleRoot := standalone.CalcTxTreeMerkleRoot(msgBlock.Transactions)
+	stakeRoot := standalone.CalcTxTreeMerkleRoot(msgBlock.STransactions)
+	return header.MerkleRoot == merkleRoot && header.StakeRoot == stakeRoot
+}
@@ -0,0 +1,346 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package netsync
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/blockchain/standalone"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/dcrutil"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// testCmpctBlockTx returns a unique regular transaction for use in the compact
+// block tests.
+func testCmpctBlockTx(id byte) *wire.MsgTx {
+	tx := wire.NewMsgTx()
+	prevOut := wire.NewOutPoint(&chainhash.Hash{id}, 0, wire.TxTreeRegular)
+	tx.AddTxIn(wire.NewTxIn(prevOut, int64(id), nil))
+	tx.AddTxOut(wire.NewTxOut(int64(id), []byte{0x51}))
+	return tx
+}
+
+// testCmpctBlock returns a block with the provided number of regular and stake
+// transactions that commits to them with the combined merkle root when
+// combined is true and with the individual merkle roots of each tree
+// otherwise.
+func testCmpctBlock(numTxns, numSTxns int, combined bool) *wire.MsgBlock {
+	block := &wire.MsgBlock{Header: wire.BlockHeader{Version: 1, Height: 1}}
+	for i := 0; i < numTxns; i++ {
+		block.AddTransaction(testCmpctBlockTx(byte(i + 1)))
+	}
+	for i := 0; i < numSTxns; i++ {
+		block.AddSTransaction(testCmpctBlockTx(byte(0x80 + i)))
+	}
+	if combined {
+		block.Header.MerkleRoot = standalone.CalcCombinedTxTreeMerkleRoot(
+			block.Transactions, block.STransactions)
+	} else {
+		block.Header.MerkleRoot = standalone.CalcTxTreeMerkleRoot(
+			block.Transactions)
+		block.Header.StakeRoot = standalone.CalcTxTreeMerkleRoot(
+			block.STransactions)
+	}
+	return block
+}
+
+// TestFillTxTree ensures transaction trees are reconstructed from short
+// transaction IDs and prefilled transactions as expected and that malformed
+// prefilled transaction indexes are rejected.
+func TestFillTxTree(t *testing.T) {
+	tx1, tx2, tx3 := testCmpctBlockTx(1), testCmpctBlockTx(2),
+		testCmpctBlockTx(3)
+	known := map[uint64]*wire.MsgTx{1: tx1, 2: tx2, 4: nil}
+
+	tests := []struct {
+		name        string
+		shortIDs    []uint64
+		prefilled   []wire.PrefilledTx
+		wantTxns    []*wire.MsgTx
+		wantMissing []uint32
+		wantErr     bool
+	}{{
+		name:     "empty tree",
+		wantTxns: []*wire.MsgTx{},
+	}, {
+		name:     "all short ids known",
+		shortIDs: []uint64{1, 2},
+		wantTxns: []*wire.MsgTx{tx1, tx2},
+	}, {
+		name:      "prefilled only",
+		prefilled: []wire.PrefilledTx{{Index: 0, Tx: tx3}, {Index: 1, Tx: tx1}},
+		wantTxns:  []*wire.MsgTx{tx3, tx1},
+	}, {
+		name:      "prefilled interleaved with short ids",
+		shortIDs:  []uint64{1, 2},
+		prefilled: []wire.PrefilledTx{{Index: 0, Tx: tx3}, {Index: 2, Tx: tx3}},
+		wantTxns:  []*wire.MsgTx{tx3, tx1, tx3, tx2},
+	}, {
+		name:        "unknown short id",
+		shortIDs:    []uint64{1, 3, 2},
+		wantTxns:    []*wire.MsgTx{tx1, nil, tx2},
+		wantMissing: []uint32{1},
+	}, {
+		name:        "ambiguous short id",
+		shortIDs:    []uint64{4, 1},
+		prefilled:   []wire.PrefilledTx{{Index: 0, Tx: tx3}},
+		wantTxns:    []*wire.MsgTx{tx3, nil, tx1},
+		wantMissing: []uint32{1},
+	}, {
+		name:      "prefilled index past end of tree",
+		shortIDs:  []uint64{1},
+		prefilled: []wire.PrefilledTx{{Index: 2, Tx: tx3}},
+		wantErr:   true,
+	}, {
+		name:      "prefilled index far out of range",
+		shortIDs:  []uint64{1, 2},
+		prefilled: []wire.PrefilledTx{{Index: ^uint32(0), Tx: tx3}},
+		wantErr:   true,
+	}, {
+		name:     "duplicate prefilled index",
+		shortIDs: []uint64{1, 2},
+		prefilled: []wire.PrefilledTx{{Index: 0, Tx: tx3},
+			{Index: 0, Tx: tx3}},
+		wantErr: true,
+	}, {
+		name:     "descending prefilled indexes",
+		shortIDs: []uint64{1},
+		prefilled: []wire.PrefilledTx{{Index: 2, Tx: tx3},
+			{Index: 0, Tx: tx3}},
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		txns, missing, err := fillTxTree(test.shortIDs, test.prefilled, known)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%q: did not receive expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(txns, test.wantTxns) {
+			t.Errorf("%q: unexpected transactions -- got %v, want %v",
+				test.name, txns, test.wantTxns)
+		}
+		if !reflect.DeepEqual(missing, test.wantMissing) {
+			t.Errorf("%q: unexpected missing indexes -- got %v, want %v",
+				test.name, missing, test.wantMissing)
+		}
+	}
+}
+
+// TestNewPartialBlock ensures blocks are reconstructed from compact blocks and
+// known transactions as expected, that blocks which do not commit to the
+// reconstructed transactions are detected, and that malformed prefilled
+// transaction indexes are rejected.
+func TestNewPartialBlock(t *testing.T) {
+	const nonce = 0x0102030405060708
+	known := func(block *wire.MsgBlock, indexes ...int) []*dcrutil.Tx {
+		txns := make([]*dcrutil.Tx, 0, len(indexes))
+		for _, idx := range indexes {
+			txns = append(txns, dcrutil.NewTx(block.Transactions[idx]))
+		}
+		return txns
+	}
+
+	// Stake transactions that are not tickets, votes, or treasury
+	// transactions are always prefilled, so only the regular tree contains
+	// short transaction IDs.
+	combined := testCmpctBlock(4, 2, true)
+	separate := testCmpctBlock(4, 2, false)
+
+	tests := []struct {
+		name        string
+		block       *wire.MsgBlock
+		msg         func(msg *wire.MsgCmpctBlock)
+		known       []*dcrutil.Tx
+		wantMissing []uint32
+		wantValid   bool
+		wantErr     bool
+	}{{
+		name:      "all known with combined merkle root",
+		block:     combined,
+		known:     known(combined, 1, 2, 3),
+		wantValid: true,
+	}, {
+		name:      "all known with separate merkle roots",
+		block:     separate,
+		known:     known(separate, 1, 2, 3),
+		wantValid: true,
+	}, {
+		name:        "unknown transactions are missing",
+		block:       combined,
+		known:       known(combined, 2),
+		wantMissing: []uint32{1, 3},
+	}, {
+		name:  "ambiguous known transactions are missing",
+		block: combined,
+		// The same transaction known twice shares a short transaction ID,
+		// which is handled the same as distinct colliding transactions.
+		known:       append(known(combined, 1, 2, 3), known(combined, 2)...),
+		wantMissing: []uint32{2},
+	}, {
+		name:  "short id collision detected by merkle roots",
+		block: combined,
+		// Replace the short ID of the transaction at index 2 with the short
+		// ID of a known transaction that is not part of the block to
+		// simulate a collision.
+		msg: func(msg *wire.MsgCmpctBlock) {
+			key := msg.ShortTxIDKey()
+			msg.ShortTxIDs[1] = wire.CmpctBlockShortTxID(&key,
+				testCmpctBlockTx(0x40).CachedTxHash())
+		},
+		known: append(known(combined, 1, 3),
+			dcrutil.NewTx(testCmpctBlockTx(0x40))),
+		wantValid: false,
+	}, {
+		name:  "prefilled index out of range",
+		block: combined,
+		msg: func(msg *wire.MsgCmpctBlock) {
+			msg.PrefilledTxs[0].Index = 4
+		},
+		wantErr: true,
+	}, {
+		name:  "prefilled stake index out of range",
+		block: combined,
+		msg: func(msg *wire.MsgCmpctBlock) {
+			msg.PrefilledSTxs[1].Index = 2
+		},
+		wantErr: true,
+	}, {
+		name:  "duplicate prefilled stake index",
+		block: combined,
+		msg: func(msg *wire.MsgCmpctBlock) {
+			msg.PrefilledSTxs[1].Index = 0
+		},
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		msg := NewCmpctBlock(dcrutil.NewBlock(test.block), nonce)
+		if test.msg != nil {
+			test.msg(msg)
+		}
+		pb, err := newPartialBlock(msg, test.known)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%q: did not receive expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if pb.hash != test.block.BlockHash() {
+			t.Errorf("%q: unexpected block hash -- got %v, want %v",
+				test.name, pb.hash, test.block.BlockHash())
+			continue
+		}
+		if !reflect.DeepEqual(pb.missingTxns, test.wantMissing) {
+			t.Errorf("%q: unexpected missing transactions -- got %v, want %v",
+				test.name, pb.missingTxns, test.wantMissing)
+			continue
+		}
+		if len(pb.missingSTxns) != 0 {
+			t.Errorf("%q: unexpected missing stake transactions %v",
+				test.name, pb.missingSTxns)
+			continue
+		}
+		if pb.isComplete() != (len(test.wantMissing) == 0) {
+			t.Errorf("%q: unexpected completion status %v", test.name,
+				pb.isComplete())
+			continue
+		}
+
+		// Fill in the missing transactions from the block the same way the
+		// peer would when they are requested.
+		if !pb.isComplete() {
+			getBlockTxns := pb.getBlockTxnsMsg()
+			var blockTxns wire.MsgBlockTxns
+			for _, idx := range getBlockTxns.TxIndexes {
+				blockTxns.Transactions = append(blockTxns.Transactions,
+					test.block.Transactions[idx])
+			}
+			if err := pb.fill(&blockTxns); err != nil {
+				t.Errorf("%q: unexpected error filling block: %v",
+					test.name, err)
+				continue
+			}
+			test.wantValid = true
+		}
+		if got := hasValidMerkleRoots(pb.msgBlock); got != test.wantValid {
+			t.Errorf("%q: unexpected merkle root validity -- got %v, want %v",
+				test.name, got, test.wantValid)
+		}
+	}
+}
+
+// TestCmpctBlockMalformedPrefilledIndexes ensures compact blocks with
+// malformed encodings of the prefilled transaction indexes are rejected when
+// decoded and thus never reach block reconstruction.
+func TestCmpctBlockMalformedPrefilledIndexes(t *testing.T) {
+	block := testCmpctBlock(2, 0, true)
+	msg := NewCmpctBlock(dcrutil.NewBlock(block), 0)
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, wire.ProtocolVersion); err != nil {
+		t.Fatalf("unable to encode compact block: %v", err)
+	}
+	encoded := buf.Bytes()
+
+	// The compact block consists of the header, the nonce, a single short
+	// ID, and the prefilled coinbase at index 0.
+	const indexOffset = wire.MaxBlockHeaderPayload + 8 + 1 + wire.ShortTxIDSize + 1
+	if encoded[indexOffset] != 0x00 {
+		t.Fatalf("unexpected prefilled index encoding %x",
+			encoded[indexOffset])
+	}
+	withIndex := func(index ...byte) []byte {
+		var b []byte
+		b = append(b, encoded[:indexOffset]...)
+		b = append(b, index...)
+		return append(b, encoded[indexOffset+1:]...)
+	}
+
+	tests := []struct {
+		name    string
+		encoded []byte
+		wantErr error
+	}{{
+		name:    "index past end of tree",
+		encoded: withIndex(0x02),
+		wantErr: wire.ErrInvalidTxIndex,
+	}, {
+		name: "index exceeds uint32",
+		encoded: withIndex(0xff, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00),
+		wantErr: wire.ErrInvalidTxIndex,
+	}, {
+		name:    "non-canonical index varint",
+		encoded: withIndex(0xfd, 0x00, 0x00),
+		wantErr: wire.ErrNonCanonicalVarInt,
+	}, {
+		name:    "truncated index",
+		encoded: encoded[:indexOffset],
+	}}
+	for _, test := range tests {
+		var msg wire.MsgCmpctBlock
+		err := msg.BtcDecode(bytes.NewReader(test.encoded), wire.ProtocolVersion)
+		if err == nil {
+			t.Errorf("%q: did not receive expected error", test.name)
+			continue
+		}
+		if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+			t.Errorf("%q: unexpected error -- got %v, want %v", test.name,
+				err, test.wantErr)
+		}
+	}
+}
@@ -7,8 +7,16 @@ Package netsync implements a concurrency safe block syncing protocol.
 
 The provided implementation of SyncManager communicates with connected peers to
 perform an initial chain sync, keep the chain in sync, and announce new blocks
-connected to the chain.  Currently the sync manager selects a single sync peer
-that it downloads all blocks from until it is up to date with the longest chain
-the sync peer is aware of.
+connected to the chain.  The sync manager selects a single sync peer that it
+downloads the block headers from until it is up to date with the longest chain
+the sync peer is aware of.  The blocks are then downloaded from many peers in
+parallel with each peer being assigned a window of the next needed blocks that
+is sized according to how well it performs.  Requests for blocks that stall are
+reassigned to other peers and peers that repeatedly stall are disconnected.
+The blocks are still connected to the chain in order.
+
+Once the chain is current, new blocks announced by peers via compact blocks are
+reconstructed from the transactions in the memory pool so that only the
+transactions which are not already known need to be downloaded.
 */
 package netsync
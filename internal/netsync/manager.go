@@ -10,7 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/monetarium/monetarium-node/blockchain/stake"
@@ -34,10 +36,33 @@ const (
 	// in the request queue before requesting more.
 	minInFlightBlocks = 10
 
-	// maxInFlightBlocks is the maximum number of blocks to allow in the sync
-	// peer request queue.
+	// maxInFlightBlocks is the maximum number of blocks to allow in the request
+	// queue of a single peer.  It is also the initial size of the block
+	// download window of each peer.
 	maxInFlightBlocks = 16
 
+	// minBlockWindow is the minimum size the block download window of a peer
+	// is reduced to when it stalls.
+	minBlockWindow = 2
+
+	// blockStallTimeout is the amount of time to wait for a requested block
+	// before the request is considered stalled and the block is requested from
+	// another peer instead.
+	blockStallTimeout = 30 * time.Second
+
+	// blockStallCheckInterval is the interval at which in-flight block
+	// requests are checked for stalls.
+	blockStallCheckInterval = 5 * time.Second
+
+	// maxConsecutiveBlockStalls is the maximum number of consecutive times
+	// block requests to a peer may stall before it is deemed to be no longer
+	// useful and disconnected.
+	maxConsecutiveBlockStalls = 3
+
+	// maxAbandonedBlocks is the maximum number of abandoned block requests to
+	// track per peer.
+	maxAbandonedBlocks = maxInFlightBlocks * maxConsecutiveBlockStalls
+
 	// maxRejectedTxns specifies the maximum number of recently rejected
 	// transactions to track.  This is primarily used to avoid wasting a bunch
 	// of bandwidth from requesting transactions that are already known to be
@@ -132,6 +157,20 @@ type headersMsg struct {
 	peer    *Peer
 }
 
+// cmpctBlockMsg packages a cmpctblock message and the peer it came from
+// together so the event handler has access to that information.
+type cmpctBlockMsg struct {
+	cmpctBlock *wire.MsgCmpctBlock
+	peer       *Peer
+}
+
+// blockTxnsMsg packages a blocktxns message and the peer it came from together
+// so the event handler has access to that information.
+type blockTxnsMsg struct {
+	blockTxns *wire.MsgBlockTxns
+	peer      *Peer
+}
+
 // notFoundMsg packages a Decred notfound message and the peer it came from
 // together so the event handler has access to that information.
 type notFoundMsg struct {
@@ -210,9 +249,52 @@ type Peer struct {
 
 	syncCandidate    bool
 	requestedTxns    map[chainhash.Hash]struct{}
-	requestedBlocks  map[chainhash.Hash]struct{}
+	requestedBlocks  map[chainhash.Hash]time.Time
 	requestedMixMsgs map[chainhash.Hash]struct{}
 
+	// abandonedBlocks tracks blocks that were requested from the peer, but
+	// stalled and were thus requested from another peer instead.  Late
+	// deliveries of them are not treated as unrequested.
+	abandonedBlocks map[chainhash.Hash]struct{}
+
+	// stalledBlocks tracks blocks that are still requested from the peer that
+	// stalled and could not be requested from another peer instead.  They
+	// have already been accounted for in the block download performance of
+	// the peer, so they are not counted as stalling again.
+	stalledBlocks map[chainhash.Hash]struct{}
+
+	// cmpctBlock is the block that is being reconstructed from a compact
+	// block sent by the peer while waiting for the peer to deliver the
+	// transactions that are not known locally.  It is only accessed from the
+	// event handler goroutine.
+	cmpctBlock *partialBlock
+
+	// These fields are used to track the block download performance of the
+	// peer which in turn determines how many blocks may be requested from it
+	// at once and which peers are preferred when requesting blocks.  They are
+	// only accessed from the event handler goroutine.
+	//
+	// blockWindow is the maximum number of blocks that may be in flight from
+	// the peer at once.  It is halved each time the peer stalls and grows
+	// back as the peer delivers the requested blocks.
+	//
+	// consecutiveBlockStalls is the number of consecutive times block requests
+	// to the peer stalled without any requested blocks being delivered.
+	//
+	// avgBlockResponse is the moving average of the time the peer takes to
+	// deliver requested blocks.  It is zero until the first delivery.
+	blockWindow            int
+	consecutiveBlockStalls int
+	avgBlockResponse       time.Duration
+
+	// These fields mirror the block download performance of the peer for
+	// concurrent access by callers outside of the event handler goroutine.
+	numBlocksInFlight   atomic.Int32
+	numBlocksReceived   atomic.Uint64
+	numBlockStalls      atomic.Uint64
+	blockWindowSize     atomic.Int32
+	blockResponseMicros atomic.Int64
+
 	// requestInitialStateOnce is used to ensure the initial state data is only
 	// requested from the peer once.
 	requestInitialStateOnce sync.Once
@@ -249,15 +331,147 @@ type Peer struct {
 // common peer with additional state that is used throughout the package.
 func NewPeer(peer *peerpkg.Peer) *Peer {
 	isSyncCandidate := peer.Services()&wire.SFNodeNetwork == wire.SFNodeNetwork
-	return &Peer{
+	p := &Peer{
 		Peer:             peer,
 		syncCandidate:    isSyncCandidate,
 		requestedTxns:    make(map[chainhash.Hash]struct{}),
-		requestedBlocks:  make(map[chainhash.Hash]struct{}),
+		requestedBlocks:  make(map[chainhash.Hash]time.Time),
 		requestedMixMsgs: make(map[chainhash.Hash]struct{}),
+		abandonedBlocks:  make(map[chainhash.Hash]struct{}),
+		stalledBlocks:    make(map[chainhash.Hash]struct{}),
+		blockWindow:      maxInFlightBlocks,
+	}
+	p.blockWindowSize.Store(maxInFlightBlocks)
+	return p
+}
+
+// BlockDownloadStats houses statistics about the blocks requested from a peer.
+type BlockDownloadStats struct {
+	// InFlight is the number of blocks currently requested from the peer.
+	InFlight int32
+
+	// Received is the total number of requested blocks the peer delivered.
+	Received uint64
+
+	// Stalls is the total number of times block requests to the peer stalled
+	// and were requested from other peers instead.
+	Stalls uint64
+
+	// Window is the maximum number of blocks that may currently be in flight
+	// from the peer at once.
+	Window int32
+
+	// AvgResponseTime is the moving average of the time the peer takes to
+	// deliver requested blocks.
+	AvgResponseTime time.Duration
+}
+
+// BlockDownloadStats returns statistics about the blocks requested from the
+// peer.
+//
+// This function is safe for concurrent access.
+func (peer *Peer) BlockDownloadStats() BlockDownloadStats {
+	micros := peer.blockResponseMicros.Load()
+	return BlockDownloadStats{
+		InFlight:        peer.numBlocksInFlight.Load(),
+		Received:        peer.numBlocksReceived.Load(),
+		Stalls:          peer.numBlockStalls.Load(),
+		Window:          peer.blockWindowSize.Load(),
+		AvgResponseTime: time.Duration(micros) * time.Microsecond,
 	}
 }
 
+// servesBlocks returns whether or not blocks may be requested from the peer
+// during the chain sync process.
+func (peer *Peer) servesBlocks() bool {
+	return peer.Services()&wire.SFNodeNetwork == wire.SFNodeNetwork &&
+		peer.Connected()
+}
+
+// addRequestedBlock marks the provided block as requested from the peer at the
+// provided time.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
+func (peer *Peer) addRequestedBlock(hash *chainhash.Hash, now time.Time) {
+	peer.requestedBlocks[*hash] = now
+	peer.numBlocksInFlight.Store(int32(len(peer.requestedBlocks)))
+}
+
+// removeRequestedBlock removes the provided block from the blocks requested
+// from the peer and returns the time it was requested along with whether or not
+// it was requested.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
+func (peer *Peer) removeRequestedBlock(hash *chainhash.Hash) (time.Time, bool) {
+	requested, ok := peer.requestedBlocks[*hash]
+	if ok {
+		delete(peer.requestedBlocks, *hash)
+		delete(peer.stalledBlocks, *hash)
+		peer.numBlocksInFlight.Store(int32(len(peer.requestedBlocks)))
+	}
+	return requested, ok
+}
+
+// abandonBlock removes the provided block from the blocks requested from the
+// peer and marks it as abandoned so a late delivery of it is still accepted.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
+func (peer *Peer) abandonBlock(hash *chainhash.Hash) {
+	peer.removeRequestedBlock(hash)
+	limitAdd(peer.abandonedBlocks, *hash, maxAbandonedBlocks)
+}
+
+// setBlockWindow sets the maximum number of blocks that may be in flight from
+// the peer at once.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
+func (peer *Peer) setBlockWindow(window int) {
+	peer.blockWindow = window
+	peer.blockWindowSize.Store(int32(window))
+}
+
+// recordBlockDelivery updates the block download performance of the peer to
+// account for the delivery of a requested block that took the provided amount
+// of time.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
+func (peer *Peer) recordBlockDelivery(elapsed time.Duration) {
+	// Update the moving average of the response time such that each new
+	// delivery accounts for one eighth of it.
+	if peer.avgBlockResponse == 0 {
+		peer.avgBlockResponse = elapsed
+	} else {
+		peer.avgBlockResponse += (elapsed - peer.avgBlockResponse) / 8
+	}
+	peer.blockResponseMicros.Store(peer.avgBlockResponse.Microseconds())
+	peer.numBlocksReceived.Add(1)
+
+	// Grow the block download window back towards the maximum and reset the
+	// stall count now that the peer is making progress.
+	peer.consecutiveBlockStalls = 0
+	if peer.blockWindow < maxInFlightBlocks {
+		peer.setBlockWindow(peer.blockWindow + 1)
+	}
+}
+
+// recordBlockStall updates the block download performance of the peer to
+// account for stalled block requests and returns whether or not the peer has
+// stalled too many consecutive times to remain useful.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
+func (peer *Peer) recordBlockStall() bool {
+	peer.numBlockStalls.Add(1)
+	peer.consecutiveBlockStalls++
+	peer.setBlockWindow(max(peer.blockWindow/2, minBlockWindow))
+	return peer.consecutiveBlockStalls >= maxConsecutiveBlockStalls
+}
+
 // maybeRequestInitialState potentially requests initial state information from
 // the peer by sending it an appropriate initial state sync message dependending
 // on the protocol version.
@@ -479,11 +693,19 @@ func (m *SyncManager) isRequestedBlock(hash *chainhash.Hash) bool {
 
 // fetchNextBlocks creates and sends a request to the provided peer for the next
 // blocks to be downloaded based on the current headers.
+//
+// Only blocks the peer is expected to have based on its latest known block
+// height are requested.  Any other needed blocks remain available to be
+// requested from other peers.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
 func (m *SyncManager) fetchNextBlocks(peer *Peer) {
-	// Nothing to do if the target maximum number of blocks to request from the
-	// peer at the same time are already in flight.
+	// Nothing to do if the maximum number of blocks to request from the peer at
+	// the same time as determined by its block download window are already in
+	// flight.
 	numInFlight := len(peer.requestedBlocks)
-	if numInFlight >= maxInFlightBlocks {
+	if numInFlight >= peer.blockWindow {
 		return
 	}
 
@@ -492,39 +714,187 @@ func (m *SyncManager) fetchNextBlocks(peer *Peer) {
 	m.maybeUpdateNextNeededBlocks()
 
 	// Build and send a getdata request for the needed blocks.
-	numNeeded := len(m.nextNeededBlocks)
-	if numNeeded == 0 {
+	//
+	// Note that the list of needed blocks is compacted in place as it is
+	// iterated such that it only retains the blocks that are still needed and
+	// have not been requested.
+	chain := m.cfg.Chain
+	needed := m.nextNeededBlocks
+	if len(needed) == 0 {
 		return
 	}
-	maxNeeded := maxInFlightBlocks - numInFlight
-	if numNeeded > maxNeeded {
-		numNeeded = maxNeeded
-	}
-	gdmsg := wire.NewMsgGetDataSizeHint(uint(numNeeded))
-	for i := 0; i < numNeeded && len(gdmsg.InvList) < wire.MaxInvPerMsg; i++ {
-		// The block is either going to be skipped because it has already been
-		// requested or it will be requested, but in either case, the block is
-		// no longer needed for future iterations.
-		hash := &m.nextNeededBlocks[0]
-		m.nextNeededBlocks = m.nextNeededBlocks[1:]
-
+	maxNeeded := peer.blockWindow - numInFlight
+	peerHeight := peer.LastBlock()
+	now := time.Now()
+	gdmsg := wire.NewMsgGetDataSizeHint(uint(min(len(needed), maxNeeded)))
+	var numKept, i int
+	for ; i < len(needed) && len(gdmsg.InvList) < maxNeeded; i++ {
 		// Skip blocks that have already been requested.  The needed blocks
 		// might have been updated above thereby potentially repopulating some
 		// blocks that are still in flight.
+		hash := &needed[i]
 		if m.isRequestedBlock(hash) {
 			continue
 		}
 
+		// Stop requesting blocks once they are beyond the latest block height
+		// known for the peer.  The needed blocks are in order of height, so
+		// none of the remaining ones are available from the peer either.
+		header, err := chain.HeaderByHash(hash)
+		if err == nil && int64(header.Height) > peerHeight {
+			break
+		}
+
 		iv := wire.NewInvVect(wire.InvTypeBlock, hash)
 		m.requestedBlocks[*hash] = peer
-		peer.requestedBlocks[*hash] = struct{}{}
+		peer.addRequestedBlock(hash, now)
 		gdmsg.AddInvVect(iv)
 	}
+	numKept += copy(needed[numKept:], needed[i:])
+	m.nextNeededBlocks = needed[:numKept]
 	if len(gdmsg.InvList) > 0 {
 		peer.QueueMessage(gdmsg, nil)
 	}
 }
 
+// blockDownloadPeers returns all peers that blocks may be requested from
+// ordered by their block download performance such that the best performing
+// peers are first.  Peers that have not delivered any blocks yet are ordered
+// after those that have.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
+func (m *SyncManager) blockDownloadPeers() []*Peer {
+	peers := make([]*Peer, 0, len(m.peers))
+	for peer := range m.peers {
+		if peer.servesBlocks() {
+			peers = append(peers, peer)
+		}
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		a, b := peers[i].avgBlockResponse, peers[j].avgBlockResponse
+		if a == 0 || b == 0 {
+			return a != 0
+		}
+		return a < b
+	})
+	return peers
+}
+
+// fetchNextBlocksFromPeers distributes requests for the next blocks to be
+// downloaded based on the current headers across all peers that are able to
+// serve them such that the blocks are downloaded from many peers in parallel.
+// The best performing peers are assigned the earliest needed blocks since the
+// chain can only connect the blocks in order.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
+func (m *SyncManager) fetchNextBlocksFromPeers() {
+	for _, peer := range m.blockDownloadPeers() {
+		m.fetchNextBlocks(peer)
+	}
+}
+
+// reassignStalledBlock attempts to request the provided block that stalled
+// while in flight from the provided peer from the best performing other peer
+// that is expected to have it instead.  The block download window of the other
+// peers is intentionally ignored since the chain is unable to make progress
+// past the block until it is delivered, however, the other peer must not
+// already be at the maximum number of in-flight blocks.
+//
+// It returns whether or not the block was requested from another peer.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
+func (m *SyncManager) reassignStalledBlock(hash *chainhash.Hash, from *Peer, peers []*Peer) bool {
+	header, err := m.cfg.Chain.HeaderByHash(hash)
+	if err != nil {
+		return false
+	}
+	for _, peer := range peers {
+		if peer == from || len(peer.requestedBlocks) >= maxInFlightBlocks ||
+			peer.LastBlock() < int64(header.Height) {
+
+			continue
+		}
+		if _, ok := peer.abandonedBlocks[*hash]; ok {
+			continue
+		}
+
+		from.abandonBlock(hash)
+		m.requestedBlocks[*hash] = peer
+		peer.addRequestedBlock(hash, time.Now())
+		gdmsg := wire.NewMsgGetDataSizeHint(1)
+		gdmsg.AddInvVect(wire.NewInvVect(wire.InvTypeBlock, hash))
+		peer.QueueMessage(gdmsg, nil)
+		return true
+	}
+	return false
+}
+
+// handleBlockStallCheck requests blocks that have not been delivered within the
+// stall timeout from other peers instead.  The block download window of peers
+// that stall is reduced and peers that stall too many consecutive times are
+// disconnected.
+//
+// Each timed out request only counts as a stall once.  Requests that are not
+// able to be reassigned remain in flight from the stalled peer without counting
+// against it again on later checks, so a peer is not disconnected merely
+// because there are no other peers to request the blocks from.
+//
+// This function is NOT safe for concurrent access.  It must be called from the
+// event handler goroutine.
+func (m *SyncManager) handleBlockStallCheck() {
+	now := time.Now()
+	var peers []*Peer
+	for peer := range m.peers {
+		var stalled []chainhash.Hash
+		var numNewStalls int
+		for hash, requested := range peer.requestedBlocks {
+			if now.Sub(requested) < blockStallTimeout {
+				continue
+			}
+			stalled = append(stalled, hash)
+			if _, ok := peer.stalledBlocks[hash]; !ok {
+				numNewStalls++
+			}
+		}
+		if len(stalled) == 0 {
+			continue
+		}
+
+		// Request the stalled blocks from other peers when possible.  Blocks
+		// that are not able to be reassigned remain in flight from the
+		// stalled peer.
+		if peers == nil {
+			peers = m.blockDownloadPeers()
+		}
+		var numReassigned uint64
+		for i := range stalled {
+			hash := &stalled[i]
+			if m.reassignStalledBlock(hash, peer, peers) {
+				numReassigned++
+				continue
+			}
+			peer.stalledBlocks[*hash] = struct{}{}
+		}
+
+		// Nothing more to do when the stalled requests were already counted.
+		if numNewStalls == 0 {
+			continue
+		}
+		if peer.recordBlockStall() {
+			log.Debugf("Block download from peer %s stalled %d consecutive "+
+				"times -- disconnecting", peer, peer.consecutiveBlockStalls)
+			peer.Disconnect()
+			continue
+		}
+		log.Debugf("Block download from peer %s stalled -- requested %d "+
+			"%s from other peers", peer, numReassigned,
+			pickNoun(numReassigned, "block", "blocks"))
+	}
+}
+
 // fetchNextHeaders requests headers from the provided peer starting from the
 // parent of the best known header for the local chain in order to discover any
 // blocks that are not already known as well as accurately discover the best
@@ -646,13 +1016,14 @@ func (m *SyncManager) startSync() {
 	}
 
 	// Download any blocks needed to catch the local chain up to the best
-	// known header (if any) when the initial headers sync is already done.
+	// known header (if any) from all peers that are able to serve them when
+	// the initial headers sync is already done.
 	//
 	// This is done in addition to the header request above to avoid waiting
 	// for the round trip when there are still blocks that are needed
 	// regardless of the headers response.
 	if headersSynced {
-		m.fetchNextBlocks(m.syncPeer)
+		m.fetchNextBlocksFromPeers()
 	}
 }
 
@@ -703,6 +1074,13 @@ func (m *SyncManager) handlePeerConnectedMsg(ctx context.Context, peer *Peer) {
 		m.startSync()
 	}
 
+	// Download any blocks that are still needed from the new peer as well when
+	// the initial headers sync process is complete so it contributes to the
+	// parallel block download.
+	if m.hdrSyncState.headersSynced && peer.servesBlocks() {
+		m.fetchNextBlocks(peer)
+	}
+
 	// Potentially request the initial state from this peer now when the manager
 	// believes the chain is fully synced.  Otherwise, it will be requested when
 	// the initial chain sync process is complete.
@@ -742,6 +1120,8 @@ TxHashes:
 		delete(m.requestedTxns, txHash)
 	}
 	inv.Type = wire.InvTypeBlock
+	now := time.Now()
+	var numDroppedBlocks int
 BlockHashes:
 	for blockHash := range peer.requestedBlocks {
 		inv.Hash = blockHash
@@ -751,11 +1131,13 @@ BlockHashes:
 			}
 			invs := append(requestQueues[pp], inv)
 			requestQueues[pp] = invs
-			pp.requestedBlocks[blockHash] = struct{}{}
+			m.requestedBlocks[blockHash] = pp
+			pp.addRequestedBlock(&blockHash, now)
 			continue BlockHashes
 		}
 		// No peers found that have announced this data.
 		delete(m.requestedBlocks, blockHash)
+		numDroppedBlocks++
 	}
 	inv.Type = wire.InvTypeMix
 MixHashes:
@@ -803,6 +1185,17 @@ MixHashes:
 		m.syncPeer = nil
 		m.startSync()
 	}
+
+	// Request any blocks that were in flight from the quitting peer, and not
+	// already requested from another peer above, from the remaining peers that
+	// are able to serve them.  Force the list of the next blocks to download to
+	// be rebuilt since it no longer includes the blocks.
+	if numDroppedBlocks > 0 {
+		m.nextBlocksHeader = zeroHash
+		if m.hdrSyncState.headersSynced {
+			m.fetchNextBlocksFromPeers()
+		}
+	}
 }
 
 // handleTxMsg handles transaction messages from all peers.
@@ -1026,13 +1419,27 @@ func (m *SyncManager) processBlock(block *dcrutil.Block) (int64, error) {
 func (m *SyncManager) handleBlockMsg(bmsg *blockMsg) {
 	peer := bmsg.peer
 
-	// The remote peer is misbehaving when the block was not requested.
+	// The remote peer is misbehaving when the block was not requested.  Note
+	// that blocks that stalled and were requested from another peer instead
+	// are still accepted since the peer might simply be slow.
 	blockHash := bmsg.block.Hash()
-	if _, exists := peer.requestedBlocks[*blockHash]; !exists {
-		log.Warnf("Got unrequested block %v from %s -- disconnecting",
-			blockHash, peer)
-		peer.Disconnect()
-		return
+	requested, exists := peer.requestedBlocks[*blockHash]
+	if !exists {
+		if _, abandoned := peer.abandonedBlocks[*blockHash]; !abandoned {
+			log.Warnf("Got unrequested block %v from %s -- disconnecting",
+				blockHash, peer)
+			peer.Disconnect()
+			return
+		}
+		delete(peer.abandonedBlocks, *blockHash)
+	} else {
+		peer.recordBlockDelivery(time.Since(requested))
+	}
+
+	// The full block supersedes any partial block that is being reconstructed
+	// from a compact block for it.
+	if peer.cmpctBlock != nil && peer.cmpctBlock.hash == *blockHash {
+		peer.cmpctBlock = nil
 	}
 
 	// Save whether or not the chain believes it is current prior to processing
@@ -1048,8 +1455,16 @@ func (m *SyncManager) handleBlockMsg(bmsg *blockMsg) {
 	// This ensures chain is aware of the block before it is removed from the
 	// maps in order to help prevent duplicate requests.
 	forkLen, err := m.processBlock(bmsg.block)
-	delete(peer.requestedBlocks, *blockHash)
-	delete(m.requestedBlocks, *blockHash)
+	peer.removeRequestedBlock(blockHash)
+	if reqPeer, ok := m.requestedBlocks[*blockHash]; ok {
+		// Remove the block from the peer it was reassigned to as well when
+		// it was delivered late by a peer that stalled.  The block will be
+		// accepted from that peer too should it be delivered anyway.
+		if reqPeer != peer {
+			reqPeer.abandonBlock(blockHash)
+		}
+		delete(m.requestedBlocks, *blockHash)
+	}
 	if err != nil {
 		// Ideally there should never be any requests for duplicate blocks, but
 		// ignore any that manage to make it through.
@@ -1155,9 +1570,11 @@ func (m *SyncManager) handleBlockMsg(bmsg *blockMsg) {
 		m.cfg.MixPool.ExpireMessagesInBackground(header.Height)
 	}
 
-	// Request more blocks using the headers when the request queue is getting
-	// short.
-	if peer == m.syncPeer && len(peer.requestedBlocks) < minInFlightBlocks {
+	// Request more blocks from the peer using the headers when its request
+	// queue is getting short.
+	if m.hdrSyncState.headersSynced && peer.servesBlocks() &&
+		len(peer.requestedBlocks) < min(minInFlightBlocks, peer.blockWindow) {
+
 		m.fetchNextBlocks(peer)
 	}
 }
@@ -1464,6 +1881,7 @@ func (m *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 	// and associated infrastructure to efficiently determine which peers have
 	// the associated block(s).
 	if isChainCurrent {
+		now := time.Now()
 		gdmsg := wire.NewMsgGetDataSizeHint(uint(len(headers)))
 		for i := range headerHashes {
 			// Skip the block when it has already been requested or is otherwise
@@ -1480,7 +1898,7 @@ func (m *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 			}
 
 			m.requestedBlocks[*hash] = peer
-			peer.requestedBlocks[*hash] = struct{}{}
+			peer.addRequestedBlock(hash, now)
 			iv := wire.NewInvVect(wire.InvTypeBlock, hash)
 			gdmsg.AddInvVect(iv)
 		}
@@ -1490,12 +1908,121 @@ func (m *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 	}
 
 	// Download any blocks needed to catch the local chain up to the best known
-	// header (if any) once the initial headers sync is done.
-	if headersSynced && m.syncPeer != nil {
-		m.fetchNextBlocks(m.syncPeer)
+	// header (if any) from all peers that are able to serve them once the
+	// initial headers sync is done.
+	if headersSynced {
+		m.fetchNextBlocksFromPeers()
 	}
 }
 
+// processPartialBlock processes the provided complete partial block that was
+// reconstructed from a compact block sent by the peer as if the peer sent the
+// full block.  The full block is requested from the peer instead when the
+// reconstructed transactions do not commit to the merkle roots in the header,
+// which typically means there was a short transaction ID collision.
+func (m *SyncManager) processPartialBlock(peer *Peer, pb *partialBlock) {
+	if !hasValidMerkleRoots(pb.msgBlock) {
+		log.Debugf("Reconstructed compact block %s from %s has mismatched "+
+			"merkle roots -- requesting full block", pb.hash, peer)
+		gdmsg := wire.NewMsgGetDataSizeHint(1)
+		gdmsg.AddInvVect(wire.NewInvVect(wire.InvTypeBlock, &pb.hash))
+		peer.QueueMessage(gdmsg, nil)
+		return
+	}
+
+	block := dcrutil.NewBlock(pb.msgBlock)
+	m.handleBlockMsg(&blockMsg{block: block, peer: peer})
+}
+
+// handleCmpctBlockMsg handles cmpctblock messages from all peers.
+//
+// The block header is processed as if it were announced via a headers message
+// and, when the block is needed, the block is reconstructed from the
+// transactions in the mempool.  Any transactions that are not known are
+// requested from the peer and the block is processed once they arrive.
+func (m *SyncManager) handleCmpctBlockMsg(cmsg *cmpctBlockMsg) {
+	peer := cmsg.peer
+	msg := cmsg.cmpctBlock
+	header := &msg.Header
+	blockHash := header.BlockHash()
+	peer.AddKnownInventory(wire.NewInvVect(wire.InvTypeBlock, &blockHash))
+
+	// Only attempt to reconstruct the block when the chain is current and the
+	// block is not already known or requested.  Blocks are downloaded in full
+	// otherwise.  Also, only a single compact block is reconstructed per peer
+	// at a time.
+	chain := m.cfg.Chain
+	hdrsMsg := &headersMsg{
+		headers: &wire.MsgHeaders{Headers: []*wire.BlockHeader{header}},
+		peer:    peer,
+	}
+	if !m.hdrSyncState.headersSynced || !chain.IsCurrent() ||
+		!chain.HaveHeader(&header.PrevBlock) || chain.HaveBlock(&blockHash) ||
+		m.isRequestedBlock(&blockHash) || peer.cmpctBlock != nil ||
+		len(m.requestedBlocks)+1 > maxRequestedBlocks {
+
+		m.handleHeadersMsg(hdrsMsg)
+		return
+	}
+
+	// Track the block as requested from the peer prior to handling the header
+	// so it is not requested in full as a result of the announcement.
+	m.requestedBlocks[blockHash] = peer
+	peer.addRequestedBlock(&blockHash, time.Now())
+	m.handleHeadersMsg(hdrsMsg)
+	if !chain.HaveHeader(&blockHash) {
+		// The header was rejected and the peer disconnected.
+		return
+	}
+
+	var knownTxns []*dcrutil.Tx
+	for _, desc := range m.cfg.TxMemPool.TxDescs() {
+		knownTxns = append(knownTxns, desc.Tx)
+	}
+	pb, err := newPartialBlock(msg, knownTxns)
+	if err != nil {
+		log.Debugf("Received invalid compact block %s from %s: %v -- "+
+			"disconnecting", blockHash, peer, err)
+		peer.Disconnect()
+		return
+	}
+	if pb.isComplete() {
+		m.processPartialBlock(peer, pb)
+		return
+	}
+
+	log.Debugf("Requesting %d regular and %d stake transactions for compact "+
+		"block %s from %s", len(pb.missingTxns), len(pb.missingSTxns),
+		blockHash, peer)
+	peer.cmpctBlock = pb
+	peer.QueueMessage(pb.getBlockTxnsMsg(), nil)
+}
+
+// handleBlockTxnsMsg handles blocktxns messages from all peers.
+func (m *SyncManager) handleBlockTxnsMsg(bmsg *blockTxnsMsg) {
+	peer := bmsg.peer
+	msg := bmsg.blockTxns
+
+	// The remote peer is misbehaving when the transactions were not
+	// requested.
+	pb := peer.cmpctBlock
+	if pb == nil || pb.hash != msg.BlockHash {
+		log.Warnf("Got unrequested block transactions for %v from %s -- "+
+			"disconnecting", msg.BlockHash, peer)
+		peer.Disconnect()
+		return
+	}
+	peer.cmpctBlock = nil
+
+	if err := pb.fill(msg); err != nil {
+		log.Debugf("Received invalid block transactions for %v from %s: %v "+
+			"-- disconnecting", msg.BlockHash, peer, err)
+		peer.Disconnect()
+		return
+	}
+	m.processPartialBlock(peer, pb)
+}
+
 // handleNotFoundMsg handles notfound messages from all peers.
 func (m *SyncManager) handleNotFoundMsg(nfmsg *notFoundMsg) {
 	peer := nfmsg.peer
@@ -1505,9 +2032,13 @@ func (m *SyncManager) handleNotFoundMsg(nfmsg *notFoundMsg) {
 		// before deleting from the global requested maps.
 		switch inv.Type {
 		case wire.InvTypeBlock:
-			if _, exists := peer.requestedBlocks[inv.Hash]; exists {
-				delete(peer.requestedBlocks, inv.Hash)
+			if _, exists := peer.removeRequestedBlock(&inv.Hash); exists {
 				delete(m.requestedBlocks, inv.Hash)
+
+				// Force the list of the next blocks to download to be rebuilt
+				// so the block is requested again, potentially from another
+				// peer.
+				m.nextBlocksHeader = zeroHash
 			}
 		case wire.InvTypeTx:
 			if _, exists := peer.requestedTxns[inv.Hash]; exists {
@@ -1721,6 +2252,9 @@ func limitAdd(m map[chainhash.Hash]struct{}, hash chainhash.Hash, limit int) {
 // because the sync manager controls which blocks are needed and how the
 // fetching should proceed.
 func (m *SyncManager) eventHandler(ctx context.Context) {
+	blockStallTicker := time.NewTicker(blockStallCheckInterval)
+	defer blockStallTicker.Stop()
+
 out:
 	for {
 		select {
@@ -1756,6 +2290,12 @@ out:
 			case *headersMsg:
 				m.handleHeadersMsg(msg)
 
+			case *cmpctBlockMsg:
+				m.handleCmpctBlockMsg(msg)
+
+			case *blockTxnsMsg:
+				m.handleBlockTxnsMsg(msg)
+
 			case *notFoundMsg:
 				m.handleNotFoundMsg(msg)
 
@@ -1815,6 +2355,9 @@ out:
 				m.syncPeer.Disconnect()
 			}
 
+		case <-blockStallTicker.C:
+			m.handleBlockStallCheck()
+
 		case <-ctx.Done():
 			break out
 		}
@@ -1868,6 +2411,24 @@ func (m *SyncManager) OnHeaders(headers *wire.MsgHeaders, peer *Peer) {
 	}
 }
 
+// OnCmpctBlock adds the passed cmpctblock message and peer to the event
+// handling queue.
+func (m *SyncManager) OnCmpctBlock(cmpctBlock *wire.MsgCmpctBlock, peer *Peer) {
+	select {
+	case m.msgChan <- &cmpctBlockMsg{cmpctBlock: cmpctBlock, peer: peer}:
+	case <-m.quit:
+	}
+}
+
+// OnBlockTxns adds the passed blocktxns message and peer to the event handling
+// queue.
+func (m *SyncManager) OnBlockTxns(blockTxns *wire.MsgBlockTxns, peer *Peer) {
+	select {
+	case m.msgChan <- &blockTxnsMsg{blockTxns: blockTxns, peer: peer}:
+	case <-m.quit:
+	}
+}
+
 // OnMixMsg adds the passed mixing message and peer to the event handling
 // queue.
 func (m *SyncManager) OnMixMsg(msg mixing.Message, peer *Peer, done chan error) {
@@ -1942,6 +2503,7 @@ func (m *SyncManager) requestFromPeer(peer *Peer, blocks, voteHashes,
 	tSpendHashes, mixHashes []chainhash.Hash) error {
 
 	// Add the blocks to the request.
+	now := time.Now()
 	msgResp := wire.NewMsgGetData()
 	for i := range blocks {
 		// Skip the block when it has already been requested.
@@ -1962,7 +2524,7 @@ func (m *SyncManager) requestFromPeer(peer *Peer, blocks, voteHashes,
 				bh, err.Error())
 		}
 
-		peer.requestedBlocks[*bh] = struct{}{}
+		peer.addRequestedBlock(bh, now)
 		m.requestedBlocks[*bh] = peer
 	}
 
@@ -0,0 +1,279 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package netsync
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/monetarium/monetarium-node/chaincfg"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/database"
+	_ "github.com/monetarium/monetarium-node/database/ffldb"
+	"github.com/monetarium/monetarium-node/internal/blockchain"
+	peerpkg "github.com/monetarium/monetarium-node/peer"
+	"github.com/monetarium/monetarium-node/wire"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// pipeConn wraps a connection from net.Pipe to report a remote address the
+// peer is able to parse.
+type pipeConn struct {
+	net.Conn
+	raddr net.Addr
+}
+
+// RemoteAddr returns the remote address of the connection.
+//
+// This is part of the net.Conn interface.
+func (c *pipeConn) RemoteAddr() net.Addr {
+	return c.raddr
+}
+
+// newTestSyncManager returns a sync manager for the regression test network
+// backed by a chain that only consists of the genesis block.
+func newTestSyncManager(t *testing.T) *SyncManager {
+	t.Helper()
+
+	params := chaincfg.RegNetParams()
+	dataDir := t.TempDir()
+	db, err := database.Create("ffldb", filepath.Join(dataDir, "blocks"),
+		params.Net)
+	if err != nil {
+		t.Fatalf("unable to create database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	utxoDb, err := leveldb.OpenFile(filepath.Join(dataDir, "utxo"), nil)
+	if err != nil {
+		t.Fatalf("unable to create utxo database: %v", err)
+	}
+	t.Cleanup(func() { utxoDb.Close() })
+
+	utxoBackend := blockchain.NewLevelDbUtxoBackend(utxoDb)
+	chain, err := blockchain.New(context.Background(), &blockchain.Config{
+		DB:          db,
+		UtxoBackend: utxoBackend,
+		ChainParams: params,
+		TimeSource:  blockchain.NewMedianTime(),
+		UtxoCache: blockchain.NewUtxoCache(&blockchain.UtxoCacheConfig{
+			Backend:      utxoBackend,
+			FlushBlockDB: db.Flush,
+			MaxSize:      1024 * 1024,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unable to create chain: %v", err)
+	}
+
+	return New(&Config{
+		Chain:       chain,
+		ChainParams: params,
+		TimeSource:  blockchain.NewMedianTime(),
+		MaxPeers:    8,
+	})
+}
+
+// newTestPeer returns a sync manager peer that has completed the version
+// handshake with a remote peer that serves blocks along with a channel that
+// receives the getdata messages the remote peer receives.  The peer is added
+// to the provided sync manager.
+func newTestPeer(t *testing.T, m *SyncManager, addr string) (*Peer, <-chan *wire.MsgGetData) {
+	t.Helper()
+
+	raddr, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		t.Fatalf("unable to resolve address %s: %v", addr, err)
+	}
+	verAck := make(chan struct{}, 1)
+	local, err := peerpkg.NewOutboundPeer(&peerpkg.Config{
+		Listeners: peerpkg.MessageListeners{
+			OnVerAck: func(p *peerpkg.Peer, msg *wire.MsgVerAck) {
+				verAck <- struct{}{}
+			},
+		},
+		UserAgentName:    "peer",
+		UserAgentVersion: "1.0",
+		Net:              m.cfg.ChainParams.Net,
+	}, addr)
+	if err != nil {
+		t.Fatalf("unable to create peer: %v", err)
+	}
+	localConn, remoteConn := net.Pipe()
+	local.AssociateConnection(&pipeConn{Conn: localConn, raddr: raddr})
+	t.Cleanup(func() {
+		local.Disconnect()
+		remoteConn.Close()
+	})
+
+	// Act as the remote peer by answering the version handshake as a full
+	// node and forwarding any getdata messages that follow.  The remote side
+	// is implemented directly since the peer package rejects connections
+	// between peers in the same process as self connections.
+	getData := make(chan *wire.MsgGetData, maxInFlightBlocks)
+	go func() {
+		pver := wire.ProtocolVersion
+		cnet := m.cfg.ChainParams.Net
+		if _, _, err := wire.ReadMessage(remoteConn, pver, cnet); err != nil {
+			return
+		}
+		me := wire.NewNetAddressIPPort(raddr.IP, uint16(raddr.Port),
+			wire.SFNodeNetwork)
+		you := wire.NewNetAddressIPPort(nil, 0, 0)
+		version := wire.NewMsgVersion(me, you, uint64(raddr.Port), 0)
+		version.Services = wire.SFNodeNetwork
+		if err := wire.WriteMessage(remoteConn, version, pver, cnet); err != nil {
+			return
+		}
+		if err := wire.WriteMessage(remoteConn, wire.NewMsgVerAck(), pver,
+			cnet); err != nil {
+
+			return
+		}
+		for {
+			msg, _, err := wire.ReadMessage(remoteConn, pver, cnet)
+			if err != nil {
+				return
+			}
+			if msg, ok := msg.(*wire.MsgGetData); ok {
+				getData <- msg
+			}
+		}
+	}()
+	select {
+	case <-verAck:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for version handshake with %s", addr)
+	}
+
+	peer := NewPeer(local)
+	m.peers[peer] = struct{}{}
+	return peer, getData
+}
+
+// addStalledBlockRequest marks the provided block as requested from the
+// provided peer long enough ago for the request to be considered stalled.
+func addStalledBlockRequest(m *SyncManager, peer *Peer, hash *chainhash.Hash) {
+	m.requestedBlocks[*hash] = peer
+	peer.addRequestedBlock(hash, time.Now().Add(-blockStallTimeout))
+}
+
+// TestBlockStallReassignment ensures stalled block requests are requested from
+// another peer that serves blocks instead and the stall counts against the
+// peer that stalled.
+func TestBlockStallReassignment(t *testing.T) {
+	m := newTestSyncManager(t)
+	stalledPeer, _ := newTestPeer(t, m, "10.0.0.1:8333")
+	otherPeer, otherGetData := newTestPeer(t, m, "10.0.0.2:8333")
+
+	// The genesis block is the only block the chain knows the header of.
+	hash := m.cfg.ChainParams.GenesisHash
+	addStalledBlockRequest(m, stalledPeer, &hash)
+	m.handleBlockStallCheck()
+
+	if _, ok := stalledPeer.requestedBlocks[hash]; ok {
+		t.Fatal("stalled block is still requested from the stalled peer")
+	}
+	if _, ok := stalledPeer.abandonedBlocks[hash]; !ok {
+		t.Fatal("stalled block is not marked abandoned")
+	}
+	if _, ok := otherPeer.requestedBlocks[hash]; !ok {
+		t.Fatal("stalled block was not requested from the other peer")
+	}
+	if m.requestedBlocks[hash] != otherPeer {
+		t.Fatal("stalled block is not tracked as requested from the other " +
+			"peer")
+	}
+	if got := stalledPeer.consecutiveBlockStalls; got != 1 {
+		t.Fatalf("unexpected consecutive stalls -- got %d, want 1", got)
+	}
+	if got := stalledPeer.blockWindow; got != maxInFlightBlocks/2 {
+		t.Fatalf("unexpected block window -- got %d, want %d", got,
+			maxInFlightBlocks/2)
+	}
+	if got := otherPeer.consecutiveBlockStalls; got != 0 {
+		t.Fatalf("unexpected consecutive stalls for other peer -- got %d, "+
+			"want 0", got)
+	}
+
+	select {
+	case msg := <-otherGetData:
+		if len(msg.InvList) != 1 || msg.InvList[0].Hash != hash {
+			t.Fatalf("unexpected getdata for other peer: %v", msg.InvList)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for getdata to other peer")
+	}
+
+	// Ensure the request is not reassigned or counted again.
+	m.handleBlockStallCheck()
+	if got := stalledPeer.consecutiveBlockStalls; got != 1 {
+		t.Fatalf("unexpected consecutive stalls -- got %d, want 1", got)
+	}
+}
+
+// TestBlockStallCounting ensures stalled block requests that can't be
+// requested from another peer only count as a stall once no matter how many
+// times they are checked and that delivering a block resets the count.
+func TestBlockStallCounting(t *testing.T) {
+	m := newTestSyncManager(t)
+	peer, _ := newTestPeer(t, m, "10.0.0.1:8333")
+
+	hash := m.cfg.ChainParams.GenesisHash
+	addStalledBlockRequest(m, peer, &hash)
+	for i := 0; i < maxConsecutiveBlockStalls*2; i++ {
+		m.handleBlockStallCheck()
+	}
+	if got := peer.consecutiveBlockStalls; got != 1 {
+		t.Fatalf("unexpected consecutive stalls -- got %d, want 1", got)
+	}
+	if got := peer.numBlockStalls.Load(); got != 1 {
+		t.Fatalf("unexpected total stalls -- got %d, want 1", got)
+	}
+	if _, ok := peer.requestedBlocks[hash]; !ok {
+		t.Fatal("stalled block is no longer requested from the peer")
+	}
+	if !peer.Connected() {
+		t.Fatal("peer was disconnected")
+	}
+
+	// Ensure delivering the block clears the stalled state and resets the
+	// consecutive stall count.
+	if _, ok := peer.removeRequestedBlock(&hash); !ok {
+		t.Fatal("stalled block is not requested from the peer")
+	}
+	peer.recordBlockDelivery(time.Second)
+	if _, ok := peer.stalledBlocks[hash]; ok {
+		t.Fatal("delivered block is still marked stalled")
+	}
+	if got := peer.consecutiveBlockStalls; got != 0 {
+		t.Fatalf("unexpected consecutive stalls -- got %d, want 0", got)
+	}
+}
+
+// TestBlockStallDisconnect ensures peers that stall on new requests too many
+// consecutive times are disconnected.
+func TestBlockStallDisconnect(t *testing.T) {
+	m := newTestSyncManager(t)
+	peer, _ := newTestPeer(t, m, "10.0.0.1:8333")
+
+	for i := 0; i < maxConsecutiveBlockStalls; i++ {
+		if !peer.Connected() {
+			t.Fatalf("peer disconnected after %d stalls", i)
+		}
+		hash := chainhash.Hash{byte(i + 1)}
+		addStalledBlockRequest(m, peer, &hash)
+		m.handleBlockStallCheck()
+	}
+	if got := peer.consecutiveBlockStalls; got != maxConsecutiveBlockStalls {
+		t.Fatalf("unexpected consecutive stalls -- got %d, want %d", got,
+			maxConsecutiveBlockStalls)
+	}
+	if peer.Connected() {
+		t.Fatal("peer was not disconnected")
+	}
+}
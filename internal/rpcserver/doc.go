@@ -5,5 +5,30 @@
 /*
 Package rpcserver includes all RPC server interfaces, types, and pieces of code
 pertaining to implementing the RPC server.
+
+# REST Interface
+
+The RPC server optionally serves an unauthenticated read-only REST interface on
+its own set of listeners.  Every endpoint ends with an extension that selects
+the response format: bin for the raw serialized data, hex for the hex-encoded
+serialized data, and json for the same JSON as the equivalent RPC.  The
+available endpoints are:
+
+	/rest/block/<hash>.<bin|hex|json>
+	/rest/headers/<count>/<hash>.<bin|hex|json>
+	/rest/blockhashbyheight/<height>.<bin|hex|json>
+	/rest/tx/<txid>.<bin|hex|json>
+	/rest/txout/<txid>/<tree>/<vout>.json[?mempool=0]
+	/rest/chaininfo.json
+	/rest/mempool/info.json
+	/rest/mempool/contents.json
+	/rest/ska/info.json
+	/rest/ska/emission/<cointype>.json
+	/rest/ska/burned.json
+	/rest/ska/burned/<cointype>.json
+
+Requests are rate limited per client host.  Serialized blocks requested by hash
+are marked as immutable for caching purposes while all other responses may
+only be cached for a few seconds.
 */
 package rpcserver
@@ -6,8 +6,10 @@ package rpcserver
 
 import (
 	"context"
+	"io"
 	"math/big"
 	"net"
+	"net/netip"
 	"time"
 
 	"github.com/monetarium/monetarium-node/addrmgr"
@@ -20,6 +22,8 @@ import (
 	"github.com/monetarium/monetarium-node/internal/blockchain/indexers"
 	"github.com/monetarium/monetarium-node/internal/mempool"
 	"github.com/monetarium/monetarium-node/internal/mining"
+	"github.com/monetarium/monetarium-node/internal/netsync"
+	"github.com/monetarium/monetarium-node/internal/staging/banmanager"
 	"github.com/monetarium/monetarium-node/math/uint256"
 	"github.com/monetarium/monetarium-node/mixing"
 	"github.com/monetarium/monetarium-node/peer"
@@ -94,6 +98,15 @@ type Peer interface {
 	// BanScore returns the current integer value that represents how close
 	// the peer is to being banned.
 	BanScore() uint32
+
+	// BlockDownloadStats returns statistics about the blocks requested from
+	// the peer during the chain sync process.
+	BlockDownloadStats() netsync.BlockDownloadStats
+
+	// MappedAS returns the autonomous system number the address of the peer
+	// is mapped to by the IP-to-ASN map in use.  It is zero when there is no
+	// map or the address is not mapped.
+	MappedAS() uint32
 }
 
 // AddrManager represents an address manager for use with the RPC server.
@@ -104,6 +117,15 @@ type AddrManager interface {
 	// LocalAddresses returns a summary of local addresses information for
 	// the getnetworkinfo rpc.
 	LocalAddresses() []addrmgr.LocalAddr
+
+	// AddressCache returns a randomized subset of the known good addresses
+	// that match the provided filter.
+	AddressCache(filter addrmgr.NetAddressTypeFilter) []*addrmgr.NetAddress
+
+	// ASN returns the autonomous system number the provided address is
+	// mapped to by the IP-to-ASN map in use.  It is zero when there is no
+	// map or the address is not mapped.
+	ASN(na *addrmgr.NetAddress) uint32
 }
 
 // ConnManager represents a connection manager for use with the RPC server.
@@ -145,6 +167,10 @@ type ConnManager interface {
 	// network for all peers.
 	NetTotals() (uint64, uint64)
 
+	// NetworkStats returns detailed traffic accounting across all peers along
+	// with the state of the configured upload limits.
+	NetworkStats() *NetworkStats
+
 	// ConnectedPeers returns an array consisting of all connected peers.
 	ConnectedPeers() []Peer
 
@@ -164,12 +190,50 @@ type ConnManager interface {
 	// the passed transactions to all connected peers.
 	RelayTransactions(txns []*dcrutil.Tx)
 
+	// StemTransaction attempts to relay the passed locally submitted
+	// transaction via the stem phase of private transaction relay.  It
+	// returns false without an error when the transaction should instead be
+	// processed and relayed normally, such as when stem relay is disabled or
+	// no stem peer is available.
+	StemTransaction(tx *dcrutil.Tx, allowHighFees bool) (bool, error)
+
 	// RelayMixMessages generates and relays inventory vectors for all of
 	// the passed mixing messages to all connected peers.
 	RelayMixMessages(msgs []mixing.Message)
 
 	// Lookup defines the DNS lookup function to be used.
 	Lookup(host string) ([]net.IP, error)
+
+	// SetBan bans the provided subnet for the provided duration along with
+	// the reason for the ban and disconnects all connected peers within it.
+	// The default ban duration is used when the duration is zero.
+	SetBan(subnet netip.Prefix, duration time.Duration, reason string) error
+
+	// RemoveBan removes the ban of the provided subnet.  Attempting to remove
+	// a subnet that is not banned will return an error.
+	RemoveBan(subnet netip.Prefix) error
+
+	// BannedSubnets returns all currently banned subnets.
+	BannedSubnets() []banmanager.BanEntry
+
+	// ClearBanned removes all bans.
+	ClearBanned() error
+}
+
+// NetworkStats houses detailed traffic accounting across all peers along with
+// the state of the configured upload limits as used by the RPC interface.
+type NetworkStats struct {
+	TotalBytesRecv      uint64
+	TotalBytesSent      uint64
+	BytesRecvPerMsg     map[string]uint64 // Keyed by message command
+	BytesSentPerMsg     map[string]uint64 // Keyed by message command
+	MaxUploadRate       uint64            // Bytes per second, 0 if unlimited
+	MaxPeerUploadRate   uint64            // Bytes per second, 0 if unlimited
+	UploadTarget        uint64            // Bytes per cycle, 0 if unlimited
+	UploadTargetCycle   time.Duration
+	UploadTargetReached bool
+	BytesLeftInCycle    uint64
+	TimeLeftInCycle     time.Duration
 }
 
 // SyncManager represents a sync manager for use with the RPC server.
@@ -196,6 +260,11 @@ type SyncManager interface {
 	ProcessTransaction(tx *dcrutil.Tx, allowOrphans bool, allowHighFees bool,
 		tag mempool.Tag) ([]*dcrutil.Tx, error)
 
+	// ProcessPackage relays the provided package of transactions for
+	// validation and insertion into the memory pool with the fee
+	// requirements checked against the package as a whole.
+	ProcessPackage(txns []*dcrutil.Tx, allowHighFees bool) ([]*dcrutil.Tx, error)
+
 	// RecentlyConfirmedTxn returns with high degree of confidence whether a
 	// transaction has been recently confirmed in a block.
 	//
@@ -229,6 +298,13 @@ type UtxoEntry interface {
 	// Amount returns the amount of the output.
 	Amount() int64
 
+	// SKAAmount returns the amount of the output for SKA coin types.  It
+	// returns nil for VAR outputs.
+	SKAAmount() *big.Int
+
+	// CoinType returns the coin type of the output.
+	CoinType() cointype.CoinType
+
 	// ScriptVersion returns the public key script version for the output.
 	ScriptVersion() uint16
 
@@ -321,6 +397,10 @@ type Chain interface {
 	// FetchUtxoStats returns statistics on the current utxo set.
 	FetchUtxoStats() (*blockchain.UtxoStats, error)
 
+	// DumpUtxoSnapshot writes a snapshot of the UTXO set and the associated
+	// chain state as of the current best chain tip to the provided writer.
+	DumpUtxoSnapshot(w io.Writer) (*blockchain.UtxoSnapshotInfo, error)
+
 	// GetStakeVersions returns a cooked array of StakeVersions.  We do this in
 	// order to not bloat memory by returning raw blocks.
 	GetStakeVersions(hash *chainhash.Hash, count int32) ([]blockchain.StakeVersions, error)
@@ -480,6 +560,11 @@ type Chain interface {
 	// GetAllSKABurnedAmounts returns a map of all SKA coin types to their total
 	// burned amounts. Only coin types with non-zero burned amounts are included.
 	GetAllSKABurnedAmounts() map[cointype.CoinType]*big.Int
+
+	// SKASupplyProof returns the supply state of the passed SKA coin type as of
+	// the current tip of the main chain along with the proofs that it is
+	// committed to by the header of the tip block.
+	SKASupplyProof(cointype.CoinType) (*blockchain.SKASupplyProof, error)
 }
 
 // Clock represents a clock for use with the RPC server. The purpose of this
@@ -703,6 +788,11 @@ type TxMempooler interface {
 	// TSpendHashes returns the hashes of the treasury spend transactions
 	// currently in the mempool.
 	TSpendHashes() []chainhash.Hash
+
+	// TxHistory returns the recently rejected and evicted transactions
+	// ordered from newest to oldest.  The entries must be treated as read
+	// only.
+	TxHistory() []*mempool.TxHistoryEntry
 }
 
 // MixPooler represents a source of mixpool message data for the RPC server.
@@ -759,6 +849,10 @@ type NtfnManager interface {
 	// NotifyTSpend passes new tspends to the manager for processing.
 	NotifyTSpend(tx *dcrutil.Tx)
 
+	// NotifyMempoolHistory passes a transaction that was rejected from or
+	// evicted out of the mempool to the manager for processing.
+	NotifyMempoolHistory(entry *mempool.TxHistoryEntry)
+
 	// NotifyReorganization passes a blockchain reorganization notification to
 	// the manager for processing.
 	NotifyReorganization(rd *blockchain.ReorganizationNtfnsData)
@@ -806,6 +900,16 @@ type NtfnManager interface {
 	// websocket client.
 	UnregisterTSpendUpdates(wsc *wsClient)
 
+	// RegisterMempoolHistoryUpdates requests notifications to the passed
+	// websocket client when transactions are rejected from or evicted out of
+	// the mempool.
+	RegisterMempoolHistoryUpdates(wsc *wsClient)
+
+	// UnregisterMempoolHistoryUpdates removes notifications to the passed
+	// websocket client when transactions are rejected from or evicted out of
+	// the mempool.
+	UnregisterMempoolHistoryUpdates(wsc *wsClient)
+
 	// RegisterWinningTickets requests winning tickets update notifications
 	// to the passed websocket client.
 	RegisterWinningTickets(wsc *wsClient)
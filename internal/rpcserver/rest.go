@@ -0,0 +1,622 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdlog "log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/dcrjson"
+	"github.com/monetarium/monetarium-node/rpc/jsonrpc/types"
+)
+
+const (
+	// restMaxHeaders is the maximum number of block headers that may be
+	// requested at once via the REST interface.
+	restMaxHeaders = 2000
+
+	// restImmutableMaxAge is the number of seconds clients and intermediate
+	// caches are permitted to cache REST responses which can never change,
+	// such as serialized blocks requested by their hash.
+	restImmutableMaxAge = 365 * 24 * 60 * 60
+
+	// restDynamicMaxAge is the number of seconds clients and intermediate
+	// caches are permitted to cache REST responses which depend on the current
+	// state of the chain or mempool.
+	restDynamicMaxAge = 5
+
+	// restLimiterPruneInterval is the interval at which the per-client state
+	// of the REST rate limiter is pruned of clients that have been idle long
+	// enough to have regained their full allowance.
+	restLimiterPruneInterval = time.Minute
+
+	// restReadTimeout and restWriteTimeout are the maximum durations for
+	// reading a REST request and writing its response, respectively.
+	restReadTimeout  = time.Second * 10
+	restWriteTimeout = time.Second * 60
+)
+
+// restFormat identifies the encoding of a REST response.
+type restFormat int
+
+const (
+	// restFormatBinary indicates the response is the raw serialized data.
+	restFormatBinary restFormat = iota
+
+	// restFormatHex indicates the response is the hex-encoded serialized data.
+	restFormatHex
+
+	// restFormatJSON indicates the response is JSON.
+	restFormatJSON
+)
+
+// restFormats maps the file extensions supported by the REST interface to the
+// response format they request.
+var restFormats = map[string]restFormat{
+	"bin":  restFormatBinary,
+	"hex":  restFormatHex,
+	"json": restFormatJSON,
+}
+
+// restResult houses the result of a REST request along with whether or not it
+// may be cached indefinitely.
+type restResult struct {
+	// value is the response.  It is a byte slice for binary and hex responses
+	// and any value that can be marshalled to JSON otherwise.
+	value interface{}
+
+	// immutable indicates the response can never change for the requested
+	// path.
+	immutable bool
+}
+
+// restHandler describes a handler for a REST endpoint.  The format is the
+// format requested by the client and is always one of the formats the
+// endpoint was registered with.
+type restHandler func(ctx context.Context, s *Server, r *http.Request, format restFormat) (*restResult, error)
+
+// restRateLimiter limits the rate of REST requests per client host by means of
+// a token bucket with a capacity of one second worth of requests.
+type restRateLimiter struct {
+	mtx       sync.Mutex
+	rate      float64
+	clients   map[string]*restClientLimit
+	lastPrune time.Time
+}
+
+// restClientLimit houses the remaining allowance of a REST client.
+type restClientLimit struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// newRESTRateLimiter returns a rate limiter which allows the provided number
+// of requests per second per client host.
+func newRESTRateLimiter(rate int) *restRateLimiter {
+	return &restRateLimiter{
+		rate:    float64(rate),
+		clients: make(map[string]*restClientLimit),
+	}
+}
+
+// allow returns whether or not a request from the provided client host is
+// allowed at the given time and consumes from its allowance when it is.
+//
+// This function is safe for concurrent access.
+func (l *restRateLimiter) allow(host string, now time.Time) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	// Prune clients that have been idle long enough to have regained their
+	// full allowance since they are indistinguishable from new clients.
+	if now.Sub(l.lastPrune) > restLimiterPruneInterval {
+		for clientHost, client := range l.clients {
+			if now.Sub(client.lastSeen) > time.Second {
+				delete(l.clients, clientHost)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	client, ok := l.clients[host]
+	if !ok {
+		client = &restClientLimit{tokens: l.rate, lastSeen: now}
+		l.clients[host] = client
+	}
+	elapsed := now.Sub(client.lastSeen).Seconds()
+	client.tokens = math.Min(l.rate, client.tokens+elapsed*l.rate)
+	client.lastSeen = now
+	if client.tokens < 1 {
+		return false
+	}
+	client.tokens--
+	return true
+}
+
+// parseRESTFile splits the final path component of a REST request into the
+// resource and the requested format while ensuring the format is one of the
+// provided allowed formats.
+func parseRESTFile(file string, allowed []restFormat) (string, restFormat, error) {
+	idx := strings.LastIndexByte(file, '.')
+	if idx == -1 {
+		return "", 0, rpcInvalidError("Missing format extension in %q", file)
+	}
+	resource, ext := file[:idx], file[idx+1:]
+	format, ok := restFormats[ext]
+	if ok {
+		for _, allowedFormat := range allowed {
+			if format == allowedFormat {
+				return resource, format, nil
+			}
+		}
+	}
+	exts := make([]string, 0, len(allowed))
+	for ext, format := range restFormats {
+		for _, allowedFormat := range allowed {
+			if format == allowedFormat {
+				exts = append(exts, ext)
+			}
+		}
+	}
+	return "", 0, rpcInvalidError("Unsupported format %q (available: %s)",
+		ext, strings.Join(exts, ", "))
+}
+
+// restRawResult converts the provided hex-encoded result of a non-verbose RPC
+// handler to the raw bytes for a REST response.
+func restRawResult(result interface{}) ([]byte, error) {
+	hexStr, ok := result.(string)
+	if !ok {
+		str := fmt.Sprintf("unexpected result type %T", result)
+		return nil, rpcInternalErr(errors.New(str), "")
+	}
+	b, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, rpcInternalErr(err, "Failed to decode result")
+	}
+	return b, nil
+}
+
+// restErrorStatus returns the HTTP status code to use for the provided error
+// returned by a REST handler.
+func restErrorStatus(err error) int {
+	var rpcErr *dcrjson.RPCError
+	if !errors.As(err, &rpcErr) {
+		return http.StatusInternalServerError
+	}
+	switch rpcErr.Code {
+	case dcrjson.ErrRPCBlockNotFound, dcrjson.ErrRPCOutOfRange:
+		return http.StatusNotFound
+	case dcrjson.ErrRPCInvalidParameter, dcrjson.ErrRPCDecodeHexString,
+		dcrjson.ErrRPCInvalidParams.Code:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// restError writes the message of the provided error with a status code
+// determined by the error to the client.
+func restError(w http.ResponseWriter, err error) {
+	message := err.Error()
+	var rpcErr *dcrjson.RPCError
+	if errors.As(err, &rpcErr) {
+		message = rpcErr.Message
+	}
+	http.Error(w, message, restErrorStatus(err))
+}
+
+// handleRESTBlock implements the /rest/block/<hash>.<bin|hex|json> endpoint.
+func handleRESTBlock(ctx context.Context, s *Server, r *http.Request, format restFormat) (*restResult, error) {
+	hash := r.PathValue("resource")
+	verbose := format == restFormatJSON
+	result, err := handleGetBlock(ctx, s, &types.GetBlockCmd{
+		Hash:      hash,
+		Verbose:   &verbose,
+		VerboseTx: &verbose,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if verbose {
+		return &restResult{value: result}, nil
+	}
+	b, err := restRawResult(result)
+	if err != nil {
+		return nil, err
+	}
+	return &restResult{value: b, immutable: true}, nil
+}
+
+// handleRESTHeaders implements the /rest/headers/<count>/<hash>.<bin|hex|json>
+// endpoint which returns up to count main chain headers starting with the
+// provided block.
+func handleRESTHeaders(ctx context.Context, s *Server, r *http.Request, format restFormat) (*restResult, error) {
+	count, err := strconv.ParseUint(r.PathValue("count"), 10, 32)
+	if err != nil || count == 0 || count > restMaxHeaders {
+		return nil, rpcInvalidError("Header count must be between 1 and %d",
+			restMaxHeaders)
+	}
+	hashStr := r.PathValue("resource")
+	hash, err := chainhash.NewHashFromStr(hashStr)
+	if err != nil {
+		return nil, rpcDecodeHexError(hashStr)
+	}
+
+	// Only blocks in the main chain have a well-defined set of headers that
+	// follow them.
+	chain := s.cfg.Chain
+	if !chain.MainChainHasBlock(hash) {
+		return nil, rpcBlockNotFoundError(*hash)
+	}
+	height, err := chain.BlockHeightByHash(hash)
+	if err != nil {
+		return nil, rpcBlockNotFoundError(*hash)
+	}
+	best := chain.BestSnapshot()
+	if remaining := uint64(best.Height-height) + 1; count > remaining {
+		count = remaining
+	}
+
+	var rawHeaders bytes.Buffer
+	var verboseHeaders []interface{}
+	verbose := format == restFormatJSON
+	for i := int64(0); i < int64(count); i++ {
+		blockHash := hash
+		if i != 0 {
+			blockHash, err = chain.BlockHashByHeight(height + i)
+			if err != nil {
+				// The chain was reorganized below the requested count
+				// while collecting the headers.
+				break
+			}
+		}
+		result, err := handleGetBlockHeader(ctx, s, &types.GetBlockHeaderCmd{
+			Hash:    blockHash.String(),
+			Verbose: &verbose,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if verbose {
+			verboseHeaders = append(verboseHeaders, result)
+			continue
+		}
+		b, err := restRawResult(result)
+		if err != nil {
+			return nil, err
+		}
+		rawHeaders.Write(b)
+	}
+	if verbose {
+		return &restResult{value: verboseHeaders}, nil
+	}
+	return &restResult{value: rawHeaders.Bytes()}, nil
+}
+
+// handleRESTBlockHashByHeight implements the
+// /rest/blockhashbyheight/<height>.<bin|hex|json> endpoint.
+func handleRESTBlockHashByHeight(ctx context.Context, s *Server, r *http.Request, format restFormat) (*restResult, error) {
+	heightStr := r.PathValue("resource")
+	height, err := strconv.ParseInt(heightStr, 10, 64)
+	if err != nil || height < 0 {
+		return nil, rpcInvalidError("Invalid height %q", heightStr)
+	}
+	result, err := handleGetBlockHash(ctx, s, &types.GetBlockHashCmd{
+		Index: height,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hashStr := result.(string)
+	switch format {
+	case restFormatJSON:
+		return &restResult{value: map[string]string{"blockhash": hashStr}}, nil
+	case restFormatHex:
+		return &restResult{value: []byte(hashStr)}, nil
+	}
+	hash, err := chainhash.NewHashFromStr(hashStr)
+	if err != nil {
+		return nil, rpcInternalErr(err, "Failed to decode block hash")
+	}
+	return &restResult{value: hash[:]}, nil
+}
+
+// handleRESTTx implements the /rest/tx/<txid>.<bin|hex|json> endpoint.
+func handleRESTTx(ctx context.Context, s *Server, r *http.Request, format restFormat) (*restResult, error) {
+	var verbose int
+	if format == restFormatJSON {
+		verbose = 1
+	}
+	result, err := handleGetRawTransaction(ctx, s, &types.GetRawTransactionCmd{
+		Txid:    r.PathValue("resource"),
+		Verbose: &verbose,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if format == restFormatJSON {
+		return &restResult{value: result}, nil
+	}
+	b, err := restRawResult(result)
+	if err != nil {
+		return nil, err
+	}
+	return &restResult{value: b}, nil
+}
+
+// handleRESTTxOut implements the /rest/txout/<txid>/<tree>/<vout>.json
+// endpoint.  Outputs in the mempool are included unless the mempool query
+// parameter is set to 0.
+func handleRESTTxOut(ctx context.Context, s *Server, r *http.Request, _ restFormat) (*restResult, error) {
+	treeStr := r.PathValue("tree")
+	tree, err := strconv.ParseInt(treeStr, 10, 8)
+	if err != nil {
+		return nil, rpcInvalidError("Invalid tree %q", treeStr)
+	}
+	voutStr := r.PathValue("resource")
+	vout, err := strconv.ParseUint(voutStr, 10, 32)
+	if err != nil {
+		return nil, rpcInvalidError("Invalid output index %q", voutStr)
+	}
+	includeMempool := r.URL.Query().Get("mempool") != "0"
+	result, err := handleGetTxOut(ctx, s, &types.GetTxOutCmd{
+		Txid:           r.PathValue("txid"),
+		Vout:           uint32(vout),
+		Tree:           int8(tree),
+		IncludeMempool: &includeMempool,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, &dcrjson.RPCError{
+			Code:    dcrjson.ErrRPCInvalidTxVout,
+			Message: "Unspent transaction output not found",
+		}
+	}
+	return &restResult{value: result}, nil
+}
+
+// handleRESTCommand returns a REST handler which responds with the result of
+// the provided RPC command handler invoked with the command returned by the
+// provided function.
+func handleRESTCommand(handler commandHandler, cmdFn func(r *http.Request) (interface{}, error)) restHandler {
+	return func(ctx context.Context, s *Server, r *http.Request, _ restFormat) (*restResult, error) {
+		cmd, err := cmdFn(r)
+		if err != nil {
+			return nil, err
+		}
+		result, err := handler(ctx, s, cmd)
+		if err != nil {
+			return nil, err
+		}
+		return &restResult{value: result}, nil
+	}
+}
+
+// restNoCmd is a command function for RPC command handlers that do not take
+// any parameters.
+func restNoCmd(*http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+// restCoinTypeCmd returns a command function which parses the coin type from
+// the resource of the request and passes it to the provided function to create
+// the command.
+func restCoinTypeCmd(fn func(coinType uint8) interface{}) func(r *http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		coinTypeStr := r.PathValue("resource")
+		coinType, err := strconv.ParseUint(coinTypeStr, 10, 8)
+		if err != nil {
+			return nil, rpcInvalidError("Invalid coin type %q", coinTypeStr)
+		}
+		return fn(uint8(coinType)), nil
+	}
+}
+
+// restEndpoint describes an endpoint of the REST interface.
+type restEndpoint struct {
+	pattern string
+	formats []restFormat
+	handler restHandler
+}
+
+// restFormatsAll and restFormatsJSON are the sets of formats supported by the
+// REST endpoints.
+var (
+	restFormatsAll  = []restFormat{restFormatBinary, restFormatHex, restFormatJSON}
+	restFormatsJSON = []restFormat{restFormatJSON}
+)
+
+// restEndpoints houses the endpoints of the REST interface.  The final path
+// component of every pattern is the resource followed by an extension that
+// identifies the requested format.  The pattern must name it {resource} when
+// the resource is a parameter.
+var restEndpoints = []restEndpoint{{
+	pattern: "/rest/block/{resource}",
+	formats: restFormatsAll,
+	handler: handleRESTBlock,
+}, {
+	pattern: "/rest/headers/{count}/{resource}",
+	formats: restFormatsAll,
+	handler: handleRESTHeaders,
+}, {
+	pattern: "/rest/blockhashbyheight/{resource}",
+	formats: restFormatsAll,
+	handler: handleRESTBlockHashByHeight,
+}, {
+	pattern: "/rest/tx/{resource}",
+	formats: restFormatsAll,
+	handler: handleRESTTx,
+}, {
+	pattern: "/rest/txout/{txid}/{tree}/{resource}",
+	formats: restFormatsJSON,
+	handler: handleRESTTxOut,
+}, {
+	pattern: "/rest/chaininfo.json",
+	formats: restFormatsJSON,
+	handler: handleRESTCommand(handleGetBlockchainInfo, restNoCmd),
+}, {
+	pattern: "/rest/mempool/info.json",
+	formats: restFormatsJSON,
+	handler: handleRESTCommand(handleGetMempoolInfo, restNoCmd),
+}, {
+	pattern: "/rest/mempool/contents.json",
+	formats: restFormatsJSON,
+	handler: handleRESTCommand(handleGetRawMempool,
+		func(*http.Request) (interface{}, error) {
+			verbose := true
+			return &types.GetRawMempoolCmd{Verbose: &verbose}, nil
+		}),
+}, {
+	pattern: "/rest/ska/info.json",
+	formats: restFormatsJSON,
+	handler: handleRESTCommand(handleGetSKAInfo, restNoCmd),
+}, {
+	pattern: "/rest/ska/emission/{resource}",
+	formats: restFormatsJSON,
+	handler: handleRESTCommand(handleGetEmissionStatus,
+		restCoinTypeCmd(func(coinType uint8) interface{} {
+			return &types.GetEmissionStatusCmd{CoinType: coinType}
+		})),
+}, {
+	pattern: "/rest/ska/burned.json",
+	formats: restFormatsJSON,
+	handler: handleRESTCommand(handleGetBurnedCoins,
+		func(*http.Request) (interface{}, error) {
+			return &types.GetBurnedCoinsCmd{}, nil
+		}),
+}, {
+	pattern: "/rest/ska/burned/{resource}",
+	formats: restFormatsJSON,
+	handler: handleRESTCommand(handleGetBurnedCoins,
+		restCoinTypeCmd(func(coinType uint8) interface{} {
+			return &types.GetBurnedCoinsCmd{CoinType: &coinType}
+		})),
+}}
+
+// serveREST serves the provided REST endpoint for the given request which has
+// already been authorized by the rate limiter.
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request, endpoint *restEndpoint) {
+	// Determine the requested format and strip the extension from the
+	// resource so handlers only need to deal with the resource itself.
+	file := r.PathValue("resource")
+	if file == "" {
+		file = endpoint.pattern[strings.LastIndexByte(endpoint.pattern, '/')+1:]
+	}
+	resource, format, err := parseRESTFile(file, endpoint.formats)
+	if err != nil {
+		restError(w, err)
+		return
+	}
+	r.SetPathValue("resource", resource)
+
+	result, err := endpoint.handler(r.Context(), s, r, format)
+	if err != nil {
+		restError(w, err)
+		return
+	}
+
+	// Immutable results are identified by their path, so there is no need to
+	// regenerate the response for clients that already have it.
+	header := w.Header()
+	if result.immutable {
+		etag := strconv.Quote(r.URL.Path)
+		header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d, "+
+			"immutable", restImmutableMaxAge))
+		header.Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	} else {
+		header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d",
+			restDynamicMaxAge))
+	}
+
+	var body []byte
+	switch format {
+	case restFormatBinary:
+		header.Set("Content-Type", "application/octet-stream")
+		body = result.value.([]byte)
+
+	case restFormatHex:
+		header.Set("Content-Type", "text/plain")
+		raw := result.value.([]byte)
+		body = make([]byte, hex.EncodedLen(len(raw))+1)
+		hex.Encode(body, raw)
+		body[len(body)-1] = '\n'
+
+	case restFormatJSON:
+		header.Set("Content-Type", "application/json")
+		body, err = json.Marshal(result.value)
+		if err != nil {
+			log.Errorf("Failed to marshal REST reply: %v", err)
+			restError(w, rpcInternalErr(err, "Failed to marshal reply"))
+			return
+		}
+		body = append(body, '\n')
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	if _, err := w.Write(body); err != nil {
+		log.Debugf("Failed to write REST reply: %v", err)
+	}
+}
+
+// restRoute returns the HTTP server which serves the read-only REST interface.
+// The interface does not require authentication, so only publicly available
+// data that does not modify any state is served.
+func (s *Server) restRoute(ctx context.Context) *http.Server {
+	limiter := newRESTRateLimiter(s.cfg.RESTMaxRequestsPerSec)
+	restServeMux := http.NewServeMux()
+	for i := range restEndpoints {
+		endpoint := &restEndpoints[i]
+		pattern := http.MethodGet + " " + endpoint.pattern
+		restServeMux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			host, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				host = r.RemoteAddr
+			}
+			if !limiter.allow(host, time.Now()) {
+				log.Debugf("REST request from %s exceeded the rate limit",
+					r.RemoteAddr)
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, "Rate limit exceeded",
+					http.StatusTooManyRequests)
+				return
+			}
+			s.serveREST(w, r, endpoint)
+		})
+	}
+	return &http.Server{
+		Handler: restServeMux,
+
+		// Use the provided context as the parent context for all requests to
+		// ensure handlers are able to react to both client disconnects as well
+		// as shutdown via the provided context.
+		BaseContext: func(l net.Listener) context.Context {
+			return ctx
+		},
+
+		ReadTimeout:  restReadTimeout,
+		WriteTimeout: restWriteTimeout,
+
+		// Reroute http server error logging through the rpcserver logger.
+		ErrorLog: stdlog.New(logForwarder{}, "", 0),
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/monetarium/monetarium-node/dcrutil"
+	"github.com/monetarium/monetarium-node/rpc/jsonrpc/types"
+)
+
+// TestRESTRateLimiter ensures the REST rate limiter enforces the per-client
+// allowance, replenishes it over time, and prunes idle clients.
+func TestRESTRateLimiter(t *testing.T) {
+	const rate = 4
+	limiter := newRESTRateLimiter(rate)
+	now := time.Unix(1700000000, 0)
+	for i := 0; i < rate; i++ {
+		if !limiter.allow("client1", now) {
+			t.Fatalf("request %d unexpectedly denied", i)
+		}
+	}
+	if limiter.allow("client1", now) {
+		t.Fatal("request over the allowance unexpectedly allowed")
+	}
+	if !limiter.allow("client2", now) {
+		t.Fatal("request from a different client unexpectedly denied")
+	}
+
+	// Ensure the allowance is replenished proportionally to the elapsed time.
+	now = now.Add(time.Second / rate)
+	if !limiter.allow("client1", now) {
+		t.Fatal("request after replenishment unexpectedly denied")
+	}
+	if limiter.allow("client1", now) {
+		t.Fatal("request over the replenished allowance unexpectedly allowed")
+	}
+
+	// Ensure idle clients are pruned.
+	now = now.Add(restLimiterPruneInterval * 2)
+	limiter.allow("client3", now)
+	if len(limiter.clients) != 1 {
+		t.Fatalf("unexpected number of tracked clients -- got %d, want 1",
+			len(limiter.clients))
+	}
+}
+
+// TestRESTServer ensures the REST interface serves the expected responses,
+// status codes, and caching headers.
+func TestRESTServer(t *testing.T) {
+	t.Parallel()
+
+	blk := dcrutil.NewBlock(&block432100)
+	blkHash := blk.Hash().String()
+	blkBytes, err := blk.Bytes()
+	if err != nil {
+		t.Fatalf("unable to serialize block: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		method     string
+		header     http.Header
+		mockChain  *testRPCChain
+		rate       int
+		numReqs    int
+		wantStatus int
+		wantType   string
+		wantBody   []byte
+		immutable  bool
+		checkJSON  func(t *testing.T, body []byte)
+	}{{
+		name:       "block binary",
+		path:       "/rest/block/" + blkHash + ".bin",
+		wantStatus: http.StatusOK,
+		wantType:   "application/octet-stream",
+		wantBody:   blkBytes,
+		immutable:  true,
+	}, {
+		name:       "block hex",
+		path:       "/rest/block/" + blkHash + ".hex",
+		wantStatus: http.StatusOK,
+		wantType:   "text/plain",
+		wantBody:   []byte(hex.EncodeToString(blkBytes) + "\n"),
+		immutable:  true,
+	}, {
+		name:       "block json",
+		path:       "/rest/block/" + blkHash + ".json",
+		wantStatus: http.StatusOK,
+		wantType:   "application/json",
+		checkJSON: func(t *testing.T, body []byte) {
+			var result types.GetBlockVerboseResult
+			if err := json.Unmarshal(body, &result); err != nil {
+				t.Fatalf("unable to unmarshal block: %v", err)
+			}
+			if result.Hash != blkHash {
+				t.Fatalf("unexpected block hash -- got %s, want %s",
+					result.Hash, blkHash)
+			}
+			if len(result.RawTx) != len(block432100.Transactions) {
+				t.Fatalf("unexpected number of txns -- got %d, want %d",
+					len(result.RawTx), len(block432100.Transactions))
+			}
+		},
+	}, {
+		name:       "block not modified",
+		path:       "/rest/block/" + blkHash + ".bin",
+		header:     http.Header{"If-None-Match": {`"/rest/block/` + blkHash + `.bin"`}},
+		wantStatus: http.StatusNotModified,
+		immutable:  true,
+	}, {
+		name: "block not found",
+		path: "/rest/block/" + blkHash + ".bin",
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.blockByHash = nil
+			chain.blockByHashErr = errors.New("block not found")
+			return chain
+		}(),
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "block invalid hash",
+		path:       "/rest/block/zz.bin",
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name:       "block missing format",
+		path:       "/rest/block/" + blkHash,
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name:       "block unsupported format",
+		path:       "/rest/block/" + blkHash + ".xml",
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name:       "block wrong method",
+		path:       "/rest/block/" + blkHash + ".bin",
+		method:     http.MethodPost,
+		wantStatus: http.StatusMethodNotAllowed,
+	}, {
+		name:       "block hash by height json",
+		path:       "/rest/blockhashbyheight/432100.json",
+		wantStatus: http.StatusOK,
+		wantType:   "application/json",
+		wantBody:   []byte(`{"blockhash":"` + blkHash + `"}` + "\n"),
+	}, {
+		name:       "block hash by height binary",
+		path:       "/rest/blockhashbyheight/432100.bin",
+		wantStatus: http.StatusOK,
+		wantType:   "application/octet-stream",
+		wantBody:   blk.Hash()[:],
+	}, {
+		name:       "block hash by height invalid",
+		path:       "/rest/blockhashbyheight/-1.json",
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name:       "headers binary",
+		path:       "/rest/headers/5/" + blkHash + ".bin",
+		wantStatus: http.StatusOK,
+		wantType:   "application/octet-stream",
+		wantBody: func() []byte {
+			var buf bytes.Buffer
+			block432100.Header.Serialize(&buf)
+			return buf.Bytes()
+		}(),
+	}, {
+		name: "headers not in main chain",
+		path: "/rest/headers/5/" + blkHash + ".bin",
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.mainChainHasBlock = false
+			return chain
+		}(),
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "headers invalid count",
+		path:       "/rest/headers/2001/" + blkHash + ".bin",
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name:       "txout json format only",
+		path:       "/rest/txout/" + blkHash + "/0/0.bin",
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name: "txout not found",
+		path: "/rest/txout/" + blkHash + "/0/0.json?mempool=0",
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.fetchUtxoEntry = nil
+			return chain
+		}(),
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "ska info",
+		path:       "/rest/ska/info.json",
+		wantStatus: http.StatusOK,
+		wantType:   "application/json",
+		checkJSON: func(t *testing.T, body []byte) {
+			var result []types.GetSKAInfoResult
+			if err := json.Unmarshal(body, &result); err != nil {
+				t.Fatalf("unable to unmarshal ska info: %v", err)
+			}
+		},
+	}, {
+		name:       "emission invalid coin type",
+		path:       "/rest/ska/emission/0.json",
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name:       "unknown endpoint",
+		path:       "/rest/unknown.json",
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "rate limited",
+		path:       "/rest/ska/info.json",
+		rate:       1,
+		numReqs:    2,
+		wantStatus: http.StatusTooManyRequests,
+	}}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := defaultMockConfig(defaultChainParams)
+			if test.mockChain != nil {
+				cfg.Chain = test.mockChain
+			}
+			cfg.RESTMaxRequestsPerSec = 100
+			if test.rate != 0 {
+				cfg.RESTMaxRequestsPerSec = test.rate
+			}
+			s, err := New(cfg)
+			if err != nil {
+				t.Fatalf("unable to create server: %v", err)
+			}
+			handler := s.restRoute(context.Background()).Handler
+
+			method := http.MethodGet
+			if test.method != "" {
+				method = test.method
+			}
+			numReqs := 1
+			if test.numReqs != 0 {
+				numReqs = test.numReqs
+			}
+			var rec *httptest.ResponseRecorder
+			for i := 0; i < numReqs; i++ {
+				req := httptest.NewRequest(method, test.path, nil)
+				for key, values := range test.header {
+					req.Header[key] = values
+				}
+				rec = httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+			}
+
+			if rec.Code != test.wantStatus {
+				t.Fatalf("unexpected status -- got %d, want %d (body: %s)",
+					rec.Code, test.wantStatus, rec.Body.String())
+			}
+			if test.wantStatus != http.StatusOK &&
+				test.wantStatus != http.StatusNotModified {
+
+				return
+			}
+			cacheControl := rec.Header().Get("Cache-Control")
+			wantCacheControl := "public, max-age=5"
+			if test.immutable {
+				wantCacheControl = "public, max-age=31536000, immutable"
+			}
+			if cacheControl != wantCacheControl {
+				t.Fatalf("unexpected cache control -- got %q, want %q",
+					cacheControl, wantCacheControl)
+			}
+			if test.wantStatus == http.StatusNotModified {
+				return
+			}
+			if gotType := rec.Header().Get("Content-Type"); gotType != test.wantType {
+				t.Fatalf("unexpected content type -- got %q, want %q",
+					gotType, test.wantType)
+			}
+			if test.wantBody != nil && !bytes.Equal(rec.Body.Bytes(), test.wantBody) {
+				t.Fatalf("unexpected body -- got %q, want %q",
+					rec.Body.Bytes(), test.wantBody)
+			}
+			if test.checkJSON != nil {
+				test.checkJSON(t, rec.Body.Bytes())
+			}
+		})
+	}
+}
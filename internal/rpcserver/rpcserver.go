@@ -25,6 +25,8 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
@@ -36,6 +38,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/jrick/bitset"
+	"github.com/monetarium/monetarium-node/addrmgr"
 	"github.com/monetarium/monetarium-node/blockchain/stake"
 	"github.com/monetarium/monetarium-node/blockchain/standalone"
 	"github.com/monetarium/monetarium-node/chaincfg"
@@ -44,16 +47,20 @@ import (
 	"github.com/monetarium/monetarium-node/crypto/blake256"
 	"github.com/monetarium/monetarium-node/crypto/rand"
 	"github.com/monetarium/monetarium-node/database"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
 	"github.com/monetarium/monetarium-node/dcrec/secp256k1/ecdsa"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1/schnorr/musig2"
 	"github.com/monetarium/monetarium-node/dcrjson"
 	"github.com/monetarium/monetarium-node/dcrutil"
 	"github.com/monetarium/monetarium-node/internal/blockchain"
 	"github.com/monetarium/monetarium-node/internal/mempool"
 	"github.com/monetarium/monetarium-node/internal/mining"
+	"github.com/monetarium/monetarium-node/internal/staging/banmanager"
 	"github.com/monetarium/monetarium-node/internal/version"
 	"github.com/monetarium/monetarium-node/mixing"
 	"github.com/monetarium/monetarium-node/rpc/jsonrpc/types"
 	"github.com/monetarium/monetarium-node/txscript"
+	"github.com/monetarium/monetarium-node/txscript/pst"
 	"github.com/monetarium/monetarium-node/txscript/stdaddr"
 	"github.com/monetarium/monetarium-node/txscript/stdscript"
 	"github.com/monetarium/monetarium-node/wire"
@@ -172,12 +179,17 @@ type commandHandler func(context.Context, *Server, interface{}) (interface{}, er
 var rpcHandlers map[types.Method]commandHandler
 var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"addnode":                  handleAddNode,
+	"analyzepst":               handleAnalyzePST,
+	"clearbanned":              handleClearBanned,
+	"combinepst":               handleCombinePST,
 	"createrawsstx":            handleCreateRawSStx,
 	"createrawssrtx":           handleCreateRawSSRtx,
 	"createrawtransaction":     handleCreateRawTransaction,
 	"debuglevel":               handleDebugLevel,
+	"decodepst":                handleDecodePST,
 	"decoderawtransaction":     handleDecodeRawTransaction,
 	"decodescript":             handleDecodeScript,
+	"dumptxoutset":             handleDumpTxOutSet,
 	"estimatefee":              handleEstimateFee,
 	"estimatesmartfee":         handleEstimateSmartFee,
 	"getfeestimatesbycointype": handleGetFeeEstimatesByCoinType,
@@ -187,6 +199,7 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"existsliveticket":         handleExistsLiveTicket,
 	"existslivetickets":        handleExistsLiveTickets,
 	"existsmempooltxs":         handleExistsMempoolTxs,
+	"finalizepst":              handleFinalizePST,
 	"generate":                 handleGenerate,
 	"getaddednodeinfo":         handleGetAddedNodeInfo,
 	"getbestblock":             handleGetBestBlock,
@@ -207,12 +220,15 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"gethashespersec":          handleGetHashesPerSec,
 	"getheaders":               handleGetHeaders,
 	"getinfo":                  handleGetInfo,
+	"getmempoolhistory":        handleGetMempoolHistory,
 	"getmempoolinfo":           handleGetMempoolInfo,
 	"getmempoolfeesinfo":       handleGetMempoolFeesInfo,
 	"getmininginfo":            handleGetMiningInfo,
 	"getmixmessage":            handleGetMixMessage,
 	"getmixpairrequests":       handleGetMixPairRequests,
 	"getnettotals":             handleGetNetTotals,
+	"getnetworkstats":          handleGetNetworkStats,
+	"getnodeaddresses":         handleGetNodeAddresses,
 	"getnetworkhashps":         handleGetNetworkHashPS,
 	"getnetworkinfo":           handleGetNetworkInfo,
 	"getpeerinfo":              handleGetPeerInfo,
@@ -221,6 +237,8 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"getskainfo":               handleGetSKAInfo,
 	"getemissionstatus":        handleGetEmissionStatus,
 	"getburnedcoins":           handleGetBurnedCoins,
+	"getburnproof":             handleGetBurnProof,
+	"getskasupplyproof":        handleGetSKASupplyProof,
 	"getstakedifficulty":       handleGetStakeDifficulty,
 	"getstakeversioninfo":      handleGetStakeVersionInfo,
 	"getstakeversions":         handleGetStakeVersions,
@@ -228,11 +246,13 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"gettreasurybalance":       handleGetTreasuryBalance,
 	"gettreasuryspendvotes":    handleGetTreasurySpendVotes,
 	"getvoteinfo":              handleGetVoteInfo,
+	"gettxinclusionproof":      handleGetTxInclusionProof,
 	"gettxout":                 handleGetTxOut,
 	"gettxoutsetinfo":          handleGetTxOutSetInfo,
 	"getwork":                  handleGetWork,
 	"help":                     handleHelp,
 	"invalidateblock":          handleInvalidateBlock,
+	"listbanned":               handleListBanned,
 	"livetickets":              handleLiveTickets,
 	"node":                     handleNode,
 	"ping":                     handlePing,
@@ -240,11 +260,13 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"regentemplate":            handleRegenTemplate,
 	"sendrawmixmessage":        handleSendRawMixMessage,
 	"sendrawtransaction":       handleSendRawTransaction,
+	"setban":                   handleSetBan,
 	"setgenerate":              handleSetGenerate,
 	"startprofiler":            handleStartProfiler,
 	"stop":                     handleStop,
 	"stopprofiler":             handleStopProfiler,
 	"submitblock":              handleSubmitBlock,
+	"submitpackage":            handleSubmitPackage,
 	"ticketfeeinfo":            handleTicketFeeInfo,
 	"ticketsforaddress":        handleTicketsForAddress,
 	"ticketvwap":               handleTicketVWAP,
@@ -252,6 +274,7 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"validateaddress":          handleValidateAddress,
 	"verifychain":              handleVerifyChain,
 	"verifymessage":            handleVerifyMessage,
+	"verifypartialsig":         handleVerifyPartialSig,
 	"version":                  handleVersion,
 }
 
@@ -346,6 +369,7 @@ var rpcUnimplemented = map[string]struct{}{
 var rpcLimited = map[string]struct{}{
 	// Websockets commands
 	"notifyblocks":          {},
+	"notifymempoolhistory":  {},
 	"notifymixmessages":     {},
 	"notifynewtransactions": {},
 	"rescan":                {},
@@ -356,21 +380,26 @@ var rpcLimited = map[string]struct{}{
 	"help": {},
 
 	// HTTP/S-only commands
+	"analyzepst":               {},
+	"combinepst":               {},
 	"createrawsstx":            {},
 	"createrawssrtx":           {},
 	"createrawtransaction":     {},
+	"decodepst":                {},
 	"decoderawtransaction":     {},
 	"decodescript":             {},
 	"estimatefee":              {},
 	"estimatesmartfee":         {},
 	"getfeestimatesbycointype": {},
 	"getmempoolfeesinfo":       {},
+	"getmempoolhistory":        {},
 	"estimatestakediff":        {},
 	"existsaddress":            {},
 	"existsaddresses":          {},
 	"existsliveticket":         {},
 	"existslivetickets":        {},
 	"existsmempooltxs":         {},
+	"finalizepst":              {},
 	"getbestblock":             {},
 	"getbestblockhash":         {},
 	"getblock":                 {},
@@ -379,6 +408,7 @@ var rpcLimited = map[string]struct{}{
 	"getblockhash":             {},
 	"getblockheader":           {},
 	"getblocksubsidy":          {},
+	"getburnproof":             {},
 	"getcfilterv2":             {},
 	"getchaintips":             {},
 	"getcoinsupply":            {},
@@ -389,6 +419,7 @@ var rpcLimited = map[string]struct{}{
 	"getmixmessage":            {},
 	"getmixpairrequests":       {},
 	"getnettotals":             {},
+	"getnetworkstats":          {},
 	"getnetworkhashps":         {},
 	"getnetworkinfo":           {},
 	"getrawmempool":            {},
@@ -396,7 +427,9 @@ var rpcLimited = map[string]struct{}{
 	"getstakeversioninfo":      {},
 	"getstakeversions":         {},
 	"getrawtransaction":        {},
+	"getskasupplyproof":        {},
 	"gettreasurybalance":       {},
+	"gettxinclusionproof":      {},
 	"gettxout":                 {},
 	"getvoteinfo":              {},
 	"livetickets":              {},
@@ -404,12 +437,14 @@ var rpcLimited = map[string]struct{}{
 	"sendrawmixmessage":        {},
 	"sendrawtransaction":       {},
 	"submitblock":              {},
+	"submitpackage":            {},
 	"ticketfeeinfo":            {},
 	"ticketsforaddress":        {},
 	"ticketvwap":               {},
 	"txfeeinfo":                {},
 	"validateaddress":          {},
 	"verifymessage":            {},
+	"verifypartialsig":         {},
 	"version":                  {},
 }
 
@@ -805,6 +840,18 @@ func handleCreateRawTransaction(_ context.Context, s *Server, cmd interface{}) (
 			return nil, rpcAddressKeyError("Could not decode address: %v", err)
 		}
 
+		// Refuse to pay addresses that commit to a coin type other than the
+		// one the created outputs pay and check the type of the underlying
+		// address for the remaining checks.
+		if coinTyped, ok := addr.(*stdaddr.AddressCoinTyped); ok {
+			if coinTyped.CoinType() != cointype.CoinTypeVAR {
+				return nil, rpcInvalidError("Address %s expects coin type "+
+					"%d, but the created outputs pay %s", encodedAddr,
+					coinTyped.CoinType(), cointype.CoinTypeVAR)
+			}
+			addr = coinTyped.Address()
+		}
+
 		// Ensure the address is one of the supported types.
 		if _, ok := addr.(stdaddr.StakeAddress); !ok {
 			return nil, rpcAddressKeyError("Invalid type: %T", addr)
@@ -1116,6 +1163,15 @@ func handleCreateRawSSRtx(_ context.Context, s *Server, cmd interface{}) (interf
 	return mtxHex, nil
 }
 
+// handleClearBanned implements the clearbanned command.
+func handleClearBanned(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
+	if err := s.cfg.ConnMgr.ClearBanned(); err != nil {
+		return nil, rpcInternalErr(err, "Could not clear banned subnets")
+	}
+
+	return nil, nil
+}
+
 // handleDebugLevel handles debuglevel commands.
 func handleDebugLevel(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.DebugLevelCmd)
@@ -1230,8 +1286,9 @@ func createVinList(mtx *wire.MsgTx, isTreasuryEnabled bool) []types.Vin {
 		vinEntry.BlockHeight = txIn.BlockHeight
 		vinEntry.BlockIndex = txIn.BlockIndex
 		vinEntry.ScriptSig = &types.ScriptSig{
-			Asm: disbuf,
-			Hex: hex.EncodeToString(txIn.SignatureScript),
+			Asm:      disbuf,
+			Hex:      hex.EncodeToString(txIn.SignatureScript),
+			Emission: createSKAEmissionAuthResult(txIn.SignatureScript),
 		}
 	}
 
@@ -1334,6 +1391,9 @@ func createVoutList(mtx *wire.MsgTx, chainParams *chaincfg.Params,
 		if commitAmt != nil {
 			voutSPK.CommitAmt = dcrjson.Float64(commitAmt.ToCoin())
 		}
+		if v.Version == 0 {
+			voutSPK.SSFee = createSSFeeMarkerResult(v.PkScript)
+		}
 		voutSPK.Version = v.Version
 
 		voutList = append(voutList, vout)
@@ -1513,9 +1573,438 @@ func handleDecodeScript(_ context.Context, s *Server, cmd interface{}) (interfac
 	if scriptType != stdscript.STScriptHash {
 		reply.P2sh = p2sh.String()
 	}
+	if scriptVersion == 0 {
+		reply.HTLC = createHTLCResult(script, s.cfg.ChainParams)
+		reply.SSFee = createSSFeeMarkerResult(script)
+		reply.Emission = createSKAEmissionAuthResult(script)
+	}
 	return reply, nil
 }
 
+// createHTLCResult returns the details of the passed version 0 script when it
+// is a standard hash-time-locked contract script.  It returns nil otherwise.
+func createHTLCResult(script []byte, params *chaincfg.Params) *types.DecodeScriptHTLCResult {
+	details := stdscript.ExtractHTLCDetailsV0(script)
+	if details == nil {
+		return nil
+	}
+
+	// The hashes are always the correct size, so the addresses can't fail.
+	recipient, _ := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(
+		details.RecipientHash160[:], params)
+	refund, _ := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(
+		details.RefundHash160[:], params)
+	lockType := "absolute"
+	if details.RelativeLockTime {
+		lockType = "relative"
+	}
+	return &types.DecodeScriptHTLCResult{
+		SecretHash: hex.EncodeToString(details.SecretHash[:]),
+		Recipient:  recipient.String(),
+		Refund:     refund.String(),
+		LockTime:   details.LockTime,
+		LockType:   lockType,
+	}
+}
+
+// createSSFeeMarkerResult returns the details of the passed version 0 script
+// when it is a standard staker or miner fee distribution marker script.  It
+// returns nil otherwise.
+func createSSFeeMarkerResult(script []byte) *types.SSFeeMarkerResult {
+	if details := stdscript.ExtractSSFeeStakerMarkerDetailsV0(script); details != nil {
+		voterSeq := details.VoterSeq
+		return &types.SSFeeMarkerResult{
+			Height:   details.Height,
+			VoterSeq: &voterSeq,
+		}
+	}
+	if details := stdscript.ExtractSSFeeMinerMarkerDetailsV0(script); details != nil {
+		return &types.SSFeeMarkerResult{Height: details.Height}
+	}
+	return nil
+}
+
+// createSKAEmissionAuthResult returns the details of the passed script when it
+// is a standard SKA emission authorization signature script.  It returns nil
+// otherwise.
+func createSKAEmissionAuthResult(script []byte) *types.SKAEmissionAuthResult {
+	details := stdscript.ExtractSKAEmissionAuthDetailsV0(script)
+	if details == nil {
+		return nil
+	}
+	return &types.SKAEmissionAuthResult{
+		CoinType: details.CoinType,
+		Nonce:    details.Nonce,
+		Height:   details.Height,
+		Amount:   details.Amount.String(),
+		PubKey:   hex.EncodeToString(details.PubKey),
+	}
+}
+
+// marshalTxOutSetSnapshot converts the provided snapshot info to the form used
+// by the dumptxoutset command.
+func marshalTxOutSetSnapshot(info *blockchain.UtxoSnapshotInfo, path string) types.TxOutSetSnapshotResult {
+	return types.TxOutSetSnapshotResult{
+		Height:    info.Height,
+		Hash:      info.BlockHash.String(),
+		Utxos:     info.NumUtxos,
+		UtxoHash:  info.UtxoHash.String(),
+		StateHash: info.StateHash.String(),
+		Path:      path,
+	}
+}
+
+// parsePST decodes the provided base64-encoded partially signed transaction.
+func parsePST(encoded string) (*pst.Packet, error) {
+	p, err := pst.ParseBase64(encoded)
+	if err != nil {
+		return nil, rpcDeserializationError("Could not decode PST: %v", err)
+	}
+	return p, nil
+}
+
+// encodePST returns the base64 encoding of the provided partially signed
+// transaction.
+func encodePST(p *pst.Packet) (string, error) {
+	encoded, err := p.B64Encode()
+	if err != nil {
+		return "", rpcInternalErr(err, "Could not encode PST")
+	}
+	return encoded, nil
+}
+
+// createPSTScriptSig returns the disassembled and hex-encoded forms of the
+// provided script or nil when there is no script.
+func createPSTScriptSig(script []byte) *types.ScriptSig {
+	if script == nil {
+		return nil
+	}
+
+	// The disassembled string will contain [error] inline if the script
+	// doesn't fully parse, so ignore the error here.
+	disbuf, _ := txscript.DisasmString(script)
+	return &types.ScriptSig{
+		Asm: disbuf,
+		Hex: hex.EncodeToString(script),
+	}
+}
+
+// createPSTPrevOut returns the previous output of a partially signed
+// transaction input in the form used by the RPC results.
+func createPSTPrevOut(prevOut *pst.PrevOutput, chainParams *chaincfg.Params) *types.PSTPrevOutResult {
+	// The disassembled string will contain [error] inline if the script
+	// doesn't fully parse, so ignore the error here.
+	disbuf, _ := txscript.DisasmString(prevOut.PkScript)
+	scriptType, addrs := stdscript.ExtractAddrs(prevOut.Version,
+		prevOut.PkScript, chainParams)
+	encodedAddrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		encodedAddrs[i] = addr.String()
+	}
+	reqSigs := stdscript.DetermineRequiredSigs(prevOut.Version,
+		prevOut.PkScript)
+
+	result := &types.PSTPrevOutResult{
+		CoinType: uint8(prevOut.CoinType),
+		ScriptPubKey: types.ScriptPubKeyResult{
+			Asm:       disbuf,
+			Hex:       hex.EncodeToString(prevOut.PkScript),
+			ReqSigs:   int32(reqSigs),
+			Type:      scriptType.String(),
+			Addresses: encodedAddrs,
+			Version:   prevOut.Version,
+		},
+	}
+	if prevOut.CoinType.IsSKA() {
+		if prevOut.SKAValue != nil {
+			result.SKAValue = prevOut.SKAValue.String()
+		}
+	} else {
+		result.Value = dcrutil.Amount(prevOut.Value).ToCoin()
+	}
+	return result
+}
+
+// pstHardenedKeyStart is the index of the first hardened child key in a
+// hierarchical deterministic derivation path.
+const pstHardenedKeyStart = 0x80000000 // 2^31
+
+// createPSTBip32Derivs returns the provided derivation paths in the form used
+// by the RPC results.  Hardened path components are denoted with an
+// apostrophe.
+func createPSTBip32Derivs(derivs []*pst.Bip32Derivation) []types.PSTBip32DerivResult {
+	if len(derivs) == 0 {
+		return nil
+	}
+
+	results := make([]types.PSTBip32DerivResult, 0, len(derivs))
+	for _, d := range derivs {
+		var path strings.Builder
+		path.WriteString("m")
+		for _, index := range d.Path {
+			if index >= pstHardenedKeyStart {
+				fmt.Fprintf(&path, "/%d'", index-pstHardenedKeyStart)
+				continue
+			}
+			fmt.Fprintf(&path, "/%d", index)
+		}
+		results = append(results, types.PSTBip32DerivResult{
+			PubKey:            hex.EncodeToString(d.PubKey),
+			MasterFingerprint: fmt.Sprintf("%08x", d.MasterKeyFingerprint),
+			Path:              path.String(),
+		})
+	}
+	return results
+}
+
+// createPSTFeeList returns the fees paid by the provided partially signed
+// transaction for each coin type sorted by coin type.  It returns nil when the
+// previous output of any input is not known.
+func createPSTFeeList(p *pst.Packet) []types.PSTFeeResult {
+	fees, err := p.Fees()
+	if err != nil {
+		return nil
+	}
+
+	coinTypes := make([]cointype.CoinType, 0, len(fees))
+	for coinType := range fees {
+		coinTypes = append(coinTypes, coinType)
+	}
+	sort.Slice(coinTypes, func(i, j int) bool {
+		return coinTypes[i] < coinTypes[j]
+	})
+	results := make([]types.PSTFeeResult, 0, len(coinTypes))
+	for _, coinType := range coinTypes {
+		result := types.PSTFeeResult{CoinType: uint8(coinType)}
+		if coinType.IsSKA() {
+			result.SKAFee = fees[coinType].String()
+		} else {
+			result.Fee = dcrutil.Amount(fees[coinType].Int64()).ToCoin()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// handleDecodePST handles decodepst commands.
+func handleDecodePST(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.DecodePSTCmd)
+
+	p, err := parsePST(c.PST)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine if the treasury rules are active as of the current best tip.
+	prevBlkHash := s.cfg.Chain.BestSnapshot().Hash
+	isTreasuryEnabled, err := s.isTreasuryAgendaActive(&prevBlkHash)
+	if err != nil {
+		return nil, err
+	}
+
+	mtx := p.UnsignedTx
+	inputs := make([]types.PSTInputResult, 0, len(p.Inputs))
+	for i := range p.Inputs {
+		in := &p.Inputs[i]
+		result := types.PSTInputResult{
+			RedeemScript:   createPSTScriptSig(in.RedeemScript),
+			SigHashType:    uint32(in.SigHashType),
+			Bip32Derivs:    createPSTBip32Derivs(in.Bip32Derivations),
+			FinalScriptSig: createPSTScriptSig(in.FinalScriptSig),
+		}
+		if in.PrevOutput != nil {
+			result.PrevOut = createPSTPrevOut(in.PrevOutput, s.cfg.ChainParams)
+		}
+		if len(in.PartialSigs) > 0 {
+			result.PartialSigs = make(map[string]string, len(in.PartialSigs))
+			for _, sig := range in.PartialSigs {
+				pubKey := hex.EncodeToString(sig.PubKey)
+				result.PartialSigs[pubKey] = hex.EncodeToString(sig.Signature)
+			}
+		}
+		inputs = append(inputs, result)
+	}
+	outputs := make([]types.PSTOutputResult, 0, len(p.Outputs))
+	for i := range p.Outputs {
+		out := &p.Outputs[i]
+		outputs = append(outputs, types.PSTOutputResult{
+			RedeemScript: createPSTScriptSig(out.RedeemScript),
+			Bip32Derivs:  createPSTBip32Derivs(out.Bip32Derivations),
+		})
+	}
+
+	return types.DecodePSTResult{
+		Tx: types.TxRawDecodeResult{
+			Txid:     mtx.TxHash().String(),
+			Version:  int32(mtx.Version),
+			Locktime: mtx.LockTime,
+			Expiry:   mtx.Expiry,
+			Vin:      createVinList(mtx, isTreasuryEnabled),
+			Vout:     createVoutList(mtx, s.cfg.ChainParams, nil),
+		},
+		Inputs:  inputs,
+		Outputs: outputs,
+		Fees:    createPSTFeeList(p),
+	}, nil
+}
+
+// handleCombinePST handles combinepst commands.
+func handleCombinePST(_ context.Context, _ *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.CombinePSTCmd)
+
+	if len(c.PSTs) == 0 {
+		return nil, rpcInvalidError("At least one PST must be provided")
+	}
+	packets := make([]*pst.Packet, 0, len(c.PSTs))
+	for _, encoded := range c.PSTs {
+		p, err := parsePST(encoded)
+		if err != nil {
+			return nil, err
+		}
+		packets = append(packets, p)
+	}
+
+	combined, err := pst.Combine(packets...)
+	if err != nil {
+		return nil, rpcInvalidError("Could not combine PSTs: %v", err)
+	}
+	return encodePST(combined)
+}
+
+// handleFinalizePST handles finalizepst commands.
+func handleFinalizePST(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.FinalizePSTCmd)
+
+	p, err := parsePST(c.PST)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finalize as many inputs as possible.  Inputs that can't be finalized
+	// yet, such as those that are still missing signatures, are left as is.
+	for i := range p.Inputs {
+		_ = p.Finalize(i)
+	}
+
+	complete := p.IsComplete()
+	if complete && (c.Extract == nil || *c.Extract) {
+		tx, err := p.Extract()
+		if err != nil {
+			return nil, rpcInternalErr(err, "Could not extract transaction")
+		}
+		txHex, err := s.messageToHex(tx)
+		if err != nil {
+			return nil, err
+		}
+		return types.FinalizePSTResult{Hex: txHex, Complete: true}, nil
+	}
+
+	encoded, err := encodePST(p)
+	if err != nil {
+		return nil, err
+	}
+	return types.FinalizePSTResult{PST: encoded, Complete: complete}, nil
+}
+
+// The following constants define the roles reported by analyzepst in the
+// order they act on a partially signed transaction.
+const (
+	pstRoleUpdater   = "updater"
+	pstRoleSigner    = "signer"
+	pstRoleFinalizer = "finalizer"
+	pstRoleExtractor = "extractor"
+)
+
+// pstRoleOrder maps each role reported by analyzepst to its position in the
+// partially signed transaction workflow.
+var pstRoleOrder = map[string]int{
+	pstRoleUpdater:   0,
+	pstRoleSigner:    1,
+	pstRoleFinalizer: 2,
+	pstRoleExtractor: 3,
+}
+
+// handleAnalyzePST handles analyzepst commands.
+func handleAnalyzePST(_ context.Context, _ *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.AnalyzePSTCmd)
+
+	p, err := parsePST(c.PST)
+	if err != nil {
+		return nil, err
+	}
+
+	// The next role for the packet as a whole is the earliest role any of
+	// its inputs still require.  Inputs that are able to be finalized only
+	// require a finalizer while those that can't require more signatures.
+	fees := createPSTFeeList(p)
+	next := pstRoleExtractor
+	inputs := make([]types.AnalyzePSTInputResult, 0, len(p.Inputs))
+	for i := range p.Inputs {
+		in := &p.Inputs[i]
+		result := types.AnalyzePSTInputResult{
+			HasPrevOut: in.PrevOutput != nil,
+			IsFinal:    in.IsFinalized(),
+		}
+		switch {
+		case result.IsFinal:
+			result.Next = pstRoleExtractor
+		case !result.HasPrevOut:
+			result.Next = pstRoleUpdater
+		case p.Finalize(i) == nil:
+			result.Next = pstRoleFinalizer
+		default:
+			result.Next = pstRoleSigner
+		}
+		if pstRoleOrder[result.Next] < pstRoleOrder[next] {
+			next = result.Next
+		}
+		inputs = append(inputs, result)
+	}
+
+	return types.AnalyzePSTResult{
+		Inputs: inputs,
+		Fees:   fees,
+		Next:   next,
+	}, nil
+}
+
+// handleDumpTxOutSet implements the dumptxoutset command.
+func handleDumpTxOutSet(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.DumpTxOutSetCmd)
+	path, err := filepath.Abs(c.Path)
+	if err != nil {
+		return nil, rpcInvalidError("Invalid path %q: %v", c.Path, err)
+	}
+
+	// Refuse to overwrite existing files.  The snapshot is written to a
+	// temporary file that is renamed into place once it is complete so a
+	// partial snapshot is never left at the requested path.
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		return nil, rpcInvalidError("File %q already exists", path)
+	}
+	tmpPath := path + ".incomplete"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, rpcInvalidError("Unable to create file: %v", err)
+	}
+	info, err := s.cfg.Chain.DumpUtxoSnapshot(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return nil, rpcInternalErr(err, "Could not dump UTXO set snapshot")
+	}
+
+	return marshalTxOutSetSnapshot(info, path), nil
+}
+
 // handleEstimateFee implements the estimatefee command.
 // TODO this is a very basic implementation.  It should be
 // modified to match the bitcoin-core one.
@@ -2140,6 +2629,13 @@ func handleGetBlock(_ context.Context, s *Server, cmd interface{}) (interface{},
 	chain := s.cfg.Chain
 	blk, err := chain.BlockByHash(hash)
 	if err != nil {
+		if errors.Is(err, blockchain.ErrBlockPruned) {
+			return nil, &dcrjson.RPCError{
+				Code: dcrjson.ErrRPCMisc,
+				Message: fmt.Sprintf("Block not available (pruned data): %v",
+					hash),
+			}
+		}
 		return nil, &dcrjson.RPCError{
 			Code:    dcrjson.ErrRPCBlockNotFound,
 			Message: fmt.Sprintf("Block not found: %v", hash),
@@ -2677,7 +3173,7 @@ func handleGetCFilterV2(_ context.Context, s *Server, cmd interface{}) (interfac
 	if len(proof.ProofHashes) > 0 {
 		proofHashes = make([]string, 0, len(proof.ProofHashes))
 		for i := range proof.ProofHashes {
-			proofHashes[i] = proof.ProofHashes[i].String()
+			proofHashes = append(proofHashes, proof.ProofHashes[i].String())
 		}
 	}
 
@@ -2711,6 +3207,48 @@ func handleGetInfo(_ context.Context, s *Server, _ interface{}) (interface{}, er
 	return ret, nil
 }
 
+// marshalMempoolHistoryEntry converts the provided mempool history entry to the
+// form used by the getmempoolhistory command and the mempoolhistory
+// notification.
+func marshalMempoolHistoryEntry(entry *mempool.TxHistoryEntry) types.GetMempoolHistoryResult {
+	result := types.GetMempoolHistoryResult{
+		TxHash:   entry.Hash.String(),
+		CoinType: uint8(entry.CoinType),
+		Reason:   string(entry.Reason),
+		Detail:   entry.Detail,
+		Time:     entry.Time.Unix(),
+	}
+	if entry.Conflict != nil {
+		result.Conflict = entry.Conflict.String()
+	}
+	return result
+}
+
+// handleGetMempoolHistory implements the getmempoolhistory command.
+func handleGetMempoolHistory(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetMempoolHistoryCmd)
+
+	var filterHash *chainhash.Hash
+	if c.TxHash != nil {
+		hash, err := chainhash.NewHashFromStr(*c.TxHash)
+		if err != nil {
+			return nil, rpcDecodeHexError(*c.TxHash)
+		}
+		filterHash = hash
+	}
+
+	entries := s.cfg.TxMempooler.TxHistory()
+	results := make([]types.GetMempoolHistoryResult, 0, len(entries))
+	for _, entry := range entries {
+		if filterHash != nil && entry.Hash != *filterHash {
+			continue
+		}
+		results = append(results, marshalMempoolHistoryEntry(entry))
+	}
+
+	return results, nil
+}
+
 // handleGetMempoolInfo implements the getmempoolinfo command.
 func handleGetMempoolInfo(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
 	mempoolTxns := s.cfg.TxMempooler.TxDescs()
@@ -3069,6 +3607,59 @@ func handleGetNetTotals(_ context.Context, s *Server, _ interface{}) (interface{
 	return reply, nil
 }
 
+// handleGetNetworkStats implements the getnetworkstats command.
+func handleGetNetworkStats(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
+	stats := s.cfg.ConnMgr.NetworkStats()
+	reply := &types.GetNetworkStatsResult{
+		TotalBytesRecv:    stats.TotalBytesRecv,
+		TotalBytesSent:    stats.TotalBytesSent,
+		TimeMillis:        s.cfg.Clock.Now().UTC().UnixNano() / int64(time.Millisecond),
+		BytesRecvPerMsg:   stats.BytesRecvPerMsg,
+		BytesSentPerMsg:   stats.BytesSentPerMsg,
+		MaxUploadRate:     stats.MaxUploadRate,
+		MaxPeerUploadRate: stats.MaxPeerUploadRate,
+		UploadTarget: types.UploadTargetResult{
+			Timeframe:             int64(stats.UploadTargetCycle / time.Second),
+			Target:                stats.UploadTarget,
+			TargetReached:         stats.UploadTargetReached,
+			ServeHistoricalBlocks: !stats.UploadTargetReached,
+			BytesLeftInCycle:      stats.BytesLeftInCycle,
+			TimeLeftInCycle:       int64(stats.TimeLeftInCycle / time.Second),
+		},
+	}
+	return reply, nil
+}
+
+// handleGetNodeAddresses implements the getnodeaddresses command.
+func handleGetNodeAddresses(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetNodeAddressesCmd)
+
+	count := *c.Count
+	if count < 0 {
+		return nil, rpcInvalidError("Address count must not be negative")
+	}
+
+	addrMgr := s.cfg.AddrManager
+	addrs := addrMgr.AddressCache(func(addrmgr.NetAddressType) bool {
+		return true
+	})
+	if count > 0 && int(count) < len(addrs) {
+		addrs = addrs[:count]
+	}
+	result := make([]types.GetNodeAddressesResult, 0, len(addrs))
+	for _, na := range addrs {
+		result = append(result, types.GetNodeAddressesResult{
+			Time:     na.Timestamp.Unix(),
+			Services: fmt.Sprintf("%08d", uint64(na.Services)),
+			Address:  net.IP(na.IP).String(),
+			Port:     na.Port,
+			MappedAS: addrMgr.ASN(na),
+		})
+	}
+
+	return result, nil
+}
+
 // handleGetNetworkHashPS implements the getnetworkhashps command.
 func handleGetNetworkHashPS(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	// Note: All valid error return paths should return an int64.  Literal
@@ -3187,30 +3778,40 @@ func handleGetPeerInfo(_ context.Context, s *Server, _ interface{}) (interface{}
 	infos := make([]*types.GetPeerInfoResult, 0, len(peers))
 	for _, p := range peers {
 		statsSnap := p.StatsSnapshot()
+		blockStats := p.BlockDownloadStats()
 		var addrLocalStr string
 		if addrLocal := p.LocalAddr(); addrLocal != nil {
 			addrLocalStr = addrLocal.String()
 		}
 		info := &types.GetPeerInfoResult{
-			ID:             statsSnap.ID,
-			Addr:           statsSnap.Addr,
-			AddrLocal:      addrLocalStr,
-			Services:       fmt.Sprintf("%08d", uint64(statsSnap.Services)),
-			RelayTxes:      !p.IsTxRelayDisabled(),
-			LastSend:       statsSnap.LastSend.Unix(),
-			LastRecv:       statsSnap.LastRecv.Unix(),
-			BytesSent:      statsSnap.BytesSent,
-			BytesRecv:      statsSnap.BytesRecv,
-			ConnTime:       statsSnap.ConnTime.Unix(),
-			PingTime:       float64(statsSnap.LastPingMicros),
-			TimeOffset:     statsSnap.TimeOffset,
-			Version:        statsSnap.Version,
-			SubVer:         statsSnap.UserAgent,
-			Inbound:        statsSnap.Inbound,
-			StartingHeight: statsSnap.StartingHeight,
-			CurrentHeight:  statsSnap.LastBlock,
-			BanScore:       int32(p.BanScore()),
-			SyncNode:       p.ID() == syncPeerID,
+			ID:              statsSnap.ID,
+			Addr:            statsSnap.Addr,
+			AddrLocal:       addrLocalStr,
+			Services:        fmt.Sprintf("%08d", uint64(statsSnap.Services)),
+			RelayTxes:       !p.IsTxRelayDisabled(),
+			LastSend:        statsSnap.LastSend.Unix(),
+			LastRecv:        statsSnap.LastRecv.Unix(),
+			BytesSent:       statsSnap.BytesSent,
+			BytesRecv:       statsSnap.BytesRecv,
+			BytesSentPerMsg: statsSnap.BytesSentPerMsg,
+			BytesRecvPerMsg: statsSnap.BytesRecvPerMsg,
+			ConnTime:        statsSnap.ConnTime.Unix(),
+			PingTime:        float64(statsSnap.LastPingMicros),
+			TimeOffset:      statsSnap.TimeOffset,
+			Version:         statsSnap.Version,
+			SubVer:          statsSnap.UserAgent,
+			Inbound:         statsSnap.Inbound,
+			Transport:       statsSnap.Transport.String(),
+			MappedAS:        p.MappedAS(),
+			StartingHeight:  statsSnap.StartingHeight,
+			CurrentHeight:   statsSnap.LastBlock,
+			BanScore:        int32(p.BanScore()),
+			SyncNode:        p.ID() == syncPeerID,
+			BlocksInFlight:  blockStats.InFlight,
+			BlocksRecv:      blockStats.Received,
+			BlockStalls:     blockStats.Stalls,
+			BlockWindow:     blockStats.Window,
+			BlockRespTime:   float64(blockStats.AvgResponseTime.Microseconds()),
 		}
 		if p.LastPingNonce() != 0 {
 			wait := float64(s.cfg.Clock.Since(statsSnap.LastPingTime).Nanoseconds())
@@ -3696,6 +4297,113 @@ func handleGetBurnedCoins(_ context.Context, s *Server, icmd interface{}) (inter
 	}, nil
 }
 
+// handleGetBurnProof implements the getburnproof JSON-RPC command.
+func handleGetBurnProof(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetBurnProofCmd)
+
+	proof, tx, err := txInclusionProof(s, c.TxHash, c.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Burns in blocks that are not in the main chain did not happen as far as
+	// the main chain is concerned, so refuse to prove them.
+	if proof.Confirmations < 0 {
+		return nil, rpcInvalidError("Block %v is not in the main chain",
+			c.BlockHash)
+	}
+
+	// Burns are only recognized in the regular transaction tree.
+	var burns []types.BurnOutput
+	if proof.Tree == wire.TxTreeRegular {
+		for i, txOut := range tx.TxOut {
+			if !txOut.CoinType.IsSKA() ||
+				!s.cfg.ChainParams.IsSKABurnScript(txOut.PkScript) {
+				continue
+			}
+			amount := txOut.SKAValue
+			if amount == nil {
+				amount = new(big.Int)
+			}
+			burns = append(burns, types.BurnOutput{
+				Vout:     uint32(i),
+				CoinType: uint8(txOut.CoinType),
+				Name:     txOut.CoinType.String(),
+				Amount: cointype.AtomsToDecimalString(amount,
+					cointype.AtomsPerSKACoin),
+				Atoms: amount.String(),
+			})
+		}
+	}
+	if len(burns) == 0 {
+		return nil, rpcInvalidError("Transaction %v does not burn any SKA "+
+			"coins", c.TxHash)
+	}
+
+	txHex, err := s.messageToHex(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.GetBurnProofResult{
+		Hex:   txHex,
+		Burns: burns,
+		Proof: *proof,
+	}, nil
+}
+
+// handleGetSKASupplyProof implements the getskasupplyproof JSON-RPC command.
+func handleGetSKASupplyProof(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetSKASupplyProofCmd)
+
+	coinType := cointype.CoinType(c.CoinType)
+	if !coinType.IsSKA() {
+		return nil, rpcInvalidError("coin type must be between 1 and 255 " +
+			"(SKA types)")
+	}
+
+	proof, err := s.cfg.Chain.SKASupplyProof(coinType)
+	if err != nil {
+		switch {
+		case errors.Is(err, blockchain.ErrNoSKASupplyCommitment):
+			return nil, rpcMiscError("The SKA supply commitment agenda is " +
+				"not active")
+
+		case errors.Is(err, blockchain.ErrNoSKASupply):
+			return nil, rpcInvalidError("No supply state for SKA coin type "+
+				"%d", c.CoinType)
+		}
+
+		return nil, rpcInternalErr(err, "Failed to obtain SKA supply proof")
+	}
+
+	hashesToStrings := func(hashes []chainhash.Hash) []string {
+		strs := make([]string, 0, len(hashes))
+		for i := range hashes {
+			strs = append(strs, hashes[i].String())
+		}
+		return strs
+	}
+
+	supply := &proof.Supply
+	return &types.GetSKASupplyProofResult{
+		BlockHash:       proof.BlockHash.String(),
+		Height:          proof.Height,
+		CoinType:        uint8(supply.CoinType),
+		Name:            supply.CoinType.String(),
+		Nonce:           supply.Nonce,
+		Emitted:         supply.Emitted.String(),
+		Burned:          supply.Burned.String(),
+		Circulating:     supply.Circulating().String(),
+		LeafHash:        supply.Hash().String(),
+		LeafIndex:       proof.LeafIndex,
+		LeafProof:       hashesToStrings(proof.LeafProof),
+		SupplyRoot:      proof.SupplyRoot.String(),
+		CommitmentIndex: proof.HeaderProof.ProofIndex,
+		CommitmentProof: hashesToStrings(proof.HeaderProof.ProofHashes),
+	}, nil
+}
+
 // convertVersionMap translates a map[int]int into a sorted array of
 // VersionCount that contains the same information.
 func convertVersionMap(m map[int]int) []types.VersionCount {
@@ -4232,6 +4940,138 @@ func bigToLEUint256(n *big.Int) [uint256Size]byte {
 	return buf
 }
 
+// txInclusionProof generates a merkle inclusion proof for the transaction with
+// the provided hash in the block with the provided hash and returns it along
+// with the transaction.
+//
+// The form of the proof is determined by comparing the tree roots with the
+// block header so that the proof always verifies against the header regardless
+// of whether or not the header commitments agenda defined in DCP0005 was
+// active for the block.
+//
+// Proofs are also generated for blocks that are not in the main chain since
+// they still verify against the header of the block.  The confirmations of the
+// result are -1 for such blocks.
+func txInclusionProof(s *Server, txHashStr, blockHashStr string) (*types.GetTxInclusionProofResult, *wire.MsgTx, error) {
+	txHash, err := chainhash.NewHashFromStr(txHashStr)
+	if err != nil {
+		return nil, nil, rpcDecodeHexError(txHashStr)
+	}
+	blockHash, err := chainhash.NewHashFromStr(blockHashStr)
+	if err != nil {
+		return nil, nil, rpcDecodeHexError(blockHashStr)
+	}
+
+	blk, err := s.cfg.Chain.BlockByHash(blockHash)
+	if err != nil {
+		if errors.Is(err, blockchain.ErrBlockPruned) {
+			return nil, nil, &dcrjson.RPCError{
+				Code: dcrjson.ErrRPCMisc,
+				Message: fmt.Sprintf("Block not available (pruned data): %v",
+					blockHash),
+			}
+		}
+		return nil, nil, &dcrjson.RPCError{
+			Code:    dcrjson.ErrRPCBlockNotFound,
+			Message: fmt.Sprintf("Block not found: %v", blockHash),
+		}
+	}
+	msgBlock := blk.MsgBlock()
+
+	// Locate the transaction in either of the transaction trees.
+	tree := wire.TxTreeRegular
+	txns := msgBlock.Transactions
+	index := -1
+	for i, tx := range txns {
+		if tx.TxHash() == *txHash {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		tree = wire.TxTreeStake
+		txns = msgBlock.STransactions
+		for i, tx := range txns {
+			if tx.TxHash() == *txHash {
+				index = i
+				break
+			}
+		}
+	}
+	if index == -1 {
+		return nil, nil, dcrjson.NewRPCError(dcrjson.ErrRPCNoTxInfo,
+			fmt.Sprintf("Transaction %v is not in block %v", txHash,
+				blockHash))
+	}
+
+	// Determine how the header commits to the transaction tree.
+	header := &msgBlock.Header
+	regularRoot := standalone.CalcTxTreeMerkleRoot(msgBlock.Transactions)
+	stakeRoot := standalone.CalcTxTreeMerkleRoot(msgBlock.STransactions)
+	treeRoot, siblingRoot := regularRoot, stakeRoot
+	if tree == wire.TxTreeStake {
+		treeRoot, siblingRoot = stakeRoot, regularRoot
+	}
+	combinedRoot := standalone.CalcCombinedTxTreeMerkleRoot(
+		msgBlock.Transactions, msgBlock.STransactions)
+	var siblingRootStr string
+	switch {
+	case header.MerkleRoot == combinedRoot:
+		siblingRootStr = siblingRoot.String()
+	case header.MerkleRoot == regularRoot && header.StakeRoot == stakeRoot:
+	default:
+		err := fmt.Errorf("merkle roots of block %v do not match its header",
+			blockHash)
+		return nil, nil, rpcInternalErr(err, "")
+	}
+
+	leaves := make([]chainhash.Hash, 0, len(txns))
+	for _, tx := range txns {
+		leaves = append(leaves, tx.TxHashFull())
+	}
+	proofHashes := standalone.GenerateInclusionProof(leaves, uint32(index))
+	proof := make([]string, 0, len(proofHashes))
+	for i := range proofHashes {
+		proof = append(proof, proofHashes[i].String())
+	}
+
+	var headerBuf bytes.Buffer
+	if err := header.Serialize(&headerBuf); err != nil {
+		return nil, nil, rpcInternalErr(err, "Failed to serialize block header")
+	}
+
+	chain := s.cfg.Chain
+	confirmations := int64(-1)
+	if chain.MainChainHasBlock(blockHash) {
+		confirmations = 1 + chain.BestSnapshot().Height - int64(header.Height)
+	}
+
+	return &types.GetTxInclusionProofResult{
+		BlockHash:       blockHash.String(),
+		Height:          int64(header.Height),
+		Confirmations:   confirmations,
+		Header:          hex.EncodeToString(headerBuf.Bytes()),
+		Tree:            tree,
+		Index:           uint32(index),
+		LeafHash:        leaves[index].String(),
+		TreeRoot:        treeRoot.String(),
+		Proof:           proof,
+		SiblingTreeRoot: siblingRootStr,
+	}, txns[index], nil
+}
+
+// handleGetTxInclusionProof implements the gettxinclusionproof JSON-RPC
+// command.
+func handleGetTxInclusionProof(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetTxInclusionProofCmd)
+
+	proof, _, err := txInclusionProof(s, c.TxHash, c.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	return *proof, nil
+}
+
 // handleGetTxOut handles gettxout commands.
 func handleGetTxOut(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.GetTxOutCmd)
@@ -4254,6 +5094,8 @@ func handleGetTxOut(_ context.Context, s *Server, cmd interface{}) (interface{},
 	var bestBlockHash string
 	var confirmations int64
 	var value int64
+	var skaValue *big.Int
+	var coinType cointype.CoinType
 	var scriptVersion uint16
 	var pkScript []byte
 	var isCoinbase bool
@@ -4302,6 +5144,8 @@ func handleGetTxOut(_ context.Context, s *Server, cmd interface{}) (interface{},
 		bestBlockHash = best.Hash.String()
 		confirmations = 0
 		value = txOut.Value
+		skaValue = txOut.SKAValue
+		coinType = txOut.CoinType
 		scriptVersion = txOut.Version
 		pkScript = txOut.PkScript
 		isCoinbase = standalone.IsCoinBaseTx(mtx, isTreasuryEnabled)
@@ -4325,6 +5169,8 @@ func handleGetTxOut(_ context.Context, s *Server, cmd interface{}) (interface{},
 		bestBlockHash = best.Hash.String()
 		confirmations = 1 + best.Height - entry.BlockHeight()
 		value = entry.Amount()
+		skaValue = entry.SKAAmount()
+		coinType = entry.CoinType()
 		scriptVersion = entry.ScriptVersion()
 		pkScript = entry.PkScript()
 		isCoinbase = entry.IsCoinBase()
@@ -4351,6 +5197,7 @@ func handleGetTxOut(_ context.Context, s *Server, cmd interface{}) (interface{},
 		BestBlock:     bestBlockHash,
 		Confirmations: confirmations,
 		Value:         dcrutil.Amount(value).ToUnit(dcrutil.AmountCoin),
+		CoinType:      uint8(coinType),
 		ScriptPubKey: types.ScriptPubKeyResult{
 			Asm:       disbuf,
 			Hex:       hex.EncodeToString(pkScript),
@@ -4361,6 +5208,9 @@ func handleGetTxOut(_ context.Context, s *Server, cmd interface{}) (interface{},
 		},
 		Coinbase: isCoinbase,
 	}
+	if coinType.IsSKA() && skaValue != nil {
+		txOutReply.SKAValue = skaValue.String()
+	}
 	return txOutReply, nil
 }
 
@@ -4844,6 +5694,25 @@ func handleInvalidateBlock(_ context.Context, s *Server, cmd interface{}) (inter
 	return nil, nil
 }
 
+// handleListBanned implements the listbanned command.
+func handleListBanned(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
+	now := s.cfg.Clock.Now()
+	bans := s.cfg.ConnMgr.BannedSubnets()
+	result := make([]types.ListBannedResult, 0, len(bans))
+	for _, ban := range bans {
+		result = append(result, types.ListBannedResult{
+			Subnet:        ban.Subnet.String(),
+			BanCreated:    ban.Created.Unix(),
+			BannedUntil:   ban.Expires.Unix(),
+			BanDuration:   int64(ban.Expires.Sub(ban.Created).Seconds()),
+			TimeRemaining: int64(ban.Expires.Sub(now).Seconds()),
+			Reason:        ban.Reason,
+		})
+	}
+
+	return result, nil
+}
+
 // handleLiveTickets implements the livetickets command.
 func handleLiveTickets(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
 	lt, err := s.cfg.Chain.LiveTickets()
@@ -5011,10 +5880,18 @@ func handleSendRawTransaction(_ context.Context, s *Server, cmd interface{}) (in
 			err)
 	}
 
+	// Attempt to relay the transaction privately via the stem phase of
+	// transaction relay first and fall back to processing and relaying it
+	// normally when that is not possible.
+	//
 	// Use 0 for the tag to represent local node.
 	tx := dcrutil.NewTx(msgtx)
-	acceptedTxs, err := s.cfg.SyncMgr.ProcessTransaction(tx, false,
-		allowHighFees, 0)
+	var acceptedTxs []*dcrutil.Tx
+	stemmed, err := s.cfg.ConnMgr.StemTransaction(tx, allowHighFees)
+	if err == nil && !stemmed {
+		acceptedTxs, err = s.cfg.SyncMgr.ProcessTransaction(tx, false,
+			allowHighFees, 0)
+	}
 	if err != nil {
 		// When the error is a rule error, it means the transaction was
 		// simply rejected as opposed to something actually going
@@ -5052,6 +5929,12 @@ func handleSendRawTransaction(_ context.Context, s *Server, cmd interface{}) (in
 		return nil, rpcDeserializationError("rejected: %v", err)
 	}
 
+	// Transactions in the stem phase are intentionally not announced or
+	// added to the rebroadcast logic until they are fluffed.
+	if stemmed {
+		return tx.Hash().String(), nil
+	}
+
 	// Generate and relay inventory vectors for all newly accepted
 	// transactions.
 	s.cfg.ConnMgr.RelayTransactions(acceptedTxs)
@@ -5073,6 +5956,117 @@ func handleSendRawTransaction(_ context.Context, s *Server, cmd interface{}) (in
 	return tx.Hash().String(), nil
 }
 
+// handleSubmitPackage implements the submitpackage command.
+func handleSubmitPackage(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.SubmitPackageCmd)
+
+	if len(c.RawTxs) < 2 || len(c.RawTxs) > mempool.MaxPackageCount {
+		return nil, rpcInvalidError("Package must contain between 2 and %d "+
+			"transactions (got %d)", mempool.MaxPackageCount, len(c.RawTxs))
+	}
+
+	// Deserialize the transactions in the package.
+	txns := make([]*dcrutil.Tx, 0, len(c.RawTxs))
+	for _, hexStr := range c.RawTxs {
+		if len(hexStr)%2 != 0 {
+			hexStr = "0" + hexStr
+		}
+		serializedTx, err := hex.DecodeString(hexStr)
+		if err != nil {
+			return nil, rpcDecodeHexError(hexStr)
+		}
+		msgTx := wire.NewMsgTx()
+		err = msgTx.Deserialize(bytes.NewReader(serializedTx))
+		if err != nil {
+			return nil, rpcDeserializationError("Could not decode Tx: %v",
+				err)
+		}
+		txns = append(txns, dcrutil.NewTx(msgTx))
+	}
+
+	acceptedTxs, err := s.cfg.SyncMgr.ProcessPackage(txns, *c.AllowHighFees)
+	if err != nil {
+		// When the error is a rule error, it means the package was simply
+		// rejected as opposed to something actually going wrong, so log it
+		// as such.  Otherwise, something really did go wrong, so log it as
+		// an actual error.
+		var rErr mempool.RuleError
+		if errors.As(err, &rErr) {
+			err = fmt.Errorf("rejected package: %w", err)
+			log.Debugf("%v", err)
+			if errors.Is(rErr, mempool.ErrDuplicate) {
+				return nil, rpcDuplicateTxError("%v", err)
+			}
+			return nil, rpcRuleError("%v", err)
+		}
+
+		err = fmt.Errorf("failed to process package: %w", err)
+		log.Errorf("%v", err)
+		return nil, rpcDeserializationError("rejected: %v", err)
+	}
+
+	// Generate and relay inventory vectors for all newly accepted
+	// transactions and notify websocket clients of them.
+	s.cfg.ConnMgr.RelayTransactions(acceptedTxs)
+	s.NotifyNewTransactions(acceptedTxs)
+
+	// Keep track of the accepted transactions so that they can be rebroadcast
+	// if they don't make their way into a block.  Packages only consist of
+	// regular transactions, but orphans accepted as a result might not be.
+	txHashes := make([]string, 0, len(acceptedTxs))
+	for _, tx := range acceptedTxs {
+		if stake.DetermineTxType(tx.MsgTx()) != stake.TxTypeSSGen {
+			iv := wire.NewInvVect(wire.InvTypeTx, tx.Hash())
+			s.cfg.ConnMgr.AddRebroadcastInventory(iv, tx)
+		}
+		txHashes = append(txHashes, tx.Hash().String())
+	}
+
+	return txHashes, nil
+}
+
+// handleSetBan implements the setban command.
+func handleSetBan(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.SetBanCmd)
+
+	subnet, err := banmanager.ParseSubnet(c.Subnet)
+	if err != nil {
+		return nil, rpcInvalidError("Invalid IP address or subnet %q: %v",
+			c.Subnet, err)
+	}
+
+	connMgr := s.cfg.ConnMgr
+	switch c.SubCmd {
+	case types.SBAdd:
+		var banTime int64
+		if c.BanTime != nil {
+			banTime = *c.BanTime
+		}
+		if banTime < 0 {
+			return nil, rpcInvalidError("Ban time must not be negative")
+		}
+		var reason string
+		if c.Reason != nil {
+			reason = *c.Reason
+		}
+		duration := time.Duration(banTime) * time.Second
+		if err := connMgr.SetBan(subnet, duration, reason); err != nil {
+			return nil, rpcInternalErr(err, "Could not ban subnet")
+		}
+
+	case types.SBRemove:
+		if err := connMgr.RemoveBan(subnet); err != nil {
+			return nil, rpcInvalidError("%v: %v", c.SubCmd, err)
+		}
+
+	default:
+		return nil, rpcInvalidError("Invalid subcommand for setban")
+	}
+
+	// no data returned unless an error.
+	return nil, nil
+}
+
 // handleSetGenerate implements the setgenerate command.
 func handleSetGenerate(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.SetGenerateCmd)
@@ -5677,6 +6671,10 @@ func handleValidateAddress(_ context.Context, s *Server, cmd interface{}) (inter
 
 	result.Address = addr.String()
 	result.IsValid = true
+	if coinTyper, ok := addr.(stdaddr.CoinTyper); ok {
+		coinType := uint8(coinTyper.CoinType())
+		result.CoinType = &coinType
+	}
 	return result, nil
 }
 
@@ -5789,6 +6787,99 @@ func handleVerifyMessage(_ context.Context, s *Server, cmd interface{}) (interfa
 	return address.String() == c.Address, nil
 }
 
+// handleVerifyPartialSig implements the verifypartialsig command.
+func handleVerifyPartialSig(_ context.Context, _ *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.VerifyPartialSigCmd)
+
+	if len(c.PubNonces) != len(c.PubKeys) {
+		return nil, rpcInvalidError("Number of public nonces (%d) does "+
+			"not match number of public keys (%d)", len(c.PubNonces),
+			len(c.PubKeys))
+	}
+
+	// Parse the participant public keys and their public nonces.
+	pubKeys := make([]*secp256k1.PublicKey, len(c.PubKeys))
+	pubNonces := make([]*musig2.PublicNonce, len(c.PubNonces))
+	for i := range c.PubKeys {
+		pubKeyBytes, err := hex.DecodeString(c.PubKeys[i])
+		if err != nil {
+			return nil, rpcDecodeHexError(c.PubKeys[i])
+		}
+		pubKeys[i], err = secp256k1.ParsePubKey(pubKeyBytes)
+		if err != nil {
+			return nil, rpcInvalidError("Invalid public key %d: %v", i, err)
+		}
+		pubNonceBytes, err := hex.DecodeString(c.PubNonces[i])
+		if err != nil {
+			return nil, rpcDecodeHexError(c.PubNonces[i])
+		}
+		pubNonces[i], err = musig2.ParsePublicNonce(pubNonceBytes)
+		if err != nil {
+			return nil, rpcInvalidError("Invalid public nonce %d: %v", i,
+				err)
+		}
+	}
+
+	// Locate the public nonce of the signer.
+	signerBytes, err := hex.DecodeString(c.SignerPubKey)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.SignerPubKey)
+	}
+	signerPubKey, err := secp256k1.ParsePubKey(signerBytes)
+	if err != nil {
+		return nil, rpcInvalidError("Invalid signer public key: %v", err)
+	}
+	var signerNonce *musig2.PublicNonce
+	for i, pubKey := range pubKeys {
+		if pubKey.IsEqual(signerPubKey) {
+			signerNonce = pubNonces[i]
+			break
+		}
+	}
+	if signerNonce == nil {
+		return nil, rpcInvalidError("Signer public key is not one of the " +
+			"provided public keys")
+	}
+
+	msg, err := hex.DecodeString(c.Message)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.Message)
+	}
+	partialSigBytes, err := hex.DecodeString(c.PartialSig)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.PartialSig)
+	}
+	partialSig, err := musig2.ParsePartialSignature(partialSigBytes)
+	if err != nil {
+		return nil, rpcInvalidError("Invalid partial signature: %v", err)
+	}
+
+	// Recreate the signing session shared by all participants.
+	aggKey, err := musig2.AggregateKeys(pubKeys)
+	if err != nil {
+		return nil, rpcInvalidError("Unable to aggregate public keys: %v",
+			err)
+	}
+	aggNonce, err := musig2.AggregateNonces(pubNonces)
+	if err != nil {
+		return nil, rpcInvalidError("Unable to aggregate public nonces: %v",
+			err)
+	}
+	session, err := musig2.NewSession(aggKey, aggNonce, msg)
+	if err != nil {
+		return nil, rpcInvalidError("Unable to create signing session: %v",
+			err)
+	}
+
+	// Treat verification failure as an invalid signature.
+	err = session.VerifyPartial(partialSig, signerNonce, signerPubKey)
+	return types.VerifyPartialSigResult{
+		AggregateKey:   hex.EncodeToString(aggKey.PubKey().SerializeCompressed()),
+		AggregateNonce: hex.EncodeToString(aggNonce[:]),
+		Valid:          err == nil,
+	}, nil
+}
+
 // handleVersion implements the version command.
 func handleVersion(_ context.Context, _ *Server, _ interface{}) (interface{}, error) {
 	runtimeVer := strings.ReplaceAll(runtime.Version(), ".", "-")
@@ -5957,6 +7048,13 @@ func (s *Server) NotifyTSpend(tx *dcrutil.Tx) {
 	s.ntfnMgr.NotifyTSpend(tx)
 }
 
+// NotifyMempoolHistory notifies websocket clients that have registered to
+// receive notifications about transactions that are rejected from or evicted
+// out of the mempool.
+func (s *Server) NotifyMempoolHistory(entry *mempool.TxHistoryEntry) {
+	s.ntfnMgr.NotifyMempoolHistory(entry)
+}
+
 // NotifyMixMessages notifies websocket clients that have registered to
 // receive mixing message notifications of newly accepted mix messages.
 func (s *Server) NotifyMixMessages(msgs []mixing.Message) {
@@ -6627,6 +7725,18 @@ func (s *Server) Run(ctx context.Context) {
 			wg.Done()
 		}(listener)
 	}
+	if len(s.cfg.RESTListeners) > 0 {
+		restServer := s.restRoute(ctx)
+		for _, listener := range s.cfg.RESTListeners {
+			wg.Add(1)
+			go func(listener net.Listener) {
+				log.Infof("REST server listening on %s", listener.Addr())
+				restServer.Serve(listener)
+				log.Tracef("REST listener done for %s", listener.Addr())
+				wg.Done()
+			}(listener)
+		}
+	}
 
 	// Subscribe for async work notifications when background template
 	// generation is enabled.
@@ -6655,7 +7765,11 @@ func (s *Server) Run(ctx context.Context) {
 	// Close all listeners and wait for all goroutines to terminate.
 	log.Warnf("RPC server shutting down")
 	var hasCloseErr bool
-	for _, listener := range s.cfg.Listeners {
+	listeners := make([]net.Listener, 0, len(s.cfg.Listeners)+
+		len(s.cfg.RESTListeners))
+	listeners = append(listeners, s.cfg.Listeners...)
+	listeners = append(listeners, s.cfg.RESTListeners...)
+	for _, listener := range listeners {
 		err := listener.Close()
 		if err != nil {
 			log.Errorf("Failed to close listener %s: %v", listener.Addr(), err)
@@ -6766,6 +7880,15 @@ type Config struct {
 	// RPCMaxWebsockets defines the max number of RPC websocket connections.
 	RPCMaxWebsockets int
 
+	// RESTListeners defines a slice of listeners on which the RPC server will
+	// serve the unauthenticated read-only REST interface.  The RPC server
+	// takes ownership of these listeners in the same way as Listeners.
+	RESTListeners []net.Listener
+
+	// RESTMaxRequestsPerSec defines the max number of REST requests per second
+	// that are served for each client host.
+	RESTMaxRequestsPerSec int
+
 	// TestNet represents whether or not the server is using testnet.
 	TestNet bool
 
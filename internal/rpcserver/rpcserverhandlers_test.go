@@ -11,6 +11,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"net"
@@ -32,6 +33,9 @@ import (
 	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
 	"github.com/monetarium/monetarium-node/cointype"
 	"github.com/monetarium/monetarium-node/database"
+	"github.com/monetarium/monetarium-node/dcrec"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1/schnorr/musig2"
 	"github.com/monetarium/monetarium-node/dcrjson"
 	"github.com/monetarium/monetarium-node/dcrutil"
 	"github.com/monetarium/monetarium-node/gcs"
@@ -40,12 +44,15 @@ import (
 	"github.com/monetarium/monetarium-node/internal/blockchain/indexers"
 	"github.com/monetarium/monetarium-node/internal/mempool"
 	"github.com/monetarium/monetarium-node/internal/mining"
+	"github.com/monetarium/monetarium-node/internal/netsync"
+	"github.com/monetarium/monetarium-node/internal/staging/banmanager"
 	"github.com/monetarium/monetarium-node/internal/version"
 	"github.com/monetarium/monetarium-node/math/uint256"
 	"github.com/monetarium/monetarium-node/mixing"
 	"github.com/monetarium/monetarium-node/peer"
 	"github.com/monetarium/monetarium-node/rpc/jsonrpc/types"
 	"github.com/monetarium/monetarium-node/txscript"
+	"github.com/monetarium/monetarium-node/txscript/pst"
 	"github.com/monetarium/monetarium-node/txscript/stdaddr"
 	"github.com/monetarium/monetarium-node/txscript/stdscript"
 	"github.com/monetarium/monetarium-node/wire"
@@ -63,6 +70,8 @@ const testDataPath = "testdata"
 // testRPCUtxoEntry provides a mock utxo entry by implementing the UtxoEntry interface.
 type testRPCUtxoEntry struct {
 	amount               int64
+	skaAmount            *big.Int
+	coinType             cointype.CoinType
 	hasExpiry            bool
 	height               uint32
 	index                uint32
@@ -101,6 +110,16 @@ func (u *testRPCUtxoEntry) Amount() int64 {
 	return u.amount
 }
 
+// SKAAmount returns a mocked SKA amount.
+func (u *testRPCUtxoEntry) SKAAmount() *big.Int {
+	return u.skaAmount
+}
+
+// CoinType returns a mocked coin type.
+func (u *testRPCUtxoEntry) CoinType() cointype.CoinType {
+	return u.coinType
+}
+
 // ScriptVersion returns a mocked public key script version of the output.
 func (u *testRPCUtxoEntry) ScriptVersion() uint16 {
 	return u.scriptVersion
@@ -157,6 +176,7 @@ type testRPCChain struct {
 	fetchUtxoEntry                UtxoEntry
 	fetchUtxoEntryErr             error
 	fetchUtxoStats                *blockchain.UtxoStats
+	dumpUtxoSnapshotFn            func(w io.Writer) (*blockchain.UtxoSnapshotInfo, error)
 	getStakeVersions              []blockchain.StakeVersions
 	getStakeVersionsErr           error
 	getVoteCounts                 blockchain.VoteCounts
@@ -206,6 +226,8 @@ type testRPCChain struct {
 	skaEmissionNonce              uint64
 	skaEmissionOccurred           bool
 	skaBurnedAmounts              map[cointype.CoinType]*big.Int
+	skaSupplyProof                *blockchain.SKASupplyProof
+	skaSupplyProofErr             error
 }
 
 // BestSnapshot returns a mocked blockchain.BestState.
@@ -288,6 +310,11 @@ func (c *testRPCChain) FetchUtxoStats() (*blockchain.UtxoStats, error) {
 	return c.fetchUtxoStats, nil
 }
 
+// DumpUtxoSnapshot returns the result of invoking the mocked dump function.
+func (c *testRPCChain) DumpUtxoSnapshot(w io.Writer) (*blockchain.UtxoSnapshotInfo, error) {
+	return c.dumpUtxoSnapshotFn(w)
+}
+
 // GetStakeVersions returns a mocked cooked array of StakeVersions.
 func (c *testRPCChain) GetStakeVersions(hash *chainhash.Hash, count int32) ([]blockchain.StakeVersions, error) {
 	return c.getStakeVersions, c.getStakeVersionsErr
@@ -491,6 +518,11 @@ func (c *testRPCChain) GetAllSKABurnedAmounts() map[cointype.CoinType]*big.Int {
 	return result
 }
 
+// SKASupplyProof returns a mocked SKA supply state and proofs.
+func (c *testRPCChain) SKASupplyProof(cointype.CoinType) (*blockchain.SKASupplyProof, error) {
+	return c.skaSupplyProof, c.skaSupplyProofErr
+}
+
 // testPeer provides a mock peer by implementing the Peer interface.
 type testPeer struct {
 	addr              string
@@ -502,6 +534,8 @@ type testPeer struct {
 	isTxRelayDisabled bool
 	banScore          uint32
 	statsSnapshot     *peer.StatsSnap
+	blockStats        netsync.BlockDownloadStats
+	mappedAS          uint32
 }
 
 // Addr returns a mocked peer address.
@@ -553,6 +587,18 @@ func (p *testPeer) BanScore() uint32 {
 	return p.banScore
 }
 
+// BlockDownloadStats returns mocked statistics about the blocks requested from
+// the peer.
+func (p *testPeer) BlockDownloadStats() netsync.BlockDownloadStats {
+	return p.blockStats
+}
+
+// MappedAS returns a mocked autonomous system number the address of the peer
+// is mapped to.
+func (p *testPeer) MappedAS() uint32 {
+	return p.mappedAS
+}
+
 // testProfManager provides a mock profiler manager by implementing the
 // ProfilerManager interface.
 type testProfManager struct {
@@ -595,6 +641,8 @@ func (m *testProfManager) Listeners() []string {
 // AddrManager interface.
 type testAddrManager struct {
 	localAddresses []addrmgr.LocalAddr
+	addressCache   []*addrmgr.NetAddress
+	asns           map[string]uint32
 }
 
 // LocalAddresses returns a mocked summary of local addresses information
@@ -603,6 +651,17 @@ func (c *testAddrManager) LocalAddresses() []addrmgr.LocalAddr {
 	return c.localAddresses
 }
 
+// AddressCache returns a mocked subset of the known good addresses.
+func (c *testAddrManager) AddressCache(filter addrmgr.NetAddressTypeFilter) []*addrmgr.NetAddress {
+	return c.addressCache
+}
+
+// ASN returns a mocked autonomous system number the provided address is
+// mapped to.
+func (c *testAddrManager) ASN(na *addrmgr.NetAddress) uint32 {
+	return c.asns[na.Key()]
+}
+
 // testSyncManager provides a mock sync manager by implementing the
 // SyncManager interface.
 type testSyncManager struct {
@@ -613,6 +672,8 @@ type testSyncManager struct {
 	syncHeight            int64
 	processTransaction    []*dcrutil.Tx
 	processTransactionErr error
+	processPackage        []*dcrutil.Tx
+	processPackageErr     error
 	recentlyConfirmedTxn  bool
 }
 
@@ -649,6 +710,13 @@ func (s *testSyncManager) ProcessTransaction(tx *dcrutil.Tx, allowOrphans bool,
 	return s.processTransaction, s.processTransactionErr
 }
 
+// ProcessPackage provides a mock implementation for relaying the provided
+// package of transactions for validation and insertion into the memory pool.
+func (s *testSyncManager) ProcessPackage(txns []*dcrutil.Tx,
+	allowHighFees bool) ([]*dcrutil.Tx, error) {
+	return s.processPackage, s.processPackageErr
+}
+
 // RecentlyConfirmedTxn provides a mock implementation for checking if a
 // transaction has been confirmed by a recent block.
 func (s *testSyncManager) RecentlyConfirmedTxn(hash *chainhash.Hash) bool {
@@ -882,9 +950,16 @@ type testConnManager struct {
 	connectedCount      int32
 	netTotalReceived    uint64
 	netTotalSent        uint64
+	networkStats        *NetworkStats
 	connectedPeers      []Peer
 	persistentPeers     []Peer
 	lookup              func(host string) ([]net.IP, error)
+	setBanErr           error
+	removeBanErr        error
+	clearBannedErr      error
+	bannedSubnets       []banmanager.BanEntry
+	stemTransaction     bool
+	stemTransactionErr  error
 }
 
 // Connect provides a mock implementation for adding the provided address as a
@@ -928,6 +1003,11 @@ func (c *testConnManager) NetTotals() (uint64, uint64) {
 	return c.netTotalReceived, c.netTotalSent
 }
 
+// NetworkStats returns mocked detailed traffic accounting across all peers.
+func (c *testConnManager) NetworkStats() *NetworkStats {
+	return c.networkStats
+}
+
 // ConnectedPeers returns a mocked slice of all connected peers.
 func (c *testConnManager) ConnectedPeers() []Peer {
 	return c.connectedPeers
@@ -951,6 +1031,12 @@ func (c *testConnManager) AddRebroadcastInventory(iv *wire.InvVect, data interfa
 // inventory vectors for all of the passed transactions to all connected peers.
 func (c *testConnManager) RelayTransactions(txns []*dcrutil.Tx) {}
 
+// StemTransaction provides a mock implementation for relaying the passed
+// transaction via the stem phase of private transaction relay.
+func (c *testConnManager) StemTransaction(tx *dcrutil.Tx, allowHighFees bool) (bool, error) {
+	return c.stemTransaction, c.stemTransactionErr
+}
+
 // RelayMixMessages generates and relays inventory vectors for all of
 // the passed mixing messages to all connected peers.
 func (c *testConnManager) RelayMixMessages(msgs []mixing.Message) {}
@@ -960,6 +1046,28 @@ func (c *testConnManager) Lookup(host string) ([]net.IP, error) {
 	return c.lookup(host)
 }
 
+// SetBan provides a mock implementation for banning the provided subnet.
+func (c *testConnManager) SetBan(subnet netip.Prefix, duration time.Duration, reason string) error {
+	return c.setBanErr
+}
+
+// RemoveBan provides a mock implementation for removing the ban of the
+// provided subnet.
+func (c *testConnManager) RemoveBan(subnet netip.Prefix) error {
+	return c.removeBanErr
+}
+
+// BannedSubnets provides a mock implementation for returning all currently
+// banned subnets.
+func (c *testConnManager) BannedSubnets() []banmanager.BanEntry {
+	return c.bannedSubnets
+}
+
+// ClearBanned provides a mock implementation for removing all bans.
+func (c *testConnManager) ClearBanned() error {
+	return c.clearBannedErr
+}
+
 // testCPUMiner provides a mock CPU miner by implementing the CPUMiner
 // interface.
 type testCPUMiner struct {
@@ -1173,6 +1281,7 @@ type testTxMempooler struct {
 	fetchTransaction    *dcrutil.Tx
 	fetchTransactionErr error
 	tspendHashes        []chainhash.Hash
+	txHistory           []*mempool.TxHistoryEntry
 }
 
 // HaveTransactions returns a mocked bool slice representing whether or not the
@@ -1210,6 +1319,12 @@ func (mp *testTxMempooler) TSpendHashes() []chainhash.Hash {
 	return mp.tspendHashes
 }
 
+// TxHistory returns the mocked list of recently rejected and evicted
+// transactions.
+func (mp *testTxMempooler) TxHistory() []*mempool.TxHistoryEntry {
+	return mp.txHistory
+}
+
 // testNtfnManager provides a mock notification manager by implementing the
 // NtfnManager interface.
 type testNtfnManager struct {
@@ -1231,6 +1346,10 @@ func (mgr *testNtfnManager) NotifyWork(templateNtfn *mining.TemplateNtfn) {}
 // NotifyTSpend passes new tspends to the manager for processing.
 func (mgr *testNtfnManager) NotifyTSpend(tx *dcrutil.Tx) {}
 
+// NotifyMempoolHistory passes a transaction that was rejected from or evicted
+// out of the mempool to the manager for processing.
+func (mgr *testNtfnManager) NotifyMempoolHistory(entry *mempool.TxHistoryEntry) {}
+
 // NotifyReorganization passes a blockchain reorganization notification to
 // the manager for processing.
 func (mgr *testNtfnManager) NotifyReorganization(rd *blockchain.ReorganizationNtfnsData) {}
@@ -1280,6 +1399,15 @@ func (mgr *testNtfnManager) RegisterTSpendUpdates(wsc *wsClient) {}
 // websocket client.
 func (mgr *testNtfnManager) UnregisterTSpendUpdates(wsc *wsClient) {}
 
+// RegisterMempoolHistoryUpdates requests notifications to the passed websocket
+// client when transactions are rejected from or evicted out of the mempool.
+func (mgr *testNtfnManager) RegisterMempoolHistoryUpdates(wsc *wsClient) {}
+
+// UnregisterMempoolHistoryUpdates removes notifications to the passed
+// websocket client when transactions are rejected from or evicted out of the
+// mempool.
+func (mgr *testNtfnManager) UnregisterMempoolHistoryUpdates(wsc *wsClient) {}
+
 // RegisterWinningTickets requests winning tickets update notifications
 // to the passed websocket client.
 func (mgr *testNtfnManager) RegisterWinningTickets(wsc *wsClient) {}
@@ -2055,6 +2183,28 @@ func TestHandleAddNode(t *testing.T) {
 	}})
 }
 
+func TestHandleClearBanned(t *testing.T) {
+	t.Parallel()
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleClearBanned: ok",
+		handler: handleClearBanned,
+		cmd:     &types.ClearBannedCmd{},
+		result:  nil,
+	}, {
+		name:    "handleClearBanned: unable to save ban list",
+		handler: handleClearBanned,
+		cmd:     &types.ClearBannedCmd{},
+		mockConnManager: func() *testConnManager {
+			connManager := defaultMockConnManager()
+			connManager.clearBannedErr = errors.New("unable to save")
+			return connManager
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
 func TestHandleCreateRawSStx(t *testing.T) {
 	t.Parallel()
 
@@ -2764,6 +2914,47 @@ func TestHandleCreateRawTransaction(t *testing.T) {
 		},
 		wantErr: true,
 		errCode: dcrjson.ErrRPCInvalidAddressOrKey,
+	}, {
+		name:    "handleCreateRawTransaction: ok with coin-typed address",
+		handler: handleCreateRawTransaction,
+		cmd: &types.CreateRawTransactionCmd{
+			Inputs: defaultCmdInputs,
+			Amounts: map[string]float64{
+				"mon1qqqltxpn7yz04g78l5x8ms0rjel7w75uz53spcgrms": 1,
+			},
+			LockTime: defaultCmdLockTime,
+			Expiry:   defaultCmdExpiry,
+		},
+		result: "01000000010d33d3840e9074183dc9a8d82a5031075a98135bfe182840ddaf575" +
+			"aa2032fe00000000000feffffff010000e1f50500000000000017a914f59833f104" +
+			"faa3c7fd0c7dc1e3967fe77a9c15238701000000010000000100e1f5050000000" +
+			"00000000000ffffffff00",
+	}, {
+		name:    "handleCreateRawTransaction: coin-typed address wrong coin type",
+		handler: handleCreateRawTransaction,
+		cmd: &types.CreateRawTransactionCmd{
+			Inputs: defaultCmdInputs,
+			Amounts: map[string]float64{
+				"mon1qyqltxpn7yz04g78l5x8ms0rjel7w75uz53smu6em3": 1,
+			},
+			LockTime: defaultCmdLockTime,
+			Expiry:   defaultCmdExpiry,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleCreateRawTransaction: coin-typed address wrong network",
+		handler: handleCreateRawTransaction,
+		cmd: &types.CreateRawTransactionCmd{
+			Inputs: defaultCmdInputs,
+			Amounts: map[string]float64{
+				"tmon1qqqf5v978y0kd20rhzyrykafkqjt83uy2vqq5ja6fy": 1,
+			},
+			LockTime: defaultCmdLockTime,
+			Expiry:   defaultCmdExpiry,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidAddressOrKey,
 	}, {
 		name:    "handleCreateRawTransaction: address wrong type",
 		handler: handleCreateRawTransaction,
@@ -2978,6 +3169,73 @@ func TestHandleDecodeScript(t *testing.T) {
 		Type:      "scripthash",
 		Addresses: []string{"Mc4pTMWGfioFDvK6Hh2FZDPPYHCCaXby3JV"},
 	}
+	// This is a hash-time-locked contract script with a relative lock time.
+	htlc := "6382012088c0209f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15" +
+		"d6c15b0f00a088876a914000000000000000000000000000000000000000167029" +
+		"000b27576a91400000000000000000000000000000000000000026888ac"
+	htlcRes := types.DecodeScriptResult{
+		Asm: "OP_IF OP_SIZE 20 OP_EQUALVERIFY OP_SHA256 9f86d081884c7d659a2" +
+			"feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08 OP_EQUALVERIFY " +
+			"OP_DUP OP_HASH160 0000000000000000000000000000000000000001 " +
+			"OP_ELSE 9000 OP_CHECKSEQUENCEVERIFY OP_DROP OP_DUP OP_HASH160 " +
+			"0000000000000000000000000000000000000002 OP_ENDIF " +
+			"OP_EQUALVERIFY OP_CHECKSIG",
+		ReqSigs: 1,
+		Type:    "htlc",
+		Addresses: []string{"MsMfNmdbcherWznPacxufe9jSCMzRi13Haw",
+			"MsMfNmdbcherWznPacxufe9jSCMzRnAWPYt"},
+		P2sh: "McHHW8KF7n5GnKwQ1edHXodM5EMhTfrnY58",
+		HTLC: &types.DecodeScriptHTLCResult{
+			SecretHash: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd1" +
+				"5d6c15b0f00a08",
+			Recipient: "MsMfNmdbcherWznPacxufe9jSCMzRi13Haw",
+			Refund:    "MsMfNmdbcherWznPacxufe9jSCMzRnAWPYt",
+			LockTime:  144,
+			LockType:  "relative",
+		},
+	}
+	// This is a staker fee distribution marker for height 1000 and voter
+	// sequence 3.
+	ssfeeStaker := "6a085346e80300000300"
+	ssfeeStakerVoterSeq := uint16(3)
+	ssfeeStakerRes := types.DecodeScriptResult{
+		Asm:       "OP_RETURN 5346e80300000300",
+		Type:      "ssfee-staker",
+		Addresses: []string{},
+		P2sh:      "McS88fKvKbD8uAmUfYzUPV5fb2FoXdNMTLp",
+		SSFee: &types.SSFeeMarkerResult{
+			Height:   1000,
+			VoterSeq: &ssfeeStakerVoterSeq,
+		},
+	}
+	// This is a miner fee distribution marker for height 1000.
+	ssfeeMiner := "6a064d46e8030000"
+	ssfeeMinerRes := types.DecodeScriptResult{
+		Asm:       "OP_RETURN 4d46e8030000",
+		Type:      "ssfee-miner",
+		Addresses: []string{},
+		P2sh:      "McHe3SowxQUvos69FoGcFpijmgbP1P6szAD",
+		SSFee:     &types.SSFeeMarkerResult{Height: 1000},
+	}
+	// This is an SKA emission authorization for 1e18 atoms of SKA-1 at height
+	// 100 with nonce 1.
+	emissionAuth := "01534b4103010000000000000001080de0b6b3a764000064000000" +
+		"0000000002f9308a019258c31049344f85f89d5229b531c845836f99b08601f113b" +
+		"ce036f9083006020101020101"
+	emissionAuthRes := types.DecodeScriptResult{
+		Asm:       "53 [error]",
+		Type:      "skaemissionauth",
+		Addresses: []string{},
+		P2sh:      "McEY7eVhdd3oToAth6C8uM11ycA1ckinrpP",
+		Emission: &types.SKAEmissionAuthResult{
+			CoinType: 1,
+			Nonce:    1,
+			Height:   100,
+			Amount:   "1000000000000000000",
+			PubKey: "02f9308a019258c31049344f85f89d5229b531c845836f99b08601f" +
+				"113bce036f9",
+		},
+	}
 	testRPCServerHandler(t, []rpcTest{{
 		name:    "handleDecodeScript: ok no version",
 		handler: handleDecodeScript,
@@ -3021,6 +3279,34 @@ func TestHandleDecodeScript(t *testing.T) {
 			HexScript: p2sh,
 		},
 		result: p2shRes,
+	}, {
+		name:    "handleDecodeScript: ok htlc",
+		handler: handleDecodeScript,
+		cmd: &types.DecodeScriptCmd{
+			HexScript: htlc,
+		},
+		result: htlcRes,
+	}, {
+		name:    "handleDecodeScript: ok ssfee staker marker",
+		handler: handleDecodeScript,
+		cmd: &types.DecodeScriptCmd{
+			HexScript: ssfeeStaker,
+		},
+		result: ssfeeStakerRes,
+	}, {
+		name:    "handleDecodeScript: ok ssfee miner marker",
+		handler: handleDecodeScript,
+		cmd: &types.DecodeScriptCmd{
+			HexScript: ssfeeMiner,
+		},
+		result: ssfeeMinerRes,
+	}, {
+		name:    "handleDecodeScript: ok ska emission auth",
+		handler: handleDecodeScript,
+		cmd: &types.DecodeScriptCmd{
+			HexScript: emissionAuth,
+		},
+		result: emissionAuthRes,
 	}, {
 		name:    "handleDecodeScript: invalid hex",
 		handler: handleDecodeScript,
@@ -3032,115 +3318,525 @@ func TestHandleDecodeScript(t *testing.T) {
 	}})
 }
 
-func TestHandleEstimateFee(t *testing.T) {
+// pstTestSKAValue is an SKA amount that does not fit in an int64 used by the
+// partially signed transaction tests.
+var pstTestSKAValue, _ = new(big.Int).SetString("123456789012345678901234567", 10)
+
+// newTestPST returns a partially signed transaction that spends a VAR and an
+// SKA pay-to-pubkey-hash output along with the private key that is able to
+// sign both inputs.
+func newTestPST(t *testing.T) (*pst.Packet, []byte) {
+	t.Helper()
+
+	privKey := chainhash.HashB([]byte("pst"))
+	pubKey := secp256k1.PrivKeyFromBytes(privKey).PubKey().SerializeCompressed()
+	addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(
+		stdaddr.Hash160(pubKey), defaultChainParams)
+	if err != nil {
+		t.Fatalf("unexpected address error: %v", err)
+	}
+	_, pkScript := addr.PaymentScript()
+
+	tx := wire.NewMsgTx()
+	for i := 0; i < 2; i++ {
+		prevOut := wire.NewOutPoint(&chainhash.Hash{byte(i + 1)}, uint32(i),
+			wire.TxTreeRegular)
+		tx.AddTxIn(wire.NewTxIn(prevOut, 0, nil))
+	}
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{txscript.OP_TRUE}))
+	tx.AddTxOut(&wire.TxOut{
+		CoinType: cointype.CoinType(1),
+		SKAValue: new(big.Int).Sub(pstTestSKAValue, big.NewInt(5000)),
+		PkScript: []byte{txscript.OP_TRUE},
+	})
+
+	p, err := pst.New(tx)
+	if err != nil {
+		t.Fatalf("unexpected PST error: %v", err)
+	}
+	p.Inputs[0].PrevOutput = &pst.PrevOutput{
+		CoinType: cointype.CoinTypeVAR,
+		Value:    3000,
+		PkScript: pkScript,
+	}
+	p.Inputs[1].PrevOutput = &pst.PrevOutput{
+		CoinType: cointype.CoinType(1),
+		SKAValue: pstTestSKAValue,
+		PkScript: pkScript,
+	}
+	return p, privKey
+}
+
+// mustEncodePST returns the base64 encoding of the provided partially signed
+// transaction.
+func mustEncodePST(t *testing.T, p *pst.Packet) string {
+	t.Helper()
+
+	encoded, err := p.B64Encode()
+	if err != nil {
+		t.Fatalf("unexpected PST encoding error: %v", err)
+	}
+	return encoded
+}
+
+// signTestPST returns a copy of the provided partially signed transaction with
+// the inputs at the given indices signed by the private key.
+func signTestPST(t *testing.T, p *pst.Packet, privKey []byte, idxs ...int) *pst.Packet {
+	t.Helper()
+
+	signed, err := pst.ParseBase64(mustEncodePST(t, p))
+	if err != nil {
+		t.Fatalf("unexpected PST decoding error: %v", err)
+	}
+	const hashType = txscript.SigHashAll | txscript.SigHashCoinType
+	for _, idx := range idxs {
+		err := signed.Sign(idx, privKey, dcrec.STEcdsaSecp256k1, hashType)
+		if err != nil {
+			t.Fatalf("unexpected PST signing error: %v", err)
+		}
+	}
+	return signed
+}
+
+func TestHandleDecodePST(t *testing.T) {
 	t.Parallel()
 
+	p, privKey := newTestPST(t)
+	p.Outputs[0].Bip32Derivations = []*pst.Bip32Derivation{{
+		PubKey:               []byte{0x02, 0x01},
+		MasterKeyFingerprint: 0xdeadbeef,
+		Path:                 []uint32{pstHardenedKeyStart + 44, 1},
+	}}
+	signed := signTestPST(t, p, privKey, 0)
+	sig := signed.Inputs[0].PartialSigs[0]
+
+	prevOutScript := func() types.ScriptPubKeyResult {
+		script := p.Inputs[0].PrevOutput.PkScript
+		disbuf, _ := txscript.DisasmString(script)
+		_, addrs := stdscript.ExtractAddrs(0, script, defaultChainParams)
+		return types.ScriptPubKeyResult{
+			Asm:       disbuf,
+			Hex:       hex.EncodeToString(script),
+			ReqSigs:   1,
+			Type:      "pubkeyhash",
+			Addresses: []string{addrs[0].String()},
+		}
+	}()
+	mtx := signed.UnsignedTx
 	testRPCServerHandler(t, []rpcTest{{
-		name:    "handleEstimateFee: ok",
-		handler: handleEstimateFee,
-		cmd:     &types.EstimateFeeCmd{},
-		result:  float64(0.0001),
+		name:    "handleDecodePST: invalid base64",
+		handler: handleDecodePST,
+		cmd:     &types.DecodePSTCmd{PST: "!"},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDeserialization,
+	}, {
+		name:    "handleDecodePST: ok",
+		handler: handleDecodePST,
+		cmd:     &types.DecodePSTCmd{PST: mustEncodePST(t, signed)},
+		result: types.DecodePSTResult{
+			Tx: types.TxRawDecodeResult{
+				Txid:     mtx.TxHash().String(),
+				Version:  int32(mtx.Version),
+				Locktime: mtx.LockTime,
+				Expiry:   mtx.Expiry,
+				Vin:      createVinList(mtx, false),
+				Vout:     createVoutList(mtx, defaultChainParams, nil),
+			},
+			Inputs: []types.PSTInputResult{{
+				PrevOut: &types.PSTPrevOutResult{
+					Value:        3e-05,
+					ScriptPubKey: prevOutScript,
+				},
+				PartialSigs: map[string]string{
+					hex.EncodeToString(sig.PubKey): hex.EncodeToString(sig.Signature),
+				},
+			}, {
+				PrevOut: &types.PSTPrevOutResult{
+					SKAValue:     pstTestSKAValue.String(),
+					CoinType:     1,
+					ScriptPubKey: prevOutScript,
+				},
+			}},
+			Outputs: []types.PSTOutputResult{{
+				Bip32Derivs: []types.PSTBip32DerivResult{{
+					PubKey:            "0201",
+					MasterFingerprint: "deadbeef",
+					Path:              "m/44'/1",
+				}},
+			}, {}},
+			Fees: []types.PSTFeeResult{{
+				Fee: 2e-05,
+			}, {
+				SKAFee:   "5000",
+				CoinType: 1,
+			}},
+		},
 	}})
 }
 
-func TestHandleEstimateSmartFee(t *testing.T) {
+func TestHandleCombinePST(t *testing.T) {
 	t.Parallel()
 
-	conservative := types.EstimateSmartFeeConservative
-	economical := types.EstimateSmartFeeEconomical
-	validFeeEstimator := defaultMockFeeEstimator()
-	validFeeEstimator.estimateFeeAmt = 123456789
-	result := &types.EstimateSmartFeeResult{
-		FeeRate: "123456789", // Atoms as string
-	}
+	p, privKey := newTestPST(t)
+	other, _ := newTestPST(t)
+	other.UnsignedTx.LockTime = 1
+
 	testRPCServerHandler(t, []rpcTest{{
-		name:    "handleEstimateSmartFee: ok with mode",
-		handler: handleEstimateSmartFee,
-		cmd: &types.EstimateSmartFeeCmd{
-			Confirmations: 0,
-			Mode:          &conservative,
-		},
-		mockFeeEstimator: validFeeEstimator,
-		result:           result,
+		name:    "handleCombinePST: no packets",
+		handler: handleCombinePST,
+		cmd:     &types.CombinePSTCmd{},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
 	}, {
-		name:             "handleEstimateSmartFee: ok no mode",
-		handler:          handleEstimateSmartFee,
-		cmd:              &types.EstimateSmartFeeCmd{},
-		mockFeeEstimator: validFeeEstimator,
-		result:           result,
+		name:    "handleCombinePST: invalid packet",
+		handler: handleCombinePST,
+		cmd: &types.CombinePSTCmd{
+			PSTs: []string{mustEncodePST(t, p), "bXBzdA=="},
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDeserialization,
 	}, {
-		name:    "handleEstimateSmartFee: not conservative mode",
-		handler: handleEstimateSmartFee,
-		cmd: &types.EstimateSmartFeeCmd{
-			Mode: &economical,
+		name:    "handleCombinePST: mismatched transactions",
+		handler: handleCombinePST,
+		cmd: &types.CombinePSTCmd{
+			PSTs: []string{mustEncodePST(t, p), mustEncodePST(t, other)},
 		},
 		wantErr: true,
 		errCode: dcrjson.ErrRPCInvalidParameter,
 	}, {
-		name:    "handleEstimateSmartFee: estimate fee error",
-		handler: handleEstimateSmartFee,
-		cmd:     &types.EstimateSmartFeeCmd{},
-		mockFeeEstimator: func() *testFeeEstimator {
-			feeEstimator := defaultMockFeeEstimator()
-			feeEstimator.estimateFeeErr = errors.New("")
-			return feeEstimator
-		}(),
-		wantErr: true,
-		errCode: dcrjson.ErrRPCInternal.Code,
+		name:    "handleCombinePST: ok",
+		handler: handleCombinePST,
+		cmd: &types.CombinePSTCmd{
+			PSTs: []string{
+				mustEncodePST(t, signTestPST(t, p, privKey, 0)),
+				mustEncodePST(t, signTestPST(t, p, privKey, 1)),
+			},
+		},
+		result: mustEncodePST(t, signTestPST(t, p, privKey, 0, 1)),
 	}})
 }
 
-func TestHandleEstimateStakeDiff(t *testing.T) {
+func TestHandleFinalizePST(t *testing.T) {
 	t.Parallel()
 
-	type stakeDiffQueueItem struct {
-		diff int64
-		err  error
+	p, privKey := newTestPST(t)
+	partial := signTestPST(t, p, privKey, 0)
+	signed := signTestPST(t, p, privKey, 0, 1)
+
+	// Create the expected partially finalized packet and signed transaction.
+	partialFinal := signTestPST(t, p, privKey, 0)
+	if err := partialFinal.Finalize(0); err != nil {
+		t.Fatalf("unexpected finalize error: %v", err)
 	}
-	var low, med, high, user int64 = 123456, 1234567, 12345678, 7345468745783
-	validQueueFn := func() []*stakeDiffQueueItem {
-		lowQItem := stakeDiffQueueItem{diff: low}
-		medQItem := stakeDiffQueueItem{diff: med}
-		highQItem := stakeDiffQueueItem{diff: high}
-		userQItem := stakeDiffQueueItem{diff: user}
-		return []*stakeDiffQueueItem{
-			&lowQItem,
-			&highQItem,
-			&medQItem,
-			&userQItem,
+	final := signTestPST(t, p, privKey, 0, 1)
+	for i := range final.Inputs {
+		if err := final.Finalize(i); err != nil {
+			t.Fatalf("unexpected finalize error: %v", err)
 		}
 	}
-	estimateFn := func(queue []*stakeDiffQueueItem) func(*chainhash.Hash, int64, bool) (int64, error) {
-		return func(*chainhash.Hash, int64, bool) (int64, error) {
-			defer func() { queue = queue[1:] }()
-			return queue[0].diff, queue[0].err
-		}
+	tx, err := final.Extract()
+	if err != nil {
+		t.Fatalf("unexpected extract error: %v", err)
+	}
+	txB, err := tx.Bytes()
+	if err != nil {
+		t.Fatalf("unexpected tx serialization error: %v", err)
 	}
+
+	noExtract := false
 	testRPCServerHandler(t, []rpcTest{{
-		name:    "handleEstimateStakeDiff: ok with Tickets arg",
-		handler: handleEstimateStakeDiff,
-		cmd: &types.EstimateStakeDiffCmd{
-			Tickets: dcrjson.Uint32(1),
+		name:    "handleFinalizePST: invalid packet",
+		handler: handleFinalizePST,
+		cmd:     &types.FinalizePSTCmd{PST: "bXBzdA=="},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDeserialization,
+	}, {
+		name:    "handleFinalizePST: incomplete",
+		handler: handleFinalizePST,
+		cmd:     &types.FinalizePSTCmd{PST: mustEncodePST(t, partial)},
+		result: types.FinalizePSTResult{
+			PST: mustEncodePST(t, partialFinal),
 		},
-		mockChain: func() *testRPCChain {
-			chain := defaultMockRPCChain()
-			chain.estimateNextStakeDifficultyFn = estimateFn(validQueueFn())
-			return chain
-		}(),
-		result: &types.EstimateStakeDiffResult{
-			Min:      dcrutil.Amount(low).ToCoin(),
-			Max:      dcrutil.Amount(high).ToCoin(),
-			Expected: dcrutil.Amount(med).ToCoin(),
-			User:     dcrjson.Float64(dcrutil.Amount(user).ToCoin()),
+	}, {
+		name:    "handleFinalizePST: complete without extract",
+		handler: handleFinalizePST,
+		cmd: &types.FinalizePSTCmd{
+			PST:     mustEncodePST(t, signed),
+			Extract: &noExtract,
+		},
+		result: types.FinalizePSTResult{
+			PST:      mustEncodePST(t, final),
+			Complete: true,
 		},
 	}, {
-		name:    "handleEstimateStakeDiff: ok no Tickets arg",
-		handler: handleEstimateStakeDiff,
-		cmd:     &types.EstimateStakeDiffCmd{},
-		mockChain: func() *testRPCChain {
-			chain := defaultMockRPCChain()
-			chain.estimateNextStakeDifficultyFn = estimateFn(validQueueFn())
-			return chain
+		name:    "handleFinalizePST: ok",
+		handler: handleFinalizePST,
+		cmd:     &types.FinalizePSTCmd{PST: mustEncodePST(t, signed)},
+		result: types.FinalizePSTResult{
+			Hex:      hex.EncodeToString(txB),
+			Complete: true,
+		},
+	}})
+}
+
+func TestHandleAnalyzePST(t *testing.T) {
+	t.Parallel()
+
+	p, privKey := newTestPST(t)
+	noPrevOut := signTestPST(t, p, privKey)
+	noPrevOut.Inputs[1].PrevOutput = nil
+	final := signTestPST(t, p, privKey, 0, 1)
+	if err := final.Finalize(0); err != nil {
+		t.Fatalf("unexpected finalize error: %v", err)
+	}
+	fees := []types.PSTFeeResult{{
+		Fee: 2e-05,
+	}, {
+		SKAFee:   "5000",
+		CoinType: 1,
+	}}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleAnalyzePST: invalid packet",
+		handler: handleAnalyzePST,
+		cmd:     &types.AnalyzePSTCmd{PST: "bXBzdA=="},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDeserialization,
+	}, {
+		name:    "handleAnalyzePST: missing previous output",
+		handler: handleAnalyzePST,
+		cmd:     &types.AnalyzePSTCmd{PST: mustEncodePST(t, noPrevOut)},
+		result: types.AnalyzePSTResult{
+			Inputs: []types.AnalyzePSTInputResult{{
+				HasPrevOut: true,
+				Next:       "signer",
+			}, {
+				Next: "updater",
+			}},
+			Next: "updater",
+		},
+	}, {
+		name:    "handleAnalyzePST: missing signatures",
+		handler: handleAnalyzePST,
+		cmd: &types.AnalyzePSTCmd{
+			PST: mustEncodePST(t, signTestPST(t, p, privKey, 0)),
+		},
+		result: types.AnalyzePSTResult{
+			Inputs: []types.AnalyzePSTInputResult{{
+				HasPrevOut: true,
+				Next:       "finalizer",
+			}, {
+				HasPrevOut: true,
+				Next:       "signer",
+			}},
+			Fees: fees,
+			Next: "signer",
+		},
+	}, {
+		name:    "handleAnalyzePST: partially finalized",
+		handler: handleAnalyzePST,
+		cmd:     &types.AnalyzePSTCmd{PST: mustEncodePST(t, final)},
+		result: types.AnalyzePSTResult{
+			Inputs: []types.AnalyzePSTInputResult{{
+				HasPrevOut: true,
+				IsFinal:    true,
+				Next:       "extractor",
+			}, {
+				HasPrevOut: true,
+				Next:       "finalizer",
+			}},
+			Fees: fees,
+			Next: "finalizer",
+		},
+	}})
+}
+
+func TestHandleDumpTxOutSet(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	existingPath := filepath.Join(dir, "existing.dat")
+	if err := os.WriteFile(existingPath, nil, 0600); err != nil {
+		t.Fatalf("unable to create file: %v", err)
+	}
+	info := &blockchain.UtxoSnapshotInfo{
+		Height:    432100,
+		BlockHash: *mustParseHash("000000000000000018a1e1f5cda81fb7ba8b47ab2dfa1fd65d02b6d2b5c9dc2d"),
+		NumUtxos:  2,
+		UtxoHash:  *mustParseHash("1f6631957b4060d81ba7e760ec9c8150ba028eb051ddadf2b9749a5ccda1a955"),
+		StateHash: *mustParseHash("eca7e802590df60f7d300b6170f63dfab213b26421ed2e70de3ec2224cb9e460"),
+	}
+	okPath := filepath.Join(dir, "ok.dat")
+	failPath := filepath.Join(dir, "fail.dat")
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleDumpTxOutSet: ok",
+		handler: handleDumpTxOutSet,
+		cmd:     &types.DumpTxOutSetCmd{Path: okPath},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.dumpUtxoSnapshotFn = func(w io.Writer) (*blockchain.UtxoSnapshotInfo, error) {
+				_, err := w.Write([]byte{0x01, 0x02})
+				return info, err
+			}
+			return chain
+		}(),
+		result: types.TxOutSetSnapshotResult{
+			Height:    info.Height,
+			Hash:      info.BlockHash.String(),
+			Utxos:     info.NumUtxos,
+			UtxoHash:  info.UtxoHash.String(),
+			StateHash: info.StateHash.String(),
+			Path:      okPath,
+		},
+	}, {
+		name:    "handleDumpTxOutSet: file already exists",
+		handler: handleDumpTxOutSet,
+		cmd:     &types.DumpTxOutSetCmd{Path: existingPath},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleDumpTxOutSet: unable to dump snapshot",
+		handler: handleDumpTxOutSet,
+		cmd:     &types.DumpTxOutSetCmd{Path: failPath},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.dumpUtxoSnapshotFn = func(w io.Writer) (*blockchain.UtxoSnapshotInfo, error) {
+				return nil, errors.New("unable to dump snapshot")
+			}
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}})
+
+	// Ensure the snapshot was written to the requested path and that the
+	// failed dump did not leave any files behind once the parallel subtests
+	// above complete.
+	t.Cleanup(func() {
+		contents, err := os.ReadFile(okPath)
+		if err != nil {
+			t.Fatalf("unable to read snapshot: %v", err)
+		}
+		if !bytes.Equal(contents, []byte{0x01, 0x02}) {
+			t.Fatalf("unexpected snapshot contents: %x", contents)
+		}
+		for _, path := range []string{failPath, failPath + ".incomplete"} {
+			if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+				t.Fatalf("unexpected file %q after failed dump", path)
+			}
+		}
+	})
+}
+
+func TestHandleEstimateFee(t *testing.T) {
+	t.Parallel()
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleEstimateFee: ok",
+		handler: handleEstimateFee,
+		cmd:     &types.EstimateFeeCmd{},
+		result:  float64(0.0001),
+	}})
+}
+
+func TestHandleEstimateSmartFee(t *testing.T) {
+	t.Parallel()
+
+	conservative := types.EstimateSmartFeeConservative
+	economical := types.EstimateSmartFeeEconomical
+	validFeeEstimator := defaultMockFeeEstimator()
+	validFeeEstimator.estimateFeeAmt = 123456789
+	result := &types.EstimateSmartFeeResult{
+		FeeRate: "123456789", // Atoms as string
+	}
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleEstimateSmartFee: ok with mode",
+		handler: handleEstimateSmartFee,
+		cmd: &types.EstimateSmartFeeCmd{
+			Confirmations: 0,
+			Mode:          &conservative,
+		},
+		mockFeeEstimator: validFeeEstimator,
+		result:           result,
+	}, {
+		name:             "handleEstimateSmartFee: ok no mode",
+		handler:          handleEstimateSmartFee,
+		cmd:              &types.EstimateSmartFeeCmd{},
+		mockFeeEstimator: validFeeEstimator,
+		result:           result,
+	}, {
+		name:    "handleEstimateSmartFee: not conservative mode",
+		handler: handleEstimateSmartFee,
+		cmd: &types.EstimateSmartFeeCmd{
+			Mode: &economical,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleEstimateSmartFee: estimate fee error",
+		handler: handleEstimateSmartFee,
+		cmd:     &types.EstimateSmartFeeCmd{},
+		mockFeeEstimator: func() *testFeeEstimator {
+			feeEstimator := defaultMockFeeEstimator()
+			feeEstimator.estimateFeeErr = errors.New("")
+			return feeEstimator
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
+func TestHandleEstimateStakeDiff(t *testing.T) {
+	t.Parallel()
+
+	type stakeDiffQueueItem struct {
+		diff int64
+		err  error
+	}
+	var low, med, high, user int64 = 123456, 1234567, 12345678, 7345468745783
+	validQueueFn := func() []*stakeDiffQueueItem {
+		lowQItem := stakeDiffQueueItem{diff: low}
+		medQItem := stakeDiffQueueItem{diff: med}
+		highQItem := stakeDiffQueueItem{diff: high}
+		userQItem := stakeDiffQueueItem{diff: user}
+		return []*stakeDiffQueueItem{
+			&lowQItem,
+			&highQItem,
+			&medQItem,
+			&userQItem,
+		}
+	}
+	estimateFn := func(queue []*stakeDiffQueueItem) func(*chainhash.Hash, int64, bool) (int64, error) {
+		return func(*chainhash.Hash, int64, bool) (int64, error) {
+			defer func() { queue = queue[1:] }()
+			return queue[0].diff, queue[0].err
+		}
+	}
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleEstimateStakeDiff: ok with Tickets arg",
+		handler: handleEstimateStakeDiff,
+		cmd: &types.EstimateStakeDiffCmd{
+			Tickets: dcrjson.Uint32(1),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.estimateNextStakeDifficultyFn = estimateFn(validQueueFn())
+			return chain
+		}(),
+		result: &types.EstimateStakeDiffResult{
+			Min:      dcrutil.Amount(low).ToCoin(),
+			Max:      dcrutil.Amount(high).ToCoin(),
+			Expected: dcrutil.Amount(med).ToCoin(),
+			User:     dcrjson.Float64(dcrutil.Amount(user).ToCoin()),
+		},
+	}, {
+		name:    "handleEstimateStakeDiff: ok no Tickets arg",
+		handler: handleEstimateStakeDiff,
+		cmd:     &types.EstimateStakeDiffCmd{},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.estimateNextStakeDifficultyFn = estimateFn(validQueueFn())
+			return chain
 		}(),
 		result: &types.EstimateStakeDiffResult{
 			Min:      dcrutil.Amount(low).ToCoin(),
@@ -4114,6 +4810,21 @@ func TestHandleGetBlock(t *testing.T) {
 		}(),
 		wantErr: true,
 		errCode: dcrjson.ErrRPCBlockNotFound,
+	}, {
+		name:    "handleGetBlock: block pruned",
+		handler: handleGetBlock,
+		cmd: &types.GetBlockCmd{
+			Hash:      blkHashString,
+			Verbose:   dcrjson.Bool(false),
+			VerboseTx: dcrjson.Bool(false),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.blockByHashErr = blockchain.ErrBlockPruned
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCMisc,
 	}, {
 		name:    "handleGetBlock: could not fetch chain work",
 		handler: handleGetBlock,
@@ -4415,6 +5126,26 @@ func TestHandleGetCFilterV2(t *testing.T) {
 			ProofIndex:  blockchain.HeaderCmtFilterIndex,
 			ProofHashes: nil,
 		},
+	}, {
+		name:    "handleGetCFilterV2: ok with proof hashes",
+		handler: handleGetCFilterV2,
+		cmd: &types.GetCFilterV2Cmd{
+			BlockHash: blkHashString,
+		},
+		mockFiltererV2: func() *testFiltererV2 {
+			testFiltererV2 := defaultMockFiltererV2()
+			testFiltererV2.filterByBlockHashProof = &blockchain.HeaderProof{
+				ProofIndex:  blockchain.HeaderCmtFilterIndex,
+				ProofHashes: []chainhash.Hash{{0x01}},
+			}
+			return testFiltererV2
+		}(),
+		result: &types.GetCFilterV2Result{
+			BlockHash:   blkHashString,
+			Data:        filter,
+			ProofIndex:  blockchain.HeaderCmtFilterIndex,
+			ProofHashes: []string{chainhash.Hash{0x01}.String()},
+		},
 	}, {
 		name:    "handleGetCFilterV2: invalid hash",
 		handler: handleGetCFilterV2,
@@ -4602,7 +5333,7 @@ func TestHandleGetInfo(t *testing.T) {
 		result: &types.InfoChainResult{
 			Version: int32(1000000*version.Major + 10000*version.Minor +
 				100*version.Patch),
-			ProtocolVersion: int32(wire.SKABigIntVersion),
+			ProtocolVersion: int32(wire.AddrV2Version),
 			Blocks:          int64(block432100.Header.Height),
 			TimeOffset:      int64(0),
 			Connections:     int32(4),
@@ -4650,6 +5381,74 @@ func TestHandleGetMempoolInfo(t *testing.T) {
 	}})
 }
 
+func TestHandleGetMempoolHistory(t *testing.T) {
+	t.Parallel()
+
+	txHashOne := block432100.Transactions[0].TxHash()
+	txHashTwo := block432100.Transactions[1].TxHash()
+	entryTime := time.Unix(1700000000, 0)
+	txHistory := []*mempool.TxHistoryEntry{{
+		Hash:     txHashTwo,
+		CoinType: 1,
+		Reason:   mempool.HistoryDoubleSpent,
+		Conflict: &txHashOne,
+		Time:     entryTime,
+	}, {
+		Hash:   txHashOne,
+		Reason: mempool.HistoryRejected,
+		Detail: "transaction is not standard",
+		Time:   entryTime,
+	}}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetMempoolHistory: ok",
+		handler: handleGetMempoolHistory,
+		mockTxMempooler: func() *testTxMempooler {
+			mp := defaultMockTxMempooler()
+			mp.txHistory = txHistory
+			return mp
+		}(),
+		cmd: &types.GetMempoolHistoryCmd{},
+		result: []types.GetMempoolHistoryResult{{
+			TxHash:   txHashTwo.String(),
+			CoinType: 1,
+			Reason:   "doublespent",
+			Conflict: txHashOne.String(),
+			Time:     1700000000,
+		}, {
+			TxHash: txHashOne.String(),
+			Reason: "rejected",
+			Detail: "transaction is not standard",
+			Time:   1700000000,
+		}},
+	}, {
+		name:    "handleGetMempoolHistory: filter by hash",
+		handler: handleGetMempoolHistory,
+		mockTxMempooler: func() *testTxMempooler {
+			mp := defaultMockTxMempooler()
+			mp.txHistory = txHistory
+			return mp
+		}(),
+		cmd: &types.GetMempoolHistoryCmd{
+			TxHash: dcrjson.String(txHashOne.String()),
+		},
+		result: []types.GetMempoolHistoryResult{{
+			TxHash: txHashOne.String(),
+			Reason: "rejected",
+			Detail: "transaction is not standard",
+			Time:   1700000000,
+		}},
+	}, {
+		name:    "handleGetMempoolHistory: invalid hash",
+		handler: handleGetMempoolHistory,
+		cmd: &types.GetMempoolHistoryCmd{
+			TxHash: dcrjson.String("invalid"),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}})
+}
+
 func TestHandleGetMiningInfo(t *testing.T) {
 	t.Parallel()
 
@@ -4694,6 +5493,87 @@ func TestHandleGetNetTotals(t *testing.T) {
 	}})
 }
 
+func TestHandleGetNetworkStats(t *testing.T) {
+	t.Parallel()
+
+	stats := &NetworkStats{
+		TotalBytesRecv:      9598159,
+		TotalBytesSent:      4783802,
+		BytesRecvPerMsg:     map[string]uint64{wire.CmdBlock: 9598000},
+		BytesSentPerMsg:     map[string]uint64{wire.CmdBlock: 4783000},
+		MaxUploadRate:       1024 * 1024,
+		MaxPeerUploadRate:   256 * 1024,
+		UploadTarget:        5000 * 1024 * 1024,
+		UploadTargetCycle:   24 * time.Hour,
+		UploadTargetReached: false,
+		BytesLeftInCycle:    5000*1024*1024 - 4783802,
+		TimeLeftInCycle:     90 * time.Minute,
+	}
+	reachedStats := *stats
+	reachedStats.UploadTargetReached = true
+	reachedStats.BytesLeftInCycle = 0
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetNetworkStats: ok",
+		handler: handleGetNetworkStats,
+		cmd:     &types.GetNetworkStatsCmd{},
+		mockConnManager: func() *testConnManager {
+			connManager := defaultMockConnManager()
+			connManager.networkStats = stats
+			return connManager
+		}(),
+		mockClock: &testClock{
+			now: time.Unix(1592931302, 0),
+		},
+		result: &types.GetNetworkStatsResult{
+			TotalBytesRecv:    9598159,
+			TotalBytesSent:    4783802,
+			TimeMillis:        1592931302000,
+			BytesRecvPerMsg:   map[string]uint64{wire.CmdBlock: 9598000},
+			BytesSentPerMsg:   map[string]uint64{wire.CmdBlock: 4783000},
+			MaxUploadRate:     1024 * 1024,
+			MaxPeerUploadRate: 256 * 1024,
+			UploadTarget: types.UploadTargetResult{
+				Timeframe:             86400,
+				Target:                5000 * 1024 * 1024,
+				TargetReached:         false,
+				ServeHistoricalBlocks: true,
+				BytesLeftInCycle:      5000*1024*1024 - 4783802,
+				TimeLeftInCycle:       5400,
+			},
+		},
+	}, {
+		name:    "handleGetNetworkStats: upload target reached",
+		handler: handleGetNetworkStats,
+		cmd:     &types.GetNetworkStatsCmd{},
+		mockConnManager: func() *testConnManager {
+			connManager := defaultMockConnManager()
+			connManager.networkStats = &reachedStats
+			return connManager
+		}(),
+		mockClock: &testClock{
+			now: time.Unix(1592931302, 0),
+		},
+		result: &types.GetNetworkStatsResult{
+			TotalBytesRecv:    9598159,
+			TotalBytesSent:    4783802,
+			TimeMillis:        1592931302000,
+			BytesRecvPerMsg:   map[string]uint64{wire.CmdBlock: 9598000},
+			BytesSentPerMsg:   map[string]uint64{wire.CmdBlock: 4783000},
+			MaxUploadRate:     1024 * 1024,
+			MaxPeerUploadRate: 256 * 1024,
+			UploadTarget: types.UploadTargetResult{
+				Timeframe:             86400,
+				Target:                5000 * 1024 * 1024,
+				TargetReached:         true,
+				ServeHistoricalBlocks: false,
+				BytesLeftInCycle:      0,
+				TimeLeftInCycle:       5400,
+			},
+		},
+	}})
+}
+
 func TestHandleGetNetworkHashPS(t *testing.T) {
 	t.Parallel()
 
@@ -4788,7 +5668,7 @@ func TestHandleGetNetworkInfo(t *testing.T) {
 				100*version.Patch),
 			SubVersion: fmt.Sprintf("%d.%d.%d", version.Major, version.Minor,
 				version.Patch),
-			ProtocolVersion: int32(wire.SKABigIntVersion),
+			ProtocolVersion: int32(wire.AddrV2Version),
 			TimeOffset:      int64(0),
 			Connections:     int32(4),
 			Networks: []types.NetworksResult{{
@@ -4821,6 +5701,64 @@ func TestHandleGetNetworkInfo(t *testing.T) {
 	}})
 }
 
+func TestHandleGetNodeAddresses(t *testing.T) {
+	t.Parallel()
+
+	addrs := []*addrmgr.NetAddress{
+		addrmgr.NewNetAddressFromIPPort(net.ParseIP("106.14.238.184"), 9108,
+			wire.SFNodeNetwork),
+		addrmgr.NewNetAddressFromIPPort(net.ParseIP("2003::1"), 19108,
+			wire.SFNodeNetwork|wire.SFNodeCF),
+	}
+	addrs[0].Timestamp = time.Unix(1592918788, 0)
+	addrs[1].Timestamp = time.Unix(1592918789, 0)
+	mockAddrManager := func() *testAddrManager {
+		addrManager := defaultMockAddrManager()
+		addrManager.addressCache = addrs
+		addrManager.asns = map[string]uint32{addrs[0].Key(): 37963}
+		return addrManager
+	}
+	results := []types.GetNodeAddressesResult{{
+		Time:     1592918788,
+		Services: "00000001",
+		Address:  "106.14.238.184",
+		Port:     9108,
+		MappedAS: 37963,
+	}, {
+		Time:     1592918789,
+		Services: "00000005",
+		Address:  "2003::1",
+		Port:     19108,
+	}}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:            "handleGetNodeAddresses: default count",
+		handler:         handleGetNodeAddresses,
+		cmd:             &types.GetNodeAddressesCmd{Count: dcrjson.Int32(1)},
+		mockAddrManager: mockAddrManager(),
+		result:          results[:1],
+	}, {
+		name:            "handleGetNodeAddresses: all addresses",
+		handler:         handleGetNodeAddresses,
+		cmd:             &types.GetNodeAddressesCmd{Count: dcrjson.Int32(0)},
+		mockAddrManager: mockAddrManager(),
+		result:          results,
+	}, {
+		name:            "handleGetNodeAddresses: count exceeds addresses",
+		handler:         handleGetNodeAddresses,
+		cmd:             &types.GetNodeAddressesCmd{Count: dcrjson.Int32(5)},
+		mockAddrManager: mockAddrManager(),
+		result:          results,
+	}, {
+		name:            "handleGetNodeAddresses: negative count",
+		handler:         handleGetNodeAddresses,
+		cmd:             &types.GetNodeAddressesCmd{Count: dcrjson.Int32(-1)},
+		mockAddrManager: mockAddrManager(),
+		wantErr:         true,
+		errCode:         dcrjson.ErrRPCInvalidParameter,
+	}})
+}
+
 func TestHandleGetPeerInfo(t *testing.T) {
 	t.Parallel()
 
@@ -4842,13 +5780,21 @@ func TestHandleGetPeerInfo(t *testing.T) {
 					addr:              "106.14.238.184:19108",
 					lastPingNonce:     uint64(10),
 					statsSnapshot: &peer.StatsSnap{
-						ID:             int32(5),
-						Addr:           "106.14.238.184:19108",
-						Services:       wire.SFNodeNetwork | wire.SFNodeCF,
-						LastSend:       time.Unix(1592918788, 0),
-						LastRecv:       time.Unix(1592918788, 0),
-						BytesSent:      uint64(3406),
-						BytesRecv:      uint64(2498),
+						ID:        int32(5),
+						Addr:      "106.14.238.184:19108",
+						Services:  wire.SFNodeNetwork | wire.SFNodeCF,
+						LastSend:  time.Unix(1592918788, 0),
+						LastRecv:  time.Unix(1592918788, 0),
+						BytesSent: uint64(3406),
+						BytesRecv: uint64(2498),
+						BytesSentPerMsg: map[string]uint64{
+							wire.CmdVersion: 3300,
+							wire.CmdVerAck:  106,
+						},
+						BytesRecvPerMsg: map[string]uint64{
+							wire.CmdVersion: 2474,
+							wire.CmdVerAck:  24,
+						},
 						ConnTime:       time.Unix(1592918784, 0),
 						TimeOffset:     int64(-75),
 						Version:        uint32(6),
@@ -4859,7 +5805,16 @@ func TestHandleGetPeerInfo(t *testing.T) {
 						LastPingNonce:  uint64(10),
 						LastPingTime:   time.Unix(1592918788, 0),
 						LastPingMicros: int64(0),
+						Transport:      peer.TransportV2,
+					},
+					blockStats: netsync.BlockDownloadStats{
+						InFlight:        12,
+						Received:        3042,
+						Stalls:          1,
+						Window:          14,
+						AvgResponseTime: 1500 * time.Microsecond,
 					},
+					mappedAS: 37963,
 				},
 			}
 			return connManager
@@ -4868,15 +5823,23 @@ func TestHandleGetPeerInfo(t *testing.T) {
 			since: time.Duration(2000),
 		},
 		result: []*types.GetPeerInfoResult{{
-			ID:             int32(5),
-			Addr:           "106.14.238.184:19108",
-			AddrLocal:      "172.17.0.2:51060",
-			Services:       "00000005",
-			RelayTxes:      true,
-			LastSend:       int64(1592918788),
-			LastRecv:       int64(1592918788),
-			BytesSent:      uint64(3406),
-			BytesRecv:      uint64(2498),
+			ID:        int32(5),
+			Addr:      "106.14.238.184:19108",
+			AddrLocal: "172.17.0.2:51060",
+			Services:  "00000005",
+			RelayTxes: true,
+			LastSend:  int64(1592918788),
+			LastRecv:  int64(1592918788),
+			BytesSent: uint64(3406),
+			BytesRecv: uint64(2498),
+			BytesSentPerMsg: map[string]uint64{
+				wire.CmdVersion: 3300,
+				wire.CmdVerAck:  106,
+			},
+			BytesRecvPerMsg: map[string]uint64{
+				wire.CmdVersion: 2474,
+				wire.CmdVerAck:  24,
+			},
 			ConnTime:       int64(1592918784),
 			TimeOffset:     int64(-75),
 			PingTime:       float64(0),
@@ -4884,10 +5847,17 @@ func TestHandleGetPeerInfo(t *testing.T) {
 			Version:        uint32(6),
 			SubVer:         "/dcrwire:0.3.0/dcrd:1.5.0(pre)/",
 			Inbound:        false,
+			Transport:      "v2",
+			MappedAS:       37963,
 			StartingHeight: int64(323327),
 			CurrentHeight:  int64(323327),
 			BanScore:       int32(0),
 			SyncNode:       false,
+			BlocksInFlight: 12,
+			BlocksRecv:     3042,
+			BlockStalls:    1,
+			BlockWindow:    14,
+			BlockRespTime:  1500,
 		}},
 	}})
 }
@@ -5122,66 +6092,429 @@ func TestHandleGetTreasuryBalance(t *testing.T) {
 			Updates: updates,
 		},
 	}, {
-		name:    "handleGetTreasuryBalance: ok empty block hash with verbose",
-		handler: handleGetTreasuryBalance,
-		cmd: &types.GetTreasuryBalanceCmd{
-			Hash:    dcrjson.String(""),
-			Verbose: dcrjson.Bool(true),
+		name:    "handleGetTreasuryBalance: ok empty block hash with verbose",
+		handler: handleGetTreasuryBalance,
+		cmd: &types.GetTreasuryBalanceCmd{
+			Hash:    dcrjson.String(""),
+			Verbose: dcrjson.Bool(true),
+		},
+		result: types.GetTreasuryBalanceResult{
+			Hash:    blkHashString,
+			Height:  blkHeight,
+			Balance: balance,
+			Updates: updates,
+		},
+	}, {
+		name:    "handleGetTreasuryBalance: ok with block hash",
+		handler: handleGetTreasuryBalance,
+		cmd: &types.GetTreasuryBalanceCmd{
+			Hash: &blkHashString,
+		},
+		result: types.GetTreasuryBalanceResult{
+			Hash:    blkHashString,
+			Height:  blkHeight,
+			Balance: balance,
+		},
+	}, {
+		name:    "handleGetTreasuryBalance: invalid hex",
+		handler: handleGetTreasuryBalance,
+		cmd: &types.GetTreasuryBalanceCmd{
+			Hash: dcrjson.String("invalid hex"),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleGetTreasuryBalance: block not found",
+		handler: handleGetTreasuryBalance,
+		cmd:     &types.GetTreasuryBalanceCmd{},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.treasuryBalanceErr = blockchain.ErrUnknownBlock
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCBlockNotFound,
+	}, {
+		name:    "handleGetTreasuryBalance: treasury inactive for block",
+		handler: handleGetTreasuryBalance,
+		cmd:     &types.GetTreasuryBalanceCmd{},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.treasuryBalanceErr = blockchain.ErrNoTreasuryBalance
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCNoTreasury,
+	}, {
+		name:    "handleGetTreasuryBalance: failed to obtain treasury balance",
+		handler: handleGetTreasuryBalance,
+		cmd:     &types.GetTreasuryBalanceCmd{},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.treasuryBalanceErr = errors.New("failed to obtain treasury balance")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
+// proofTestBlock returns a block with three regular transactions, the last of
+// which burns SKA coins, and two stake transactions for use in the inclusion
+// proof tests.  The block header commits to the transaction trees as defined by
+// DCP0005 when combined is true and commits to them individually otherwise.
+func proofTestBlock(combined bool) *dcrutil.Block {
+	newTx := func(seed byte, txOuts ...*wire.TxOut) *wire.MsgTx {
+		tx := wire.NewMsgTx()
+		prevOut := wire.NewOutPoint(&chainhash.Hash{seed}, 0,
+			wire.TxTreeRegular)
+		tx.AddTxIn(wire.NewTxIn(prevOut, 1000, []byte{seed}))
+		for _, txOut := range txOuts {
+			tx.AddTxOut(txOut)
+		}
+		return tx
+	}
+	p2pkh := hexToBytes("76a914000000000000000000000000000000000000000088ac")
+	burnScript := stdscript.NewSKABurnScriptV0(1)
+	skaAmount, _ := new(big.Int).SetString("1500000000000000000", 10)
+
+	var msgBlock wire.MsgBlock
+	msgBlock.Header.Height = 1000
+	msgBlock.AddTransaction(newTx(1, wire.NewTxOut(1000, p2pkh)))
+	msgBlock.AddTransaction(newTx(2, wire.NewTxOut(900, p2pkh)))
+	msgBlock.AddTransaction(newTx(3,
+		wire.NewTxOutSKA(big.NewInt(1000), 1, p2pkh),
+		wire.NewTxOutSKA(skaAmount, 1, burnScript)))
+	msgBlock.AddSTransaction(newTx(4, wire.NewTxOut(800, p2pkh)))
+	msgBlock.AddSTransaction(newTx(5, wire.NewTxOut(700, p2pkh)))
+	if combined {
+		msgBlock.Header.MerkleRoot = standalone.CalcCombinedTxTreeMerkleRoot(
+			msgBlock.Transactions, msgBlock.STransactions)
+	} else {
+		msgBlock.Header.MerkleRoot = standalone.CalcTxTreeMerkleRoot(
+			msgBlock.Transactions)
+		msgBlock.Header.StakeRoot = standalone.CalcTxTreeMerkleRoot(
+			msgBlock.STransactions)
+	}
+	return dcrutil.NewBlock(&msgBlock)
+}
+
+// expectedInclusionProof returns the inclusion proof result expected for the
+// transaction at the provided index of the provided tree of the block with the
+// provided number of confirmations.
+func expectedInclusionProof(blk *dcrutil.Block, tree int8, index uint32, confirmations int64) types.GetTxInclusionProofResult {
+	msgBlock := blk.MsgBlock()
+	txns, siblingTxns := msgBlock.Transactions, msgBlock.STransactions
+	if tree == wire.TxTreeStake {
+		txns, siblingTxns = siblingTxns, txns
+	}
+	leaves := make([]chainhash.Hash, 0, len(txns))
+	for _, tx := range txns {
+		leaves = append(leaves, tx.TxHashFull())
+	}
+	proof := make([]string, 0)
+	for _, hash := range standalone.GenerateInclusionProof(leaves, index) {
+		proof = append(proof, hash.String())
+	}
+	var siblingTreeRoot string
+	if msgBlock.Header.MerkleRoot == standalone.CalcCombinedTxTreeMerkleRoot(
+		msgBlock.Transactions, msgBlock.STransactions) {
+
+		siblingTreeRoot = standalone.CalcTxTreeMerkleRoot(siblingTxns).String()
+	}
+	var headerBuf bytes.Buffer
+	if err := msgBlock.Header.Serialize(&headerBuf); err != nil {
+		panic(err)
+	}
+	return types.GetTxInclusionProofResult{
+		BlockHash:       blk.Hash().String(),
+		Height:          int64(msgBlock.Header.Height),
+		Confirmations:   confirmations,
+		Header:          hex.EncodeToString(headerBuf.Bytes()),
+		Tree:            tree,
+		Index:           index,
+		LeafHash:        leaves[index].String(),
+		TreeRoot:        standalone.CalcTxTreeMerkleRoot(txns).String(),
+		Proof:           proof,
+		SiblingTreeRoot: siblingTreeRoot,
+	}
+}
+
+func TestHandleGetTxInclusionProof(t *testing.T) {
+	t.Parallel()
+
+	blk := proofTestBlock(true)
+	legacyBlk := proofTestBlock(false)
+	badBlk := proofTestBlock(true)
+	badBlk.MsgBlock().Header.MerkleRoot = chainhash.Hash{0x01}
+	mockChain := func(blk *dcrutil.Block) *testRPCChain {
+		chain := defaultMockRPCChain()
+		chain.blockByHash = blk
+		chain.bestSnapshot.Height = int64(blk.MsgBlock().Header.Height) + 11
+		return chain
+	}
+	sideChain := func(blk *dcrutil.Block) *testRPCChain {
+		chain := mockChain(blk)
+		chain.mainChainHasBlock = false
+		return chain
+	}
+	txHash := func(blk *dcrutil.Block, tree int8, index int) string {
+		if tree == wire.TxTreeStake {
+			return blk.MsgBlock().STransactions[index].TxHash().String()
+		}
+		return blk.MsgBlock().Transactions[index].TxHash().String()
+	}
+
+	// Ensure the expected proofs verify against the header.
+	combinedProof := expectedInclusionProof(blk, wire.TxTreeRegular, 2, 12)
+	leaf := blk.MsgBlock().Transactions[2].TxHashFull()
+	proof := make([]chainhash.Hash, len(combinedProof.Proof))
+	for i, hashStr := range combinedProof.Proof {
+		proof[i] = *mustParseHash(hashStr)
+	}
+	treeRoot := mustParseHash(combinedProof.TreeRoot)
+	if !standalone.VerifyInclusionProof(treeRoot, &leaf, 2, proof) {
+		t.Fatal("expected inclusion proof does not verify")
+	}
+	siblingRoot := mustParseHash(combinedProof.SiblingTreeRoot)
+	combinedRoot := standalone.CalcMerkleRoot([]chainhash.Hash{*treeRoot,
+		*siblingRoot})
+	if combinedRoot != blk.MsgBlock().Header.MerkleRoot {
+		t.Fatal("expected combined root does not match header")
+	}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetTxInclusionProof: ok regular tree",
+		handler: handleGetTxInclusionProof,
+		cmd: &types.GetTxInclusionProofCmd{
+			TxHash:    txHash(blk, wire.TxTreeRegular, 2),
+			BlockHash: blk.Hash().String(),
+		},
+		mockChain: mockChain(blk),
+		result:    combinedProof,
+	}, {
+		name:    "handleGetTxInclusionProof: ok stake tree",
+		handler: handleGetTxInclusionProof,
+		cmd: &types.GetTxInclusionProofCmd{
+			TxHash:    txHash(blk, wire.TxTreeStake, 0),
+			BlockHash: blk.Hash().String(),
+		},
+		mockChain: mockChain(blk),
+		result:    expectedInclusionProof(blk, wire.TxTreeStake, 0, 12),
+	}, {
+		name:    "handleGetTxInclusionProof: ok individual tree roots",
+		handler: handleGetTxInclusionProof,
+		cmd: &types.GetTxInclusionProofCmd{
+			TxHash:    txHash(legacyBlk, wire.TxTreeRegular, 1),
+			BlockHash: legacyBlk.Hash().String(),
+		},
+		mockChain: mockChain(legacyBlk),
+		result:    expectedInclusionProof(legacyBlk, wire.TxTreeRegular, 1, 12),
+	}, {
+		name:    "handleGetTxInclusionProof: ok side chain block",
+		handler: handleGetTxInclusionProof,
+		cmd: &types.GetTxInclusionProofCmd{
+			TxHash:    txHash(blk, wire.TxTreeRegular, 2),
+			BlockHash: blk.Hash().String(),
+		},
+		mockChain: sideChain(blk),
+		result:    expectedInclusionProof(blk, wire.TxTreeRegular, 2, -1),
+	}, {
+		name:    "handleGetTxInclusionProof: invalid tx hash",
+		handler: handleGetTxInclusionProof,
+		cmd: &types.GetTxInclusionProofCmd{
+			TxHash:    "invalid",
+			BlockHash: blk.Hash().String(),
+		},
+		mockChain: mockChain(blk),
+		wantErr:   true,
+		errCode:   dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleGetTxInclusionProof: block not found",
+		handler: handleGetTxInclusionProof,
+		cmd: &types.GetTxInclusionProofCmd{
+			TxHash:    txHash(blk, wire.TxTreeRegular, 2),
+			BlockHash: blk.Hash().String(),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.blockByHashErr = errors.New("block not found")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCBlockNotFound,
+	}, {
+		name:    "handleGetTxInclusionProof: tx not in block",
+		handler: handleGetTxInclusionProof,
+		cmd: &types.GetTxInclusionProofCmd{
+			TxHash:    txHash(blk, wire.TxTreeRegular, 2),
+			BlockHash: blk.Hash().String(),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCNoTxInfo,
+	}, {
+		name:    "handleGetTxInclusionProof: header does not commit to trees",
+		handler: handleGetTxInclusionProof,
+		cmd: &types.GetTxInclusionProofCmd{
+			TxHash:    txHash(badBlk, wire.TxTreeRegular, 2),
+			BlockHash: badBlk.Hash().String(),
 		},
-		result: types.GetTreasuryBalanceResult{
-			Hash:    blkHashString,
-			Height:  blkHeight,
-			Balance: balance,
-			Updates: updates,
+		mockChain: mockChain(badBlk),
+		wantErr:   true,
+		errCode:   dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
+func TestHandleGetBurnProof(t *testing.T) {
+	t.Parallel()
+
+	blk := proofTestBlock(true)
+	mockChain := defaultMockRPCChain()
+	mockChain.blockByHash = blk
+	mockChain.bestSnapshot.Height = int64(blk.MsgBlock().Header.Height) + 11
+	sideChain := defaultMockRPCChain()
+	sideChain.blockByHash = blk
+	sideChain.mainChainHasBlock = false
+	burnTx := blk.MsgBlock().Transactions[2]
+	var txBuf bytes.Buffer
+	if err := burnTx.Serialize(&txBuf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetBurnProof: ok",
+		handler: handleGetBurnProof,
+		cmd: &types.GetBurnProofCmd{
+			TxHash:    burnTx.TxHash().String(),
+			BlockHash: blk.Hash().String(),
+		},
+		mockChain: mockChain,
+		result: types.GetBurnProofResult{
+			Hex: hex.EncodeToString(txBuf.Bytes()),
+			Burns: []types.BurnOutput{{
+				Vout:     1,
+				CoinType: 1,
+				Name:     cointype.CoinType(1).String(),
+				Amount:   "1.5",
+				Atoms:    "1500000000000000000",
+			}},
+			Proof: expectedInclusionProof(blk, wire.TxTreeRegular, 2, 12),
 		},
 	}, {
-		name:    "handleGetTreasuryBalance: ok with block hash",
-		handler: handleGetTreasuryBalance,
-		cmd: &types.GetTreasuryBalanceCmd{
-			Hash: &blkHashString,
+		name:    "handleGetBurnProof: side chain block",
+		handler: handleGetBurnProof,
+		cmd: &types.GetBurnProofCmd{
+			TxHash:    burnTx.TxHash().String(),
+			BlockHash: blk.Hash().String(),
 		},
-		result: types.GetTreasuryBalanceResult{
-			Hash:    blkHashString,
-			Height:  blkHeight,
-			Balance: balance,
+		mockChain: sideChain,
+		wantErr:   true,
+		errCode:   dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetBurnProof: no burn outputs",
+		handler: handleGetBurnProof,
+		cmd: &types.GetBurnProofCmd{
+			TxHash:    blk.MsgBlock().Transactions[1].TxHash().String(),
+			BlockHash: blk.Hash().String(),
 		},
+		mockChain: mockChain,
+		wantErr:   true,
+		errCode:   dcrjson.ErrRPCInvalidParameter,
 	}, {
-		name:    "handleGetTreasuryBalance: invalid hex",
-		handler: handleGetTreasuryBalance,
-		cmd: &types.GetTreasuryBalanceCmd{
-			Hash: dcrjson.String("invalid hex"),
+		name:    "handleGetBurnProof: stake tree",
+		handler: handleGetBurnProof,
+		cmd: &types.GetBurnProofCmd{
+			TxHash:    blk.MsgBlock().STransactions[0].TxHash().String(),
+			BlockHash: blk.Hash().String(),
+		},
+		mockChain: mockChain,
+		wantErr:   true,
+		errCode:   dcrjson.ErrRPCInvalidParameter,
+	}})
+}
+
+func TestHandleGetSKASupplyProof(t *testing.T) {
+	t.Parallel()
+
+	emitted, _ := new(big.Int).SetString("900000000000000000000000", 10)
+	burned, _ := new(big.Int).SetString("1500000000000000000", 10)
+	supply := blockchain.SKASupply{
+		CoinType: 2,
+		Nonce:    1,
+		Emitted:  emitted,
+		Burned:   burned,
+	}
+	proof := &blockchain.SKASupplyProof{
+		BlockHash:  block432100.BlockHash(),
+		Height:     int64(block432100.Header.Height),
+		Supply:     supply,
+		LeafIndex:  1,
+		LeafProof:  []chainhash.Hash{{0x01}},
+		SupplyRoot: chainhash.Hash{0x02},
+		HeaderProof: blockchain.HeaderProof{
+			ProofIndex:  blockchain.HeaderCmtSKASupplyIndex,
+			ProofHashes: []chainhash.Hash{{0x03}},
 		},
+	}
+	mockChain := defaultMockRPCChain()
+	mockChain.skaSupplyProof = proof
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:      "handleGetSKASupplyProof: ok",
+		handler:   handleGetSKASupplyProof,
+		cmd:       &types.GetSKASupplyProofCmd{CoinType: 2},
+		mockChain: mockChain,
+		result: &types.GetSKASupplyProofResult{
+			BlockHash:       block432100.BlockHash().String(),
+			Height:          int64(block432100.Header.Height),
+			CoinType:        2,
+			Name:            cointype.CoinType(2).String(),
+			Nonce:           1,
+			Emitted:         "900000000000000000000000",
+			Burned:          "1500000000000000000",
+			Circulating:     "899998500000000000000000",
+			LeafHash:        supply.Hash().String(),
+			LeafIndex:       1,
+			LeafProof:       []string{chainhash.Hash{0x01}.String()},
+			SupplyRoot:      chainhash.Hash{0x02}.String(),
+			CommitmentIndex: blockchain.HeaderCmtSKASupplyIndex,
+			CommitmentProof: []string{chainhash.Hash{0x03}.String()},
+		},
+	}, {
+		name:    "handleGetSKASupplyProof: VAR coin type",
+		handler: handleGetSKASupplyProof,
+		cmd:     &types.GetSKASupplyProofCmd{CoinType: 0},
 		wantErr: true,
-		errCode: dcrjson.ErrRPCDecodeHexString,
+		errCode: dcrjson.ErrRPCInvalidParameter,
 	}, {
-		name:    "handleGetTreasuryBalance: block not found",
-		handler: handleGetTreasuryBalance,
-		cmd:     &types.GetTreasuryBalanceCmd{},
+		name:    "handleGetSKASupplyProof: agenda inactive",
+		handler: handleGetSKASupplyProof,
+		cmd:     &types.GetSKASupplyProofCmd{CoinType: 2},
 		mockChain: func() *testRPCChain {
 			chain := defaultMockRPCChain()
-			chain.treasuryBalanceErr = blockchain.ErrUnknownBlock
+			chain.skaSupplyProofErr = blockchain.ErrNoSKASupplyCommitment
 			return chain
 		}(),
 		wantErr: true,
-		errCode: dcrjson.ErrRPCBlockNotFound,
+		errCode: dcrjson.ErrRPCMisc,
 	}, {
-		name:    "handleGetTreasuryBalance: treasury inactive for block",
-		handler: handleGetTreasuryBalance,
-		cmd:     &types.GetTreasuryBalanceCmd{},
+		name:    "handleGetSKASupplyProof: no supply state",
+		handler: handleGetSKASupplyProof,
+		cmd:     &types.GetSKASupplyProofCmd{CoinType: 3},
 		mockChain: func() *testRPCChain {
 			chain := defaultMockRPCChain()
-			chain.treasuryBalanceErr = blockchain.ErrNoTreasuryBalance
+			chain.skaSupplyProofErr = blockchain.ErrNoSKASupply
 			return chain
 		}(),
 		wantErr: true,
-		errCode: dcrjson.ErrRPCNoTreasury,
+		errCode: dcrjson.ErrRPCInvalidParameter,
 	}, {
-		name:    "handleGetTreasuryBalance: failed to obtain treasury balance",
-		handler: handleGetTreasuryBalance,
-		cmd:     &types.GetTreasuryBalanceCmd{},
+		name:    "handleGetSKASupplyProof: failed to obtain proof",
+		handler: handleGetSKASupplyProof,
+		cmd:     &types.GetSKASupplyProofCmd{CoinType: 2},
 		mockChain: func() *testRPCChain {
 			chain := defaultMockRPCChain()
-			chain.treasuryBalanceErr = errors.New("failed to obtain treasury balance")
+			chain.skaSupplyProofErr = errors.New("failed to obtain proof")
 			return chain
 		}(),
 		wantErr: true,
@@ -5233,6 +6566,10 @@ func TestHandleGetTxOut(t *testing.T) {
 	}
 	txOutResultChain := txOutResultMempool
 	txOutResultChain.Confirmations = 1
+	txOutResultSKA := txOutResultChain
+	txOutResultSKA.Value = 0
+	txOutResultSKA.SKAValue = "1000000000000000000000"
+	txOutResultSKA.CoinType = 1
 
 	// Setup a mock mempooler that has the test tx.
 	mempoolerWithTx := func() *testTxMempooler {
@@ -5270,6 +6607,25 @@ func TestHandleGetTxOut(t *testing.T) {
 		cmd:       &cmd,
 		mockChain: chainWithTx(),
 		result:    &txOutResultChain,
+	}, {
+		name:    "handleGetTxOut: ok SKA output from chain",
+		handler: handleGetTxOut,
+		cmd:     &cmd,
+		mockChain: func() *testRPCChain {
+			chain := chainWithTx()
+			skaAmount, _ := new(big.Int).SetString(txOutResultSKA.SKAValue, 10)
+			chain.fetchUtxoEntry = &testRPCUtxoEntry{
+				skaAmount:     skaAmount,
+				coinType:      1,
+				height:        432100,
+				index:         1,
+				pkScript:      script,
+				scriptVersion: scriptVersion,
+				txType:        stake.TxTypeRegular,
+			}
+			return chain
+		}(),
+		result: &txOutResultSKA,
 	}, {
 		name:    "handleGetTxOut: ok transaction not found",
 		handler: handleGetTxOut,
@@ -5434,6 +6790,43 @@ func TestHandleInvalidateBlock(t *testing.T) {
 	}})
 }
 
+func TestHandleListBanned(t *testing.T) {
+	t.Parallel()
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleListBanned: no bans",
+		handler: handleListBanned,
+		cmd:     &types.ListBannedCmd{},
+		result:  []types.ListBannedResult{},
+	}, {
+		name:    "handleListBanned: ok",
+		handler: handleListBanned,
+		cmd:     &types.ListBannedCmd{},
+		mockConnManager: func() *testConnManager {
+			connManager := defaultMockConnManager()
+			created := time.Unix(1700000000, 0)
+			connManager.bannedSubnets = []banmanager.BanEntry{{
+				Subnet:  netip.MustParsePrefix("192.168.0.0/16"),
+				Created: created,
+				Expires: created.Add(time.Hour),
+				Reason:  "spam",
+			}}
+			return connManager
+		}(),
+		mockClock: &testClock{
+			now: time.Unix(1700000600, 0),
+		},
+		result: []types.ListBannedResult{{
+			Subnet:        "192.168.0.0/16",
+			BanCreated:    1700000000,
+			BannedUntil:   1700003600,
+			BanDuration:   3600,
+			TimeRemaining: 3000,
+			Reason:        "spam",
+		}},
+	}})
+}
+
 func TestHandleLiveTickets(t *testing.T) {
 	t.Parallel()
 
@@ -5726,6 +7119,8 @@ func TestHandleSubmitBlock(t *testing.T) {
 func TestHandleValidateAddress(t *testing.T) {
 	t.Parallel()
 
+	coinTypedAddrCoinType := uint8(1)
+
 	testRPCServerHandler(t, []rpcTest{{
 		name:    "handleValidateAddress: ok",
 		handler: handleValidateAddress,
@@ -5736,6 +7131,17 @@ func TestHandleValidateAddress(t *testing.T) {
 			IsValid: true,
 			Address: "McAwUqK2yNJ6Rksb2ADNaR8C1dcWP6KJCSA",
 		},
+	}, {
+		name:    "handleValidateAddress: ok with coin-typed address",
+		handler: handleValidateAddress,
+		cmd: &types.ValidateAddressCmd{
+			Address: "mon1qyq5xf8xcxv3grqcq9249fgfd53wn3vqlzpqnm89cl",
+		},
+		result: types.ValidateAddressChainResult{
+			IsValid:  true,
+			Address:  "mon1qyq5xf8xcxv3grqcq9249fgfd53wn3vqlzpqnm89cl",
+			CoinType: &coinTypedAddrCoinType,
+		},
 	}, {
 		name:    "handleValidateAddress: invalid address",
 		handler: handleValidateAddress,
@@ -6175,6 +7581,94 @@ func TestHandleGetWork(t *testing.T) {
 	}})
 }
 
+func TestHandleSetBan(t *testing.T) {
+	t.Parallel()
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleSetBan: ok add address",
+		handler: handleSetBan,
+		cmd: &types.SetBanCmd{
+			Subnet: "127.0.0.210",
+			SubCmd: types.SBAdd,
+		},
+		result: nil,
+	}, {
+		name:    "handleSetBan: ok add subnet",
+		handler: handleSetBan,
+		cmd: &types.SetBanCmd{
+			Subnet:  "192.168.0.0/16",
+			SubCmd:  types.SBAdd,
+			BanTime: dcrjson.Int64(3600),
+			Reason:  dcrjson.String("spam"),
+		},
+		result: nil,
+	}, {
+		name:    "handleSetBan: ok remove",
+		handler: handleSetBan,
+		cmd: &types.SetBanCmd{
+			Subnet: "192.168.0.0/16",
+			SubCmd: types.SBRemove,
+		},
+		result: nil,
+	}, {
+		name:    "handleSetBan: invalid subnet",
+		handler: handleSetBan,
+		cmd: &types.SetBanCmd{
+			Subnet: "mydomain.org",
+			SubCmd: types.SBAdd,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleSetBan: negative ban time",
+		handler: handleSetBan,
+		cmd: &types.SetBanCmd{
+			Subnet:  "127.0.0.210",
+			SubCmd:  types.SBAdd,
+			BanTime: dcrjson.Int64(-1),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleSetBan: invalid subcommand",
+		handler: handleSetBan,
+		cmd: &types.SetBanCmd{
+			Subnet: "127.0.0.210",
+			SubCmd: "invalid",
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleSetBan: unable to save ban list",
+		handler: handleSetBan,
+		cmd: &types.SetBanCmd{
+			Subnet: "127.0.0.210",
+			SubCmd: types.SBAdd,
+		},
+		mockConnManager: func() *testConnManager {
+			connManager := defaultMockConnManager()
+			connManager.setBanErr = errors.New("unable to save")
+			return connManager
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleSetBan: remove subnet that is not banned",
+		handler: handleSetBan,
+		cmd: &types.SetBanCmd{
+			Subnet: "127.0.0.210",
+			SubCmd: types.SBRemove,
+		},
+		mockConnManager: func() *testConnManager {
+			connManager := defaultMockConnManager()
+			connManager.removeBanErr = errors.New("subnet is not banned")
+			return connManager
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}})
+}
+
 func TestHandleSetGenerate(t *testing.T) {
 	t.Parallel()
 
@@ -7007,6 +8501,156 @@ func TestHandleVerifyMessage(t *testing.T) {
 	}})
 }
 
+func TestHandleVerifyPartialSig(t *testing.T) {
+	t.Parallel()
+
+	// Create a signing session for two participants.
+	privKeys := []*secp256k1.PrivateKey{
+		secp256k1.PrivKeyFromBytes(hexToBytes("0000000000000000000000000000000000000000000000000000000000000001")),
+		secp256k1.PrivKeyFromBytes(hexToBytes("0000000000000000000000000000000000000000000000000000000000000002")),
+	}
+	pubKeys := []*secp256k1.PublicKey{privKeys[0].PubKey(), privKeys[1].PubKey()}
+	aggKey, err := musig2.AggregateKeys(pubKeys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg := chainhash.HashB([]byte("test message"))
+	secNonces := make([]*musig2.SecretNonce, len(privKeys))
+	pubNonces := make([]*musig2.PublicNonce, len(privKeys))
+	for i, privKey := range privKeys {
+		secNonces[i], pubNonces[i], err = musig2.GenerateNonce(privKey,
+			aggKey, msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	aggNonce, err := musig2.AggregateNonces(pubNonces)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	session, err := musig2.NewSession(aggKey, aggNonce, msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	partialSig, err := session.Sign(secNonces[0], privKeys[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hexPubKeys := []string{
+		hex.EncodeToString(pubKeys[0].SerializeCompressed()),
+		hex.EncodeToString(pubKeys[1].SerializeCompressed()),
+	}
+	hexPubNonces := []string{
+		hex.EncodeToString(pubNonces[0][:]),
+		hex.EncodeToString(pubNonces[1][:]),
+	}
+	hexMsg := hex.EncodeToString(msg)
+	hexPartialSig := hex.EncodeToString(partialSig.Serialize())
+	result := func(valid bool) types.VerifyPartialSigResult {
+		return types.VerifyPartialSigResult{
+			AggregateKey:   hex.EncodeToString(aggKey.PubKey().SerializeCompressed()),
+			AggregateNonce: hex.EncodeToString(aggNonce[:]),
+			Valid:          valid,
+		}
+	}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleVerifyPartialSig: ok",
+		handler: handleVerifyPartialSig,
+		cmd: &types.VerifyPartialSigCmd{
+			PubKeys:      hexPubKeys,
+			PubNonces:    hexPubNonces,
+			SignerPubKey: hexPubKeys[0],
+			Message:      hexMsg,
+			PartialSig:   hexPartialSig,
+		},
+		result: result(true),
+	}, {
+		name:    "handleVerifyPartialSig: ok, participants in another order",
+		handler: handleVerifyPartialSig,
+		cmd: &types.VerifyPartialSigCmd{
+			PubKeys:      []string{hexPubKeys[1], hexPubKeys[0]},
+			PubNonces:    []string{hexPubNonces[1], hexPubNonces[0]},
+			SignerPubKey: hexPubKeys[0],
+			Message:      hexMsg,
+			PartialSig:   hexPartialSig,
+		},
+		result: result(true),
+	}, {
+		name:    "handleVerifyPartialSig: signature by another participant",
+		handler: handleVerifyPartialSig,
+		cmd: &types.VerifyPartialSigCmd{
+			PubKeys:      hexPubKeys,
+			PubNonces:    hexPubNonces,
+			SignerPubKey: hexPubKeys[1],
+			Message:      hexMsg,
+			PartialSig:   hexPartialSig,
+		},
+		result: result(false),
+	}, {
+		name:    "handleVerifyPartialSig: mismatched number of nonces",
+		handler: handleVerifyPartialSig,
+		cmd: &types.VerifyPartialSigCmd{
+			PubKeys:      hexPubKeys,
+			PubNonces:    hexPubNonces[:1],
+			SignerPubKey: hexPubKeys[0],
+			Message:      hexMsg,
+			PartialSig:   hexPartialSig,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleVerifyPartialSig: signer is not a participant",
+		handler: handleVerifyPartialSig,
+		cmd: &types.VerifyPartialSigCmd{
+			PubKeys:      hexPubKeys[:1],
+			PubNonces:    hexPubNonces[:1],
+			SignerPubKey: hexPubKeys[1],
+			Message:      hexMsg,
+			PartialSig:   hexPartialSig,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleVerifyPartialSig: invalid public nonce",
+		handler: handleVerifyPartialSig,
+		cmd: &types.VerifyPartialSigCmd{
+			PubKeys:      hexPubKeys,
+			PubNonces:    []string{hexPubNonces[0], "02"},
+			SignerPubKey: hexPubKeys[0],
+			Message:      hexMsg,
+			PartialSig:   hexPartialSig,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleVerifyPartialSig: invalid message length",
+		handler: handleVerifyPartialSig,
+		cmd: &types.VerifyPartialSigCmd{
+			PubKeys:      hexPubKeys,
+			PubNonces:    hexPubNonces,
+			SignerPubKey: hexPubKeys[0],
+			Message:      hexMsg[2:],
+			PartialSig:   hexPartialSig,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleVerifyPartialSig: invalid hex",
+		handler: handleVerifyPartialSig,
+		cmd: &types.VerifyPartialSigCmd{
+			PubKeys:      hexPubKeys,
+			PubNonces:    hexPubNonces,
+			SignerPubKey: hexPubKeys[0],
+			Message:      hexMsg,
+			PartialSig:   "zz",
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}})
+}
+
 func TestHandleSendRawTransaction(t *testing.T) {
 	t.Parallel()
 
@@ -7130,6 +8774,42 @@ func TestHandleSendRawTransaction(t *testing.T) {
 		}(),
 		wantErr: true,
 		errCode: dcrjson.ErrRPCMisc,
+	}, {
+		name:    "handleSendRawTransaction: duplicate stem transaction",
+		handler: handleSendRawTransaction,
+		cmd: &types.SendRawTransactionCmd{
+			HexTx:         hexTx,
+			AllowHighFees: &allowHighFees,
+		},
+		mockConnManager: func() *testConnManager {
+			connManager := defaultMockConnManager()
+			connManager.stemTransactionErr = mempool.RuleError{
+				Err:         mempool.ErrDuplicate,
+				Description: "duplicate tx",
+			}
+			return connManager
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDuplicateTx,
+	}, {
+		name:    "handleSendRawTransaction: ok stem transaction",
+		handler: handleSendRawTransaction,
+		cmd: &types.SendRawTransactionCmd{
+			HexTx:         hexTx,
+			AllowHighFees: &allowHighFees,
+		},
+		mockConnManager: func() *testConnManager {
+			connManager := defaultMockConnManager()
+			connManager.stemTransaction = true
+			return connManager
+		}(),
+		mockSyncManager: func() *testSyncManager {
+			syncManager := defaultMockSyncManager()
+			syncManager.processTransactionErr =
+				errors.New("transaction processed during stem phase")
+			return syncManager
+		}(),
+		result: tx.Hash().String(),
 	}, {
 		name:    "handleSendRawTransaction: ok",
 		handler: handleSendRawTransaction,
@@ -7146,6 +8826,73 @@ func TestHandleSendRawTransaction(t *testing.T) {
 	}})
 }
 
+func TestHandleSubmitPackage(t *testing.T) {
+	t.Parallel()
+
+	allowHighFees := false
+	tx1 := dcrutil.NewTx(block432100.Transactions[1])
+	tx2 := dcrutil.NewTx(block432100.STransactions[0])
+	tx1B, err := block432100.Transactions[1].Bytes()
+	if err != nil {
+		t.Fatalf("unexpected tx serialization error: %v", err)
+	}
+	tx2B, err := block432100.STransactions[0].Bytes()
+	if err != nil {
+		t.Fatalf("unexpected tx serialization error: %v", err)
+	}
+	hexTxns := []string{hex.EncodeToString(tx1B), hex.EncodeToString(tx2B)}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleSubmitPackage: too few transactions",
+		handler: handleSubmitPackage,
+		cmd: &types.SubmitPackageCmd{
+			RawTxs:        hexTxns[:1],
+			AllowHighFees: &allowHighFees,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleSubmitPackage: invalid tx hex",
+		handler: handleSubmitPackage,
+		cmd: &types.SubmitPackageCmd{
+			RawTxs:        []string{hexTxns[0], "invalid"},
+			AllowHighFees: &allowHighFees,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleSubmitPackage: insufficient package fee",
+		handler: handleSubmitPackage,
+		cmd: &types.SubmitPackageCmd{
+			RawTxs:        hexTxns,
+			AllowHighFees: &allowHighFees,
+		},
+		mockSyncManager: func() *testSyncManager {
+			syncManager := defaultMockSyncManager()
+			syncManager.processPackageErr = mempool.RuleError{
+				Err:         mempool.ErrInsufficientFee,
+				Description: "insufficient package fee",
+			}
+			return syncManager
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCMisc,
+	}, {
+		name:    "handleSubmitPackage: ok",
+		handler: handleSubmitPackage,
+		cmd: &types.SubmitPackageCmd{
+			RawTxs:        hexTxns,
+			AllowHighFees: &allowHighFees,
+		},
+		mockSyncManager: func() *testSyncManager {
+			syncManager := defaultMockSyncManager()
+			syncManager.processPackage = []*dcrutil.Tx{tx1, tx2}
+			return syncManager
+		}(),
+		result: []string{tx1.Hash().String(), tx2.Hash().String()},
+	}})
+}
+
 func TestHandleGetVoteInfo(t *testing.T) {
 	t.Parallel()
 
@@ -35,6 +35,25 @@ var helpDescsEnUS = map[string]string{
 	"addnode-addr":      "IP address and port of the peer to operate on",
 	"addnode-subcmd":    "'add' to add a persistent peer, 'remove' to remove a persistent peer, or 'onetry' to try a single connection to a peer",
 
+	// ClearBannedCmd help.
+	"clearbanned--synopsis": "Removes all banned IP addresses and subnets.",
+
+	// ListBannedCmd help.
+	"listbanned--synopsis":           "Returns all banned IP addresses and subnets.",
+	"listbannedresult-subnet":        "The banned subnet in CIDR notation",
+	"listbannedresult-bancreated":    "The time the ban was created in seconds since 1 Jan 1970 GMT",
+	"listbannedresult-banneduntil":   "The time the ban expires in seconds since 1 Jan 1970 GMT",
+	"listbannedresult-banduration":   "The total duration of the ban in seconds",
+	"listbannedresult-timeremaining": "The remaining duration of the ban in seconds",
+	"listbannedresult-reason":        "The reason for the ban",
+
+	// SetBanCmd help.
+	"setban--synopsis": "Bans or unbans an IP address or subnet.  Connections to and from banned addresses are refused and connected peers within a newly banned subnet are disconnected.  Bans persist across restarts.",
+	"setban-subnet":    "The IP address or subnet in CIDR notation (e.g. 192.168.0.0/16) to operate on",
+	"setban-subcmd":    "'add' to ban the subnet or 'remove' to remove the ban",
+	"setban-bantime":   "The duration of the ban in seconds or 0 to use the default ban duration of the server (only for 'add')",
+	"setban-reason":    "The reason for the ban (only for 'add')",
+
 	// NodeCmd help.
 	"node--synopsis":     "Attempts to add or remove a peer.",
 	"node-subcmd":        "'disconnect' to remove all matching non-persistent peers, 'remove' to remove a persistent peer, or 'connect' to connect to a peer",
@@ -89,8 +108,9 @@ var helpDescsEnUS = map[string]string{
 	"createrawtransaction--result0":       "Hex-encoded bytes of the serialized transaction",
 
 	// ScriptSig help.
-	"scriptsig-asm": "Disassembly of the script",
-	"scriptsig-hex": "Hex-encoded bytes of the script",
+	"scriptsig-asm":      "Disassembly of the script",
+	"scriptsig-hex":      "Hex-encoded bytes of the script",
+	"scriptsig-emission": "The details of the SKA emission authorization (only present if the script is a standard SKA emission authorization script)",
 
 	// PrevOut help.
 	"prevout-addresses": "previous output addresses",
@@ -133,6 +153,7 @@ var helpDescsEnUS = map[string]string{
 	"scriptpubkeyresult-type":      "The type of the script (e.g. 'pubkeyhash')",
 	"scriptpubkeyresult-addresses": "The Decred addresses associated with this script",
 	"scriptpubkeyresult-commitamt": "The ticket commitment value if the script is for a staking commitment",
+	"scriptpubkeyresult-ssfee":     "The details of the fee distribution marker (only present if the script is a standard staker or miner fee distribution marker script)",
 	"scriptpubkeyresult-version":   "The script version",
 
 	// Vout help.
@@ -161,12 +182,105 @@ var helpDescsEnUS = map[string]string{
 	"decodescriptresult-type":      "The type of the script (e.g. 'pubkeyhash')",
 	"decodescriptresult-addresses": "The Decred addresses associated with this script",
 	"decodescriptresult-p2sh":      "The script hash for use in pay-to-script-hash transactions (only present if the provided redeem script is not already a pay-to-script-hash script)",
+	"decodescriptresult-htlc":      "The details of the hash-time-locked contract (only present if the script is a standard hash-time-locked contract script)",
+	"decodescriptresult-ssfee":     "The details of the fee distribution marker (only present if the script is a standard staker or miner fee distribution marker script)",
+	"decodescriptresult-emission":  "The details of the SKA emission authorization (only present if the script is a standard SKA emission authorization script)",
+
+	// DecodeScriptHTLCResult help.
+	"decodescripthtlcresult-secrethash": "The hex-encoded SHA-256 hash of the secret that allows the recipient to redeem the contract",
+	"decodescripthtlcresult-recipient":  "The address that may redeem the contract with the secret",
+	"decodescripthtlcresult-refund":     "The address that may redeem the contract once the lock time has been reached",
+	"decodescripthtlcresult-locktime":   "The lock time that must be reached before the refund is possible",
+	"decodescripthtlcresult-locktype":   "Whether the lock time is an absolute lock time or a relative lock time (sequence) (absolute or relative)",
+
+	// SSFeeMarkerResult help.
+	"ssfeemarkerresult-height":   "The height of the block that includes the fee distribution transaction",
+	"ssfeemarkerresult-voterseq": "The sequence of the voter the fee distribution pays (staker markers only)",
+
+	// SKAEmissionAuthResult help.
+	"skaemissionauthresult-cointype": "The SKA coin type being emitted",
+	"skaemissionauthresult-nonce":    "The emission nonce that provides replay protection",
+	"skaemissionauthresult-height":   "The height the emission was authorized for",
+	"skaemissionauthresult-amount":   "The total amount of the emission in atoms",
+	"skaemissionauthresult-pubkey":   "The hex-encoded emission public key",
 
 	// DecodeScriptCmd help.
 	"decodescript--synopsis": "Returns a JSON object with information about the provided hex-encoded script.",
 	"decodescript-hexscript": "Hex-encoded script",
 	"decodescript-version":   "The script version, defaults to version 0 if not set.",
 
+	// PSTPrevOutResult help.
+	"pstprevoutresult-value":        "The amount of the previous output in coins (VAR only)",
+	"pstprevoutresult-skavalue":     "The SKA amount of the previous output as a string (atoms) to preserve precision for large values",
+	"pstprevoutresult-cointype":     "The coin type of the previous output (0=VAR, 1-255=SKA)",
+	"pstprevoutresult-scriptPubKey": "The public key script of the previous output as a JSON object",
+
+	// PSTBip32DerivResult help.
+	"pstbip32derivresult-pubkey":            "The hex-encoded public key",
+	"pstbip32derivresult-masterfingerprint": "The hex-encoded fingerprint of the master key the path is relative to",
+	"pstbip32derivresult-path":              "The derivation path from the master key with hardened indices denoted by an apostrophe",
+
+	// PSTInputResult help.
+	"pstinputresult-prevout":            "The previous output spent by the input (only when known)",
+	"pstinputresult-redeemscript":       "The redeem script of a pay-to-script-hash previous output",
+	"pstinputresult-sighashtype":        "The signature hash type signers are requested to use",
+	"pstinputresult-partialsigs":        "The hex-encoded partial signatures keyed by the hex-encoded public key they are valid for",
+	"pstinputresult-partialsigs--desc":  "The partial signatures provided so far",
+	"pstinputresult-partialsigs--key":   "pubkey",
+	"pstinputresult-partialsigs--value": "The hex-encoded signature that is valid for the hex-encoded public key",
+	"pstinputresult-bip32derivs":        "The derivation paths of the public keys involved in spending the previous output",
+	"pstinputresult-finalscriptsig":     "The final signature script of the input (only when finalized)",
+
+	// PSTOutputResult help.
+	"pstoutputresult-redeemscript": "The redeem script of a pay-to-script-hash output",
+	"pstoutputresult-bip32derivs":  "The derivation paths of the public keys involved in the output",
+
+	// PSTFeeResult help.
+	"pstfeeresult-fee":      "The fee in coins (VAR only)",
+	"pstfeeresult-skafee":   "The SKA fee as a string (atoms) to preserve precision for large values",
+	"pstfeeresult-cointype": "The coin type of the fee (0=VAR, 1-255=SKA)",
+
+	// DecodePSTResult help.
+	"decodepstresult-tx":      "The decoded unsigned transaction",
+	"decodepstresult-inputs":  "The additional information for each transaction input",
+	"decodepstresult-outputs": "The additional information for each transaction output",
+	"decodepstresult-fees":    "The fee paid for each coin type (only when the previous outputs of all inputs are known)",
+
+	// DecodePSTCmd help.
+	"decodepst--synopsis": "Returns a JSON object representing the provided base64-encoded partially signed transaction.",
+	"decodepst-pst":       "The base64-encoded partially signed transaction",
+
+	// CombinePSTCmd help.
+	"combinepst--synopsis": "Combines multiple partially signed transactions for the same transaction into a single one.",
+	"combinepst-psts":      "The base64-encoded partially signed transactions to combine",
+	"combinepst--result0":  "The base64-encoded combined partially signed transaction",
+
+	// FinalizePSTResult help.
+	"finalizepstresult-pst":      "The base64-encoded updated partially signed transaction (only when not extracted)",
+	"finalizepstresult-hex":      "The serialized, hex-encoded signed transaction (only when extracted)",
+	"finalizepstresult-complete": "Whether or not every input is finalized",
+
+	// FinalizePSTCmd help.
+	"finalizepst--synopsis": "Creates the final signature script for every input of the provided partially signed transaction that has the required signatures.\n" +
+		"The signed transaction is extracted when every input is finalized and extraction is requested.\n" +
+		"Signatures are not verified.",
+	"finalizepst-pst":     "The base64-encoded partially signed transaction",
+	"finalizepst-extract": "Whether or not to extract the signed transaction when every input is finalized",
+
+	// AnalyzePSTInputResult help.
+	"analyzepstinputresult-hasprevout": "Whether or not the previous output spent by the input is known",
+	"analyzepstinputresult-isfinal":    "Whether or not the input is finalized",
+	"analyzepstinputresult-next":       "The role that must act on the input next (updater, signer, finalizer, or extractor)",
+
+	// AnalyzePSTResult help.
+	"analyzepstresult-inputs": "The analysis of each transaction input",
+	"analyzepstresult-fees":   "The fee paid for each coin type (only when the previous outputs of all inputs are known)",
+	"analyzepstresult-next":   "The role that must act on the partially signed transaction next (updater, signer, finalizer, or extractor)",
+
+	// AnalyzePSTCmd help.
+	"analyzepst--synopsis": "Analyzes the provided partially signed transaction and reports the next role required to complete it.",
+	"analyzepst-pst":       "The base64-encoded partially signed transaction",
+
 	// ExistsAddressCmd help.
 	"existsaddress--synopsis": "Test for the existence of the provided address",
 	"existsaddress-address":   "The address to check",
@@ -375,6 +489,43 @@ var helpDescsEnUS = map[string]string{
 	"getburnedcoinsstat-name":        "The name of the coin type (e.g., 'SKA-1', 'SKA-2')",
 	"getburnedcoinsstat-totalburned": "Total amount of coins burned",
 
+	// GetBurnProofCmd help.
+	"getburnproof--synopsis": "Returns the SKA burn outputs of a transaction along with a merkle inclusion proof that can be used to prove the transaction is committed to by the block header.",
+	"getburnproof-txhash":    "The hash of the burn transaction",
+	"getburnproof-blockhash": "The hash of the block that contains the transaction, which must be in the main chain",
+
+	// GetBurnProofResult help.
+	"getburnproofresult-hex":   "The hex-encoded serialized burn transaction",
+	"getburnproofresult-burns": "The SKA burn outputs of the transaction",
+	"getburnproofresult-proof": "The merkle inclusion proof of the transaction",
+
+	// BurnOutput help.
+	"burnoutput-vout":     "The index of the burn output",
+	"burnoutput-cointype": "The coin type number (1-255)",
+	"burnoutput-name":     "The name of the coin type (e.g., 'SKA-1', 'SKA-2')",
+	"burnoutput-amount":   "The amount of coins burned",
+	"burnoutput-atoms":    "The amount burned as a string (atoms) to preserve precision for large values",
+
+	// GetSKASupplyProofCmd help.
+	"getskasupplyproof--synopsis": "Returns the supply state of an SKA coin type as of the current best block along with the merkle proofs that can be used to prove the state is committed to by the header of the block.",
+	"getskasupplyproof-cointype":  "The SKA coin type to get the supply proof for (1-255)",
+
+	// GetSKASupplyProofResult help.
+	"getskasupplyproofresult-blockhash":       "The hash of the block whose header commits to the supply state",
+	"getskasupplyproofresult-height":          "The height of the block",
+	"getskasupplyproofresult-cointype":        "The coin type number (1-255)",
+	"getskasupplyproofresult-name":            "The name of the coin type (e.g., 'SKA-1', 'SKA-2')",
+	"getskasupplyproofresult-nonce":           "The last emission nonce used for the coin type",
+	"getskasupplyproofresult-emitted":         "The cumulative amount emitted as a string (atoms) to preserve precision for large values",
+	"getskasupplyproofresult-burned":          "The cumulative amount burned as a string (atoms) to preserve precision for large values",
+	"getskasupplyproofresult-circulating":     "The circulating amount (emitted less burned) as a string (atoms) to preserve precision for large values",
+	"getskasupplyproofresult-leafhash":        "The hash of the serialized supply state that forms the leaf of the SKA supply merkle tree",
+	"getskasupplyproofresult-leafindex":       "The index of the leaf in the SKA supply merkle tree",
+	"getskasupplyproofresult-leafproof":       "The hashes needed to prove the leaf is committed to by the SKA supply root",
+	"getskasupplyproofresult-supplyroot":      "The root of the SKA supply merkle tree",
+	"getskasupplyproofresult-commitmentindex": "The index of the leaf that represents the SKA supply root in the header commitment",
+	"getskasupplyproofresult-commitmentproof": "The hashes needed to prove the SKA supply root is committed to by the header commitment",
+
 	// GetCFilterV2Cmd help.
 	"getcfilterv2--synopsis": "Returns the version 2 block filter for the given block along with a proof that can be used to prove the filter is committed to by the block header",
 	"getcfilterv2-blockhash": "The block hash of the filter to retrieve",
@@ -530,6 +681,18 @@ var helpDescsEnUS = map[string]string{
 	"getemissionstatusresult-maxsupply":         "The maximum supply for this coin type in atoms",
 	"getemissionstatusresult-circulatingsupply": "The current circulating supply in atoms (max supply minus burned), 0 if not yet emitted",
 
+	// GetMempoolHistoryCmd help.
+	"getmempoolhistory--synopsis": "Returns the transactions that were recently rejected from or evicted out of the memory pool ordered from newest to oldest.",
+	"getmempoolhistory-txhash":    "Only return entries for the transaction with this hash",
+
+	// GetMempoolHistoryResult help.
+	"getmempoolhistoryresult-txhash":   "The hash of the rejected or evicted transaction",
+	"getmempoolhistoryresult-cointype": "The coin type of the transaction (0 for VAR, 1-255 for SKA variants)",
+	"getmempoolhistoryresult-reason":   "The reason the transaction was rejected or evicted (rejected, expired, doublespent, stakepruned, invalidated, parentevicted)",
+	"getmempoolhistoryresult-detail":   "Additional details about the reason such as the rule a rejected transaction violated",
+	"getmempoolhistoryresult-conflict": "The hash of the transaction that caused the eviction, if any",
+	"getmempoolhistoryresult-time":     "The time the transaction was rejected or evicted in seconds since 1 Jan 1970 GMT",
+
 	// GetMempoolInfoCmd help.
 	"getmempoolinfo--synopsis": "Returns memory pool information",
 
@@ -634,27 +797,80 @@ var helpDescsEnUS = map[string]string{
 	"getnettotalsresult-totalbytessent": "Total bytes sent",
 	"getnettotalsresult-timemillis":     "Number of milliseconds since 1 Jan 1970 GMT",
 
+	// GetNetworkStatsCmd help.
+	"getnetworkstats--synopsis": "Returns a JSON object containing detailed network traffic statistics including the bytes sent and received by message type and the state of the upload limits.",
+
+	// GetNetworkStatsResult help.
+	"getnetworkstatsresult-totalbytesrecv":           "Total bytes received",
+	"getnetworkstatsresult-totalbytessent":           "Total bytes sent",
+	"getnetworkstatsresult-timemillis":               "Number of milliseconds since 1 Jan 1970 GMT",
+	"getnetworkstatsresult-bytesrecv_per_msg":        "Total bytes received from all peers by message type",
+	"getnetworkstatsresult-bytesrecv_per_msg--desc":  "Bytes received by message type",
+	"getnetworkstatsresult-bytesrecv_per_msg--key":   "The message type",
+	"getnetworkstatsresult-bytesrecv_per_msg--value": "The total bytes received",
+	"getnetworkstatsresult-bytessent_per_msg":        "Total bytes sent to all peers by message type",
+	"getnetworkstatsresult-bytessent_per_msg--desc":  "Bytes sent by message type",
+	"getnetworkstatsresult-bytessent_per_msg--key":   "The message type",
+	"getnetworkstatsresult-bytessent_per_msg--value": "The total bytes sent",
+	"getnetworkstatsresult-maxuploadrate":            "The max combined rate of data sent to all peers that are not whitelisted in bytes per second (0 when unlimited)",
+	"getnetworkstatsresult-maxpeeruploadrate":        "The max rate of data sent to each peer that is not whitelisted in bytes per second (0 when unlimited)",
+	"getnetworkstatsresult-uploadtarget":             "The state of the max upload target",
+
+	// UploadTargetResult help.
+	"uploadtargetresult-timeframe":               "The duration of the upload target cycle in seconds",
+	"uploadtargetresult-target":                  "The max bytes sent to peers per cycle (0 when unlimited)",
+	"uploadtargetresult-target_reached":          "Whether or not the target has been reached for the current cycle",
+	"uploadtargetresult-serve_historical_blocks": "Whether or not historical blocks are currently served to peers that are not whitelisted",
+	"uploadtargetresult-bytes_left_in_cycle":     "The bytes that may still be sent during the current cycle before the target is reached (0 when unlimited)",
+	"uploadtargetresult-time_left_in_cycle":      "The number of seconds until the current cycle ends",
+
+	// GetNodeAddressesCmd help.
+	"getnodeaddresses--synopsis": "Returns a randomized subset of the known good addresses that can be used to find new peers in the network.",
+	"getnodeaddresses-count":     "The maximum number of addresses to return or 0 to return all addresses that are eligible to be shared",
+
+	// GetNodeAddressesResult help.
+	"getnodeaddressesresult-time":     "The time the address was last seen in seconds since 1 Jan 1970 GMT",
+	"getnodeaddressesresult-services": "Services bitmask which represents the services supported by the node",
+	"getnodeaddressesresult-address":  "The IP address of the node",
+	"getnodeaddressesresult-port":     "The port of the node",
+	"getnodeaddressesresult-mappedas": "The autonomous system number the address is mapped to by the IP-to-ASN map in use (omitted when there is no map or the address is not mapped)",
+
 	// GetPeerInfoResult help.
-	"getpeerinforesult-id":             "A unique node ID",
-	"getpeerinforesult-addr":           "The ip address and port of the peer",
-	"getpeerinforesult-addrlocal":      "Local address",
-	"getpeerinforesult-services":       "Services bitmask which represents the services supported by the peer",
-	"getpeerinforesult-relaytxes":      "Peer has requested transactions be relayed to it",
-	"getpeerinforesult-lastsend":       "Time the last message was received in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-lastrecv":       "Time the last message was sent in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-bytessent":      "Total bytes sent",
-	"getpeerinforesult-bytesrecv":      "Total bytes received",
-	"getpeerinforesult-conntime":       "Time the connection was made in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-timeoffset":     "The time offset of the peer",
-	"getpeerinforesult-pingtime":       "Number of microseconds the last ping took",
-	"getpeerinforesult-pingwait":       "Number of microseconds a queued ping has been waiting for a response",
-	"getpeerinforesult-version":        "The protocol version of the peer",
-	"getpeerinforesult-subver":         "The user agent of the peer",
-	"getpeerinforesult-inbound":        "Whether or not the peer is an inbound connection",
-	"getpeerinforesult-startingheight": "The latest block height the peer knew about when the connection was established",
-	"getpeerinforesult-currentheight":  "The current height of the peer",
-	"getpeerinforesult-banscore":       "The ban score",
-	"getpeerinforesult-syncnode":       "Whether or not the peer is the sync peer",
+	"getpeerinforesult-id":                       "A unique node ID",
+	"getpeerinforesult-addr":                     "The ip address and port of the peer",
+	"getpeerinforesult-addrlocal":                "Local address",
+	"getpeerinforesult-services":                 "Services bitmask which represents the services supported by the peer",
+	"getpeerinforesult-relaytxes":                "Peer has requested transactions be relayed to it",
+	"getpeerinforesult-lastsend":                 "Time the last message was received in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-lastrecv":                 "Time the last message was sent in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-bytessent":                "Total bytes sent",
+	"getpeerinforesult-bytesrecv":                "Total bytes received",
+	"getpeerinforesult-bytessent_per_msg":        "Total bytes sent by message type",
+	"getpeerinforesult-bytessent_per_msg--desc":  "Bytes sent by message type",
+	"getpeerinforesult-bytessent_per_msg--key":   "The message type",
+	"getpeerinforesult-bytessent_per_msg--value": "The total bytes sent",
+	"getpeerinforesult-bytesrecv_per_msg":        "Total bytes received by message type",
+	"getpeerinforesult-bytesrecv_per_msg--desc":  "Bytes received by message type",
+	"getpeerinforesult-bytesrecv_per_msg--key":   "The message type",
+	"getpeerinforesult-bytesrecv_per_msg--value": "The total bytes received",
+	"getpeerinforesult-conntime":                 "Time the connection was made in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-timeoffset":               "The time offset of the peer",
+	"getpeerinforesult-pingtime":                 "Number of microseconds the last ping took",
+	"getpeerinforesult-pingwait":                 "Number of microseconds a queued ping has been waiting for a response",
+	"getpeerinforesult-version":                  "The protocol version of the peer",
+	"getpeerinforesult-subver":                   "The user agent of the peer",
+	"getpeerinforesult-inbound":                  "Whether or not the peer is an inbound connection",
+	"getpeerinforesult-transport":                "The transport used to communicate with the peer (v1 for plaintext or v2 for encrypted)",
+	"getpeerinforesult-mappedas":                 "The autonomous system number the address of the peer is mapped to by the IP-to-ASN map in use (omitted when there is no map or the address is not mapped)",
+	"getpeerinforesult-startingheight":           "The latest block height the peer knew about when the connection was established",
+	"getpeerinforesult-currentheight":            "The current height of the peer",
+	"getpeerinforesult-banscore":                 "The ban score",
+	"getpeerinforesult-syncnode":                 "Whether or not the peer is the sync peer",
+	"getpeerinforesult-blocksinflight":           "The number of blocks currently requested from the peer",
+	"getpeerinforesult-blocksrecv":               "The total number of requested blocks the peer delivered",
+	"getpeerinforesult-blockstalls":              "The total number of times block requests to the peer stalled and were requested from other peers instead",
+	"getpeerinforesult-blockwindow":              "The maximum number of blocks that may currently be requested from the peer at once based on how well it performs",
+	"getpeerinforesult-blockresptime":            "The average number of microseconds the peer takes to deliver requested blocks",
 
 	// GetPeerInfoCmd help.
 	"getpeerinfo--synopsis": "Returns data about each connected network peer as an array of json objects.",
@@ -726,9 +942,28 @@ var helpDescsEnUS = map[string]string{
 	"gettxoutresult-bestblock":     "The block hash that contains the transaction output",
 	"gettxoutresult-confirmations": "The number of confirmations",
 	"gettxoutresult-value":         "The transaction amount in VAR",
+	"gettxoutresult-skavalue":      "The SKA amount as a string (atoms) to preserve precision for large values",
+	"gettxoutresult-cointype":      "The coin type (0=VAR, 1-255=SKA)",
 	"gettxoutresult-scriptPubKey":  "The public key script used to pay coins as a JSON object",
 	"gettxoutresult-coinbase":      "Whether or not the transaction is a coinbase",
 
+	// GetTxInclusionProofCmd help.
+	"gettxinclusionproof--synopsis": "Returns a merkle inclusion proof that can be used to prove a transaction is committed to by the header of the block that contains it.",
+	"gettxinclusionproof-txhash":    "The hash of the transaction",
+	"gettxinclusionproof-blockhash": "The hash of the block that contains the transaction",
+
+	// GetTxInclusionProofResult help.
+	"gettxinclusionproofresult-blockhash":       "The hash of the block that contains the transaction",
+	"gettxinclusionproofresult-height":          "The height of the block that contains the transaction",
+	"gettxinclusionproofresult-confirmations":   "The number of confirmations of the block that contains the transaction or -1 if it is not in the main chain",
+	"gettxinclusionproofresult-header":          "The hex-encoded serialized block header",
+	"gettxinclusionproofresult-tree":            "The tree of the transaction (0 = regular, 1 = stake)",
+	"gettxinclusionproofresult-index":           "The index of the transaction within its tree",
+	"gettxinclusionproofresult-leafhash":        "The full hash of the transaction including its witness data, which is the leaf of the merkle tree",
+	"gettxinclusionproofresult-treeroot":        "The merkle root of the transaction tree that contains the transaction",
+	"gettxinclusionproofresult-proof":           "The sibling hashes along the path from the leaf to the tree merkle root",
+	"gettxinclusionproofresult-siblingtreeroot": "The merkle root of the other transaction tree when the header merkle root commits to the combined root of both trees as defined by DCP0005",
+
 	// GetTxOutCmd help.
 	"gettxout--synopsis":      "Returns information about an unspent transaction output.",
 	"gettxout-txid":           "The hash of the transaction",
@@ -822,9 +1057,16 @@ var helpDescsEnUS = map[string]string{
 	"submitblock--condition1": "Block rejected",
 	"submitblock--result1":    "The reason the block was rejected",
 
+	// SubmitPackageCmd help.
+	"submitpackage--synopsis":     "Submits a package of serialized, hex-encoded transactions to the local peer and relays them to the network.\nThe package must be sorted such that parents come before their children and every transaction except the last must be an ancestor of the last one.\nThe fee requirements are checked against the combined fees of the new transactions in the package per coin type, which allows a child to pay for parents that do not meet them on their own.",
+	"submitpackage-rawtxs":        "Serialized, hex-encoded signed transactions in the package",
+	"submitpackage-allowhighfees": "Whether or not to allow insanely high fees",
+	"submitpackage--result0":      "The hashes of all transactions accepted to the memory pool as a result",
+
 	// ValidateAddressResult help.
-	"validateaddresschainresult-isvalid": "Whether or not the address is valid",
-	"validateaddresschainresult-address": "The Decred address (only when isvalid is true)",
+	"validateaddresschainresult-isvalid":  "Whether or not the address is valid",
+	"validateaddresschainresult-address":  "The Decred address (only when isvalid is true)",
+	"validateaddresschainresult-cointype": "The coin type the address commits to (only for coin-typed addresses)",
 
 	// ValidateAddressCmd help.
 	"validateaddress--synopsis": "Verify an address is valid.",
@@ -847,6 +1089,19 @@ var helpDescsEnUS = map[string]string{
 	"verifymessage-message":   "The signed message",
 	"verifymessage--result0":  "Whether or not the signature verified",
 
+	// VerifyPartialSigCmd help.
+	"verifypartialsig--synopsis":    "Verify a partial signature produced by one participant of a multi-party (MuSig2) Schnorr signing session.",
+	"verifypartialsig-pubkeys":      "The hex-encoded compressed public keys of all participants",
+	"verifypartialsig-pubnonces":    "The hex-encoded public nonces of all participants in the same order as pubkeys",
+	"verifypartialsig-signerpubkey": "The hex-encoded compressed public key of the participant that produced the partial signature",
+	"verifypartialsig-message":      "The hex-encoded 32-byte message hash being signed",
+	"verifypartialsig-partialsig":   "The hex-encoded partial signature to verify",
+
+	// VerifyPartialSigResult help.
+	"verifypartialsigresult-aggregatekey":   "The hex-encoded aggregate public key of all participants",
+	"verifypartialsigresult-aggregatenonce": "The hex-encoded aggregate public nonce of all participants",
+	"verifypartialsigresult-valid":          "Whether or not the partial signature verified",
+
 	// -------- Websocket-specific help --------
 
 	// Session help.
@@ -874,6 +1129,12 @@ var helpDescsEnUS = map[string]string{
 	// NotifyTSpendCmd help.
 	"notifytspend--synopsis": "Request notifications for whenever a new tspend arrives in the mempool.",
 
+	// NotifyMempoolHistoryCmd help.
+	"notifymempoolhistory--synopsis": "Request notifications for whenever a transaction is rejected from or evicted out of the mempool.",
+
+	// StopNotifyMempoolHistoryCmd help.
+	"stopnotifymempoolhistory--synopsis": "Cancel registered notifications for whenever a transaction is rejected from or evicted out of the mempool.",
+
 	// StopNotifyTSpendCmd help.
 	"stopnotifytspend--synopsis": "Cancel registered notifications for whenever a new tspend arrives in the mempool.",
 
@@ -958,6 +1219,18 @@ var helpDescsEnUS = map[string]string{
 	"livetickets--synopsis":     "Returns live ticket hashes from the ticket database",
 	"liveticketsresult-tickets": "List of live tickets",
 
+	// DumpTxOutSet help.
+	"dumptxoutset--synopsis": "Writes a snapshot of the unspent transaction output set along with the stake and SKA state required to continue validating blocks as of the current best block to a file.  Snapshots may be verified offline, but starting a node from a snapshot is not supported.",
+	"dumptxoutset-path":      "The path of the file to write the snapshot to.  Existing files are not overwritten.",
+
+	// TxOutSetSnapshotResult help.
+	"txoutsetsnapshotresult-height":    "The height of the block the snapshot was taken at",
+	"txoutsetsnapshotresult-hash":      "The hash of the block the snapshot was taken at",
+	"txoutsetsnapshotresult-utxos":     "The number of unspent transaction outputs in the snapshot",
+	"txoutsetsnapshotresult-utxohash":  "The merklized hash of the unspent transaction outputs in the snapshot, including their outpoints, coin types, and amounts",
+	"txoutsetsnapshotresult-statehash": "The hash of the treasury, ticket, and SKA emission and burn state in the snapshot",
+	"txoutsetsnapshotresult-path":      "The absolute path of the snapshot file",
+
 	// TicketBuckets help.
 	"ticketbuckets--synopsis": "Request for the number of tickets currently in each bucket of the ticket database.",
 	"ticketbucket-tickets":    "Number of tickets in bucket.",
@@ -1044,12 +1317,17 @@ var helpDescsEnUS = map[string]string{
 // pointer to the type (or nil to indicate no return value).
 var rpcResultTypes = map[types.Method][]interface{}{
 	"addnode":                  nil,
+	"analyzepst":               {(*types.AnalyzePSTResult)(nil)},
+	"clearbanned":              nil,
+	"combinepst":               {(*string)(nil)},
 	"createrawssrtx":           {(*string)(nil)},
 	"createrawsstx":            {(*string)(nil)},
 	"createrawtransaction":     {(*string)(nil)},
 	"debuglevel":               {(*string)(nil), (*string)(nil)},
+	"decodepst":                {(*types.DecodePSTResult)(nil)},
 	"decoderawtransaction":     {(*types.TxRawDecodeResult)(nil)},
 	"decodescript":             {(*types.DecodeScriptResult)(nil)},
+	"dumptxoutset":             {(*types.TxOutSetSnapshotResult)(nil)},
 	"estimatefee":              {(*float64)(nil)},
 	"estimatesmartfee":         {(*types.EstimateSmartFeeResult)(nil)},
 	"estimatestakediff":        {(*types.EstimateStakeDiffResult)(nil)},
@@ -1058,6 +1336,7 @@ var rpcResultTypes = map[types.Method][]interface{}{
 	"existsliveticket":         {(*bool)(nil)},
 	"existslivetickets":        {(*string)(nil)},
 	"existsmempooltxs":         {(*string)(nil)},
+	"finalizepst":              {(*types.FinalizePSTResult)(nil)},
 	"generate":                 {(*[]string)(nil)},
 	"getaddednodeinfo":         {(*[]string)(nil), (*[]types.GetAddedNodeInfoResult)(nil)},
 	"getbestblock":             {(*types.GetBestBlockResult)(nil)},
@@ -1069,6 +1348,7 @@ var rpcResultTypes = map[types.Method][]interface{}{
 	"getblockheader":           {(*string)(nil), (*types.GetBlockHeaderVerboseResult)(nil)},
 	"getblocksubsidy":          {(*types.GetBlockSubsidyResult)(nil)},
 	"getburnedcoins":           {(*types.GetBurnedCoinsResult)(nil)},
+	"getburnproof":             {(*types.GetBurnProofResult)(nil)},
 	"getcfilterv2":             {(*types.GetCFilterV2Result)(nil)},
 	"getchaintips":             {(*[]types.GetChainTipsResult)(nil)},
 	"getcoinsupply":            {(*int64)(nil)},
@@ -1081,13 +1361,17 @@ var rpcResultTypes = map[types.Method][]interface{}{
 	"getheaders":               {(*types.GetHeadersResult)(nil)},
 	"getinfo":                  {(*types.InfoChainResult)(nil)},
 	"getskainfo":               {(*[]types.GetSKAInfoResult)(nil)},
+	"getskasupplyproof":        {(*types.GetSKASupplyProofResult)(nil)},
 	"getemissionstatus":        {(*types.GetEmissionStatusResult)(nil)},
+	"getmempoolhistory":        {(*[]types.GetMempoolHistoryResult)(nil)},
 	"getmempoolinfo":           {(*types.GetMempoolInfoResult)(nil)},
 	"getmempoolfeesinfo":       {(*types.GetMempoolFeesInfoResult)(nil)},
 	"getmininginfo":            {(*types.GetMiningInfoResult)(nil)},
 	"getmixmessage":            {(*types.GetMixMessageResult)(nil)},
 	"getmixpairrequests":       {(*[]string)(nil)},
 	"getnettotals":             {(*types.GetNetTotalsResult)(nil)},
+	"getnetworkstats":          {(*types.GetNetworkStatsResult)(nil)},
+	"getnodeaddresses":         {(*[]types.GetNodeAddressesResult)(nil)},
 	"getnetworkhashps":         {(*int64)(nil)},
 	"getnetworkinfo":           {(*[]types.GetNetworkInfoResult)(nil)},
 	"getpeerinfo":              {(*[]types.GetPeerInfoResult)(nil)},
@@ -1099,12 +1383,14 @@ var rpcResultTypes = map[types.Method][]interface{}{
 	"getticketpoolvalue":       {(*float64)(nil)},
 	"gettreasurybalance":       {(*types.GetTreasuryBalanceResult)(nil)},
 	"gettreasuryspendvotes":    {(*types.GetTreasurySpendVotesResult)(nil)},
+	"gettxinclusionproof":      {(*types.GetTxInclusionProofResult)(nil)},
 	"gettxout":                 {(*types.GetTxOutResult)(nil)},
 	"gettxoutsetinfo":          {(*types.GetTxOutSetInfoResult)(nil)},
 	"getvoteinfo":              {(*types.GetVoteInfoResult)(nil)},
 	"getwork":                  {(*types.GetWorkResult)(nil), (*bool)(nil)},
 	"help":                     {(*string)(nil), (*string)(nil)},
 	"invalidateblock":          nil,
+	"listbanned":               {(*[]types.ListBannedResult)(nil)},
 	"livetickets":              {(*types.LiveTicketsResult)(nil)},
 	"node":                     nil,
 	"ping":                     nil,
@@ -1112,11 +1398,13 @@ var rpcResultTypes = map[types.Method][]interface{}{
 	"regentemplate":            nil,
 	"sendrawmixmessage":        nil,
 	"sendrawtransaction":       {(*string)(nil)},
+	"setban":                   nil,
 	"setgenerate":              nil,
 	"startprofiler":            {(*types.StartProfilerResult)(nil)},
 	"stop":                     {(*string)(nil)},
 	"stopprofiler":             {(*string)(nil)},
 	"submitblock":              {nil, (*string)(nil)},
+	"submitpackage":            {(*[]string)(nil)},
 	"ticketfeeinfo":            {(*types.TicketFeeInfoResult)(nil)},
 	"ticketsforaddress":        {(*types.TicketsForAddressResult)(nil)},
 	"ticketvwap":               {(*float64)(nil)},
@@ -1124,11 +1412,13 @@ var rpcResultTypes = map[types.Method][]interface{}{
 	"validateaddress":          {(*types.ValidateAddressChainResult)(nil)},
 	"verifychain":              {(*bool)(nil)},
 	"verifymessage":            {(*bool)(nil)},
+	"verifypartialsig":         {(*types.VerifyPartialSigResult)(nil)},
 	"version":                  {(*map[string]types.VersionResult)(nil)},
 
 	// Websocket commands.
 	"loadtxfilter":              nil,
 	"notifyblocks":              nil,
+	"notifymempoolhistory":      nil,
 	"notifymixmessages":         nil,
 	"notifynewtickets":          nil,
 	"notifynewtransactions":     nil,
@@ -1139,6 +1429,7 @@ var rpcResultTypes = map[types.Method][]interface{}{
 	"rescan":                    {(*types.RescanResult)(nil)},
 	"session":                   {(*types.SessionResult)(nil)},
 	"stopnotifyblocks":          nil,
+	"stopnotifymempoolhistory":  nil,
 	"stopnotifymixmessages":     nil,
 	"stopnotifynewtransactions": nil,
 	"stopnotifytspend":          nil,
@@ -28,6 +28,7 @@ import (
 	"github.com/monetarium/monetarium-node/dcrjson"
 	"github.com/monetarium/monetarium-node/dcrutil"
 	"github.com/monetarium/monetarium-node/internal/blockchain"
+	"github.com/monetarium/monetarium-node/internal/mempool"
 	"github.com/monetarium/monetarium-node/internal/mining"
 	"github.com/monetarium/monetarium-node/mixing"
 	"github.com/monetarium/monetarium-node/rpc/jsonrpc/types"
@@ -84,6 +85,7 @@ var wsHandlersBeforeInit = map[types.Method]wsCommandHandler{
 	"notifyblocks":              handleNotifyBlocks,
 	"notifywork":                handleNotifyWork,
 	"notifytspend":              handleNotifyTSpend,
+	"notifymempoolhistory":      handleNotifyMempoolHistory,
 	"notifywinningtickets":      handleWinningTickets,
 	"notifynewtickets":          handleNewTickets,
 	"notifynewtransactions":     handleNotifyNewTransactions,
@@ -94,6 +96,7 @@ var wsHandlersBeforeInit = map[types.Method]wsCommandHandler{
 	"stopnotifyblocks":          handleStopNotifyBlocks,
 	"stopnotifywork":            handleStopNotifyWork,
 	"stopnotifytspend":          handleStopNotifyTSpend,
+	"stopnotifymempoolhistory":  handleStopNotifyMempoolHistory,
 	"stopnotifynewtransactions": handleStopNotifyNewTransactions,
 	"stopnotifymixmessages":     handleStopNotifyMixMessages,
 }
@@ -238,6 +241,15 @@ func (m *wsNotificationManager) NotifyTSpend(tx *dcrutil.Tx) {
 	}
 }
 
+// NotifyMempoolHistory passes a transaction that was rejected from or evicted
+// out of the mempool to the notification manager for processing.
+func (m *wsNotificationManager) NotifyMempoolHistory(entry *mempool.TxHistoryEntry) {
+	select {
+	case m.queueNotification <- (*notificationMempoolHistory)(entry):
+	case <-m.quit:
+	}
+}
+
 // NotifyReorganization passes a blockchain reorganization notification for
 // reorganization notification processing.
 func (m *wsNotificationManager) NotifyReorganization(rd *blockchain.ReorganizationNtfnsData) {
@@ -413,6 +425,7 @@ type notificationBlockConnected dcrutil.Block
 type notificationBlockDisconnected dcrutil.Block
 type notificationWork mining.TemplateNtfn
 type notificationTSpend dcrutil.Tx
+type notificationMempoolHistory mempool.TxHistoryEntry
 type notificationReorganization blockchain.ReorganizationNtfnsData
 type notificationWinningTickets WinningTicketsNtfnData
 type notificationNewTickets blockchain.TicketNotificationsData
@@ -431,6 +444,8 @@ type notificationRegisterWork wsClient
 type notificationUnregisterWork wsClient
 type notificationRegisterTSpend wsClient
 type notificationUnregisterTSpend wsClient
+type notificationRegisterMempoolHistory wsClient
+type notificationUnregisterMempoolHistory wsClient
 type notificationRegisterWinningTickets wsClient
 type notificationUnregisterWinningTickets wsClient
 type notificationRegisterNewTickets wsClient
@@ -456,6 +471,7 @@ func (m *wsNotificationManager) notificationHandler(ctx context.Context) {
 	blockNotifications := make(map[chan struct{}]*wsClient)
 	workNotifications := make(map[chan struct{}]*wsClient)
 	tspendNotifications := make(map[chan struct{}]*wsClient)
+	historyNotifications := make(map[chan struct{}]*wsClient)
 	winningTicketNotifications := make(map[chan struct{}]*wsClient)
 	ticketNewNotifications := make(map[chan struct{}]*wsClient)
 	txNotifications := make(map[chan struct{}]*wsClient)
@@ -487,6 +503,10 @@ out:
 			case *notificationTSpend:
 				m.notifyTSpend(tspendNotifications, (*dcrutil.Tx)(n))
 
+			case *notificationMempoolHistory:
+				m.notifyMempoolHistory(historyNotifications,
+					(*mempool.TxHistoryEntry)(n))
+
 			case *notificationReorganization:
 				m.notifyReorganization(blockNotifications,
 					(*blockchain.ReorganizationNtfnsData)(n))
@@ -540,6 +560,14 @@ out:
 				wsc := (*wsClient)(n)
 				delete(tspendNotifications, wsc.quit)
 
+			case *notificationRegisterMempoolHistory:
+				wsc := (*wsClient)(n)
+				historyNotifications[wsc.quit] = wsc
+
+			case *notificationUnregisterMempoolHistory:
+				wsc := (*wsClient)(n)
+				delete(historyNotifications, wsc.quit)
+
 			case *notificationRegisterWinningTickets:
 				wsc := (*wsClient)(n)
 				winningTicketNotifications[wsc.quit] = wsc
@@ -567,6 +595,7 @@ out:
 				delete(blockNotifications, wsc.quit)
 				delete(workNotifications, wsc.quit)
 				delete(tspendNotifications, wsc.quit)
+				delete(historyNotifications, wsc.quit)
 				delete(txNotifications, wsc.quit)
 				delete(winningTicketNotifications, wsc.quit)
 				delete(ticketNewNotifications, wsc.quit)
@@ -657,6 +686,25 @@ func (m *wsNotificationManager) UnregisterTSpendUpdates(wsc *wsClient) {
 	}
 }
 
+// RegisterMempoolHistoryUpdates requests notifications to the passed websocket
+// client when transactions are rejected from or evicted out of the mempool.
+func (m *wsNotificationManager) RegisterMempoolHistoryUpdates(wsc *wsClient) {
+	select {
+	case m.queueNotification <- (*notificationRegisterMempoolHistory)(wsc):
+	case <-m.quit:
+	}
+}
+
+// UnregisterMempoolHistoryUpdates removes notifications to the passed
+// websocket client when transactions are rejected from or evicted out of the
+// mempool.
+func (m *wsNotificationManager) UnregisterMempoolHistoryUpdates(wsc *wsClient) {
+	select {
+	case m.queueNotification <- (*notificationUnregisterMempoolHistory)(wsc):
+	case <-m.quit:
+	}
+}
+
 // subscribedClients returns the set of all websocket client quit channels that
 // are registered to receive notifications regarding tx, either due to tx
 // spending a watched output or outputting to a watched address.  Matching
@@ -941,6 +989,30 @@ func (m *wsNotificationManager) notifyTSpend(clients map[chan struct{}]*wsClient
 	}
 }
 
+// notifyMempoolHistory notifies websocket clients that have registered for
+// mempool history updates about a transaction that was rejected from or
+// evicted out of the mempool.
+func (m *wsNotificationManager) notifyMempoolHistory(clients map[chan struct{}]*wsClient,
+	entry *mempool.TxHistoryEntry) {
+	// Skip notification creation if no clients have requested mempool
+	// history notifications.
+	if len(clients) == 0 {
+		return
+	}
+
+	ntfn := types.NewMempoolHistoryNtfn(marshalMempoolHistoryEntry(entry))
+	marshalledJSON, err := dcrjson.MarshalCmd("1.0", nil, ntfn)
+	if err != nil {
+		log.Errorf("Failed to marshal mempool history notification: %v",
+			err)
+		return
+	}
+
+	for _, wsc := range clients {
+		wsc.QueueNotification(marshalledJSON)
+	}
+}
+
 // notifyReorganization notifies websocket clients that have registered for
 // block updates when the blockchain is beginning a reorganization.
 func (m *wsNotificationManager) notifyReorganization(clients map[chan struct{}]*wsClient, rd *blockchain.ReorganizationNtfnsData) {
@@ -2188,6 +2260,13 @@ func handleNotifyTSpend(_ context.Context, wsc *wsClient, _ interface{}) (interf
 	return nil, nil
 }
 
+// handleNotifyMempoolHistory implements the notifymempoolhistory command
+// extension for websocket connections.
+func handleNotifyMempoolHistory(_ context.Context, wsc *wsClient, _ interface{}) (interface{}, error) {
+	wsc.rpcServer.ntfnMgr.RegisterMempoolHistoryUpdates(wsc)
+	return nil, nil
+}
+
 // handleSession implements the session command extension for websocket
 // connections.
 func handleSession(_ context.Context, wsc *wsClient, icmd interface{}) (interface{}, error) {
@@ -2229,6 +2308,13 @@ func handleStopNotifyTSpend(_ context.Context, wsc *wsClient, _ interface{}) (in
 	return nil, nil
 }
 
+// handleStopNotifyMempoolHistory implements the stopnotifymempoolhistory
+// command extension for websocket connections.
+func handleStopNotifyMempoolHistory(_ context.Context, wsc *wsClient, _ interface{}) (interface{}, error) {
+	wsc.rpcServer.ntfnMgr.UnregisterMempoolHistoryUpdates(wsc)
+	return nil, nil
+}
+
 // handleNotifyNewTransations implements the notifynewtransactions command
 // extension for websocket connections.
 func handleNotifyNewTransactions(_ context.Context, wsc *wsClient, icmd interface{}) (interface{}, error) {
@@ -0,0 +1,343 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package banmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// banListVersion is the current version of the serialized ban list.
+const banListVersion = 1
+
+// BanEntry describes a banned subnet along with when the ban was created, when
+// it expires, and the reason for it.
+type BanEntry struct {
+	Subnet  netip.Prefix
+	Created time.Time
+	Expires time.Time
+	Reason  string
+}
+
+// serializedBanEntry is the serialized form of a ban entry.
+type serializedBanEntry struct {
+	Subnet  string `json:"subnet"`
+	Created int64  `json:"created"`
+	Expires int64  `json:"expires"`
+	Reason  string `json:"reason"`
+}
+
+// serializedBanList is the serialized form of a ban list.
+type serializedBanList struct {
+	Version int                   `json:"version"`
+	Bans    []*serializedBanEntry `json:"bans"`
+}
+
+// BanList houses a list of banned subnets that is optionally persisted to a
+// file so bans survive restarts.  Individual addresses are represented by
+// subnets that only contain the address.
+//
+// All methods are safe for concurrent access.
+type BanList struct {
+	mtx     sync.Mutex
+	path    string
+	entries map[netip.Prefix]*BanEntry
+
+	// timeNow defines the function to use to get the current local time.  It
+	// defaults to time.Now but an alternative function can be provided for
+	// testing purposes.
+	timeNow func() time.Time
+}
+
+// NewBanList returns a new empty ban list that is persisted to the provided
+// file path.  The bans are only kept in memory when the path is empty.
+//
+// Callers should invoke Load to restore any bans previously saved to the file.
+func NewBanList(path string) *BanList {
+	return &BanList{
+		path:    path,
+		entries: make(map[netip.Prefix]*BanEntry),
+		timeNow: time.Now,
+	}
+}
+
+// ParseSubnet parses the provided string as either an individual IP address or
+// a subnet in CIDR notation and returns the resulting subnet.  Individual
+// addresses result in a subnet that only contains the address and IPv4-mapped
+// IPv6 addresses are converted to IPv4.
+func ParseSubnet(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		subnet, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		addr := subnet.Addr()
+		bits := subnet.Bits()
+		if addr.Is4In6() {
+			addr = addr.Unmap()
+			bits -= 96
+			if bits < 0 {
+				return netip.Prefix{}, fmt.Errorf("subnet %q is not a "+
+					"valid IPv4-mapped subnet", s)
+			}
+		}
+		return netip.PrefixFrom(addr, bits).Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return hostSubnet(addr), nil
+}
+
+// hostSubnet returns the subnet that only contains the provided address.
+func hostSubnet(addr netip.Addr) netip.Prefix {
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen())
+}
+
+// isExpired returns whether or not the entry is expired as of the provided
+// time.
+func (e *BanEntry) isExpired(now time.Time) bool {
+	return !now.Before(e.Expires)
+}
+
+// removeExpired removes all expired entries from the ban list and returns
+// whether or not any were removed.
+//
+// This function MUST be called with the ban list mutex held (for writes).
+func (bl *BanList) removeExpired() bool {
+	now := bl.timeNow()
+	var removed bool
+	for subnet, entry := range bl.entries {
+		if entry.isExpired(now) {
+			delete(bl.entries, subnet)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// save writes the ban list to its file.  It has no effect when the ban list is
+// not persisted.
+//
+// This function MUST be called with the ban list mutex held (for reads).
+func (bl *BanList) save() error {
+	if bl.path == "" {
+		return nil
+	}
+
+	sbl := serializedBanList{
+		Version: banListVersion,
+		Bans:    make([]*serializedBanEntry, 0, len(bl.entries)),
+	}
+	for _, entry := range bl.sortedEntries() {
+		sbl.Bans = append(sbl.Bans, &serializedBanEntry{
+			Subnet:  entry.Subnet.String(),
+			Created: entry.Created.Unix(),
+			Expires: entry.Expires.Unix(),
+			Reason:  entry.Reason,
+		})
+	}
+
+	// Write temporary ban list file and then move it into place.
+	tmpfile := bl.path + ".new"
+	w, err := os.Create(tmpfile)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(&sbl); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to encode file %s: %w", tmpfile, err)
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpfile, bl.path)
+}
+
+// Load restores the bans previously saved to the ban list file.  Expired bans
+// are discarded.  It is not an error if the file does not exist.
+func (bl *BanList) Load() error {
+	if bl.path == "" {
+		return nil
+	}
+
+	f, err := os.Open(bl.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	var sbl serializedBanList
+	if err := json.NewDecoder(f).Decode(&sbl); err != nil {
+		return fmt.Errorf("failed to decode file %s: %w", bl.path, err)
+	}
+	if sbl.Version != banListVersion {
+		return fmt.Errorf("unknown version %d in serialized ban list %s",
+			sbl.Version, bl.path)
+	}
+
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	for _, sbe := range sbl.Bans {
+		subnet, err := ParseSubnet(sbe.Subnet)
+		if err != nil {
+			return fmt.Errorf("invalid subnet %q in serialized ban list %s: "+
+				"%w", sbe.Subnet, bl.path, err)
+		}
+		bl.entries[subnet] = &BanEntry{
+			Subnet:  subnet,
+			Created: time.Unix(sbe.Created, 0),
+			Expires: time.Unix(sbe.Expires, 0),
+			Reason:  sbe.Reason,
+		}
+	}
+	bl.removeExpired()
+	return nil
+}
+
+// Ban adds the provided subnet to the ban list for the provided duration along
+// with the reason for the ban and saves the ban list.  Banning a subnet that
+// is already banned replaces the existing ban.
+func (bl *BanList) Ban(subnet netip.Prefix, duration time.Duration, reason string) error {
+	if !subnet.IsValid() {
+		return fmt.Errorf("invalid subnet %v", subnet)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("invalid ban duration %v", duration)
+	}
+
+	subnet = subnet.Masked()
+	now := bl.timeNow()
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	bl.entries[subnet] = &BanEntry{
+		Subnet:  subnet,
+		Created: now,
+		Expires: now.Add(duration),
+		Reason:  reason,
+	}
+	bl.removeExpired()
+	return bl.save()
+}
+
+// BanHost adds the provided host to the ban list for the provided duration
+// along with the reason for the ban and saves the ban list.
+func (bl *BanList) BanHost(host string, duration time.Duration, reason string) error {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	return bl.Ban(hostSubnet(addr), duration, reason)
+}
+
+// Unban removes the provided subnet from the ban list and saves the ban list.
+// It returns false when the subnet is not banned.
+func (bl *BanList) Unban(subnet netip.Prefix) (bool, error) {
+	subnet = subnet.Masked()
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	entry, ok := bl.entries[subnet]
+	if !ok || entry.isExpired(bl.timeNow()) {
+		return false, nil
+	}
+	delete(bl.entries, subnet)
+	bl.removeExpired()
+	return true, bl.save()
+}
+
+// Clear removes all entries from the ban list and saves the ban list.
+func (bl *BanList) Clear() error {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	bl.entries = make(map[netip.Prefix]*BanEntry)
+	return bl.save()
+}
+
+// IsBanned returns the ban entry for the banned subnet that contains the
+// provided address, if any, and whether or not the address is banned.  When
+// the address is contained in multiple banned subnets, the entry that expires
+// last is returned.
+func (bl *BanList) IsBanned(addr netip.Addr) (BanEntry, bool) {
+	addr = addr.Unmap()
+	now := bl.timeNow()
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	var found *BanEntry
+	for subnet, entry := range bl.entries {
+		if !subnet.Contains(addr) || entry.isExpired(now) {
+			continue
+		}
+		if found == nil || entry.Expires.After(found.Expires) {
+			found = entry
+		}
+	}
+	if found == nil {
+		return BanEntry{}, false
+	}
+	return *found, true
+}
+
+// IsHostBanned returns whether or not the provided host is banned.  Hosts that
+// are not IP addresses are never banned.
+func (bl *BanList) IsHostBanned(host string) bool {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	_, banned := bl.IsBanned(addr)
+	return banned
+}
+
+// IsAddrBanned returns whether or not the host of the provided network address
+// is banned.  Addresses that do not have an IP address host are never banned.
+func (bl *BanList) IsAddrBanned(addr net.Addr) bool {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return false
+	}
+	return bl.IsHostBanned(host)
+}
+
+// sortedEntries returns copies of all entries in the ban list sorted by
+// subnet.
+//
+// This function MUST be called with the ban list mutex held (for reads).
+func (bl *BanList) sortedEntries() []BanEntry {
+	entries := make([]BanEntry, 0, len(bl.entries))
+	for _, entry := range bl.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].Subnet, entries[j].Subnet
+		if cmp := a.Addr().Compare(b.Addr()); cmp != 0 {
+			return cmp < 0
+		}
+		return a.Bits() < b.Bits()
+	})
+	return entries
+}
+
+// Entries returns all unexpired entries in the ban list sorted by subnet.
+func (bl *BanList) Entries() []BanEntry {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	bl.removeExpired()
+	return bl.sortedEntries()
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package banmanager
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParseSubnet ensures individual addresses and subnets in CIDR notation are
+// parsed into the expected subnets.
+func TestParseSubnet(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{{
+		name:  "ipv4 address",
+		input: "192.168.1.1",
+		want:  "192.168.1.1/32",
+	}, {
+		name:  "ipv6 address",
+		input: "2001:db8::1",
+		want:  "2001:db8::1/128",
+	}, {
+		name:  "ipv4-mapped ipv6 address",
+		input: "::ffff:192.168.1.1",
+		want:  "192.168.1.1/32",
+	}, {
+		name:  "ipv4 subnet with host bits",
+		input: "192.168.1.1/24",
+		want:  "192.168.1.0/24",
+	}, {
+		name:  "ipv4-mapped ipv6 subnet",
+		input: "::ffff:10.0.0.0/104",
+		want:  "10.0.0.0/8",
+	}, {
+		name:  "ipv6 subnet",
+		input: "2001:db8::/32",
+		want:  "2001:db8::/32",
+	}, {
+		name:    "invalid address",
+		input:   "example.com",
+		wantErr: true,
+	}, {
+		name:    "invalid prefix length",
+		input:   "192.168.1.0/33",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		subnet, err := ParseSubnet(test.input)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%q: did not receive expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if subnet.String() != test.want {
+			t.Errorf("%q: unexpected subnet -- got %v, want %v", test.name,
+				subnet, test.want)
+		}
+	}
+}
+
+// TestBanList ensures banning, unbanning, expiring, and clearing bans work as
+// expected and that the bans are persisted across instances.
+func TestBanList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banlist.json")
+	now := time.Unix(1700000000, 0)
+	newBanList := func() *BanList {
+		bl := NewBanList(path)
+		bl.timeNow = func() time.Time { return now }
+		if err := bl.Load(); err != nil {
+			t.Fatalf("unexpected error loading ban list: %v", err)
+		}
+		return bl
+	}
+	checkBanned := func(bl *BanList, addr string, want bool) {
+		t.Helper()
+		_, banned := bl.IsBanned(netip.MustParseAddr(addr))
+		if banned != want {
+			t.Fatalf("unexpected ban status for %s -- got %v, want %v", addr,
+				banned, want)
+		}
+	}
+
+	// Ban an individual address and a subnet and ensure addresses within them
+	// are banned while others are not.
+	bl := newBanList()
+	if err := bl.BanHost("10.0.0.1", time.Hour, "misbehaving"); err != nil {
+		t.Fatalf("unexpected error banning host: %v", err)
+	}
+	subnet := netip.MustParsePrefix("192.168.0.0/16")
+	if err := bl.Ban(subnet, time.Minute, "manual"); err != nil {
+		t.Fatalf("unexpected error banning subnet: %v", err)
+	}
+	if err := bl.Ban(subnet, 0, "manual"); err == nil {
+		t.Fatal("did not receive expected error for zero duration")
+	}
+	checkBanned(bl, "10.0.0.1", true)
+	checkBanned(bl, "::ffff:10.0.0.1", true)
+	checkBanned(bl, "10.0.0.2", false)
+	checkBanned(bl, "192.168.12.34", true)
+	checkBanned(bl, "192.169.0.1", false)
+	if bl.IsHostBanned("example.onion") {
+		t.Fatal("non-IP host unexpectedly banned")
+	}
+
+	// Ensure the bans are persisted.
+	wantEntries := []BanEntry{{
+		Subnet:  netip.MustParsePrefix("10.0.0.1/32"),
+		Created: now,
+		Expires: now.Add(time.Hour),
+		Reason:  "misbehaving",
+	}, {
+		Subnet:  subnet,
+		Created: now,
+		Expires: now.Add(time.Minute),
+		Reason:  "manual",
+	}}
+	bl = newBanList()
+	if entries := bl.Entries(); !reflect.DeepEqual(entries, wantEntries) {
+		t.Fatalf("mismatched entries -- got %+v, want %+v", entries,
+			wantEntries)
+	}
+
+	// Ensure expired bans no longer apply and are not loaded.
+	now = now.Add(time.Minute)
+	checkBanned(bl, "192.168.12.34", false)
+	bl = newBanList()
+	if entries := bl.Entries(); !reflect.DeepEqual(entries, wantEntries[:1]) {
+		t.Fatalf("mismatched entries -- got %+v, want %+v", entries,
+			wantEntries[:1])
+	}
+
+	// Ensure unbanning removes the ban and is persisted.
+	removed, err := bl.Unban(subnet)
+	if err != nil || removed {
+		t.Fatalf("unexpected unban result for expired subnet: %v, %v",
+			removed, err)
+	}
+	removed, err = bl.Unban(netip.MustParsePrefix("10.0.0.1/32"))
+	if err != nil || !removed {
+		t.Fatalf("unexpected unban result: %v, %v", removed, err)
+	}
+	checkBanned(bl, "10.0.0.1", false)
+	if entries := newBanList().Entries(); len(entries) != 0 {
+		t.Fatalf("unexpected entries after unban: %+v", entries)
+	}
+
+	// Ensure clearing the ban list removes all bans.
+	if err := bl.BanHost("2001:db8::1", time.Hour, ""); err != nil {
+		t.Fatalf("unexpected error banning host: %v", err)
+	}
+	if err := bl.Clear(); err != nil {
+		t.Fatalf("unexpected error clearing ban list: %v", err)
+	}
+	checkBanned(bl, "2001:db8::1", false)
+	if entries := newBanList().Entries(); len(entries) != 0 {
+		t.Fatalf("unexpected entries after clear: %+v", entries)
+	}
+
+	// Ensure a malformed ban list file is rejected.
+	if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatalf("unable to write ban list file: %v", err)
+	}
+	if err := NewBanList(path).Load(); err == nil {
+		t.Fatal("did not receive expected error for malformed file")
+	}
+}
@@ -7,6 +7,7 @@ package banmanager
 import (
 	"fmt"
 	"net"
+	"net/netip"
 	"runtime/debug"
 	"sync"
 	"time"
@@ -34,6 +35,10 @@ type Config struct {
 
 	// Whitelist represents the whitelisted IPs of the server.
 	WhiteList []net.IPNet
+
+	// BanList houses the banned subnets.  It may be persisted so bans survive
+	// restarts.  An in-memory ban list is used when it is nil.
+	BanList *BanList
 }
 
 // banMgrPeer extends a peer to maintain additional state maintained by the
@@ -49,16 +54,20 @@ type banMgrPeer struct {
 type BanManager struct {
 	cfg    Config
 	peers  map[*peer.Peer]*banMgrPeer
-	banned map[string]time.Time
+	banned *BanList
 	mtx    sync.Mutex
 }
 
 // NewBanManager initializes a new peer banning manager.
 func NewBanManager(cfg *Config) *BanManager {
+	banned := cfg.BanList
+	if banned == nil {
+		banned = NewBanList("")
+	}
 	return &BanManager{
 		cfg:    *cfg,
 		peers:  make(map[*peer.Peer]*banMgrPeer, cfg.MaxPeers),
-		banned: make(map[string]time.Time, cfg.MaxPeers),
+		banned: banned,
 	}
 }
 
@@ -122,22 +131,12 @@ func (bm *BanManager) AddPeer(p *peer.Peer) error {
 		return fmt.Errorf("cannot split hostport %w", err)
 	}
 
-	bm.mtx.Lock()
-	banEnd, ok := bm.banned[host]
-	bm.mtx.Unlock()
-
-	if ok {
-		if time.Now().Before(banEnd) {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if ban, ok := bm.banned.IsBanned(addr); ok {
 			p.Disconnect()
 			return fmt.Errorf("peer %s is banned for another %v - disconnecting",
-				host, time.Until(banEnd))
+				host, time.Until(ban.Expires))
 		}
-
-		log.Infof("Peer %s is no longer banned", host)
-
-		bm.mtx.Lock()
-		delete(bm.banned, host)
-		bm.mtx.Unlock()
 	}
 
 	bmp := &banMgrPeer{
@@ -161,6 +160,11 @@ func (bm *BanManager) RemovePeer(p *peer.Peer) {
 
 // BanPeer bans the provided peer.
 func (bm *BanManager) BanPeer(p *peer.Peer) {
+	bm.banPeer(p, "manually banned")
+}
+
+// banPeer bans the provided peer for the provided reason.
+func (bm *BanManager) banPeer(p *peer.Peer, reason string) {
 	// Return immediately if banning is disabled.
 	if bm.cfg.DisableBanning {
 		return
@@ -189,9 +193,10 @@ func (bm *BanManager) BanPeer(p *peer.Peer) {
 	log.Infof("Banned peer %s (%s) for %v", host, direction,
 		bm.cfg.BanDuration)
 
-	bm.mtx.Lock()
-	bm.banned[host] = time.Now().Add(bm.cfg.BanDuration)
-	bm.mtx.Unlock()
+	err = bm.banned.BanHost(host, bm.cfg.BanDuration, reason)
+	if err != nil {
+		log.Errorf("Unable to save ban for peer %s: %v", host, err)
+	}
 
 	p.Disconnect()
 	bm.RemovePeer(p)
@@ -238,7 +243,7 @@ func (bm *BanManager) AddBanScore(p *peer.Peer, persistent, transient uint32, re
 			p, reason, banScore)
 		if banScore > bm.cfg.BanThreshold {
 			log.Warnf("Misbehaving peer %s -- banning and disconnecting", p)
-			bm.BanPeer(p)
+			bm.banPeer(p, reason)
 			return true
 		}
 	}
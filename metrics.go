@@ -0,0 +1,438 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/monetarium/monetarium-node/cointype"
+)
+
+const (
+	// metricsNamespace is the prefix used for the names of all metrics.
+	metricsNamespace = "monetarium_"
+
+	// metricsContentType is the content type of the Prometheus text
+	// exposition format served by the metrics endpoint.
+	metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// metricType identifies the type of a metric family in the Prometheus text
+// exposition format.
+type metricType string
+
+const (
+	// metricCounter is a cumulative value that only increases.
+	metricCounter metricType = "counter"
+
+	// metricGauge is a value that may arbitrarily go up and down.
+	metricGauge metricType = "gauge"
+)
+
+// metricSample is a single sample of a metric family along with the labels
+// that distinguish it from the other samples of the same family.  The labels
+// are specified as alternating label names and values.
+type metricSample struct {
+	labels []string
+	value  float64
+}
+
+// sample returns a metric sample with the provided value and alternating label
+// names and values.
+func sample(value float64, labels ...string) metricSample {
+	return metricSample{labels: labels, value: value}
+}
+
+// metricsWriter accumulates metric families in the Prometheus text exposition
+// format.
+type metricsWriter struct {
+	buf bytes.Buffer
+}
+
+// metricLabelEscaper escapes label values per the Prometheus text exposition
+// format.
+var metricLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatMetricValue returns the provided value formatted per the Prometheus
+// text exposition format.
+func formatMetricValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// family writes a metric family with the provided name, help text, type, and
+// samples.  The name is automatically prefixed with the metrics namespace.
+func (w *metricsWriter) family(name, help string, typ metricType, samples ...metricSample) {
+	name = metricsNamespace + name
+	fmt.Fprintf(&w.buf, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(&w.buf, "# TYPE %s %s\n", name, typ)
+	for _, s := range samples {
+		w.buf.WriteString(name)
+		if len(s.labels) > 0 {
+			w.buf.WriteByte('{')
+			for i := 0; i+1 < len(s.labels); i += 2 {
+				if i > 0 {
+					w.buf.WriteByte(',')
+				}
+				fmt.Fprintf(&w.buf, "%s=\"%s\"", s.labels[i],
+					metricLabelEscaper.Replace(s.labels[i+1]))
+			}
+			w.buf.WriteByte('}')
+		}
+		w.buf.WriteByte(' ')
+		w.buf.WriteString(formatMetricValue(s.value))
+		w.buf.WriteByte('\n')
+	}
+}
+
+// counter writes a counter metric family with a single unlabeled sample.
+func (w *metricsWriter) counter(name, help string, value float64) {
+	w.family(name, help, metricCounter, sample(value))
+}
+
+// gauge writes a gauge metric family with a single unlabeled sample.
+func (w *metricsWriter) gauge(name, help string, value float64) {
+	w.family(name, help, metricGauge, sample(value))
+}
+
+// boolToFloat returns 1 when the provided flag is true and 0 otherwise.
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// bigToFloat returns the provided big integer as a float64.  Nil is treated as
+// zero.
+func bigToFloat(n *big.Int) float64 {
+	if n == nil {
+		return 0
+	}
+	f, _ := new(big.Float).SetInt(n).Float64()
+	return f
+}
+
+// coinTypeLabel returns the label value used for the provided coin type.
+func coinTypeLabel(coinType cointype.CoinType) string {
+	return strconv.Itoa(int(coinType))
+}
+
+// writeChainMetrics writes the metrics related to the chain and the sync state
+// along with the block validation and connection latencies.
+func (s *server) writeChainMetrics(w *metricsWriter) {
+	best := s.chain.BestSnapshot()
+	w.gauge("chain_best_height", "Height of the current best chain tip.",
+		float64(best.Height))
+	w.gauge("chain_sync_height", "Height of the best chain tip announced by "+
+		"peers.", float64(s.syncManager.SyncHeight()))
+	w.gauge("chain_is_current", "Whether or not the chain is believed to be "+
+		"synced with the network (1 = current).",
+		boolToFloat(s.syncManager.IsCurrent()))
+
+	stats := s.chain.BlockProcessingStats()
+	w.counter("chain_blocks_validated_total", "Total number of blocks "+
+		"validated prior to being connected to the main chain.",
+		float64(stats.BlocksValidated))
+	w.counter("chain_block_validate_seconds_total", "Total time spent "+
+		"validating blocks prior to connecting them to the main chain.",
+		stats.ValidateDuration.Seconds())
+	w.counter("chain_blocks_connected_total", "Total number of blocks "+
+		"connected to the main chain.", float64(stats.BlocksConnected))
+	w.counter("chain_block_connect_seconds_total", "Total time spent "+
+		"connecting blocks to the main chain.",
+		stats.ConnectDuration.Seconds())
+}
+
+// writeMempoolMetrics writes the number of transactions in the mempool and
+// the total fees they pay for each coin type.
+func (s *server) writeMempoolMetrics(w *metricsWriter) {
+	counts := make(map[cointype.CoinType]int)
+	fees := make(map[cointype.CoinType]*big.Int)
+	for _, desc := range s.txMemPool.TxDescs() {
+		coinType := s.txMemPool.PrimaryCoinType(desc.Tx.MsgTx())
+		fee := fees[coinType]
+		if fee == nil {
+			fee = new(big.Int)
+			fees[coinType] = fee
+		}
+		if coinType.IsSKA() && desc.SKAFee != nil {
+			fee.Add(fee, desc.SKAFee)
+		} else {
+			fee.Add(fee, big.NewInt(desc.Fee))
+		}
+		counts[coinType]++
+	}
+
+	// Always report VAR so the metric families are never empty.
+	if _, ok := counts[cointype.CoinTypeVAR]; !ok {
+		counts[cointype.CoinTypeVAR] = 0
+		fees[cointype.CoinTypeVAR] = new(big.Int)
+	}
+	coinTypes := make([]cointype.CoinType, 0, len(counts))
+	for coinType := range counts {
+		coinTypes = append(coinTypes, coinType)
+	}
+	sort.Slice(coinTypes, func(i, j int) bool {
+		return coinTypes[i] < coinTypes[j]
+	})
+
+	countSamples := make([]metricSample, 0, len(coinTypes))
+	feeSamples := make([]metricSample, 0, len(coinTypes))
+	for _, coinType := range coinTypes {
+		label := coinTypeLabel(coinType)
+		countSamples = append(countSamples, sample(float64(counts[coinType]),
+			"cointype", label))
+		feeSamples = append(feeSamples, sample(bigToFloat(fees[coinType]),
+			"cointype", label))
+	}
+	w.family("mempool_transactions", "Number of transactions in the mempool "+
+		"by coin type.", metricGauge, countSamples...)
+	w.family("mempool_fees_atoms", "Total fees in atoms paid by the "+
+		"transactions in the mempool by coin type.", metricGauge,
+		feeSamples...)
+}
+
+// writeUtxoCacheMetrics writes the utxo cache statistics.
+func (s *server) writeUtxoCacheMetrics(w *metricsWriter) {
+	stats := s.utxoCache.Stats()
+	w.gauge("utxocache_entries", "Number of entries in the utxo cache.",
+		float64(stats.Entries))
+	w.gauge("utxocache_size_bytes", "Estimated size of the utxo cache in "+
+		"bytes.", float64(stats.Size))
+	w.gauge("utxocache_max_size_bytes", "Maximum size of the utxo cache in "+
+		"bytes.", float64(stats.MaxSize))
+	w.counter("utxocache_hits_total", "Total number of utxo cache lookups "+
+		"that were found in the cache.", float64(stats.Hits))
+	w.counter("utxocache_misses_total", "Total number of utxo cache lookups "+
+		"that had to be loaded from the backend.", float64(stats.Misses))
+	w.counter("utxocache_flushes_total", "Total number of utxo cache "+
+		"flushes to the backend.", float64(stats.Flushes))
+	w.counter("utxocache_evictions_total", "Total number of entries "+
+		"evicted from the utxo cache.", float64(stats.Evictions))
+	w.counter("utxocache_flush_seconds_total", "Total time spent flushing "+
+		"the utxo cache to the backend.", stats.FlushDuration.Seconds())
+}
+
+// writePeerMetrics writes the byte and message counts of the connected peers
+// aggregated by connection direction along with the overall network totals,
+// the address manager bucket fill, and the number of peers banned.
+//
+// The counts are aggregated rather than reported per peer to keep the number
+// of series bounded as peers come and go.
+func (s *server) writePeerMetrics(w *metricsWriter) {
+	type peerStats struct {
+		peers                uint64
+		bytesRecv, bytesSent uint64
+		msgsRecv, msgsSent   uint64
+	}
+	var inbound, outbound peerStats
+	s.peerState.ForAllPeers(func(sp *serverPeer) {
+		if !sp.Connected() {
+			return
+		}
+		snap := sp.StatsSnapshot()
+		stats := &outbound
+		if snap.Inbound {
+			stats = &inbound
+		}
+		stats.peers++
+		stats.bytesRecv += snap.BytesRecv
+		stats.bytesSent += snap.BytesSent
+		stats.msgsRecv += sp.msgsReceived.Load()
+		stats.msgsSent += sp.msgsSent.Load()
+	})
+
+	directionSamples := func(value func(stats *peerStats) uint64) []metricSample {
+		return []metricSample{
+			sample(float64(value(&inbound)), "direction",
+				directionString(true)),
+			sample(float64(value(&outbound)), "direction",
+				directionString(false)),
+		}
+	}
+	w.family("peers_connected", "Number of connected peers by direction.",
+		metricGauge, directionSamples(func(stats *peerStats) uint64 {
+			return stats.peers
+		})...)
+	w.family("peer_bytes_received_total", "Total bytes received from the "+
+		"connected peers by direction.", metricCounter,
+		directionSamples(func(stats *peerStats) uint64 {
+			return stats.bytesRecv
+		})...)
+	w.family("peer_bytes_sent_total", "Total bytes sent to the connected "+
+		"peers by direction.", metricCounter,
+		directionSamples(func(stats *peerStats) uint64 {
+			return stats.bytesSent
+		})...)
+	w.family("peer_messages_received_total", "Total messages received from "+
+		"the connected peers by direction.", metricCounter,
+		directionSamples(func(stats *peerStats) uint64 {
+			return stats.msgsRecv
+		})...)
+	w.family("peer_messages_sent_total", "Total messages sent to the "+
+		"connected peers by direction.", metricCounter,
+		directionSamples(func(stats *peerStats) uint64 {
+			return stats.msgsSent
+		})...)
+	w.counter("net_bytes_received_total", "Total bytes received from all "+
+		"peers.", float64(s.bytesReceived.Load()))
+	w.counter("net_bytes_sent_total", "Total bytes sent to all peers.",
+		float64(s.bytesSent.Load()))
+	w.counter("peer_bans_total", "Total number of peers banned.",
+		float64(s.numBans.Load()))
+
+	stats := s.addrManager.BucketStats()
+	w.family("addrmgr_addresses", "Number of addresses in the address "+
+		"manager buckets.", metricGauge,
+		sample(float64(stats.NumNew), "bucket", "new"),
+		sample(float64(stats.NumTried), "bucket", "tried"))
+	w.family("addrmgr_capacity", "Maximum number of addresses the address "+
+		"manager buckets can hold.", metricGauge,
+		sample(float64(stats.NewCapacity), "bucket", "new"),
+		sample(float64(stats.TriedCapacity), "bucket", "tried"))
+	w.family("addrmgr_buckets_used", "Number of address manager buckets "+
+		"that contain at least one address.", metricGauge,
+		sample(float64(stats.NewBucketsUsed), "bucket", "new"),
+		sample(float64(stats.TriedBucketsUsed), "bucket", "tried"))
+}
+
+// writeMiningMetrics writes the SSFee consolidation statistics of the block
+// template generator.  Nothing is written when mining is not enabled.
+func (s *server) writeMiningMetrics(w *metricsWriter) {
+	if s.bg == nil {
+		return
+	}
+	hits, misses := s.bg.SSFeeConsolidationStats()
+	w.counter("ssfee_consolidation_hits_total", "Total number of SSFee "+
+		"outputs created by augmenting an existing SSFee UTXO.",
+		float64(hits))
+	w.counter("ssfee_consolidation_misses_total", "Total number of SSFee "+
+		"outputs that required a new UTXO.", float64(misses))
+}
+
+// writeSKAMetrics writes the cumulative amounts emitted and burned for each
+// configured SKA coin type.
+func (s *server) writeSKAMetrics(w *metricsWriter) {
+	coinTypes := make([]cointype.CoinType, 0, len(s.chainParams.SKACoins))
+	for coinType := range s.chainParams.SKACoins {
+		coinTypes = append(coinTypes, coinType)
+	}
+	sort.Slice(coinTypes, func(i, j int) bool {
+		return coinTypes[i] < coinTypes[j]
+	})
+
+	emittedAmts := s.chain.GetAllSKAEmittedAmounts()
+	burnedAmts := s.chain.GetAllSKABurnedAmounts()
+	emitted := make([]metricSample, 0, len(coinTypes))
+	burned := make([]metricSample, 0, len(coinTypes))
+	for _, coinType := range coinTypes {
+		label := coinTypeLabel(coinType)
+		emitted = append(emitted, sample(bigToFloat(emittedAmts[coinType]),
+			"cointype", label))
+		burned = append(burned, sample(bigToFloat(burnedAmts[coinType]),
+			"cointype", label))
+	}
+	w.family("ska_emitted_atoms", "Cumulative amount in atoms emitted for "+
+		"each SKA coin type.", metricGauge, emitted...)
+	w.family("ska_burned_atoms", "Cumulative amount in atoms burned for "+
+		"each SKA coin type.", metricGauge, burned...)
+}
+
+// writeMetrics writes all metrics collected from the core subsystems.
+func (s *server) writeMetrics(w *metricsWriter) {
+	s.writeChainMetrics(w)
+	s.writeMempoolMetrics(w)
+	s.writeUtxoCacheMetrics(w)
+	s.writePeerMetrics(w)
+	s.writeMiningMetrics(w)
+	s.writeSKAMetrics(w)
+}
+
+// newMetricsHandler returns an HTTP handler that serves the metrics written by
+// the provided function at /metrics in the Prometheus text exposition format.
+func newMetricsHandler(writeMetrics func(w *metricsWriter)) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", func(rw http.ResponseWriter, r *http.Request) {
+		var w metricsWriter
+		writeMetrics(&w)
+		rw.Header().Set("Content-Type", metricsContentType)
+		rw.Header().Set("Cache-Control", "no-store")
+		rw.Write(w.buf.Bytes())
+	})
+	return mux
+}
+
+// setupMetricsListeners returns a slice of listeners that are configured for
+// use with the metrics server depending on the configuration settings.
+func setupMetricsListeners() ([]net.Listener, error) {
+	listenAddrs := normalizeAddresses([]string{cfg.Metrics}, "",
+		normalizeInterfaceAddrs)
+	netAddrs, err := parseListeners(listenAddrs)
+	if err != nil {
+		return nil, err
+	}
+
+	listeners := make([]net.Listener, 0, len(netAddrs))
+	for _, addr := range netAddrs {
+		listener, err := net.Listen(addr.Network(), addr.String())
+		if err != nil {
+			srvrLog.Warnf("Can't listen on %s: %v", addr, err)
+			continue
+		}
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+// serveMetrics serves the metrics endpoint on all of the metrics listeners
+// until the provided context is cancelled.
+func (s *server) serveMetrics(ctx context.Context) {
+	httpServer := &http.Server{
+		Handler:           newMetricsHandler(s.writeMetrics),
+		ReadHeaderTimeout: time.Second * 3,
+		WriteTimeout:      time.Second * 30,
+	}
+
+	var wg sync.WaitGroup
+	for _, listener := range s.metricsListeners {
+		wg.Add(1)
+		go func(listener net.Listener) {
+			defer wg.Done()
+
+			srvrLog.Infof("Metrics server listening on %s", listener.Addr())
+			err := httpServer.Serve(listener)
+			if !errors.Is(err, http.ErrServerClosed) {
+				srvrLog.Errorf("Metrics server listening on %s exited with "+
+					"unexpected error: %v", listener.Addr(), err)
+			}
+		}(listener)
+	}
+
+	<-ctx.Done()
+	httpServer.Close()
+	wg.Wait()
+	srvrLog.Info("Metrics server stopped")
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestMetricsWriter ensures the metrics writer produces output that conforms
+// to the Prometheus text exposition format.
+func TestMetricsWriter(t *testing.T) {
+	var w metricsWriter
+	w.gauge("test_gauge", "A test gauge.", 1.5)
+	w.counter("test_counter", "A test counter.", 1e21)
+	w.family("test_labeled", "A labeled test gauge.", metricGauge,
+		sample(1, "cointype", "0"),
+		sample(2, "addr", "a\"b\\c\nd", "direction", "inbound"))
+	w.family("test_empty", "An empty test counter.", metricCounter)
+	w.gauge("test_nan", "A test gauge that is not a number.", math.NaN())
+	w.gauge("test_big", "A test gauge from a big integer.",
+		bigToFloat(new(big.Int).Lsh(big.NewInt(1), 70)))
+
+	want := "# HELP monetarium_test_gauge A test gauge.\n" +
+		"# TYPE monetarium_test_gauge gauge\n" +
+		"monetarium_test_gauge 1.5\n" +
+		"# HELP monetarium_test_counter A test counter.\n" +
+		"# TYPE monetarium_test_counter counter\n" +
+		"monetarium_test_counter 1e+21\n" +
+		"# HELP monetarium_test_labeled A labeled test gauge.\n" +
+		"# TYPE monetarium_test_labeled gauge\n" +
+		"monetarium_test_labeled{cointype=\"0\"} 1\n" +
+		"monetarium_test_labeled{addr=\"a\\\"b\\\\c\\nd\",direction=\"inbound\"} 2\n" +
+		"# HELP monetarium_test_empty An empty test counter.\n" +
+		"# TYPE monetarium_test_empty counter\n" +
+		"# HELP monetarium_test_nan A test gauge that is not a number.\n" +
+		"# TYPE monetarium_test_nan gauge\n" +
+		"monetarium_test_nan NaN\n" +
+		"# HELP monetarium_test_big A test gauge from a big integer.\n" +
+		"# TYPE monetarium_test_big gauge\n" +
+		"monetarium_test_big 1.1805916207174113e+21\n"
+	if got := w.buf.String(); got != want {
+		t.Fatalf("unexpected output:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestMetricsHandler ensures the metrics handler serves the metrics at the
+// expected path with the expected content type and rejects other requests.
+func TestMetricsHandler(t *testing.T) {
+	handler := newMetricsHandler(func(w *metricsWriter) {
+		w.gauge("test_gauge", "A test gauge.", 1)
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{{
+		name:       "metrics",
+		method:     http.MethodGet,
+		path:       "/metrics",
+		wantStatus: http.StatusOK,
+	}, {
+		name:       "wrong method",
+		method:     http.MethodPost,
+		path:       "/metrics",
+		wantStatus: http.StatusMethodNotAllowed,
+	}, {
+		name:       "unknown path",
+		method:     http.MethodGet,
+		path:       "/",
+		wantStatus: http.StatusNotFound,
+	}}
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != test.wantStatus {
+			t.Fatalf("%q: unexpected status -- got %d, want %d", test.name,
+				rec.Code, test.wantStatus)
+		}
+		if test.wantStatus != http.StatusOK {
+			continue
+		}
+		if got := rec.Header().Get("Content-Type"); got != metricsContentType {
+			t.Fatalf("%q: unexpected content type -- got %q, want %q",
+				test.name, got, metricsContentType)
+		}
+		want := "# HELP monetarium_test_gauge A test gauge.\n" +
+			"# TYPE monetarium_test_gauge gauge\n" +
+			"monetarium_test_gauge 1\n"
+		if got := rec.Body.String(); got != want {
+			t.Fatalf("%q: unexpected body -- got %q, want %q", test.name, got,
+				want)
+		}
+	}
+}
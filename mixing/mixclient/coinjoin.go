@@ -5,8 +5,10 @@
 package mixclient
 
 import (
+	"bytes"
 	"crypto/subtle"
 	"errors"
+	"sort"
 
 	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
 	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
@@ -52,10 +54,11 @@ type CoinJoin struct {
 	genScripts   [][]byte
 	mixedIndices []int
 
-	mixValue   int64
-	inputValue int64
-	prExpiry   uint32
-	mcount     uint32
+	mixValue       int64
+	inputValue     int64
+	prExpiry       uint32
+	mcount         uint32
+	canonicalOrder bool
 }
 
 // GenFunc generates fresh secp256k1 P2PKH hash160s from the wallet.
@@ -77,6 +80,19 @@ func NewCoinJoin(gen GenFunc, change *wire.TxOut, mixValue int64, prExpiry uint3
 	}
 }
 
+// SetCanonicalOrder sets whether the coinjoin transaction is sorted using the
+// coin type aware canonical ordering defined by the txsort package, which
+// groups outputs by coin type and compares SKA outputs by their full precision
+// amounts.  When unset, outputs are sorted by their VAR amount, script version,
+// and script only, matching peers that predate the canonical ordering.
+//
+// The ordering is only distinguishable when a peer contributes SKA outputs, but
+// all peers in a mix must use the same setting for their signatures to be
+// valid.  It must be set before the mixing protocol is performed.
+func (c *CoinJoin) SetCanonicalOrder(canonical bool) {
+	c.canonicalOrder = canonical
+}
+
 // AddInput adds an contributed input to the coinjoin transaction.
 //
 // The private key is used to generate a UTXO signature proof demonstrating
@@ -194,6 +210,9 @@ func (c *CoinJoin) addMixedMessage(m []byte) {
 // on the trasaction to sign.
 func (c *CoinJoin) sort() {
 	txsort.InPlaceSort(c.tx)
+	if !c.canonicalOrder {
+		sort.Sort(valueOrderedOutputs(c.tx.TxOut))
+	}
 
 	c.myInputs = c.myInputs[:0]
 	for i, in := range c.tx.TxIn {
@@ -206,6 +225,24 @@ func (c *CoinJoin) sort() {
 	c.txHash = c.tx.TxHash()
 }
 
+// valueOrderedOutputs implements sort.Interface to sort coinjoin outputs by
+// their VAR amount, script version, and script without regard to coin type.
+// This is the output ordering used by peers that predate the coin type aware
+// canonical ordering.
+type valueOrderedOutputs []*wire.TxOut
+
+func (s valueOrderedOutputs) Len() int      { return len(s) }
+func (s valueOrderedOutputs) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s valueOrderedOutputs) Less(i, j int) bool {
+	if s[i].Value != s[j].Value {
+		return s[i].Value < s[j].Value
+	}
+	if s[i].Version != s[j].Version {
+		return s[i].Version < s[j].Version
+	}
+	return bytes.Compare(s[i].PkScript, s[j].PkScript) < 0
+}
+
 // constantTimeOutputSearch searches for the output indices of mixed outputs to
 // verify inclusion in a coinjoin.  It is constant time such that, for each
 // searched script, all outputs with equal value, script versions, and script
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mixclient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/txscript"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// TestCoinJoinSortOutputs ensures the coinjoin transaction outputs are sorted
+// with the coin type aware canonical ordering when it is enabled and by their
+// VAR amount, script version, and script otherwise.
+func TestCoinJoinSortOutputs(t *testing.T) {
+	p2pkh := func(b byte) []byte {
+		script := make([]byte, 25)
+		script[0] = txscript.OP_DUP
+		script[1] = txscript.OP_HASH160
+		script[2] = txscript.OP_DATA_20
+		script[3] = b
+		script[23] = txscript.OP_EQUALVERIFY
+		script[24] = txscript.OP_CHECKSIG
+		return script
+	}
+	skaOut := func(amount *big.Int, script []byte) *wire.TxOut {
+		return &wire.TxOut{
+			CoinType: cointype.CoinType(1),
+			SKAValue: amount,
+			PkScript: script,
+		}
+	}
+
+	// The large SKA amount exceeds the range of an int64.
+	largeSKAAmount, ok := new(big.Int).SetString("100000000000000000000", 10)
+	if !ok {
+		t.Fatal("unable to parse SKA amount")
+	}
+	varLarge := wire.NewTxOut(300, p2pkh(0x01))
+	skaLarge := skaOut(largeSKAAmount, p2pkh(0x02))
+	varSmall := wire.NewTxOut(100, p2pkh(0x03))
+	skaSmall := skaOut(big.NewInt(5), p2pkh(0x04))
+	unsorted := []*wire.TxOut{varLarge, skaLarge, varSmall, skaSmall}
+
+	tests := []struct {
+		name      string
+		canonical bool
+		want      []*wire.TxOut
+	}{{
+		name:      "canonical order groups outputs by coin type",
+		canonical: true,
+		want:      []*wire.TxOut{varSmall, varLarge, skaSmall, skaLarge},
+	}, {
+		name:      "value order ignores coin type and SKA amounts",
+		canonical: false,
+		want:      []*wire.TxOut{skaLarge, skaSmall, varSmall, varLarge},
+	}}
+	for _, test := range tests {
+		c := NewCoinJoin(nil, nil, 0, 0, 0)
+		c.SetCanonicalOrder(test.canonical)
+		c.tx.TxOut = append(c.tx.TxOut, unsorted...)
+		c.sort()
+
+		if len(c.tx.TxOut) != len(test.want) {
+			t.Errorf("%q: unexpected number of outputs -- got %d, want %d",
+				test.name, len(c.tx.TxOut), len(test.want))
+			continue
+		}
+		for i, out := range c.tx.TxOut {
+			if out != test.want[i] {
+				t.Errorf("%q: unexpected output %d -- got %+v, want %+v",
+					test.name, i, out, test.want[i])
+			}
+		}
+		if c.txHash != c.tx.TxHash() {
+			t.Errorf("%q: transaction hash not updated after sort", test.name)
+		}
+	}
+}
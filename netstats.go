@@ -0,0 +1,134 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// uploadTargetCycle is the duration of the cycle the max upload target
+	// applies to.
+	uploadTargetCycle = 24 * time.Hour
+
+	// historicalBlockAge is the minimum age of blocks that are considered
+	// historical and are therefore no longer served to peers that are not
+	// whitelisted once the max upload target is reached.
+	historicalBlockAge = 7 * 24 * time.Hour
+)
+
+// msgByteCounters tracks the total number of bytes sent and received across
+// all peers by message command.  It is safe for concurrent access.
+type msgByteCounters struct {
+	mtx  sync.Mutex
+	sent map[string]uint64
+	recv map[string]uint64
+}
+
+// add adds the provided number of bytes to the sent or received counter of the
+// provided message command.
+func (c *msgByteCounters) add(command string, n uint64, sent bool) {
+	c.mtx.Lock()
+	if c.sent == nil {
+		c.sent = make(map[string]uint64)
+		c.recv = make(map[string]uint64)
+	}
+	if sent {
+		c.sent[command] += n
+	} else {
+		c.recv[command] += n
+	}
+	c.mtx.Unlock()
+}
+
+// snapshot returns copies of the sent and received counters.
+func (c *msgByteCounters) snapshot() (map[string]uint64, map[string]uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	sent := make(map[string]uint64, len(c.sent))
+	for command, n := range c.sent {
+		sent[command] = n
+	}
+	recv := make(map[string]uint64, len(c.recv))
+	for command, n := range c.recv {
+		recv[command] = n
+	}
+	return sent, recv
+}
+
+// uploadTarget tracks the number of bytes sent to peers during the current
+// cycle against a max upload target.  Serving historical blocks to peers that
+// are not whitelisted stops once the target is reached until the next cycle
+// starts in order to prioritize relaying new blocks and transactions on
+// bandwidth constrained links.  It is safe for concurrent access.
+type uploadTarget struct {
+	mtx        sync.Mutex
+	target     uint64
+	cycleStart time.Time
+	cycleBytes uint64
+}
+
+// maybeStartCycle starts a new cycle when the current one has ended as of the
+// provided time.
+//
+// This function MUST be called with the mutex held (for writes).
+func (u *uploadTarget) maybeStartCycle(now time.Time) {
+	if u.cycleStart.IsZero() || now.Sub(u.cycleStart) >= uploadTargetCycle {
+		u.cycleStart = now
+		u.cycleBytes = 0
+	}
+}
+
+// addBytes adds the provided number of bytes sent as of the provided time to
+// the current cycle.
+func (u *uploadTarget) addBytes(now time.Time, n uint64) {
+	u.mtx.Lock()
+	u.maybeStartCycle(now)
+	u.cycleBytes += n
+	u.mtx.Unlock()
+}
+
+// reached returns whether or not the upload target for the current cycle has
+// been reached as of the provided time.  It is never reached when there is no
+// target.
+func (u *uploadTarget) reached(now time.Time) bool {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+
+	if u.target == 0 {
+		return false
+	}
+	u.maybeStartCycle(now)
+	return u.cycleBytes >= u.target
+}
+
+// uploadTargetStats houses the state of the max upload target.
+type uploadTargetStats struct {
+	target    uint64
+	reached   bool
+	bytesLeft uint64
+	timeLeft  time.Duration
+}
+
+// stats returns the state of the upload target as of the provided time.
+func (u *uploadTarget) stats(now time.Time) uploadTargetStats {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+
+	u.maybeStartCycle(now)
+	stats := uploadTargetStats{
+		target:   u.target,
+		timeLeft: uploadTargetCycle - now.Sub(u.cycleStart),
+	}
+	if u.target != 0 {
+		stats.reached = u.cycleBytes >= u.target
+		if !stats.reached {
+			stats.bytesLeft = u.target - u.cycleBytes
+		}
+	}
+	return stats
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestUploadTarget ensures the max upload target tracks the data sent during
+// each cycle and reports when the target is reached as expected.
+func TestUploadTarget(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	// No target is never reached.
+	var unlimited uploadTarget
+	unlimited.addBytes(start, 1<<40)
+	if unlimited.reached(start) {
+		t.Fatal("upload target reached without a target")
+	}
+
+	u := uploadTarget{target: 1000}
+	u.addBytes(start, 600)
+	now := start.Add(time.Hour)
+	if u.reached(now) {
+		t.Fatal("upload target reached prematurely")
+	}
+	want := uploadTargetStats{
+		target:    1000,
+		bytesLeft: 400,
+		timeLeft:  23 * time.Hour,
+	}
+	if got := u.stats(now); !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatched stats: got %+v, want %+v", got, want)
+	}
+
+	// Reaching the target is reported until the cycle ends.
+	u.addBytes(now, 400)
+	if !u.reached(now) {
+		t.Fatal("upload target not reached")
+	}
+	want = uploadTargetStats{
+		target:   1000,
+		reached:  true,
+		timeLeft: 23 * time.Hour,
+	}
+	if got := u.stats(now); !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatched stats: got %+v, want %+v", got, want)
+	}
+
+	// A new cycle starts once the current one ends.
+	now = start.Add(uploadTargetCycle)
+	if u.reached(now) {
+		t.Fatal("upload target still reached after cycle ended")
+	}
+	want = uploadTargetStats{
+		target:    1000,
+		bytesLeft: 1000,
+		timeLeft:  uploadTargetCycle,
+	}
+	if got := u.stats(now); !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatched stats: got %+v, want %+v", got, want)
+	}
+}
+
+// TestMsgByteCounters ensures the per-message byte counters accumulate the
+// bytes sent and received by message command as expected.
+func TestMsgByteCounters(t *testing.T) {
+	var c msgByteCounters
+	c.add("block", 1000, true)
+	c.add("block", 500, true)
+	c.add("inv", 37, false)
+	sent, recv := c.snapshot()
+	wantSent := map[string]uint64{"block": 1500}
+	wantRecv := map[string]uint64{"inv": 37}
+	if !reflect.DeepEqual(sent, wantSent) {
+		t.Fatalf("mismatched sent counters: got %v, want %v", sent, wantSent)
+	}
+	if !reflect.DeepEqual(recv, wantRecv) {
+		t.Fatalf("mismatched received counters: got %v, want %v", recv,
+			wantRecv)
+	}
+
+	// Ensure the snapshot is a copy.
+	sent["block"] = 0
+	if sent, _ := c.snapshot(); sent["block"] != 1500 {
+		t.Fatal("snapshot is not a copy")
+	}
+}
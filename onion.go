@@ -0,0 +1,192 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/monetarium/monetarium-node/addrmgr"
+	"github.com/monetarium/monetarium-node/connmgr"
+)
+
+const (
+	// onionKeyFilename is the name of the file in the data directory that
+	// houses the private key of the onion service created via the Tor control
+	// port so the node keeps the same onion address across restarts.
+	onionKeyFilename = "onion_v3_private_key"
+
+	// onionServiceRetryInterval is the amount of time to wait before trying to
+	// create the onion service again after a failure such as the Tor control
+	// port being unavailable.
+	onionServiceRetryInterval = time.Minute
+)
+
+// onionAddr implements the net.Addr interface and represents a TORv3 onion
+// address.  It is used to dial onion peers by host name via the onion proxy
+// since onion addresses can't be resolved to IP addresses.
+type onionAddr struct {
+	addr string
+}
+
+// String returns the onion address.
+//
+// This is part of the net.Addr interface.
+func (oa *onionAddr) String() string {
+	return oa.addr
+}
+
+// Network returns "onion".
+//
+// This is part of the net.Addr interface.
+func (oa *onionAddr) Network() string {
+	return "onion"
+}
+
+// Ensure onionAddr implements the net.Addr interface.
+var _ net.Addr = (*onionAddr)(nil)
+
+// onionServiceTarget returns the address the onion service forwards incoming
+// connections to based on the provided listeners.  Listeners bound to an
+// unspecified address are reached via the loopback address of the same family.
+func onionServiceTarget(listeners []net.Listener) string {
+	for _, listener := range listeners {
+		addr, ok := listener.Addr().(*net.TCPAddr)
+		if !ok {
+			continue
+		}
+		ip := addr.IP
+		if ip.IsUnspecified() {
+			ip = net.IPv6loopback
+			if ip4 := addr.IP.To4(); ip4 != nil {
+				ip = net.IPv4(127, 0, 0, 1)
+			}
+		}
+		return net.JoinHostPort(ip.String(), strconv.Itoa(addr.Port))
+	}
+	return ""
+}
+
+// readOnionKey returns the onion service private key stored in the provided
+// file.  An empty key is returned without an error when the file does not
+// exist.
+func readOnionKey(filePath string) (string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeOnionKey stores the provided onion service private key in the provided
+// file such that it is only readable by the current user.
+func writeOnionKey(filePath, key string) error {
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(key+"\n"), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// createOnionService creates the onion service for incoming connections via the
+// Tor control port, advertises its address, and returns the control connection
+// which must remain open for as long as the onion service is needed.
+func (s *server) createOnionService(ctx context.Context) (*connmgr.TorControl, error) {
+	keyPath := filepath.Join(cfg.DataDir, onionKeyFilename)
+	privateKey, err := readOnionKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	control, err := connmgr.DialTorControl(ctx, cfg.TorControl)
+	if err != nil {
+		return nil, err
+	}
+	if err := control.Authenticate(cfg.TorPassword); err != nil {
+		control.Close()
+		return nil, err
+	}
+
+	port, err := strconv.ParseUint(s.chainParams.DefaultPort, 10, 16)
+	if err != nil {
+		control.Close()
+		return nil, err
+	}
+	service, err := control.AddOnion(privateKey, uint16(port), s.onionTarget)
+	if err != nil {
+		control.Close()
+		return nil, err
+	}
+	if service.PrivateKey != privateKey {
+		if err := writeOnionKey(keyPath, service.PrivateKey); err != nil {
+			control.Close()
+			return nil, err
+		}
+	}
+
+	// Advertise the onion address to peers.
+	host := service.ServiceID + ".onion"
+	addrType, pubKey := addrmgr.EncodeHost(host)
+	na, err := addrmgr.NewNetAddressFromParams(addrType, pubKey, uint16(port),
+		time.Now(), s.services)
+	if err != nil {
+		control.Close()
+		return nil, err
+	}
+	s.addrManager.AddLocalAddress(na, addrmgr.ManualPrio)
+	srvrLog.Infof("Accepting incoming connections via onion service %s",
+		net.JoinHostPort(host, strconv.FormatUint(port, 10)))
+	return control, nil
+}
+
+// onionServiceHandler creates the onion service for incoming connections via
+// the Tor control port and keeps it available until the provided context is
+// cancelled.  Creating the onion service is retried periodically on failure
+// since Tor may not be running yet or may be restarted.
+//
+// It must be run as a goroutine.
+func (s *server) onionServiceHandler(ctx context.Context) {
+	for {
+		control, err := s.createOnionService(ctx)
+		if err != nil {
+			srvrLog.Warnf("Unable to create onion service via Tor control "+
+				"port %s: %v", cfg.TorControl, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(onionServiceRetryInterval):
+				continue
+			}
+		}
+
+		// Tor removes the onion service when the control connection is
+		// closed.  Detect the connection being closed by Tor by waiting for
+		// a read to fail so the onion service can be created again.
+		closed := make(chan struct{})
+		go func() {
+			control.Wait()
+			close(closed)
+		}()
+		select {
+		case <-ctx.Done():
+			control.Close()
+			<-closed
+			return
+		case <-closed:
+			control.Close()
+			srvrLog.Warnf("Connection to Tor control port %s lost",
+				cfg.TorControl)
+		}
+	}
+}
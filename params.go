@@ -13,7 +13,8 @@ import (
 // network and test networks.
 type params struct {
 	*chaincfg.Params
-	rpcPort string
+	rpcPort  string
+	restPort string
 }
 
 // mainNetParams contains parameters specific to the main network
@@ -23,27 +24,31 @@ type params struct {
 // it does not handle on to dcrd.  This approach allows the wallet process
 // to emulate the full reference implementation RPC API.
 var mainNetParams = params{
-	Params:  chaincfg.MainNetParams(),
-	rpcPort: "9509",
+	Params:   chaincfg.MainNetParams(),
+	rpcPort:  "9509",
+	restPort: "9510",
 }
 
 // testNet3Params contains parameters specific to the test network (version 3)
 // (wire.TestNet3).
 var testNet3Params = params{
-	Params:  chaincfg.TestNet3Params(),
-	rpcPort: "19509",
+	Params:   chaincfg.TestNet3Params(),
+	rpcPort:  "19509",
+	restPort: "19510",
 }
 
 // simNetParams contains parameters specific to the simulation test network
 // (wire.SimNet).
 var simNetParams = params{
-	Params:  chaincfg.SimNetParams(),
-	rpcPort: "19956",
+	Params:   chaincfg.SimNetParams(),
+	rpcPort:  "19956",
+	restPort: "19957",
 }
 
 // regNetParams contains parameters specific to the regression test
 // network (wire.RegNet).
 var regNetParams = params{
-	Params:  chaincfg.RegNetParams(),
-	rpcPort: "19056",
+	Params:   chaincfg.RegNetParams(),
+	rpcPort:  "19056",
+	restPort: "19057",
 }
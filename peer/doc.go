@@ -43,6 +43,9 @@ A quick overview of the major features peer provides are as follows:
     message output function, but the helpers provide additional nice
     functionality such as duplicate filtering and address randomization
   - Ability to wait for shutdown/disconnect
+  - Opportunistic encrypted v2 transport negotiated via an ephemeral ECDH key
+    exchange with optional static key authentication and fallback to the
+    plaintext v1 transport
   - Comprehensive test coverage
 
 # Peer Configuration
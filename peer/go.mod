@@ -1,28 +1,32 @@
 module github.com/monetarium/monetarium-node/peer
 
-go 1.18
+go 1.23
 
 require (
 	github.com/davecgh/go-spew v1.1.1
+	github.com/decred/go-socks v1.1.0
+	github.com/decred/slog v1.2.0
 	github.com/monetarium/monetarium-node/chaincfg/chainhash v1.0.11
 	github.com/monetarium/monetarium-node/container/lru v1.0.11
 	github.com/monetarium/monetarium-node/crypto/blake256 v1.0.11
 	github.com/monetarium/monetarium-node/crypto/rand v1.0.11
+	github.com/monetarium/monetarium-node/dcrec/secp256k1 v1.0.11
 	github.com/monetarium/monetarium-node/txscript v1.0.11
 	github.com/monetarium/monetarium-node/wire v1.0.11
-	github.com/decred/go-socks v1.1.0
-	github.com/decred/slog v1.2.0
+	golang.org/x/crypto v0.33.0
 )
 
 require (
 	github.com/agl/ed25519 v0.0.0-20170116200512-5312a6153412 // indirect
 	github.com/dchest/siphash v1.2.3 // indirect
+	github.com/decred/dcrd/crypto/blake256 v1.1.0 // indirect
+	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
+	github.com/monetarium/monetarium-node/cointype v1.0.11 // indirect
 	github.com/monetarium/monetarium-node/crypto/ripemd160 v1.0.11 // indirect
 	github.com/monetarium/monetarium-node/dcrec v1.0.11 // indirect
 	github.com/monetarium/monetarium-node/dcrec/edwards v1.0.11 // indirect
-	github.com/monetarium/monetarium-node/dcrec/secp256k1 v1.0.11 // indirect
-	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
-	golang.org/x/crypto v0.24.0 // indirect
-	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
 	lukechampine.com/blake3 v1.3.0 // indirect
 )
+
+replace github.com/monetarium/monetarium-node/wire => ../wire
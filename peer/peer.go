@@ -25,12 +25,13 @@ import (
 	"github.com/monetarium/monetarium-node/container/lru"
 	"github.com/monetarium/monetarium-node/crypto/blake256"
 	"github.com/monetarium/monetarium-node/crypto/rand"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
 	"github.com/monetarium/monetarium-node/wire"
 )
 
 const (
 	// MaxProtocolVersion is the max protocol version the peer supports.
-	MaxProtocolVersion = wire.BatchedCFiltersV2Version
+	MaxProtocolVersion = wire.AddrV2Version
 
 	// outputBufferSize is the number of elements the output channels use.
 	outputBufferSize = 5000
@@ -108,6 +109,9 @@ type MessageListeners struct {
 	// OnAddr is invoked when a peer receives an addr wire message.
 	OnAddr func(p *Peer, msg *wire.MsgAddr)
 
+	// OnAddrV2 is invoked when a peer receives an addrv2 wire message.
+	OnAddrV2 func(p *Peer, msg *wire.MsgAddrV2)
+
 	// OnPing is invoked when a peer receives a ping wire message.
 	OnPing func(p *Peer, msg *wire.MsgPing)
 
@@ -206,6 +210,23 @@ type MessageListeners struct {
 	// message.
 	OnSendHeaders func(p *Peer, msg *wire.MsgSendHeaders)
 
+	// OnSendCmpct is invoked when a peer receives a sendcmpct wire message.
+	OnSendCmpct func(p *Peer, msg *wire.MsgSendCmpct)
+
+	// OnCmpctBlock is invoked when a peer receives a cmpctblock wire
+	// message.
+	OnCmpctBlock func(p *Peer, msg *wire.MsgCmpctBlock)
+
+	// OnGetBlockTxns is invoked when a peer receives a getblocktxns wire
+	// message.
+	OnGetBlockTxns func(p *Peer, msg *wire.MsgGetBlockTxns)
+
+	// OnBlockTxns is invoked when a peer receives a blocktxns wire message.
+	OnBlockTxns func(p *Peer, msg *wire.MsgBlockTxns)
+
+	// OnStemTx is invoked when a peer receives a stemtx wire message.
+	OnStemTx func(p *Peer, msg *wire.MsgStemTx)
+
 	// OnGetInitState is invoked when a peer receives a getinitstate wire
 	// message.
 	OnGetInitState func(p *Peer, msg *wire.MsgGetInitState)
@@ -312,6 +333,45 @@ type Config struct {
 	// IdleTimeout is the duration of inactivity before a peer is timed
 	// out in seconds.
 	IdleTimeout time.Duration
+
+	// V2Transport specifies whether or not to use the encrypted v2 transport.
+	// Outbound peers attempt the v2 transport handshake while inbound peers
+	// accept both the v1 and v2 transports when it is set.
+	V2Transport bool
+
+	// OnV2TransportFailed specifies a callback which is invoked when the
+	// remote peer of an outbound connection closes the connection without
+	// responding to the v2 transport handshake, which typically means it only
+	// supports the v1 transport.  It is not invoked for other failures, such
+	// as timeouts.  Callers may use it to reconnect using the v1 transport,
+	// however, an attacker that is able to interfere with the connection is
+	// also able to trigger it, so a single failure should not be treated as
+	// conclusive.  This can be nil.
+	OnV2TransportFailed func(p *Peer)
+
+	// TransportKey specifies an optional static private key that is used to
+	// authenticate to remote peers when using the v2 transport.  The peer is
+	// anonymous when it is nil.
+	TransportKey *secp256k1.PrivateKey
+
+	// RequireAuth specifies whether or not the remote peer is required to use
+	// the v2 transport and authenticate with one of the static keys in
+	// AuthorizedKeys.  The connection is not allowed to fall back to the v1
+	// transport when it is set.
+	RequireAuth bool
+
+	// AuthorizedKeys specifies the static public keys remote peers are
+	// allowed to authenticate with when RequireAuth is set.
+	AuthorizedKeys []*secp256k1.PublicKey
+
+	// MaxUploadRate specifies the max number of bytes per second that may be
+	// sent to the peer.  There is no per-peer limit when it is zero.
+	MaxUploadRate uint64
+
+	// UploadLimiter specifies an optional rate limiter that is shared by
+	// multiple peers to limit the combined rate of data sent to all of them.
+	// There is no combined limit when it is nil.
+	UploadLimiter *RateLimiter
 }
 
 // minUint32 is a helper function to return the minimum of two uint32s.
@@ -397,23 +457,26 @@ type stallControlMsg struct {
 
 // StatsSnap is a snapshot of peer stats at a point in time.
 type StatsSnap struct {
-	ID             int32
-	Addr           string
-	Services       wire.ServiceFlag
-	LastSend       time.Time
-	LastRecv       time.Time
-	BytesSent      uint64
-	BytesRecv      uint64
-	ConnTime       time.Time
-	TimeOffset     int64
-	Version        uint32
-	UserAgent      string
-	Inbound        bool
-	StartingHeight int64
-	LastBlock      int64
-	LastPingNonce  uint64
-	LastPingTime   time.Time
-	LastPingMicros int64
+	ID              int32
+	Addr            string
+	Services        wire.ServiceFlag
+	LastSend        time.Time
+	LastRecv        time.Time
+	BytesSent       uint64
+	BytesRecv       uint64
+	BytesSentPerMsg map[string]uint64
+	BytesRecvPerMsg map[string]uint64
+	ConnTime        time.Time
+	TimeOffset      int64
+	Version         uint32
+	UserAgent       string
+	Inbound         bool
+	StartingHeight  int64
+	LastBlock       int64
+	LastPingNonce   uint64
+	LastPingTime    time.Time
+	LastPingMicros  int64
+	Transport       TransportType
 }
 
 // HashFunc is a function which returns a block hash, height and error
@@ -465,6 +528,16 @@ type Peer struct {
 	conn    net.Conn
 	connMtx sync.Mutex
 
+	// uploadLimiters houses the rate limiters that apply to data sent to the
+	// peer.  It is set at creation time and never modified.
+	uploadLimiters []*RateLimiter
+
+	// msgStatsMtx protects the per-message byte counters below which track
+	// the number of bytes sent and received by message command.
+	msgStatsMtx     sync.Mutex
+	bytesSentPerMsg map[string]uint64
+	bytesRecvPerMsg map[string]uint64
+
 	// blake256Hasher is the hash.Hash object that is used by readMessage
 	// to calculate the hash of read mixing messages.  Every peer's hasher
 	// is a distinct object and does not require locking.
@@ -485,8 +558,11 @@ type Peer struct {
 	advertisedProtoVer   uint32 // protocol version advertised by remote
 	protocolVersion      uint32 // negotiated protocol version
 	sendHeadersPreferred bool   // peer sent a sendheaders message
+	sendCmpctPreferred   bool   // peer sent a sendcmpct message to announce
 	versionSent          bool
 	verAckReceived       bool
+	transport            TransportType
+	remoteStaticKey      *secp256k1.PublicKey
 
 	knownInventory     *lru.Set[wire.InvVect]
 	prevGetBlocksMtx   sync.Mutex
@@ -569,27 +645,31 @@ func (p *Peer) StatsSnapshot() *StatsSnap {
 	userAgent := p.userAgent
 	services := p.services
 	protocolVersion := p.advertisedProtoVer
+	transport := p.transport
 	p.flagsMtx.Unlock()
 
 	// Get a copy of all relevant flags and stats.
 	statsSnap := &StatsSnap{
-		ID:             id,
-		Addr:           addr,
-		UserAgent:      userAgent,
-		Services:       services,
-		LastSend:       p.LastSend(),
-		LastRecv:       p.LastRecv(),
-		BytesSent:      p.BytesSent(),
-		BytesRecv:      p.BytesReceived(),
-		ConnTime:       p.timeConnected,
-		TimeOffset:     p.timeOffset,
-		Version:        protocolVersion,
-		Inbound:        p.inbound,
-		StartingHeight: p.startingHeight,
-		LastBlock:      p.lastBlock,
-		LastPingNonce:  p.lastPingNonce,
-		LastPingMicros: p.lastPingMicros,
-		LastPingTime:   p.lastPingTime,
+		ID:              id,
+		Addr:            addr,
+		UserAgent:       userAgent,
+		Services:        services,
+		LastSend:        p.LastSend(),
+		LastRecv:        p.LastRecv(),
+		BytesSent:       p.BytesSent(),
+		BytesRecv:       p.BytesReceived(),
+		BytesSentPerMsg: p.BytesSentPerMsg(),
+		BytesRecvPerMsg: p.BytesReceivedPerMsg(),
+		ConnTime:        p.timeConnected,
+		TimeOffset:      p.timeOffset,
+		Version:         protocolVersion,
+		Inbound:         p.inbound,
+		StartingHeight:  p.startingHeight,
+		LastBlock:       p.lastBlock,
+		LastPingNonce:   p.lastPingNonce,
+		LastPingMicros:  p.lastPingMicros,
+		LastPingTime:    p.lastPingTime,
+		Transport:       transport,
 	}
 
 	p.statsMtx.RUnlock()
@@ -765,6 +845,41 @@ func (p *Peer) LocalAddr() net.Addr {
 	return localAddr
 }
 
+// addMsgBytes adds the provided number of bytes to the provided per-message
+// byte counters for the provided message command.
+func (p *Peer) addMsgBytes(counters map[string]uint64, command string, n int) {
+	p.msgStatsMtx.Lock()
+	counters[command] += uint64(n)
+	p.msgStatsMtx.Unlock()
+}
+
+// copyMsgBytes returns a copy of the provided per-message byte counters.
+func (p *Peer) copyMsgBytes(counters map[string]uint64) map[string]uint64 {
+	p.msgStatsMtx.Lock()
+	result := make(map[string]uint64, len(counters))
+	for command, n := range counters {
+		result[command] = n
+	}
+	p.msgStatsMtx.Unlock()
+	return result
+}
+
+// BytesSentPerMsg returns the total number of bytes sent to the peer keyed by
+// message command.
+//
+// This function is safe for concurrent access.
+func (p *Peer) BytesSentPerMsg() map[string]uint64 {
+	return p.copyMsgBytes(p.bytesSentPerMsg)
+}
+
+// BytesReceivedPerMsg returns the total number of bytes received from the peer
+// keyed by message command.
+//
+// This function is safe for concurrent access.
+func (p *Peer) BytesReceivedPerMsg() map[string]uint64 {
+	return p.copyMsgBytes(p.bytesRecvPerMsg)
+}
+
 // BytesSent returns the total number of bytes sent by the peer.
 //
 // This function is safe for concurrent access.
@@ -827,6 +942,19 @@ func (p *Peer) WantsHeaders() bool {
 	return sendHeadersPreferred
 }
 
+// WantsCmpctBlocks returns if the peer wants new blocks to be announced via
+// compact block messages in the compact block protocol version implemented
+// by the wire package.
+//
+// This function is safe for concurrent access.
+func (p *Peer) WantsCmpctBlocks() bool {
+	p.flagsMtx.Lock()
+	sendCmpctPreferred := p.sendCmpctPreferred
+	p.flagsMtx.Unlock()
+
+	return sendCmpctPreferred
+}
+
 // PushAddrMsg sends an addr message to the connected peer using the provided
 // addresses.  This function is useful over manually sending the message via
 // QueueMessage since it automatically limits the addresses to the maximum
@@ -858,6 +986,41 @@ func (p *Peer) PushAddrMsg(addresses []*wire.NetAddress) ([]*wire.NetAddress, er
 	return msg.AddrList, nil
 }
 
+// PushAddrV2Msg sends an addrv2 message to the connected peer using the
+// provided addresses.  This function is useful over manually sending the
+// message via QueueMessage since it automatically limits the addresses to the
+// maximum number allowed by the message and randomizes the chosen addresses
+// when there are too many.  It returns the addresses that were actually sent
+// and no message will be sent if there are no entries in the provided
+// addresses slice.
+//
+// The remote peer must have negotiated a protocol version of at least
+// wire.AddrV2Version.
+//
+// This function is safe for concurrent access.
+func (p *Peer) PushAddrV2Msg(addresses []*wire.NetAddressV2) ([]*wire.NetAddressV2, error) {
+	// Nothing to send.
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	msg := wire.NewMsgAddrV2()
+	msg.AddrList = make([]*wire.NetAddressV2, len(addresses))
+	copy(msg.AddrList, addresses)
+
+	// Randomize the addresses sent if there are more than the maximum allowed.
+	if len(msg.AddrList) > wire.MaxAddrPerV2Msg {
+		// Shuffle the address list.
+		rand.ShuffleSlice(msg.AddrList)
+
+		// Truncate it to the maximum size.
+		msg.AddrList = msg.AddrList[:wire.MaxAddrPerV2Msg]
+	}
+
+	p.QueueMessage(msg, nil)
+	return msg.AddrList, nil
+}
+
 // PushGetBlocksMsg sends a getblocks message for the provided block locator
 // and stop hash.  It will ignore back-to-back duplicate requests.
 //
@@ -995,6 +1158,9 @@ func (p *Peer) readMessage() (wire.Message, []byte, error) {
 	n, msg, buf, err := wire.ReadMessageN(p.conn, p.ProtocolVersion(),
 		p.cfg.Net)
 	atomic.AddUint64(&p.bytesReceived, uint64(n))
+	if msg != nil {
+		p.addMsgBytes(p.bytesRecvPerMsg, msg.Command(), n)
+	}
 
 	// Calculate and store the message hash of any mixing message
 	// immediately after deserializing it.
@@ -1054,9 +1220,16 @@ func (p *Peer) writeMessage(msg wire.Message) error {
 		}
 	}
 
-	// Write the message to the peer.
-	n, err := wire.WriteMessageN(p.conn, msg, p.ProtocolVersion(), p.cfg.Net)
+	// Write the message to the peer while remaining within the upload rate
+	// limits when there are any.
+	var w io.Writer = p.conn
+	if len(p.uploadLimiters) > 0 {
+		w = &rateLimitedWriter{w: p.conn, limiters: p.uploadLimiters,
+			quit: p.quit}
+	}
+	n, err := wire.WriteMessageN(w, msg, p.ProtocolVersion(), p.cfg.Net)
 	atomic.AddUint64(&p.bytesSent, uint64(n))
+	p.addMsgBytes(p.bytesSentPerMsg, msg.Command(), n)
 	if p.cfg.Listeners.OnWrite != nil {
 		p.cfg.Listeners.OnWrite(p, n, msg, err)
 	}
@@ -1141,6 +1314,10 @@ func (p *Peer) maybeAddDeadline(pendingResponses map[string]time.Time, msgCmd st
 	case wire.CmdGetInitState:
 		pendingResponses[wire.CmdInitState] = deadline
 		addedDeadline = true
+
+	case wire.CmdGetBlockTxns:
+		pendingResponses[wire.CmdBlockTxns] = deadline
+		addedDeadline = true
 	}
 
 	if addedDeadline {
@@ -1393,6 +1570,11 @@ out:
 				p.cfg.Listeners.OnAddr(p, msg)
 			}
 
+		case *wire.MsgAddrV2:
+			if p.cfg.Listeners.OnAddrV2 != nil {
+				p.cfg.Listeners.OnAddrV2(p, msg)
+			}
+
 		case *wire.MsgPing:
 			p.handlePingMsg(msg)
 			if p.cfg.Listeners.OnPing != nil {
@@ -1504,6 +1686,40 @@ out:
 				p.cfg.Listeners.OnSendHeaders(p, msg)
 			}
 
+		case *wire.MsgSendCmpct:
+			// Only the compact block protocol version implemented by
+			// the wire package is supported, so ignore requests for
+			// other versions.
+			if msg.Version == wire.CmpctBlockProtocol {
+				p.flagsMtx.Lock()
+				p.sendCmpctPreferred = msg.Announce
+				p.flagsMtx.Unlock()
+			}
+
+			if p.cfg.Listeners.OnSendCmpct != nil {
+				p.cfg.Listeners.OnSendCmpct(p, msg)
+			}
+
+		case *wire.MsgCmpctBlock:
+			if p.cfg.Listeners.OnCmpctBlock != nil {
+				p.cfg.Listeners.OnCmpctBlock(p, msg)
+			}
+
+		case *wire.MsgGetBlockTxns:
+			if p.cfg.Listeners.OnGetBlockTxns != nil {
+				p.cfg.Listeners.OnGetBlockTxns(p, msg)
+			}
+
+		case *wire.MsgBlockTxns:
+			if p.cfg.Listeners.OnBlockTxns != nil {
+				p.cfg.Listeners.OnBlockTxns(p, msg)
+			}
+
+		case *wire.MsgStemTx:
+			if p.cfg.Listeners.OnStemTx != nil {
+				p.cfg.Listeners.OnStemTx(p, msg)
+			}
+
 		case *wire.MsgGetCFilterV2:
 			if p.cfg.Listeners.OnGetCFilterV2 != nil {
 				p.cfg.Listeners.OnGetCFilterV2(p, msg)
@@ -2114,6 +2330,10 @@ func (p *Peer) start() error {
 
 	negotiateErr := make(chan error, 1)
 	go func() {
+		if err := p.negotiateTransport(); err != nil {
+			negotiateErr <- err
+			return
+		}
 		if p.inbound {
 			negotiateErr <- p.negotiateInboundProtocol()
 		} else {
@@ -2132,7 +2352,7 @@ func (p *Peer) start() error {
 		p.Disconnect()
 		return errors.New("protocol negotiation timeout")
 	}
-	log.Debugf("Connected to %s", p.Addr())
+	log.Debugf("Connected to %s (%s transport)", p.Addr(), p.Transport())
 
 	// The protocol has been negotiated successfully so start processing input
 	// and output messages.
@@ -2240,6 +2460,15 @@ func newPeerBase(cfgOrig *Config, inbound bool) *Peer {
 		cfg:             cfg,
 		services:        cfg.Services,
 		protocolVersion: protocolVersion,
+		bytesSentPerMsg: make(map[string]uint64),
+		bytesRecvPerMsg: make(map[string]uint64),
+	}
+	if cfg.UploadLimiter != nil {
+		p.uploadLimiters = append(p.uploadLimiters, cfg.UploadLimiter)
+	}
+	if cfg.MaxUploadRate != 0 {
+		p.uploadLimiters = append(p.uploadLimiters,
+			NewRateLimiter(cfg.MaxUploadRate))
 	}
 	return &p
 }
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"io"
 	"net"
+	"reflect"
 	"strconv"
 	"sync"
 	"testing"
@@ -108,6 +109,7 @@ type peerStats struct {
 	wantTimeOffset      int64
 	wantBytesSent       uint64
 	wantBytesReceived   uint64
+	wantBytesPerMsg     map[string]uint64
 }
 
 // testPeer tests the given peer's flags and stats.
@@ -206,6 +208,18 @@ func testPeer(t *testing.T, p *Peer, s peerStats) {
 		t.Errorf("testPeer: wrong LastRecv - got %v, want %v", p.LastRecv(), stats.LastRecv)
 		return
 	}
+
+	if !reflect.DeepEqual(stats.BytesSentPerMsg, s.wantBytesPerMsg) {
+		t.Errorf("testPeer: wrong BytesSentPerMsg - got %v, want %v",
+			stats.BytesSentPerMsg, s.wantBytesPerMsg)
+		return
+	}
+
+	if !reflect.DeepEqual(stats.BytesRecvPerMsg, s.wantBytesPerMsg) {
+		t.Errorf("testPeer: wrong BytesRecvPerMsg - got %v, want %v",
+			stats.BytesRecvPerMsg, s.wantBytesPerMsg)
+		return
+	}
 }
 
 // TestPeerConnection tests connection between inbound and outbound peers.
@@ -246,6 +260,10 @@ func TestPeerConnection(t *testing.T) {
 		wantTimeOffset:      int64(0),
 		wantBytesSent:       161, // 137 version + 24 verack
 		wantBytesReceived:   161,
+		wantBytesPerMsg: map[string]uint64{
+			wire.CmdVersion: 137,
+			wire.CmdVerAck:  24,
+		},
 	}
 	tests := []struct {
 		name  string
@@ -417,6 +435,24 @@ func TestPeerListeners(t *testing.T) {
 			OnCFiltersV2: func(p *Peer, msg *wire.MsgCFiltersV2) {
 				ok <- msg
 			},
+			OnSendCmpct: func(p *Peer, msg *wire.MsgSendCmpct) {
+				ok <- msg
+			},
+			OnCmpctBlock: func(p *Peer, msg *wire.MsgCmpctBlock) {
+				ok <- msg
+			},
+			OnGetBlockTxns: func(p *Peer, msg *wire.MsgGetBlockTxns) {
+				ok <- msg
+			},
+			OnBlockTxns: func(p *Peer, msg *wire.MsgBlockTxns) {
+				ok <- msg
+			},
+			OnStemTx: func(p *Peer, msg *wire.MsgStemTx) {
+				ok <- msg
+			},
+			OnAddrV2: func(p *Peer, msg *wire.MsgAddrV2) {
+				ok <- msg
+			},
 		},
 		UserAgentName:    "peer",
 		UserAgentVersion: "1.0",
@@ -579,6 +615,30 @@ func TestPeerListeners(t *testing.T) {
 			"OnCFiltersV2",
 			wire.NewMsgCFiltersV2([]wire.MsgCFilterV2{}),
 		},
+		{
+			"OnSendCmpct",
+			wire.NewMsgSendCmpct(true, wire.CmpctBlockProtocol),
+		},
+		{
+			"OnCmpctBlock",
+			wire.NewMsgCmpctBlock(&wire.BlockHeader{}, 0),
+		},
+		{
+			"OnGetBlockTxns",
+			wire.NewMsgGetBlockTxns(&chainhash.Hash{}, nil, nil),
+		},
+		{
+			"OnBlockTxns",
+			wire.NewMsgBlockTxns(&chainhash.Hash{}),
+		},
+		{
+			"OnStemTx",
+			wire.NewMsgStemTx(wire.NewMsgTx()),
+		},
+		{
+			"OnAddrV2",
+			wire.NewMsgAddrV2(),
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for _, test := range tests {
@@ -760,6 +820,15 @@ func TestOutboundPeer(t *testing.T) {
 		t.Errorf("PushAddrMsg: unexpected err %v\n", err)
 		return
 	}
+	var addrsV2 []*wire.NetAddressV2
+	for i := 0; i < 5; i++ {
+		na := wire.NetAddressV2{}
+		addrsV2 = append(addrsV2, &na)
+	}
+	if _, err := p2.PushAddrV2Msg(addrsV2); err != nil {
+		t.Errorf("PushAddrV2Msg: unexpected err %v\n", err)
+		return
+	}
 	if err := p2.PushGetBlocksMsg(nil, &chainhash.Hash{}); err != nil {
 		t.Errorf("PushGetBlocksMsg: unexpected err %v\n", err)
 		return
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package peer
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// uploadChunkSize is the max number of bytes written to the connection at once
+// when the upload rate is limited.  Writing large messages such as blocks in
+// chunks spreads them out over time instead of sending them in a burst
+// followed by a long pause.
+const uploadChunkSize = 16 * 1024
+
+// errRateLimitQuit is returned when a rate limited write is interrupted due to
+// the peer disconnecting.
+var errRateLimitQuit = errors.New("peer disconnected while waiting for " +
+	"upload bandwidth")
+
+// RateLimiter limits the rate of data transferred to a max number of bytes per
+// second by way of a token bucket that allows bursts of up to one second worth
+// of data.
+//
+// The same rate limiter may be provided to multiple peers to limit the
+// combined rate of all of them.  It is safe for concurrent access.
+type RateLimiter struct {
+	mtx    sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a rate limiter that limits the transfer rate to the
+// provided number of bytes per second.
+func NewRateLimiter(bytesPerSecond uint64) *RateLimiter {
+	burst := float64(bytesPerSecond)
+	if burst < uploadChunkSize {
+		burst = uploadChunkSize
+	}
+	return &RateLimiter{
+		rate:   float64(bytesPerSecond),
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+// Rate returns the max number of bytes per second allowed by the rate limiter.
+func (l *RateLimiter) Rate() uint64 {
+	return uint64(l.rate)
+}
+
+// reserve reserves the provided number of bytes as of the provided time and
+// returns how long the caller must wait before transferring them in order to
+// stay within the rate limit.
+func (l *RateLimiter) reserve(now time.Time, n int) time.Duration {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	// Refill the bucket based on the time elapsed since the last reservation.
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	if now.After(l.last) {
+		l.last = now
+	}
+
+	// Allow the bucket to go into debt so callers are served in the order
+	// they reserved and wait for the debt to be paid off.
+	l.tokens -= float64(n)
+	if l.tokens >= 0 || l.rate == 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// rateLimitedWriter is an io.Writer that limits the rate of writes to the
+// underlying writer according to a set of rate limiters.
+type rateLimitedWriter struct {
+	w        io.Writer
+	limiters []*RateLimiter
+	quit     <-chan struct{}
+}
+
+// Write writes the provided data to the underlying writer in chunks while
+// waiting as needed to remain within the limits of all rate limiters.
+//
+// This is part of the io.Writer interface.
+func (w *rateLimitedWriter) Write(b []byte) (int, error) {
+	var written int
+	for len(b) > 0 {
+		chunk := b
+		if len(chunk) > uploadChunkSize {
+			chunk = chunk[:uploadChunkSize]
+		}
+
+		var wait time.Duration
+		now := time.Now()
+		for _, limiter := range w.limiters {
+			if d := limiter.reserve(now, len(chunk)); d > wait {
+				wait = d
+			}
+		}
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-w.quit:
+				timer.Stop()
+				return written, errRateLimitQuit
+			case <-timer.C:
+			}
+		}
+
+		n, err := w.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		b = b[n:]
+	}
+	return written, nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package peer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRateLimiterReserve ensures the rate limiter allows bursts up to its
+// capacity and requires waiting for the expected amount of time once the
+// capacity is exhausted.
+func TestRateLimiterReserve(t *testing.T) {
+	const rate = 100000
+	l := NewRateLimiter(rate)
+	if l.Rate() != rate {
+		t.Fatalf("mismatched rate: got %d, want %d", l.Rate(), rate)
+	}
+
+	// The full burst is available immediately.
+	now := time.Unix(1700000000, 0)
+	if wait := l.reserve(now, rate); wait != 0 {
+		t.Fatalf("unexpected wait for burst: %v", wait)
+	}
+
+	// Reserving more without time passing requires waiting for the deficit
+	// to be refilled.
+	if wait := l.reserve(now, rate/2); wait != 500*time.Millisecond {
+		t.Fatalf("mismatched wait: got %v, want %v", wait, 500*time.Millisecond)
+	}
+
+	// The deficit is paid off after enough time passes and the bucket refills
+	// to at most the burst size.
+	now = now.Add(10 * time.Second)
+	if wait := l.reserve(now, rate); wait != 0 {
+		t.Fatalf("unexpected wait after refill: %v", wait)
+	}
+	if wait := l.reserve(now, rate/10); wait != 100*time.Millisecond {
+		t.Fatalf("mismatched wait: got %v, want %v", wait, 100*time.Millisecond)
+	}
+
+	// Small rates still allow writing a full chunk at once.
+	l = NewRateLimiter(1)
+	if wait := l.reserve(now, uploadChunkSize); wait != 0 {
+		t.Fatalf("unexpected wait for chunk: %v", wait)
+	}
+}
+
+// TestRateLimitedWriter ensures writes through a rate limited writer are
+// written in full and are interrupted when the quit channel is closed.
+func TestRateLimitedWriter(t *testing.T) {
+	data := bytes.Repeat([]byte{0x01}, uploadChunkSize*3+100)
+
+	// Writes within the burst size of the limiter do not wait.
+	var buf bytes.Buffer
+	w := &rateLimitedWriter{
+		w:        &buf,
+		limiters: []*RateLimiter{NewRateLimiter(uint64(len(data)))},
+		quit:     make(chan struct{}),
+	}
+	n, err := w.Write(data)
+	if err != nil {
+		t.Fatalf("unexpected write error: %v", err)
+	}
+	if n != len(data) || !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("mismatched written data: wrote %d bytes, want %d", n,
+			len(data))
+	}
+
+	// Writes that exceed the limit are interrupted by quit.
+	quit := make(chan struct{})
+	close(quit)
+	buf.Reset()
+	w = &rateLimitedWriter{
+		w:        &buf,
+		limiters: []*RateLimiter{NewRateLimiter(1)},
+		quit:     quit,
+	}
+	n, err = w.Write(data)
+	if !errors.Is(err, errRateLimitQuit) {
+		t.Fatalf("mismatched error: got %v, want %v", err, errRateLimitQuit)
+	}
+	if n != uploadChunkSize {
+		t.Fatalf("mismatched written bytes: got %d, want %d", n,
+			uploadChunkSize)
+	}
+}
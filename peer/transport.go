@@ -0,0 +1,481 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package peer
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"syscall"
+
+	"github.com/monetarium/monetarium-node/crypto/blake256"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1/ecdsa"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// TransportType identifies the transport used to communicate with a peer.
+type TransportType uint8
+
+const (
+	// TransportV1 is the original plaintext transport that sends wire
+	// messages directly over the connection.
+	TransportV1 TransportType = iota
+
+	// TransportV2 is the encrypted and authenticated transport that is
+	// negotiated via an ephemeral ECDH key exchange.
+	TransportV2
+)
+
+// transportTypeStrings is a map of transport types back to their constant
+// names for pretty printing.
+var transportTypeStrings = map[TransportType]string{
+	TransportV1: "v1",
+	TransportV2: "v2",
+}
+
+// String returns the TransportType in human-readable form.
+func (t TransportType) String() string {
+	if s, ok := transportTypeStrings[t]; ok {
+		return s
+	}
+	return fmt.Sprintf("Unknown TransportType (%d)", uint8(t))
+}
+
+const (
+	// transportPubKeySize is the size of the serialized ephemeral public keys
+	// that are exchanged at the start of the v2 transport handshake.
+	transportPubKeySize = secp256k1.PubKeyBytesLenCompressed
+
+	// transportAuthSigSize is the size of the compact signature that is sent
+	// by peers that authenticate with a static key.
+	transportAuthSigSize = 65
+
+	// transportLenSize is the size of the encoded frame payload length.
+	transportLenSize = 3
+
+	// maxTransportFrameSize is the maximum size of the payload of a single
+	// encrypted frame.  Writes that are larger than this are split into
+	// multiple frames.
+	maxTransportFrameSize = 1<<(transportLenSize*8) - 1
+
+	// transportRekeyInterval is the number of encryption operations after
+	// which the keys of each direction are rotated in order to limit the
+	// amount of data that is encrypted with a single key and provide forward
+	// secrecy within long lived connections.
+	transportRekeyInterval = 1 << 16
+)
+
+var (
+	// transportKDFSalt is the salt used when deriving the session keys from
+	// the shared secret of the v2 transport handshake.  It is combined with
+	// the network magic so keys are never shared across networks.
+	transportKDFSalt = []byte("monetarium v2 transport")
+
+	// ErrTransportAuth indicates the remote peer did not authenticate with
+	// one of the authorized static keys when authentication is required.
+	ErrTransportAuth = errors.New("v2 transport authentication failed")
+)
+
+// transportCipher houses the state of the authenticated encryption for one
+// direction of a v2 transport connection.
+type transportCipher struct {
+	key   [chacha20poly1305.KeySize]byte
+	aead  cipher.AEAD
+	ops   uint64
+	epoch uint64
+	nonce [chacha20poly1305.NonceSize]byte
+}
+
+// newTransportCipher returns a transport cipher that uses the provided key.
+func newTransportCipher(key []byte) *transportCipher {
+	var c transportCipher
+	copy(c.key[:], key)
+	c.aead, _ = chacha20poly1305.New(c.key[:])
+	return &c
+}
+
+// nextNonce returns the nonce to use for the next encryption operation and
+// rotates the key once the rekey interval is reached.
+func (c *transportCipher) nextNonce() []byte {
+	if c.ops == transportRekeyInterval {
+		c.rekey()
+	}
+	binary.LittleEndian.PutUint32(c.nonce[0:4], 0)
+	binary.LittleEndian.PutUint64(c.nonce[4:12], c.ops)
+	c.ops++
+	return c.nonce[:]
+}
+
+// rekey deterministically derives a new key from the current one by
+// encrypting zeros with a nonce that is never used for regular data.  Both
+// sides of the connection perform the same operation after the same number of
+// encryption operations so they remain in sync.
+func (c *transportCipher) rekey() {
+	var nonce [chacha20poly1305.NonceSize]byte
+	binary.LittleEndian.PutUint32(nonce[0:4], 0xffffffff)
+	binary.LittleEndian.PutUint64(nonce[4:12], c.epoch)
+	var zeros [chacha20poly1305.KeySize]byte
+	newKey := c.aead.Seal(nil, nonce[:], zeros[:], nil)
+	copy(c.key[:], newKey)
+	c.aead, _ = chacha20poly1305.New(c.key[:])
+	c.ops = 0
+	c.epoch++
+}
+
+// seal encrypts and authenticates the plaintext, appends the result to dst,
+// and returns the updated slice.
+func (c *transportCipher) seal(dst, plaintext []byte) []byte {
+	return c.aead.Seal(dst, c.nextNonce(), plaintext, nil)
+}
+
+// open authenticates and decrypts the ciphertext and returns the plaintext.
+func (c *transportCipher) open(ciphertext []byte) ([]byte, error) {
+	return c.aead.Open(ciphertext[:0], c.nextNonce(), ciphertext, nil)
+}
+
+// prefixConn is a net.Conn that returns the provided prefix bytes prior to any
+// further data read from the underlying connection.  It is used to replay the
+// bytes that are read to detect the transport type of inbound connections.
+type prefixConn struct {
+	net.Conn
+	r io.Reader
+}
+
+// Read reads data from the prefix followed by the underlying connection.
+//
+// This is part of the net.Conn interface.
+func (c *prefixConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// v2Conn is a net.Conn that encrypts and authenticates all data written to and
+// read from the underlying connection.
+//
+// Each write is sent as one or more frames which consist of the encrypted
+// payload length followed by the encrypted payload.  The length is encrypted
+// separately so that it can be authenticated prior to reading the payload
+// while not revealing message boundaries to observers.
+type v2Conn struct {
+	net.Conn
+	r io.Reader
+
+	writeMtx sync.Mutex
+	send     *transportCipher
+
+	recv    *transportCipher
+	readBuf []byte
+}
+
+// writeFrame encrypts and writes the provided payload as a single frame.  The
+// payload must not be larger than the max frame size.
+//
+// This function MUST be called with the write mutex held (for writes).
+func (c *v2Conn) writeFrame(payload []byte) error {
+	var lenBytes [4]byte
+	binary.LittleEndian.PutUint32(lenBytes[:], uint32(len(payload)))
+	frame := make([]byte, 0, transportLenSize+len(payload)+
+		2*chacha20poly1305.Overhead)
+	frame = c.send.seal(frame, lenBytes[:transportLenSize])
+	frame = c.send.seal(frame, payload)
+	_, err := c.Conn.Write(frame)
+	return err
+}
+
+// readFrame reads, authenticates, and decrypts the next frame and returns its
+// payload.
+func (c *v2Conn) readFrame() ([]byte, error) {
+	var encLen [transportLenSize + chacha20poly1305.Overhead]byte
+	if _, err := io.ReadFull(c.r, encLen[:]); err != nil {
+		return nil, err
+	}
+	lenBytes, err := c.recv.open(encLen[:])
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt frame length: %w", err)
+	}
+	var paddedLen [4]byte
+	copy(paddedLen[:], lenBytes)
+	payloadLen := binary.LittleEndian.Uint32(paddedLen[:])
+
+	payload := make([]byte, payloadLen+chacha20poly1305.Overhead)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return nil, err
+	}
+	payload, err = c.recv.open(payload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt frame: %w", err)
+	}
+	return payload, nil
+}
+
+// Read reads decrypted data from the connection.
+//
+// This is part of the net.Conn interface.
+func (c *v2Conn) Read(b []byte) (int, error) {
+	for len(c.readBuf) == 0 {
+		payload, err := c.readFrame()
+		if err != nil {
+			return 0, err
+		}
+		c.readBuf = payload
+	}
+	n := copy(b, c.readBuf)
+	c.readBuf = c.readBuf[n:]
+	return n, nil
+}
+
+// Write encrypts and writes data to the connection.
+//
+// This is part of the net.Conn interface.
+func (c *v2Conn) Write(b []byte) (int, error) {
+	c.writeMtx.Lock()
+	defer c.writeMtx.Unlock()
+
+	var n int
+	for len(b) > 0 {
+		chunk := b[:min(len(b), maxTransportFrameSize)]
+		if err := c.writeFrame(chunk); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+		b = b[len(chunk):]
+	}
+	return n, nil
+}
+
+// transportAuthHash returns the hash that is signed by a peer to authenticate
+// with its static key for the session with the provided ID.  The role of the
+// signer is committed to in order to prevent signatures from being reflected
+// back to the peer that created them.
+func transportAuthHash(sessionID []byte, initiator bool) []byte {
+	role := byte('r')
+	if initiator {
+		role = 'i'
+	}
+	h := blake256.New()
+	h.Write(sessionID)
+	h.Write([]byte{role})
+	return h.Sum(nil)
+}
+
+// netMagic returns the serialized network magic for the peer that begins all
+// v1 transport messages.
+func (p *Peer) netMagic() [4]byte {
+	var magic [4]byte
+	binary.LittleEndian.PutUint32(magic[:], uint32(p.cfg.Net))
+	return magic
+}
+
+// setTransport updates the connection of the peer along with the negotiated
+// transport details.
+func (p *Peer) setTransport(conn net.Conn, transport TransportType, remoteKey *secp256k1.PublicKey) {
+	p.connMtx.Lock()
+	p.conn = conn
+	p.connMtx.Unlock()
+
+	p.flagsMtx.Lock()
+	p.transport = transport
+	p.remoteStaticKey = remoteKey
+	p.flagsMtx.Unlock()
+}
+
+// isRemoteClose returns whether or not the provided error from reading from or
+// writing to a connection indicates the remote peer closed it.
+func isRemoteClose(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.ErrClosedPipe) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+}
+
+// negotiateTransport negotiates the transport to use with the remote peer
+// prior to the version handshake.
+//
+// Outbound peers that are configured to use the v2 transport initiate the
+// handshake immediately.  Inbound peers that are configured to use it accept
+// both transports by inspecting the first bytes sent by the remote peer since
+// v1 transport messages always begin with the network magic.
+func (p *Peer) negotiateTransport() error {
+	if !p.cfg.V2Transport && !p.cfg.RequireAuth {
+		return nil
+	}
+
+	ourMagic := p.netMagic()
+	var remotePubKey []byte
+	if p.inbound {
+		remotePubKey = make([]byte, transportPubKeySize)
+		if _, err := io.ReadFull(p.conn, remotePubKey[:len(ourMagic)]); err != nil {
+			return err
+		}
+		if bytes.Equal(remotePubKey[:len(ourMagic)], ourMagic[:]) {
+			if p.cfg.RequireAuth {
+				return fmt.Errorf("%w: remote peer uses the v1 transport",
+					ErrTransportAuth)
+			}
+			prefix := bytes.NewReader(remotePubKey[:len(ourMagic)])
+			conn := &prefixConn{
+				Conn: p.conn,
+				r:    io.MultiReader(prefix, p.conn),
+			}
+			p.setTransport(conn, TransportV1, nil)
+			return nil
+		}
+		_, err := io.ReadFull(p.conn, remotePubKey[len(ourMagic):])
+		if err != nil {
+			return err
+		}
+	}
+
+	// Generate an ephemeral key for the session.  Keys that happen to begin
+	// with the network magic are rejected since they would be misinterpreted
+	// as the v1 transport.
+	var privKey *secp256k1.PrivateKey
+	var ourPubKey []byte
+	for {
+		var err error
+		privKey, err = secp256k1.GeneratePrivateKey()
+		if err != nil {
+			return err
+		}
+		ourPubKey = privKey.PubKey().SerializeCompressed()
+		if !bytes.Equal(ourPubKey[:len(ourMagic)], ourMagic[:]) {
+			break
+		}
+	}
+	if p.inbound {
+		if _, err := p.conn.Write(ourPubKey); err != nil {
+			return err
+		}
+	} else {
+		remotePubKey = make([]byte, transportPubKeySize)
+		_, err := p.conn.Write(ourPubKey)
+		if err == nil {
+			_, err = io.ReadFull(p.conn, remotePubKey)
+		}
+		if err != nil {
+			// The remote peer closing the connection without responding
+			// typically means it only supports the v1 transport.  Other
+			// errors, such as timeouts, say nothing about the transports
+			// the remote peer supports.
+			if p.cfg.OnV2TransportFailed != nil && !p.cfg.RequireAuth &&
+				isRemoteClose(err) {
+
+				p.cfg.OnV2TransportFailed(p)
+			}
+			return err
+		}
+	}
+	pubKey, err := secp256k1.ParsePubKey(remotePubKey)
+	if err != nil {
+		return fmt.Errorf("invalid v2 transport public key: %w", err)
+	}
+
+	// Derive the keys for both directions and the session ID from the shared
+	// secret.  The keys are bound to the network and both ephemeral public
+	// keys in the order of initiator then responder.
+	initiatorPubKey, responderPubKey := ourPubKey, remotePubKey
+	if p.inbound {
+		initiatorPubKey, responderPubKey = remotePubKey, ourPubKey
+	}
+	secret := secp256k1.GenerateSharedSecret(privKey, pubKey)
+	salt := append(append([]byte{}, transportKDFSalt...), ourMagic[:]...)
+	info := append(append([]byte{}, initiatorPubKey...), responderPubKey...)
+	kdf := hkdf.New(sha256.New, secret, salt, info)
+	var keys [3 * 32]byte
+	if _, err := io.ReadFull(kdf, keys[:]); err != nil {
+		return err
+	}
+	initiatorKey, responderKey, sessionID := keys[:32], keys[32:64], keys[64:]
+	conn := &v2Conn{Conn: p.conn, r: p.conn}
+	if p.inbound {
+		conn.send = newTransportCipher(responderKey)
+		conn.recv = newTransportCipher(initiatorKey)
+	} else {
+		conn.send = newTransportCipher(initiatorKey)
+		conn.recv = newTransportCipher(responderKey)
+	}
+
+	// Authenticate with the static key when one is configured by signing the
+	// session ID.  An empty payload indicates the peer is anonymous.  The
+	// initiator sends its authentication first.
+	var authSig []byte
+	if p.cfg.TransportKey != nil {
+		hash := transportAuthHash(sessionID, !p.inbound)
+		authSig = ecdsa.SignCompact(p.cfg.TransportKey, hash, true)
+	}
+	if !p.inbound {
+		if err := conn.writeFrame(authSig); err != nil {
+			return err
+		}
+	}
+	remoteAuthSig, err := conn.readFrame()
+	if err != nil {
+		return err
+	}
+	if p.inbound {
+		if err := conn.writeFrame(authSig); err != nil {
+			return err
+		}
+	}
+	var remoteKey *secp256k1.PublicKey
+	switch len(remoteAuthSig) {
+	case 0:
+	case transportAuthSigSize:
+		hash := transportAuthHash(sessionID, p.inbound)
+		remoteKey, _, err = ecdsa.RecoverCompact(remoteAuthSig, hash)
+		if err != nil {
+			return fmt.Errorf("invalid v2 transport authentication: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid v2 transport authentication length %d",
+			len(remoteAuthSig))
+	}
+
+	if p.cfg.RequireAuth {
+		var authorized bool
+		for _, key := range p.cfg.AuthorizedKeys {
+			if remoteKey != nil && key.IsEqual(remoteKey) {
+				authorized = true
+				break
+			}
+		}
+		if !authorized {
+			return ErrTransportAuth
+		}
+	}
+
+	p.setTransport(conn, TransportV2, remoteKey)
+	return nil
+}
+
+// Transport returns the transport negotiated with the remote peer.
+//
+// This function is safe for concurrent access.
+func (p *Peer) Transport() TransportType {
+	p.flagsMtx.Lock()
+	transport := p.transport
+	p.flagsMtx.Unlock()
+
+	return transport
+}
+
+// RemoteStaticKey returns the static key the remote peer authenticated with
+// when using the v2 transport.  It returns nil when the remote peer did not
+// authenticate.
+//
+// This function is safe for concurrent access.
+func (p *Peer) RemoteStaticKey() *secp256k1.PublicKey {
+	p.flagsMtx.Lock()
+	key := p.remoteStaticKey
+	p.flagsMtx.Unlock()
+
+	return key
+}
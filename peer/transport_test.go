@@ -0,0 +1,305 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package peer
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// TestTransportNegotiation ensures peers negotiate the expected transport and
+// enforce authentication as configured.
+func TestTransportNegotiation(t *testing.T) {
+	authKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	otherKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		in            Config
+		out           Config
+		wantOK        bool
+		wantTransport TransportType
+		wantAuthKey   *secp256k1.PublicKey
+	}{{
+		name:          "both v1",
+		wantOK:        true,
+		wantTransport: TransportV1,
+	}, {
+		name:          "v2 inbound accepts v1 outbound",
+		in:            Config{V2Transport: true},
+		wantOK:        true,
+		wantTransport: TransportV1,
+	}, {
+		name:          "both v2 anonymous",
+		in:            Config{V2Transport: true},
+		out:           Config{V2Transport: true},
+		wantOK:        true,
+		wantTransport: TransportV2,
+	}, {
+		name: "v2 authenticated",
+		in: Config{
+			V2Transport:    true,
+			RequireAuth:    true,
+			AuthorizedKeys: []*secp256k1.PublicKey{authKey.PubKey()},
+		},
+		out:           Config{V2Transport: true, TransportKey: authKey},
+		wantOK:        true,
+		wantTransport: TransportV2,
+		wantAuthKey:   authKey.PubKey(),
+	}, {
+		name: "v2 authenticated with unauthorized key",
+		in: Config{
+			V2Transport:    true,
+			RequireAuth:    true,
+			AuthorizedKeys: []*secp256k1.PublicKey{authKey.PubKey()},
+		},
+		out:    Config{V2Transport: true, TransportKey: otherKey},
+		wantOK: false,
+	}, {
+		name: "required authentication with anonymous peer",
+		in: Config{
+			V2Transport:    true,
+			RequireAuth:    true,
+			AuthorizedKeys: []*secp256k1.PublicKey{authKey.PubKey()},
+		},
+		out:    Config{V2Transport: true},
+		wantOK: false,
+	}, {
+		name: "required authentication with v1 peer",
+		in: Config{
+			RequireAuth:    true,
+			AuthorizedKeys: []*secp256k1.PublicKey{authKey.PubKey()},
+		},
+		wantOK: false,
+	}}
+
+	for _, test := range tests {
+		verack := make(chan struct{}, 2)
+		onVerAck := func(p *Peer, msg *wire.MsgVerAck) {
+			verack <- struct{}{}
+		}
+		inCfg, outCfg := test.in, test.out
+		inCfg.Net, outCfg.Net = wire.MainNet, wire.MainNet
+		inCfg.Listeners.OnVerAck = onVerAck
+		outCfg.Listeners.OnVerAck = onVerAck
+
+		inConn, outConn := pipe(
+			&conn{raddr: "10.0.0.1:8333"},
+			&conn{raddr: "10.0.0.2:8333"},
+		)
+		inPeer := NewInboundPeer(&inCfg)
+		inPeer.AssociateConnection(inConn)
+		outPeer, err := NewOutboundPeer(&outCfg, "10.0.0.2:8333")
+		if err != nil {
+			t.Fatalf("%q: unexpected err: %v", test.name, err)
+		}
+		outPeer.AssociateConnection(outConn)
+
+		if !test.wantOK {
+			select {
+			case <-inPeer.quit:
+			case <-time.After(time.Second):
+				t.Fatalf("%q: inbound peer did not disconnect", test.name)
+			}
+			outPeer.Disconnect()
+			outPeer.WaitForDisconnect()
+			continue
+		}
+
+		for i := 0; i < 2; i++ {
+			select {
+			case <-verack:
+			case <-time.After(time.Second):
+				t.Fatalf("%q: verack timeout", test.name)
+			}
+		}
+		for _, p := range []*Peer{inPeer, outPeer} {
+			if transport := p.Transport(); transport != test.wantTransport {
+				t.Errorf("%q: unexpected transport for %s -- got %v, want %v",
+					test.name, p, transport, test.wantTransport)
+			}
+			snap := p.StatsSnapshot()
+			if snap.Transport != test.wantTransport {
+				t.Errorf("%q: unexpected snapshot transport for %s -- got %v, "+
+					"want %v", test.name, p, snap.Transport, test.wantTransport)
+			}
+		}
+		gotAuthKey := inPeer.RemoteStaticKey()
+		if (gotAuthKey == nil) != (test.wantAuthKey == nil) ||
+			(gotAuthKey != nil && !gotAuthKey.IsEqual(test.wantAuthKey)) {
+
+			t.Errorf("%q: unexpected remote static key -- got %v, want %v",
+				test.name, gotAuthKey, test.wantAuthKey)
+		}
+
+		inPeer.Disconnect()
+		outPeer.Disconnect()
+		inPeer.WaitForDisconnect()
+		outPeer.WaitForDisconnect()
+	}
+}
+
+// TestTransportFallback ensures outbound peers invoke the configured callback
+// when the remote peer closes the connection during the v2 transport
+// handshake.
+func TestTransportFallback(t *testing.T) {
+	failed := make(chan struct{}, 1)
+	cfg := &Config{
+		Net:         wire.MainNet,
+		V2Transport: true,
+		OnV2TransportFailed: func(p *Peer) {
+			failed <- struct{}{}
+		},
+	}
+
+	// Mock a v1 remote peer that reads the start of the handshake and closes
+	// the connection due to the invalid network magic.
+	remoteConn, outConn := pipe(
+		&conn{raddr: "10.0.0.1:8333"},
+		&conn{raddr: "10.0.0.2:8333"},
+	)
+	go func() {
+		var hdr [24]byte
+		io.ReadFull(remoteConn, hdr[:])
+		remoteConn.Close()
+	}()
+
+	outPeer, err := NewOutboundPeer(cfg, "10.0.0.2:8333")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	outPeer.AssociateConnection(outConn)
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Fatal("v2 transport failure callback was not invoked")
+	}
+	outPeer.WaitForDisconnect()
+}
+
+// timeoutConn is a connection that times out on every read.
+type timeoutConn struct {
+	net.Conn
+}
+
+// Read always returns a timeout error.
+func (c timeoutConn) Read(b []byte) (int, error) {
+	return 0, os.ErrDeadlineExceeded
+}
+
+// TestTransportFallbackTimeout ensures outbound peers do not invoke the
+// configured v2 transport failure callback when the handshake fails for
+// reasons other than the remote peer closing the connection.
+func TestTransportFallbackTimeout(t *testing.T) {
+	failed := make(chan struct{}, 1)
+	cfg := &Config{
+		Net:         wire.MainNet,
+		V2Transport: true,
+		OnV2TransportFailed: func(p *Peer) {
+			failed <- struct{}{}
+		},
+	}
+
+	// Mock a remote peer that reads the start of the handshake and never
+	// responds.
+	remoteConn, outConn := pipe(
+		&conn{raddr: "10.0.0.1:8333"},
+		&conn{raddr: "10.0.0.2:8333"},
+	)
+	defer remoteConn.Close()
+	go func() {
+		var pubKey [transportPubKeySize]byte
+		io.ReadFull(remoteConn, pubKey[:])
+	}()
+
+	outPeer, err := NewOutboundPeer(cfg, "10.0.0.2:8333")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	outPeer.AssociateConnection(timeoutConn{outConn})
+	outPeer.WaitForDisconnect()
+	select {
+	case <-failed:
+		t.Fatal("v2 transport failure callback was invoked after a timeout")
+	default:
+	}
+}
+
+// TestTransportCipher ensures the transport ciphers remain in sync across key
+// rotations and detect modified ciphertexts.
+func TestTransportCipher(t *testing.T) {
+	key := bytes.Repeat([]byte{0x01}, 32)
+	sender := newTransportCipher(key)
+	receiver := newTransportCipher(key)
+
+	plaintext := []byte("monetarium")
+	for i := 0; i < transportRekeyInterval+2; i++ {
+		ciphertext := sender.seal(nil, plaintext)
+		got, err := receiver.open(ciphertext)
+		if err != nil {
+			t.Fatalf("unexpected err opening ciphertext %d: %v", i, err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Fatalf("mismatched plaintext %d -- got %x, want %x", i, got,
+				plaintext)
+		}
+	}
+	if sender.epoch != 1 || receiver.epoch != 1 {
+		t.Fatalf("unexpected key epochs -- got %d and %d, want 1",
+			sender.epoch, receiver.epoch)
+	}
+	if bytes.Equal(sender.key[:], key) {
+		t.Fatal("key was not rotated")
+	}
+
+	// Ensure modified ciphertexts are rejected.
+	ciphertext := sender.seal(nil, plaintext)
+	ciphertext[0] ^= 0x01
+	if _, err := receiver.open(ciphertext); err == nil {
+		t.Fatal("modified ciphertext was not rejected")
+	}
+
+	// Ensure the frames of a v2 connection round trip including writes that
+	// span multiple frames and empty frames.
+	r1, w1 := io.Pipe()
+	r2, w2 := io.Pipe()
+	c1 := &v2Conn{Conn: &conn{ReadCloser: r2, WriteCloser: w1}, r: r2,
+		send: newTransportCipher(key), recv: newTransportCipher(key)}
+	c2 := &v2Conn{Conn: &conn{ReadCloser: r1, WriteCloser: w2}, r: r1,
+		send: newTransportCipher(key), recv: newTransportCipher(key)}
+	data := bytes.Repeat([]byte{0xab}, maxTransportFrameSize+10)
+	errChan := make(chan error, 1)
+	go func() {
+		if err := c1.writeFrame(nil); err != nil {
+			errChan <- err
+			return
+		}
+		_, err := c1.Write(data)
+		errChan <- err
+	}()
+	got := make([]byte, len(data))
+	if _, err := io.ReadFull(c2, got); err != nil {
+		t.Fatalf("unexpected read err: %v", err)
+	}
+	if err := <-errChan; err != nil {
+		t.Fatalf("unexpected write err: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("mismatched data read from v2 connection")
+	}
+}
@@ -45,6 +45,18 @@ const (
 	NDisconnect NodeSubCmd = "disconnect"
 )
 
+// SetBanSubCmd defines the type used in the setban JSON-RPC command for the
+// sub command field.
+type SetBanSubCmd string
+
+const (
+	// SBAdd indicates the specified subnet should be banned.
+	SBAdd SetBanSubCmd = "add"
+
+	// SBRemove indicates the ban of the specified subnet should be removed.
+	SBRemove SetBanSubCmd = "remove"
+)
+
 // AddNodeCmd defines the addnode JSON-RPC command.
 type AddNodeCmd struct {
 	Addr   string
@@ -60,6 +72,19 @@ func NewAddNodeCmd(addr string, subCmd AddNodeSubCmd) *AddNodeCmd {
 	}
 }
 
+// AnalyzePSTCmd defines the analyzepst JSON-RPC command.
+type AnalyzePSTCmd struct {
+	PST string
+}
+
+// NewAnalyzePSTCmd returns a new instance which can be used to issue an
+// analyzepst JSON-RPC command.
+func NewAnalyzePSTCmd(pst string) *AnalyzePSTCmd {
+	return &AnalyzePSTCmd{
+		PST: pst,
+	}
+}
+
 // SStxInput represents the inputs to an SStx transaction. Specifically a
 // transactionsha and output number pair, along with the output amounts.
 type SStxInput struct {
@@ -96,6 +121,28 @@ func NewCreateRawSStxCmd(inputs []SStxInput, amount map[string]int64,
 	}
 }
 
+// ClearBannedCmd defines the clearbanned JSON-RPC command.
+type ClearBannedCmd struct{}
+
+// NewClearBannedCmd returns a new instance which can be used to issue a
+// clearbanned JSON-RPC command.
+func NewClearBannedCmd() *ClearBannedCmd {
+	return &ClearBannedCmd{}
+}
+
+// CombinePSTCmd defines the combinepst JSON-RPC command.
+type CombinePSTCmd struct {
+	PSTs []string
+}
+
+// NewCombinePSTCmd returns a new instance which can be used to issue a
+// combinepst JSON-RPC command.
+func NewCombinePSTCmd(psts []string) *CombinePSTCmd {
+	return &CombinePSTCmd{
+		PSTs: psts,
+	}
+}
+
 // CreateRawSSRtxCmd is a type handling custom marshaling and
 // unmarshaling of createrawssrtx JSON RPC commands.
 type CreateRawSSRtxCmd struct {
@@ -158,6 +205,19 @@ func NewDebugLevelCmd(levelSpec string) *DebugLevelCmd {
 	}
 }
 
+// DecodePSTCmd defines the decodepst JSON-RPC command.
+type DecodePSTCmd struct {
+	PST string
+}
+
+// NewDecodePSTCmd returns a new instance which can be used to issue a
+// decodepst JSON-RPC command.
+func NewDecodePSTCmd(pst string) *DecodePSTCmd {
+	return &DecodePSTCmd{
+		PST: pst,
+	}
+}
+
 // DecodeRawTransactionCmd defines the decoderawtransaction JSON-RPC command.
 type DecodeRawTransactionCmd struct {
 	HexTx string
@@ -185,6 +245,19 @@ func NewDecodeScriptCmd(hexScript string) *DecodeScriptCmd {
 	}
 }
 
+// DumpTxOutSetCmd defines the dumptxoutset JSON-RPC command.
+type DumpTxOutSetCmd struct {
+	Path string
+}
+
+// NewDumpTxOutSetCmd returns a new instance which can be used to issue a
+// dumptxoutset JSON-RPC command.
+func NewDumpTxOutSetCmd(path string) *DumpTxOutSetCmd {
+	return &DumpTxOutSetCmd{
+		Path: path,
+	}
+}
+
 // EstimateFeeCmd defines the estimatefee JSON-RPC command.
 type EstimateFeeCmd struct {
 	NumBlocks int64
@@ -345,6 +418,24 @@ func NewExistsMempoolTxsCmd(txHashes []string) *ExistsMempoolTxsCmd {
 	}
 }
 
+// FinalizePSTCmd defines the finalizepst JSON-RPC command.
+type FinalizePSTCmd struct {
+	PST     string
+	Extract *bool `jsonrpcdefault:"true"`
+}
+
+// NewFinalizePSTCmd returns a new instance which can be used to issue a
+// finalizepst JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewFinalizePSTCmd(pst string, extract *bool) *FinalizePSTCmd {
+	return &FinalizePSTCmd{
+		PST:     pst,
+		Extract: extract,
+	}
+}
+
 // GenerateCmd defines the generate JSON-RPC command.
 type GenerateCmd struct {
 	NumBlocks uint32
@@ -597,6 +688,22 @@ func NewGetHeadersCmd(blockLocators []string, hashStop string) *GetHeadersCmd {
 	}
 }
 
+// GetMempoolHistoryCmd defines the getmempoolhistory JSON-RPC command.
+type GetMempoolHistoryCmd struct {
+	TxHash *string
+}
+
+// NewGetMempoolHistoryCmd returns a new instance which can be used to issue a
+// getmempoolhistory JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetMempoolHistoryCmd(txHash *string) *GetMempoolHistoryCmd {
+	return &GetMempoolHistoryCmd{
+		TxHash: txHash,
+	}
+}
+
 // GetMempoolInfoCmd defines the getmempoolinfo JSON-RPC command.
 type GetMempoolInfoCmd struct{}
 
@@ -646,6 +753,22 @@ func NewGetNetworkInfoCmd() *GetNetworkInfoCmd {
 	return &GetNetworkInfoCmd{}
 }
 
+// GetNodeAddressesCmd defines the getnodeaddresses JSON-RPC command.
+type GetNodeAddressesCmd struct {
+	Count *int32 `jsonrpcdefault:"1"`
+}
+
+// NewGetNodeAddressesCmd returns a new instance which can be used to issue a
+// getnodeaddresses JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetNodeAddressesCmd(count *int32) *GetNodeAddressesCmd {
+	return &GetNodeAddressesCmd{
+		Count: count,
+	}
+}
+
 // GetNetTotalsCmd defines the getnettotals JSON-RPC command.
 type GetNetTotalsCmd struct{}
 
@@ -655,6 +778,15 @@ func NewGetNetTotalsCmd() *GetNetTotalsCmd {
 	return &GetNetTotalsCmd{}
 }
 
+// GetNetworkStatsCmd defines the getnetworkstats JSON-RPC command.
+type GetNetworkStatsCmd struct{}
+
+// NewGetNetworkStatsCmd returns a new instance which can be used to issue a
+// getnetworkstats JSON-RPC command.
+func NewGetNetworkStatsCmd() *GetNetworkStatsCmd {
+	return &GetNetworkStatsCmd{}
+}
+
 // GetNetworkHashPSCmd defines the getnetworkhashps JSON-RPC command.
 type GetNetworkHashPSCmd struct {
 	Blocks *int `jsonrpcdefault:"120"`
@@ -797,6 +929,21 @@ func NewGetTicketPoolValueCmd() *GetTicketPoolValueCmd {
 	return &GetTicketPoolValueCmd{}
 }
 
+// GetTxInclusionProofCmd defines the gettxinclusionproof JSON-RPC command.
+type GetTxInclusionProofCmd struct {
+	TxHash    string
+	BlockHash string
+}
+
+// NewGetTxInclusionProofCmd returns a new instance which can be used to issue
+// a gettxinclusionproof JSON-RPC command.
+func NewGetTxInclusionProofCmd(txHash, blockHash string) *GetTxInclusionProofCmd {
+	return &GetTxInclusionProofCmd{
+		TxHash:    txHash,
+		BlockHash: blockHash,
+	}
+}
+
 // GetTxOutCmd defines the gettxout JSON-RPC command.
 type GetTxOutCmd struct {
 	Txid           string
@@ -931,6 +1078,15 @@ func NewInvalidateBlockCmd(hash string) *InvalidateBlockCmd {
 	}
 }
 
+// ListBannedCmd defines the listbanned JSON-RPC command.
+type ListBannedCmd struct{}
+
+// NewListBannedCmd returns a new instance which can be used to issue a
+// listbanned JSON-RPC command.
+func NewListBannedCmd() *ListBannedCmd {
+	return &ListBannedCmd{}
+}
+
 // LiveTicketsCmd is a type handling custom marshaling and
 // unmarshaling of livetickets JSON RPC commands.
 type LiveTicketsCmd struct{}
@@ -1016,6 +1172,28 @@ func NewSendRawTransactionCmd(hexTx string, allowHighFees *bool) *SendRawTransac
 	}
 }
 
+// SetBanCmd defines the setban JSON-RPC command.
+type SetBanCmd struct {
+	Subnet  string
+	SubCmd  SetBanSubCmd `jsonrpcusage:"\"add|remove\""`
+	BanTime *int64       `jsonrpcdefault:"0"`
+	Reason  *string
+}
+
+// NewSetBanCmd returns a new instance which can be used to issue a setban
+// JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewSetBanCmd(subnet string, subCmd SetBanSubCmd, banTime *int64, reason *string) *SetBanCmd {
+	return &SetBanCmd{
+		Subnet:  subnet,
+		SubCmd:  subCmd,
+		BanTime: banTime,
+		Reason:  reason,
+	}
+}
+
 // SetGenerateCmd defines the setgenerate JSON-RPC command.
 type SetGenerateCmd struct {
 	Generate     bool
@@ -1034,6 +1212,24 @@ func NewSetGenerateCmd(generate bool, genProcLimit *int) *SetGenerateCmd {
 	}
 }
 
+// SubmitPackageCmd defines the submitpackage JSON-RPC command.
+type SubmitPackageCmd struct {
+	RawTxs        []string
+	AllowHighFees *bool `jsonrpcdefault:"false"`
+}
+
+// NewSubmitPackageCmd returns a new instance which can be used to issue a
+// submitpackage JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewSubmitPackageCmd(rawTxs []string, allowHighFees *bool) *SubmitPackageCmd {
+	return &SubmitPackageCmd{
+		RawTxs:        rawTxs,
+		AllowHighFees: allowHighFees,
+	}
+}
+
 // StartProfilerCmd defines the startprofiler JSON-RPC command.
 type StartProfilerCmd struct {
 	Addr             string
@@ -1198,6 +1394,27 @@ func NewVerifyMessageCmd(address, signature, message string) *VerifyMessageCmd {
 	}
 }
 
+// VerifyPartialSigCmd defines the verifypartialsig JSON-RPC command.
+type VerifyPartialSigCmd struct {
+	PubKeys      []string
+	PubNonces    []string
+	SignerPubKey string
+	Message      string
+	PartialSig   string
+}
+
+// NewVerifyPartialSigCmd returns a new instance which can be used to issue a
+// verifypartialsig JSON-RPC command.
+func NewVerifyPartialSigCmd(pubKeys, pubNonces []string, signerPubKey, message, partialSig string) *VerifyPartialSigCmd {
+	return &VerifyPartialSigCmd{
+		PubKeys:      pubKeys,
+		PubNonces:    pubNonces,
+		SignerPubKey: signerPubKey,
+		Message:      message,
+		PartialSig:   partialSig,
+	}
+}
+
 // VersionCmd defines the version JSON-RPC command.
 type VersionCmd struct{}
 
@@ -1218,17 +1435,50 @@ func NewGetBurnedCoinsCmd(coinType *uint8) *GetBurnedCoinsCmd {
 	}
 }
 
+// GetBurnProofCmd defines the getburnproof JSON-RPC command.
+type GetBurnProofCmd struct {
+	TxHash    string
+	BlockHash string
+}
+
+// NewGetBurnProofCmd returns a new instance which can be used to issue a
+// getburnproof JSON-RPC command.
+func NewGetBurnProofCmd(txHash, blockHash string) *GetBurnProofCmd {
+	return &GetBurnProofCmd{
+		TxHash:    txHash,
+		BlockHash: blockHash,
+	}
+}
+
+// GetSKASupplyProofCmd defines the getskasupplyproof JSON-RPC command.
+type GetSKASupplyProofCmd struct {
+	CoinType uint8
+}
+
+// NewGetSKASupplyProofCmd returns a new instance which can be used to issue a
+// getskasupplyproof JSON-RPC command.
+func NewGetSKASupplyProofCmd(coinType uint8) *GetSKASupplyProofCmd {
+	return &GetSKASupplyProofCmd{
+		CoinType: coinType,
+	}
+}
+
 func init() {
 	// No special flags for commands in this file.
 	flags := dcrjson.UsageFlag(0)
 
 	dcrjson.MustRegister(Method("addnode"), (*AddNodeCmd)(nil), flags)
+	dcrjson.MustRegister(Method("analyzepst"), (*AnalyzePSTCmd)(nil), flags)
+	dcrjson.MustRegister(Method("clearbanned"), (*ClearBannedCmd)(nil), flags)
+	dcrjson.MustRegister(Method("combinepst"), (*CombinePSTCmd)(nil), flags)
 	dcrjson.MustRegister(Method("createrawssrtx"), (*CreateRawSSRtxCmd)(nil), flags)
 	dcrjson.MustRegister(Method("createrawsstx"), (*CreateRawSStxCmd)(nil), flags)
 	dcrjson.MustRegister(Method("createrawtransaction"), (*CreateRawTransactionCmd)(nil), flags)
 	dcrjson.MustRegister(Method("debuglevel"), (*DebugLevelCmd)(nil), flags)
+	dcrjson.MustRegister(Method("decodepst"), (*DecodePSTCmd)(nil), flags)
 	dcrjson.MustRegister(Method("decoderawtransaction"), (*DecodeRawTransactionCmd)(nil), flags)
 	dcrjson.MustRegister(Method("decodescript"), (*DecodeScriptCmd)(nil), flags)
+	dcrjson.MustRegister(Method("dumptxoutset"), (*DumpTxOutSetCmd)(nil), flags)
 	dcrjson.MustRegister(Method("estimatefee"), (*EstimateFeeCmd)(nil), flags)
 	dcrjson.MustRegister(Method("estimatesmartfee"), (*EstimateSmartFeeCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getfeestimatesbycointype"), (*GetFeeEstimatesByCoinTypeCmd)(nil), flags)
@@ -1239,6 +1489,7 @@ func init() {
 	dcrjson.MustRegister(Method("existsliveticket"), (*ExistsLiveTicketCmd)(nil), flags)
 	dcrjson.MustRegister(Method("existslivetickets"), (*ExistsLiveTicketsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("existsmempooltxs"), (*ExistsMempoolTxsCmd)(nil), flags)
+	dcrjson.MustRegister(Method("finalizepst"), (*FinalizePSTCmd)(nil), flags)
 	dcrjson.MustRegister(Method("generate"), (*GenerateCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getaddednodeinfo"), (*GetAddedNodeInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getbestblock"), (*GetBestBlockCmd)(nil), flags)
@@ -1261,12 +1512,15 @@ func init() {
 	dcrjson.MustRegister(Method("getinfo"), (*GetInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getskainfo"), (*GetSKAInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getemissionstatus"), (*GetEmissionStatusCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getmempoolhistory"), (*GetMempoolHistoryCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getmempoolinfo"), (*GetMempoolInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getmininginfo"), (*GetMiningInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getmixmessage"), (*GetMixMessageCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getmixpairrequests"), (*GetMixPairRequestsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getnetworkinfo"), (*GetNetworkInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getnettotals"), (*GetNetTotalsCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getnetworkstats"), (*GetNetworkStatsCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getnodeaddresses"), (*GetNodeAddressesCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getnetworkhashps"), (*GetNetworkHashPSCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getpeerinfo"), (*GetPeerInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getrawmempool"), (*GetRawMempoolCmd)(nil), flags)
@@ -1277,12 +1531,14 @@ func init() {
 	dcrjson.MustRegister(Method("getticketpoolvalue"), (*GetTicketPoolValueCmd)(nil), flags)
 	dcrjson.MustRegister(Method("gettreasurybalance"), (*GetTreasuryBalanceCmd)(nil), flags)
 	dcrjson.MustRegister(Method("gettreasuryspendvotes"), (*GetTreasurySpendVotesCmd)(nil), flags)
+	dcrjson.MustRegister(Method("gettxinclusionproof"), (*GetTxInclusionProofCmd)(nil), flags)
 	dcrjson.MustRegister(Method("gettxout"), (*GetTxOutCmd)(nil), flags)
 	dcrjson.MustRegister(Method("gettxoutsetinfo"), (*GetTxOutSetInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getvoteinfo"), (*GetVoteInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getwork"), (*GetWorkCmd)(nil), flags)
 	dcrjson.MustRegister(Method("help"), (*HelpCmd)(nil), flags)
 	dcrjson.MustRegister(Method("invalidateblock"), (*InvalidateBlockCmd)(nil), flags)
+	dcrjson.MustRegister(Method("listbanned"), (*ListBannedCmd)(nil), flags)
 	dcrjson.MustRegister(Method("livetickets"), (*LiveTicketsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("node"), (*NodeCmd)(nil), flags)
 	dcrjson.MustRegister(Method("ping"), (*PingCmd)(nil), flags)
@@ -1290,11 +1546,13 @@ func init() {
 	dcrjson.MustRegister(Method("regentemplate"), (*RegenTemplateCmd)(nil), flags)
 	dcrjson.MustRegister(Method("sendrawmixmessage"), (*SendRawMixMessageCmd)(nil), flags)
 	dcrjson.MustRegister(Method("sendrawtransaction"), (*SendRawTransactionCmd)(nil), flags)
+	dcrjson.MustRegister(Method("setban"), (*SetBanCmd)(nil), flags)
 	dcrjson.MustRegister(Method("setgenerate"), (*SetGenerateCmd)(nil), flags)
 	dcrjson.MustRegister(Method("startprofiler"), (*StartProfilerCmd)(nil), flags)
 	dcrjson.MustRegister(Method("stop"), (*StopCmd)(nil), flags)
 	dcrjson.MustRegister(Method("stopprofiler"), (*StopProfilerCmd)(nil), flags)
 	dcrjson.MustRegister(Method("submitblock"), (*SubmitBlockCmd)(nil), flags)
+	dcrjson.MustRegister(Method("submitpackage"), (*SubmitPackageCmd)(nil), flags)
 	dcrjson.MustRegister(Method("ticketfeeinfo"), (*TicketFeeInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("ticketsforaddress"), (*TicketsForAddressCmd)(nil), flags)
 	dcrjson.MustRegister(Method("ticketvwap"), (*TicketVWAPCmd)(nil), flags)
@@ -1302,6 +1560,9 @@ func init() {
 	dcrjson.MustRegister(Method("validateaddress"), (*ValidateAddressCmd)(nil), flags)
 	dcrjson.MustRegister(Method("verifychain"), (*VerifyChainCmd)(nil), flags)
 	dcrjson.MustRegister(Method("verifymessage"), (*VerifyMessageCmd)(nil), flags)
+	dcrjson.MustRegister(Method("verifypartialsig"), (*VerifyPartialSigCmd)(nil), flags)
 	dcrjson.MustRegister(Method("version"), (*VersionCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getburnedcoins"), (*GetBurnedCoinsCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getburnproof"), (*GetBurnProofCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getskasupplyproof"), (*GetSKASupplyProofCmd)(nil), flags)
 }
@@ -41,6 +41,39 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"addnode","params":["127.0.0.1","remove"],"id":1}`,
 			unmarshalled: &AddNodeCmd{Addr: "127.0.0.1", SubCmd: ANRemove},
 		},
+		{
+			name: "analyzepst",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("analyzepst"), "bXBzdP8A")
+			},
+			staticCmd: func() interface{} {
+				return NewAnalyzePSTCmd("bXBzdP8A")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"analyzepst","params":["bXBzdP8A"],"id":1}`,
+			unmarshalled: &AnalyzePSTCmd{PST: "bXBzdP8A"},
+		},
+		{
+			name: "clearbanned",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("clearbanned"))
+			},
+			staticCmd: func() interface{} {
+				return NewClearBannedCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"clearbanned","params":[],"id":1}`,
+			unmarshalled: &ClearBannedCmd{},
+		},
+		{
+			name: "combinepst",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("combinepst"), []string{"bXBzdP8A", "bXBzdP8B"})
+			},
+			staticCmd: func() interface{} {
+				return NewCombinePSTCmd([]string{"bXBzdP8A", "bXBzdP8B"})
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"combinepst","params":[["bXBzdP8A","bXBzdP8B"]],"id":1}`,
+			unmarshalled: &CombinePSTCmd{PSTs: []string{"bXBzdP8A", "bXBzdP8B"}},
+		},
 		{
 			name: "createrawtransaction",
 			newCmd: func() (interface{}, error) {
@@ -94,6 +127,17 @@ func TestChainSvrCmds(t *testing.T) {
 				LevelSpec: "trace",
 			},
 		},
+		{
+			name: "decodepst",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("decodepst"), "bXBzdP8A")
+			},
+			staticCmd: func() interface{} {
+				return NewDecodePSTCmd("bXBzdP8A")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"decodepst","params":["bXBzdP8A"],"id":1}`,
+			unmarshalled: &DecodePSTCmd{PST: "bXBzdP8A"},
+		},
 		{
 			name: "decoderawtransaction",
 			newCmd: func() (interface{}, error) {
@@ -129,6 +173,19 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"decodescript","params":["00",1],"id":1}`,
 			unmarshalled: &DecodeScriptCmd{HexScript: "00", Version: dcrjson.Uint16(1)},
 		},
+		{
+			name: "dumptxoutset",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("dumptxoutset"), "utxos.dat")
+			},
+			staticCmd: func() interface{} {
+				return NewDumpTxOutSetCmd("utxos.dat")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"dumptxoutset","params":["utxos.dat"],"id":1}`,
+			unmarshalled: &DumpTxOutSetCmd{
+				Path: "utxos.dat",
+			},
+		},
 		{
 			name: "estimatefee",
 			newCmd: func() (interface{}, error) {
@@ -171,6 +228,34 @@ func TestChainSvrCmds(t *testing.T) {
 				Mode:          EstimateSmartFeeModeAddr(EstimateSmartFeeConservative),
 			},
 		},
+		{
+			name: "finalizepst",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("finalizepst"), "bXBzdP8A")
+			},
+			staticCmd: func() interface{} {
+				return NewFinalizePSTCmd("bXBzdP8A", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"finalizepst","params":["bXBzdP8A"],"id":1}`,
+			unmarshalled: &FinalizePSTCmd{
+				PST:     "bXBzdP8A",
+				Extract: dcrjson.Bool(true),
+			},
+		},
+		{
+			name: "finalizepst optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("finalizepst"), "bXBzdP8A", false)
+			},
+			staticCmd: func() interface{} {
+				return NewFinalizePSTCmd("bXBzdP8A", dcrjson.Bool(false))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"finalizepst","params":["bXBzdP8A",false],"id":1}`,
+			unmarshalled: &FinalizePSTCmd{
+				PST:     "bXBzdP8A",
+				Extract: dcrjson.Bool(false),
+			},
+		},
 		{
 			name: "generate",
 			newCmd: func() (interface{}, error) {
@@ -341,6 +426,20 @@ func TestChainSvrCmds(t *testing.T) {
 				Voters: 256,
 			},
 		},
+		{
+			name: "getburnproof",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getburnproof"), "123", "456")
+			},
+			staticCmd: func() interface{} {
+				return NewGetBurnProofCmd("123", "456")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getburnproof","params":["123","456"],"id":1}`,
+			unmarshalled: &GetBurnProofCmd{
+				TxHash:    "123",
+				BlockHash: "456",
+			},
+		},
 		{
 			name: "getcfilterv2",
 			newCmd: func() (interface{}, error) {
@@ -431,6 +530,30 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getinfo","params":[],"id":1}`,
 			unmarshalled: &GetInfoCmd{},
 		},
+		{
+			name: "getmempoolhistory",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getmempoolhistory"))
+			},
+			staticCmd: func() interface{} {
+				return NewGetMempoolHistoryCmd(nil)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getmempoolhistory","params":[],"id":1}`,
+			unmarshalled: &GetMempoolHistoryCmd{},
+		},
+		{
+			name: "getmempoolhistory optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getmempoolhistory"), "123")
+			},
+			staticCmd: func() interface{} {
+				return NewGetMempoolHistoryCmd(dcrjson.String("123"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getmempoolhistory","params":["123"],"id":1}`,
+			unmarshalled: &GetMempoolHistoryCmd{
+				TxHash: dcrjson.String("123"),
+			},
+		},
 		{
 			name: "getmempoolinfo",
 			newCmd: func() (interface{}, error) {
@@ -486,6 +609,43 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getnettotals","params":[],"id":1}`,
 			unmarshalled: &GetNetTotalsCmd{},
 		},
+		{
+			name: "getnetworkstats",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getnetworkstats"))
+			},
+			staticCmd: func() interface{} {
+				return NewGetNetworkStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getnetworkstats","params":[],"id":1}`,
+			unmarshalled: &GetNetworkStatsCmd{},
+		},
+		{
+			name: "getnodeaddresses",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getnodeaddresses"))
+			},
+			staticCmd: func() interface{} {
+				return NewGetNodeAddressesCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnodeaddresses","params":[],"id":1}`,
+			unmarshalled: &GetNodeAddressesCmd{
+				Count: dcrjson.Int32(1),
+			},
+		},
+		{
+			name: "getnodeaddresses optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getnodeaddresses"), 8)
+			},
+			staticCmd: func() interface{} {
+				return NewGetNodeAddressesCmd(dcrjson.Int32(8))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnodeaddresses","params":[8],"id":1}`,
+			unmarshalled: &GetNodeAddressesCmd{
+				Count: dcrjson.Int32(8),
+			},
+		},
 		{
 			name: "getnetworkhashps",
 			newCmd: func() (interface{}, error) {
@@ -607,6 +767,19 @@ func TestChainSvrCmds(t *testing.T) {
 				Verbose: dcrjson.Int(1),
 			},
 		},
+		{
+			name: "getskasupplyproof",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getskasupplyproof"), 1)
+			},
+			staticCmd: func() interface{} {
+				return NewGetSKASupplyProofCmd(1)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getskasupplyproof","params":[1],"id":1}`,
+			unmarshalled: &GetSKASupplyProofCmd{
+				CoinType: 1,
+			},
+		},
 		{
 			name: "getstakeversions",
 			newCmd: func() (interface{}, error) {
@@ -621,6 +794,20 @@ func TestChainSvrCmds(t *testing.T) {
 				Count: 1,
 			},
 		},
+		{
+			name: "gettxinclusionproof",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("gettxinclusionproof"), "123", "456")
+			},
+			staticCmd: func() interface{} {
+				return NewGetTxInclusionProofCmd("123", "456")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gettxinclusionproof","params":["123","456"],"id":1}`,
+			unmarshalled: &GetTxInclusionProofCmd{
+				TxHash:    "123",
+				BlockHash: "456",
+			},
+		},
 		{
 			name: "gettxout",
 			newCmd: func() (interface{}, error) {
@@ -771,6 +958,17 @@ func TestChainSvrCmds(t *testing.T) {
 				Command: dcrjson.String("getblock"),
 			},
 		},
+		{
+			name: "listbanned",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("listbanned"))
+			},
+			staticCmd: func() interface{} {
+				return NewListBannedCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listbanned","params":[],"id":1}`,
+			unmarshalled: &ListBannedCmd{},
+		},
 		{
 			name: "node option remove",
 			newCmd: func() (interface{}, error) {
@@ -867,6 +1065,67 @@ func TestChainSvrCmds(t *testing.T) {
 				AllowHighFees: dcrjson.Bool(false),
 			},
 		},
+		{
+			name: "submitpackage",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("submitpackage"), []string{"1122", "3344"})
+			},
+			staticCmd: func() interface{} {
+				return NewSubmitPackageCmd([]string{"1122", "3344"}, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"submitpackage","params":[["1122","3344"]],"id":1}`,
+			unmarshalled: &SubmitPackageCmd{
+				RawTxs:        []string{"1122", "3344"},
+				AllowHighFees: dcrjson.Bool(false),
+			},
+		},
+		{
+			name: "submitpackage optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("submitpackage"), []string{"1122", "3344"}, true)
+			},
+			staticCmd: func() interface{} {
+				return NewSubmitPackageCmd([]string{"1122", "3344"}, dcrjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"submitpackage","params":[["1122","3344"],true],"id":1}`,
+			unmarshalled: &SubmitPackageCmd{
+				RawTxs:        []string{"1122", "3344"},
+				AllowHighFees: dcrjson.Bool(true),
+			},
+		},
+		{
+			name: "setban",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("setban"), "192.168.0.0/16", SBAdd)
+			},
+			staticCmd: func() interface{} {
+				return NewSetBanCmd("192.168.0.0/16", SBAdd, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setban","params":["192.168.0.0/16","add"],"id":1}`,
+			unmarshalled: &SetBanCmd{
+				Subnet:  "192.168.0.0/16",
+				SubCmd:  SBAdd,
+				BanTime: dcrjson.Int64(0),
+			},
+		},
+		{
+			name: "setban optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("setban"), "10.0.0.1", SBAdd, 3600,
+					"spam")
+			},
+			staticCmd: func() interface{} {
+				return NewSetBanCmd("10.0.0.1", SBAdd, dcrjson.Int64(3600),
+					dcrjson.String("spam"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setban","params":["10.0.0.1","add",3600,"spam"],"id":1}`,
+			unmarshalled: &SetBanCmd{
+				Subnet:  "10.0.0.1",
+				SubCmd:  SBAdd,
+				BanTime: dcrjson.Int64(3600),
+				Reason:  dcrjson.String("spam"),
+			},
+		},
 		{
 			name: "setgenerate",
 			newCmd: func() (interface{}, error) {
@@ -1048,6 +1307,26 @@ func TestChainSvrCmds(t *testing.T) {
 				Message:   "test",
 			},
 		},
+		{
+			name: "verifypartialsig",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("verifypartialsig"),
+					[]string{"02aa", "03bb"}, []string{"02cc", "03dd"}, "02aa",
+					"0123", "4567")
+			},
+			staticCmd: func() interface{} {
+				return NewVerifyPartialSigCmd([]string{"02aa", "03bb"},
+					[]string{"02cc", "03dd"}, "02aa", "0123", "4567")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"verifypartialsig","params":[["02aa","03bb"],["02cc","03dd"],"02aa","0123","4567"],"id":1}`,
+			unmarshalled: &VerifyPartialSigCmd{
+				PubKeys:      []string{"02aa", "03bb"},
+				PubNonces:    []string{"02cc", "03dd"},
+				SignerPubKey: "02aa",
+				Message:      "0123",
+				PartialSig:   "4567",
+			},
+		},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -19,11 +19,131 @@ type TxRawDecodeResult struct {
 
 // DecodeScriptResult models the data returned from the decodescript command.
 type DecodeScriptResult struct {
-	Asm       string   `json:"asm"`
-	ReqSigs   int32    `json:"reqSigs,omitempty"`
-	Type      string   `json:"type"`
-	Addresses []string `json:"addresses,omitempty"`
-	P2sh      string   `json:"p2sh,omitempty"`
+	Asm       string                  `json:"asm"`
+	ReqSigs   int32                   `json:"reqSigs,omitempty"`
+	Type      string                  `json:"type"`
+	Addresses []string                `json:"addresses,omitempty"`
+	P2sh      string                  `json:"p2sh,omitempty"`
+	HTLC      *DecodeScriptHTLCResult `json:"htlc,omitempty"`
+	SSFee     *SSFeeMarkerResult      `json:"ssfee,omitempty"`
+	Emission  *SKAEmissionAuthResult  `json:"emission,omitempty"`
+}
+
+// DecodeScriptHTLCResult models the details of a hash-time-locked contract
+// script returned by the decodescript command.
+type DecodeScriptHTLCResult struct {
+	SecretHash string `json:"secrethash"`
+	Recipient  string `json:"recipient"`
+	Refund     string `json:"refund"`
+	LockTime   int64  `json:"locktime"`
+	LockType   string `json:"locktype"`
+}
+
+// SSFeeMarkerResult models the details of a staker or miner fee distribution
+// (SSFee) marker script.  The voter sequence is only present for staker
+// markers.
+type SSFeeMarkerResult struct {
+	Height   uint32  `json:"height"`
+	VoterSeq *uint16 `json:"voterseq,omitempty"`
+}
+
+// SKAEmissionAuthResult models the details of an SKA emission authorization
+// signature script.
+type SKAEmissionAuthResult struct {
+	CoinType uint8  `json:"cointype"`
+	Nonce    uint64 `json:"nonce"`
+	Height   int64  `json:"height"`
+	Amount   string `json:"amount"` // Atoms as string
+	PubKey   string `json:"pubkey"`
+}
+
+// PSTPrevOutResult models the previous output spent by an input of a
+// partially signed transaction.
+type PSTPrevOutResult struct {
+	Value        float64            `json:"value,omitempty"`    // VAR only (omitted for SKA)
+	SKAValue     string             `json:"skavalue,omitempty"` // SKA only (atoms as string)
+	CoinType     uint8              `json:"cointype"`
+	ScriptPubKey ScriptPubKeyResult `json:"scriptPubKey"`
+}
+
+// PSTBip32DerivResult models the hierarchical deterministic derivation path of
+// a public key in a partially signed transaction.
+type PSTBip32DerivResult struct {
+	PubKey            string `json:"pubkey"`
+	MasterFingerprint string `json:"masterfingerprint"`
+	Path              string `json:"path"`
+}
+
+// PSTInputResult models the data of an input of a partially signed
+// transaction.
+type PSTInputResult struct {
+	PrevOut        *PSTPrevOutResult     `json:"prevout,omitempty"`
+	RedeemScript   *ScriptSig            `json:"redeemscript,omitempty"`
+	SigHashType    uint32                `json:"sighashtype,omitempty"`
+	PartialSigs    map[string]string     `json:"partialsigs,omitempty"` // Keyed by hex-encoded pubkey
+	Bip32Derivs    []PSTBip32DerivResult `json:"bip32derivs,omitempty"`
+	FinalScriptSig *ScriptSig            `json:"finalscriptsig,omitempty"`
+}
+
+// PSTOutputResult models the data of an output of a partially signed
+// transaction.
+type PSTOutputResult struct {
+	RedeemScript *ScriptSig            `json:"redeemscript,omitempty"`
+	Bip32Derivs  []PSTBip32DerivResult `json:"bip32derivs,omitempty"`
+}
+
+// PSTFeeResult models the fee paid by a partially signed transaction for a
+// single coin type.
+type PSTFeeResult struct {
+	Fee      float64 `json:"fee,omitempty"`    // VAR only (omitted for SKA)
+	SKAFee   string  `json:"skafee,omitempty"` // SKA only (atoms as string)
+	CoinType uint8   `json:"cointype"`
+}
+
+// DecodePSTResult models the data returned from the decodepst command.  Fees
+// are only provided when the previous outputs of all inputs are known.
+type DecodePSTResult struct {
+	Tx      TxRawDecodeResult `json:"tx"`
+	Inputs  []PSTInputResult  `json:"inputs"`
+	Outputs []PSTOutputResult `json:"outputs"`
+	Fees    []PSTFeeResult    `json:"fees,omitempty"`
+}
+
+// FinalizePSTResult models the data returned from the finalizepst command.
+// Hex is only provided when the packet is complete and extraction was
+// requested.  Otherwise, PST houses the updated packet.
+type FinalizePSTResult struct {
+	PST      string `json:"pst,omitempty"`
+	Hex      string `json:"hex,omitempty"`
+	Complete bool   `json:"complete"`
+}
+
+// AnalyzePSTInputResult models the analysis of an input of a partially signed
+// transaction.
+type AnalyzePSTInputResult struct {
+	HasPrevOut bool   `json:"hasprevout"`
+	IsFinal    bool   `json:"isfinal"`
+	Next       string `json:"next"`
+}
+
+// AnalyzePSTResult models the data returned from the analyzepst command.  Next
+// is the role that must act on the packet next and is one of "updater",
+// "signer", "finalizer", or "extractor".
+type AnalyzePSTResult struct {
+	Inputs []AnalyzePSTInputResult `json:"inputs"`
+	Fees   []PSTFeeResult          `json:"fees,omitempty"`
+	Next   string                  `json:"next"`
+}
+
+// TxOutSetSnapshotResult models the data returned from the dumptxoutset
+// command.
+type TxOutSetSnapshotResult struct {
+	Height    int64  `json:"height"`
+	Hash      string `json:"hash"`
+	Utxos     int64  `json:"utxos"`
+	UtxoHash  string `json:"utxohash"`
+	StateHash string `json:"statehash"`
+	Path      string `json:"path"`
 }
 
 // EstimateSmartFeeResult models the data returned from the estimatesmartfee
@@ -261,6 +381,41 @@ type GetBurnedCoinsResult struct {
 	Stats []GetBurnedCoinsStat `json:"stats"` // Burn statistics by coin type
 }
 
+// BurnOutput models a single SKA burn output of a transaction.
+type BurnOutput struct {
+	Vout     uint32 `json:"vout"`     // Index of the burn output
+	CoinType uint8  `json:"cointype"` // Coin type (1-255 for SKA)
+	Name     string `json:"name"`     // Coin name (e.g., "SKA-1")
+	Amount   string `json:"amount"`   // Amount burned in coins (string for big.Int precision)
+	Atoms    string `json:"atoms"`    // Amount burned in atoms (string for big.Int precision)
+}
+
+// GetBurnProofResult models the data returned from the getburnproof command.
+type GetBurnProofResult struct {
+	Hex   string                    `json:"hex"`   // Serialized burn transaction
+	Burns []BurnOutput              `json:"burns"` // Burn outputs of the transaction
+	Proof GetTxInclusionProofResult `json:"proof"` // Inclusion proof of the transaction
+}
+
+// GetSKASupplyProofResult models the data returned from the getskasupplyproof
+// command.
+type GetSKASupplyProofResult struct {
+	BlockHash       string   `json:"blockhash"`       // Block whose header commits to the supply
+	Height          int64    `json:"height"`          // Height of the block
+	CoinType        uint8    `json:"cointype"`        // Coin type (1-255 for SKA)
+	Name            string   `json:"name"`            // Coin name (e.g., "SKA-1")
+	Nonce           uint64   `json:"nonce"`           // Last used emission nonce
+	Emitted         string   `json:"emitted"`         // Cumulative emitted atoms (string for big.Int)
+	Burned          string   `json:"burned"`          // Cumulative burned atoms (string for big.Int)
+	Circulating     string   `json:"circulating"`     // Circulating atoms (string for big.Int)
+	LeafHash        string   `json:"leafhash"`        // Hash of the serialized supply state
+	LeafIndex       uint32   `json:"leafindex"`       // Index of the leaf in the supply tree
+	LeafProof       []string `json:"leafproof"`       // Proof of the leaf to the supply root
+	SupplyRoot      string   `json:"supplyroot"`      // Root of the SKA supply merkle tree
+	CommitmentIndex uint32   `json:"commitmentindex"` // Index of the supply root in the header commitments
+	CommitmentProof []string `json:"commitmentproof"` // Proof of the supply root to the commitment root
+}
+
 // GetChainTipsResult models the data returns from the getchaintips command.
 type GetChainTipsResult struct {
 	Height    int64  `json:"height"`
@@ -298,6 +453,17 @@ type InfoChainResult struct {
 	TxIndex         bool    `json:"txindex"`
 }
 
+// GetMempoolHistoryResult models a single transaction that was rejected from
+// or evicted out of the mempool as returned by the getmempoolhistory command.
+type GetMempoolHistoryResult struct {
+	TxHash   string `json:"txhash"`
+	CoinType uint8  `json:"cointype"`
+	Reason   string `json:"reason"`
+	Detail   string `json:"detail,omitempty"`
+	Conflict string `json:"conflict,omitempty"`
+	Time     int64  `json:"time"`
+}
+
 // GetMempoolInfoResult models the data returned from the getmempoolinfo
 // command.
 type GetMempoolInfoResult struct {
@@ -366,28 +532,71 @@ type GetNetTotalsResult struct {
 	TimeMillis     int64  `json:"timemillis"`
 }
 
+// UploadTargetResult models the max upload target data returned from the
+// getnetworkstats command.
+type UploadTargetResult struct {
+	Timeframe             int64  `json:"timeframe"`
+	Target                uint64 `json:"target"`
+	TargetReached         bool   `json:"target_reached"`
+	ServeHistoricalBlocks bool   `json:"serve_historical_blocks"`
+	BytesLeftInCycle      uint64 `json:"bytes_left_in_cycle"`
+	TimeLeftInCycle       int64  `json:"time_left_in_cycle"`
+}
+
+// GetNetworkStatsResult models the data returned from the getnetworkstats
+// command.
+type GetNetworkStatsResult struct {
+	TotalBytesRecv    uint64             `json:"totalbytesrecv"`
+	TotalBytesSent    uint64             `json:"totalbytessent"`
+	TimeMillis        int64              `json:"timemillis"`
+	BytesRecvPerMsg   map[string]uint64  `json:"bytesrecv_per_msg"`
+	BytesSentPerMsg   map[string]uint64  `json:"bytessent_per_msg"`
+	MaxUploadRate     uint64             `json:"maxuploadrate"`
+	MaxPeerUploadRate uint64             `json:"maxpeeruploadrate"`
+	UploadTarget      UploadTargetResult `json:"uploadtarget"`
+}
+
+// GetNodeAddressesResult models the data returned from the getnodeaddresses
+// command.
+type GetNodeAddressesResult struct {
+	Time     int64  `json:"time"`
+	Services string `json:"services"`
+	Address  string `json:"address"`
+	Port     uint16 `json:"port"`
+	MappedAS uint32 `json:"mappedas,omitempty"`
+}
+
 // GetPeerInfoResult models the data returned from the getpeerinfo command.
 type GetPeerInfoResult struct {
-	ID             int32   `json:"id"`
-	Addr           string  `json:"addr"`
-	AddrLocal      string  `json:"addrlocal,omitempty"`
-	Services       string  `json:"services"`
-	RelayTxes      bool    `json:"relaytxes"`
-	LastSend       int64   `json:"lastsend"`
-	LastRecv       int64   `json:"lastrecv"`
-	BytesSent      uint64  `json:"bytessent"`
-	BytesRecv      uint64  `json:"bytesrecv"`
-	ConnTime       int64   `json:"conntime"`
-	TimeOffset     int64   `json:"timeoffset"`
-	PingTime       float64 `json:"pingtime"`
-	PingWait       float64 `json:"pingwait,omitempty"`
-	Version        uint32  `json:"version"`
-	SubVer         string  `json:"subver"`
-	Inbound        bool    `json:"inbound"`
-	StartingHeight int64   `json:"startingheight"`
-	CurrentHeight  int64   `json:"currentheight,omitempty"`
-	BanScore       int32   `json:"banscore"`
-	SyncNode       bool    `json:"syncnode"`
+	ID              int32             `json:"id"`
+	Addr            string            `json:"addr"`
+	AddrLocal       string            `json:"addrlocal,omitempty"`
+	Services        string            `json:"services"`
+	RelayTxes       bool              `json:"relaytxes"`
+	LastSend        int64             `json:"lastsend"`
+	LastRecv        int64             `json:"lastrecv"`
+	BytesSent       uint64            `json:"bytessent"`
+	BytesRecv       uint64            `json:"bytesrecv"`
+	BytesSentPerMsg map[string]uint64 `json:"bytessent_per_msg"`
+	BytesRecvPerMsg map[string]uint64 `json:"bytesrecv_per_msg"`
+	ConnTime        int64             `json:"conntime"`
+	TimeOffset      int64             `json:"timeoffset"`
+	PingTime        float64           `json:"pingtime"`
+	PingWait        float64           `json:"pingwait,omitempty"`
+	Version         uint32            `json:"version"`
+	SubVer          string            `json:"subver"`
+	Inbound         bool              `json:"inbound"`
+	Transport       string            `json:"transport"`
+	MappedAS        uint32            `json:"mappedas,omitempty"`
+	StartingHeight  int64             `json:"startingheight"`
+	CurrentHeight   int64             `json:"currentheight,omitempty"`
+	BanScore        int32             `json:"banscore"`
+	SyncNode        bool              `json:"syncnode"`
+	BlocksInFlight  int32             `json:"blocksinflight"`
+	BlocksRecv      uint64            `json:"blocksrecv"`
+	BlockStalls     uint64            `json:"blockstalls"`
+	BlockWindow     int32             `json:"blockwindow"`
+	BlockRespTime   float64           `json:"blockresptime"`
 }
 
 // GetRawMempoolVerboseResult models the data returned from the getrawmempool
@@ -473,11 +682,36 @@ type GetStakeVersionsResult struct {
 	StakeVersions []StakeVersions `json:"stakeversions"`
 }
 
+// GetTxInclusionProofResult models the data returned from the
+// gettxinclusionproof command.
+//
+// The proof hashes are the sibling hashes along the path from the leaf, which
+// is the full hash of the transaction including its witness data, to the
+// merkle root of the transaction tree it resides in.  When the sibling tree
+// root is set, the merkle root in the header commits to the combined root
+// BLAKE-256(regular tree root || stake tree root) as defined by DCP0005.
+// Otherwise, the regular and stake tree roots are the merkle root and stake
+// root in the header, respectively.
+type GetTxInclusionProofResult struct {
+	BlockHash       string   `json:"blockhash"`
+	Height          int64    `json:"height"`
+	Confirmations   int64    `json:"confirmations"`
+	Header          string   `json:"header"`
+	Tree            int8     `json:"tree"`
+	Index           uint32   `json:"index"`
+	LeafHash        string   `json:"leafhash"`
+	TreeRoot        string   `json:"treeroot"`
+	Proof           []string `json:"proof"`
+	SiblingTreeRoot string   `json:"siblingtreeroot,omitempty"`
+}
+
 // GetTxOutResult models the data from the gettxout command.
 type GetTxOutResult struct {
 	BestBlock     string             `json:"bestblock"`
 	Confirmations int64              `json:"confirmations"`
 	Value         float64            `json:"value"`
+	SKAValue      string             `json:"skavalue,omitempty"` // SKA only (atoms as string)
+	CoinType      uint8              `json:"cointype"`
 	ScriptPubKey  ScriptPubKeyResult `json:"scriptPubKey"`
 	Coinbase      bool               `json:"coinbase"`
 }
@@ -568,6 +802,16 @@ type Ticket struct {
 	Owner string `json:"owner"`
 }
 
+// ListBannedResult models the data returned from the listbanned command.
+type ListBannedResult struct {
+	Subnet        string `json:"subnet"`
+	BanCreated    int64  `json:"bancreated"`
+	BannedUntil   int64  `json:"banneduntil"`
+	BanDuration   int64  `json:"banduration"`
+	TimeRemaining int64  `json:"timeremaining"`
+	Reason        string `json:"reason"`
+}
+
 // LiveTicketsResult models the data returned from the livetickets
 // command.
 type LiveTicketsResult struct {
@@ -645,8 +889,17 @@ type TicketsForAddressResult struct {
 // ValidateAddressChainResult models the data returned by the chain server
 // validateaddress command.
 type ValidateAddressChainResult struct {
-	IsValid bool   `json:"isvalid"`
-	Address string `json:"address,omitempty"`
+	IsValid  bool   `json:"isvalid"`
+	Address  string `json:"address,omitempty"`
+	CoinType *uint8 `json:"cointype,omitempty"`
+}
+
+// VerifyPartialSigResult models the data returned by the verifypartialsig
+// command.
+type VerifyPartialSigResult struct {
+	AggregateKey   string `json:"aggregatekey"`
+	AggregateNonce string `json:"aggregatenonce"`
+	Valid          bool   `json:"valid"`
 }
 
 // VersionResult models objects included in the version response.  In the actual
@@ -663,21 +916,23 @@ type VersionResult struct {
 // ScriptPubKeyResult models the scriptPubKey data of a tx script.  It is
 // defined separately since it is used by multiple commands.
 type ScriptPubKeyResult struct {
-	Asm       string   `json:"asm"`
-	Hex       string   `json:"hex,omitempty"`
-	ReqSigs   int32    `json:"reqSigs,omitempty"`
-	Type      string   `json:"type"`
-	Addresses []string `json:"addresses,omitempty"`
-	CommitAmt *float64 `json:"commitamt,omitempty"`
-	Version   uint16   `json:"version"`
+	Asm       string             `json:"asm"`
+	Hex       string             `json:"hex,omitempty"`
+	ReqSigs   int32              `json:"reqSigs,omitempty"`
+	Type      string             `json:"type"`
+	Addresses []string           `json:"addresses,omitempty"`
+	CommitAmt *float64           `json:"commitamt,omitempty"`
+	SSFee     *SSFeeMarkerResult `json:"ssfee,omitempty"`
+	Version   uint16             `json:"version"`
 }
 
 // ScriptSig models a signature script.  It is defined separately since it only
 // applies to non-coinbase.  Therefore the field in the Vin structure needs
 // to be a pointer.
 type ScriptSig struct {
-	Asm string `json:"asm"`
-	Hex string `json:"hex"`
+	Asm      string                 `json:"asm"`
+	Hex      string                 `json:"hex"`
+	Emission *SKAEmissionAuthResult `json:"emission,omitempty"`
 }
 
 // Vin models parts of the tx data.  It is defined separately since
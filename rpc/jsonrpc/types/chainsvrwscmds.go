@@ -78,6 +78,15 @@ func NewNotifyTSpendCmd() *NotifyTSpendCmd {
 	return &NotifyTSpendCmd{}
 }
 
+// NotifyMempoolHistoryCmd defines the notifymempoolhistory JSON-RPC command.
+type NotifyMempoolHistoryCmd struct{}
+
+// NewNotifyMempoolHistoryCmd returns a new instance which can be used to issue
+// a notifymempoolhistory JSON-RPC command.
+func NewNotifyMempoolHistoryCmd() *NotifyMempoolHistoryCmd {
+	return &NotifyMempoolHistoryCmd{}
+}
+
 // NotifyWinningTicketsCmd is a type handling custom marshaling and
 // unmarshaling of notifywinningtickets JSON websocket extension
 // commands.
@@ -137,6 +146,16 @@ func NewStopNotifyTSpendCmd() *StopNotifyTSpendCmd {
 	return &StopNotifyTSpendCmd{}
 }
 
+// StopNotifyMempoolHistoryCmd defines the stopnotifymempoolhistory JSON-RPC
+// command.
+type StopNotifyMempoolHistoryCmd struct{}
+
+// NewStopNotifyMempoolHistoryCmd returns a new instance which can be used to
+// issue a stopnotifymempoolhistory JSON-RPC command.
+func NewStopNotifyMempoolHistoryCmd() *StopNotifyMempoolHistoryCmd {
+	return &StopNotifyMempoolHistoryCmd{}
+}
+
 // NotifyNewTransactionsCmd defines the notifynewtransactions JSON-RPC command.
 type NotifyNewTransactionsCmd struct {
 	Verbose *bool `jsonrpcdefault:"false"`
@@ -212,6 +231,7 @@ func init() {
 	dcrjson.MustRegister(Method("notifyblocks"), (*NotifyBlocksCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifywork"), (*NotifyWorkCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifytspend"), (*NotifyTSpendCmd)(nil), flags)
+	dcrjson.MustRegister(Method("notifymempoolhistory"), (*NotifyMempoolHistoryCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifynewtransactions"), (*NotifyNewTransactionsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifynewtickets"), (*NotifyNewTicketsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifywinningtickets"), (*NotifyWinningTicketsCmd)(nil), flags)
@@ -221,6 +241,7 @@ func init() {
 	dcrjson.MustRegister(Method("stopnotifyblocks"), (*StopNotifyBlocksCmd)(nil), flags)
 	dcrjson.MustRegister(Method("stopnotifywork"), (*StopNotifyWorkCmd)(nil), flags)
 	dcrjson.MustRegister(Method("stopnotifytspend"), (*StopNotifyTSpendCmd)(nil), flags)
+	dcrjson.MustRegister(Method("stopnotifymempoolhistory"), (*StopNotifyMempoolHistoryCmd)(nil), flags)
 	dcrjson.MustRegister(Method("stopnotifynewtransactions"), (*StopNotifyNewTransactionsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("stopnotifymixmessages"), (*StopNotifyMixMessagesCmd)(nil), flags)
 	dcrjson.MustRegister(Method("rescan"), (*RescanCmd)(nil), flags)
@@ -118,6 +118,28 @@ func TestChainSvrWsCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifywork","params":[],"id":1}`,
 			unmarshalled: &StopNotifyWorkCmd{},
 		},
+		{
+			name: "notifymempoolhistory",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("notifymempoolhistory"))
+			},
+			staticCmd: func() interface{} {
+				return NewNotifyMempoolHistoryCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"notifymempoolhistory","params":[],"id":1}`,
+			unmarshalled: &NotifyMempoolHistoryCmd{},
+		},
+		{
+			name: "stopnotifymempoolhistory",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("stopnotifymempoolhistory"))
+			},
+			staticCmd: func() interface{} {
+				return NewStopNotifyMempoolHistoryCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifymempoolhistory","params":[],"id":1}`,
+			unmarshalled: &StopNotifyMempoolHistoryCmd{},
+		},
 		{
 			name: "stopnotifytspend",
 			newCmd: func() (interface{}, error) {
@@ -30,6 +30,11 @@ const (
 	// server that a new tspend has arrived in the mempool.
 	TSpendNtfnMethod Method = "tspend"
 
+	// MempoolHistoryNtfnMethod is the method used for notifications from the
+	// chain server that a transaction has been rejected from or evicted out
+	// of the mempool.
+	MempoolHistoryNtfnMethod Method = "mempoolhistory"
+
 	// ReorganizationNtfnMethod is the method used for notifications that the
 	// block chain is in the process of a reorganization.
 	ReorganizationNtfnMethod Method = "reorganization"
@@ -133,6 +138,19 @@ func NewTSpendNtfn(tspend string) *TSpendNtfn {
 	}
 }
 
+// MempoolHistoryNtfn defines the mempoolhistory JSON-RPC notification.
+type MempoolHistoryNtfn struct {
+	Entry GetMempoolHistoryResult `json:"entry"`
+}
+
+// NewMempoolHistoryNtfn returns a new instance which can be used to issue a
+// mempoolhistory JSON-RPC notification.
+func NewMempoolHistoryNtfn(entry GetMempoolHistoryResult) *MempoolHistoryNtfn {
+	return &MempoolHistoryNtfn{
+		Entry: entry,
+	}
+}
+
 // ReorganizationNtfn defines the reorganization JSON-RPC notification.
 type ReorganizationNtfn struct {
 	OldHash   string `json:"oldhash"`
@@ -233,6 +251,7 @@ func init() {
 	dcrjson.MustRegister(BlockDisconnectedNtfnMethod, (*BlockDisconnectedNtfn)(nil), flags)
 	dcrjson.MustRegister(WorkNtfnMethod, (*WorkNtfn)(nil), flags)
 	dcrjson.MustRegister(TSpendNtfnMethod, (*TSpendNtfn)(nil), flags)
+	dcrjson.MustRegister(MempoolHistoryNtfnMethod, (*MempoolHistoryNtfn)(nil), flags)
 	dcrjson.MustRegister(NewTicketsNtfnMethod, (*NewTicketsNtfn)(nil), flags)
 	dcrjson.MustRegister(ReorganizationNtfnMethod, (*ReorganizationNtfn)(nil), flags)
 	dcrjson.MustRegister(TxAcceptedNtfnMethod, (*TxAcceptedNtfn)(nil), flags)
@@ -129,6 +129,31 @@ func TestChainSvrWsNtfns(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "mempoolhistory",
+			newNtfn: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("mempoolhistory"), `{"txhash":"123","cointype":1,"reason":"doublespent","conflict":"456","time":1700000000}`)
+			},
+			staticNtfn: func() interface{} {
+				return NewMempoolHistoryNtfn(GetMempoolHistoryResult{
+					TxHash:   "123",
+					CoinType: 1,
+					Reason:   "doublespent",
+					Conflict: "456",
+					Time:     1700000000,
+				})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"mempoolhistory","params":[{"txhash":"123","cointype":1,"reason":"doublespent","conflict":"456","time":1700000000}],"id":null}`,
+			unmarshalled: &MempoolHistoryNtfn{
+				Entry: GetMempoolHistoryResult{
+					TxHash:   "123",
+					CoinType: 1,
+					Reason:   "doublespent",
+					Conflict: "456",
+					Time:     1700000000,
+				},
+			},
+		},
 		{
 			name: "winningtickets",
 			newNtfn: func() (interface{}, error) {
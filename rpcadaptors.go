@@ -10,6 +10,7 @@ import (
 	"errors"
 	"math/big"
 	"net"
+	"net/netip"
 	"time"
 
 	"github.com/monetarium/monetarium-node/chaincfg"
@@ -24,6 +25,7 @@ import (
 	"github.com/monetarium/monetarium-node/internal/mining/cpuminer"
 	"github.com/monetarium/monetarium-node/internal/netsync"
 	"github.com/monetarium/monetarium-node/internal/rpcserver"
+	"github.com/monetarium/monetarium-node/internal/staging/banmanager"
 	"github.com/monetarium/monetarium-node/mixing"
 	"github.com/monetarium/monetarium-node/peer"
 	"github.com/monetarium/monetarium-node/wire"
@@ -111,6 +113,30 @@ func (p *rpcPeer) BanScore() uint32 {
 	return (*serverPeer)(p).banScore.Int()
 }
 
+// BlockDownloadStats returns statistics about the blocks requested from the peer
+// during the chain sync process.
+//
+// This function is safe for concurrent access and is part of the rpcserver.Peer
+// interface implementation.
+func (p *rpcPeer) BlockDownloadStats() netsync.BlockDownloadStats {
+	return (*serverPeer)(p).syncMgrPeer.BlockDownloadStats()
+}
+
+// MappedAS returns the autonomous system number the address of the peer is
+// mapped to by the IP-to-ASN map in use.  It is zero when there is no map or
+// the address is not mapped.
+//
+// This function is safe for concurrent access and is part of the rpcserver.Peer
+// interface implementation.
+func (p *rpcPeer) MappedAS() uint32 {
+	sp := (*serverPeer)(p)
+	na := sp.NA()
+	if na == nil {
+		return 0
+	}
+	return sp.server.addrManager.ASN(wireToAddrmgrNetAddress(na))
+}
+
 // rpcConnManager provides a connection manager for use with the RPC server and
 // implements the rpcserver.ConnManager interface.
 type rpcConnManager struct {
@@ -182,7 +208,7 @@ func (cm *rpcConnManager) removeNode(cmp func(*serverPeer) bool) error {
 		// Update the group counts since the peer will be removed from the
 		// persistent peers just after this func returns.
 		remoteAddr := wireToAddrmgrNetAddress(sp.NA())
-		state.outboundGroups[remoteAddr.GroupKey()]--
+		state.outboundGroups[cm.server.addrManager.GroupKey(remoteAddr)]--
 
 		connReq := sp.connReq.Load()
 		peerLog.Debugf("Removing persistent peer %s (reqid %d)", remoteAddr,
@@ -258,7 +284,7 @@ func (cm *rpcConnManager) disconnectNode(cmp func(sp *serverPeer) bool) error {
 			// Update the group counts since the peer will be removed from the
 			// persistent peers just after this func returns.
 			remoteAddr := wireToAddrmgrNetAddress(sp.NA())
-			state.outboundGroups[remoteAddr.GroupKey()]--
+			state.outboundGroups[cm.server.addrManager.GroupKey(remoteAddr)]--
 		})
 		if !found {
 			break
@@ -310,6 +336,15 @@ func (cm *rpcConnManager) NetTotals() (uint64, uint64) {
 	return cm.server.NetTotals()
 }
 
+// NetworkStats returns detailed traffic accounting across all peers along with
+// the state of the configured upload limits.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConnManager interface implementation.
+func (cm *rpcConnManager) NetworkStats() *rpcserver.NetworkStats {
+	return cm.server.NetworkStats()
+}
+
 // ConnectedPeers returns an array consisting of all connected peers.
 //
 // This function is safe for concurrent access and is part of the
@@ -372,6 +407,17 @@ func (cm *rpcConnManager) RelayTransactions(txns []*dcrutil.Tx) {
 	cm.server.relayTransactions(txns)
 }
 
+// StemTransaction attempts to relay the passed locally submitted transaction
+// via the stem phase of private transaction relay.  It returns false without
+// an error when the transaction should instead be processed and relayed
+// normally.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConnManager interface implementation.
+func (cm *rpcConnManager) StemTransaction(tx *dcrutil.Tx, allowHighFees bool) (bool, error) {
+	return cm.server.stemTransaction(tx, nil, allowHighFees)
+}
+
 // RelayMixMessages generates and relays inventory vectors for all of the
 // passed mixing messages to all connected peers.
 //
@@ -389,6 +435,75 @@ func (*rpcConnManager) Lookup(host string) ([]net.IP, error) {
 	return dcrdLookup(host)
 }
 
+// SetBan bans the provided subnet for the provided duration along with the
+// reason for the ban and disconnects all connected peers within it.  The
+// configured ban duration is used when the duration is zero.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConnManager interface implementation.
+func (cm *rpcConnManager) SetBan(subnet netip.Prefix, duration time.Duration, reason string) error {
+	if duration == 0 {
+		duration = cfg.BanDuration
+	}
+	if reason == "" {
+		reason = "manually banned"
+	}
+	err := cm.server.banList.Ban(subnet, duration, reason)
+	if err != nil {
+		return err
+	}
+	srvrLog.Infof("Banned %v for %v: %s", subnet, duration, reason)
+
+	// Disconnect all peers within the newly banned subnet.
+	state := &cm.server.peerState
+	state.Lock()
+	state.forAllPeers(func(sp *serverPeer) {
+		host, _, err := net.SplitHostPort(sp.Addr())
+		if err == nil && cm.server.banList.IsHostBanned(host) {
+			sp.Disconnect()
+		}
+	})
+	state.Unlock()
+	return nil
+}
+
+// RemoveBan removes the ban of the provided subnet.  Attempting to remove a
+// subnet that is not banned will return an error.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConnManager interface implementation.
+func (cm *rpcConnManager) RemoveBan(subnet netip.Prefix) error {
+	removed, err := cm.server.banList.Unban(subnet)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.New("subnet is not banned")
+	}
+	srvrLog.Infof("Removed ban for %v", subnet)
+	return nil
+}
+
+// BannedSubnets returns all currently banned subnets.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConnManager interface implementation.
+func (cm *rpcConnManager) BannedSubnets() []banmanager.BanEntry {
+	return cm.server.banList.Entries()
+}
+
+// ClearBanned removes all bans.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConnManager interface implementation.
+func (cm *rpcConnManager) ClearBanned() error {
+	if err := cm.server.banList.Clear(); err != nil {
+		return err
+	}
+	srvrLog.Info("Cleared all bans")
+	return nil
+}
+
 // rpcSyncMgr provides an adaptor for use with the RPC server and implements the
 // rpcserver.SyncManager interface.
 type rpcSyncMgr struct {
@@ -439,6 +554,12 @@ func (b *rpcSyncMgr) ProcessTransaction(tx *dcrutil.Tx, allowOrphans bool,
 		allowHighFees, tag)
 }
 
+// ProcessPackage relays the provided package of transactions for validation
+// and insertion into the memory pool.
+func (b *rpcSyncMgr) ProcessPackage(txns []*dcrutil.Tx, allowHighFees bool) ([]*dcrutil.Tx, error) {
+	return b.server.txMemPool.ProcessPackage(txns, allowHighFees)
+}
+
 // RecentlyConfirmedTxn returns with high degree of confidence whether a
 // transaction has been recently confirmed in a block.
 //
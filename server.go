@@ -45,6 +45,7 @@ import (
 	"github.com/monetarium/monetarium-node/internal/mining/cpuminer"
 	"github.com/monetarium/monetarium-node/internal/netsync"
 	"github.com/monetarium/monetarium-node/internal/rpcserver"
+	"github.com/monetarium/monetarium-node/internal/staging/banmanager"
 	"github.com/monetarium/monetarium-node/internal/version"
 	"github.com/monetarium/monetarium-node/math/uint256"
 	"github.com/monetarium/monetarium-node/mixing"
@@ -80,6 +81,10 @@ const (
 	// maxProtocolVersion is the max protocol version the server supports.
 	maxProtocolVersion = wire.ProtocolVersion
 
+	// banListFilename is the name of the file in the data directory that
+	// houses the banned subnets.
+	banListFilename = "banlist.json"
+
 	// These fields are used to track known addresses on a per-peer basis.
 	//
 	// maxKnownAddrsPerPeer is the maximum number of items to track.
@@ -165,6 +170,37 @@ const (
 	// These values result in about 640 KiB memory usage including overhead.
 	maxRecentlyAdvertisedTxns = 4500
 	recentlyAdvertisedTxnsTTL = 45 * time.Second
+
+	// These fields are used to track the addresses of outbound peers that
+	// failed the v2 transport handshake so that subsequent connections to
+	// them use the v1 transport.
+	//
+	// maxV2TransportFailures is the number of consecutive times the v2
+	// transport handshake with an address must fail before connections to it
+	// fall back to the v1 transport.  A single failure is not enough since it
+	// might also be the result of network issues or an attacker interfering
+	// with the handshake in an attempt to downgrade the connection.
+	//
+	// v2TransportFailureTTL is the time to keep the number of failures for an
+	// address before they are expired.
+	//
+	// maxV1OnlyPeers specifies the maximum number of addresses to track.
+	//
+	// v1OnlyPeerTTL is the time to keep addresses before they are expired so
+	// the v2 transport is attempted again in case the failures were not
+	// actually due to the peers only supporting the v1 transport or the peers
+	// upgrade.
+	maxV2TransportFailures = 2
+	v2TransportFailureTTL  = 6 * time.Hour
+	maxV1OnlyPeers         = 1000
+	v1OnlyPeerTTL          = time.Hour
+
+	// maxBlockTxnsDepth is the maximum depth below the current best chain tip
+	// of the blocks for which transactions requested via getblocktxns are
+	// served.  Compact blocks are only sent for new blocks, so requests for
+	// transactions of older blocks are ignored to prevent them from being used
+	// as a cheaper alternative to downloading full blocks.
+	maxBlockTxnsDepth = 10
 )
 
 var (
@@ -230,6 +266,14 @@ type relayMsg struct {
 	reqServices wire.ServiceFlag
 }
 
+// blockAnnouncement houses the data needed to announce a new block to peers
+// via either an inventory vector, a headers message, or a compact block
+// depending on their preferences.
+type blockAnnouncement struct {
+	header     wire.BlockHeader
+	cmpctBlock *wire.MsgCmpctBlock
+}
+
 // naSubmission represents a network address submission from an outbound peer.
 type naSubmission struct {
 	na           *wire.NetAddress
@@ -344,7 +388,7 @@ func (sc *naSubmissionCache) bestSubmission(net addrmgr.NetAddressType) *naSubmi
 }
 
 // peerState houses state of inbound, persistent, and outbound peers as well
-// as banned peers and outbound groups.
+// as outbound groups.
 type peerState struct {
 	sync.Mutex
 
@@ -352,7 +396,6 @@ type peerState struct {
 	inboundPeers    map[int32]*serverPeer
 	outboundPeers   map[int32]*serverPeer
 	persistentPeers map[int32]*serverPeer
-	banned          map[string]time.Time
 	outboundGroups  map[string]int
 	lastMaxIPLog    map[string]time.Time // tracks last INFO log time per IP
 
@@ -362,14 +405,12 @@ type peerState struct {
 }
 
 // makePeerState returns a peer state instance that is used to maintain the
-// state of inbound, persistent, and outbound peers as well as banned peers and
-// outbound groups.
+// state of inbound, persistent, and outbound peers as well as outbound groups.
 func makePeerState() peerState {
 	return peerState{
 		inboundPeers:    make(map[int32]*serverPeer),
 		persistentPeers: make(map[int32]*serverPeer),
 		outboundPeers:   make(map[int32]*serverPeer),
-		banned:          make(map[string]time.Time),
 		outboundGroups:  make(map[string]int),
 		lastMaxIPLog:    make(map[string]time.Time),
 		subCache: &naSubmissionCache{
@@ -573,6 +614,7 @@ func (ps *peerState) ResolveLocalAddress(netType addrmgr.NetAddressType, addrMgr
 type server struct {
 	bytesReceived atomic.Uint64 // Total bytes received from all peers since start.
 	bytesSent     atomic.Uint64 // Total bytes sent by all peers since start.
+	numBans       atomic.Uint64 // Total peers banned since start.
 	shutdown      atomic.Bool
 
 	// targetOutbound is the calculated number of target outbound peers to
@@ -590,12 +632,14 @@ type server struct {
 	chainParams          *chaincfg.Params
 	addrManager          *addrmgr.AddrManager
 	connManager          *connmgr.ConnManager
+	banList              *banmanager.BanList
 	sigCache             *txscript.SigCache
 	subsidyCache         *standalone.SubsidyCache
 	rpcServer            *rpcserver.Server
 	syncManager          *netsync.SyncManager
 	bg                   *mining.BgBlkTmplGenerator
 	chain                *blockchain.BlockChain
+	utxoCache            *blockchain.UtxoCache
 	txMemPool            *mempool.TxPool
 	feeEstimator         *fees.Estimator
 	feeCalculator        *fees.CoinTypeFeeCalculator // Shared fee calculator for mining and RPC
@@ -620,6 +664,10 @@ type server struct {
 	existsAddrIndex *indexers.ExistsAddrIndex
 	ssfeeIndex      *indexers.SSFeeIndex
 
+	// metricsListeners houses the listeners the metrics endpoint is served
+	// on.  It is empty when metrics are not enabled.
+	metricsListeners []net.Listener
+
 	// These following fields are used to filter duplicate block lottery data
 	// anouncements.
 	lotteryDataBroadcastMtx sync.Mutex
@@ -654,6 +702,35 @@ type server struct {
 	// overhead is not warranted.
 	recentlyAdvertisedTxns *lru.Map[chainhash.Hash, *dcrutil.Tx]
 
+	// v1Fallback tracks the addresses of outbound peers that do not support
+	// the v2 transport.
+	v1Fallback *v1TransportFallback
+
+	// stemRouter houses the state used to route transactions during the stem
+	// phase of private transaction relay.
+	stemRouter stemRouter
+
+	// anchors houses the outbound peers saved on the previous clean shutdown
+	// that are reconnected to before any others.
+	anchors anchorState
+
+	// uploadLimiter limits the combined rate of data sent to all peers that
+	// are not whitelisted.  It is nil when there is no limit.
+	uploadLimiter *peer.RateLimiter
+
+	// uploadTarget tracks the data sent to peers against the max upload
+	// target.
+	uploadTarget uploadTarget
+
+	// msgBytes tracks the total bytes sent and received across all peers by
+	// message command.
+	msgBytes msgByteCounters
+
+	// onionTarget is the address the onion service created via the Tor
+	// control port forwards incoming connections to.  It is empty when no
+	// onion service is to be created.
+	onionTarget string
+
 	// The following fields are used to periodically log the total number
 	// evicted recently advertised transactions.  They are only accessed from
 	// a single long-running goroutine, so they are not protected for concurrent
@@ -691,6 +768,8 @@ type serverPeer struct {
 	connReq        atomic.Pointer[connmgr.ConnReq]
 	continueHash   atomic.Pointer[chainhash.Hash]
 	disableRelayTx atomic.Bool
+	msgsReceived   atomic.Uint64
+	msgsSent       atomic.Uint64
 	knownAddresses *apbf.Filter
 	banScore       connmgr.DynamicBanScore
 
@@ -793,6 +872,12 @@ func (sp *serverPeer) handleServeGetData(invVects []*wire.InvVect,
 
 		case wire.InvTypeBlock:
 			blockHash := &iv.Hash
+			if sp.isHistoricalBlockRestricted(blockHash) {
+				peerLog.Debugf("Not serving historical block %v to peer %s "+
+					"since the max upload target has been reached",
+					blockHash, sp)
+				break
+			}
 			block, err := sp.server.chain.BlockByHash(blockHash)
 			if err != nil {
 				peerLog.Debugf("Unable to fetch block hash %v for peer %s: %v",
@@ -896,6 +981,25 @@ func (sp *serverPeer) handleServeGetData(invVects []*wire.InvVect,
 	}
 }
 
+// isHistoricalBlockRestricted returns whether or not the block associated with
+// the provided hash must not be served to the peer because it is a historical
+// block and the max upload target has been reached.  Whitelisted peers are
+// exempt.
+func (sp *serverPeer) isHistoricalBlockRestricted(blockHash *chainhash.Hash) bool {
+	if sp.isWhitelisted {
+		return false
+	}
+	now := time.Now()
+	if !sp.server.uploadTarget.reached(now) {
+		return false
+	}
+	header, err := sp.server.chain.HeaderByHash(blockHash)
+	if err != nil {
+		return false
+	}
+	return now.Sub(header.Timestamp) > historicalBlockAge
+}
+
 // serveGetData provides an asynchronous queue that services all data requested
 // via getdata requests such that the peer may mix and match simultaneous
 // getdata requests for varying amounts of data items so long as it does not
@@ -1011,9 +1115,37 @@ func addrmgrToWireNetAddress(netAddr *addrmgr.NetAddress) *wire.NetAddress {
 		netAddr.IP, netAddr.Port)
 }
 
+// addrmgrToWireNetAddressV2 converts an address manager net address to a wire
+// version 2 net address.
+func addrmgrToWireNetAddressV2(netAddr *addrmgr.NetAddress) *wire.NetAddressV2 {
+	encodedAddr := netAddr.IP
+	switch netAddr.Type {
+	case addrmgr.IPv4Address:
+		encodedAddr = net.IP(netAddr.IP).To4()
+	case addrmgr.IPv6Address:
+		encodedAddr = net.IP(netAddr.IP).To16()
+	}
+	return wire.NewNetAddressV2(netAddr.Timestamp, netAddr.Services,
+		wire.NetAddressType(netAddr.Type), encodedAddr, netAddr.Port)
+}
+
+// wireV2ToAddrmgrNetAddress converts a wire version 2 net address to an address
+// manager net address.  An error is returned when the address is of a type the
+// address manager does not support or is otherwise invalid.
+func wireV2ToAddrmgrNetAddress(netAddr *wire.NetAddressV2) (*addrmgr.NetAddress, error) {
+	return addrmgr.NewNetAddressFromParams(addrmgr.NetAddressType(netAddr.Type),
+		netAddr.EncodedAddr, netAddr.Port, netAddr.Timestamp, netAddr.Services)
+}
+
 // pushAddrMsg sends an addr message to the connected peer using the provided
-// addresses.
+// addresses.  Peers that negotiated a protocol version that supports addrv2
+// messages are sent an addrv2 message instead.
 func (sp *serverPeer) pushAddrMsg(addresses []*addrmgr.NetAddress) {
+	if sp.ProtocolVersion() >= wire.AddrV2Version {
+		sp.pushAddrV2Msg(addresses)
+		return
+	}
+
 	// Filter addresses already known to the peer.
 	addrs := make([]*wire.NetAddress, 0, len(addresses))
 	for _, addr := range addresses {
@@ -1033,6 +1165,34 @@ func (sp *serverPeer) pushAddrMsg(addresses []*addrmgr.NetAddress) {
 	sp.addKnownAddresses(knownNetAddrs)
 }
 
+// pushAddrV2Msg sends an addrv2 message to the connected peer using the
+// provided addresses.
+func (sp *serverPeer) pushAddrV2Msg(addresses []*addrmgr.NetAddress) {
+	// Filter addresses already known to the peer.
+	addrs := make([]*wire.NetAddressV2, 0, len(addresses))
+	for _, addr := range addresses {
+		if !sp.addressKnown(addr) {
+			addrs = append(addrs, addrmgrToWireNetAddressV2(addr))
+		}
+	}
+	known, err := sp.PushAddrV2Msg(addrs)
+	if err != nil {
+		peerLog.Errorf("Can't push addrv2 message to %s: %v", sp, err)
+		sp.Disconnect()
+		return
+	}
+
+	knownNetAddrs := make([]*addrmgr.NetAddress, 0, len(known))
+	for _, wireAddr := range known {
+		na, err := wireV2ToAddrmgrNetAddress(wireAddr)
+		if err != nil {
+			continue
+		}
+		knownNetAddrs = append(knownNetAddrs, na)
+	}
+	sp.addKnownAddresses(knownNetAddrs)
+}
+
 // addBanScore increases the persistent and decaying ban score fields by the
 // values passed as parameters. If the resulting score exceeds half of the ban
 // threshold, a warning is logged including the reason provided. Further, if
@@ -1084,9 +1244,22 @@ func isSupportedNetAddrTypeV1(addrType addrmgr.NetAddressType) bool {
 	return addrType == addrmgr.IPv4Address || addrType == addrmgr.IPv6Address
 }
 
+// isSupportedNetAddrTypeV2 is a filter which returns whether the provided
+// network address type is supported by the addrv2 wire message.
+func isSupportedNetAddrTypeV2(addrType addrmgr.NetAddressType) bool {
+	switch addrType {
+	case addrmgr.IPv4Address, addrmgr.IPv6Address, addrmgr.TORv3Address:
+		return true
+	}
+	return false
+}
+
 // natfSupported returns a filter for the address types supported by the
 // protocol version.
 func natfSupported(pver uint32) addrmgr.NetAddressTypeFilter {
+	if pver >= wire.AddrV2Version {
+		return isSupportedNetAddrTypeV2
+	}
 	return isSupportedNetAddrTypeV1
 }
 
@@ -1213,9 +1386,20 @@ func (sp *serverPeer) OnVersion(_ *peer.Peer, msg *wire.MsgVersion) {
 
 // OnVerAck is invoked when a peer receives a verack wire message.  It creates
 // and sends a sendheaders message to request all block annoucements are made
-// via full headers instead of the inv message.
+// via full headers instead of the inv message.  A sendcmpct message is also
+// sent to request block announcements are made via compact blocks when the
+// negotiated protocol version supports them.  Any previous v2 transport
+// handshake failures for outbound peers that negotiated the v2 transport are
+// also forgotten.
 func (sp *serverPeer) OnVerAck(_ *peer.Peer, msg *wire.MsgVerAck) {
+	if !sp.Inbound() && sp.Transport() == peer.TransportV2 {
+		sp.server.v1Fallback.recordSuccess(sp.Addr())
+	}
 	sp.QueueMessage(wire.NewMsgSendHeaders(), nil)
+	if sp.ProtocolVersion() >= wire.CmpctBlockVersion {
+		msgSendCmpct := wire.NewMsgSendCmpct(true, wire.CmpctBlockProtocol)
+		sp.QueueMessage(msgSendCmpct, nil)
+	}
 }
 
 // OnMemPool is invoked when a peer receives a mempool wire message.  It creates
@@ -1542,6 +1726,64 @@ func (sp *serverPeer) OnHeaders(_ *peer.Peer, msg *wire.MsgHeaders) {
 	sp.server.syncManager.OnHeaders(msg, sp.syncMgrPeer)
 }
 
+// OnCmpctBlock is invoked when a peer receives a cmpctblock wire message.  The
+// message is passed down to the net sync manager.
+func (sp *serverPeer) OnCmpctBlock(_ *peer.Peer, msg *wire.MsgCmpctBlock) {
+	sp.server.syncManager.OnCmpctBlock(msg, sp.syncMgrPeer)
+}
+
+// OnBlockTxns is invoked when a peer receives a blocktxns wire message.  The
+// message is passed down to the net sync manager.
+func (sp *serverPeer) OnBlockTxns(_ *peer.Peer, msg *wire.MsgBlockTxns) {
+	sp.server.syncManager.OnBlockTxns(msg, sp.syncMgrPeer)
+}
+
+// OnGetBlockTxns is invoked when a peer receives a getblocktxns wire message
+// and is used to deliver the transactions of a recent block that the peer was
+// unable to reconstruct from a compact block.
+func (sp *serverPeer) OnGetBlockTxns(_ *peer.Peer, msg *wire.MsgGetBlockTxns) {
+	block, err := sp.server.chain.BlockByHash(&msg.BlockHash)
+	if err != nil {
+		peerLog.Debugf("Unable to fetch block hash %v for peer %s: %v",
+			msg.BlockHash, sp, err)
+		return
+	}
+
+	// Ignore requests for blocks that are not recent.
+	bestHeight := sp.server.chain.BestSnapshot().Height
+	if block.Height()+maxBlockTxnsDepth < bestHeight {
+		peerLog.Debugf("Ignoring getblocktxns for old block %v from %s",
+			msg.BlockHash, sp)
+		return
+	}
+
+	// Ban peers requesting transactions that are not in the block.  Note that
+	// the indexes are ensured to be in ascending order when decoding, so only
+	// the final index needs to be checked.
+	msgBlock := block.MsgBlock()
+	numTxIdx, numSTxIdx := len(msg.TxIndexes), len(msg.STxIndexes)
+	if (numTxIdx > 0 &&
+		msg.TxIndexes[numTxIdx-1] >= uint32(len(msgBlock.Transactions))) ||
+		(numSTxIdx > 0 &&
+			msg.STxIndexes[numSTxIdx-1] >= uint32(len(msgBlock.STransactions))) {
+
+		const reason = "requested out of range block transactions"
+		sp.server.BanPeer(sp, reason)
+		return
+	}
+
+	blockTxns := wire.NewMsgBlockTxns(&msg.BlockHash)
+	for _, txIdx := range msg.TxIndexes {
+		tx := msgBlock.Transactions[txIdx]
+		blockTxns.Transactions = append(blockTxns.Transactions, tx)
+	}
+	for _, txIdx := range msg.STxIndexes {
+		stx := msgBlock.STransactions[txIdx]
+		blockTxns.STransactions = append(blockTxns.STransactions, stx)
+	}
+	sp.QueueMessage(blockTxns, nil)
+}
+
 // OnGetData is invoked when a peer receives a getdata wire message and is used
 // to deliver block and transaction information.
 func (sp *serverPeer) OnGetData(_ *peer.Peer, msg *wire.MsgGetData) {
@@ -1799,8 +2041,50 @@ func (sp *serverPeer) OnAddr(_ *peer.Peer, msg *wire.MsgAddr) {
 		return
 	}
 
+	sp.addAdvertisedAddresses(wireToAddrmgrNetAddresses(msg.AddrList))
+}
+
+// OnAddrV2 is invoked when a peer receives an addrv2 wire message and is used
+// to notify the server about advertised addresses including those of network
+// types that can't be represented by addr messages such as TORv3.
+func (sp *serverPeer) OnAddrV2(_ *peer.Peer, msg *wire.MsgAddrV2) {
+	// Ignore addresses when running on the simulation and regression test
+	// networks for the same reasons as addr messages.
+	if cfg.SimNet || cfg.RegNet {
+		return
+	}
+
+	// A message that has no addresses is invalid.
+	if len(msg.AddrList) == 0 {
+		// Ban peers sending empty address requests.
+		const reason = "sent an empty address list"
+		sp.server.BanPeer(sp, reason)
+		return
+	}
+
+	// Skip addresses of unknown types since they may be of network types
+	// introduced after this version of the software.
+	addrList := make([]*addrmgr.NetAddress, 0, len(msg.AddrList))
+	for _, wireAddr := range msg.AddrList {
+		na, err := wireV2ToAddrmgrNetAddress(wireAddr)
+		if err != nil {
+			peerLog.Tracef("Ignoring address %v from %v: %v", wireAddr.Type,
+				sp, err)
+			continue
+		}
+		addrList = append(addrList, na)
+	}
+	if len(addrList) == 0 {
+		return
+	}
+
+	sp.addAdvertisedAddresses(addrList)
+}
+
+// addAdvertisedAddresses adds the provided addresses advertised by the peer to
+// the known addresses of the peer and the address manager.
+func (sp *serverPeer) addAdvertisedAddresses(addrList []*addrmgr.NetAddress) {
 	now := time.Now()
-	addrList := wireToAddrmgrNetAddresses(msg.AddrList)
 	for _, na := range addrList {
 		// Don't add more address if we're disconnecting.
 		if !sp.Connected() {
@@ -1901,7 +2185,7 @@ func (sp *serverPeer) OnMixSecrets(_ *peer.Peer, msg *wire.MsgMixSecrets) {
 }
 
 // OnRead is invoked when a peer receives a message and it is used to update
-// the bytes received by the server.
+// the bytes and messages received by the server.
 func (sp *serverPeer) OnRead(_ *peer.Peer, bytesRead int, msg wire.Message, err error) {
 	// Ban peers sending messages that do not conform to the wire protocol.
 	var errCode wire.ErrorCode
@@ -1910,13 +2194,24 @@ func (sp *serverPeer) OnRead(_ *peer.Peer, bytesRead int, msg wire.Message, err
 		sp.server.BanPeer(sp, reason)
 	}
 
+	if err == nil {
+		sp.msgsReceived.Add(1)
+	}
 	sp.server.AddBytesReceived(uint64(bytesRead))
+	if msg != nil {
+		sp.server.msgBytes.add(msg.Command(), uint64(bytesRead), false)
+	}
 }
 
 // OnWrite is invoked when a peer sends a message and it is used to update
-// the bytes sent by the server.
+// the bytes and messages sent by the server.
 func (sp *serverPeer) OnWrite(_ *peer.Peer, bytesWritten int, msg wire.Message, err error) {
+	if err == nil {
+		sp.msgsSent.Add(1)
+	}
 	sp.server.AddBytesSent(uint64(bytesWritten))
+	sp.server.msgBytes.add(msg.Command(), uint64(bytesWritten), true)
+	sp.server.uploadTarget.addBytes(time.Now(), uint64(bytesWritten))
 }
 
 // OnNotFound is invoked when a peer sends a notfound message.
@@ -2044,6 +2339,16 @@ func (s *server) relayMixMessages(msgs []mixing.Message) {
 // websocket clients of the passed transactions.  This function should be
 // called whenever new transactions are added to the mempool.
 func (s *server) AnnounceNewTransactions(txns []*dcrutil.Tx) {
+	// Remove any newly accepted transactions from the stem pool since they
+	// are now in the fluff phase.
+	if s.stemRouter.pool != nil {
+		for _, tx := range txns {
+			if desc := s.stemRouter.pool.RemoveTransaction(tx); desc != nil {
+				s.maybeRebroadcastStemTransaction(desc)
+			}
+		}
+	}
+
 	// Generate and relay inventory vectors for all newly accepted
 	// transactions.
 	s.relayTransactions(txns)
@@ -2073,6 +2378,9 @@ func (s *server) AnnounceMixMessages(msgs []mixing.Message) {
 func (s *server) TransactionConfirmed(tx *dcrutil.Tx) {
 	txHash := tx.Hash()
 	s.recentlyConfirmedTxns.Add(txHash[:])
+	if s.stemRouter.pool != nil {
+		s.stemRouter.pool.RemoveTransaction(tx)
+	}
 
 	// Rebroadcasting is only necessary when the RPC server is active.
 	if s.rpcServer != nil {
@@ -2131,16 +2439,23 @@ func (s *server) handleRelayPeerInvMsg(msg relayMsg, sp *serverPeer) {
 		sp.announcedBlock = &iv.Hash
 	}
 
-	// Generate and send a headers message instead of an inventory message
-	// for block announcements when the peer prefers headers.
-	if isBlockAnnouncement && sp.WantsHeaders() {
-		blockHeader, ok := msg.data.(wire.BlockHeader)
+	// Generate and send a compact block or a headers message instead of an
+	// inventory message for block announcements when the peer prefers them.
+	if isBlockAnnouncement && (sp.WantsCmpctBlocks() || sp.WantsHeaders()) {
+		announcement, ok := msg.data.(*blockAnnouncement)
 		if !ok {
-			peerLog.Warn("Underlying data for headers is not a block header")
+			peerLog.Warn("Underlying data for block announcement is not a " +
+				"block announcement")
+			return
+		}
+		if sp.WantsCmpctBlocks() &&
+			sp.ProtocolVersion() >= wire.CmpctBlockVersion {
+
+			sp.QueueMessage(announcement.cmpctBlock, nil)
 			return
 		}
 		msgHeaders := wire.NewMsgHeaders()
-		if err := msgHeaders.AddBlockHeader(&blockHeader); err != nil {
+		if err := msgHeaders.AddBlockHeader(&announcement.header); err != nil {
 			peerLog.Errorf("Failed to add block header: %v", err)
 			return
 		}
@@ -2254,6 +2569,63 @@ func disconnectPeer(peerList map[int32]*serverPeer, compareFunc func(*serverPeer
 	return false
 }
 
+// v1TransportFallback tracks the outbound peer addresses that fail the v2
+// transport handshake in order to determine which ones to connect to using the
+// v1 transport instead.
+type v1TransportFallback struct {
+	mtx      sync.Mutex
+	failures *lru.Map[string, uint32]
+	v1Only   *lru.Set[string]
+}
+
+// newV1TransportFallback returns a new v1 transport fallback tracker.
+func newV1TransportFallback() *v1TransportFallback {
+	return &v1TransportFallback{
+		failures: lru.NewMapWithDefaultTTL[string, uint32](maxV1OnlyPeers,
+			v2TransportFailureTTL),
+		v1Only: lru.NewSetWithDefaultTTL[string](maxV1OnlyPeers,
+			v1OnlyPeerTTL),
+	}
+}
+
+// recordFailure records a failed v2 transport handshake with the provided
+// address and returns whether or not connections to it now use the v1
+// transport.
+//
+// This function is safe for concurrent access.
+func (f *v1TransportFallback) recordFailure(addr string) bool {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	failures, _ := f.failures.Get(addr)
+	failures++
+	if failures < maxV2TransportFailures {
+		f.failures.Put(addr, failures)
+		return false
+	}
+	f.failures.Delete(addr)
+	f.v1Only.Put(addr)
+	return true
+}
+
+// recordSuccess records a successful v2 transport handshake with the provided
+// address which resets its failures.
+//
+// This function is safe for concurrent access.
+func (f *v1TransportFallback) recordSuccess(addr string) {
+	f.mtx.Lock()
+	f.failures.Delete(addr)
+	f.mtx.Unlock()
+}
+
+// useV1 returns whether or not connections to the provided address use the v1
+// transport.
+//
+// This function is safe for concurrent access.
+func (f *v1TransportFallback) useV1(addr string) bool {
+	return f.v1Only.Contains(addr)
+}
+
 // newPeerConfig returns the configuration for the given serverPeer.
 func newPeerConfig(sp *serverPeer) *peer.Config {
 	var userAgentComments []string
@@ -2261,7 +2633,7 @@ func newPeerConfig(sp *serverPeer) *peer.Config {
 		userAgentComments = append(userAgentComments, version.PreRelease)
 	}
 
-	return &peer.Config{
+	peerCfg := &peer.Config{
 		Listeners: peer.MessageListeners{
 			OnVersion:         sp.OnVersion,
 			OnVerAck:          sp.OnVerAck,
@@ -2271,6 +2643,7 @@ func newPeerConfig(sp *serverPeer) *peer.Config {
 			OnGetInitState:    sp.OnGetInitState,
 			OnInitState:       sp.OnInitState,
 			OnTx:              sp.OnTx,
+			OnStemTx:          sp.OnStemTx,
 			OnBlock:           sp.OnBlock,
 			OnMixPairReq:      sp.OnMixPairReq,
 			OnMixKeyExchange:  sp.OnMixKeyExchange,
@@ -2288,10 +2661,14 @@ func newPeerConfig(sp *serverPeer) *peer.Config {
 			OnGetCFilter:      sp.OnGetCFilter,
 			OnGetCFilterV2:    sp.OnGetCFilterV2,
 			OnGetCFiltersV2:   sp.OnGetCFiltersV2,
+			OnCmpctBlock:      sp.OnCmpctBlock,
+			OnGetBlockTxns:    sp.OnGetBlockTxns,
+			OnBlockTxns:       sp.OnBlockTxns,
 			OnGetCFHeaders:    sp.OnGetCFHeaders,
 			OnGetCFTypes:      sp.OnGetCFTypes,
 			OnGetAddr:         sp.OnGetAddr,
 			OnAddr:            sp.OnAddr,
+			OnAddrV2:          sp.OnAddrV2,
 			OnRead:            sp.OnRead,
 			OnWrite:           sp.OnWrite,
 			OnNotFound:        sp.OnNotFound,
@@ -2313,7 +2690,28 @@ func newPeerConfig(sp *serverPeer) *peer.Config {
 		DisableRelayTx:    cfg.BlocksOnly,
 		ProtocolVersion:   maxProtocolVersion,
 		IdleTimeout:       cfg.PeerIdleTimeout,
+		V2Transport:       !cfg.NoV2Transport,
+		OnV2TransportFailed: func(p *peer.Peer) {
+			if !sp.server.v1Fallback.recordFailure(p.Addr()) {
+				peerLog.Debugf("Peer %s closed the connection during the v2 "+
+					"transport handshake", p)
+				return
+			}
+			peerLog.Debugf("Peer %s does not appear to support the v2 "+
+				"transport -- using the v1 transport for connections for "+
+				"the next %v", p, v1OnlyPeerTTL)
+		},
+		TransportKey:   cfg.transportKey,
+		RequireAuth:    sp.isWhitelisted && len(cfg.authPeerKeys) > 0,
+		AuthorizedKeys: cfg.authPeerKeys,
+	}
+
+	// Limit the rate of data sent to peers that are not whitelisted.
+	if !sp.isWhitelisted {
+		peerCfg.MaxUploadRate = cfg.MaxPeerUploadRate * 1024
+		peerCfg.UploadLimiter = sp.server.uploadLimiter
 	}
+	return peerCfg
 }
 
 // inboundPeerConnected is invoked by the connection manager when a new inbound
@@ -2336,7 +2734,12 @@ func (s *server) inboundPeerConnected(conn net.Conn) {
 // peer processing goroutines.
 func (s *server) outboundPeerConnected(c *connmgr.ConnReq, conn net.Conn) {
 	sp := newServerPeer(s, c.Permanent)
-	p, err := peer.NewOutboundPeer(newPeerConfig(sp), c.Addr.String())
+	sp.isWhitelisted = isWhitelisted(conn.RemoteAddr())
+	peerCfg := newPeerConfig(sp)
+	if s.v1Fallback.useV1(c.Addr.String()) {
+		peerCfg.V2Transport = false
+	}
+	p, err := peer.NewOutboundPeer(peerCfg, c.Addr.String())
 	if err != nil {
 		srvrLog.Debugf("Cannot create outbound peer %s: %v", c.Addr, err)
 		s.connManager.Disconnect(c.ID())
@@ -2345,7 +2748,6 @@ func (s *server) outboundPeerConnected(c *connmgr.ConnReq, conn net.Conn) {
 	sp.Peer = p
 	sp.syncMgrPeer = netsync.NewPeer(sp.Peer)
 	sp.connReq.Store(c)
-	sp.isWhitelisted = isWhitelisted(conn.RemoteAddr())
 	sp.AssociateConnection(conn)
 	go sp.Run()
 }
@@ -2378,6 +2780,10 @@ out:
 		case <-ctx.Done():
 			close(s.quit)
 
+			// Save the current outbound peers to reconnect to them first on
+			// the next start prior to disconnecting them.
+			s.saveAnchors()
+
 			// Disconnect all peers on server shutdown.
 			s.peerState.ForAllPeers(func(sp *serverPeer) {
 				srvrLog.Tracef("Shutdown peer %s", sp)
@@ -2417,16 +2823,13 @@ func (s *server) handleAddPeer(sp *serverPeer) bool {
 		sp.Disconnect()
 		return false
 	}
-	if banEnd, ok := state.banned[host]; ok {
-		if time.Now().Before(banEnd) {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if ban, ok := s.banList.IsBanned(addr); ok {
 			srvrLog.Debugf("Peer %s is banned for another %v - disconnecting",
-				host, time.Until(banEnd))
+				host, time.Until(ban.Expires).Round(time.Second))
 			sp.Disconnect()
 			return false
 		}
-
-		srvrLog.Infof("Peer %s is no longer banned", host)
-		delete(state.banned, host)
 	}
 
 	// Limit max number of connections from a single IP.  However, allow
@@ -2485,7 +2888,7 @@ func (s *server) handleAddPeer(sp *serverPeer) bool {
 
 	// The peer is an outbound peer at this point.
 	remoteAddr := wireToAddrmgrNetAddress(sp.NA())
-	state.outboundGroups[remoteAddr.GroupKey()]++
+	state.outboundGroups[s.addrManager.GroupKey(remoteAddr)]++
 	if sp.persistent {
 		state.persistentPeers[sp.ID()] = sp
 	} else {
@@ -2591,7 +2994,7 @@ func (s *server) DonePeer(sp *serverPeer) {
 	if _, ok := list[sp.ID()]; ok {
 		if !sp.Inbound() && sp.VersionKnown() {
 			remoteAddr := wireToAddrmgrNetAddress(sp.NA())
-			state.outboundGroups[remoteAddr.GroupKey()]--
+			state.outboundGroups[s.addrManager.GroupKey(remoteAddr)]--
 		}
 		if !sp.Inbound() {
 			connReq := sp.connReq.Load()
@@ -2648,10 +3051,10 @@ func (s *server) BanPeer(sp *serverPeer, reason string) {
 	direction := directionString(sp.Inbound())
 	srvrLog.Warnf("Misbehaving peer %s (%s): %s -- banned for %v", host,
 		direction, reason, cfg.BanDuration)
-	bannedUntil := time.Now().Add(cfg.BanDuration)
-	s.peerState.Lock()
-	s.peerState.banned[host] = bannedUntil
-	s.peerState.Unlock()
+	if err := s.banList.BanHost(host, cfg.BanDuration, reason); err != nil {
+		srvrLog.Errorf("Unable to save ban for %s: %v", host, err)
+	}
+	s.numBans.Add(1)
 	sp.Disconnect()
 }
 
@@ -2669,11 +3072,15 @@ func (s *server) RelayInventory(invVect *wire.InvVect, data interface{}, immedia
 // the given required services and are not already known to have it.
 func (s *server) RelayBlockAnnouncement(block *dcrutil.Block, reqServices wire.ServiceFlag) {
 	invVect := wire.NewInvVect(wire.InvTypeBlock, block.Hash())
+	announcement := &blockAnnouncement{
+		header:     block.MsgBlock().Header,
+		cmpctBlock: netsync.NewCmpctBlock(block, rand.Uint64()),
+	}
 	select {
 	case <-s.quit:
 	case s.relayInv <- relayMsg{
 		invVect:     invVect,
-		data:        block.MsgBlock().Header,
+		data:        announcement,
 		immediate:   true,
 		reqServices: reqServices,
 	}:
@@ -2727,6 +3134,28 @@ func (s *server) NetTotals() (uint64, uint64) {
 	return s.bytesReceived.Load(), s.bytesSent.Load()
 }
 
+// NetworkStats returns detailed traffic accounting across all peers along with
+// the state of the configured upload limits.  It is safe for concurrent access.
+func (s *server) NetworkStats() *rpcserver.NetworkStats {
+	now := time.Now()
+	totalRecv, totalSent := s.NetTotals()
+	sentPerMsg, recvPerMsg := s.msgBytes.snapshot()
+	target := s.uploadTarget.stats(now)
+	return &rpcserver.NetworkStats{
+		TotalBytesRecv:      totalRecv,
+		TotalBytesSent:      totalSent,
+		BytesRecvPerMsg:     recvPerMsg,
+		BytesSentPerMsg:     sentPerMsg,
+		MaxUploadRate:       cfg.MaxUploadRate * 1024,
+		MaxPeerUploadRate:   cfg.MaxPeerUploadRate * 1024,
+		UploadTarget:        target.target,
+		UploadTargetCycle:   uploadTargetCycle,
+		UploadTargetReached: target.reached,
+		BytesLeftInCycle:    target.bytesLeft,
+		TimeLeftInCycle:     target.timeLeft,
+	}
+}
+
 // notifiedWinningTickets returns whether or not the winning tickets
 // notification for the specified block hash has already been sent.
 func (s *server) notifiedWinningTickets(hash *chainhash.Hash) bool {
@@ -3357,7 +3786,17 @@ func (s *server) Run(ctx context.Context) {
 		}()
 	}
 
-	if !cfg.DisableRPC {
+	// Start the handler that creates the onion service for incoming
+	// connections via the Tor control port.
+	if s.onionTarget != "" {
+		wg.Add(1)
+		go func() {
+			s.onionServiceHandler(ctx)
+			wg.Done()
+		}()
+	}
+
+	if s.rpcServer != nil {
 		// Start the RPC server and rebroadcast handler which ensures
 		// transactions submitted to the RPC server are rebroadcast until being
 		// included in a block.
@@ -3372,6 +3811,16 @@ func (s *server) Run(ctx context.Context) {
 		}()
 	}
 
+	// Start the handler that fluffs transactions in the stem phase of private
+	// transaction relay once their embargo expires.
+	if s.stemRouter.pool != nil {
+		wg.Add(1)
+		go func() {
+			s.stemEmbargoHandler(ctx)
+			wg.Done()
+		}()
+	}
+
 	// Start the background block template generator and CPU miner if the config
 	// provides a mining address.
 	if len(cfg.miningAddrs) > 0 {
@@ -3399,6 +3848,15 @@ func (s *server) Run(ctx context.Context) {
 		wg.Done()
 	}()
 
+	// Start the metrics server when enabled.
+	if len(s.metricsListeners) > 0 {
+		wg.Add(1)
+		go func() {
+			s.serveMetrics(ctx)
+			wg.Done()
+		}()
+	}
+
 	// Shutdown the server when the context is cancelled.
 	<-ctx.Done()
 	s.shutdown.Store(true)
@@ -3531,6 +3989,26 @@ func standardScriptVerifyFlags(chain *blockchain.BlockChain) (txscript.ScriptFla
 		scriptFlags |= txscript.ScriptVerifyTreasury
 	}
 
+	// Enable signatures that commit to the spent outputs when the associated
+	// agenda is active.
+	isActive, err = chain.IsCoinTypeSigHashAgendaActive(tipHash)
+	if err != nil {
+		return 0, err
+	}
+	if isActive {
+		scriptFlags |= txscript.ScriptVerifyCoinTypeSigHash
+	}
+
+	// Enable version 1 scripts and the introspection opcodes they provide when
+	// the associated agenda is active.
+	isActive, err = chain.IsIntrospectionAgendaActive(tipHash)
+	if err != nil {
+		return 0, err
+	}
+	if isActive {
+		scriptFlags |= txscript.ScriptVerifyIntrospection
+	}
+
 	return scriptFlags, nil
 }
 
@@ -3849,6 +4327,29 @@ func setupRPCListeners() ([]net.Listener, error) {
 	return listeners, nil
 }
 
+// setupRESTListeners returns a slice of listeners that are configured for use
+// with the REST interface depending on the configuration settings for listen
+// addresses.  The REST interface only serves public data, so TLS is expected to
+// be provided by a reverse proxy when required.
+func setupRESTListeners() ([]net.Listener, error) {
+	netAddrs, err := parseListeners(cfg.RESTListeners)
+	if err != nil {
+		return nil, err
+	}
+
+	listeners := make([]net.Listener, 0, len(netAddrs))
+	for _, addr := range netAddrs {
+		listener, err := net.Listen(addr.Network(), addr.String())
+		if err != nil {
+			rpcsLog.Warnf("Can't listen on %s: %v", addr, err)
+			continue
+		}
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
 // newServer returns a new dcrd server configured to listen on addr for the
 // decred network type specified by chainParams.  Use start to begin accepting
 // connections from peers.
@@ -3857,8 +4358,26 @@ func newServer(ctx context.Context, profiler *profileServer,
 	chainParams *chaincfg.Params, dataDir string) (*server, error) {
 
 	amgr := addrmgr.New(cfg.DataDir)
+	if cfg.asmap != nil {
+		amgr.SetASMap(cfg.asmap)
+		srvrLog.Infof("Grouping peer addresses by autonomous system using "+
+			"%d prefixes from %s", cfg.asmap.NumPrefixes(), cfg.ASMap)
+	}
 	services := defaultServices
 
+	// Restore the bans that were saved by previous runs.  Failing to do so is
+	// not fatal since the ban list is overwritten as new bans are added.
+	banList := banmanager.NewBanList(path.Join(dataDir, banListFilename))
+	if err := banList.Load(); err != nil {
+		srvrLog.Warnf("Unable to load ban list: %v", err)
+	}
+
+	// Pruned nodes do not have the full block history and therefore must not
+	// advertise that they can serve it.
+	if cfg.Prune != 0 {
+		services &^= wire.SFNodeNetwork
+	}
+
 	var listeners []net.Listener
 	var nat *upnpNAT
 	if !cfg.DisableListen {
@@ -3883,6 +4402,7 @@ func newServer(ctx context.Context, profiler *profileServer,
 		targetOutbound:       defaultTargetOutbound,
 		chainParams:          chainParams,
 		addrManager:          amgr,
+		banList:              banList,
 		peerState:            makePeerState(),
 		relayInv:             make(chan relayMsg, cfg.MaxPeers),
 		broadcast:            make(chan broadcastMsg, cfg.MaxPeers),
@@ -3901,6 +4421,7 @@ func newServer(ctx context.Context, profiler *profileServer,
 		recentlyAdvertisedTxns: lru.NewMapWithDefaultTTL[chainhash.Hash,
 			*dcrutil.Tx](maxRecentlyAdvertisedTxns, recentlyAdvertisedTxnsTTL),
 		lastAdvertisedTxnsEvictedLogged: time.Now(),
+		v1Fallback:                      newV1TransportFallback(),
 	}
 
 	// Convert the minimum known work to a uint256 when it exists.  Ideally, the
@@ -3912,6 +4433,24 @@ func newServer(ctx context.Context, profiler *profileServer,
 		s.minKnownWork.SetBig(minKnownWorkBig)
 	}
 
+	// Limit the combined rate of data sent to all peers and track the data
+	// sent against the max upload target when requested.
+	if cfg.MaxUploadRate != 0 {
+		s.uploadLimiter = peer.NewRateLimiter(cfg.MaxUploadRate * 1024)
+	}
+	s.uploadTarget.target = cfg.MaxUploadTarget * 1024 * 1024
+
+	// Create an onion service that forwards incoming connections to the
+	// listeners when requested.
+	if cfg.TorControl != "" {
+		s.onionTarget = onionServiceTarget(listeners)
+	}
+
+	// Create the stem pool used for private transaction relay when enabled.
+	if cfg.Dandelion {
+		s.stemRouter.pool = mempool.NewStemPool(maxStemPoolTxns)
+	}
+
 	feC := fees.EstimatorConfig{
 		MinBucketFee: cfg.minRelayTxFee,
 		MaxBucketFee: dcrutil.Amount(fees.DefaultMaxBucketFeeMultiplier) * cfg.minRelayTxFee,
@@ -3967,6 +4506,7 @@ func newServer(ctx context.Context, profiler *profileServer,
 		FlushBlockDB: s.db.Flush,
 		MaxSize:      uint64(cfg.UtxoCacheMaxSize) * 1024 * 1024,
 	})
+	s.utxoCache = utxoCache
 	s.chain, err = blockchain.New(ctx,
 		&blockchain.Config{
 			DB:              s.db,
@@ -3979,10 +4519,15 @@ func newServer(ctx context.Context, profiler *profileServer,
 			SubsidyCache:    s.subsidyCache,
 			IndexSubscriber: s.indexSubscriber,
 			UtxoCache:       utxoCache,
+			PruneTarget:     cfg.Prune * 1024 * 1024,
 		})
 	if err != nil {
 		return nil, err
 	}
+	if cfg.Prune == 0 && s.chain.PrunedHeight() > 0 {
+		return nil, fmt.Errorf("the block database has been pruned and must " +
+			"be used with the --prune option")
+	}
 
 	queryer := &blockchain.ChainQueryerAdapter{BlockChain: s.chain}
 	if cfg.TxIndex {
@@ -4068,6 +4613,11 @@ func newServer(ctx context.Context, profiler *profileServer,
 				s.rpcServer.NotifyTSpend(tx)
 			}
 		},
+		OnTxHistoryEntry: func(entry *mempool.TxHistoryEntry) {
+			if s.rpcServer != nil {
+				s.rpcServer.NotifyMempoolHistory(entry)
+			}
+		},
 		IsTreasuryAgendaActive: func() (bool, error) {
 			tipHash := &s.chain.BestSnapshot().Hash
 			return s.chain.IsTreasuryAgendaActive(tipHash)
@@ -4084,6 +4634,10 @@ func newServer(ctx context.Context, profiler *profileServer,
 			tipHash := &s.chain.BestSnapshot().Hash
 			return s.chain.IsSubsidySplitR2AgendaActive(tipHash)
 		},
+		IsSchnorrEmissionAuthAgendaActive: func() (bool, error) {
+			tipHash := &s.chain.BestSnapshot().Hash
+			return s.chain.IsSchnorrEmissionAuthAgendaActive(tipHash)
+		},
 		// Add SKA emission state checks for mempool protection
 		HasSKAEmissionOccurred: s.chain.HasSKAEmissionOccurred,
 		GetSKAEmissionNonce:    s.chain.GetSKAEmissionNonce,
@@ -4152,6 +4706,7 @@ func newServer(ctx context.Context, profiler *profileServer,
 			BlockByHash:                s.chain.BlockByHash,
 			CalcNextRequiredDifficulty: s.chain.CalcNextRequiredDifficulty,
 			CalcStakeVersionByHash:     s.chain.CalcStakeVersionByHash,
+			CalcSKASupplyRoot:          s.chain.CalcSKASupplyRoot,
 			CheckConnectBlockTemplate:  s.chain.CheckConnectBlockTemplate,
 			CheckTicketExhaustion:      s.chain.CheckTicketExhaustion,
 			CheckTransactionInputs: func(tx *dcrutil.Tx, txHeight int64,
@@ -4164,20 +4719,21 @@ func newServer(ctx context.Context, profiler *profileServer,
 					view, checkFraudProof, s.chainParams, prevHeader, isTreasuryEnabled,
 					isAutoRevocationsEnabled, subsidySplitVariant)
 			},
-			CheckTSpendHasVotes:             s.chain.CheckTSpendHasVotes,
-			CountSigOps:                     blockchain.CountSigOps,
-			FetchUtxoEntry:                  s.chain.FetchUtxoEntry,
-			FetchUtxoView:                   s.chain.FetchUtxoView,
-			FetchUtxoViewParentTemplate:     s.chain.FetchUtxoViewParentTemplate,
-			ForceHeadReorganization:         s.chain.ForceHeadReorganization,
-			HeaderByHash:                    s.chain.HeaderByHash,
-			IsFinalizedTransaction:          blockchain.IsFinalizedTransaction,
-			IsHeaderCommitmentsAgendaActive: s.chain.IsHeaderCommitmentsAgendaActive,
-			IsTreasuryAgendaActive:          s.chain.IsTreasuryAgendaActive,
-			IsAutoRevocationsAgendaActive:   s.chain.IsAutoRevocationsAgendaActive,
-			IsSubsidySplitAgendaActive:      s.chain.IsSubsidySplitAgendaActive,
-			IsSubsidySplitR2AgendaActive:    s.chain.IsSubsidySplitR2AgendaActive,
-			MaxTreasuryExpenditure:          s.chain.MaxTreasuryExpenditure,
+			CheckTSpendHasVotes:               s.chain.CheckTSpendHasVotes,
+			CountSigOps:                       blockchain.CountSigOps,
+			FetchUtxoEntry:                    s.chain.FetchUtxoEntry,
+			FetchUtxoView:                     s.chain.FetchUtxoView,
+			FetchUtxoViewParentTemplate:       s.chain.FetchUtxoViewParentTemplate,
+			ForceHeadReorganization:           s.chain.ForceHeadReorganization,
+			HeaderByHash:                      s.chain.HeaderByHash,
+			IsFinalizedTransaction:            blockchain.IsFinalizedTransaction,
+			IsHeaderCommitmentsAgendaActive:   s.chain.IsHeaderCommitmentsAgendaActive,
+			IsSKASupplyCommitmentAgendaActive: s.chain.IsSKASupplyCommitmentAgendaActive,
+			IsTreasuryAgendaActive:            s.chain.IsTreasuryAgendaActive,
+			IsAutoRevocationsAgendaActive:     s.chain.IsAutoRevocationsAgendaActive,
+			IsSubsidySplitAgendaActive:        s.chain.IsSubsidySplitAgendaActive,
+			IsSubsidySplitR2AgendaActive:      s.chain.IsSubsidySplitR2AgendaActive,
+			MaxTreasuryExpenditure:            s.chain.MaxTreasuryExpenditure,
 			NewUtxoViewpoint: func() *blockchain.UtxoViewpoint {
 				return blockchain.NewUtxoViewpoint(utxoCache)
 			},
@@ -4217,7 +4773,28 @@ func newServer(ctx context.Context, profiler *profileServer,
 	// network.
 	var newAddressFunc func() (net.Addr, error)
 	if !cfg.SimNet && !cfg.RegNet && len(cfg.ConnectPeers) == 0 {
+		// Reconnect to the anchors saved on the previous clean shutdown before
+		// selecting any other addresses.  They are subject to the same version
+		// negotiation checks as all other outbound peers, so they are
+		// disconnected if they no longer serve the expected network and
+		// services.
+		s.anchors.path = path.Join(dataDir, anchorsFilename)
+		s.loadAnchors()
+
+		// Onion addresses can only be connected to via Tor.
+		onionReachable := !cfg.NoOnion && (cfg.Proxy != "" ||
+			cfg.OnionProxy != "")
+
 		newAddressFunc = func() (net.Addr, error) {
+			if anchor := s.nextAnchor(); anchor != "" {
+				addr, err := addrStringToNetAddr(anchor)
+				if err == nil {
+					srvrLog.Debugf("Connecting to anchor peer %s", anchor)
+					return addr, nil
+				}
+				srvrLog.Debugf("Ignoring anchor peer %s: %v", anchor, err)
+			}
+
 			for tries := 0; tries < 100; tries++ {
 				// Note that this does not filter by address type.  Unsupported
 				// network address types should be pruned from the address
@@ -4234,7 +4811,10 @@ func newServer(ctx context.Context, profiler *profileServer,
 				// to the same network segment at the expense of
 				// others.
 				netAddr := addr.NetAddress()
-				if s.OutboundGroupCount(netAddr.GroupKey()) != 0 {
+				if netAddr.Type == addrmgr.TORv3Address && !onionReachable {
+					continue
+				}
+				if s.OutboundGroupCount(s.addrManager.GroupKey(netAddr)) != 0 {
 					continue
 				}
 
@@ -4274,6 +4854,7 @@ func newServer(ctx context.Context, profiler *profileServer,
 		Timeout:        cfg.DialTimeout,
 		OnConnection:   s.outboundPeerConnected,
 		GetNewAddress:  newAddressFunc,
+		IsBanned:       s.banList.IsAddrBanned,
 	})
 	if err != nil {
 		return nil, err
@@ -4298,20 +4879,38 @@ func newServer(ctx context.Context, profiler *profileServer,
 			})
 	}
 
-	if !cfg.DisableRPC {
+	if !cfg.DisableRPC || cfg.EnableREST {
 		// Setup listeners for the configured RPC listen addresses and
 		// TLS settings.
-		rpcListeners, err := setupRPCListeners()
-		if err != nil {
-			return nil, err
+		var rpcListeners []net.Listener
+		if !cfg.DisableRPC {
+			rpcListeners, err = setupRPCListeners()
+			if err != nil {
+				return nil, err
+			}
+
+			if len(rpcListeners) == 0 {
+				return nil, errors.New("no usable rpc listen addresses")
+			}
 		}
 
-		if len(rpcListeners) == 0 {
-			return nil, errors.New("no usable rpc listen addresses")
+		// Setup listeners for the configured REST listen addresses.
+		var restListeners []net.Listener
+		if cfg.EnableREST {
+			restListeners, err = setupRESTListeners()
+			if err != nil {
+				return nil, err
+			}
+
+			if len(restListeners) == 0 {
+				return nil, errors.New("no usable rest listen addresses")
+			}
 		}
 
 		rpcsConfig := rpcserver.Config{
 			Listeners:             rpcListeners,
+			RESTListeners:         restListeners,
+			RESTMaxRequestsPerSec: cfg.RESTMaxRequests,
 			ProfilerMgr:           profiler,
 			ConnMgr:               &rpcConnManager{&s},
 			SyncMgr:               &rpcSyncMgr{server: &s, syncMgr: s.syncManager},
@@ -4373,6 +4972,18 @@ func newServer(ctx context.Context, profiler *profileServer,
 		}()
 	}
 
+	// Setup listeners for the metrics endpoint when enabled.
+	if cfg.Metrics != "" {
+		s.metricsListeners, err = setupMetricsListeners()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(s.metricsListeners) == 0 {
+			return nil, errors.New("no usable metrics listen addresses")
+		}
+	}
+
 	return &s, nil
 }
 
@@ -4471,6 +5082,15 @@ func addrStringToNetAddr(addr string) (net.Addr, error) {
 		return nil, err
 	}
 
+	// Onion addresses can't be resolved to IP addresses, so they are dialed
+	// by host name through the onion proxy instead.
+	if strings.HasSuffix(strings.ToLower(host), ".onion") {
+		if cfg.NoOnion {
+			return nil, errors.New("tor has been disabled")
+		}
+		return &onionAddr{addr: addr}, nil
+	}
+
 	// Attempt to look up an IP address associated with the parsed host.
 	// The dcrdLookup function will transparently handle performing the
 	// lookup over Tor if necessary.
@@ -5,6 +5,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
 	"net"
 	"reflect"
@@ -89,6 +90,23 @@ func TestHostToNetAddress(t *testing.T) {
 		wantErr:    false,
 		want: addrmgr.NewNetAddressFromIPPort(net.ParseIP("2003::1"), 8333,
 			services),
+	}, {
+		name:       "valid TORv3 onion address",
+		host:       "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion",
+		port:       8333,
+		lookupFunc: nil,
+		wantErr:    false,
+		want: addrmgr.NewNetAddressFromIPPort(hexToBytes("1d04a1d04a338c6e6ae9"+
+			"70bfabee49049d6702250984ca950c01673f4ec034ad"), 8333, services),
+	}, {
+		name: "invalid TORv3 onion address checksum",
+		host: "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczbd.onion",
+		port: 8333,
+		lookupFunc: func(host string) ([]net.IP, error) {
+			return nil, fmt.Errorf("unresolvable host %v", host)
+		},
+		wantErr: true,
+		want:    nil,
 	}}
 
 	for _, test := range tests {
@@ -102,3 +120,54 @@ func TestHostToNetAddress(t *testing.T) {
 		}
 	}
 }
+
+// hexToBytes converts the passed hex string into bytes and will panic if there
+// is an error.  This is only provided for the hard-coded constants so errors in
+// the source code can be detected. It will only (and must only) be called with
+// hard-coded values.
+func hexToBytes(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic("invalid hex in source file: " + s)
+	}
+	return b
+}
+
+// TestV1TransportFallback ensures outbound peer addresses only fall back to
+// the v1 transport after repeatedly failing the v2 transport handshake and
+// that successful handshakes reset the failures.
+func TestV1TransportFallback(t *testing.T) {
+	const addr = "10.0.0.1:8333"
+	f := newV1TransportFallback()
+
+	// A single failure is not enough to fall back.
+	if f.recordFailure(addr) {
+		t.Fatal("fell back to the v1 transport after a single failure")
+	}
+	if f.useV1(addr) {
+		t.Fatal("using the v1 transport after a single failure")
+	}
+
+	// A success in between failures resets them.
+	f.recordSuccess(addr)
+	if f.recordFailure(addr) {
+		t.Fatal("fell back to the v1 transport after a reset")
+	}
+
+	// Consecutive failures fall back.
+	for i := 1; i < maxV2TransportFailures-1; i++ {
+		if f.recordFailure(addr) {
+			t.Fatalf("fell back to the v1 transport after %d failures", i+1)
+		}
+	}
+	if !f.recordFailure(addr) {
+		t.Fatalf("did not fall back to the v1 transport after %d failures",
+			maxV2TransportFailures)
+	}
+	if !f.useV1(addr) {
+		t.Fatal("not using the v1 transport after falling back")
+	}
+	if f.useV1("10.0.0.2:8333") {
+		t.Fatal("using the v1 transport for an unrelated address")
+	}
+}
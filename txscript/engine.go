@@ -56,8 +56,29 @@ const (
 	// (previously OP_UNKNOWN195) as the OP_TADD, OP_TSPEND and OP_TGEN
 	// opcodes which add and spend an amount from the treasury.
 	ScriptVerifyTreasury
+
+	// ScriptVerifyCoinTypeSigHash defines whether to allow signatures with
+	// the SigHashCoinType flag set, which commit to the coin type, amount,
+	// and script version of every output spent by the transaction.
+	ScriptVerifyCoinTypeSigHash
+
+	// ScriptVerifyIntrospection defines whether to execute version 1 scripts
+	// and to treat opcode 196 (previously OP_UNKNOWN196) through opcode 199
+	// (previously OP_UNKNOWN199) within them as the OP_INPUTCOINTYPE,
+	// OP_INPUTAMOUNT, OP_OUTPUTCOINTYPE and OP_OUTPUTAMOUNT opcodes which
+	// push the coin type and amount of the output spent by the input being
+	// verified and of a given output of the transaction.  It also allows the
+	// numeric comparison opcodes within version 1 scripts to operate on
+	// numbers of up to AmountMaxScriptNumLen bytes.
+	ScriptVerifyIntrospection
 )
 
+// IntrospectionScriptVersion is the script version that is executed with the
+// introspection opcodes enabled when the ScriptVerifyIntrospection flag is set.
+// Scripts of this version are parsed with the same semantics as version 0
+// scripts.
+const IntrospectionScriptVersion = 1
+
 const (
 	// MaxStackSize is the maximum combined height of stack and alt stack
 	// during execution.
@@ -100,12 +121,18 @@ type Engine struct {
 	// since transaction scripts are often executed more than once from various
 	// contexts (e.g. new block templates, when transactions are first seen
 	// prior to being mined, part of full block verification, etc).
+	//
+	// prevOuts houses the details of the outputs spent by every input of the
+	// transaction which are committed to by signatures with the
+	// SigHashCoinType flag set and pushed by the input introspection opcodes.
+	// It is nil when they were not provided.
 	flags    ScriptFlags
 	tx       wire.MsgTx
 	txIdx    int
 	version  uint16
 	isP2SH   bool
 	sigCache *SigCache
+	prevOuts []PrevOutput
 
 	// The following fields handle keeping track of the current execution state
 	// of the engine.
@@ -201,6 +228,33 @@ func (vm *Engine) hasFlag(flag ScriptFlags) bool {
 	return vm.flags&flag == flag
 }
 
+// checkHashTypeEncoding returns whether or not the passed hashtype adheres to
+// the strict encoding requirements while additionally allowing the
+// SigHashCoinType flag when the engine permits it.
+func (vm *Engine) checkHashTypeEncoding(hashType SigHashType) error {
+	if vm.hasFlag(ScriptVerifyCoinTypeSigHash) {
+		hashType &^= SigHashCoinType
+	}
+	return CheckHashTypeEncoding(hashType)
+}
+
+// isIntrospectionEnabled returns whether or not the engine is executing a
+// version 1 script with the flag that enables the introspection opcodes set.
+func (vm *Engine) isIntrospectionEnabled() bool {
+	return vm.version == IntrospectionScriptVersion &&
+		vm.hasFlag(ScriptVerifyIntrospection)
+}
+
+// tokenizerVersion returns the script version that defines how the scripts
+// executed by the engine are parsed.  Version 1 scripts are parsed with the same
+// semantics as version 0 scripts when they are executed.
+func (vm *Engine) tokenizerVersion() uint16 {
+	if vm.isIntrospectionEnabled() {
+		return 0
+	}
+	return vm.version
+}
+
 // isBranchExecuting returns whether or not the current conditional branch is
 // actively executing.  For example, when the data stack has an OP_FALSE on it
 // and an OP_IF is encountered, the branch is inactive until an OP_ELSE or
@@ -412,7 +466,7 @@ func (vm *Engine) DisasmScript(idx int) (string, error) {
 
 	var disbuf strings.Builder
 	script := vm.scripts[idx]
-	tokenizer := MakeScriptTokenizer(vm.version, script)
+	tokenizer := MakeScriptTokenizer(vm.tokenizerVersion(), script)
 	var opcodeIdx int
 	for tokenizer.Next() {
 		disbuf.WriteString(fmt.Sprintf("%02x:%04x: ", idx, opcodeIdx))
@@ -552,7 +606,7 @@ func (vm *Engine) Step() (done bool, err error) {
 			// Obtain the redeem script from the first stack and ensure it
 			// parses.
 			script := vm.savedFirstStack[len(vm.savedFirstStack)-1]
-			if err := checkScriptParses(vm.version, script); err != nil {
+			if err := checkScriptParses(vm.tokenizerVersion(), script); err != nil {
 				return false, err
 			}
 			vm.scripts = append(vm.scripts, script)
@@ -578,7 +632,8 @@ func (vm *Engine) Step() (done bool, err error) {
 		// Finally, update the current tokenizer used to parse through scripts
 		// one opcode at a time to start from the beginning of the new script
 		// associated with the program counter.
-		vm.tokenizer = MakeScriptTokenizer(vm.version, vm.scripts[vm.scriptIdx])
+		vm.tokenizer = MakeScriptTokenizer(vm.tokenizerVersion(),
+			vm.scripts[vm.scriptIdx])
 	}
 
 	return false, nil
@@ -588,9 +643,11 @@ func (vm *Engine) Step() (done bool, err error) {
 // for successful validation or an error if one occurred.
 func (vm *Engine) Execute() (err error) {
 	// All script versions other than 0 currently execute without issue,
-	// making all outputs to them anyone can pay. In the future this
-	// will allow for the addition of new scripting languages.
-	if vm.version != 0 {
+	// making all outputs to them anyone can pay, with the exception of version
+	// 1 scripts once the flag that enables the introspection opcodes is set.
+	// In the future this will allow for the addition of new scripting
+	// languages.
+	if vm.version != 0 && !vm.isIntrospectionEnabled() {
 		return nil
 	}
 
@@ -694,13 +751,35 @@ func (vm *Engine) SetAltStack(data [][]byte) {
 // NewEngine returns a new script engine for the provided public key script,
 // transaction, and input index.  The flags modify the behavior of the script
 // engine according to the description provided by each flag.
+//
+// Signatures with the SigHashCoinType flag set will fail to verify and the
+// input introspection opcodes will fail to execute since the details of the
+// outputs being spent are not available.  Use NewEngineWithPrevOuts to verify
+// them.
 func NewEngine(scriptPubKey []byte, tx *wire.MsgTx, txIdx int, flags ScriptFlags, scriptVersion uint16, sigCache *SigCache) (*Engine, error) {
+	return NewEngineWithPrevOuts(scriptPubKey, tx, txIdx, flags,
+		scriptVersion, sigCache, nil)
+}
+
+// NewEngineWithPrevOuts returns a new script engine for the provided public key
+// script, transaction, and input index in the same way as NewEngine.  The
+// provided previous outputs must correspond, in order, to the outputs spent by
+// every input of the transaction and are committed to by signatures with the
+// SigHashCoinType flag set and pushed by the input introspection opcodes.
+func NewEngineWithPrevOuts(scriptPubKey []byte, tx *wire.MsgTx, txIdx int, flags ScriptFlags, scriptVersion uint16, sigCache *SigCache, prevOuts []PrevOutput) (*Engine, error) {
 	// The provided transaction input index must refer to a valid input.
 	if txIdx < 0 || txIdx >= len(tx.TxIn) {
 		str := fmt.Sprintf("transaction input index %d is negative or "+
 			">= %d", txIdx, len(tx.TxIn))
 		return nil, scriptError(ErrInvalidIndex, str)
 	}
+
+	// The previous outputs, when provided, must refer to every input.
+	if prevOuts != nil && len(prevOuts) != len(tx.TxIn) {
+		str := fmt.Sprintf("%d previous outputs provided for a transaction "+
+			"with %d inputs", len(prevOuts), len(tx.TxIn))
+		return nil, scriptError(ErrInvalidPrevOuts, str)
+	}
 	scriptSig := tx.TxIn[txIdx].SignatureScript
 
 	// When both the signature script and public key script are empty the result
@@ -794,10 +873,12 @@ func NewEngine(scriptPubKey []byte, tx *wire.MsgTx, txIdx int, flags ScriptFlags
 
 	// Setup the current tokenizer used to parse through the script one opcode
 	// at a time with the script associated with the program counter.
-	vm.tokenizer = MakeScriptTokenizer(scriptVersion, scripts[vm.scriptIdx])
+	vm.tokenizer = MakeScriptTokenizer(vm.tokenizerVersion(),
+		scripts[vm.scriptIdx])
 
 	vm.tx = *tx
 	vm.txIdx = txIdx
+	vm.prevOuts = prevOuts
 	vm.condDisableDepth = noCondDisableDepth
 
 	return &vm, nil
@@ -27,6 +27,11 @@ const (
 	// index that is greater than or equal to the number of outputs.
 	ErrInvalidSigHashSingleIndex = ErrorKind("ErrInvalidSigHashSingleIndex")
 
+	// ErrInvalidPrevOuts is returned when an attempt is made to calculate a
+	// signature hash that commits to the outputs being spent and the
+	// provided previous outputs do not correspond to the transaction inputs.
+	ErrInvalidPrevOuts = ErrorKind("ErrInvalidPrevOuts")
+
 	// ErrUnsupportedScriptVersion is returned when an unsupported script
 	// version is passed to a function which deals with script analysis.
 	ErrUnsupportedScriptVersion = ErrorKind("ErrUnsupportedScriptVersion")
@@ -187,6 +192,16 @@ const (
 	// for a shift.
 	ErrOverflowShift = ErrorKind("ErrOverflowShift")
 
+	// ErrInvalidOutputIndex is returned when an OP_OUTPUTCOINTYPE or
+	// OP_OUTPUTAMOUNT opcode encounters an output index that is negative or
+	// does not refer to an output of the transaction.
+	ErrInvalidOutputIndex = ErrorKind("ErrInvalidOutputIndex")
+
+	// ErrMissingPrevOuts is returned when an OP_INPUTCOINTYPE or
+	// OP_INPUTAMOUNT opcode is executed and the details of the outputs spent
+	// by the transaction were not provided to the engine.
+	ErrMissingPrevOuts = ErrorKind("ErrMissingPrevOuts")
+
 	// ErrP2SHTreasuryOpCodes is returned when one or more treasury opcodes
 	// are found in the redeem script of a pay-to-script-hash script.
 	ErrP2SHTreasuryOpCodes = ErrorKind("ErrP2SHTreasuryOpCodes")
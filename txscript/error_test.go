@@ -19,6 +19,7 @@ func TestErrorKindStringer(t *testing.T) {
 	}{
 		{ErrInvalidIndex, "ErrInvalidIndex"},
 		{ErrInvalidSigHashSingleIndex, "ErrInvalidSigHashSingleIndex"},
+		{ErrInvalidPrevOuts, "ErrInvalidPrevOuts"},
 		{ErrUnsupportedScriptVersion, "ErrUnsupportedScriptVersion"},
 		{ErrEarlyReturn, "ErrEarlyReturn"},
 		{ErrEmptyStack, "ErrEmptyStack"},
@@ -51,6 +52,8 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrDivideByZero, "ErrDivideByZero"},
 		{ErrNegativeShift, "ErrNegativeShift"},
 		{ErrOverflowShift, "ErrOverflowShift"},
+		{ErrInvalidOutputIndex, "ErrInvalidOutputIndex"},
+		{ErrMissingPrevOuts, "ErrMissingPrevOuts"},
 		{ErrP2SHTreasuryOpCodes, "ErrP2SHTreasuryOpCodes"},
 		{ErrMinimalData, "ErrMinimalData"},
 		{ErrInvalidSigHashType, "ErrInvalidSigHashType"},
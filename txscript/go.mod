@@ -7,6 +7,7 @@ toolchain go1.23.4
 require (
 	github.com/dchest/siphash v1.2.3
 	github.com/decred/base58 v1.0.5
+	github.com/monetarium/monetarium-node/bech32 v1.0.14
 	github.com/monetarium/monetarium-node/chaincfg/chainhash v1.0.11
 	github.com/monetarium/monetarium-node/chaincfg v1.0.11
 	github.com/monetarium/monetarium-node/cointype v1.0.11
@@ -12,9 +12,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"hash"
+	"math/big"
 	"strings"
 
 	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
 	"github.com/monetarium/monetarium-node/crypto/ripemd160"
 	"github.com/monetarium/monetarium-node/dcrec"
 	"github.com/monetarium/monetarium-node/dcrec/edwards"
@@ -246,10 +248,10 @@ const (
 	OP_TADD                = 0xc1 // 193 DECRED
 	OP_TSPEND              = 0xc2 // 194 DECRED
 	OP_TGEN                = 0xc3 // 195 DECRED
-	OP_UNKNOWN196          = 0xc4 // 196
-	OP_UNKNOWN197          = 0xc5 // 197
-	OP_UNKNOWN198          = 0xc6 // 198
-	OP_UNKNOWN199          = 0xc7 // 199
+	OP_INPUTCOINTYPE       = 0xc4 // 196
+	OP_INPUTAMOUNT         = 0xc5 // 197
+	OP_OUTPUTCOINTYPE      = 0xc6 // 198
+	OP_OUTPUTAMOUNT        = 0xc7 // 199
 	OP_UNKNOWN200          = 0xc8 // 200
 	OP_UNKNOWN201          = 0xc9 // 201
 	OP_UNKNOWN202          = 0xca // 202
@@ -534,11 +536,13 @@ var opcodeArray = [256]opcode{
 	OP_TSPEND: {OP_TSPEND, "OP_TSPEND", 1, opcodeTSpend},
 	OP_TGEN:   {OP_TGEN, "OP_TGEN", 1, opcodeTGen},
 
+	// Introspection opcodes.
+	OP_INPUTCOINTYPE:  {OP_INPUTCOINTYPE, "OP_INPUTCOINTYPE", 1, opcodeInputCoinType},
+	OP_INPUTAMOUNT:    {OP_INPUTAMOUNT, "OP_INPUTAMOUNT", 1, opcodeInputAmount},
+	OP_OUTPUTCOINTYPE: {OP_OUTPUTCOINTYPE, "OP_OUTPUTCOINTYPE", 1, opcodeOutputCoinType},
+	OP_OUTPUTAMOUNT:   {OP_OUTPUTAMOUNT, "OP_OUTPUTAMOUNT", 1, opcodeOutputAmount},
+
 	// Undefined opcodes.
-	OP_UNKNOWN196: {OP_UNKNOWN196, "OP_UNKNOWN196", 1, opcodeNop},
-	OP_UNKNOWN197: {OP_UNKNOWN197, "OP_UNKNOWN197", 1, opcodeNop},
-	OP_UNKNOWN198: {OP_UNKNOWN198, "OP_UNKNOWN198", 1, opcodeNop},
-	OP_UNKNOWN199: {OP_UNKNOWN199, "OP_UNKNOWN199", 1, opcodeNop},
 	OP_UNKNOWN200: {OP_UNKNOWN200, "OP_UNKNOWN200", 1, opcodeNop},
 	OP_UNKNOWN201: {OP_UNKNOWN201, "OP_UNKNOWN201", 1, opcodeNop},
 	OP_UNKNOWN202: {OP_UNKNOWN202, "OP_UNKNOWN202", 1, opcodeNop},
@@ -740,7 +744,6 @@ func opcodeNop(op *opcode, data []byte, vm *Engine) error {
 	switch op.value {
 	case OP_NOP1, OP_NOP4, OP_NOP5, OP_NOP6,
 		OP_NOP7, OP_NOP8, OP_NOP9, OP_NOP10,
-		OP_UNKNOWN196, OP_UNKNOWN197, OP_UNKNOWN198, OP_UNKNOWN199,
 		OP_UNKNOWN200, OP_UNKNOWN201, OP_UNKNOWN202, OP_UNKNOWN203,
 		OP_UNKNOWN204, OP_UNKNOWN205, OP_UNKNOWN206, OP_UNKNOWN207,
 		OP_UNKNOWN208, OP_UNKNOWN209, OP_UNKNOWN210, OP_UNKNOWN211,
@@ -2065,12 +2068,47 @@ func opcodeBoolOr(op *opcode, data []byte, vm *Engine) error {
 	return nil
 }
 
+// compareAmounts treats the top two items on the data stack as integers of up
+// to AmountMaxScriptNumLen bytes and replaces them with a 1 when the provided
+// function returns true for the result of comparing the second-to-top item to
+// the top item, otherwise a 0.  It is used by the numeric comparison opcodes in
+// version 1 scripts in order to allow the amounts pushed by the introspection
+// opcodes to be compared.
+//
+// Stack transformation: [... x1 x2] -> [... bool]
+func compareAmounts(vm *Engine, cmpFn func(cmp int) bool) error {
+	so, err := vm.dstack.PopByteArray()
+	if err != nil {
+		return err
+	}
+	v0, err := makeBigScriptNum(so, AmountMaxScriptNumLen)
+	if err != nil {
+		return err
+	}
+
+	so, err = vm.dstack.PopByteArray()
+	if err != nil {
+		return err
+	}
+	v1, err := makeBigScriptNum(so, AmountMaxScriptNumLen)
+	if err != nil {
+		return err
+	}
+
+	vm.dstack.PushBool(cmpFn(v1.Cmp(v0)))
+	return nil
+}
+
 // opcodeNumEqual treats the top two items on the data stack as integers.  When
 // they are equal, they are replaced with a 1, otherwise a 0.
 //
 // Stack transformation (x1==x2): [... 5 5] -> [... 1]
 // Stack transformation (x1!=x2): [... 5 7] -> [... 0]
 func opcodeNumEqual(op *opcode, data []byte, vm *Engine) error {
+	if vm.isIntrospectionEnabled() {
+		return compareAmounts(vm, func(cmp int) bool { return cmp == 0 })
+	}
+
 	v0, err := vm.dstack.PopInt(MathOpCodeMaxScriptNumLen)
 	if err != nil {
 		return err
@@ -2112,6 +2150,10 @@ func opcodeNumEqualVerify(op *opcode, data []byte, vm *Engine) error {
 // Stack transformation (x1==x2): [... 5 5] -> [... 0]
 // Stack transformation (x1!=x2): [... 5 7] -> [... 1]
 func opcodeNumNotEqual(op *opcode, data []byte, vm *Engine) error {
+	if vm.isIntrospectionEnabled() {
+		return compareAmounts(vm, func(cmp int) bool { return cmp != 0 })
+	}
+
 	v0, err := vm.dstack.PopInt(MathOpCodeMaxScriptNumLen)
 	if err != nil {
 		return err
@@ -2137,6 +2179,10 @@ func opcodeNumNotEqual(op *opcode, data []byte, vm *Engine) error {
 //
 // Stack transformation: [... x1 x2] -> [... bool]
 func opcodeLessThan(op *opcode, data []byte, vm *Engine) error {
+	if vm.isIntrospectionEnabled() {
+		return compareAmounts(vm, func(cmp int) bool { return cmp < 0 })
+	}
+
 	v0, err := vm.dstack.PopInt(MathOpCodeMaxScriptNumLen)
 	if err != nil {
 		return err
@@ -2162,6 +2208,10 @@ func opcodeLessThan(op *opcode, data []byte, vm *Engine) error {
 //
 // Stack transformation: [... x1 x2] -> [... bool]
 func opcodeGreaterThan(op *opcode, data []byte, vm *Engine) error {
+	if vm.isIntrospectionEnabled() {
+		return compareAmounts(vm, func(cmp int) bool { return cmp > 0 })
+	}
+
 	v0, err := vm.dstack.PopInt(MathOpCodeMaxScriptNumLen)
 	if err != nil {
 		return err
@@ -2186,6 +2236,10 @@ func opcodeGreaterThan(op *opcode, data []byte, vm *Engine) error {
 //
 // Stack transformation: [... x1 x2] -> [... bool]
 func opcodeLessThanOrEqual(op *opcode, data []byte, vm *Engine) error {
+	if vm.isIntrospectionEnabled() {
+		return compareAmounts(vm, func(cmp int) bool { return cmp <= 0 })
+	}
+
 	v0, err := vm.dstack.PopInt(MathOpCodeMaxScriptNumLen)
 	if err != nil {
 		return err
@@ -2210,6 +2264,10 @@ func opcodeLessThanOrEqual(op *opcode, data []byte, vm *Engine) error {
 //
 // Stack transformation: [... x1 x2] -> [... bool]
 func opcodeGreaterThanOrEqual(op *opcode, data []byte, vm *Engine) error {
+	if vm.isIntrospectionEnabled() {
+		return compareAmounts(vm, func(cmp int) bool { return cmp >= 0 })
+	}
+
 	v0, err := vm.dstack.PopInt(MathOpCodeMaxScriptNumLen)
 	if err != nil {
 		return err
@@ -2457,7 +2515,7 @@ func opcodeCheckSig(op *opcode, data []byte, vm *Engine) error {
 	// the data stack.
 	hashType := SigHashType(fullSigBytes[len(fullSigBytes)-1])
 	sigBytes := fullSigBytes[:len(fullSigBytes)-1]
-	if err := CheckHashTypeEncoding(hashType); err != nil {
+	if err := vm.checkHashTypeEncoding(hashType); err != nil {
 		return err
 	}
 	if err := CheckSignatureEncoding(sigBytes); err != nil {
@@ -2480,7 +2538,7 @@ func opcodeCheckSig(op *opcode, data []byte, vm *Engine) error {
 		prefixHash = vm.tx.CachedTxHash()
 	}
 	hash, err := calcSignatureHash(subScript, hashType, &vm.tx, vm.txIdx,
-		prefixHash)
+		vm.prevOuts, prefixHash)
 	if err != nil {
 		vm.dstack.PushBool(false)
 		return nil // nolint:nilerr
@@ -2672,7 +2730,7 @@ func opcodeCheckMultiSig(op *opcode, data []byte, vm *Engine) error {
 		// Only parse and check the signature encoding once.
 		var parsedSig *ecdsa.Signature
 		if !sigInfo.parsed {
-			if err := CheckHashTypeEncoding(hashType); err != nil {
+			if err := vm.checkHashTypeEncoding(hashType); err != nil {
 				return err
 			}
 			if err := CheckSignatureEncoding(signature); err != nil {
@@ -2713,7 +2771,7 @@ func opcodeCheckMultiSig(op *opcode, data []byte, vm *Engine) error {
 			prefixHash = vm.tx.CachedTxHash()
 		}
 		hash, err := calcSignatureHash(script, hashType, &vm.tx, vm.txIdx,
-			prefixHash)
+			vm.prevOuts, prefixHash)
 		if err != nil {
 			return err
 		}
@@ -2844,7 +2902,7 @@ func opcodeCheckSigAlt(op *opcode, data []byte, vm *Engine) error {
 	// requirements enabled by the flags.
 	hashType := SigHashType(fullSigBytes[len(fullSigBytes)-1])
 	sigBytes := fullSigBytes[:len(fullSigBytes)-1]
-	if err := CheckHashTypeEncoding(hashType); err != nil {
+	if err := vm.checkHashTypeEncoding(hashType); err != nil {
 		return err
 	}
 
@@ -2864,7 +2922,7 @@ func opcodeCheckSigAlt(op *opcode, data []byte, vm *Engine) error {
 		}
 	}
 	hash, err := calcSignatureHash(subScript, hashType, &vm.tx, vm.txIdx,
-		prefixHash)
+		vm.prevOuts, prefixHash)
 	if err != nil {
 		vm.dstack.PushBool(false)
 		return nil // nolint:nilerr
@@ -2964,6 +3022,164 @@ func opcodeTGen(op *opcode, data []byte, vm *Engine) error {
 	return nil
 }
 
+// amountScriptNumBytes returns the amount of an output with the provided coin
+// type, VAR value, and SKA value serialized as a script number.  An error is
+// returned when the serialized amount exceeds AmountMaxScriptNumLen bytes.
+func amountScriptNumBytes(coinType cointype.CoinType, value int64, skaValue *big.Int) ([]byte, error) {
+	amount := big.NewInt(value)
+	if coinType.IsSKA() {
+		amount.SetInt64(0)
+		if skaValue != nil {
+			amount.Set(skaValue)
+		}
+	}
+
+	amountBytes := bigScriptNumBytes(amount)
+	if len(amountBytes) > AmountMaxScriptNumLen {
+		str := fmt.Sprintf("amount %v encoded as %x is %d bytes which "+
+			"exceeds the max allowed of %d", amount, amountBytes,
+			len(amountBytes), AmountMaxScriptNumLen)
+		return nil, scriptError(ErrNumOutOfRange, str)
+	}
+	return amountBytes, nil
+}
+
+// spentPrevOut returns the details of the output spent by the input being
+// verified.  An error is returned when the details of the outputs spent by the
+// transaction were not provided to the engine.
+func (vm *Engine) spentPrevOut() (*PrevOutput, error) {
+	if vm.prevOuts == nil {
+		str := "the details of the outputs spent by the transaction are " +
+			"not available"
+		return nil, scriptError(ErrMissingPrevOuts, str)
+	}
+	return &vm.prevOuts[vm.txIdx], nil
+}
+
+// popTxOut treats the top item on the data stack as an output index and
+// returns the referenced output of the transaction.  An error is returned when
+// the index does not refer to a valid output.
+//
+// Stack transformation: [... index] -> [...]
+func (vm *Engine) popTxOut() (*wire.TxOut, error) {
+	idx, err := vm.dstack.PopInt(MathOpCodeMaxScriptNumLen)
+	if err != nil {
+		return nil, err
+	}
+	if idx < 0 || int64(idx) >= int64(len(vm.tx.TxOut)) {
+		str := fmt.Sprintf("output index %d is negative or >= %d", idx,
+			len(vm.tx.TxOut))
+		return nil, scriptError(ErrInvalidOutputIndex, str)
+	}
+	return vm.tx.TxOut[idx], nil
+}
+
+// opcodeInputCoinType pushes the coin type of the output spent by the input
+// being verified to the data stack.
+//
+// Stack transformation: [...] -> [... cointype]
+func opcodeInputCoinType(op *opcode, data []byte, vm *Engine) error {
+	// Treat the opcode as OP_UNKNOWN196 if the introspection opcodes are not
+	// enabled.
+	if !vm.isIntrospectionEnabled() {
+		if vm.hasFlag(ScriptDiscourageUpgradableNops) {
+			return scriptError(ErrDiscourageUpgradableNOPs,
+				"OP_UNKNOWN196 reserved for upgrades")
+		}
+		return nil
+	}
+
+	prevOut, err := vm.spentPrevOut()
+	if err != nil {
+		return err
+	}
+	vm.dstack.PushInt(ScriptNum(prevOut.CoinType))
+	return nil
+}
+
+// opcodeInputAmount pushes the amount of the output spent by the input being
+// verified to the data stack.  The amount is encoded as a script number of up
+// to AmountMaxScriptNumLen bytes.
+//
+// Stack transformation: [...] -> [... amount]
+func opcodeInputAmount(op *opcode, data []byte, vm *Engine) error {
+	// Treat the opcode as OP_UNKNOWN197 if the introspection opcodes are not
+	// enabled.
+	if !vm.isIntrospectionEnabled() {
+		if vm.hasFlag(ScriptDiscourageUpgradableNops) {
+			return scriptError(ErrDiscourageUpgradableNOPs,
+				"OP_UNKNOWN197 reserved for upgrades")
+		}
+		return nil
+	}
+
+	prevOut, err := vm.spentPrevOut()
+	if err != nil {
+		return err
+	}
+	amount, err := amountScriptNumBytes(prevOut.CoinType, prevOut.Value,
+		prevOut.SKAValue)
+	if err != nil {
+		return err
+	}
+	vm.dstack.PushByteArray(amount)
+	return nil
+}
+
+// opcodeOutputCoinType treats the top item on the data stack as an output index
+// and replaces it with the coin type of the referenced output of the
+// transaction.
+//
+// Stack transformation: [... index] -> [... cointype]
+func opcodeOutputCoinType(op *opcode, data []byte, vm *Engine) error {
+	// Treat the opcode as OP_UNKNOWN198 if the introspection opcodes are not
+	// enabled.
+	if !vm.isIntrospectionEnabled() {
+		if vm.hasFlag(ScriptDiscourageUpgradableNops) {
+			return scriptError(ErrDiscourageUpgradableNOPs,
+				"OP_UNKNOWN198 reserved for upgrades")
+		}
+		return nil
+	}
+
+	txOut, err := vm.popTxOut()
+	if err != nil {
+		return err
+	}
+	vm.dstack.PushInt(ScriptNum(txOut.CoinType))
+	return nil
+}
+
+// opcodeOutputAmount treats the top item on the data stack as an output index
+// and replaces it with the amount of the referenced output of the transaction.
+// The amount is encoded as a script number of up to AmountMaxScriptNumLen
+// bytes.
+//
+// Stack transformation: [... index] -> [... amount]
+func opcodeOutputAmount(op *opcode, data []byte, vm *Engine) error {
+	// Treat the opcode as OP_UNKNOWN199 if the introspection opcodes are not
+	// enabled.
+	if !vm.isIntrospectionEnabled() {
+		if vm.hasFlag(ScriptDiscourageUpgradableNops) {
+			return scriptError(ErrDiscourageUpgradableNOPs,
+				"OP_UNKNOWN199 reserved for upgrades")
+		}
+		return nil
+	}
+
+	txOut, err := vm.popTxOut()
+	if err != nil {
+		return err
+	}
+	amount, err := amountScriptNumBytes(txOut.CoinType, txOut.Value,
+		txOut.SKAValue)
+	if err != nil {
+		return err
+	}
+	vm.dstack.PushByteArray(amount)
+	return nil
+}
+
 // OpcodeByName is a map that can be used to lookup an opcode by its
 // human-readable name (OP_CHECKMULTISIG, OP_CHECKSIG, etc).
 var OpcodeByName = make(map[string]byte)
@@ -96,6 +96,8 @@ func TestOpcodeDisasm(t *testing.T) {
 		0xbc: "OP_SSRTX", 0xbd: "OP_SSTXCHANGE", 0xbe: "OP_CHECKSIGALT",
 		0xbf: "OP_CHECKSIGALTVERIFY", 0xc0: "OP_SHA256",
 		0xc1: "OP_TADD", 0xc2: "OP_TSPEND", 0xc3: "OP_TGEN",
+		0xc4: "OP_INPUTCOINTYPE", 0xc5: "OP_INPUTAMOUNT",
+		0xc6: "OP_OUTPUTCOINTYPE", 0xc7: "OP_OUTPUTAMOUNT",
 	}
 	for opcodeVal, expectedStr := range expectedStrings {
 		var data []byte
@@ -141,7 +143,7 @@ func TestOpcodeDisasm(t *testing.T) {
 			}
 
 		// OP_UNKNOWN#.
-		case opcodeVal >= 0xc4 && opcodeVal <= 0xf8 || opcodeVal == 0xfc:
+		case opcodeVal >= 0xc8 && opcodeVal <= 0xf8 || opcodeVal == 0xfc:
 			expectedStr = "OP_UNKNOWN" + strconv.Itoa(opcodeVal)
 		}
 
@@ -208,7 +210,7 @@ func TestOpcodeDisasm(t *testing.T) {
 			}
 
 		// OP_UNKNOWN#.
-		case opcodeVal >= 0xc4 && opcodeVal <= 0xf8 || opcodeVal == 0xfc:
+		case opcodeVal >= 0xc8 && opcodeVal <= 0xf8 || opcodeVal == 0xfc:
 			expectedStr = "OP_UNKNOWN" + strconv.Itoa(opcodeVal)
 		}
 
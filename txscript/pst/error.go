@@ -0,0 +1,92 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package pst
+
+// ErrorKind identifies a kind of error.
+type ErrorKind string
+
+// These constants are used to identify a specific ErrorKind.
+const (
+	// ErrInvalidMagic indicates a serialized packet does not begin with the
+	// expected magic bytes.
+	ErrInvalidMagic = ErrorKind("ErrInvalidMagic")
+
+	// ErrUnsupportedVersion indicates a serialized packet specifies a
+	// version that is not supported.
+	ErrUnsupportedVersion = ErrorKind("ErrUnsupportedVersion")
+
+	// ErrMalformedPacket indicates a serialized packet could not be decoded.
+	ErrMalformedPacket = ErrorKind("ErrMalformedPacket")
+
+	// ErrDuplicateKey indicates a serialized packet contains the same key
+	// more than once within a single map.
+	ErrDuplicateKey = ErrorKind("ErrDuplicateKey")
+
+	// ErrSignedTx indicates the transaction of a packet already has one or
+	// more signature scripts.
+	ErrSignedTx = ErrorKind("ErrSignedTx")
+
+	// ErrInvalidIndex indicates an input or output index that is out of
+	// range for the transaction of a packet.
+	ErrInvalidIndex = ErrorKind("ErrInvalidIndex")
+
+	// ErrMissingPrevOut indicates an operation requires the previous output
+	// of an input and it is not known.
+	ErrMissingPrevOut = ErrorKind("ErrMissingPrevOut")
+
+	// ErrMismatchedTx indicates an attempt to combine packets for different
+	// transactions.
+	ErrMismatchedTx = ErrorKind("ErrMismatchedTx")
+
+	// ErrConflictingData indicates an attempt to combine packets that
+	// contain different values for the same field.
+	ErrConflictingData = ErrorKind("ErrConflictingData")
+
+	// ErrUnsupportedScript indicates an input can not be finalized because
+	// the script it spends is not a supported standard script.
+	ErrUnsupportedScript = ErrorKind("ErrUnsupportedScript")
+
+	// ErrMissingRedeemScript indicates an input that spends a
+	// pay-to-script-hash output can not be finalized because its redeem
+	// script is not known.
+	ErrMissingRedeemScript = ErrorKind("ErrMissingRedeemScript")
+
+	// ErrMissingSignatures indicates an input can not be finalized because
+	// it does not have enough partial signatures.
+	ErrMissingSignatures = ErrorKind("ErrMissingSignatures")
+
+	// ErrNotFinalized indicates an attempt to extract the transaction of a
+	// packet before all of its inputs are finalized.
+	ErrNotFinalized = ErrorKind("ErrNotFinalized")
+)
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e ErrorKind) Error() string {
+	return string(e)
+}
+
+// Error identifies a partially signed transaction related error.
+//
+// It has full support for errors.Is and errors.As, so the caller can ascertain
+// the specific reason for the error by checking the underlying error.
+type Error struct {
+	Err         error
+	Description string
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e Error) Error() string {
+	return e.Description
+}
+
+// Unwrap returns the underlying wrapped error.
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// makeError creates an Error given a set of arguments.
+func makeError(kind ErrorKind, desc string) Error {
+	return Error{Err: kind, Description: desc}
+}
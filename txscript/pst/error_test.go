@@ -0,0 +1,59 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package pst
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestErrorKindStringer tests the stringized output for the ErrorKind type.
+func TestErrorKindStringer(t *testing.T) {
+	tests := []struct {
+		in   ErrorKind
+		want string
+	}{
+		{ErrInvalidMagic, "ErrInvalidMagic"},
+		{ErrUnsupportedVersion, "ErrUnsupportedVersion"},
+		{ErrMalformedPacket, "ErrMalformedPacket"},
+		{ErrDuplicateKey, "ErrDuplicateKey"},
+		{ErrSignedTx, "ErrSignedTx"},
+		{ErrInvalidIndex, "ErrInvalidIndex"},
+		{ErrMissingPrevOut, "ErrMissingPrevOut"},
+		{ErrMismatchedTx, "ErrMismatchedTx"},
+		{ErrConflictingData, "ErrConflictingData"},
+		{ErrUnsupportedScript, "ErrUnsupportedScript"},
+		{ErrMissingRedeemScript, "ErrMissingRedeemScript"},
+		{ErrMissingSignatures, "ErrMissingSignatures"},
+		{ErrNotFinalized, "ErrNotFinalized"},
+	}
+
+	for i, test := range tests {
+		result := test.in.Error()
+		if result != test.want {
+			t.Errorf("#%d: got: %s want: %s", i, result, test.want)
+			continue
+		}
+	}
+}
+
+// TestErrorKindIsAs ensures both ErrorKind and Error can be identified as
+// being a specific error kind via errors.Is and unwrapped via errors.As.
+func TestErrorKindIsAs(t *testing.T) {
+	err := makeError(ErrMalformedPacket, "malformed")
+	if !errors.Is(err, ErrMalformedPacket) {
+		t.Fatalf("error does not match kind %v", ErrMalformedPacket)
+	}
+	if errors.Is(err, ErrInvalidMagic) {
+		t.Fatalf("error unexpectedly matches kind %v", ErrInvalidMagic)
+	}
+	var kind ErrorKind
+	if !errors.As(err, &kind) || kind != ErrMalformedPacket {
+		t.Fatalf("unable to unwrap kind - got %v", kind)
+	}
+	if err.Error() != "malformed" {
+		t.Fatalf("unexpected description %q", err.Error())
+	}
+}
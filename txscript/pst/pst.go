@@ -0,0 +1,279 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// Package pst implements a versioned interchange format for unsigned and
+// partially signed transactions.
+//
+// A packet carries an unsigned transaction along with the previous output,
+// including the coin type and amount, spent by each input, partial signatures,
+// redeem scripts, and key derivation paths.  This allows multiple parties and
+// offline signers to cooperate on signing VAR and SKA transactions without any
+// out-of-band information.  Packets are serialized to a compact binary format
+// and are typically exchanged as base64 strings.
+//
+// The typical workflow is for a creator to call New with the unsigned
+// transaction and populate the previous outputs of its inputs, for each signer
+// to call Sign or SignTxOutput, for the resulting packets to be merged with
+// Combine, and finally for Finalize and Extract to produce the fully signed
+// transaction.
+package pst
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/txscript"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// Version is the current version of the partially signed transaction format.
+const Version = 0
+
+// magic is the sequence of bytes every serialized packet begins with.  It is
+// the ASCII string "mpst" followed by 0xff.
+var magic = [5]byte{0x6d, 0x70, 0x73, 0x74, 0xff}
+
+// PrevOutput describes the previous output spent by a transaction input.  It
+// provides everything a signer needs to verify what is being spent and to
+// calculate signature hashes that commit to it.
+type PrevOutput struct {
+	// CoinType is the coin type of the output being spent.
+	CoinType cointype.CoinType
+
+	// Value is the amount of the output being spent in atoms when the coin
+	// type is VAR.
+	Value int64
+
+	// SKAValue is the amount of the output being spent in atoms when the
+	// coin type is SKA.
+	SKAValue *big.Int
+
+	// Version is the script version of the output being spent.
+	Version uint16
+
+	// PkScript is the public key script of the output being spent.
+	PkScript []byte
+}
+
+// sigHashPrevOutput returns the details of the previous output committed to by
+// signatures with the SigHashCoinType flag set.
+func (p *PrevOutput) sigHashPrevOutput() txscript.PrevOutput {
+	return txscript.PrevOutput{
+		CoinType: p.CoinType,
+		Value:    p.Value,
+		SKAValue: p.SKAValue,
+		Version:  p.Version,
+	}
+}
+
+// PartialSig is a signature for an input along with the public key it is
+// valid for.
+type PartialSig struct {
+	// PubKey is the serialized public key the signature is valid for.
+	PubKey []byte
+
+	// Signature is the serialized signature with the signature hash type
+	// appended to it.
+	Signature []byte
+}
+
+// Bip32Derivation describes the hierarchical deterministic derivation path of
+// a public key so that signers are able to locate the associated private key.
+type Bip32Derivation struct {
+	// PubKey is the serialized public key.
+	PubKey []byte
+
+	// MasterKeyFingerprint is the fingerprint of the master key the path is
+	// relative to.
+	MasterKeyFingerprint uint32
+
+	// Path is the derivation path from the master key.
+	Path []uint32
+}
+
+// Input houses the information needed to sign and finalize a transaction
+// input.
+type Input struct {
+	// PrevOutput is the previous output spent by the input.  It is nil when
+	// it is not yet known.
+	PrevOutput *PrevOutput
+
+	// RedeemScript is the redeem script of a pay-to-script-hash previous
+	// output.
+	RedeemScript []byte
+
+	// SigHashType is the signature hash type signers are requested to use.
+	// It is zero when no specific type is requested.
+	SigHashType txscript.SigHashType
+
+	// PartialSigs are the signatures for the input that have been provided
+	// so far.
+	PartialSigs []*PartialSig
+
+	// Bip32Derivations are the derivation paths of the public keys involved
+	// in spending the previous output.
+	Bip32Derivations []*Bip32Derivation
+
+	// FinalScriptSig is the complete signature script for the input.  It is
+	// nil until the input is finalized.
+	FinalScriptSig []byte
+}
+
+// IsFinalized returns whether or not the input has a complete signature
+// script.
+func (i *Input) IsFinalized() bool {
+	return i.FinalScriptSig != nil
+}
+
+// Output houses the information that allows signers to recognize outputs,
+// such as change, that pay to keys under their control.
+type Output struct {
+	// RedeemScript is the redeem script of a pay-to-script-hash output.
+	RedeemScript []byte
+
+	// Bip32Derivations are the derivation paths of the public keys involved
+	// in the output.
+	Bip32Derivations []*Bip32Derivation
+}
+
+// Packet is a partially signed transaction.  It houses an unsigned transaction
+// along with the per-input and per-output information required by the parties
+// involved in signing it.
+type Packet struct {
+	// UnsignedTx is the transaction being signed.  Its signature scripts are
+	// always empty.
+	UnsignedTx *wire.MsgTx
+
+	// Inputs houses the information for each input of the transaction.
+	Inputs []Input
+
+	// Outputs houses the information for each output of the transaction.
+	Outputs []Output
+}
+
+// New returns a new packet for the provided unsigned transaction.  The
+// transaction must not have any signature scripts.
+func New(tx *wire.MsgTx) (*Packet, error) {
+	for i, txIn := range tx.TxIn {
+		if len(txIn.SignatureScript) != 0 {
+			str := fmt.Sprintf("input %d has a signature script", i)
+			return nil, makeError(ErrSignedTx, str)
+		}
+	}
+
+	return &Packet{
+		UnsignedTx: tx.Copy(),
+		Inputs:     make([]Input, len(tx.TxIn)),
+		Outputs:    make([]Output, len(tx.TxOut)),
+	}, nil
+}
+
+// checkInputIndex returns an error when the provided index does not refer to an
+// input of the packet.
+func (p *Packet) checkInputIndex(idx int) error {
+	if idx < 0 || idx >= len(p.Inputs) {
+		str := fmt.Sprintf("input index %d is negative or >= %d", idx,
+			len(p.Inputs))
+		return makeError(ErrInvalidIndex, str)
+	}
+	return nil
+}
+
+// prevOutputs returns the details of the previous outputs of all inputs as
+// committed to by signatures with the SigHashCoinType flag set.  An error is
+// returned when any of them are not known.
+func (p *Packet) prevOutputs() ([]txscript.PrevOutput, error) {
+	prevOuts := make([]txscript.PrevOutput, len(p.Inputs))
+	for i := range p.Inputs {
+		prevOut := p.Inputs[i].PrevOutput
+		if prevOut == nil {
+			str := fmt.Sprintf("previous output for input %d is not known", i)
+			return nil, makeError(ErrMissingPrevOut, str)
+		}
+		prevOuts[i] = prevOut.sigHashPrevOutput()
+	}
+	return prevOuts, nil
+}
+
+// IsComplete returns whether or not every input of the packet is finalized.
+func (p *Packet) IsComplete() bool {
+	for i := range p.Inputs {
+		if !p.Inputs[i].IsFinalized() {
+			return false
+		}
+	}
+	return true
+}
+
+// Fees returns the amount each coin type in the transaction pays in fees,
+// which is the total of the previous outputs less the total of the outputs of
+// the coin type.  An error is returned when the previous output of any input
+// is not known.
+func (p *Packet) Fees() (map[cointype.CoinType]*big.Int, error) {
+	fees := make(map[cointype.CoinType]*big.Int)
+	add := func(coinType cointype.CoinType, amount *big.Int) {
+		fee, ok := fees[coinType]
+		if !ok {
+			fee = new(big.Int)
+			fees[coinType] = fee
+		}
+		fee.Add(fee, amount)
+	}
+	amount := func(coinType cointype.CoinType, value int64, skaValue *big.Int) *big.Int {
+		if coinType.IsSKA() {
+			if skaValue == nil {
+				return new(big.Int)
+			}
+			return skaValue
+		}
+		return big.NewInt(value)
+	}
+
+	for i := range p.Inputs {
+		prevOut := p.Inputs[i].PrevOutput
+		if prevOut == nil {
+			str := fmt.Sprintf("previous output for input %d is not known", i)
+			return nil, makeError(ErrMissingPrevOut, str)
+		}
+		add(prevOut.CoinType, amount(prevOut.CoinType, prevOut.Value,
+			prevOut.SKAValue))
+	}
+	for _, txOut := range p.UnsignedTx.TxOut {
+		value := amount(txOut.CoinType, txOut.Value, txOut.SKAValue)
+		add(txOut.CoinType, new(big.Int).Neg(value))
+	}
+	return fees, nil
+}
+
+// B64Encode returns the base64 encoding of the serialized packet.
+func (p *Packet) B64Encode() (string, error) {
+	var buf bytes.Buffer
+	if err := p.Serialize(&buf); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Parse decodes a packet from the provided serialized bytes.
+func Parse(b []byte) (*Packet, error) {
+	p := new(Packet)
+	if err := p.Deserialize(bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ParseBase64 decodes a packet from the provided base64 encoding of its
+// serialized bytes.
+func ParseBase64(s string) (*Packet, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		str := fmt.Sprintf("invalid base64 encoding: %v", err)
+		return nil, makeError(ErrMalformedPacket, str)
+	}
+	return Parse(b)
+}
@@ -0,0 +1,379 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package pst
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/chaincfg"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/dcrec"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+	"github.com/monetarium/monetarium-node/txscript"
+	"github.com/monetarium/monetarium-node/txscript/stdaddr"
+	"github.com/monetarium/monetarium-node/txscript/stdscript"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// testKey houses a private key used throughout the tests along with its
+// serialized compressed public key.
+type testKey struct {
+	priv   []byte
+	pubKey []byte
+}
+
+// newTestKey returns a deterministic test key derived from the provided seed.
+func newTestKey(seed byte) testKey {
+	priv := chainhash.HashB([]byte{seed})
+	pubKey := secp256k1.PrivKeyFromBytes(priv).PubKey().SerializeCompressed()
+	return testKey{priv: priv, pubKey: pubKey}
+}
+
+// p2pkhScript returns a version 0 pay-to-pubkey-hash script for the key.
+func (k testKey) p2pkhScript(t *testing.T) []byte {
+	t.Helper()
+
+	params := chaincfg.RegNetParams()
+	addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(
+		stdaddr.Hash160(k.pubKey), params)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	_, script := addr.PaymentScript()
+	return script
+}
+
+// testSKAValue is an SKA amount that does not fit in an int64.
+var testSKAValue, _ = new(big.Int).SetString("123456789012345678901234567", 10)
+
+// newTestTx returns an unsigned transaction with the provided number of inputs
+// and one VAR and one SKA output.
+func newTestTx(numInputs int) *wire.MsgTx {
+	tx := wire.NewMsgTx()
+	for i := 0; i < numInputs; i++ {
+		prevOut := wire.NewOutPoint(&chainhash.Hash{byte(i + 1)}, uint32(i),
+			wire.TxTreeRegular)
+		tx.AddTxIn(wire.NewTxIn(prevOut, 0, nil))
+	}
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{txscript.OP_TRUE}))
+	tx.AddTxOut(&wire.TxOut{
+		CoinType: cointype.CoinType(3),
+		SKAValue: new(big.Int).Sub(testSKAValue, big.NewInt(1000)),
+		PkScript: []byte{txscript.OP_TRUE},
+	})
+	return tx
+}
+
+// roundTrip serializes and parses the provided packet.
+func roundTrip(t *testing.T, p *Packet) *Packet {
+	t.Helper()
+
+	encoded, err := p.B64Encode()
+	if err != nil {
+		t.Fatalf("unable to encode packet: %v", err)
+	}
+	decoded, err := ParseBase64(encoded)
+	if err != nil {
+		t.Fatalf("unable to decode packet: %v", err)
+	}
+	return decoded
+}
+
+// TestSerializeRoundTrip ensures packets with every field populated survive
+// being serialized and parsed.
+func TestSerializeRoundTrip(t *testing.T) {
+	key := newTestKey(1)
+	p, err := New(newTestTx(2))
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+	p.Inputs[0] = Input{
+		PrevOutput: &PrevOutput{
+			CoinType: cointype.CoinTypeVAR,
+			Value:    2000,
+			PkScript: key.p2pkhScript(t),
+		},
+		SigHashType: txscript.SigHashAll | txscript.SigHashCoinType,
+		PartialSigs: []*PartialSig{{
+			PubKey:    key.pubKey,
+			Signature: []byte{0x30, 0x01},
+		}},
+		Bip32Derivations: []*Bip32Derivation{{
+			PubKey:               key.pubKey,
+			MasterKeyFingerprint: 0xdeadbeef,
+			Path:                 []uint32{0x8000002c, 1, 0},
+		}},
+	}
+	p.Inputs[1] = Input{
+		PrevOutput: &PrevOutput{
+			CoinType: cointype.CoinType(3),
+			SKAValue: testSKAValue,
+			Version:  1,
+			PkScript: []byte{txscript.OP_TRUE},
+		},
+		RedeemScript:   []byte{txscript.OP_TRUE},
+		FinalScriptSig: []byte{},
+	}
+	p.Outputs[1] = Output{
+		RedeemScript: []byte{txscript.OP_TRUE},
+		Bip32Derivations: []*Bip32Derivation{{
+			PubKey: key.pubKey,
+		}},
+	}
+
+	var want bytes.Buffer
+	if err := p.Serialize(&want); err != nil {
+		t.Fatalf("unable to serialize packet: %v", err)
+	}
+	decoded := roundTrip(t, p)
+	var got bytes.Buffer
+	if err := decoded.Serialize(&got); err != nil {
+		t.Fatalf("unable to serialize decoded packet: %v", err)
+	}
+	if !bytes.Equal(got.Bytes(), want.Bytes()) {
+		t.Fatalf("mismatched round trip\ngot:  %x\nwant: %x", got.Bytes(),
+			want.Bytes())
+	}
+
+	if decoded.UnsignedTx.TxHash() != p.UnsignedTx.TxHash() {
+		t.Fatal("mismatched unsigned transaction")
+	}
+	in := decoded.Inputs[1]
+	if in.PrevOutput.SKAValue.Cmp(testSKAValue) != 0 {
+		t.Fatalf("mismatched SKA value - got %v, want %v",
+			in.PrevOutput.SKAValue, testSKAValue)
+	}
+	if !in.IsFinalized() {
+		t.Fatal("empty final signature script not treated as finalized")
+	}
+	d := decoded.Inputs[0].Bip32Derivations[0]
+	if d.MasterKeyFingerprint != 0xdeadbeef || len(d.Path) != 3 ||
+		d.Path[0] != 0x8000002c {
+
+		t.Fatalf("mismatched derivation path %+v", d)
+	}
+}
+
+// TestParseErrors ensures malformed packets are rejected with the expected
+// errors.
+func TestParseErrors(t *testing.T) {
+	p, err := New(newTestTx(1))
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := p.Serialize(&buf); err != nil {
+		t.Fatalf("unable to serialize packet: %v", err)
+	}
+	valid := buf.Bytes()
+
+	badMagic := append([]byte(nil), valid...)
+	badMagic[0] ^= 0xff
+	badVersion := append([]byte(nil), valid...)
+	badVersion[len(magic)] = Version + 1
+
+	tests := []struct {
+		name string
+		b    []byte
+		want error
+	}{{
+		name: "bad magic",
+		b:    badMagic,
+		want: ErrInvalidMagic,
+	}, {
+		name: "unsupported version",
+		b:    badVersion,
+		want: ErrUnsupportedVersion,
+	}, {
+		name: "truncated",
+		b:    valid[:len(valid)-1],
+		want: ErrMalformedPacket,
+	}, {
+		name: "missing unsigned tx",
+		b:    append(append([]byte(nil), valid[:len(magic)+1]...), 0x00),
+		want: ErrMalformedPacket,
+	}}
+	for _, test := range tests {
+		_, err := Parse(test.b)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%q: mismatched error - got %v, want %v", test.name,
+				err, test.want)
+		}
+	}
+
+	signedTx := newTestTx(1)
+	signedTx.TxIn[0].SignatureScript = []byte{txscript.OP_TRUE}
+	if _, err := New(signedTx); !errors.Is(err, ErrSignedTx) {
+		t.Errorf("mismatched error for signed tx - got %v, want %v", err,
+			ErrSignedTx)
+	}
+}
+
+// verifyTx ensures every input of the provided transaction validates with the
+// coin type signature hash enabled.
+func verifyTx(t *testing.T, tx *wire.MsgTx, prevOuts []*PrevOutput) {
+	t.Helper()
+
+	sigHashPrevOuts := make([]txscript.PrevOutput, len(prevOuts))
+	for i, prevOut := range prevOuts {
+		sigHashPrevOuts[i] = prevOut.sigHashPrevOutput()
+	}
+	const flags = txscript.ScriptVerifyCoinTypeSigHash
+	for i, prevOut := range prevOuts {
+		vm, err := txscript.NewEngineWithPrevOuts(prevOut.PkScript, tx, i,
+			flags, prevOut.Version, nil, sigHashPrevOuts)
+		if err != nil {
+			t.Fatalf("input %d: unable to create engine: %v", i, err)
+		}
+		if err := vm.Execute(); err != nil {
+			t.Fatalf("input %d: failed to validate: %v", i, err)
+		}
+	}
+}
+
+// TestSignCombineFinalize ensures multiple parties can independently sign a
+// dual-coin transaction with signatures that commit to the coin types and
+// amounts being spent and that the combined packet finalizes to a valid
+// transaction.
+func TestSignCombineFinalize(t *testing.T) {
+	varKey, skaKey := newTestKey(1), newTestKey(2)
+	msKey1, msKey2 := newTestKey(3), newTestKey(4)
+	redeemScript, err := stdscript.MultiSigScriptV0(2, msKey1.pubKey,
+		msKey2.pubKey)
+	if err != nil {
+		t.Fatalf("unable to create multisig script: %v", err)
+	}
+	p2sh, err := stdaddr.NewAddressScriptHashV0(redeemScript,
+		chaincfg.RegNetParams())
+	if err != nil {
+		t.Fatalf("unable to create script hash address: %v", err)
+	}
+	_, p2shScript := p2sh.PaymentScript()
+
+	prevOuts := []*PrevOutput{{
+		CoinType: cointype.CoinTypeVAR,
+		Value:    3000,
+		PkScript: varKey.p2pkhScript(t),
+	}, {
+		CoinType: cointype.CoinType(3),
+		SKAValue: testSKAValue,
+		PkScript: skaKey.p2pkhScript(t),
+	}, {
+		CoinType: cointype.CoinTypeVAR,
+		Value:    500,
+		PkScript: p2shScript,
+	}}
+
+	// The creator populates the previous outputs and requests signatures
+	// that commit to them.
+	const hashType = txscript.SigHashAll | txscript.SigHashCoinType
+	creator, err := New(newTestTx(len(prevOuts)))
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+	for i, prevOut := range prevOuts {
+		creator.Inputs[i].PrevOutput = prevOut
+		creator.Inputs[i].SigHashType = hashType
+	}
+	creator.Inputs[2].RedeemScript = redeemScript
+
+	fees, err := creator.Fees()
+	if err != nil {
+		t.Fatalf("unable to calculate fees: %v", err)
+	}
+	if fees[cointype.CoinTypeVAR].Int64() != 2500 ||
+		fees[cointype.CoinType(3)].Int64() != 1000 {
+
+		t.Fatalf("unexpected fees %v", fees)
+	}
+
+	// Each party signs their own copy of the packet.
+	signers := []struct {
+		idx int
+		key testKey
+	}{
+		{idx: 0, key: varKey},
+		{idx: 1, key: skaKey},
+		{idx: 2, key: msKey1},
+		{idx: 2, key: msKey2},
+	}
+	packets := make([]*Packet, 0, len(signers))
+	for _, signer := range signers {
+		p := roundTrip(t, creator)
+		err := p.Sign(signer.idx, signer.key.priv, dcrec.STEcdsaSecp256k1,
+			hashType)
+		if err != nil {
+			t.Fatalf("unable to sign input %d: %v", signer.idx, err)
+		}
+		packets = append(packets, roundTrip(t, p))
+	}
+
+	// Signing with a hash type other than the requested one must fail.
+	err = roundTrip(t, creator).Sign(0, varKey.priv, dcrec.STEcdsaSecp256k1,
+		txscript.SigHashAll)
+	if !errors.Is(err, ErrConflictingData) {
+		t.Fatalf("mismatched error - got %v, want %v", err,
+			ErrConflictingData)
+	}
+
+	// A single multisig signature is not enough to finalize.
+	partial := roundTrip(t, packets[2])
+	if err := partial.Finalize(2); !errors.Is(err, ErrMissingSignatures) {
+		t.Fatalf("mismatched error - got %v, want %v", err,
+			ErrMissingSignatures)
+	}
+
+	combined, err := Combine(packets...)
+	if err != nil {
+		t.Fatalf("unable to combine packets: %v", err)
+	}
+	if _, err := combined.Extract(); !errors.Is(err, ErrNotFinalized) {
+		t.Fatalf("mismatched error - got %v, want %v", err, ErrNotFinalized)
+	}
+	for i := range combined.Inputs {
+		if err := combined.Finalize(i); err != nil {
+			t.Fatalf("unable to finalize input %d: %v", i, err)
+		}
+	}
+	if !combined.IsComplete() {
+		t.Fatal("finalized packet is not complete")
+	}
+	tx, err := roundTrip(t, combined).Extract()
+	if err != nil {
+		t.Fatalf("unable to extract transaction: %v", err)
+	}
+	if tx.TxIn[1].SKAValueIn.Cmp(testSKAValue) != 0 {
+		t.Fatalf("mismatched SKA value in - got %v, want %v",
+			tx.TxIn[1].SKAValueIn, testSKAValue)
+	}
+	verifyTx(t, tx, prevOuts)
+}
+
+// TestCombineErrors ensures packets that can not be combined are rejected.
+func TestCombineErrors(t *testing.T) {
+	a, err := New(newTestTx(1))
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+	b, err := New(newTestTx(2))
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+	if _, err := Combine(a, b); !errors.Is(err, ErrMismatchedTx) {
+		t.Fatalf("mismatched error - got %v, want %v", err, ErrMismatchedTx)
+	}
+
+	c := roundTrip(t, a)
+	a.Inputs[0].PrevOutput = &PrevOutput{Value: 1}
+	c.Inputs[0].PrevOutput = &PrevOutput{Value: 2}
+	if _, err := Combine(a, c); !errors.Is(err, ErrConflictingData) {
+		t.Fatalf("mismatched error - got %v, want %v", err,
+			ErrConflictingData)
+	}
+}
@@ -0,0 +1,480 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package pst
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/txscript"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// A serialized packet consists of the magic bytes and the format version
+// followed by a global map, one map per transaction input, and one map per
+// transaction output, in that order.
+//
+// Each map is a sequence of key-value entries terminated by a single 0x00
+// byte.  Every entry is encoded as:
+//
+//   <key length varint> <key type byte> <key data> <value length varint> <value>
+//
+// The key length includes the key type byte, so a key length of zero is the
+// map terminator.  Entries with unknown key types are skipped when decoding so
+// that newer producers remain readable.
+
+// Global map key types.
+const (
+	// globalUnsignedTx is the key type for the serialized unsigned
+	// transaction.  It has no key data.
+	globalUnsignedTx = 0x00
+)
+
+// Input map key types.
+const (
+	// inputPrevOutput is the key type for the previous output spent by the
+	// input.  It has no key data.  The value is the coin type byte followed
+	// by the amount, the script version as a little-endian uint16, and the
+	// varint length prefixed public key script.  The amount is a
+	// little-endian int64 for VAR and the varint length prefixed big-endian
+	// magnitude for SKA.
+	inputPrevOutput = 0x00
+
+	// inputRedeemScript is the key type for the redeem script.  It has no
+	// key data.
+	inputRedeemScript = 0x01
+
+	// inputSigHashType is the key type for the requested signature hash
+	// type.  It has no key data and the value is a little-endian uint32.
+	inputSigHashType = 0x02
+
+	// inputPartialSig is the key type for a partial signature.  The key data
+	// is the public key and the value is the signature.
+	inputPartialSig = 0x03
+
+	// inputBip32Derivation is the key type for a derivation path.  The key
+	// data is the public key and the value is the little-endian uint32
+	// master key fingerprint followed by each little-endian uint32 path
+	// element.
+	inputBip32Derivation = 0x04
+
+	// inputFinalScriptSig is the key type for the final signature script.
+	// It has no key data.
+	inputFinalScriptSig = 0x05
+)
+
+// Output map key types.
+const (
+	// outputRedeemScript is the key type for the redeem script.  It has no
+	// key data.
+	outputRedeemScript = 0x00
+
+	// outputBip32Derivation is the key type for a derivation path.  It is
+	// encoded the same way as inputBip32Derivation.
+	outputBip32Derivation = 0x01
+)
+
+// maxEntrySize is the maximum size of the key or value of a map entry.
+const maxEntrySize = wire.MaxBlockPayload
+
+// writeEntry writes a map entry with the provided key type, key data, and
+// value.
+func writeEntry(w io.Writer, keyType byte, keyData, value []byte) error {
+	key := make([]byte, 0, 1+len(keyData))
+	key = append(key, keyType)
+	key = append(key, keyData...)
+	if err := wire.WriteVarBytes(w, 0, key); err != nil {
+		return err
+	}
+	return wire.WriteVarBytes(w, 0, value)
+}
+
+// writeSeparator writes the map terminator.
+func writeSeparator(w io.Writer) error {
+	_, err := w.Write([]byte{0x00})
+	return err
+}
+
+// serializePrevOutput returns the serialized value of a previous output entry.
+func serializePrevOutput(prevOut *PrevOutput) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(byte(prevOut.CoinType))
+	if prevOut.CoinType.IsSKA() {
+		var amount []byte
+		if prevOut.SKAValue != nil {
+			amount = prevOut.SKAValue.Bytes()
+		}
+		if err := wire.WriteVarBytes(&buf, 0, amount); err != nil {
+			return nil, err
+		}
+	} else {
+		var amount [8]byte
+		binary.LittleEndian.PutUint64(amount[:], uint64(prevOut.Value))
+		buf.Write(amount[:])
+	}
+	var version [2]byte
+	binary.LittleEndian.PutUint16(version[:], prevOut.Version)
+	buf.Write(version[:])
+	if err := wire.WriteVarBytes(&buf, 0, prevOut.PkScript); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// serializeDerivation returns the serialized value of a derivation path entry.
+func serializeDerivation(d *Bip32Derivation) []byte {
+	value := make([]byte, 4*(1+len(d.Path)))
+	binary.LittleEndian.PutUint32(value, d.MasterKeyFingerprint)
+	for i, element := range d.Path {
+		binary.LittleEndian.PutUint32(value[4*(i+1):], element)
+	}
+	return value
+}
+
+// Serialize encodes the packet to the provided writer.
+func (p *Packet) Serialize(w io.Writer) error {
+	if len(p.Inputs) != len(p.UnsignedTx.TxIn) ||
+		len(p.Outputs) != len(p.UnsignedTx.TxOut) {
+
+		str := fmt.Sprintf("packet has %d inputs and %d outputs for a "+
+			"transaction with %d inputs and %d outputs", len(p.Inputs),
+			len(p.Outputs), len(p.UnsignedTx.TxIn),
+			len(p.UnsignedTx.TxOut))
+		return makeError(ErrMalformedPacket, str)
+	}
+
+	if _, err := w.Write(magic[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{Version}); err != nil {
+		return err
+	}
+
+	// Global map.
+	var txBuf bytes.Buffer
+	txBuf.Grow(p.UnsignedTx.SerializeSize())
+	if err := p.UnsignedTx.Serialize(&txBuf); err != nil {
+		return err
+	}
+	if err := writeEntry(w, globalUnsignedTx, nil, txBuf.Bytes()); err != nil {
+		return err
+	}
+	if err := writeSeparator(w); err != nil {
+		return err
+	}
+
+	// Input maps.
+	for i := range p.Inputs {
+		in := &p.Inputs[i]
+		if in.PrevOutput != nil {
+			value, err := serializePrevOutput(in.PrevOutput)
+			if err != nil {
+				return err
+			}
+			if err := writeEntry(w, inputPrevOutput, nil, value); err != nil {
+				return err
+			}
+		}
+		if in.RedeemScript != nil {
+			err := writeEntry(w, inputRedeemScript, nil, in.RedeemScript)
+			if err != nil {
+				return err
+			}
+		}
+		if in.SigHashType != 0 {
+			var value [4]byte
+			binary.LittleEndian.PutUint32(value[:], uint32(in.SigHashType))
+			err := writeEntry(w, inputSigHashType, nil, value[:])
+			if err != nil {
+				return err
+			}
+		}
+		for _, sig := range in.PartialSigs {
+			err := writeEntry(w, inputPartialSig, sig.PubKey, sig.Signature)
+			if err != nil {
+				return err
+			}
+		}
+		for _, d := range in.Bip32Derivations {
+			err := writeEntry(w, inputBip32Derivation, d.PubKey,
+				serializeDerivation(d))
+			if err != nil {
+				return err
+			}
+		}
+		if in.FinalScriptSig != nil {
+			err := writeEntry(w, inputFinalScriptSig, nil, in.FinalScriptSig)
+			if err != nil {
+				return err
+			}
+		}
+		if err := writeSeparator(w); err != nil {
+			return err
+		}
+	}
+
+	// Output maps.
+	for i := range p.Outputs {
+		out := &p.Outputs[i]
+		if out.RedeemScript != nil {
+			err := writeEntry(w, outputRedeemScript, nil, out.RedeemScript)
+			if err != nil {
+				return err
+			}
+		}
+		for _, d := range out.Bip32Derivations {
+			err := writeEntry(w, outputBip32Derivation, d.PubKey,
+				serializeDerivation(d))
+			if err != nil {
+				return err
+			}
+		}
+		if err := writeSeparator(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// entry is a decoded map entry.
+type entry struct {
+	keyType byte
+	keyData []byte
+	value   []byte
+}
+
+// readMap decodes the entries of a map up to and including its terminator.  It
+// returns an error when the same key appears more than once.
+func readMap(r io.Reader) ([]entry, error) {
+	var entries []entry
+	seen := make(map[string]struct{})
+	for {
+		key, err := wire.ReadVarBytes(r, 0, maxEntrySize, "key")
+		if err != nil {
+			str := fmt.Sprintf("unable to read key: %v", err)
+			return nil, makeError(ErrMalformedPacket, str)
+		}
+		if len(key) == 0 {
+			return entries, nil
+		}
+		if _, ok := seen[string(key)]; ok {
+			str := fmt.Sprintf("duplicate key %x", key)
+			return nil, makeError(ErrDuplicateKey, str)
+		}
+		seen[string(key)] = struct{}{}
+
+		value, err := wire.ReadVarBytes(r, 0, maxEntrySize, "value")
+		if err != nil {
+			str := fmt.Sprintf("unable to read value for key %x: %v", key,
+				err)
+			return nil, makeError(ErrMalformedPacket, str)
+		}
+		entries = append(entries, entry{
+			keyType: key[0],
+			keyData: key[1:],
+			value:   value,
+		})
+	}
+}
+
+// malformedEntry returns an error for an entry with the provided description
+// that can not be decoded.
+func malformedEntry(what string, e *entry) error {
+	str := fmt.Sprintf("malformed %s entry (key type %#02x)", what, e.keyType)
+	return makeError(ErrMalformedPacket, str)
+}
+
+// deserializePrevOutput decodes the value of a previous output entry.
+func deserializePrevOutput(value []byte) (*PrevOutput, error) {
+	r := bytes.NewReader(value)
+	coinType, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	prevOut := &PrevOutput{CoinType: cointype.CoinType(coinType)}
+	if prevOut.CoinType.IsSKA() {
+		amount, err := wire.ReadVarBytes(r, 0, maxEntrySize, "amount")
+		if err != nil {
+			return nil, err
+		}
+		prevOut.SKAValue = new(big.Int).SetBytes(amount)
+	} else {
+		var amount [8]byte
+		if _, err := io.ReadFull(r, amount[:]); err != nil {
+			return nil, err
+		}
+		prevOut.Value = int64(binary.LittleEndian.Uint64(amount[:]))
+	}
+	var version [2]byte
+	if _, err := io.ReadFull(r, version[:]); err != nil {
+		return nil, err
+	}
+	prevOut.Version = binary.LittleEndian.Uint16(version[:])
+	prevOut.PkScript, err = wire.ReadVarBytes(r, 0, maxEntrySize, "pkscript")
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%d trailing bytes", r.Len())
+	}
+	return prevOut, nil
+}
+
+// deserializeDerivation decodes the value of a derivation path entry.
+func deserializeDerivation(e *entry) (*Bip32Derivation, error) {
+	if len(e.keyData) == 0 || len(e.value) < 4 || len(e.value)%4 != 0 {
+		return nil, malformedEntry("derivation path", e)
+	}
+	d := &Bip32Derivation{
+		PubKey:               e.keyData,
+		MasterKeyFingerprint: binary.LittleEndian.Uint32(e.value),
+		Path:                 make([]uint32, len(e.value)/4-1),
+	}
+	for i := range d.Path {
+		d.Path[i] = binary.LittleEndian.Uint32(e.value[4*(i+1):])
+	}
+	return d, nil
+}
+
+// Deserialize decodes a packet from the provided reader into the receiver.
+func (p *Packet) Deserialize(r io.Reader) error {
+	var header [len(magic) + 1]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		str := fmt.Sprintf("unable to read header: %v", err)
+		return makeError(ErrMalformedPacket, str)
+	}
+	if !bytes.Equal(header[:len(magic)], magic[:]) {
+		str := fmt.Sprintf("invalid magic %x", header[:len(magic)])
+		return makeError(ErrInvalidMagic, str)
+	}
+	if version := header[len(magic)]; version != Version {
+		str := fmt.Sprintf("unsupported version %d", version)
+		return makeError(ErrUnsupportedVersion, str)
+	}
+
+	// Global map.
+	entries, err := readMap(r)
+	if err != nil {
+		return err
+	}
+	var tx *wire.MsgTx
+	for i := range entries {
+		e := &entries[i]
+		if e.keyType != globalUnsignedTx {
+			continue
+		}
+		if len(e.keyData) != 0 {
+			return malformedEntry("unsigned transaction", e)
+		}
+		tx = new(wire.MsgTx)
+		txReader := bytes.NewReader(e.value)
+		if err := tx.Deserialize(txReader); err != nil {
+			str := fmt.Sprintf("unable to decode unsigned transaction: %v",
+				err)
+			return makeError(ErrMalformedPacket, str)
+		}
+		if txReader.Len() != 0 {
+			return malformedEntry("unsigned transaction", e)
+		}
+	}
+	if tx == nil {
+		return makeError(ErrMalformedPacket, "missing unsigned transaction")
+	}
+	packet, err := New(tx)
+	if err != nil {
+		return err
+	}
+
+	// Input maps.
+	for i := range packet.Inputs {
+		entries, err := readMap(r)
+		if err != nil {
+			return err
+		}
+		in := &packet.Inputs[i]
+		for j := range entries {
+			e := &entries[j]
+			switch e.keyType {
+			case inputPrevOutput:
+				if len(e.keyData) != 0 {
+					return malformedEntry("previous output", e)
+				}
+				in.PrevOutput, err = deserializePrevOutput(e.value)
+				if err != nil {
+					return malformedEntry("previous output", e)
+				}
+
+			case inputRedeemScript:
+				if len(e.keyData) != 0 {
+					return malformedEntry("redeem script", e)
+				}
+				in.RedeemScript = e.value
+
+			case inputSigHashType:
+				if len(e.keyData) != 0 || len(e.value) != 4 {
+					return malformedEntry("signature hash type", e)
+				}
+				hashType := binary.LittleEndian.Uint32(e.value)
+				in.SigHashType = txscript.SigHashType(hashType)
+
+			case inputPartialSig:
+				if len(e.keyData) == 0 || len(e.value) == 0 {
+					return malformedEntry("partial signature", e)
+				}
+				in.PartialSigs = append(in.PartialSigs, &PartialSig{
+					PubKey:    e.keyData,
+					Signature: e.value,
+				})
+
+			case inputBip32Derivation:
+				d, err := deserializeDerivation(e)
+				if err != nil {
+					return err
+				}
+				in.Bip32Derivations = append(in.Bip32Derivations, d)
+
+			case inputFinalScriptSig:
+				if len(e.keyData) != 0 {
+					return malformedEntry("final signature script", e)
+				}
+				in.FinalScriptSig = e.value
+			}
+		}
+	}
+
+	// Output maps.
+	for i := range packet.Outputs {
+		entries, err := readMap(r)
+		if err != nil {
+			return err
+		}
+		out := &packet.Outputs[i]
+		for j := range entries {
+			e := &entries[j]
+			switch e.keyType {
+			case outputRedeemScript:
+				if len(e.keyData) != 0 {
+					return malformedEntry("redeem script", e)
+				}
+				out.RedeemScript = e.value
+
+			case outputBip32Derivation:
+				d, err := deserializeDerivation(e)
+				if err != nil {
+					return err
+				}
+				out.Bip32Derivations = append(out.Bip32Derivations, d)
+			}
+		}
+	}
+
+	*p = *packet
+	return nil
+}
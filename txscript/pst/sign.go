@@ -0,0 +1,438 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package pst
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/monetarium/monetarium-node/dcrec"
+	"github.com/monetarium/monetarium-node/dcrec/edwards"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+	"github.com/monetarium/monetarium-node/txscript"
+	"github.com/monetarium/monetarium-node/txscript/sign"
+	"github.com/monetarium/monetarium-node/txscript/stdaddr"
+	"github.com/monetarium/monetarium-node/txscript/stdscript"
+	"github.com/monetarium/monetarium-node/wire"
+)
+
+// signableInput returns the input at the provided index after ensuring its
+// previous output is known.
+func (p *Packet) signableInput(idx int) (*Input, error) {
+	if err := p.checkInputIndex(idx); err != nil {
+		return nil, err
+	}
+	in := &p.Inputs[idx]
+	if in.PrevOutput == nil {
+		str := fmt.Sprintf("previous output for input %d is not known", idx)
+		return nil, makeError(ErrMissingPrevOut, str)
+	}
+	return in, nil
+}
+
+// pubKeyForPrivKey returns the serialized public key that is associated with
+// the provided private key of the given signature type.
+func pubKeyForPrivKey(privKey []byte, sigType dcrec.SignatureType) ([]byte, error) {
+	switch sigType {
+	case dcrec.STEcdsaSecp256k1, dcrec.STSchnorrSecp256k1:
+		priv := secp256k1.PrivKeyFromBytes(privKey)
+		return priv.PubKey().SerializeCompressed(), nil
+	case dcrec.STEd25519:
+		_, pub := edwards.PrivKeyFromBytes(privKey)
+		if pub == nil {
+			return nil, fmt.Errorf("invalid privkey")
+		}
+		return pub.Serialize(), nil
+	}
+	return nil, fmt.Errorf("unsupported signature type '%v'", sigType)
+}
+
+// Sign adds a partial signature for the input at the provided index that is
+// created with the given private key, signature type, and signature hash type.
+// The signature hash type must match the one requested by the input, if any.
+//
+// Signatures with the SigHashCoinType flag set commit to the previous outputs
+// of all inputs, so they must all be known in that case.
+func (p *Packet) Sign(idx int, privKey []byte, sigType dcrec.SignatureType,
+	hashType txscript.SigHashType) error {
+
+	in, err := p.signableInput(idx)
+	if err != nil {
+		return err
+	}
+	if in.SigHashType != 0 && in.SigHashType != hashType {
+		str := fmt.Sprintf("input %d requests signature hash type %#x, "+
+			"not %#x", idx, in.SigHashType, hashType)
+		return makeError(ErrConflictingData, str)
+	}
+
+	subScript := in.PrevOutput.PkScript
+	if in.RedeemScript != nil {
+		subScript = in.RedeemScript
+	}
+
+	var sig []byte
+	if hashType&txscript.SigHashCoinType != 0 {
+		prevOuts, err := p.prevOutputs()
+		if err != nil {
+			return err
+		}
+		sig, err = sign.RawTxInSignatureWithPrevOuts(p.UnsignedTx, idx,
+			subScript, hashType, privKey, sigType, prevOuts)
+		if err != nil {
+			return err
+		}
+	} else {
+		sig, err = sign.RawTxInSignature(p.UnsignedTx, idx, subScript,
+			hashType, privKey, sigType)
+		if err != nil {
+			return err
+		}
+	}
+
+	pubKey, err := pubKeyForPrivKey(privKey, sigType)
+	if err != nil {
+		return err
+	}
+	in.addPartialSig(&PartialSig{PubKey: pubKey, Signature: sig})
+	return nil
+}
+
+// SignTxOutput signs the input at the provided index with any keys and scripts
+// available from the given databases via sign.SignTxOutput and stores the
+// result, merged with any existing final signature script, as the final
+// signature script of the input.  It is intended for inputs that a single
+// party is able to completely sign.
+//
+// The SigHashCoinType flag is not supported by this method.  Use Sign to create
+// such signatures instead.
+func (p *Packet) SignTxOutput(idx int, chainParams stdaddr.AddressParams,
+	hashType txscript.SigHashType, kdb sign.KeyDB, sdb sign.ScriptDB,
+	isTreasuryEnabled bool) error {
+
+	in, err := p.signableInput(idx)
+	if err != nil {
+		return err
+	}
+	sigScript, err := sign.SignTxOutput(chainParams, p.UnsignedTx, idx,
+		in.PrevOutput.PkScript, hashType, kdb, sdb, in.FinalScriptSig,
+		isTreasuryEnabled)
+	if err != nil {
+		return err
+	}
+	in.FinalScriptSig = sigScript
+	return nil
+}
+
+// addPartialSig adds the provided partial signature to the input unless it
+// already has a signature for the same public key.
+func (i *Input) addPartialSig(sig *PartialSig) {
+	for _, existing := range i.PartialSigs {
+		if bytes.Equal(existing.PubKey, sig.PubKey) {
+			return
+		}
+	}
+	i.PartialSigs = append(i.PartialSigs, sig)
+}
+
+// addDerivation adds the provided derivation path to the given slice unless it
+// already has one for the same public key.
+func addDerivation(ds []*Bip32Derivation, d *Bip32Derivation) []*Bip32Derivation {
+	for _, existing := range ds {
+		if bytes.Equal(existing.PubKey, d.PubKey) {
+			return ds
+		}
+	}
+	return append(ds, d)
+}
+
+// partialSigFor returns the signature of the input for the provided public key
+// or nil if there is none.
+func (i *Input) partialSigFor(pubKey []byte) []byte {
+	for _, sig := range i.PartialSigs {
+		if bytes.Equal(sig.PubKey, pubKey) {
+			return sig.Signature
+		}
+	}
+	return nil
+}
+
+// isScriptHashType returns whether or not the provided script type requires a
+// redeem script to spend.
+func isScriptHashType(scriptType stdscript.ScriptType) bool {
+	switch scriptType {
+	case stdscript.STScriptHash,
+		stdscript.STStakeSubmissionScriptHash,
+		stdscript.STStakeGenScriptHash,
+		stdscript.STStakeRevocationScriptHash,
+		stdscript.STStakeChangeScriptHash,
+		stdscript.STTreasuryGenScriptHash:
+		return true
+	}
+	return false
+}
+
+// isPubKeyHashType returns whether or not the provided script type requires a
+// public key that hashes to a value in the script along with a signature.
+func isPubKeyHashType(scriptType stdscript.ScriptType) bool {
+	switch scriptType {
+	case stdscript.STPubKeyHashEcdsaSecp256k1,
+		stdscript.STPubKeyHashEd25519,
+		stdscript.STPubKeyHashSchnorrSecp256k1,
+		stdscript.STStakeSubmissionPubKeyHash,
+		stdscript.STStakeGenPubKeyHash,
+		stdscript.STStakeRevocationPubKeyHash,
+		stdscript.STStakeChangePubKeyHash,
+		stdscript.STTreasuryGenPubKeyHash:
+		return true
+	}
+	return false
+}
+
+// finalSigScript builds a signature script that satisfies the provided
+// non-script-hash script using the partial signatures of the input.
+func (i *Input) finalSigScript(scriptVersion uint16, script []byte) ([]byte, error) {
+	builder := txscript.NewScriptBuilder()
+	scriptType := stdscript.DetermineScriptType(scriptVersion, script)
+	switch {
+	case isPubKeyHashType(scriptType):
+		// The public key hash is the only 20-byte push in all of the
+		// supported public key hash scripts, so the signature for the public
+		// key that hashes to a value in the script satisfies it.
+		for _, sig := range i.PartialSigs {
+			if bytes.Contains(script, stdaddr.Hash160(sig.PubKey)) {
+				builder.AddData(sig.Signature).AddData(sig.PubKey)
+				return builder.Script()
+			}
+		}
+
+	case scriptType == stdscript.STPubKeyEcdsaSecp256k1:
+		pubKey := stdscript.ExtractPubKeyV0(script)
+		if sig := i.partialSigFor(pubKey); sig != nil {
+			return builder.AddData(sig).Script()
+		}
+
+	case scriptType == stdscript.STPubKeyEd25519:
+		pubKey := stdscript.ExtractPubKeyEd25519V0(script)
+		if sig := i.partialSigFor(pubKey); sig != nil {
+			return builder.AddData(sig).Script()
+		}
+
+	case scriptType == stdscript.STPubKeySchnorrSecp256k1:
+		pubKey := stdscript.ExtractPubKeySchnorrSecp256k1V0(script)
+		if sig := i.partialSigFor(pubKey); sig != nil {
+			return builder.AddData(sig).Script()
+		}
+
+	case scriptType == stdscript.STMultiSig:
+		// Signatures must be provided in the same order as the public keys
+		// they are for.
+		details := stdscript.ExtractMultiSigScriptDetailsV0(script, true)
+		var numSigs uint16
+		for _, pubKey := range details.PubKeys {
+			if sig := i.partialSigFor(pubKey); sig != nil {
+				builder.AddData(sig)
+				numSigs++
+				if numSigs == details.RequiredSigs {
+					return builder.Script()
+				}
+			}
+		}
+
+	default:
+		str := fmt.Sprintf("unable to finalize %v script", scriptType)
+		return nil, makeError(ErrUnsupportedScript, str)
+	}
+
+	str := fmt.Sprintf("not enough signatures for %v script", scriptType)
+	return nil, makeError(ErrMissingSignatures, str)
+}
+
+// Finalize builds the final signature script for the input at the provided
+// index from its partial signatures and redeem script.  The partial
+// signatures, signature hash type, redeem script, and derivation paths are
+// removed from the input once it is finalized since they are no longer needed.
+// Inputs that are already finalized are left unchanged.
+//
+// Finalize does not verify the signatures.  The transaction returned by
+// Extract must be validated prior to being relied upon.
+func (p *Packet) Finalize(idx int) error {
+	in, err := p.signableInput(idx)
+	if err != nil {
+		return err
+	}
+	if in.IsFinalized() {
+		return nil
+	}
+
+	prevOut := in.PrevOutput
+	scriptType := stdscript.DetermineScriptType(prevOut.Version,
+		prevOut.PkScript)
+	var sigScript []byte
+	if isScriptHashType(scriptType) {
+		if in.RedeemScript == nil {
+			str := fmt.Sprintf("missing redeem script for input %d", idx)
+			return makeError(ErrMissingRedeemScript, str)
+		}
+		sigScript, err = in.finalSigScript(prevOut.Version, in.RedeemScript)
+		if err != nil {
+			return err
+		}
+		builder := txscript.NewScriptBuilder()
+		builder.AddOps(sigScript).AddData(in.RedeemScript)
+		sigScript, err = builder.Script()
+	} else {
+		sigScript, err = in.finalSigScript(prevOut.Version, prevOut.PkScript)
+	}
+	if err != nil {
+		return err
+	}
+
+	in.FinalScriptSig = sigScript
+	in.PartialSigs = nil
+	in.SigHashType = 0
+	in.RedeemScript = nil
+	in.Bip32Derivations = nil
+	return nil
+}
+
+// Extract returns the fully signed transaction of a packet for which every
+// input is finalized.  The witness value of each input is set to the amount of
+// the previous output it spends.
+func (p *Packet) Extract() (*wire.MsgTx, error) {
+	tx := p.UnsignedTx.Copy()
+	for i := range p.Inputs {
+		in := &p.Inputs[i]
+		if !in.IsFinalized() {
+			str := fmt.Sprintf("input %d is not finalized", i)
+			return nil, makeError(ErrNotFinalized, str)
+		}
+		txIn := tx.TxIn[i]
+		txIn.SignatureScript = in.FinalScriptSig
+		if prevOut := in.PrevOutput; prevOut != nil {
+			if prevOut.CoinType.IsSKA() {
+				if prevOut.SKAValue != nil {
+					txIn.SKAValueIn = new(big.Int).Set(prevOut.SKAValue)
+				}
+			} else {
+				txIn.ValueIn = prevOut.Value
+			}
+		}
+	}
+	return tx, nil
+}
+
+// Combine returns a new packet that merges the information of all provided
+// packets, which must be for the same transaction.  An error is returned when
+// the packets contain different values for the same field.
+func Combine(packets ...*Packet) (*Packet, error) {
+	if len(packets) == 0 {
+		return nil, makeError(ErrMalformedPacket, "no packets to combine")
+	}
+
+	// Start with a deep copy of the first packet so that none of the
+	// provided packets are modified.
+	var buf bytes.Buffer
+	if err := packets[0].Serialize(&buf); err != nil {
+		return nil, err
+	}
+	result, err := Parse(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	txHash := result.UnsignedTx.TxHash()
+	for _, packet := range packets[1:] {
+		if packet.UnsignedTx.TxHash() != txHash ||
+			len(packet.Inputs) != len(result.Inputs) ||
+			len(packet.Outputs) != len(result.Outputs) {
+
+			str := fmt.Sprintf("packet for transaction %v can not be "+
+				"combined with packet for transaction %v",
+				packet.UnsignedTx.TxHash(), txHash)
+			return nil, makeError(ErrMismatchedTx, str)
+		}
+
+		for i := range packet.Inputs {
+			if err := result.Inputs[i].merge(&packet.Inputs[i]); err != nil {
+				return nil, fmt.Errorf("input %d: %w", i, err)
+			}
+		}
+		for i := range packet.Outputs {
+			if err := result.Outputs[i].merge(&packet.Outputs[i]); err != nil {
+				return nil, fmt.Errorf("output %d: %w", i, err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// conflictError returns an error for a field with conflicting values.
+func conflictError(field string) error {
+	str := fmt.Sprintf("conflicting %s", field)
+	return makeError(ErrConflictingData, str)
+}
+
+// merge merges the information of the other input into the receiver.
+func (i *Input) merge(other *Input) error {
+	if other.PrevOutput != nil {
+		if i.PrevOutput == nil {
+			prevOut := *other.PrevOutput
+			i.PrevOutput = &prevOut
+		} else {
+			a, err := serializePrevOutput(i.PrevOutput)
+			if err != nil {
+				return err
+			}
+			b, err := serializePrevOutput(other.PrevOutput)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(a, b) {
+				return conflictError("previous output")
+			}
+		}
+	}
+	if other.RedeemScript != nil {
+		if i.RedeemScript == nil {
+			i.RedeemScript = other.RedeemScript
+		} else if !bytes.Equal(i.RedeemScript, other.RedeemScript) {
+			return conflictError("redeem script")
+		}
+	}
+	if other.SigHashType != 0 {
+		if i.SigHashType == 0 {
+			i.SigHashType = other.SigHashType
+		} else if i.SigHashType != other.SigHashType {
+			return conflictError("signature hash type")
+		}
+	}
+	for _, sig := range other.PartialSigs {
+		i.addPartialSig(sig)
+	}
+	for _, d := range other.Bip32Derivations {
+		i.Bip32Derivations = addDerivation(i.Bip32Derivations, d)
+	}
+	if i.FinalScriptSig == nil {
+		i.FinalScriptSig = other.FinalScriptSig
+	}
+	return nil
+}
+
+// merge merges the information of the other output into the receiver.
+func (o *Output) merge(other *Output) error {
+	if other.RedeemScript != nil {
+		if o.RedeemScript == nil {
+			o.RedeemScript = other.RedeemScript
+		} else if !bytes.Equal(o.RedeemScript, other.RedeemScript) {
+			return conflictError("redeem script")
+		}
+	}
+	for _, d := range other.Bip32Derivations {
+		o.Bip32Derivations = addDerivation(o.Bip32Derivations, d)
+	}
+	return nil
+}
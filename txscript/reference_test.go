@@ -11,12 +11,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
 	"github.com/monetarium/monetarium-node/wire"
 )
 
@@ -69,6 +72,8 @@ func parseScriptFlags(flagStr string) (ScriptFlags, error) {
 			flags |= ScriptVerifySHA256
 		case "TREASURY":
 			flags |= ScriptVerifyTreasury
+		case "INTROSPECTION":
+			flags |= ScriptVerifyIntrospection
 		default:
 			return flags, fmt.Errorf("invalid flag: %s", flag)
 		}
@@ -107,6 +112,8 @@ func parseExpectedResult(expected string) ([]ErrorKind, error) {
 		return []ErrorKind{ErrVerify}, nil
 	case "ERR_EQUAL_VERIFY":
 		return []ErrorKind{ErrEqualVerify}, nil
+	case "ERR_NUM_EQUAL_VERIFY":
+		return []ErrorKind{ErrNumEqualVerify}, nil
 	case "ERR_DISABLED_OPCODE":
 		return []ErrorKind{ErrDisabledOpcode}, nil
 	case "ERR_RESERVED_OPCODE":
@@ -181,6 +188,10 @@ func parseExpectedResult(expected string) ([]ErrorKind, error) {
 		return []ErrorKind{ErrNegativeLockTime}, nil
 	case "ERR_UNSATISFIED_LOCKTIME":
 		return []ErrorKind{ErrUnsatisfiedLockTime}, nil
+	case "ERR_INVALID_OUTPUT_INDEX":
+		return []ErrorKind{ErrInvalidOutputIndex}, nil
+	case "ERR_MISSING_PREVOUTS":
+		return []ErrorKind{ErrMissingPrevOuts}, nil
 	}
 
 	return nil, fmt.Errorf("unrecognized expected result in test data: %v",
@@ -435,6 +446,155 @@ func TestScripts(t *testing.T) {
 	testScripts(t, tests, false)
 }
 
+// parseTestOutput parses the provided output string from the "cointype:amount"
+// format used in the introspection reference tests into an output that pays
+// the amount in the coin type.
+func parseTestOutput(output string) (*wire.TxOut, error) {
+	ctStr, amountStr, ok := strings.Cut(output, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid output: %q", output)
+	}
+	ct, err := strconv.ParseUint(ctStr, 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output coin type %q: %w", ctStr, err)
+	}
+	coinType := cointype.CoinType(ct)
+	amount, ok := new(big.Int).SetString(amountStr, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid output amount: %q", amountStr)
+	}
+
+	txOut := &wire.TxOut{CoinType: coinType}
+	if coinType.IsSKA() {
+		txOut.SKAValue = amount
+	} else {
+		txOut.Value = amount.Int64()
+	}
+	return txOut, nil
+}
+
+// TestIntrospectionScripts ensures all of the tests in introspection_tests.json
+// execute with the expected results as defined in the test data.
+func TestIntrospectionScripts(t *testing.T) {
+	file, err := os.ReadFile(filepath.Join(testDataPath,
+		"introspection_tests.json"))
+	if err != nil {
+		t.Fatalf("TestIntrospectionScripts: %v\n", err)
+	}
+
+	var tests [][]string
+	err = json.Unmarshal(file, &tests)
+	if err != nil {
+		t.Fatalf("TestIntrospectionScripts failed to unmarshal: %v", err)
+	}
+
+	// "Format is: [scriptSig, scriptPubKey, flags, spentOutput, outputs,
+	//   expectedScriptError, ... comments]"
+	for i, test := range tests {
+		// Skip single line comments.
+		if len(test) == 1 {
+			continue
+		}
+		if len(test) < 6 {
+			t.Errorf("invalid test #%d: wrong number of fields", i)
+			continue
+		}
+
+		// Construct a name for the test based on the comment and test data.
+		name := fmt.Sprintf("test ([%s, %s, %s, %s, %s])", test[0], test[1],
+			test[2], test[3], test[4])
+		if len(test) > 6 {
+			name = fmt.Sprintf("test (%s)", test[len(test)-1])
+		}
+
+		// Extract and parse the signature and public key scripts, script
+		// flags, and expected result from the test fields.
+		scriptSig, err := parseShortFormV0(test[0])
+		if err != nil {
+			t.Errorf("%s: can't parse scriptSig; %v", name, err)
+			continue
+		}
+		scriptPubKey, err := parseShortFormV0(test[1])
+		if err != nil {
+			t.Errorf("%s: can't parse scriptPubkey; %v", name, err)
+			continue
+		}
+		flags, err := parseScriptFlags(test[2])
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		resultStr := test[5]
+		allowErrorKinds, err := parseExpectedResult(resultStr)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+
+		// Generate a transaction that spends the described output to the
+		// described outputs.  The details of the spent output are not
+		// provided to the engine when it is empty.
+		tx := createSpendingTx(scriptSig, scriptPubKey)
+		var prevOuts []PrevOutput
+		if test[3] != "" {
+			spent, err := parseTestOutput(test[3])
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			prevOuts = []PrevOutput{{
+				CoinType: spent.CoinType,
+				Value:    spent.Value,
+				SKAValue: spent.SKAValue,
+				Version:  IntrospectionScriptVersion,
+			}}
+		}
+		tx.TxOut = nil
+		var parseErr error
+		for _, output := range strings.Fields(test[4]) {
+			txOut, err := parseTestOutput(output)
+			if err != nil {
+				parseErr = err
+				break
+			}
+			tx.AddTxOut(txOut)
+		}
+		if parseErr != nil {
+			t.Errorf("%s: %v", name, parseErr)
+			continue
+		}
+
+		// Execute the scripts as version 1 scripts.
+		vm, err := NewEngineWithPrevOuts(scriptPubKey, tx, 0, flags,
+			IntrospectionScriptVersion, nil, prevOuts)
+		if err == nil {
+			err = vm.Execute()
+		}
+
+		// Ensure there were no errors when the expected result is OK.
+		if resultStr == "OK" {
+			if err != nil {
+				t.Errorf("%s failed to execute: %v", name, err)
+			}
+			continue
+		}
+
+		// At this point an error was expected so ensure the result of the
+		// execution matches it.
+		success := false
+		for _, kind := range allowErrorKinds {
+			if errors.Is(err, kind) {
+				success = true
+				break
+			}
+		}
+		if !success {
+			t.Errorf("%s: want error kinds %v, got err: %v (%T)", name,
+				allowErrorKinds, err, err)
+		}
+	}
+}
+
 // testVecF64ToUint32 properly handles conversion of float64s read from the JSON
 // test data to unsigned 32-bit integers.  This is necessary because some of the
 // test data uses -1 as a shortcut to mean max uint32 and direct conversion of a
@@ -7,6 +7,7 @@ package txscript
 
 import (
 	"fmt"
+	"math/big"
 )
 
 const (
@@ -41,6 +42,15 @@ const (
 	// beyond the current sequence limit.
 	CsvMaxScriptNumLen = 5
 
+	// AmountMaxScriptNumLen is the maximum number of bytes data being
+	// interpreted as an integer may be for the numeric comparison opcodes in
+	// version 1 scripts and the amounts pushed by the introspection opcodes.
+	//
+	// The value comes from the fact that the maximum SKA amount of 9*10^32
+	// atoms requires 110 bits.  Thus, a 16-byte script number, which supports
+	// up to 2^127-1, is large enough to represent any valid amount.
+	AmountMaxScriptNumLen = 16
+
 	// altSigSuitesMaxscriptNumLen is the maximum number of bytes for the
 	// type of alternative signature suite.
 	altSigSuitesMaxscriptNumLen = 1
@@ -251,3 +261,76 @@ func MakeScriptNum(v []byte, scriptNumLen int) (ScriptNum, error) {
 
 	return ScriptNum(result), nil
 }
+
+// bigScriptNumBytes returns the passed integer serialized as a little endian
+// with a sign bit in the same way as ScriptNum.Bytes, except that it supports
+// integers of arbitrary size.
+func bigScriptNumBytes(n *big.Int) []byte {
+	// Zero encodes as an empty byte slice.
+	if n.Sign() == 0 {
+		return nil
+	}
+
+	// Encode the absolute value to little endian.
+	result := n.Bytes()
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+
+	// Add an additional high byte to indicate the sign when the most
+	// significant byte already has the high bit set or set the high bit of the
+	// most significant byte to indicate the value is negative otherwise.  See
+	// ScriptNum.Bytes for more details.
+	isNegative := n.Sign() < 0
+	if result[len(result)-1]&0x80 != 0 {
+		extraByte := byte(0x00)
+		if isNegative {
+			extraByte = 0x80
+		}
+		result = append(result, extraByte)
+	} else if isNegative {
+		result[len(result)-1] |= 0x80
+	}
+
+	return result
+}
+
+// makeBigScriptNum interprets the passed serialized bytes as an encoded integer
+// and returns the result as a big integer.  It enforces the same maximum length
+// and minimal encoding requirements as MakeScriptNum, but, unlike it, supports
+// lengths that exceed the range of an int64, such as AmountMaxScriptNumLen.
+func makeBigScriptNum(v []byte, scriptNumLen int) (*big.Int, error) {
+	// Interpreting data requires that it is not larger than the passed
+	// scriptNumLen value.
+	if len(v) > scriptNumLen {
+		str := fmt.Sprintf("numeric value encoded as %x is %d bytes "+
+			"which exceeds the max allowed of %d", v, len(v),
+			scriptNumLen)
+		return nil, scriptError(ErrNumOutOfRange, str)
+	}
+
+	// Enforce minimal encoding.
+	if err := checkMinimalDataEncoding(v); err != nil {
+		return nil, err
+	}
+
+	// Zero is encoded as an empty byte slice.
+	result := new(big.Int)
+	if len(v) == 0 {
+		return result, nil
+	}
+
+	// Decode from little endian while removing the sign bit from the most
+	// significant byte.
+	be := make([]byte, len(v))
+	for i, val := range v {
+		be[len(v)-1-i] = val
+	}
+	isNegative := be[0]&0x80 != 0
+	be[0] &= 0x7f
+	result.SetBytes(be)
+	if isNegative {
+		result.Neg(result)
+	}
+	return result, nil
+}
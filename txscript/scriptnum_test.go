@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"errors"
+	"math/big"
 	"testing"
 )
 
@@ -190,6 +191,134 @@ func TestMakeScriptNum(t *testing.T) {
 	}
 }
 
+// TestBigScriptNum ensures that converting between arbitrarily sized integers
+// and their byte representations works as expected and matches the behavior of
+// script numbers for values that fit in them.
+func TestBigScriptNum(t *testing.T) {
+	t.Parallel()
+
+	// hexToBigInt converts the passed hex string into a big integer and will
+	// panic if there is an error.
+	hexToBigInt := func(s string) *big.Int {
+		n, ok := new(big.Int).SetString(s, 16)
+		if !ok {
+			panic("invalid hex in source file: " + s)
+		}
+		return n
+	}
+
+	tests := []struct {
+		name       string
+		num        *big.Int
+		serialized []byte
+		numLen     int
+		err        error
+	}{{
+		name:       "zero",
+		num:        big.NewInt(0),
+		serialized: nil,
+		numLen:     AmountMaxScriptNumLen,
+	}, {
+		name:       "-1",
+		num:        big.NewInt(-1),
+		serialized: hexToBytes("81"),
+		numLen:     AmountMaxScriptNumLen,
+	}, {
+		name:       "128 requires sign byte",
+		num:        big.NewInt(128),
+		serialized: hexToBytes("8000"),
+		numLen:     AmountMaxScriptNumLen,
+	}, {
+		name:       "-32768 requires sign byte",
+		num:        big.NewInt(-32768),
+		serialized: hexToBytes("008080"),
+		numLen:     AmountMaxScriptNumLen,
+	}, {
+		name:       "max VAR amount",
+		num:        big.NewInt(2100000000000000),
+		serialized: hexToBytes("0040075af07507"),
+		numLen:     AmountMaxScriptNumLen,
+	}, {
+		name:       "max int64",
+		num:        big.NewInt(9223372036854775807),
+		serialized: hexToBytes("ffffffffffffff7f"),
+		numLen:     AmountMaxScriptNumLen,
+	}, {
+		name:       "max SKA amount",
+		num:        hexToBigInt("2c5f98d74c37b3146b8900000000"),
+		serialized: hexToBytes("00000000896b14b3374cd7985f2c"),
+		numLen:     AmountMaxScriptNumLen,
+	}, {
+		name:       "max 16-byte value",
+		num:        hexToBigInt("7fffffffffffffffffffffffffffffff"),
+		serialized: hexToBytes("ffffffffffffffffffffffffffffff7f"),
+		numLen:     AmountMaxScriptNumLen,
+	}, {
+		name:       "min 16-byte value",
+		num:        hexToBigInt("-7fffffffffffffffffffffffffffffff"),
+		serialized: hexToBytes("ffffffffffffffffffffffffffffffff"),
+		numLen:     AmountMaxScriptNumLen,
+	}, {
+		name:       "2^127 exceeds 16 bytes",
+		num:        hexToBigInt("80000000000000000000000000000000"),
+		serialized: hexToBytes("0000000000000000000000000000008000"),
+		numLen:     AmountMaxScriptNumLen,
+		err:        ErrNumOutOfRange,
+	}, {
+		name:       "5 bytes exceeds 4-byte limit",
+		num:        big.NewInt(2147483648),
+		serialized: hexToBytes("0000008000"),
+		numLen:     MathOpCodeMaxScriptNumLen,
+		err:        ErrNumOutOfRange,
+	}}
+
+	for _, test := range tests {
+		gotBytes := bigScriptNumBytes(test.num)
+		if !bytes.Equal(gotBytes, test.serialized) {
+			t.Errorf("%q: did not get expected bytes - got %x, want %x",
+				test.name, gotBytes, test.serialized)
+			continue
+		}
+
+		// Ensure the encoding matches that of script numbers for values that
+		// fit in them.
+		if test.num.IsInt64() {
+			want := ScriptNum(test.num.Int64()).Bytes()
+			if !bytes.Equal(gotBytes, want) {
+				t.Errorf("%q: mismatched script num bytes - got %x, want %x",
+					test.name, gotBytes, want)
+				continue
+			}
+		}
+
+		gotNum, err := makeBigScriptNum(test.serialized, test.numLen)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%q: unexpected error - got %v, want %v", test.name,
+				err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if gotNum.Cmp(test.num) != 0 {
+			t.Errorf("%q: did not get expected number - got %v, want %v",
+				test.name, gotNum, test.num)
+			continue
+		}
+	}
+
+	// Ensure non-minimally encoded values are rejected.
+	nonMinimal := [][]byte{hexToBytes("00"), hexToBytes("80"),
+		hexToBytes("0100"), hexToBytes("ffffffffffffffffffffffffffffff0000")}
+	for _, serialized := range nonMinimal {
+		_, err := makeBigScriptNum(serialized, len(serialized))
+		if !errors.Is(err, ErrMinimalData) {
+			t.Errorf("makeBigScriptNum(%x): unexpected error - got %v, "+
+				"want %v", serialized, err, ErrMinimalData)
+		}
+	}
+}
+
 // TestScriptNumInt32 ensures that the Int32 function on script number behaves
 // as expected.
 func TestScriptNumInt32(t *testing.T) {
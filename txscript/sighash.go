@@ -9,8 +9,10 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"math/big"
 
 	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
 	"github.com/monetarium/monetarium-node/wire"
 )
 
@@ -24,6 +26,12 @@ const (
 	SigHashSingle       SigHashType = 0x3
 	SigHashAnyOneCanPay SigHashType = 0x80
 
+	// SigHashCoinType is a bit flag that can be combined with the other
+	// signature hash types to additionally commit to the coin type, amount,
+	// and script version of the outputs being spent.  It is only valid once
+	// the ScriptVerifyCoinTypeSigHash flag is active.
+	SigHashCoinType SigHashType = 0x40
+
 	// sigHashMask defines the number of bits of the hash type which is used
 	// to identify which outputs are signed.
 	sigHashMask = 0x1f
@@ -47,8 +55,42 @@ const (
 	// SigHashSerializeWitness indicates the serialization only contains
 	// witness data.
 	SigHashSerializeWitness = 3
+
+	// SigHashSerializePrevOuts indicates the serialization only contains
+	// the coin type, amount, and script version of the outputs being spent.
+	SigHashSerializePrevOuts = 4
 )
 
+// PrevOutput houses the details of a previous output spent by a transaction
+// input that are committed to by signatures with the SigHashCoinType flag set.
+// Inputs that do not spend a previous output, such as stakebase inputs, are
+// represented by the zero value.
+type PrevOutput struct {
+	// CoinType is the coin type of the output being spent.
+	CoinType cointype.CoinType
+
+	// Value is the amount of the output being spent in atoms when the coin
+	// type is VAR.
+	Value int64
+
+	// SKAValue is the amount of the output being spent in atoms when the
+	// coin type is SKA.
+	SKAValue *big.Int
+
+	// Version is the script version of the output being spent.
+	Version uint16
+}
+
+// sigHashAmountBytes returns the big-endian encoded amount of the provided
+// previous output when it is an SKA output.  It returns nil for VAR outputs
+// and SKA outputs without an amount.
+func (p *PrevOutput) sigHashAmountBytes() []byte {
+	if !p.CoinType.IsSKA() || p.SKAValue == nil {
+		return nil
+	}
+	return p.SKAValue.Bytes()
+}
+
 // -----------------------------------------------------------------------------
 // A variable length integer (varint) is an encoding for integers up to a max
 // value of 2^64-1 that uses a variable number of bytes depending on the value
@@ -221,12 +263,38 @@ func sigHashWitnessSerializeSize(txIns []*wire.TxIn, signScript []byte) int {
 		len(signScript)
 }
 
+// sigHashPrevOutsSerializeSize returns the number of bytes the passed
+// parameters would take when encoded with the format used by the previous
+// outputs hash portion of the overall signature hash.
+func sigHashPrevOutsSerializeSize(prevOuts []PrevOutput) int {
+	// 1) 4 bytes version/serialization type
+	// 2) number of inputs varint
+	// 3) per input:
+	//    a) 1 byte coin type
+	//    b) 8 bytes amount for VAR or amount len varint plus N bytes
+	//       amount for SKA
+	//    c) 2 bytes script version
+	numPrevOuts := len(prevOuts)
+	size := 4 + varIntSerializeSize(uint64(numPrevOuts)) + numPrevOuts*(1+2)
+	for i := range prevOuts {
+		prevOut := &prevOuts[i]
+		if !prevOut.CoinType.IsSKA() {
+			size += 8
+			continue
+		}
+		amount := prevOut.sigHashAmountBytes()
+		size += varIntSerializeSize(uint64(len(amount))) + len(amount)
+	}
+	return size
+}
+
 // calcSignatureHash computes the signature hash for the specified input of the
-// target transaction observing the desired signature hash type.  The cached
-// prefix parameter allows the caller to optimize the calculation by providing
-// the prefix hash to be reused in the case of SigHashAll without the
-// SigHashAnyOneCanPay flag set.
-func calcSignatureHash(signScript []byte, hashType SigHashType, tx *wire.MsgTx, idx int, cachedPrefix *chainhash.Hash) ([]byte, error) {
+// target transaction observing the desired signature hash type.  The previous
+// outputs must be provided when the hash type has the SigHashCoinType flag set
+// and are otherwise ignored.  The cached prefix parameter allows the caller to
+// optimize the calculation by providing the prefix hash to be reused in the
+// case of SigHashAll without the SigHashAnyOneCanPay flag set.
+func calcSignatureHash(signScript []byte, hashType SigHashType, tx *wire.MsgTx, idx int, prevOuts []PrevOutput, cachedPrefix *chainhash.Hash) ([]byte, error) {
 	// The SigHashSingle signature type signs only the corresponding input
 	// and output (the output with the same index number as the input).
 	//
@@ -239,6 +307,15 @@ func calcSignatureHash(signScript []byte, hashType SigHashType, tx *wire.MsgTx,
 		return nil, scriptError(ErrInvalidSigHashSingleIndex, str)
 	}
 
+	// The SigHashCoinType flag commits to the details of the outputs being
+	// spent, so they must be provided and correspond to the inputs.
+	commitPrevOuts := hashType&SigHashCoinType != 0
+	if commitPrevOuts && len(prevOuts) != len(tx.TxIn) {
+		str := fmt.Sprintf("attempt to commit to %d previous outputs for "+
+			"a transaction with %d inputs", len(prevOuts), len(tx.TxIn))
+		return nil, scriptError(ErrInvalidPrevOuts, str)
+	}
+
 	// Choose the inputs that will be committed to based on the signature
 	// hash type.
 	//
@@ -432,10 +509,63 @@ func calcSignatureHash(signScript []byte, hashType SigHashType, tx *wire.MsgTx,
 	// 1) the hash type (as little-endian uint32)
 	// 2) prefix hash (as produced by hash function)
 	// 3) witness hash (as produced by hash function)
-	sigHashBuf := make([]byte, chainhash.HashSize*2+4)
+	// 4) previous outputs hash (as produced by hash function) only when the
+	//    SigHashCoinType flag is set
+	if !commitPrevOuts {
+		sigHashBuf := make([]byte, chainhash.HashSize*2+4)
+		offset = putUint32LE(sigHashBuf, uint32(hashType))
+		offset += copy(sigHashBuf[offset:], prefixHash[:])
+		copy(sigHashBuf[offset:], witnessHash[:])
+		return chainhash.HashB(sigHashBuf), nil
+	}
+
+	// The previous outputs hash commits to the coin type, amount, and
+	// script version of the outputs spent by the relevant inputs.  Like the
+	// witness hash, the SigHashAnyOneCanPay flag restricts the commitment to
+	// only the output spent by the input being signed.  It consists of the
+	// hash of the serialization of the following fields:
+	//
+	// 1) txversion|(SigHashSerializePrevOuts<<16) (as little-endian uint32)
+	// 2) number of inputs (as varint)
+	// 3) per input:
+	//    a) coin type of the output being spent (as single byte)
+	//    b) amount of the output being spent (as little-endian uint64 for
+	//       VAR, or as a varint length followed by the big-endian magnitude
+	//       for SKA)
+	//    c) script version of the output being spent (as little-endian
+	//       uint16)
+	if hashType&SigHashAnyOneCanPay != 0 {
+		prevOuts = prevOuts[idx : idx+1]
+	}
+	size = sigHashPrevOutsSerializeSize(prevOuts)
+	prevOutsBuf := make([]byte, size)
+
+	// Commit to the version and hash serialization type.
+	version = uint32(tx.Version) | uint32(SigHashSerializePrevOuts)<<16
+	offset = putUint32LE(prevOutsBuf, version)
+
+	// Commit to the relevant previous outputs.
+	offset += putVarInt(prevOutsBuf[offset:], uint64(len(prevOuts)))
+	for i := range prevOuts {
+		prevOut := &prevOuts[i]
+		offset += putByte(prevOutsBuf[offset:], byte(prevOut.CoinType))
+		if prevOut.CoinType.IsSKA() {
+			amount := prevOut.sigHashAmountBytes()
+			offset += putVarInt(prevOutsBuf[offset:], uint64(len(amount)))
+			offset += copy(prevOutsBuf[offset:], amount)
+		} else {
+			offset += putUint64LE(prevOutsBuf[offset:], uint64(prevOut.Value))
+		}
+		offset += putUint16LE(prevOutsBuf[offset:], prevOut.Version)
+	}
+
+	prevOutsHash := chainhash.HashH(prevOutsBuf)
+
+	sigHashBuf := make([]byte, chainhash.HashSize*3+4)
 	offset = putUint32LE(sigHashBuf, uint32(hashType))
 	offset += copy(sigHashBuf[offset:], prefixHash[:])
-	copy(sigHashBuf[offset:], witnessHash[:])
+	offset += copy(sigHashBuf[offset:], witnessHash[:])
+	copy(sigHashBuf[offset:], prevOutsHash[:])
 	return chainhash.HashB(sigHashBuf), nil
 }
 
@@ -454,5 +584,25 @@ func CalcSignatureHash(script []byte, hashType SigHashType, tx *wire.MsgTx, idx
 		return nil, err
 	}
 
-	return calcSignatureHash(script, hashType, tx, idx, cachedPrefix)
+	return calcSignatureHash(script, hashType, tx, idx, nil, cachedPrefix)
+}
+
+// CalcSignatureHashWithPrevOuts computes the signature hash for the specified
+// input of the target transaction observing the desired signature hash type
+// in the same way as CalcSignatureHash.  The provided previous outputs must
+// correspond, in order, to the outputs spent by every input of the
+// transaction.  They are committed to when the hash type has the
+// SigHashCoinType flag set, which allows signers to verify the coin type and
+// amount of every output being spent from the transaction alone.
+//
+// NOTE: This function is only valid for version 0 scripts.  Since the function
+// does not accept a script version, the results are undefined for other script
+// versions.
+func CalcSignatureHashWithPrevOuts(script []byte, hashType SigHashType, tx *wire.MsgTx, idx int, prevOuts []PrevOutput, cachedPrefix *chainhash.Hash) ([]byte, error) {
+	const scriptVersion = 0
+	if err := checkScriptParses(scriptVersion, script); err != nil {
+		return nil, err
+	}
+
+	return calcSignatureHash(script, hashType, tx, idx, prevOuts, cachedPrefix)
 }
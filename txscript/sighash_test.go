@@ -7,9 +7,12 @@ package txscript
 
 import (
 	"bytes"
+	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
 	"github.com/monetarium/monetarium-node/wire"
 )
 
@@ -141,3 +144,119 @@ func TestCalcSignatureHash(t *testing.T) {
 			msg1, msg3)
 	}
 }
+
+// TestCalcSignatureHashWithPrevOuts ensures the signature hash only commits to
+// the previous outputs when the SigHashCoinType flag is set and that it
+// commits to the coin type, amount, and script version of each of them.
+func TestCalcSignatureHashWithPrevOuts(t *testing.T) {
+	tx := new(wire.MsgTx)
+	tx.SerType = wire.TxSerializeFull
+	tx.Version = 1
+	for i := 0; i < 2; i++ {
+		txIn := new(wire.TxIn)
+		txIn.Sequence = 0xFFFFFFFF
+		txIn.PreviousOutPoint.Hash = chainhash.HashH([]byte{byte(i)})
+		txIn.PreviousOutPoint.Index = uint32(i)
+		tx.AddTxIn(txIn)
+	}
+	txOut := new(wire.TxOut)
+	txOut.PkScript = hexToBytes("51")
+	txOut.Value = 0x0000FF00FF00FF00
+	tx.AddTxOut(txOut)
+
+	script := hexToBytes("51")
+	skaValue, _ := new(big.Int).SetString("1000000000000000000000000", 10)
+	prevOuts := []PrevOutput{{
+		CoinType: cointype.CoinTypeVAR,
+		Value:    0x0000FF00FF00FF00,
+	}, {
+		CoinType: cointype.CoinType(3),
+		SKAValue: skaValue,
+	}}
+
+	// The previous outputs must not change the hash without the flag.
+	legacy, err := CalcSignatureHash(script, SigHashAll, tx, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	got, err := CalcSignatureHashWithPrevOuts(script, SigHashAll, tx, 0,
+		prevOuts, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(got, legacy) {
+		t.Fatalf("previous outputs changed hash without flag -- got %x, "+
+			"want %x", got, legacy)
+	}
+
+	// The flag must result in a new hash.
+	const hashType = SigHashAll | SigHashCoinType
+	base, err := CalcSignatureHashWithPrevOuts(script, hashType, tx, 0,
+		prevOuts, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if bytes.Equal(base, legacy) {
+		t.Fatalf("flag did not change hash %x", base)
+	}
+
+	// Modifying any committed field of any previous output must result in a
+	// new hash.
+	tests := []struct {
+		name   string
+		modify func(prevOuts []PrevOutput)
+	}{{
+		name:   "other input coin type",
+		modify: func(p []PrevOutput) { p[1].CoinType = 1 },
+	}, {
+		name:   "other input SKA amount",
+		modify: func(p []PrevOutput) { p[1].SKAValue = big.NewInt(1) },
+	}, {
+		name:   "signed input amount",
+		modify: func(p []PrevOutput) { p[0].Value-- },
+	}, {
+		name:   "signed input script version",
+		modify: func(p []PrevOutput) { p[0].Version = 1 },
+	}}
+	for _, test := range tests {
+		modified := make([]PrevOutput, len(prevOuts))
+		copy(modified, prevOuts)
+		test.modify(modified)
+		got, err := CalcSignatureHashWithPrevOuts(script, hashType, tx, 0,
+			modified, nil)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.name, err)
+			continue
+		}
+		if bytes.Equal(got, base) {
+			t.Errorf("%q: hash did not change", test.name)
+		}
+	}
+
+	// Only the previous output of the signed input is committed to when the
+	// SigHashAnyOneCanPay flag is also set.
+	const anyHashType = hashType | SigHashAnyOneCanPay
+	anyBase, err := CalcSignatureHashWithPrevOuts(script, anyHashType, tx, 0,
+		prevOuts, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	modified := []PrevOutput{prevOuts[0], {CoinType: 1}}
+	got, err = CalcSignatureHashWithPrevOuts(script, anyHashType, tx, 0,
+		modified, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(got, anyBase) {
+		t.Fatalf("anyonecanpay hash committed to other input -- got %x, "+
+			"want %x", got, anyBase)
+	}
+
+	// Previous outputs that do not match the inputs must be rejected.
+	_, err = CalcSignatureHashWithPrevOuts(script, hashType, tx, 0,
+		prevOuts[:1], nil)
+	if !errors.Is(err, ErrInvalidPrevOuts) {
+		t.Fatalf("mismatched previous outputs -- got %v, want %v", err,
+			ErrInvalidPrevOuts)
+	}
+}
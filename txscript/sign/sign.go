@@ -35,6 +35,36 @@ func RawTxInSignature(tx *wire.MsgTx, idx int, subScript []byte,
 		return nil, err
 	}
 
+	return signHash(hash, hashType, key, sigType)
+}
+
+// RawTxInSignatureWithPrevOuts returns the serialized signature for the input
+// idx of the given transaction, with hashType appended to it, in the same way
+// as RawTxInSignature.  The provided previous outputs must correspond, in
+// order, to the outputs spent by every input of the transaction and are
+// committed to by the signature when hashType has the SigHashCoinType flag set.
+//
+// NOTE: This function is only valid for version 0 scripts.  Since the function
+// does not accept a script version, the results are undefined for other script
+// versions.
+func RawTxInSignatureWithPrevOuts(tx *wire.MsgTx, idx int, subScript []byte,
+	hashType txscript.SigHashType, key []byte, sigType dcrec.SignatureType,
+	prevOuts []txscript.PrevOutput) ([]byte, error) {
+
+	hash, err := txscript.CalcSignatureHashWithPrevOuts(subScript, hashType,
+		tx, idx, prevOuts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return signHash(hash, hashType, key, sigType)
+}
+
+// signHash returns the serialized signature of the provided signature hash
+// using the given private key and signature type, with hashType appended to it.
+func signHash(hash []byte, hashType txscript.SigHashType, key []byte,
+	sigType dcrec.SignatureType) ([]byte, error) {
+
 	var sigBytes []byte
 	switch sigType {
 	case dcrec.STEcdsaSecp256k1:
@@ -82,6 +112,34 @@ func SignatureScript(tx *wire.MsgTx, idx int, subscript []byte,
 		return nil, err
 	}
 
+	return sigPubKeyScript(sig, privKey, sigType, compress)
+}
+
+// SignatureScriptWithPrevOuts creates an input signature script for tx in the
+// same way as SignatureScript.  The provided previous outputs must correspond,
+// in order, to the outputs spent by every input of tx and are committed to by
+// the signature when hashType has the SigHashCoinType flag set.  This allows
+// offline and hardware signers to verify the coin type and amount being spent
+// from the transaction alone.
+func SignatureScriptWithPrevOuts(tx *wire.MsgTx, idx int, subscript []byte,
+	hashType txscript.SigHashType, privKey []byte,
+	sigType dcrec.SignatureType, compress bool,
+	prevOuts []txscript.PrevOutput) ([]byte, error) {
+
+	sig, err := RawTxInSignatureWithPrevOuts(tx, idx, subscript, hashType,
+		privKey, sigType, prevOuts)
+	if err != nil {
+		return nil, err
+	}
+
+	return sigPubKeyScript(sig, privKey, sigType, compress)
+}
+
+// sigPubKeyScript creates a signature script that pushes the provided
+// signature followed by the public key associated with the private key.
+func sigPubKeyScript(sig, privKey []byte, sigType dcrec.SignatureType,
+	compress bool) ([]byte, error) {
+
 	var pkData []byte
 	switch sigType {
 	case dcrec.STEcdsaSecp256k1:
@@ -631,13 +689,23 @@ func SignTxOutput(chainParams stdaddr.AddressParams, tx *wire.MsgTx, idx int,
 	return mergedScript, nil
 }
 
+// TSpendSignatureHash returns the signature hash that must be signed to
+// authorize the provided tx, which is expected to be a treasury spend
+// transaction, to spend coins from the treasury.
+//
+// This is primarily useful when the signature is produced by means other than
+// TSpendSignatureScript, such as by multiple parties that jointly control an
+// aggregate Pi key.
+func TSpendSignatureHash(msgTx *wire.MsgTx) ([]byte, error) {
+	return txscript.CalcSignatureHash(nil, txscript.SigHashAll, msgTx, 0, nil)
+}
+
 // TSpendSignatureScript creates an input signature for the provided tx, which
 // is expected to be a treasury spend transaction, to authorize coins to be
 // spent from the treasury.  The private key must correspond to one of the
 // valid public keys for a Pi instance recognized by consensus.
 func TSpendSignatureScript(msgTx *wire.MsgTx, privKey []byte) ([]byte, error) {
-	hash, err := txscript.CalcSignatureHash(nil, txscript.SigHashAll, msgTx, 0,
-		nil)
+	hash, err := TSpendSignatureHash(msgTx)
 	if err != nil {
 		return nil, err
 	}
@@ -647,8 +715,18 @@ func TSpendSignatureScript(msgTx *wire.MsgTx, privKey []byte) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot sign tx input: %w", err)
 	}
+	return TSpendSignatureScriptFromSig(sig, priv.PubKey())
+}
+
+// TSpendSignatureScriptFromSig creates an input signature script for a
+// treasury spend transaction from an existing Schnorr signature over the hash
+// returned by TSpendSignatureHash.  The public key must be one of the valid
+// public keys for a Pi instance recognized by consensus, which may be the
+// aggregate of several participants' keys when the signature was produced with
+// the musig2 package.
+func TSpendSignatureScriptFromSig(sig *schnorr.Signature, pubKey *secp256k1.PublicKey) ([]byte, error) {
 	sigBytes := sig.Serialize()
-	pkBytes := priv.PubKey().SerializeCompressed()
+	pkBytes := pubKey.SerializeCompressed()
 
 	return txscript.NewScriptBuilder().AddData(sigBytes).AddData(pkBytes).
 		AddOp(txscript.OP_TSPEND).Script()
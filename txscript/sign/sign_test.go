@@ -6,17 +6,21 @@
 package sign
 
 import (
+	"bytes"
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"math/big"
 	mrand "math/rand"
 	"testing"
 
 	"github.com/monetarium/monetarium-node/chaincfg"
 	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+	"github.com/monetarium/monetarium-node/cointype"
 	"github.com/monetarium/monetarium-node/dcrec"
 	"github.com/monetarium/monetarium-node/dcrec/edwards"
 	"github.com/monetarium/monetarium-node/dcrec/secp256k1"
+	"github.com/monetarium/monetarium-node/dcrec/secp256k1/schnorr"
 	"github.com/monetarium/monetarium-node/txscript"
 	"github.com/monetarium/monetarium-node/txscript/stdaddr"
 	"github.com/monetarium/monetarium-node/txscript/stdscript"
@@ -2747,3 +2751,127 @@ nexttest:
 		}
 	}
 }
+
+// TestSignatureScriptWithPrevOuts ensures signature scripts created with the
+// SigHashCoinType flag only validate when the engine permits the flag and is
+// provided with the exact previous outputs that were signed.
+func TestSignatureScriptWithPrevOuts(t *testing.T) {
+	t.Parallel()
+
+	tx := wire.NewMsgTx()
+	tx.AddTxOut(wire.NewTxOut(500, []byte{txscript.OP_RETURN}))
+	tx.AddTxIn(wire.NewTxIn(coinbaseOutPoint, 500, nil))
+	tx.AddTxIn(wire.NewTxIn(coinbaseOutPoint, 500, nil))
+
+	skaValue, _ := new(big.Int).SetString("1000000000000000000000000", 10)
+	prevOuts := []txscript.PrevOutput{{
+		CoinType: cointype.CoinTypeVAR,
+		Value:    coinbaseVal,
+	}, {
+		CoinType: cointype.CoinType(3),
+		SKAValue: skaValue,
+	}}
+
+	const hashType = txscript.SigHashAll | txscript.SigHashCoinType
+	for idx := range tx.TxIn {
+		script, err := SignatureScriptWithPrevOuts(tx, idx,
+			uncompressedPkScript, hashType, privKeyD,
+			dcrec.STEcdsaSecp256k1, false, prevOuts)
+		if err != nil {
+			t.Fatalf("unable to create signature script: %v", err)
+		}
+		tx.TxIn[idx].SignatureScript = script
+	}
+
+	wrongCoinType := []txscript.PrevOutput{prevOuts[0], prevOuts[1]}
+	wrongCoinType[1].CoinType = 1
+	wrongAmount := []txscript.PrevOutput{prevOuts[0], prevOuts[1]}
+	wrongAmount[1].SKAValue = new(big.Int).Sub(skaValue, big.NewInt(1))
+
+	const flags = txscript.ScriptVerifyCoinTypeSigHash
+	tests := []struct {
+		name     string
+		flags    txscript.ScriptFlags
+		prevOuts []txscript.PrevOutput
+		valid    bool
+	}{{
+		name:     "flag active with signed previous outputs",
+		flags:    flags,
+		prevOuts: prevOuts,
+		valid:    true,
+	}, {
+		name:     "flag inactive",
+		flags:    0,
+		prevOuts: prevOuts,
+		valid:    false,
+	}, {
+		name:     "no previous outputs",
+		flags:    flags,
+		prevOuts: nil,
+		valid:    false,
+	}, {
+		name:     "wrong coin type",
+		flags:    flags,
+		prevOuts: wrongCoinType,
+		valid:    false,
+	}, {
+		name:     "wrong amount",
+		flags:    flags,
+		prevOuts: wrongAmount,
+		valid:    false,
+	}}
+	for _, test := range tests {
+		for idx := range tx.TxIn {
+			vm, err := txscript.NewEngineWithPrevOuts(uncompressedPkScript,
+				tx, idx, test.flags, 0, nil, test.prevOuts)
+			if err != nil {
+				t.Fatalf("%q: cannot create script vm: %v", test.name, err)
+			}
+			err = vm.Execute()
+			if (err == nil) != test.valid {
+				t.Errorf("%q: input %d unexpected validation result: %v",
+					test.name, idx, err)
+			}
+		}
+	}
+}
+
+// TestTSpendSignatureScriptFromSig ensures a treasury spend signature script
+// built from an externally produced signature matches the one created by
+// TSpendSignatureScript and verifies against the signature hash.
+func TestTSpendSignatureScriptFromSig(t *testing.T) {
+	tx := wire.NewMsgTx()
+	tx.Version = wire.TxVersionTreasury
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, 100000000, nil))
+	tx.AddTxOut(wire.NewTxOut(100000000, []byte{txscript.OP_TADD}))
+	tx.Expiry = 1000
+
+	privKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("failed to generate private key: %v", err)
+	}
+	want, err := TSpendSignatureScript(tx, privKey.Serialize())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hash, err := TSpendSignatureHash(tx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sig, err := schnorr.Sign(privKey, hash)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := TSpendSignatureScriptFromSig(sig, privKey.PubKey())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("mismatched signature script -- got %x, want %x", got,
+			want)
+	}
+	if !sig.Verify(hash, privKey.PubKey()) {
+		t.Fatal("signature does not verify against the signature hash")
+	}
+}
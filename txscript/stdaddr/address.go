@@ -442,12 +442,21 @@ func probablyV0Base58Addr(s string) bool {
 // DecodeAddress decodes the string encoding of an address and returns the
 // relevant Address if it is a valid encoding for a known address type and is
 // for the provided network.
+//
+// Coin-typed addresses are only recognized when the provided parameters also
+// implement the AddressParamsCoinTyped interface.
 func DecodeAddress(addr string, params AddressParams) (Address, error) {
 	// Parsing code for future address/script versions should be added as the
 	// most recent case in the switch statement.  The expectation is that newer
 	// version addresses will become more common, so they should be checked
 	// first.
+	coinTypedParams, hasCoinTyped := params.(AddressParamsCoinTyped)
 	switch {
+	case hasCoinTyped && probablyCoinTypedAddr(addr,
+		coinTypedParams.AddrHRPCoinTyped()):
+
+		return DecodeAddressCoinTyped(addr, coinTypedParams)
+
 	case probablyV0Base58Addr(addr):
 		return DecodeAddressV0(addr, params)
 	}
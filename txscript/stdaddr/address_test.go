@@ -25,6 +25,7 @@ type mockAddrParams struct {
 	pkhSchnorrID [2]byte
 	scriptHashID [2]byte
 	privKeyID    [2]byte
+	coinTypedHRP string
 }
 
 // AddrIDPubKeyV0 returns the magic prefix bytes associated with the mock params
@@ -70,6 +71,14 @@ func (p *mockAddrParams) AddrIDScriptHashV0() [2]byte {
 	return p.scriptHashID
 }
 
+// AddrHRPCoinTyped returns the human-readable part associated with the mock
+// params for coin-typed addresses.
+//
+// This is part of the AddressParamsCoinTyped interface.
+func (p *mockAddrParams) AddrHRPCoinTyped() string {
+	return p.coinTypedHRP
+}
+
 // mockMainNetParams returns mock mainnet address parameters to use throughout
 // the tests.  They match the Decred mainnet params as of the time this comment
 // was written.
@@ -81,6 +90,7 @@ func mockMainNetParams() *mockAddrParams {
 		pkhSchnorrID: [2]byte{0x07, 0x01}, // starts with DS
 		scriptHashID: [2]byte{0x07, 0x1a}, // starts with Dc
 		privKeyID:    [2]byte{0x22, 0xde}, // starts with Pm
+		coinTypedHRP: "mon",
 	}
 }
 
@@ -95,6 +105,7 @@ func mockTestNetParams() *mockAddrParams {
 		pkhSchnorrID: [2]byte{0x0e, 0xe3}, // starts with TS
 		scriptHashID: [2]byte{0x0e, 0xfc}, // starts with Tc
 		privKeyID:    [2]byte{0x23, 0x0e}, // starts with Pt
+		coinTypedHRP: "tmon",
 	}
 }
 
@@ -109,6 +120,7 @@ func mockRegNetParams() *mockAddrParams {
 		pkhSchnorrID: [2]byte{0x0d, 0xc2}, // starts with RS
 		scriptHashID: [2]byte{0x0d, 0xdb}, // starts with Rc
 		privKeyID:    [2]byte{0x22, 0xfe}, // starts with Pr
+		coinTypedHRP: "rmon",
 	}
 }
 
@@ -0,0 +1,212 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stdaddr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/monetarium/monetarium-node/bech32"
+	"github.com/monetarium/monetarium-node/cointype"
+	"github.com/monetarium/monetarium-node/crypto/ripemd160"
+)
+
+// The following constants identify the type of the underlying address encoded
+// in a coin-typed address.
+const (
+	coinTypedPubKeyHashEcdsaSecp256k1V0   = 0x00
+	coinTypedScriptHashV0                 = 0x01
+	coinTypedPubKeyHashEd25519V0          = 0x02
+	coinTypedPubKeyHashSchnorrSecp256k1V0 = 0x03
+)
+
+// coinTypedDataLen is the length of the data encoded in a coin-typed address,
+// which consists of the 1-byte coin type, the 1-byte underlying address type,
+// and the 20-byte hash of the underlying address.
+const coinTypedDataLen = 2 + ripemd160.Size
+
+// AddressParamsCoinTyped defines an interface that is used to provide the
+// parameters required when encoding and decoding coin-typed addresses.  These
+// values are typically well-defined and unique per network.
+//
+// Coin-typed addresses are only recognized by DecodeAddress when the provided
+// parameters also implement this interface.
+type AddressParamsCoinTyped interface {
+	AddressParamsV0
+
+	// AddrHRPCoinTyped returns the human-readable part of the bech32 encoding
+	// of coin-typed addresses.
+	AddrHRPCoinTyped() string
+}
+
+// CoinTyper is an interface for addresses that commit to the coin type the
+// recipient expects to receive.
+type CoinTyper interface {
+	// CoinType returns the coin type the address is intended to receive.
+	CoinType() cointype.CoinType
+}
+
+// AddressCoinTyped specifies an address that represents the same payment
+// destination as an underlying pay-to-pubkey-hash or pay-to-script-hash
+// address while additionally committing to the coin type the recipient
+// expects to receive.
+//
+// The coin type is not enforced by the payment script in any way.  It only
+// allows software that creates transactions to refuse to pay a coin type other
+// than the one the recipient expects, which protects against the loss of
+// funds that results from sending a coin type to an address whose owner does
+// not support it.
+//
+// The address is encoded with bech32 using the human-readable part of the
+// network followed by the coin type, the underlying address type, and the
+// hash of the underlying address.
+type AddressCoinTyped struct {
+	addr     Address
+	hrp      string
+	coinType cointype.CoinType
+	addrType byte
+	hash     [ripemd160.Size]byte
+}
+
+// Ensure AddressCoinTyped implements the CoinTyper and Hash160er interfaces.
+var _ CoinTyper = (*AddressCoinTyped)(nil)
+var _ Hash160er = (*AddressCoinTyped)(nil)
+
+// NewAddressCoinTyped returns an address that represents the same payment
+// destination as the provided address while additionally committing to the
+// given coin type the recipient expects to receive.
+//
+// The provided address must be a version 0 pay-to-pubkey-hash or
+// pay-to-script-hash address.  Pay-to-pubkey addresses are not supported.
+func NewAddressCoinTyped(addr Address, coinType cointype.CoinType,
+	params AddressParamsCoinTyped) (*AddressCoinTyped, error) {
+
+	var addrType byte
+	var hash *[ripemd160.Size]byte
+	switch a := addr.(type) {
+	case *AddressPubKeyHashEcdsaSecp256k1V0:
+		addrType, hash = coinTypedPubKeyHashEcdsaSecp256k1V0, a.Hash160()
+	case *AddressScriptHashV0:
+		addrType, hash = coinTypedScriptHashV0, a.Hash160()
+	case *AddressPubKeyHashEd25519V0:
+		addrType, hash = coinTypedPubKeyHashEd25519V0, a.Hash160()
+	case *AddressPubKeyHashSchnorrSecp256k1V0:
+		addrType, hash = coinTypedPubKeyHashSchnorrSecp256k1V0, a.Hash160()
+	default:
+		str := fmt.Sprintf("coin-typed addresses for %T are not supported",
+			addr)
+		return nil, makeError(ErrUnsupportedAddress, str)
+	}
+
+	return &AddressCoinTyped{
+		addr:     addr,
+		hrp:      params.AddrHRPCoinTyped(),
+		coinType: coinType,
+		addrType: addrType,
+		hash:     *hash,
+	}, nil
+}
+
+// String returns the string encoding of the payment address for the associated
+// script version and payment script.
+//
+// This is part of the Address interface implementation.
+func (addr *AddressCoinTyped) String() string {
+	var data [coinTypedDataLen]byte
+	data[0] = byte(addr.coinType)
+	data[1] = addr.addrType
+	copy(data[2:], addr.hash[:])
+
+	// The error is impossible since the data is always converted to 5-bit
+	// groups with padding.
+	encoded, _ := bech32.EncodeFromBase256(addr.hrp, data[:])
+	return encoded
+}
+
+// PaymentScript returns the script version associated with the address along
+// with a script to pay a transaction output to the address.  It is the same as
+// the payment script of the underlying address.
+//
+// This is part of the Address interface implementation.
+func (addr *AddressCoinTyped) PaymentScript() (uint16, []byte) {
+	return addr.addr.PaymentScript()
+}
+
+// CoinType returns the coin type the address is intended to receive.
+//
+// This is part of the CoinTyper interface implementation.
+func (addr *AddressCoinTyped) CoinType() cointype.CoinType {
+	return addr.coinType
+}
+
+// Address returns the underlying address that does not commit to the coin
+// type.
+func (addr *AddressCoinTyped) Address() Address {
+	return addr.addr
+}
+
+// Hash160 returns the underlying array of the public key hash or script hash
+// of the underlying address.  This can be useful when an array is more
+// appropriate than a slice (for example, when used as map keys).
+//
+// This is part of the Hash160er interface implementation.
+func (addr *AddressCoinTyped) Hash160() *[ripemd160.Size]byte {
+	return &addr.hash
+}
+
+// probablyCoinTypedAddr returns true when the provided string looks like a
+// coin-typed address for the provided human-readable part as determined by
+// its prefix.
+func probablyCoinTypedAddr(s, hrp string) bool {
+	prefix := hrp + "1"
+	return len(s) > len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// DecodeAddressCoinTyped decodes the string encoding of a coin-typed address
+// and returns it if it is a valid encoding for a known underlying address type
+// and is for the network identified by the provided parameters.
+func DecodeAddressCoinTyped(addr string, params AddressParamsCoinTyped) (*AddressCoinTyped, error) {
+	hrp, data, err := bech32.DecodeToBase256(addr)
+	if err != nil {
+		kind := ErrMalformedAddress
+		var checksumErr bech32.ErrInvalidChecksum
+		if errors.As(err, &checksumErr) {
+			kind = ErrBadAddressChecksum
+		}
+		str := fmt.Sprintf("failed to decode address %q: %v", addr, err)
+		return nil, makeError(kind, str)
+	}
+	if hrp != params.AddrHRPCoinTyped() {
+		str := fmt.Sprintf("address %q is not for this network", addr)
+		return nil, makeError(ErrUnsupportedAddress, str)
+	}
+	if len(data) != coinTypedDataLen {
+		str := fmt.Sprintf("address %q decoded data is %d bytes vs required "+
+			"%d bytes", addr, len(data), coinTypedDataLen)
+		return nil, makeError(ErrMalformedAddressData, str)
+	}
+
+	// Decode the underlying address according to the address type.
+	coinType, addrType, hash := cointype.CoinType(data[0]), data[1], data[2:]
+	var underlying Address
+	switch addrType {
+	case coinTypedPubKeyHashEcdsaSecp256k1V0:
+		underlying, err = NewAddressPubKeyHashEcdsaSecp256k1V0(hash, params)
+	case coinTypedScriptHashV0:
+		underlying, err = NewAddressScriptHashV0FromHash(hash, params)
+	case coinTypedPubKeyHashEd25519V0:
+		underlying, err = NewAddressPubKeyHashEd25519V0(hash, params)
+	case coinTypedPubKeyHashSchnorrSecp256k1V0:
+		underlying, err = NewAddressPubKeyHashSchnorrSecp256k1V0(hash, params)
+	default:
+		str := fmt.Sprintf("address %q is not a supported type", addr)
+		return nil, makeError(ErrUnsupportedAddress, str)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewAddressCoinTyped(underlying, coinType, params)
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stdaddr
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/monetarium/monetarium-node/bech32"
+	"github.com/monetarium/monetarium-node/cointype"
+)
+
+// TestCoinTypedAddresses ensures that coin-typed addresses are encoded and
+// decoded as expected, commit to the expected coin type, and produce the same
+// payment script as the underlying address.
+func TestCoinTypedAddresses(t *testing.T) {
+	mainNetParams := mockMainNetParams()
+	testNetParams := mockTestNetParams()
+	hash := hexToBytes("27e2e44d9b2c7d1a52b7a3b1b0b6d9c4d2a1f0e3")
+
+	tests := []struct {
+		name       string                  // test description
+		makeAddr   func() (Address, error) // underlying address constructor
+		coinType   cointype.CoinType       // coin type to commit to
+		net        AddressParamsCoinTyped  // network params
+		underlying string                  // expected underlying address
+		encoded    string                  // expected coin-typed address
+	}{{
+		name: "p2pkh-ecdsa-secp256k1 VAR mainnet",
+		makeAddr: func() (Address, error) {
+			return NewAddressPubKeyHashEcdsaSecp256k1V0(hash, mainNetParams)
+		},
+		coinType:   cointype.CoinTypeVAR,
+		net:        mainNetParams,
+		underlying: "DsUboedZTdLKR27nPCTGL5i1DKiNK818nnX",
+		encoded:    "mon1qqqz0chyfkdjclg622m68vdskmvuf54p7r3sp9gxnj",
+	}, {
+		name: "p2sh coin type 1 mainnet",
+		makeAddr: func() (Address, error) {
+			return NewAddressScriptHashV0FromHash(hash, mainNetParams)
+		},
+		coinType:   1,
+		net:        mainNetParams,
+		underlying: "Dcb6VDcXoMfAzm5dBHvwY94w733qQn8mMYh",
+		encoded:    "mon1qyqj0chyfkdjclg622m68vdskmvuf54p7r3s0dtgu8",
+	}, {
+		name: "p2pkh-ed25519 coin type 2 mainnet",
+		makeAddr: func() (Address, error) {
+			return NewAddressPubKeyHashEd25519V0(hash, mainNetParams)
+		},
+		coinType:   2,
+		net:        mainNetParams,
+		underlying: "DebnW9A2EuZVNrFKcR2c8ZhJ3Ba88LGVpEn",
+		encoded:    "mon1qgpz0chyfkdjclg622m68vdskmvuf54p7r3sjalvgj",
+	}, {
+		name: "p2pkh-schnorr-secp256k1 coin type 3 mainnet",
+		makeAddr: func() (Address, error) {
+			return NewAddressPubKeyHashSchnorrSecp256k1V0(hash, mainNetParams)
+		},
+		coinType:   3,
+		net:        mainNetParams,
+		underlying: "DSXeQbu5bc9b6KF91gSca1w8SJSPq4P7qWs",
+		encoded:    "mon1qvpj0chyfkdjclg622m68vdskmvuf54p7r3su4uz88",
+	}, {
+		name: "p2pkh-ecdsa-secp256k1 coin type 1 testnet",
+		makeAddr: func() (Address, error) {
+			return NewAddressPubKeyHashEcdsaSecp256k1V0(hash, testNetParams)
+		},
+		coinType:   1,
+		net:        testNetParams,
+		underlying: "TsUf2dm4rRPRXNo9Cb5RUejGoRgHsnSY8ac",
+		encoded:    "tmon1qyqz0chyfkdjclg622m68vdskmvuf54p7r3srqkx9k",
+	}}
+
+	for _, test := range tests {
+		underlying, err := test.makeAddr()
+		if err != nil {
+			t.Errorf("%s: unexpected constructor error: %v", test.name, err)
+			continue
+		}
+		addr, err := NewAddressCoinTyped(underlying, test.coinType, test.net)
+		if err != nil {
+			t.Errorf("%s: unexpected coin-typed error: %v", test.name, err)
+			continue
+		}
+
+		// Ensure the encoding and the underlying address are as expected.
+		if got := addr.String(); got != test.encoded {
+			t.Errorf("%s: unexpected encoding -- got %s, want %s", test.name,
+				got, test.encoded)
+			continue
+		}
+		if got := addr.Address().String(); got != test.underlying {
+			t.Errorf("%s: unexpected underlying address -- got %s, want %s",
+				test.name, got, test.underlying)
+			continue
+		}
+
+		// Ensure the payment script matches the underlying address.
+		wantVer, wantScript := underlying.PaymentScript()
+		gotVer, gotScript := addr.PaymentScript()
+		if gotVer != wantVer || !bytes.Equal(gotScript, wantScript) {
+			t.Errorf("%s: mismatched payment script -- got %d:%x, want %d:%x",
+				test.name, gotVer, gotScript, wantVer, wantScript)
+			continue
+		}
+
+		// Ensure the generic decoder recognizes the address in both lower and
+		// upper case and that it decodes to the same coin type and underlying
+		// address.
+		for _, encoded := range []string{test.encoded,
+			strings.ToUpper(test.encoded)} {
+
+			decoded, err := DecodeAddress(encoded, test.net)
+			if err != nil {
+				t.Errorf("%s: unexpected decode error: %v", test.name, err)
+				continue
+			}
+			coinTyped, ok := decoded.(*AddressCoinTyped)
+			if !ok {
+				t.Errorf("%s: decoded address is %T instead of coin-typed",
+					test.name, decoded)
+				continue
+			}
+			if coinTyped.CoinType() != test.coinType {
+				t.Errorf("%s: unexpected decoded coin type -- got %d, want %d",
+					test.name, coinTyped.CoinType(), test.coinType)
+				continue
+			}
+			if got := coinTyped.Address().String(); got != test.underlying {
+				t.Errorf("%s: unexpected decoded underlying address -- got "+
+					"%s, want %s", test.name, got, test.underlying)
+				continue
+			}
+			if !bytes.Equal(coinTyped.Hash160()[:], hash) {
+				t.Errorf("%s: unexpected decoded hash -- got %x, want %x",
+					test.name, coinTyped.Hash160()[:], hash)
+				continue
+			}
+		}
+	}
+}
+
+// TestCoinTypedAddressErrors ensures that invalid coin-typed addresses and
+// unsupported underlying addresses are rejected with the expected errors.
+func TestCoinTypedAddressErrors(t *testing.T) {
+	mainNetParams := mockMainNetParams()
+	hash := hexToBytes("27e2e44d9b2c7d1a52b7a3b1b0b6d9c4d2a1f0e3")
+
+	// encode returns the bech32 encoding of the provided data with the
+	// mainnet human-readable part.
+	encode := func(data []byte) string {
+		t.Helper()
+		encoded, err := bech32.EncodeFromBase256("mon", data)
+		if err != nil {
+			t.Fatalf("unable to encode test data: %v", err)
+		}
+		return encoded
+	}
+
+	tests := []struct {
+		name    string // test description
+		addr    string // coin-typed address to decode
+		wantErr error  // expected error
+	}{{
+		name:    "bad checksum",
+		addr:    "mon1qqqz0chyfkdjclg622m68vdskmvuf54p7r3sp9gxnk",
+		wantErr: ErrBadAddressChecksum,
+	}, {
+		name:    "mixed case",
+		addr:    "mon1qqqz0chyfkdjclg622m68vdskmvuf54p7r3sp9gxnJ",
+		wantErr: ErrMalformedAddress,
+	}, {
+		name:    "other network",
+		addr:    "tmon1qyqz0chyfkdjclg622m68vdskmvuf54p7r3srqkx9k",
+		wantErr: ErrUnsupportedAddress,
+	}, {
+		name:    "short data",
+		addr:    encode(append([]byte{0x00, 0x00}, hash[:19]...)),
+		wantErr: ErrMalformedAddressData,
+	}, {
+		name:    "long data",
+		addr:    encode(append(append([]byte{0x00, 0x00}, hash...), 0x00)),
+		wantErr: ErrMalformedAddressData,
+	}, {
+		name:    "unknown underlying address type",
+		addr:    encode(append([]byte{0x00, 0x04}, hash...)),
+		wantErr: ErrUnsupportedAddress,
+	}}
+
+	for _, test := range tests {
+		_, err := DecodeAddressCoinTyped(test.addr, mainNetParams)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%s: mismatched err -- got %v, want %v", test.name, err,
+				test.wantErr)
+			continue
+		}
+	}
+
+	// Ensure pay-to-pubkey addresses are not supported as the underlying
+	// address.
+	pubKey := hexToBytes("028f53838b7639563f27c94845549a41e5146bcd52e7fef0ea6da" +
+		"143a02b0fe2ed")
+	p2pk, err := NewAddressPubKeyEcdsaSecp256k1V0Raw(pubKey, mainNetParams)
+	if err != nil {
+		t.Fatalf("unexpected p2pk constructor error: %v", err)
+	}
+	_, err = NewAddressCoinTyped(p2pk, cointype.CoinTypeVAR, mainNetParams)
+	if !errors.Is(err, ErrUnsupportedAddress) {
+		t.Fatalf("mismatched p2pk err -- got %v, want %v", err,
+			ErrUnsupportedAddress)
+	}
+}
@@ -101,6 +101,17 @@ func ExtractAddrsV0(pkScript []byte, params stdaddr.AddressParamsV0) (ScriptType
 		return STMultiSig, addrs
 	}
 
+	// Check for hash-time-locked contract script.  The recipient address is
+	// always first followed by the refund address.
+	if details := ExtractHTLCDetailsV0(pkScript); details != nil {
+		recipient, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(
+			details.RecipientHash160[:], params)
+		addrs := addrToSlice(recipient, err)
+		refund, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(
+			details.RefundHash160[:], params)
+		return STHTLC, append(addrs, addrToSlice(refund, err)...)
+	}
+
 	// Check for stake submission script.  Only stake-submission-tagged
 	// pay-to-pubkey-hash and pay-to-script-hash are allowed.
 	if h := ExtractStakeSubmissionPubKeyHashV0(pkScript); h != nil {
@@ -146,6 +157,15 @@ func ExtractAddrsV0(pkScript []byte, params stdaddr.AddressParamsV0) (ScriptType
 	}
 
 	// Check for null data script.
+	// Check for fee distribution markers.  They are also null data scripts,
+	// so they must be checked first.
+	if IsSSFeeStakerMarkerScriptV0(pkScript) {
+		return STSSFeeStakerMarker, nil
+	}
+	if IsSSFeeMinerMarkerScriptV0(pkScript) {
+		return STSSFeeMinerMarker, nil
+	}
+
 	if IsNullDataScriptV0(pkScript) {
 		// Null data scripts do not have an associated address.
 		return STNullData, nil
@@ -173,6 +193,12 @@ func ExtractAddrsV0(pkScript []byte, params stdaddr.AddressParamsV0) (ScriptType
 		return STSKABurn, nil
 	}
 
+	// Check for SKA emission authorization script.  It has no associated
+	// address since it only authorizes an emission.
+	if IsSKAEmissionAuthScriptV0(pkScript) {
+		return STSKAEmissionAuth, nil
+	}
+
 	// Don't attempt to extract addresses for nonstandard transactions.
 	return STNonStandard, nil
 }
@@ -128,6 +128,9 @@ var addressV0Tests = func() []addressTest {
 	// Script hash for a 2-of-3 multisig composed of pkCE, pkCE2, and pkCO.
 	p2sh := "f86b5a7c6d32566aa4dccc04d1533530b4d64cf3"
 
+	// Hash-time-locked contract secret hash.
+	htlcSecretHash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
 	return []addressTest{{
 		// ---------------------------------------------------------------------
 		// Misc negative tests.
@@ -620,6 +623,46 @@ var addressV0Tests = func() []addressTest {
 			"TkKnSffGoD8BJFECygT9a2MYZ9iLsCY1GrgaPFyuoqt2xU61HDtDf",
 			"TkQ5JwZ4SWXtBmuyryhn7HXzwJto3dhgnXBa3hZbpPH9yPnnjHyAg",
 		},
+	}, {
+		// ---------------------------------------------------------------------
+		// Negative hash-time-locked contract tests.
+		// ---------------------------------------------------------------------
+
+		name: "almost v0 htlc -- zero lock time",
+		script: p("IF SIZE 32 EQUALVERIFY SHA256 DATA_32 0x%s EQUALVERIFY DUP "+
+			"HASH160 DATA_20 0x%s ELSE 0 CHECKLOCKTIMEVERIFY DROP DUP HASH160 "+
+			"DATA_20 0x%s ENDIF EQUALVERIFY CHECKSIG", htlcSecretHash, h160CE,
+			h160CE2),
+		params:   mainNetParams,
+		wantType: STNonStandard,
+	}, {
+		// ---------------------------------------------------------------------
+		// Positive hash-time-locked contract tests.
+		// ---------------------------------------------------------------------
+
+		name: "mainnet v0 htlc",
+		script: p("IF SIZE 32 EQUALVERIFY SHA256 DATA_32 0x%s EQUALVERIFY DUP "+
+			"HASH160 DATA_20 0x%s ELSE 300000 CHECKLOCKTIMEVERIFY DROP DUP "+
+			"HASH160 DATA_20 0x%s ENDIF EQUALVERIFY CHECKSIG", htlcSecretHash,
+			h160CE, h160CE2),
+		params:   mainNetParams,
+		wantType: STHTLC,
+		wantAddrs: []string{
+			"DsmcYVbP1Nmag2H4AS17UTvmWXmGeA7nLDx",
+			"DsR5xYvsdJqAW6eDivvtcV8AshKXCvw2xaS",
+		},
+	}, {
+		name: "testnet v0 htlc",
+		script: p("IF SIZE 32 EQUALVERIFY SHA256 DATA_32 0x%s EQUALVERIFY DUP "+
+			"HASH160 DATA_20 0x%s ELSE 144 CHECKSEQUENCEVERIFY DROP DUP "+
+			"HASH160 DATA_20 0x%s ENDIF EQUALVERIFY CHECKSIG", htlcSecretHash,
+			h160CE, h160CE2),
+		params:   testNetParams,
+		wantType: STHTLC,
+		wantAddrs: []string{
+			"TsmfmUitQApgnNxQypdGd2x36djCCpDpERU",
+			"TsR9BY4P26tGcTKaYKZ3m49SToHSmeamuva",
+		},
 	}, {
 		// ---------------------------------------------------------------------
 		// Negative nulldata tests.
@@ -707,6 +750,30 @@ var addressV0Tests = func() []addressTest {
 		script:   p("RETURN PUSHDATA2 0x0001 0x01{256}"),
 		params:   mainNetParams,
 		wantType: STNullData,
+	}, {
+		// ---------------------------------------------------------------------
+		// Positive fee distribution marker tests.
+		// ---------------------------------------------------------------------
+
+		name:     "mainnet v0 ssfee staker marker",
+		script:   p("RETURN DATA_8 0x5346e80300000300"),
+		params:   mainNetParams,
+		wantType: STSSFeeStakerMarker,
+	}, {
+		name:     "mainnet v0 ssfee miner marker",
+		script:   p("RETURN DATA_6 0x4d46e8030000"),
+		params:   mainNetParams,
+		wantType: STSSFeeMinerMarker,
+	}, {
+		// ---------------------------------------------------------------------
+		// Positive SKA emission authorization tests.
+		// ---------------------------------------------------------------------
+
+		name: "mainnet v0 ska emission auth",
+		script: hexToBytes("01534b4103010000000000000001080de0b6b3a76400006400" +
+			"000000000000" + pkCE + "083006020101020101"),
+		params:   mainNetParams,
+		wantType: STSKAEmissionAuth,
 	}, {
 		// ---------------------------------------------------------------------
 		// Negative stake submission P2PKH tests.
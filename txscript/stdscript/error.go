@@ -33,6 +33,11 @@ const (
 	// ErrNonStandardScript is returned when a script does not match any
 	// known standard script type.
 	ErrNonStandardScript = ErrorKind("ErrNonStandardScript")
+
+	// ErrInvalidHTLC is returned when attempting to generate a
+	// hash-time-locked contract script or an associated signature script
+	// with parameters that would not produce a standard script.
+	ErrInvalidHTLC = ErrorKind("ErrInvalidHTLC")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
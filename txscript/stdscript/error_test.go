@@ -21,6 +21,7 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrTooManyRequiredSigs, "ErrTooManyRequiredSigs"},
 		{ErrPubKeyType, "ErrPubKeyType"},
 		{ErrTooMuchNullData, "ErrTooMuchNullData"},
+		{ErrInvalidHTLC, "ErrInvalidHTLC"},
 	}
 
 	for i, test := range tests {
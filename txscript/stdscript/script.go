@@ -178,6 +178,39 @@ const (
 	// the burned coins permanently removed from circulation.
 	STSKABurn
 
+	// STHTLC identifies a standard hash-time-locked contract script that
+	// imposes an encumbrance that either requires a 32-byte secret that hashes
+	// to a specific value along with a secp256k1 public key that hashes to the
+	// recipient hash and a valid ECDSA signature for that public key, or, once
+	// an absolute or relative lock time has been reached, a secp256k1 public
+	// key that hashes to the refund hash and a valid ECDSA signature for that
+	// public key.
+	//
+	// This is commonly used as the redeem script of a pay-to-script-hash
+	// output to perform cross-chain atomic swaps.
+	STHTLC
+
+	// STSSFeeStakerMarker identifies a provably unspendable null data script
+	// that marks a staker fee distribution (SSFee) transaction.  The script
+	// commits to the height of the block that includes the transaction and
+	// the sequence of the voter it pays in order to ensure each transaction
+	// has a unique hash.
+	STSSFeeStakerMarker
+
+	// STSSFeeMinerMarker identifies a provably unspendable null data script
+	// that marks a miner fee distribution (SSFee) transaction.  The script
+	// commits to the height of the block that includes the transaction.
+	STSSFeeMinerMarker
+
+	// STSKAEmissionAuth identifies the signature script of an authorized SKA
+	// emission transaction.  It carries the emission nonce, coin type, amount,
+	// and authorization height along with the emission public key and the
+	// signature that authorizes the emission.
+	//
+	// Unlike the other script types, this is only ever found in the null input
+	// of an emission transaction as opposed to a public key script.
+	STSKAEmissionAuth
+
 	// numScriptTypes is the maximum script type number used in tests.  This
 	// entry MUST be the last entry in the enum.
 	numScriptTypes
@@ -208,6 +241,10 @@ var scriptTypeToName = []string{
 	STTreasuryGenPubKeyHash:      "treasurygen-pubkeyhash",
 	STTreasuryGenScriptHash:      "treasurygen-scripthash",
 	STSKABurn:                    "skaburn",
+	STHTLC:                       "htlc",
+	STSSFeeStakerMarker:          "ssfee-staker",
+	STSSFeeMinerMarker:           "ssfee-miner",
+	STSKAEmissionAuth:            "skaemissionauth",
 }
 
 // String returns the ScriptType as a human-readable name.
@@ -519,6 +556,62 @@ func IsTreasuryGenScriptHashScript(scriptVersion uint16, script []byte) bool {
 	return false
 }
 
+// IsHTLCScript returns whether or not the passed script is a standard
+// hash-time-locked contract script.
+//
+// NOTE: Version 0 scripts are the only currently supported version.  It will
+// always return false for other script versions.
+func IsHTLCScript(scriptVersion uint16, script []byte) bool {
+	switch scriptVersion {
+	case 0:
+		return IsHTLCScriptV0(script)
+	}
+
+	return false
+}
+
+// IsSSFeeStakerMarkerScript returns whether or not the passed script is a
+// standard staker fee distribution marker script.
+//
+// NOTE: Version 0 scripts are the only currently supported version.  It will
+// always return false for other script versions.
+func IsSSFeeStakerMarkerScript(scriptVersion uint16, script []byte) bool {
+	switch scriptVersion {
+	case 0:
+		return IsSSFeeStakerMarkerScriptV0(script)
+	}
+
+	return false
+}
+
+// IsSSFeeMinerMarkerScript returns whether or not the passed script is a
+// standard miner fee distribution marker script.
+//
+// NOTE: Version 0 scripts are the only currently supported version.  It will
+// always return false for other script versions.
+func IsSSFeeMinerMarkerScript(scriptVersion uint16, script []byte) bool {
+	switch scriptVersion {
+	case 0:
+		return IsSSFeeMinerMarkerScriptV0(script)
+	}
+
+	return false
+}
+
+// IsSKAEmissionAuthScript returns whether or not the passed script is a
+// standard SKA emission authorization signature script.
+//
+// NOTE: Version 0 scripts are the only currently supported version.  It will
+// always return false for other script versions.
+func IsSKAEmissionAuthScript(scriptVersion uint16, script []byte) bool {
+	switch scriptVersion {
+	case 0:
+		return IsSKAEmissionAuthScriptV0(script)
+	}
+
+	return false
+}
+
 // DetermineScriptType returns the type of the script passed.
 //
 // NOTE: Version 0 scripts are the only currently supported version.  It will
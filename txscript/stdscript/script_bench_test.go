@@ -308,6 +308,15 @@ func BenchmarkIsTreasuryGenScriptHashScript(b *testing.B) {
 	benchIsX(b, filterFn, IsTreasuryGenScriptHashScript)
 }
 
+// BenchmarkIsHTLCScript benchmarks the performance of analyzing various public
+// key scripts to determine if they are hash-time-locked contract scripts.
+func BenchmarkIsHTLCScript(b *testing.B) {
+	filterFn := func(test scriptTest) bool {
+		return test.wantType == STHTLC
+	}
+	benchIsX(b, filterFn, IsHTLCScript)
+}
+
 // BenchmarkDetermineScriptType benchmarks the performance of analyzing various
 // public key scripts to determine what type of standard script they are.
 func BenchmarkDetermineScriptType(b *testing.B) {
@@ -38,6 +38,10 @@ func TestScriptTypeStringer(t *testing.T) {
 		{STTreasuryGenPubKeyHash, "treasurygen-pubkeyhash"},
 		{STTreasuryGenScriptHash, "treasurygen-scripthash"},
 		{STSKABurn, "skaburn"},
+		{STHTLC, "htlc"},
+		{STSSFeeStakerMarker, "ssfee-staker"},
+		{STSSFeeMinerMarker, "ssfee-miner"},
+		{STSKAEmissionAuth, "skaemissionauth"},
 		{0xff, "invalid"},
 	}
 
@@ -155,7 +159,18 @@ func TestDetermineScriptType(t *testing.T) {
 		testIsX(IsPubKeyHashSchnorrSecp256k1Script, STPubKeyHashSchnorrSecp256k1)
 		testIsX(IsScriptHashScript, STScriptHash)
 		testIsX(IsMultiSigScript, STMultiSig)
-		testIsX(IsNullDataScript, STNullData)
+		// Fee distribution markers are a more specific form of null data
+		// script, so they are also expected to be detected as such.
+		if test.wantType == STSSFeeStakerMarker ||
+			test.wantType == STSSFeeMinerMarker {
+
+			if !IsNullDataScript(test.version, test.script) {
+				t.Errorf("%q: fee distribution marker is not null data "+
+					"(script %x)", test.name, test.script)
+			}
+		} else {
+			testIsX(IsNullDataScript, STNullData)
+		}
 		testIsX(IsStakeSubmissionPubKeyHashScript, STStakeSubmissionPubKeyHash)
 		testIsX(IsStakeSubmissionScriptHashScript, STStakeSubmissionScriptHash)
 		testIsX(IsStakeGenPubKeyHashScript, STStakeGenPubKeyHash)
@@ -167,6 +182,10 @@ func TestDetermineScriptType(t *testing.T) {
 		testIsX(IsTreasuryAddScript, STTreasuryAdd)
 		testIsX(IsTreasuryGenPubKeyHashScript, STTreasuryGenPubKeyHash)
 		testIsX(IsTreasuryGenScriptHashScript, STTreasuryGenScriptHash)
+		testIsX(IsHTLCScript, STHTLC)
+		testIsX(IsSSFeeStakerMarkerScript, STSSFeeStakerMarker)
+		testIsX(IsSSFeeMinerMarkerScript, STSSFeeMinerMarker)
+		testIsX(IsSKAEmissionAuthScript, STSKAEmissionAuth)
 
 		// Ensure the special case of determining if a signature script appears
 		// to be a signature script which consists of a pay-to-script-hash
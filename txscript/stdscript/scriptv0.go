@@ -5,10 +5,16 @@
 package stdscript
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
+	"math"
+	"math/big"
 
 	"github.com/monetarium/monetarium-node/dcrec"
 	"github.com/monetarium/monetarium-node/txscript"
+	"github.com/monetarium/monetarium-node/wire"
 )
 
 const (
@@ -16,8 +22,20 @@ const (
 	// data to be considered a standard version 0 provably pruneable nulldata
 	// script.
 	MaxDataCarrierSizeV0 = 256
+
+	// HTLCSecretSizeV0 is the size of the secret that is required to redeem a
+	// standard version 0 hash-time-locked contract.
+	HTLCSecretSizeV0 = 32
+
+	// skaEmissionAuthVersionV0 is the only authorization version of the data
+	// carried by standard version 0 SKA emission authorization scripts.
+	skaEmissionAuthVersionV0 = 0x03
 )
 
+// skaEmissionAuthMarkerV0 is the marker that all standard version 0 SKA
+// emission authorization scripts start with.
+var skaEmissionAuthMarkerV0 = []byte{0x01, 'S', 'K', 'A'}
+
 // ExtractCompressedPubKeyV0 extracts a compressed public key from the passed
 // script if it is a standard version 0 pay-to-compressed-secp256k1-pubkey
 // script.  It will return nil otherwise.
@@ -725,6 +743,291 @@ func ExtractStakeScriptHashV0(script []byte) []byte {
 	return nil
 }
 
+// HTLCDetailsV0 houses details extracted from a standard version 0
+// hash-time-locked contract script.
+type HTLCDetailsV0 struct {
+	// SecretHash is the SHA-256 hash of the secret that allows the recipient
+	// to redeem the contract.
+	SecretHash [32]byte
+
+	// RecipientHash160 is the hash of the public key of the recipient that may
+	// redeem the contract with the secret.
+	RecipientHash160 [20]byte
+
+	// RefundHash160 is the hash of the public key of the party that may redeem
+	// the contract once the lock time has been reached.
+	RefundHash160 [20]byte
+
+	// LockTime is either the absolute lock time or the relative lock time
+	// (sequence) that must be reached before the refund is possible depending
+	// on RelativeLockTime.
+	LockTime int64
+
+	// RelativeLockTime indicates whether the refund is enforced with
+	// CHECKSEQUENCEVERIFY as opposed to CHECKLOCKTIMEVERIFY.
+	RelativeLockTime bool
+}
+
+// isStandardHTLCLockTimeV0 returns whether or not the passed lock time is
+// allowed in a standard version 0 hash-time-locked contract for the given kind
+// of lock time.
+func isStandardHTLCLockTimeV0(lockTime int64, relativeLockTime bool) bool {
+	// Relative lock times with the disable flag set would make the refund
+	// path available immediately since CHECKSEQUENCEVERIFY behaves as a NOP
+	// in that case, so they are not allowed.  Absolute lock times are compared
+	// against the 32-bit lock time of the transaction.
+	if relativeLockTime {
+		return lockTime > 0 && lockTime < int64(wire.SequenceLockTimeDisabled)
+	}
+	return lockTime > 0 && lockTime <= math.MaxUint32
+}
+
+// ExtractHTLCDetailsV0 attempts to extract details from the passed version 0
+// script if it is a standard hash-time-locked contract script.  It will return
+// nil otherwise.
+func ExtractHTLCDetailsV0(script []byte) *HTLCDetailsV0 {
+	// A standard hash-time-locked contract is of the form:
+	//  IF
+	//   SIZE 32 EQUALVERIFY
+	//   SHA256 <32-byte secret hash> EQUALVERIFY
+	//   DUP HASH160 <20-byte recipient hash>
+	//  ELSE
+	//   <lock time> <CHECKLOCKTIMEVERIFY or CHECKSEQUENCEVERIFY> DROP
+	//   DUP HASH160 <20-byte refund hash>
+	//  ENDIF
+	//  EQUALVERIFY CHECKSIG
+	//
+	// Everything other than the lock time is at a fixed position, so the
+	// fixed prefix and suffix are checked directly and only the lock time is
+	// parsed.
+	const (
+		prefixLen     = 64
+		suffixLen     = 28
+		maxLockPush   = 1 + txscript.CltvMaxScriptNumLen
+		scriptVersion = 0
+	)
+	if len(script) < prefixLen+1+suffixLen ||
+		len(script) > prefixLen+maxLockPush+suffixLen {
+
+		return nil
+	}
+	if script[0] != txscript.OP_IF ||
+		script[1] != txscript.OP_SIZE ||
+		script[2] != txscript.OP_DATA_1 ||
+		script[3] != HTLCSecretSizeV0 ||
+		script[4] != txscript.OP_EQUALVERIFY ||
+		script[5] != txscript.OP_SHA256 ||
+		script[6] != txscript.OP_DATA_32 ||
+		script[39] != txscript.OP_EQUALVERIFY ||
+		script[40] != txscript.OP_DUP ||
+		script[41] != txscript.OP_HASH160 ||
+		script[42] != txscript.OP_DATA_20 ||
+		script[63] != txscript.OP_ELSE {
+
+		return nil
+	}
+	suffix := script[len(script)-suffixLen:]
+	if (suffix[0] != txscript.OP_CHECKLOCKTIMEVERIFY &&
+		suffix[0] != txscript.OP_CHECKSEQUENCEVERIFY) ||
+		suffix[1] != txscript.OP_DROP ||
+		suffix[2] != txscript.OP_DUP ||
+		suffix[3] != txscript.OP_HASH160 ||
+		suffix[4] != txscript.OP_DATA_20 ||
+		suffix[25] != txscript.OP_ENDIF ||
+		suffix[26] != txscript.OP_EQUALVERIFY ||
+		suffix[27] != txscript.OP_CHECKSIG {
+
+		return nil
+	}
+
+	// The lock time must be the only opcode between the fixed prefix and
+	// suffix and be a canonically-encoded number.
+	lockScript := script[prefixLen : len(script)-suffixLen]
+	tokenizer := txscript.MakeScriptTokenizer(scriptVersion, lockScript)
+	if !tokenizer.Next() || !tokenizer.Done() {
+		return nil
+	}
+	var lockTime int64
+	op, data := tokenizer.Opcode(), tokenizer.Data()
+	switch {
+	case txscript.IsSmallInt(op):
+		lockTime = int64(txscript.AsSmallInt(op))
+
+	case data != nil && isCanonicalPushV0(op, data):
+		val, err := txscript.MakeScriptNum(data, txscript.CltvMaxScriptNumLen)
+		if err != nil {
+			return nil
+		}
+		lockTime = int64(val)
+
+	default:
+		return nil
+	}
+	relativeLockTime := suffix[0] == txscript.OP_CHECKSEQUENCEVERIFY
+	if !isStandardHTLCLockTimeV0(lockTime, relativeLockTime) {
+		return nil
+	}
+
+	details := HTLCDetailsV0{
+		LockTime:         lockTime,
+		RelativeLockTime: relativeLockTime,
+	}
+	copy(details.SecretHash[:], script[7:39])
+	copy(details.RecipientHash160[:], script[43:63])
+	copy(details.RefundHash160[:], suffix[5:25])
+	return &details
+}
+
+// IsHTLCScriptV0 returns whether or not the passed script is a standard
+// version 0 hash-time-locked contract script.
+func IsHTLCScriptV0(script []byte) bool {
+	return ExtractHTLCDetailsV0(script) != nil
+}
+
+// SSFeeMarkerDetailsV0 houses details extracted from a standard version 0 fee
+// distribution (SSFee) marker script.
+type SSFeeMarkerDetailsV0 struct {
+	// Height is the height of the block that includes the fee distribution
+	// transaction.
+	Height uint32
+
+	// VoterSeq is the sequence of the voter the fee distribution pays.  It is
+	// always zero for miner fee distribution markers.
+	VoterSeq uint16
+}
+
+// ExtractSSFeeStakerMarkerDetailsV0 attempts to extract details from the passed
+// version 0 script if it is a standard staker fee distribution marker script.
+// It will return nil otherwise.
+func ExtractSSFeeStakerMarkerDetailsV0(script []byte) *SSFeeMarkerDetailsV0 {
+	// A staker fee distribution marker script is of the form:
+	//  OP_RETURN OP_DATA_8 "SF" <4-byte LE height> <2-byte LE voter sequence>
+	if len(script) != 10 ||
+		script[0] != txscript.OP_RETURN ||
+		script[1] != txscript.OP_DATA_8 ||
+		script[2] != 'S' || script[3] != 'F' {
+
+		return nil
+	}
+
+	return &SSFeeMarkerDetailsV0{
+		Height:   binary.LittleEndian.Uint32(script[4:8]),
+		VoterSeq: binary.LittleEndian.Uint16(script[8:10]),
+	}
+}
+
+// IsSSFeeStakerMarkerScriptV0 returns whether or not the passed script is a
+// standard version 0 staker fee distribution marker script.
+func IsSSFeeStakerMarkerScriptV0(script []byte) bool {
+	return ExtractSSFeeStakerMarkerDetailsV0(script) != nil
+}
+
+// ExtractSSFeeMinerMarkerDetailsV0 attempts to extract details from the passed
+// version 0 script if it is a standard miner fee distribution marker script.
+// It will return nil otherwise.
+func ExtractSSFeeMinerMarkerDetailsV0(script []byte) *SSFeeMarkerDetailsV0 {
+	// A miner fee distribution marker script is of the form:
+	//  OP_RETURN OP_DATA_6 "MF" <4-byte LE height>
+	if len(script) != 8 ||
+		script[0] != txscript.OP_RETURN ||
+		script[1] != txscript.OP_DATA_6 ||
+		script[2] != 'M' || script[3] != 'F' {
+
+		return nil
+	}
+
+	return &SSFeeMarkerDetailsV0{
+		Height: binary.LittleEndian.Uint32(script[4:8]),
+	}
+}
+
+// IsSSFeeMinerMarkerScriptV0 returns whether or not the passed script is a
+// standard version 0 miner fee distribution marker script.
+func IsSSFeeMinerMarkerScriptV0(script []byte) bool {
+	return ExtractSSFeeMinerMarkerDetailsV0(script) != nil
+}
+
+// SKAEmissionAuthDetailsV0 houses details extracted from a standard version 0
+// SKA emission authorization signature script.
+type SKAEmissionAuthDetailsV0 struct {
+	// Nonce is the emission nonce that provides replay protection.
+	Nonce uint64
+
+	// CoinType is the SKA coin type being emitted.
+	CoinType uint8
+
+	// Amount is the total amount of the emission in atoms.
+	Amount *big.Int
+
+	// Height is the height the emission was authorized for.
+	Height int64
+
+	// PubKey is the compressed secp256k1 emission public key.
+	PubKey []byte
+
+	// Signature is the signature that authorizes the emission.
+	Signature []byte
+}
+
+// ExtractSKAEmissionAuthDetailsV0 attempts to extract details from the passed
+// version 0 script if it is a standard SKA emission authorization signature
+// script.  It will return nil otherwise.
+//
+// NOTE: The signature is not verified since doing so requires the transaction
+// and chain parameters.
+func ExtractSKAEmissionAuthDetailsV0(script []byte) *SKAEmissionAuthDetailsV0 {
+	// An SKA emission authorization script is of the form:
+	//  <4-byte marker> <1-byte version> <8-byte LE nonce> <1-byte coin type>
+	//  <1-byte amount len> <big-endian amount> <8-byte LE height>
+	//  <33-byte compressed pubkey> <1-byte sig len> <signature>
+	//
+	// The script is raw data rather than a sequence of opcodes, so each field
+	// is checked directly.  Notice that trailing data is not permitted.
+	const minLen = 4 + 1 + 8 + 1 + 1 + 8 + 33 + 1
+	if len(script) < minLen ||
+		!bytes.Equal(script[0:4], skaEmissionAuthMarkerV0) ||
+		script[4] != skaEmissionAuthVersionV0 {
+
+		return nil
+	}
+	nonce := binary.LittleEndian.Uint64(script[5:13])
+	coinType := script[13]
+	if coinType == 0 {
+		return nil
+	}
+	amountLen := int(script[14])
+	amountEnd := 15 + amountLen
+	if len(script) < minLen+amountLen {
+		return nil
+	}
+	amount := new(big.Int).SetBytes(script[15:amountEnd])
+	height := int64(binary.LittleEndian.Uint64(script[amountEnd : amountEnd+8]))
+	pubKey := script[amountEnd+8 : amountEnd+41]
+	if !txscript.IsStrictCompressedPubKeyEncoding(pubKey) {
+		return nil
+	}
+	sigLen := int(script[amountEnd+41])
+	sig := script[amountEnd+42:]
+	if sigLen == 0 || len(sig) != sigLen {
+		return nil
+	}
+
+	return &SKAEmissionAuthDetailsV0{
+		Nonce:     nonce,
+		CoinType:  coinType,
+		Amount:    amount,
+		Height:    height,
+		PubKey:    pubKey,
+		Signature: sig,
+	}
+}
+
+// IsSKAEmissionAuthScriptV0 returns whether or not the passed script is a
+// standard version 0 SKA emission authorization signature script.
+func IsSKAEmissionAuthScriptV0(script []byte) bool {
+	return ExtractSKAEmissionAuthDetailsV0(script) != nil
+}
+
 // DetermineScriptTypeV0 returns the type of the passed version 0 script for
 // the known standard types.  This includes both types that are required by
 // consensus as well as those which are not.
@@ -748,8 +1051,14 @@ func DetermineScriptTypeV0(script []byte) ScriptType {
 		return STScriptHash
 	case IsMultiSigScriptV0(script):
 		return STMultiSig
+	case IsHTLCScriptV0(script):
+		return STHTLC
 	case IsSKABurnScriptV0(script):
 		return STSKABurn
+	case IsSSFeeStakerMarkerScriptV0(script):
+		return STSSFeeStakerMarker
+	case IsSSFeeMinerMarkerScriptV0(script):
+		return STSSFeeMinerMarker
 	case IsNullDataScriptV0(script):
 		return STNullData
 	case IsStakeSubmissionPubKeyHashScriptV0(script):
@@ -774,6 +1083,8 @@ func DetermineScriptTypeV0(script []byte) ScriptType {
 		return STTreasuryGenPubKeyHash
 	case IsTreasuryGenScriptHashScriptV0(script):
 		return STTreasuryGenScriptHash
+	case IsSKAEmissionAuthScriptV0(script):
+		return STSKAEmissionAuth
 	}
 
 	return STNonStandard
@@ -794,7 +1105,7 @@ func DetermineRequiredSigsV0(script []byte) uint16 {
 		STStakeGenPubKeyHash, STStakeGenScriptHash,
 		STStakeRevocationPubKeyHash, STStakeRevocationScriptHash,
 		STStakeChangePubKeyHash, STStakeChangeScriptHash,
-		STTreasuryGenPubKeyHash, STTreasuryGenScriptHash:
+		STTreasuryGenPubKeyHash, STTreasuryGenScriptHash, STHTLC:
 
 		return 1
 
@@ -805,7 +1116,9 @@ func DetermineRequiredSigsV0(script []byte) uint16 {
 		}
 		return 0
 
-	case STNullData, STTreasuryAdd, STSKABurn:
+	case STNullData, STTreasuryAdd, STSKABurn, STSSFeeStakerMarker,
+		STSSFeeMinerMarker, STSKAEmissionAuth:
+
 		return 0
 	}
 
@@ -865,6 +1178,146 @@ func ProvablyPruneableScriptV0(data []byte) ([]byte, error) {
 	return builder.AddOp(txscript.OP_RETURN).AddData(data).Script()
 }
 
+// HTLCScriptV0 returns a valid version 0 standard hash-time-locked contract
+// script that may either be redeemed by the owner of the public key that hashes
+// to the recipient hash with a 32-byte secret that hashes to the secret hash or
+// by the owner of the public key that hashes to the refund hash once the lock
+// time has been reached.
+//
+// The lock time is enforced with CHECKSEQUENCEVERIFY when the relative lock
+// time flag is set and CHECKLOCKTIMEVERIFY otherwise.
+//
+// An Error with kind ErrInvalidHTLC will be returned if any of the hashes are
+// not the expected size or the lock time is not allowed in a standard
+// hash-time-locked contract.
+func HTLCScriptV0(secretHash, recipientHash160, refundHash160 []byte,
+	lockTime int64, relativeLockTime bool) ([]byte, error) {
+
+	if len(secretHash) != sha256.Size {
+		str := fmt.Sprintf("unable to generate htlc script with secret hash "+
+			"of %d bytes instead of %d", len(secretHash), sha256.Size)
+		return nil, makeError(ErrInvalidHTLC, str)
+	}
+	if len(recipientHash160) != 20 || len(refundHash160) != 20 {
+		str := fmt.Sprintf("unable to generate htlc script with recipient "+
+			"and refund hashes of %d and %d bytes instead of 20",
+			len(recipientHash160), len(refundHash160))
+		return nil, makeError(ErrInvalidHTLC, str)
+	}
+	if !isStandardHTLCLockTimeV0(lockTime, relativeLockTime) {
+		str := fmt.Sprintf("unable to generate htlc script with lock time %d "+
+			"(relative %v)", lockTime, relativeLockTime)
+		return nil, makeError(ErrInvalidHTLC, str)
+	}
+
+	lockOp := byte(txscript.OP_CHECKLOCKTIMEVERIFY)
+	if relativeLockTime {
+		lockOp = txscript.OP_CHECKSEQUENCEVERIFY
+	}
+	builder := txscript.NewScriptBuilder()
+	builder.AddOp(txscript.OP_IF)
+	builder.AddOp(txscript.OP_SIZE).AddInt64(HTLCSecretSizeV0)
+	builder.AddOp(txscript.OP_EQUALVERIFY)
+	builder.AddOp(txscript.OP_SHA256).AddData(secretHash)
+	builder.AddOp(txscript.OP_EQUALVERIFY)
+	builder.AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160)
+	builder.AddData(recipientHash160)
+	builder.AddOp(txscript.OP_ELSE)
+	builder.AddInt64(lockTime).AddOp(lockOp).AddOp(txscript.OP_DROP)
+	builder.AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160)
+	builder.AddData(refundHash160)
+	builder.AddOp(txscript.OP_ENDIF)
+	builder.AddOp(txscript.OP_EQUALVERIFY).AddOp(txscript.OP_CHECKSIG)
+	return builder.Script()
+}
+
+// checkHTLCSigScriptParamsV0 ensures the passed public key and optional redeem
+// script are suitable for a signature script that redeems a standard version 0
+// hash-time-locked contract.  The details of the redeem script are returned
+// when it is provided.
+func checkHTLCSigScriptParamsV0(pubKey, redeemScript []byte) (*HTLCDetailsV0, error) {
+	if !txscript.IsStrictCompressedPubKeyEncoding(pubKey) {
+		str := fmt.Sprintf("unable to generate htlc signature script with "+
+			"unsupported public key %x", pubKey)
+		return nil, makeError(ErrPubKeyType, str)
+	}
+	if redeemScript == nil {
+		return nil, nil
+	}
+	details := ExtractHTLCDetailsV0(redeemScript)
+	if details == nil {
+		str := "unable to generate htlc signature script with a redeem " +
+			"script that is not a standard htlc script"
+		return nil, makeError(ErrInvalidHTLC, str)
+	}
+	return details, nil
+}
+
+// HTLCClaimSigScriptV0 returns a valid version 0 signature script that redeems
+// a standard hash-time-locked contract with the provided secret.
+//
+// The redeem script must be provided when the contract is paid to via
+// pay-to-script-hash and must be nil when the contract is the public key script
+// itself.  When it is provided, the secret must hash to the secret hash of the
+// contract.
+//
+// The provided public key must be serialized in the compressed format or an
+// error with kind ErrPubKeyType will be returned.  An Error with kind
+// ErrInvalidHTLC will be returned for all other invalid parameters.
+func HTLCClaimSigScriptV0(sig, pubKey, secret, redeemScript []byte) ([]byte, error) {
+	if len(secret) != HTLCSecretSizeV0 {
+		str := fmt.Sprintf("unable to generate htlc claim signature script "+
+			"with a secret of %d bytes instead of %d", len(secret),
+			HTLCSecretSizeV0)
+		return nil, makeError(ErrInvalidHTLC, str)
+	}
+	details, err := checkHTLCSigScriptParamsV0(pubKey, redeemScript)
+	if err != nil {
+		return nil, err
+	}
+	if details != nil {
+		secretHash := sha256.Sum256(secret)
+		if !bytes.Equal(secretHash[:], details.SecretHash[:]) {
+			str := "unable to generate htlc claim signature script with a " +
+				"secret that does not match the secret hash of the contract"
+			return nil, makeError(ErrInvalidHTLC, str)
+		}
+	}
+
+	builder := txscript.NewScriptBuilder()
+	builder.AddData(sig).AddData(pubKey).AddData(secret)
+	builder.AddOp(txscript.OP_TRUE)
+	if redeemScript != nil {
+		builder.AddData(redeemScript)
+	}
+	return builder.Script()
+}
+
+// HTLCRefundSigScriptV0 returns a valid version 0 signature script that
+// redeems a standard hash-time-locked contract via the refund path.  The
+// spending transaction must satisfy the lock time of the contract.
+//
+// The redeem script must be provided when the contract is paid to via
+// pay-to-script-hash and must be nil when the contract is the public key script
+// itself.
+//
+// The provided public key must be serialized in the compressed format or an
+// error with kind ErrPubKeyType will be returned.  An Error with kind
+// ErrInvalidHTLC will be returned if the redeem script is not a standard
+// hash-time-locked contract.
+func HTLCRefundSigScriptV0(sig, pubKey, redeemScript []byte) ([]byte, error) {
+	if _, err := checkHTLCSigScriptParamsV0(pubKey, redeemScript); err != nil {
+		return nil, err
+	}
+
+	builder := txscript.NewScriptBuilder()
+	builder.AddData(sig).AddData(pubKey).AddOp(txscript.OP_FALSE)
+	if redeemScript != nil {
+		builder.AddData(redeemScript)
+	}
+	return builder.Script()
+}
+
 // AtomicSwapDataPushesV0 houses the data pushes found in hash-based atomic swap
 // contracts using version 0 scripts.
 type AtomicSwapDataPushesV0 struct {
@@ -878,9 +1331,10 @@ type AtomicSwapDataPushesV0 struct {
 // ExtractAtomicSwapDataPushesV0 returns the data pushes from an atomic swap
 // contract using version 0 scripts if it is one.  It will return nil otherwise.
 //
-// NOTE: Atomic swaps are not considered standard script types by the dcrd
-// mempool policy and should be used with P2SH.  The atomic swap format is also
-// expected to change to use a more secure hash function in the future.
+// NOTE: Atomic swaps are not considered standard script types by the mempool
+// policy unless they are also standard hash-time-locked contracts as
+// identified by ExtractHTLCDetailsV0 and should be used with P2SH.  New
+// contracts should prefer HTLCScriptV0.
 func ExtractAtomicSwapDataPushesV0(redeemScript []byte) *AtomicSwapDataPushesV0 {
 	// Local constants for convenience.
 	const (
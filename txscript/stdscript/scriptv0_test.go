@@ -6,9 +6,11 @@ package stdscript
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -64,6 +66,28 @@ var scriptV0Tests = func() []scriptTest {
 	// Script hash for a 2-of-3 multisig composed of pkCE, pkCE2, and pkCO.
 	p2sh := "f86b5a7c6d32566aa4dccc04d1533530b4d64cf3"
 
+	// Hash-time-locked contract secret hash along with a convenience function
+	// to create contracts that pay to h160CE and refund to h160CE2 with the
+	// given lock time and lock opcode.
+	htlcSecretHash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	htlc := func(lockTime, lockOp string) []byte {
+		return p("IF SIZE 32 EQUALVERIFY SHA256 DATA_32 0x%s EQUALVERIFY DUP "+
+			"HASH160 DATA_20 0x%s ELSE %s %s DROP DUP HASH160 DATA_20 0x%s "+
+			"ENDIF EQUALVERIFY CHECKSIG", htlcSecretHash, h160CE, lockTime,
+			lockOp, h160CE2)
+	}
+
+	// Convenience function to create SKA emission authorization scripts that
+	// authorize an emission of 1e18 atoms of SKA-1 at height 100 with nonce 1
+	// using pkCE with the given version, public key prefix, signature length,
+	// and signature.
+	emissionAuth := func(ver, pkPrefix, sigLen, sig string) []byte {
+		return hexToBytes("01534b41" + ver + "0100000000000000" + "01" +
+			"080de0b6b3a7640000" + "6400000000000000" + pkPrefix + pkCE[2:] +
+			sigLen + sig)
+	}
+	emissionSig := "3006020101020101"
+
 	return []scriptTest{{
 		// ---------------------------------------------------------------------
 		// Misc negative tests.
@@ -463,6 +487,123 @@ var scriptV0Tests = func() []scriptTest {
 		isSig:    true,
 		wantType: STMultiSig,
 		wantData: p("1 DATA_33 0x%s DATA_33 0x%s 2 CHECKMULTISIG", pkCE, pkCE2),
+	}, {
+		// ---------------------------------------------------------------------
+		// Negative hash-time-locked contract tests.
+		// ---------------------------------------------------------------------
+
+		name: "almost v0 htlc -- wrong secret size",
+		script: p("IF SIZE 16 EQUALVERIFY SHA256 DATA_32 0x%s EQUALVERIFY DUP "+
+			"HASH160 DATA_20 0x%s ELSE 300000 CHECKLOCKTIMEVERIFY DROP DUP "+
+			"HASH160 DATA_20 0x%s ENDIF EQUALVERIFY CHECKSIG", htlcSecretHash,
+			h160CE, h160CE2),
+		wantType: STNonStandard,
+	}, {
+		name: "almost v0 htlc -- RIPEMD160 secret hash",
+		script: p("IF SIZE 32 EQUALVERIFY RIPEMD160 DATA_20 0x%s EQUALVERIFY "+
+			"DUP HASH160 DATA_20 0x%s ELSE 300000 CHECKLOCKTIMEVERIFY DROP DUP "+
+			"HASH160 DATA_20 0x%s ENDIF EQUALVERIFY CHECKSIG", h160CE, h160CE,
+			h160CE2),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- zero absolute lock time",
+		script:   htlc("0", "CHECKLOCKTIMEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- zero relative lock time",
+		script:   htlc("0", "CHECKSEQUENCEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- negative lock time",
+		script:   htlc("-300000", "CHECKLOCKTIMEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- absolute lock time exceeds 32 bits",
+		script:   htlc("4294967296", "CHECKLOCKTIMEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- relative lock time disable flag set",
+		script:   htlc("2147483648", "CHECKSEQUENCEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- lock time exceeds max script num len",
+		script:   htlc("DATA_6 0x010000000000", "CHECKLOCKTIMEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- small lock time not pushed as small int",
+		script:   htlc("DATA_1 0x10", "CHECKLOCKTIMEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- non-minimally encoded lock time",
+		script:   htlc("DATA_2 0x1100", "CHECKLOCKTIMEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- lock time not a push",
+		script:   htlc("NOP", "CHECKLOCKTIMEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- extra opcode after lock time",
+		script:   htlc("300000 NOP", "CHECKLOCKTIMEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- missing lock time",
+		script:   htlc("", "CHECKLOCKTIMEVERIFY"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 htlc -- unsupported lock opcode",
+		script:   htlc("300000", "NOP"),
+		wantType: STNonStandard,
+	}, {
+		name: "almost v0 htlc -- trailing opcode",
+		script: append(htlc("300000", "CHECKLOCKTIMEVERIFY"),
+			0x51 /* OP_TRUE */),
+		wantType: STNonStandard,
+	}, {
+		name: "almost v0 htlc -- ends with CHECKSIGVERIFY instead",
+		script: p("IF SIZE 32 EQUALVERIFY SHA256 DATA_32 0x%s EQUALVERIFY DUP "+
+			"HASH160 DATA_20 0x%s ELSE 300000 CHECKLOCKTIMEVERIFY DROP DUP "+
+			"HASH160 DATA_20 0x%s ENDIF EQUALVERIFY CHECKSIGVERIFY",
+			htlcSecretHash, h160CE, h160CE2),
+		wantType: STNonStandard,
+	}, {
+		// ---------------------------------------------------------------------
+		// Positive hash-time-locked contract tests.
+		// ---------------------------------------------------------------------
+
+		name:     "v0 htlc absolute lock time",
+		script:   htlc("300000", "CHECKLOCKTIMEVERIFY"),
+		wantType: STHTLC,
+		wantData: expectedHTLCDetailsV0(htlcSecretHash, h160CE, h160CE2,
+			300000, false),
+		wantSigs: 1,
+	}, {
+		name:     "v0 htlc relative lock time",
+		script:   htlc("144", "CHECKSEQUENCEVERIFY"),
+		wantType: STHTLC,
+		wantData: expectedHTLCDetailsV0(htlcSecretHash, h160CE, h160CE2, 144,
+			true),
+		wantSigs: 1,
+	}, {
+		name:     "v0 htlc small int lock time",
+		script:   htlc("16", "CHECKSEQUENCEVERIFY"),
+		wantType: STHTLC,
+		wantData: expectedHTLCDetailsV0(htlcSecretHash, h160CE, h160CE2, 16,
+			true),
+		wantSigs: 1,
+	}, {
+		name:     "v0 htlc max absolute lock time",
+		script:   htlc("4294967295", "CHECKLOCKTIMEVERIFY"),
+		wantType: STHTLC,
+		wantData: expectedHTLCDetailsV0(htlcSecretHash, h160CE, h160CE2,
+			4294967295, false),
+		wantSigs: 1,
+	}, {
+		name:     "v0 htlc max relative lock time",
+		script:   htlc("2147483647", "CHECKSEQUENCEVERIFY"),
+		wantType: STHTLC,
+		wantData: expectedHTLCDetailsV0(htlcSecretHash, h160CE, h160CE2,
+			2147483647, true),
+		wantSigs: 1,
 	}, {
 		// ---------------------------------------------------------------------
 		// Negative nulldata tests.
@@ -535,6 +676,98 @@ var scriptV0Tests = func() []scriptTest {
 		name:     "v0 nulldata max standard push",
 		script:   p("RETURN PUSHDATA2 0x0001 0x01{256}"),
 		wantType: STNullData,
+	}, {
+		// ---------------------------------------------------------------------
+		// Negative fee distribution marker tests.
+		// ---------------------------------------------------------------------
+
+		name:     "almost v0 ssfee staker marker -- missing voter sequence",
+		script:   p("RETURN DATA_6 0x5346e8030000"),
+		wantType: STNullData,
+	}, {
+		name:     "almost v0 ssfee staker marker -- trailing data",
+		script:   p("RETURN DATA_9 0x5346e8030000030000"),
+		wantType: STNullData,
+	}, {
+		name:     "almost v0 ssfee staker marker -- wrong marker",
+		script:   p("RETURN DATA_8 0x5343e80300000300"),
+		wantType: STNullData,
+	}, {
+		name:     "almost v0 ssfee miner marker -- with voter sequence",
+		script:   p("RETURN DATA_8 0x4d46e80300000300"),
+		wantType: STNullData,
+	}, {
+		name:     "almost v0 ssfee miner marker -- non-canonical push",
+		script:   p("RETURN PUSHDATA1 0x06 0x4d46e8030000"),
+		wantType: STNonStandard,
+	}, {
+		// ---------------------------------------------------------------------
+		// Positive fee distribution marker tests.
+		// ---------------------------------------------------------------------
+
+		name:     "v0 ssfee staker marker",
+		script:   p("RETURN DATA_8 0x5346e80300000300"),
+		wantType: STSSFeeStakerMarker,
+		wantData: &SSFeeMarkerDetailsV0{Height: 1000, VoterSeq: 3},
+	}, {
+		name:     "v0 ssfee staker marker max values",
+		script:   p("RETURN DATA_8 0x5346ffffffffffff"),
+		wantType: STSSFeeStakerMarker,
+		wantData: &SSFeeMarkerDetailsV0{Height: 4294967295, VoterSeq: 65535},
+	}, {
+		name:     "v0 ssfee miner marker",
+		script:   p("RETURN DATA_6 0x4d46e8030000"),
+		wantType: STSSFeeMinerMarker,
+		wantData: &SSFeeMarkerDetailsV0{Height: 1000},
+	}, {
+		// ---------------------------------------------------------------------
+		// Negative SKA emission authorization tests.
+		// ---------------------------------------------------------------------
+
+		name:     "almost v0 ska emission auth -- unsupported version",
+		script:   emissionAuth("02", "02", "08", emissionSig),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 ska emission auth -- uncompressed pubkey prefix",
+		script:   emissionAuth("03", "04", "08", emissionSig),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 ska emission auth -- missing signature",
+		script:   emissionAuth("03", "02", "00", ""),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 ska emission auth -- short signature",
+		script:   emissionAuth("03", "02", "09", emissionSig),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 ska emission auth -- trailing data",
+		script:   emissionAuth("03", "02", "08", emissionSig+"00"),
+		wantType: STNonStandard,
+	}, {
+		name:     "almost v0 ska emission auth -- truncated",
+		script:   emissionAuth("03", "02", "08", emissionSig)[:56],
+		wantType: STNonStandard,
+	}, {
+		name: "almost v0 ska emission auth -- VAR coin type",
+		script: hexToBytes("01534b41030100000000000000000800000000000000016400" +
+			"000000000000" + pkCE + "08" + emissionSig),
+		wantType: STNonStandard,
+	}, {
+		// ---------------------------------------------------------------------
+		// Positive SKA emission authorization tests.
+		// ---------------------------------------------------------------------
+
+		name:     "v0 ska emission auth",
+		script:   emissionAuth("03", "02", "08", emissionSig),
+		wantType: STSKAEmissionAuth,
+		wantData: &SKAEmissionAuthDetailsV0{
+			Nonce:     1,
+			CoinType:  1,
+			Amount:    new(big.Int).SetUint64(1e18),
+			Height:    100,
+			PubKey:    hexToBytes(pkCE),
+			Signature: hexToBytes(emissionSig),
+		},
 	}, {
 		// ---------------------------------------------------------------------
 		// Negative stake submission P2PKH tests.
@@ -1644,6 +1877,337 @@ func TestExtractAtomicSwapDataPushesV0(t *testing.T) {
 	}
 }
 
+// expectedHTLCDetailsV0 is a convenience function that converts the passed
+// parameters into an expected version 0 hash-time-locked contract details
+// structure.
+func expectedHTLCDetailsV0(secretHash, recipientHash, refundHash string, lockTime int64, relativeLockTime bool) *HTLCDetailsV0 {
+	result := &HTLCDetailsV0{
+		LockTime:         lockTime,
+		RelativeLockTime: relativeLockTime,
+	}
+	copy(result.SecretHash[:], hexToBytes(secretHash))
+	copy(result.RecipientHash160[:], hexToBytes(recipientHash))
+	copy(result.RefundHash160[:], hexToBytes(refundHash))
+	return result
+}
+
+// TestExtractHTLCDetailsV0 ensures that extracting details about a version 0
+// hash-time-locked contract script works as intended for all of the version 0
+// test scripts.
+func TestExtractHTLCDetailsV0(t *testing.T) {
+	for _, test := range scriptV0Tests {
+		// Determine the expected data based on the expected script type and
+		// data specified in the test.
+		var want *HTLCDetailsV0
+		if test.wantType == STHTLC && !test.isSig {
+			var ok bool
+			want, ok = test.wantData.(*HTLCDetailsV0)
+			if !ok {
+				t.Fatalf("%q: unexpected want data type -- got %T", test.name,
+					test.wantData)
+			}
+		}
+
+		got := ExtractHTLCDetailsV0(test.script)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: unexpected htlc details -- got %+v, want %+v",
+				test.name, got, want)
+			continue
+		}
+	}
+}
+
+// TestExtractSSFeeMarkerDetailsV0 ensures that extracting details about version
+// 0 staker and miner fee distribution marker scripts works as intended for all
+// of the version 0 test scripts.
+func TestExtractSSFeeMarkerDetailsV0(t *testing.T) {
+	for _, test := range scriptV0Tests {
+		// Determine the expected data based on the expected script type and
+		// data specified in the test.
+		var wantStaker, wantMiner *SSFeeMarkerDetailsV0
+		if test.wantType == STSSFeeStakerMarker ||
+			test.wantType == STSSFeeMinerMarker {
+
+			want, ok := test.wantData.(*SSFeeMarkerDetailsV0)
+			if !ok {
+				t.Fatalf("%q: unexpected want data type -- got %T", test.name,
+					test.wantData)
+			}
+			if test.wantType == STSSFeeStakerMarker {
+				wantStaker = want
+			} else {
+				wantMiner = want
+			}
+		}
+
+		got := ExtractSSFeeStakerMarkerDetailsV0(test.script)
+		if !reflect.DeepEqual(got, wantStaker) {
+			t.Errorf("%q: unexpected staker marker details -- got %+v, want "+
+				"%+v", test.name, got, wantStaker)
+			continue
+		}
+		got = ExtractSSFeeMinerMarkerDetailsV0(test.script)
+		if !reflect.DeepEqual(got, wantMiner) {
+			t.Errorf("%q: unexpected miner marker details -- got %+v, want "+
+				"%+v", test.name, got, wantMiner)
+			continue
+		}
+	}
+}
+
+// TestExtractSKAEmissionAuthDetailsV0 ensures that extracting details about a
+// version 0 SKA emission authorization script works as intended for all of the
+// version 0 test scripts.
+func TestExtractSKAEmissionAuthDetailsV0(t *testing.T) {
+	for _, test := range scriptV0Tests {
+		// Determine the expected data based on the expected script type and
+		// data specified in the test.
+		var want *SKAEmissionAuthDetailsV0
+		if test.wantType == STSKAEmissionAuth {
+			var ok bool
+			want, ok = test.wantData.(*SKAEmissionAuthDetailsV0)
+			if !ok {
+				t.Fatalf("%q: unexpected want data type -- got %T", test.name,
+					test.wantData)
+			}
+		}
+
+		got := ExtractSKAEmissionAuthDetailsV0(test.script)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: unexpected emission auth details -- got %+v, want "+
+				"%+v", test.name, got, want)
+			continue
+		}
+	}
+}
+
+// TestHTLCScriptV0 ensures version 0 hash-time-locked contract scripts are
+// created as expected, can be parsed back into the same details, and that
+// invalid parameters are rejected with the expected errors.
+func TestHTLCScriptV0(t *testing.T) {
+	t.Parallel()
+
+	// Define some values shared in the tests for convenience.
+	secretHash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	recipient := "e280cb6e66b96679aec288b1fbdbd4db08077a1b"
+	refund := "01557763e0252dc0ff9e0996ad1d04b167bb993c"
+
+	tests := []struct {
+		name       string // test description
+		secretHash string // hex-encoded secret hash
+		recipient  string // hex-encoded recipient hash
+		refund     string // hex-encoded refund hash
+		lockTime   int64  // lock time
+		relative   bool   // whether the lock time is relative
+		expected   string // expected script in short form
+		err        error  // expected error
+	}{{
+		name:       "absolute lock time",
+		secretHash: secretHash,
+		recipient:  recipient,
+		refund:     refund,
+		lockTime:   300000,
+		expected: fmt.Sprintf("IF SIZE 32 EQUALVERIFY SHA256 DATA_32 0x%s "+
+			"EQUALVERIFY DUP HASH160 DATA_20 0x%s ELSE 300000 "+
+			"CHECKLOCKTIMEVERIFY DROP DUP HASH160 DATA_20 0x%s ENDIF "+
+			"EQUALVERIFY CHECKSIG", secretHash, recipient, refund),
+	}, {
+		name:       "relative lock time",
+		secretHash: secretHash,
+		recipient:  recipient,
+		refund:     refund,
+		lockTime:   144,
+		relative:   true,
+		expected: fmt.Sprintf("IF SIZE 32 EQUALVERIFY SHA256 DATA_32 0x%s "+
+			"EQUALVERIFY DUP HASH160 DATA_20 0x%s ELSE 144 "+
+			"CHECKSEQUENCEVERIFY DROP DUP HASH160 DATA_20 0x%s ENDIF "+
+			"EQUALVERIFY CHECKSIG", secretHash, recipient, refund),
+	}, {
+		name:       "short secret hash",
+		secretHash: secretHash[:62],
+		recipient:  recipient,
+		refund:     refund,
+		lockTime:   300000,
+		err:        ErrInvalidHTLC,
+	}, {
+		name:       "long recipient hash",
+		secretHash: secretHash,
+		recipient:  recipient + "00",
+		refund:     refund,
+		lockTime:   300000,
+		err:        ErrInvalidHTLC,
+	}, {
+		name:       "short refund hash",
+		secretHash: secretHash,
+		recipient:  recipient,
+		refund:     refund[:38],
+		lockTime:   300000,
+		err:        ErrInvalidHTLC,
+	}, {
+		name:       "zero lock time",
+		secretHash: secretHash,
+		recipient:  recipient,
+		refund:     refund,
+		lockTime:   0,
+		err:        ErrInvalidHTLC,
+	}, {
+		name:       "relative lock time with disable flag",
+		secretHash: secretHash,
+		recipient:  recipient,
+		refund:     refund,
+		lockTime:   1 << 31,
+		relative:   true,
+		err:        ErrInvalidHTLC,
+	}}
+
+	for _, test := range tests {
+		script, err := HTLCScriptV0(hexToBytes(test.secretHash),
+			hexToBytes(test.recipient), hexToBytes(test.refund), test.lockTime,
+			test.relative)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%q: unexpected error - got %v, want %v", test.name, err,
+				test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+
+		expected := mustParseShortForm(0, test.expected)
+		if !bytes.Equal(script, expected) {
+			t.Errorf("%q: unexpected result -- got: %x\nwant: %x", test.name,
+				script, expected)
+			continue
+		}
+
+		// Ensure the created script parses back into the same details.
+		want := expectedHTLCDetailsV0(test.secretHash, test.recipient,
+			test.refund, test.lockTime, test.relative)
+		got := ExtractHTLCDetailsV0(script)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: unexpected htlc details -- got %+v, want %+v",
+				test.name, got, want)
+			continue
+		}
+	}
+}
+
+// TestHTLCSigScriptsV0 ensures the version 0 signature scripts that redeem
+// hash-time-locked contracts are created as expected and that invalid
+// parameters are rejected with the expected errors.
+func TestHTLCSigScriptsV0(t *testing.T) {
+	t.Parallel()
+
+	// Define some values shared in the tests for convenience.
+	secret := hexToBytes("74657374000000000000000000000000000000000000000000" +
+		"00000000000000")
+	secretHash := sha256.Sum256(secret)
+	recipient := "e280cb6e66b96679aec288b1fbdbd4db08077a1b"
+	refund := "01557763e0252dc0ff9e0996ad1d04b167bb993c"
+	redeemScript, err := HTLCScriptV0(secretHash[:], hexToBytes(recipient),
+		hexToBytes(refund), 300000, false)
+	if err != nil {
+		t.Fatalf("unexpected error creating htlc script: %v", err)
+	}
+	sig := hexToBytes("3006020101020101" + "01")
+	pubKey := hexToBytes("02f9308a019258c31049344f85f89d5229b531c845836f99b0" +
+		"8601f113bce036f9")
+	uncompressedPubKey := hexToBytes("0479be667ef9dcbbac55a06295ce870b07029bf" +
+		"cdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448" +
+		"a68554199c47d08ffb10d4b8")
+
+	tests := []struct {
+		name         string // test description
+		claim        bool   // create a claim versus refund signature script
+		pubKey       []byte // public key to include
+		secret       []byte // secret to include for claims
+		redeemScript []byte // optional redeem script
+		expected     string // expected script in short form
+		err          error  // expected error
+	}{{
+		name:         "claim with redeem script",
+		claim:        true,
+		pubKey:       pubKey,
+		secret:       secret,
+		redeemScript: redeemScript,
+		expected: fmt.Sprintf("DATA_9 0x%x DATA_33 0x%x DATA_32 0x%x TRUE "+
+			"PUSHDATA1 0x%02x 0x%x", sig, pubKey, secret, len(redeemScript),
+			redeemScript),
+	}, {
+		name:   "claim without redeem script",
+		claim:  true,
+		pubKey: pubKey,
+		secret: secret,
+		expected: fmt.Sprintf("DATA_9 0x%x DATA_33 0x%x DATA_32 0x%x TRUE", sig,
+			pubKey, secret),
+	}, {
+		name:         "refund with redeem script",
+		pubKey:       pubKey,
+		redeemScript: redeemScript,
+		expected: fmt.Sprintf("DATA_9 0x%x DATA_33 0x%x FALSE PUSHDATA1 "+
+			"0x%02x 0x%x", sig, pubKey, len(redeemScript), redeemScript),
+	}, {
+		name:     "refund without redeem script",
+		pubKey:   pubKey,
+		expected: fmt.Sprintf("DATA_9 0x%x DATA_33 0x%x FALSE", sig, pubKey),
+	}, {
+		name:   "claim with short secret",
+		claim:  true,
+		pubKey: pubKey,
+		secret: secret[:31],
+		err:    ErrInvalidHTLC,
+	}, {
+		name:         "claim with secret that does not match",
+		claim:        true,
+		pubKey:       pubKey,
+		secret:       secretHash[:],
+		redeemScript: redeemScript,
+		err:          ErrInvalidHTLC,
+	}, {
+		name:   "claim with uncompressed pubkey",
+		claim:  true,
+		pubKey: uncompressedPubKey,
+		secret: secret,
+		err:    ErrPubKeyType,
+	}, {
+		name:   "refund with uncompressed pubkey",
+		pubKey: uncompressedPubKey,
+		err:    ErrPubKeyType,
+	}, {
+		name:         "refund with non-htlc redeem script",
+		pubKey:       pubKey,
+		redeemScript: hexToBytes("51"),
+		err:          ErrInvalidHTLC,
+	}}
+
+	for _, test := range tests {
+		var script []byte
+		var err error
+		if test.claim {
+			script, err = HTLCClaimSigScriptV0(sig, test.pubKey, test.secret,
+				test.redeemScript)
+		} else {
+			script, err = HTLCRefundSigScriptV0(sig, test.pubKey,
+				test.redeemScript)
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("%q: unexpected error - got %v, want %v", test.name, err,
+				test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+
+		expected := mustParseShortForm(0, test.expected)
+		if !bytes.Equal(script, expected) {
+			t.Errorf("%q: unexpected result -- got: %x\nwant: %x", test.name,
+				script, expected)
+			continue
+		}
+	}
+}
+
 // TestNewSKABurnScriptV0 ensures creating version 0 SKA burn scripts works as
 // intended.
 func TestNewSKABurnScriptV0(t *testing.T) {
@@ -153,6 +153,14 @@ const (
 	// ErrTooManyCFilters is returned when the number of committed filters
 	// exceeds the maximum allowed in a batch.
 	ErrTooManyCFilters
+
+	// ErrInvalidTxIndex is returned when a transaction index in a compact
+	// block related message is out of range or not in ascending order.
+	ErrInvalidTxIndex
+
+	// ErrInvalidNetAddrType is returned when a network address has an unknown
+	// type or an encoded address with a size that does not match its type.
+	ErrInvalidNetAddrType
 )
 
 // Map of ErrorCode values back to their constant names for pretty printing.
@@ -193,6 +201,8 @@ var errorCodeStrings = map[ErrorCode]string{
 	ErrTooManyMixPairReqUTXOs:        "ErrTooManyMixPairReqUTXOs",
 	ErrTooManyPrevMixMsgs:            "ErrTooManyPrevMixMsgs",
 	ErrTooManyCFilters:               "ErrTooManyCFilters",
+	ErrInvalidTxIndex:                "ErrInvalidTxIndex",
+	ErrInvalidNetAddrType:            "ErrInvalidNetAddrType",
 }
 
 // String returns the ErrorCode as a human-readable name.
@@ -55,6 +55,8 @@ func TestMessageErrorCodeStringer(t *testing.T) {
 		{ErrTooManyMixPairReqUTXOs, "ErrTooManyMixPairReqUTXOs"},
 		{ErrTooManyPrevMixMsgs, "ErrTooManyPrevMixMsgs"},
 		{ErrTooManyCFilters, "ErrTooManyCFilters"},
+		{ErrInvalidTxIndex, "ErrInvalidTxIndex"},
+		{ErrInvalidNetAddrType, "ErrInvalidNetAddrType"},
 
 		{0xffff, "Unknown ErrorCode (65535)"},
 	}
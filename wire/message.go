@@ -62,6 +62,12 @@ const (
 	CmdMixSecrets      = "mixsecrets"
 	CmdGetCFiltersV2   = "getcfsv2"
 	CmdCFiltersV2      = "cfiltersv2"
+	CmdSendCmpct       = "sendcmpct"
+	CmdCmpctBlock      = "cmpctblock"
+	CmdGetBlockTxns    = "getblocktxns"
+	CmdBlockTxns       = "blocktxns"
+	CmdStemTx          = "stemtx"
+	CmdAddrV2          = "addrv2"
 )
 
 const (
@@ -225,6 +231,24 @@ func makeEmptyMessage(command string) (Message, error) {
 	case CmdCFiltersV2:
 		msg = &MsgCFiltersV2{}
 
+	case CmdSendCmpct:
+		msg = &MsgSendCmpct{}
+
+	case CmdCmpctBlock:
+		msg = &MsgCmpctBlock{}
+
+	case CmdGetBlockTxns:
+		msg = &MsgGetBlockTxns{}
+
+	case CmdBlockTxns:
+		msg = &MsgBlockTxns{}
+
+	case CmdStemTx:
+		msg = &MsgStemTx{}
+
+	case CmdAddrV2:
+		msg = &MsgAddrV2{}
+
 	default:
 		str := fmt.Sprintf("unhandled command [%s]", command)
 		return nil, messageError(op, ErrUnknownCmd, str)
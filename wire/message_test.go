@@ -90,6 +90,13 @@ func TestMessage(t *testing.T) {
 	msgMixDC := NewMsgMixDCNet([33]byte{}, [32]byte{}, 1, []MixVect{make(MixVect, 1)}, []chainhash.Hash{})
 	msgMixCM := NewMsgMixConfirm([33]byte{}, [32]byte{}, 1, NewMsgTx(), []chainhash.Hash{})
 	msgMixRS := NewMsgMixSecrets([33]byte{}, [32]byte{}, 1, [32]byte{}, [][]byte{}, MixVect{})
+	msgSendCmpct := NewMsgSendCmpct(true, CmpctBlockProtocol)
+	msgCmpctBlock := NewMsgCmpctBlock(&testBlock.Header, 123123)
+	msgGetBlockTxns := NewMsgGetBlockTxns(&chainhash.Hash{}, []uint32{},
+		[]uint32{})
+	msgBlockTxns := NewMsgBlockTxns(&chainhash.Hash{})
+	msgStemTx := NewMsgStemTx(NewMsgTx())
+	msgAddrV2 := NewMsgAddrV2()
 
 	tests := []struct {
 		in     Message     // Value to encode
@@ -129,6 +136,12 @@ func TestMessage(t *testing.T) {
 		{msgMixDC, msgMixDC, pver, MainNet, 181},
 		{msgMixCM, msgMixCM, pver, MainNet, 173},
 		{msgMixRS, msgMixRS, pver, MainNet, 192},
+		{msgSendCmpct, msgSendCmpct, pver, MainNet, 33},
+		{msgCmpctBlock, msgCmpctBlock, pver, MainNet, 216},
+		{msgGetBlockTxns, msgGetBlockTxns, pver, MainNet, 58},
+		{msgBlockTxns, msgBlockTxns, pver, MainNet, 58},
+		{msgStemTx, msgStemTx, pver, MainNet, 39},
+		{msgAddrV2, msgAddrV2, pver, MainNet, 25},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"fmt"
+	"io"
+)
+
+// MaxAddrPerV2Msg is the maximum number of addresses that can be in a single
+// addrv2 message (MsgAddrV2).
+const MaxAddrPerV2Msg = 1000
+
+// MsgAddrV2 implements the Message interface and represents an addrv2 message.
+// It is used to provide a list of known active peers on the network in the same
+// way as the addr message (MsgAddr), but also supports network address types
+// that can't be represented as an IP address such as TORv3 onion services.
+//
+// This message was not added until protocol versions starting with
+// AddrV2Version.
+type MsgAddrV2 struct {
+	AddrList []*NetAddressV2
+}
+
+// AddAddress adds a known active peer to the message.
+func (msg *MsgAddrV2) AddAddress(na *NetAddressV2) error {
+	const op = "MsgAddrV2.AddAddress"
+	if len(msg.AddrList)+1 > MaxAddrPerV2Msg {
+		msg := fmt.Sprintf("too many addresses in message [max %v]",
+			MaxAddrPerV2Msg)
+		return messageError(op, ErrTooManyAddrs, msg)
+	}
+
+	msg.AddrList = append(msg.AddrList, na)
+	return nil
+}
+
+// BtcDecode decodes r using the protocol encoding into the receiver.  This is
+// part of the Message interface implementation.
+func (msg *MsgAddrV2) BtcDecode(r io.Reader, pver uint32) error {
+	const op = "MsgAddrV2.BtcDecode"
+	if pver < AddrV2Version {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return err
+	}
+
+	// Limit to max addresses per message.
+	if count > MaxAddrPerV2Msg {
+		msg := fmt.Sprintf("too many addresses for message [count %v, max %v]",
+			count, MaxAddrPerV2Msg)
+		return messageError(op, ErrTooManyAddrs, msg)
+	}
+
+	addrList := make([]NetAddressV2, count)
+	msg.AddrList = make([]*NetAddressV2, 0, count)
+	for i := uint64(0); i < count; i++ {
+		na := &addrList[i]
+		err := readNetAddressV2(op, r, pver, na)
+		if err != nil {
+			return err
+		}
+		msg.AddrList = append(msg.AddrList, na)
+	}
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the protocol encoding.  This is
+// part of the Message interface implementation.
+func (msg *MsgAddrV2) BtcEncode(w io.Writer, pver uint32) error {
+	const op = "MsgAddrV2.BtcEncode"
+	if pver < AddrV2Version {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	count := len(msg.AddrList)
+	if count > MaxAddrPerV2Msg {
+		msg := fmt.Sprintf("too many addresses for message [count %v, max %v]",
+			count, MaxAddrPerV2Msg)
+		return messageError(op, ErrTooManyAddrs, msg)
+	}
+
+	err := WriteVarInt(w, pver, uint64(count))
+	if err != nil {
+		return err
+	}
+
+	for _, na := range msg.AddrList {
+		err = writeNetAddressV2(op, w, pver, na)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgAddrV2) Command() string {
+	return CmdAddrV2
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgAddrV2) MaxPayloadLength(pver uint32) uint32 {
+	// Num addresses (size of varInt for max address per message) + max allowed
+	// addresses * max address size.
+	return uint32(VarIntSerializeSize(MaxAddrPerV2Msg)) +
+		(MaxAddrPerV2Msg * maxNetAddressV2Payload)
+}
+
+// NewMsgAddrV2 returns a new addrv2 message that conforms to the Message
+// interface.  See MsgAddrV2 for details.
+func NewMsgAddrV2() *MsgAddrV2 {
+	return &MsgAddrV2{
+		AddrList: make([]*NetAddressV2, 0, MaxAddrPerV2Msg),
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestAddrV2 tests the MsgAddrV2 API against the latest protocol version.
+func TestAddrV2(t *testing.T) {
+	pver := ProtocolVersion
+
+	// Ensure the command is expected value.
+	wantCmd := "addrv2"
+	msg := NewMsgAddrV2()
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgAddrV2: wrong command - got %v want %v", cmd,
+			wantCmd)
+	}
+
+	// Ensure max payload is expected value for latest protocol version.
+	// Num addresses (varInt) + max allowed addresses * (timestamp 4 bytes +
+	// services 8 bytes + type 1 byte + address length varInt 3 bytes + max
+	// address 512 bytes + port 2 bytes).
+	wantPayload := uint32(3 + MaxAddrPerV2Msg*(4+8+1+3+512+2))
+	maxPayload := msg.MaxPayloadLength(pver)
+	if maxPayload != wantPayload {
+		t.Errorf("MaxPayloadLength: wrong max payload length for protocol "+
+			"version %d - got %v, want %v", pver, maxPayload, wantPayload)
+	}
+
+	// Ensure the max payload does not exceed the max allowed message payload.
+	if maxPayload > MaxMessagePayload {
+		t.Errorf("MaxPayloadLength: payload length (%v) for protocol version "+
+			"%d exceeds MaxMessagePayload (%v)", maxPayload, pver,
+			MaxMessagePayload)
+	}
+
+	// Ensure adding more than the max allowed addresses fails.
+	na := NewNetAddressV2(time.Unix(0x495fab29, 0), SFNodeNetwork, IPv4Address,
+		[]byte{127, 0, 0, 1}, 9108)
+	for i := 0; i < MaxAddrPerV2Msg; i++ {
+		if err := msg.AddAddress(na); err != nil {
+			t.Fatalf("AddAddress #%d: unexpected error: %v", i, err)
+		}
+	}
+	if err := msg.AddAddress(na); !errors.Is(err, ErrTooManyAddrs) {
+		t.Errorf("AddAddress: did not receive expected error when adding "+
+			"more than the max allowed addresses - got %v, want %v", err,
+			ErrTooManyAddrs)
+	}
+}
+
+// TestAddrV2PreviousProtocol tests the MsgAddrV2 API against the protocol
+// prior to version AddrV2Version.
+func TestAddrV2PreviousProtocol(t *testing.T) {
+	// Use the protocol version just prior to AddrV2Version changes.
+	pver := AddrV2Version - 1
+
+	msg := NewMsgAddrV2()
+
+	// Test encode with old protocol version.
+	var buf bytes.Buffer
+	err := msg.BtcEncode(&buf, pver)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Errorf("unexpected error when encoding for protocol version %d, "+
+			"prior to message introduction - got %v, want %v", pver,
+			err, ErrMsgInvalidForPVer)
+	}
+
+	// Test decode with old protocol version.
+	var readmsg MsgAddrV2
+	err = readmsg.BtcDecode(&buf, pver)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Errorf("unexpected error when decoding for protocol version %d, "+
+			"prior to message introduction - got %v, want %v", pver,
+			err, ErrMsgInvalidForPVer)
+	}
+}
+
+// TestAddrV2Wire tests the MsgAddrV2 wire encode and decode for various
+// address types.
+func TestAddrV2Wire(t *testing.T) {
+	pver := ProtocolVersion
+	ts := time.Unix(0x495fab29, 0) // 2009-01-03 12:15:05 -0600 CST
+	torV3Key := bytes.Repeat([]byte{0xab}, 32)
+
+	multiAddr := NewMsgAddrV2()
+	multiAddr.AddAddress(NewNetAddressV2(ts, SFNodeNetwork, IPv4Address,
+		[]byte{127, 0, 0, 1}, 9108))
+	multiAddr.AddAddress(NewNetAddressV2(ts, SFNodeNetwork, IPv6Address,
+		bytes.Repeat([]byte{0x20}, 16), 9108))
+	multiAddr.AddAddress(NewNetAddressV2(ts, SFNodeNetwork, TORv3Address,
+		torV3Key, 9108))
+	multiAddr.AddAddress(NewNetAddressV2(ts, 0, NetAddressType(0xff),
+		[]byte{0x01, 0x02}, 1))
+	multiAddrEncoded := []byte{
+		0x04, // Varint for number of addresses
+		// IPv4
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // SFNodeNetwork
+		0x01,                   // Type
+		0x04,                   // Varint for address length
+		0x7f, 0x00, 0x00, 0x01, // 127.0.0.1
+		0x23, 0x94, // Port 9108 in big-endian
+		// IPv6
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // SFNodeNetwork
+		0x02, // Type
+		0x10, // Varint for address length
+		0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+		0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, // Address
+		0x23, 0x94, // Port 9108 in big-endian
+		// TORv3
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // SFNodeNetwork
+		0x04, // Type
+		0x20, // Varint for address length
+		0xab, 0xab, 0xab, 0xab, 0xab, 0xab, 0xab, 0xab,
+		0xab, 0xab, 0xab, 0xab, 0xab, 0xab, 0xab, 0xab,
+		0xab, 0xab, 0xab, 0xab, 0xab, 0xab, 0xab, 0xab,
+		0xab, 0xab, 0xab, 0xab, 0xab, 0xab, 0xab, 0xab, // Public key
+		0x23, 0x94, // Port 9108 in big-endian
+		// Unknown type
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // No services
+		0xff,       // Type
+		0x02,       // Varint for address length
+		0x01, 0x02, // Address
+		0x00, 0x01, // Port 1 in big-endian
+	}
+
+	tests := []struct {
+		in  *MsgAddrV2 // Message to encode
+		out *MsgAddrV2 // Expected decoded message
+		buf []byte     // Wire encoding
+	}{{
+		in:  NewMsgAddrV2(),
+		out: NewMsgAddrV2(),
+		buf: []byte{0x00},
+	}, {
+		in:  multiAddr,
+		out: multiAddr,
+		buf: multiAddrEncoded,
+	}}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Encode the message to wire format.
+		var buf bytes.Buffer
+		err := test.in.BtcEncode(&buf, pver)
+		if err != nil {
+			t.Errorf("BtcEncode #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("BtcEncode #%d\n got: %s want: %s", i,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.buf))
+			continue
+		}
+
+		// Decode the message from wire format.
+		var msg MsgAddrV2
+		rbuf := bytes.NewReader(test.buf)
+		err = msg.BtcDecode(rbuf, pver)
+		if err != nil {
+			t.Errorf("BtcDecode #%d error %v", i, err)
+			continue
+		}
+		if len(msg.AddrList) == 0 {
+			msg.AddrList = test.out.AddrList[:0]
+		}
+		if !reflect.DeepEqual(&msg, test.out) {
+			t.Errorf("BtcDecode #%d\n got: %s want: %s", i,
+				spew.Sdump(&msg), spew.Sdump(test.out))
+			continue
+		}
+	}
+}
+
+// TestAddrV2WireErrors performs negative tests against wire encode and decode
+// of MsgAddrV2 to confirm error paths work correctly.
+func TestAddrV2WireErrors(t *testing.T) {
+	pver := ProtocolVersion
+	ts := time.Unix(0x495fab29, 0)
+
+	baseAddr := NewMsgAddrV2()
+	baseAddr.AddAddress(NewNetAddressV2(ts, SFNodeNetwork, IPv4Address,
+		[]byte{127, 0, 0, 1}, 9108))
+	baseAddrEncoded := []byte{
+		0x01,                   // Varint for number of addresses
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // SFNodeNetwork
+		0x01,                   // Type
+		0x04,                   // Varint for address length
+		0x7f, 0x00, 0x00, 0x01, // 127.0.0.1
+		0x23, 0x94, // Port 9108 in big-endian
+	}
+
+	// Message that forces an error by having more than the max allowed
+	// addresses.
+	maxAddr := NewMsgAddrV2()
+	for i := 0; i < MaxAddrPerV2Msg; i++ {
+		maxAddr.AddAddress(baseAddr.AddrList[0])
+	}
+	maxAddr.AddrList = append(maxAddr.AddrList, baseAddr.AddrList[0])
+	maxAddrEncoded := []byte{
+		0xfd, 0xe9, 0x03, // Varint for number of addresses (1001)
+	}
+
+	// Message that forces an error by having an address with a size that does
+	// not match its type.
+	badSizeAddr := NewMsgAddrV2()
+	badSizeAddr.AddAddress(NewNetAddressV2(ts, SFNodeNetwork, TORv3Address,
+		[]byte{127, 0, 0, 1}, 9108))
+	badSizeAddrEncoded := []byte{
+		0x01,                   // Varint for number of addresses
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // SFNodeNetwork
+		0x04,                   // Type
+		0x04,                   // Varint for address length
+		0x7f, 0x00, 0x00, 0x01, // 127.0.0.1
+		0x23, 0x94, // Port 9108 in big-endian
+	}
+
+	// Message that forces an error by having an address that is larger than
+	// the max allowed size.
+	largeAddr := NewMsgAddrV2()
+	largeAddr.AddAddress(NewNetAddressV2(ts, SFNodeNetwork, NetAddressType(0xff),
+		make([]byte, MaxNetAddressV2Size+1), 9108))
+	largeAddrEncoded := []byte{
+		0x01,                   // Varint for number of addresses
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // SFNodeNetwork
+		0xff,             // Type
+		0xfd, 0x01, 0x02, // Varint for address length (513)
+	}
+
+	tests := []struct {
+		in       *MsgAddrV2 // Value to encode
+		buf      []byte     // Wire encoding
+		max      int        // Max size of fixed buffer to induce errors
+		writeErr error      // Expected write error
+		readErr  error      // Expected read error
+	}{
+		// Force error in addresses count
+		{baseAddr, baseAddrEncoded, 0, io.ErrShortWrite, io.EOF},
+		// Force error in timestamp.
+		{baseAddr, baseAddrEncoded, 1, io.ErrShortWrite, io.EOF},
+		// Force error in services.
+		{baseAddr, baseAddrEncoded, 5, io.ErrShortWrite, io.EOF},
+		// Force error in type.
+		{baseAddr, baseAddrEncoded, 13, io.ErrShortWrite, io.EOF},
+		// Force error in address.
+		{baseAddr, baseAddrEncoded, 14, io.ErrShortWrite, io.EOF},
+		// Force error in port.
+		{baseAddr, baseAddrEncoded, 19, io.ErrShortWrite, io.EOF},
+		// Force error with greater than max addresses.
+		{maxAddr, maxAddrEncoded, 3, ErrTooManyAddrs, ErrTooManyAddrs},
+		// Force error with address size not matching its type.
+		{badSizeAddr, badSizeAddrEncoded, len(badSizeAddrEncoded),
+			ErrInvalidNetAddrType, ErrInvalidNetAddrType},
+		// Force error with address larger than max allowed size.
+		{largeAddr, largeAddrEncoded, len(largeAddrEncoded),
+			ErrInvalidNetAddrType, ErrVarBytesTooLong},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Encode to wire format.
+		w := newFixedWriter(test.max)
+		err := test.in.BtcEncode(w, pver)
+		if !errors.Is(err, test.writeErr) {
+			t.Errorf("BtcEncode #%d wrong error got: %v, want: %v",
+				i, err, test.writeErr)
+			continue
+		}
+
+		// Decode from wire format.
+		var msg MsgAddrV2
+		r := newFixedReader(test.max, test.buf)
+		err = msg.BtcDecode(r, pver)
+		if !errors.Is(err, test.readErr) {
+			t.Errorf("BtcDecode #%d wrong error got: %v, want: %v",
+				i, err, test.readErr)
+			continue
+		}
+	}
+}
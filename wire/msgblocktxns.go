@@ -0,0 +1,140 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+)
+
+// MsgBlockTxns implements the Message interface and represents a blocktxns
+// message.  It is used to deliver the transactions of a block requested via a
+// getblocktxns message (MsgGetBlockTxns) in the same order as the requested
+// regular and stake transaction tree indexes, respectively.
+//
+// This message was not added until protocol versions starting with
+// CmpctBlockVersion.
+type MsgBlockTxns struct {
+	BlockHash     chainhash.Hash
+	Transactions  []*MsgTx
+	STransactions []*MsgTx
+}
+
+// readBlockTxns reads a list of transactions from r.
+func readBlockTxns(op string, r io.Reader, pver uint32) ([]*MsgTx, error) {
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit to max transactions per tree to prevent memory exhaustion.
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	if count > maxTxPerTree {
+		msg := fmt.Sprintf("too many transactions to fit into a block "+
+			"[count %d, max %d]", count, maxTxPerTree)
+		return nil, messageError(op, ErrTooManyTxs, msg)
+	}
+
+	txns := make([]*MsgTx, 0, count)
+	for i := uint64(0); i < count; i++ {
+		var tx MsgTx
+		if err := tx.BtcDecode(r, pver); err != nil {
+			return nil, err
+		}
+		txns = append(txns, &tx)
+	}
+	return txns, nil
+}
+
+// writeBlockTxns writes a list of transactions to w.
+func writeBlockTxns(op string, w io.Writer, pver uint32, txns []*MsgTx) error {
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	count := uint64(len(txns))
+	if count > maxTxPerTree {
+		msg := fmt.Sprintf("too many transactions to fit into a block "+
+			"[count %d, max %d]", count, maxTxPerTree)
+		return messageError(op, ErrTooManyTxs, msg)
+	}
+
+	err := WriteVarInt(w, pver, count)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txns {
+		if err := tx.BtcEncode(w, pver); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BtcDecode decodes r using the protocol encoding into the receiver.  This is
+// part of the Message interface implementation.
+func (msg *MsgBlockTxns) BtcDecode(r io.Reader, pver uint32) error {
+	const op = "MsgBlockTxns.BtcDecode"
+	if pver < CmpctBlockVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	err := readElement(r, &msg.BlockHash)
+	if err != nil {
+		return err
+	}
+	msg.Transactions, err = readBlockTxns(op, r, pver)
+	if err != nil {
+		return err
+	}
+	msg.STransactions, err = readBlockTxns(op, r, pver)
+	return err
+}
+
+// BtcEncode encodes the receiver to w using the protocol encoding.  This is
+// part of the Message interface implementation.
+func (msg *MsgBlockTxns) BtcEncode(w io.Writer, pver uint32) error {
+	const op = "MsgBlockTxns.BtcEncode"
+	if pver < CmpctBlockVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	err := writeElement(w, &msg.BlockHash)
+	if err != nil {
+		return err
+	}
+	err = writeBlockTxns(op, w, pver, msg.Transactions)
+	if err != nil {
+		return err
+	}
+	return writeBlockTxns(op, w, pver, msg.STransactions)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgBlockTxns) Command() string {
+	return CmdBlockTxns
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgBlockTxns) MaxPayloadLength(pver uint32) uint32 {
+	// The transactions are a subset of a block, so the block hash and
+	// transaction counts are bounded by the header it doesn't include.
+	return MaxBlockPayload
+}
+
+// NewMsgBlockTxns returns a new blocktxns message that conforms to the Message
+// interface using the passed parameters and defaults for the remaining fields.
+func NewMsgBlockTxns(blockHash *chainhash.Hash) *MsgBlockTxns {
+	return &MsgBlockTxns{
+		BlockHash:     *blockHash,
+		Transactions:  make([]*MsgTx, 0),
+		STransactions: make([]*MsgTx, 0),
+	}
+}
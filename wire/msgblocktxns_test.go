@@ -0,0 +1,202 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// baseMsgBlockTxns returns a MsgBlockTxns struct populated with mock values
+// that are used throughout tests along with its expected encoding.  Note that
+// the tests will need to be updated if these values are changed since they
+// rely on the current values.
+func baseMsgBlockTxns() (*MsgBlockTxns, []byte) {
+	blockHash := testBlock.Header.BlockHash()
+	msg := NewMsgBlockTxns(&blockHash)
+	msg.Transactions = []*MsgTx{multiTx}
+	msg.STransactions = []*MsgTx{multiTx, multiTx}
+
+	var encoded []byte
+	encoded = append(encoded, blockHash[:]...)
+	encoded = append(encoded, 0x01) // Varint for number of txns
+	encoded = append(encoded, multiTxEncoded...)
+	encoded = append(encoded, 0x02) // Varint for number of stake txns
+	encoded = append(encoded, multiTxEncoded...)
+	encoded = append(encoded, multiTxEncoded...)
+	return msg, encoded
+}
+
+// TestBlockTxns tests the MsgBlockTxns API against the latest protocol
+// version.
+func TestBlockTxns(t *testing.T) {
+	pver := ProtocolVersion
+
+	// Ensure the command is expected value.
+	wantCmd := "blocktxns"
+	msg, _ := baseMsgBlockTxns()
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgBlockTxns: wrong command - got %v want %v", cmd,
+			wantCmd)
+	}
+
+	// Ensure max payload is expected value for latest protocol version.
+	wantPayload := uint32(MaxBlockPayload)
+	maxPayload := msg.MaxPayloadLength(pver)
+	if maxPayload != wantPayload {
+		t.Errorf("MaxPayloadLength: wrong max payload length for protocol "+
+			"version %d - got %v, want %v", pver, maxPayload, wantPayload)
+	}
+}
+
+// TestBlockTxnsPreviousProtocol tests the MsgBlockTxns API against the
+// protocol prior to version CmpctBlockVersion.
+func TestBlockTxnsPreviousProtocol(t *testing.T) {
+	// Use the protocol version just prior to CmpctBlockVersion changes.
+	pver := CmpctBlockVersion - 1
+
+	msg, _ := baseMsgBlockTxns()
+
+	// Test encode with old protocol version.
+	var buf bytes.Buffer
+	err := msg.BtcEncode(&buf, pver)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Errorf("unexpected error when encoding for protocol version %d, "+
+			"prior to message introduction - got %v, want %v", pver,
+			err, ErrMsgInvalidForPVer)
+	}
+
+	// Test decode with old protocol version.
+	var readmsg MsgBlockTxns
+	err = readmsg.BtcDecode(&buf, pver)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Errorf("unexpected error when decoding for protocol version %d, "+
+			"prior to message introduction - got %v, want %v", pver,
+			err, ErrMsgInvalidForPVer)
+	}
+}
+
+// TestBlockTxnsWire tests the MsgBlockTxns wire encode and decode for various
+// protocol versions.
+func TestBlockTxnsWire(t *testing.T) {
+	msgBlockTxns, msgBlockTxnsEncoded := baseMsgBlockTxns()
+
+	tests := []struct {
+		in   *MsgBlockTxns // Message to encode
+		out  *MsgBlockTxns // Expected decoded message
+		buf  []byte        // Wire encoding
+		pver uint32        // Protocol version for wire encoding
+	}{{
+		// Latest protocol version.
+		msgBlockTxns,
+		msgBlockTxns,
+		msgBlockTxnsEncoded,
+		ProtocolVersion,
+	}, {
+		// Protocol version CmpctBlockVersion.
+		msgBlockTxns,
+		msgBlockTxns,
+		msgBlockTxnsEncoded,
+		CmpctBlockVersion,
+	}}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Encode the message to wire format.
+		var buf bytes.Buffer
+		err := test.in.BtcEncode(&buf, test.pver)
+		if err != nil {
+			t.Errorf("BtcEncode #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("BtcEncode #%d\n got: %s want: %s", i,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.buf))
+			continue
+		}
+
+		// Decode the message from wire format.
+		var msg MsgBlockTxns
+		rbuf := bytes.NewReader(test.buf)
+		err = msg.BtcDecode(rbuf, test.pver)
+		if err != nil {
+			t.Errorf("BtcDecode #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(&msg, test.out) {
+			t.Errorf("BtcDecode #%d\n got: %s want: %s", i, spew.Sdump(&msg),
+				spew.Sdump(test.out))
+			continue
+		}
+	}
+}
+
+// TestBlockTxnsWireErrors performs negative tests against wire encode and
+// decode of MsgBlockTxns to confirm error paths work correctly.
+func TestBlockTxnsWireErrors(t *testing.T) {
+	pver := ProtocolVersion
+	baseBlockTxns, baseBlockTxnsEncoded := baseMsgBlockTxns()
+	txLen := len(multiTxEncoded)
+
+	// Message with more transactions than fit in a block.
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	tooMany := NewMsgBlockTxns(&baseBlockTxns.BlockHash)
+	tooMany.STransactions = make([]*MsgTx, maxTxPerTree+1)
+	tooManyEncoded := append([]byte{}, baseBlockTxnsEncoded[:32]...)
+	tooManyEncoded = append(tooManyEncoded, 0x00, 0xfd, uint8(maxTxPerTree+1),
+		uint8((maxTxPerTree+1)>>8))
+
+	tests := []struct {
+		in       *MsgBlockTxns // Value to encode
+		buf      []byte        // Wire encoding
+		pver     uint32        // Protocol version for wire encoding
+		max      int           // Max size of fixed buffer to induce errors
+		writeErr error         // Expected write error
+		readErr  error         // Expected read error
+	}{
+		// Force error in start of block hash.
+		{baseBlockTxns, baseBlockTxnsEncoded, pver, 0, io.ErrShortWrite, io.EOF},
+		// Force error in middle of block hash.
+		{baseBlockTxns, baseBlockTxnsEncoded, pver, 8, io.ErrShortWrite, io.ErrUnexpectedEOF},
+		// Force error in number of txns.
+		{baseBlockTxns, baseBlockTxnsEncoded, pver, 32, io.ErrShortWrite, io.EOF},
+		// Force error in tx.
+		{baseBlockTxns, baseBlockTxnsEncoded, pver, 33, io.ErrShortWrite, io.EOF},
+		// Force error in number of stake txns.
+		{baseBlockTxns, baseBlockTxnsEncoded, pver, 33 + txLen, io.ErrShortWrite, io.EOF},
+		// Force error in stake tx.
+		{baseBlockTxns, baseBlockTxnsEncoded, pver, 34 + txLen, io.ErrShortWrite, io.EOF},
+		// Force error with too many stake txns.
+		{tooMany, tooManyEncoded, pver, len(tooManyEncoded), ErrTooManyTxs,
+			ErrTooManyTxs},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Encode to wire format.
+		w := newFixedWriter(test.max)
+		err := test.in.BtcEncode(w, test.pver)
+		if !errors.Is(err, test.writeErr) {
+			t.Errorf("BtcEncode #%d wrong error got: %v, want: %v", i, err,
+				test.writeErr)
+			continue
+		}
+
+		// Decode from wire format.
+		var msg MsgBlockTxns
+		r := newFixedReader(test.max, test.buf)
+		err = msg.BtcDecode(r, test.pver)
+		if !errors.Is(err, test.readErr) {
+			t.Errorf("BtcDecode #%d wrong error got: %v, want: %v", i, err,
+				test.readErr)
+			continue
+		}
+	}
+}
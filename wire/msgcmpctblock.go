@@ -0,0 +1,326 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+)
+
+const (
+	// ShortTxIDSize is the number of bytes used to encode a short
+	// transaction ID in a compact block.
+	ShortTxIDSize = 6
+
+	// ShortTxIDKeySize is the size of the key used to calculate the short
+	// transaction IDs of a compact block.
+	ShortTxIDKeySize = 16
+
+	// maxShortTxID is the maximum value of a short transaction ID.
+	maxShortTxID = 1<<(ShortTxIDSize*8) - 1
+)
+
+// PrefilledTx defines a transaction that is sent in full as part of a compact
+// block along with its index in the transaction tree it belongs to.
+type PrefilledTx struct {
+	Index uint32
+	Tx    *MsgTx
+}
+
+// MsgCmpctBlock implements the Message interface and represents a cmpctblock
+// message.  It is used to relay a block by sending its header along with short
+// transaction IDs for the transactions the receiver is expected to already
+// have and the full transactions it is not, which are referred to as
+// prefilled transactions.
+//
+// The regular and stake transaction trees are encoded independently.  The
+// number of transactions in each tree is the number of short IDs plus the
+// number of prefilled transactions for the tree.  The prefilled transactions
+// are in ascending order of their index in the tree and the short IDs are in
+// the order of the remaining transactions.
+//
+// Short transaction IDs are calculated with CmpctBlockShortTxID using the key
+// returned by ShortTxIDKey.  The key commits to both the header and a nonce
+// chosen by the sender in order to make it impractical to produce collisions
+// across the network.
+//
+// This message was not added until protocol versions starting with
+// CmpctBlockVersion.
+type MsgCmpctBlock struct {
+	Header        BlockHeader
+	Nonce         uint64
+	ShortTxIDs    []uint64
+	PrefilledTxs  []PrefilledTx
+	ShortSTxIDs   []uint64
+	PrefilledSTxs []PrefilledTx
+}
+
+// ShortTxIDKey returns the key used to calculate the short transaction IDs of
+// the compact block.  It is the first ShortTxIDKeySize bytes of the hash of
+// the serialized header followed by the nonce.
+func (msg *MsgCmpctBlock) ShortTxIDKey() [ShortTxIDKeySize]byte {
+	buf := bytes.NewBuffer(make([]byte, 0, MaxBlockHeaderPayload+8))
+	// Writing to a bytes.Buffer can't fail.
+	_ = writeBlockHeader(buf, 0, &msg.Header)
+	_ = writeElement(buf, msg.Nonce)
+	hash := chainhash.HashH(buf.Bytes())
+
+	var key [ShortTxIDKeySize]byte
+	copy(key[:], hash[:])
+	return key
+}
+
+// CmpctBlockShortTxID returns the short transaction ID of the transaction with
+// the provided hash for a compact block with the provided short transaction ID
+// key.
+func CmpctBlockShortTxID(key *[ShortTxIDKeySize]byte, txHash *chainhash.Hash) uint64 {
+	var buf [ShortTxIDKeySize + chainhash.HashSize]byte
+	copy(buf[:], key[:])
+	copy(buf[ShortTxIDKeySize:], txHash[:])
+	hash := chainhash.HashH(buf[:])
+	return binary.LittleEndian.Uint64(hash[:8]) & maxShortTxID
+}
+
+// readShortTxIDs reads a list of short transaction IDs from r.
+func readShortTxIDs(op string, r io.Reader, pver uint32) ([]uint64, error) {
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit to max transactions per tree to prevent memory exhaustion.
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	if count > maxTxPerTree {
+		msg := fmt.Sprintf("too many short transaction ids to fit into a "+
+			"block [count %d, max %d]", count, maxTxPerTree)
+		return nil, messageError(op, ErrTooManyTxs, msg)
+	}
+
+	ids := make([]uint64, count)
+	var buf [8]byte
+	for i := range ids {
+		if _, err := io.ReadFull(r, buf[:ShortTxIDSize]); err != nil {
+			return nil, err
+		}
+		ids[i] = binary.LittleEndian.Uint64(buf[:])
+	}
+	return ids, nil
+}
+
+// writeShortTxIDs writes a list of short transaction IDs to w.
+func writeShortTxIDs(op string, w io.Writer, pver uint32, ids []uint64) error {
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	count := uint64(len(ids))
+	if count > maxTxPerTree {
+		msg := fmt.Sprintf("too many short transaction ids to fit into a "+
+			"block [count %d, max %d]", count, maxTxPerTree)
+		return messageError(op, ErrTooManyTxs, msg)
+	}
+
+	err := WriteVarInt(w, pver, count)
+	if err != nil {
+		return err
+	}
+
+	var buf [8]byte
+	for _, id := range ids {
+		binary.LittleEndian.PutUint64(buf[:], id)
+		if _, err := w.Write(buf[:ShortTxIDSize]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkTxIndex returns an error if the provided transaction index does not
+// come after the previous index or is not less than the number of transactions
+// in the tree.  A negative previous index indicates there is none.
+func checkTxIndex(op string, index uint32, prev int64, numTxns uint64) error {
+	if int64(index) <= prev || uint64(index) >= numTxns {
+		msg := fmt.Sprintf("transaction index %d is out of range or not in "+
+			"ascending order [prev %d, num txns %d]", index, prev, numTxns)
+		return messageError(op, ErrInvalidTxIndex, msg)
+	}
+	return nil
+}
+
+// readPrefilledTxs reads a list of prefilled transactions from r for a tree
+// that also contains the provided number of short transaction IDs.
+func readPrefilledTxs(op string, r io.Reader, pver uint32, numShortIDs int) ([]PrefilledTx, error) {
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit to max transactions per tree to prevent memory exhaustion.
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	numTxns := count + uint64(numShortIDs)
+	if numTxns > maxTxPerTree {
+		msg := fmt.Sprintf("too many transactions to fit into a block "+
+			"[count %d, max %d]", numTxns, maxTxPerTree)
+		return nil, messageError(op, ErrTooManyTxs, msg)
+	}
+
+	prefilled := make([]PrefilledTx, 0, count)
+	prev := int64(-1)
+	for i := uint64(0); i < count; i++ {
+		var ptx PrefilledTx
+		index, err := ReadVarInt(r, pver)
+		if err != nil {
+			return nil, err
+		}
+		if index > uint64(^uint32(0)) {
+			msg := fmt.Sprintf("transaction index %d is out of range", index)
+			return nil, messageError(op, ErrInvalidTxIndex, msg)
+		}
+		ptx.Index = uint32(index)
+		if err := checkTxIndex(op, ptx.Index, prev, numTxns); err != nil {
+			return nil, err
+		}
+		prev = int64(ptx.Index)
+
+		var tx MsgTx
+		if err := tx.BtcDecode(r, pver); err != nil {
+			return nil, err
+		}
+		ptx.Tx = &tx
+		prefilled = append(prefilled, ptx)
+	}
+	return prefilled, nil
+}
+
+// writePrefilledTxs writes a list of prefilled transactions to w for a tree
+// that also contains the provided number of short transaction IDs.
+func writePrefilledTxs(op string, w io.Writer, pver uint32, prefilled []PrefilledTx, numShortIDs int) error {
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	numTxns := uint64(len(prefilled) + numShortIDs)
+	if numTxns > maxTxPerTree {
+		msg := fmt.Sprintf("too many transactions to fit into a block "+
+			"[count %d, max %d]", numTxns, maxTxPerTree)
+		return messageError(op, ErrTooManyTxs, msg)
+	}
+
+	err := WriteVarInt(w, pver, uint64(len(prefilled)))
+	if err != nil {
+		return err
+	}
+
+	prev := int64(-1)
+	for _, ptx := range prefilled {
+		if err := checkTxIndex(op, ptx.Index, prev, numTxns); err != nil {
+			return err
+		}
+		prev = int64(ptx.Index)
+
+		if err := WriteVarInt(w, pver, uint64(ptx.Index)); err != nil {
+			return err
+		}
+		if err := ptx.Tx.BtcEncode(w, pver); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BtcDecode decodes r using the protocol encoding into the receiver.  This is
+// part of the Message interface implementation.
+func (msg *MsgCmpctBlock) BtcDecode(r io.Reader, pver uint32) error {
+	const op = "MsgCmpctBlock.BtcDecode"
+	if pver < CmpctBlockVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	err := readBlockHeader(r, pver, &msg.Header)
+	if err != nil {
+		return err
+	}
+	if err := readElement(r, &msg.Nonce); err != nil {
+		return err
+	}
+
+	msg.ShortTxIDs, err = readShortTxIDs(op, r, pver)
+	if err != nil {
+		return err
+	}
+	msg.PrefilledTxs, err = readPrefilledTxs(op, r, pver, len(msg.ShortTxIDs))
+	if err != nil {
+		return err
+	}
+	msg.ShortSTxIDs, err = readShortTxIDs(op, r, pver)
+	if err != nil {
+		return err
+	}
+	msg.PrefilledSTxs, err = readPrefilledTxs(op, r, pver, len(msg.ShortSTxIDs))
+	return err
+}
+
+// BtcEncode encodes the receiver to w using the protocol encoding.  This is
+// part of the Message interface implementation.
+func (msg *MsgCmpctBlock) BtcEncode(w io.Writer, pver uint32) error {
+	const op = "MsgCmpctBlock.BtcEncode"
+	if pver < CmpctBlockVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	err := writeBlockHeader(w, pver, &msg.Header)
+	if err != nil {
+		return err
+	}
+	if err := writeElement(w, msg.Nonce); err != nil {
+		return err
+	}
+
+	err = writeShortTxIDs(op, w, pver, msg.ShortTxIDs)
+	if err != nil {
+		return err
+	}
+	err = writePrefilledTxs(op, w, pver, msg.PrefilledTxs, len(msg.ShortTxIDs))
+	if err != nil {
+		return err
+	}
+	err = writeShortTxIDs(op, w, pver, msg.ShortSTxIDs)
+	if err != nil {
+		return err
+	}
+	return writePrefilledTxs(op, w, pver, msg.PrefilledSTxs,
+		len(msg.ShortSTxIDs))
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgCmpctBlock) Command() string {
+	return CmdCmpctBlock
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgCmpctBlock) MaxPayloadLength(pver uint32) uint32 {
+	// A compact block is never larger than the block it represents aside
+	// from the nonce and the prefilled transaction indexes, which are
+	// bounded by the short transaction IDs they replace.
+	return MaxBlockPayload
+}
+
+// NewMsgCmpctBlock returns a new cmpctblock message that conforms to the
+// Message interface using the passed parameters and defaults for the remaining
+// fields.
+func NewMsgCmpctBlock(header *BlockHeader, nonce uint64) *MsgCmpctBlock {
+	return &MsgCmpctBlock{
+		Header:        *header,
+		Nonce:         nonce,
+		ShortTxIDs:    make([]uint64, 0),
+		PrefilledTxs:  make([]PrefilledTx, 0),
+		ShortSTxIDs:   make([]uint64, 0),
+		PrefilledSTxs: make([]PrefilledTx, 0),
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+)
+
+// baseMsgCmpctBlock returns a MsgCmpctBlock struct populated with mock values
+// that are used throughout tests along with its expected encoding.  Note that
+// the tests will need to be updated if these values are changed since they
+// rely on the current values.
+func baseMsgCmpctBlock() (*MsgCmpctBlock, []byte) {
+	msg := NewMsgCmpctBlock(&testBlock.Header, 0x0102030405060708)
+	msg.ShortTxIDs = []uint64{0x010203040506, maxShortTxID}
+	msg.PrefilledTxs = []PrefilledTx{{Index: 1, Tx: multiTx}}
+	msg.PrefilledSTxs = []PrefilledTx{{Index: 0, Tx: multiTx}}
+
+	var encoded []byte
+	encoded = append(encoded, testBlockBytes[:MaxBlockHeaderPayload]...)
+	encoded = append(encoded, []byte{
+		0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, // Nonce
+		0x02,                               // Varint for number of short IDs
+		0x06, 0x05, 0x04, 0x03, 0x02, 0x01, // Short ID 1
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // Short ID 2
+		0x01, // Varint for number of prefilled txns
+		0x01, // Varint for prefilled tx index
+	}...)
+	encoded = append(encoded, multiTxEncoded...)
+	encoded = append(encoded, []byte{
+		0x00, // Varint for number of stake short IDs
+		0x01, // Varint for number of prefilled stake txns
+		0x00, // Varint for prefilled stake tx index
+	}...)
+	encoded = append(encoded, multiTxEncoded...)
+	return msg, encoded
+}
+
+// TestCmpctBlock tests the MsgCmpctBlock API against the latest protocol
+// version.
+func TestCmpctBlock(t *testing.T) {
+	pver := ProtocolVersion
+
+	// Ensure the command is expected value.
+	wantCmd := "cmpctblock"
+	msg, _ := baseMsgCmpctBlock()
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgCmpctBlock: wrong command - got %v want %v", cmd,
+			wantCmd)
+	}
+
+	// Ensure max payload is expected value for latest protocol version.
+	wantPayload := uint32(MaxBlockPayload)
+	maxPayload := msg.MaxPayloadLength(pver)
+	if maxPayload != wantPayload {
+		t.Errorf("MaxPayloadLength: wrong max payload length for protocol "+
+			"version %d - got %v, want %v", pver, maxPayload, wantPayload)
+	}
+}
+
+// TestCmpctBlockShortTxIDs ensures the short transaction IDs of a compact
+// block depend on both the header and nonce and are within range.
+func TestCmpctBlockShortTxIDs(t *testing.T) {
+	msg, _ := baseMsgCmpctBlock()
+	txHash := multiTx.TxHash()
+	key := msg.ShortTxIDKey()
+	shortID := CmpctBlockShortTxID(&key, &txHash)
+	if shortID > maxShortTxID {
+		t.Fatalf("short id %x exceeds the max short id", shortID)
+	}
+	if key2 := msg.ShortTxIDKey(); key2 != key {
+		t.Fatalf("short id key is not deterministic -- got %x, want %x",
+			key2, key)
+	}
+
+	// Ensure changing the nonce changes the key and short ID.
+	msg.Nonce++
+	key2 := msg.ShortTxIDKey()
+	if key2 == key {
+		t.Fatal("short id key did not change with the nonce")
+	}
+	if CmpctBlockShortTxID(&key2, &txHash) == shortID {
+		t.Fatal("short id did not change with the nonce")
+	}
+
+	// Ensure changing the header changes the key.
+	msg.Nonce--
+	msg.Header.Height++
+	if msg.ShortTxIDKey() == key {
+		t.Fatal("short id key did not change with the header")
+	}
+
+	// Ensure different transactions have different short IDs.
+	otherHash := chainhash.Hash{0x01}
+	if CmpctBlockShortTxID(&key, &otherHash) == shortID {
+		t.Fatal("short id is the same for different transactions")
+	}
+}
+
+// TestCmpctBlockPreviousProtocol tests the MsgCmpctBlock API against the
+// protocol prior to version CmpctBlockVersion.
+func TestCmpctBlockPreviousProtocol(t *testing.T) {
+	// Use the protocol version just prior to CmpctBlockVersion changes.
+	pver := CmpctBlockVersion - 1
+
+	msg, _ := baseMsgCmpctBlock()
+
+	// Test encode with old protocol version.
+	var buf bytes.Buffer
+	err := msg.BtcEncode(&buf, pver)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Errorf("unexpected error when encoding for protocol version %d, "+
+			"prior to message introduction - got %v, want %v", pver,
+			err, ErrMsgInvalidForPVer)
+	}
+
+	// Test decode with old protocol version.
+	var readmsg MsgCmpctBlock
+	err = readmsg.BtcDecode(&buf, pver)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Errorf("unexpected error when decoding for protocol version %d, "+
+			"prior to message introduction - got %v, want %v", pver,
+			err, ErrMsgInvalidForPVer)
+	}
+}
+
+// TestCmpctBlockWire tests the MsgCmpctBlock wire encode and decode for
+// various protocol versions.
+func TestCmpctBlockWire(t *testing.T) {
+	msgCmpctBlock, msgCmpctBlockEncoded := baseMsgCmpctBlock()
+	noTxns := NewMsgCmpctBlock(&testBlock.Header, 0)
+	noTxnsEncoded := append([]byte{}, testBlockBytes[:MaxBlockHeaderPayload]...)
+	noTxnsEncoded = append(noTxnsEncoded, []byte{
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Nonce
+		0x00, 0x00, // Varints for number of short IDs and prefilled txns
+		0x00, 0x00, // Varints for number of stake short IDs and prefilled txns
+	}...)
+
+	tests := []struct {
+		in   *MsgCmpctBlock // Message to encode
+		out  *MsgCmpctBlock // Expected decoded message
+		buf  []byte         // Wire encoding
+		pver uint32         // Protocol version for wire encoding
+	}{{
+		// Latest protocol version with no transactions.
+		noTxns,
+		noTxns,
+		noTxnsEncoded,
+		ProtocolVersion,
+	}, {
+		// Latest protocol version with short IDs and prefilled txns.
+		msgCmpctBlock,
+		msgCmpctBlock,
+		msgCmpctBlockEncoded,
+		ProtocolVersion,
+	}, {
+		// Protocol version CmpctBlockVersion.
+		msgCmpctBlock,
+		msgCmpctBlock,
+		msgCmpctBlockEncoded,
+		CmpctBlockVersion,
+	}}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Encode the message to wire format.
+		var buf bytes.Buffer
+		err := test.in.BtcEncode(&buf, test.pver)
+		if err != nil {
+			t.Errorf("BtcEncode #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("BtcEncode #%d\n got: %s want: %s", i,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.buf))
+			continue
+		}
+
+		// Decode the message from wire format.
+		var msg MsgCmpctBlock
+		rbuf := bytes.NewReader(test.buf)
+		err = msg.BtcDecode(rbuf, test.pver)
+		if err != nil {
+			t.Errorf("BtcDecode #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(&msg, test.out) {
+			t.Errorf("BtcDecode #%d\n got: %s want: %s", i, spew.Sdump(&msg),
+				spew.Sdump(test.out))
+			continue
+		}
+	}
+}
+
+// TestCmpctBlockWireErrors performs negative tests against wire encode and
+// decode of MsgCmpctBlock to confirm error paths work correctly.
+func TestCmpctBlockWireErrors(t *testing.T) {
+	pver := ProtocolVersion
+	baseCmpctBlock, baseCmpctBlockEncoded := baseMsgCmpctBlock()
+	hdrLen := MaxBlockHeaderPayload
+
+	// Message with a prefilled transaction index that is out of range of the
+	// number of transactions in the tree.
+	outOfRange := NewMsgCmpctBlock(&testBlock.Header, 0)
+	outOfRange.PrefilledTxs = []PrefilledTx{{Index: 1, Tx: multiTx}}
+	outOfRangeEncoded := append([]byte{}, baseCmpctBlockEncoded[:hdrLen]...)
+	outOfRangeEncoded = append(outOfRangeEncoded, []byte{
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Nonce
+		0x00, // Varint for number of short IDs
+		0x01, // Varint for number of prefilled txns
+		0x01, // Varint for prefilled tx index
+	}...)
+
+	// Message with prefilled transaction indexes that are not ascending.
+	notAscending := NewMsgCmpctBlock(&testBlock.Header, 0)
+	notAscending.ShortSTxIDs = []uint64{1}
+	notAscending.PrefilledSTxs = []PrefilledTx{{Index: 1, Tx: multiTx},
+		{Index: 1, Tx: multiTx}}
+	notAscendingEncoded := append([]byte{}, baseCmpctBlockEncoded[:hdrLen]...)
+	notAscendingEncoded = append(notAscendingEncoded, []byte{
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Nonce
+		0x00, 0x00, // Varints for number of short IDs and prefilled txns
+		0x01,                               // Varint for number of stake short IDs
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, // Stake short ID
+		0x02, // Varint for number of prefilled stake txns
+		0x01, // Varint for prefilled stake tx index
+	}...)
+	notAscendingEncoded = append(notAscendingEncoded, multiTxEncoded...)
+	notAscendingEncoded = append(notAscendingEncoded, 0x01)
+	notAscendingEncoded = append(notAscendingEncoded, multiTxEncoded...)
+
+	// Message with more short IDs than fit in a block.
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	tooManyIDs := NewMsgCmpctBlock(&testBlock.Header, 0)
+	tooManyIDs.ShortTxIDs = make([]uint64, maxTxPerTree+1)
+	tooManyIDsEncoded := append([]byte{}, baseCmpctBlockEncoded[:hdrLen+8]...)
+	tooManyIDsEncoded = append(tooManyIDsEncoded, 0xfd)
+	tooManyIDsEncoded = append(tooManyIDsEncoded, uint8(maxTxPerTree+1),
+		uint8((maxTxPerTree+1)>>8))
+
+	// Message with more short IDs and prefilled transactions combined than
+	// fit in a block.
+	tooManyTxns := NewMsgCmpctBlock(&testBlock.Header, 0)
+	tooManyTxns.ShortTxIDs = make([]uint64, maxTxPerTree)
+	tooManyTxns.PrefilledTxs = []PrefilledTx{{Index: 0, Tx: multiTx}}
+	tooManyTxnsEncoded := append([]byte{}, baseCmpctBlockEncoded[:hdrLen+8]...)
+	tooManyTxnsEncoded = append(tooManyTxnsEncoded, 0xfd)
+	tooManyTxnsEncoded = append(tooManyTxnsEncoded, uint8(maxTxPerTree),
+		uint8(maxTxPerTree>>8))
+	tooManyTxnsEncoded = append(tooManyTxnsEncoded,
+		make([]byte, maxTxPerTree*ShortTxIDSize)...)
+	tooManyTxnsEncoded = append(tooManyTxnsEncoded, 0x01)
+
+	tests := []struct {
+		in       *MsgCmpctBlock // Value to encode
+		buf      []byte         // Wire encoding
+		pver     uint32         // Protocol version for wire encoding
+		max      int            // Max size of fixed buffer to induce errors
+		writeErr error          // Expected write error
+		readErr  error          // Expected read error
+	}{
+		// Force error in header.
+		{baseCmpctBlock, baseCmpctBlockEncoded, pver, 0, io.ErrShortWrite, io.EOF},
+		// Force error in nonce.
+		{baseCmpctBlock, baseCmpctBlockEncoded, pver, hdrLen, io.ErrShortWrite, io.EOF},
+		// Force error in number of short IDs.
+		{baseCmpctBlock, baseCmpctBlockEncoded, pver, hdrLen + 8, io.ErrShortWrite, io.EOF},
+		// Force error in middle of short ID.
+		{baseCmpctBlock, baseCmpctBlockEncoded, pver, hdrLen + 12, io.ErrShortWrite, io.ErrUnexpectedEOF},
+		// Force error in number of prefilled txns.
+		{baseCmpctBlock, baseCmpctBlockEncoded, pver, hdrLen + 21, io.ErrShortWrite, io.EOF},
+		// Force error in prefilled tx index.
+		{baseCmpctBlock, baseCmpctBlockEncoded, pver, hdrLen + 22, io.ErrShortWrite, io.EOF},
+		// Force error in prefilled tx.
+		{baseCmpctBlock, baseCmpctBlockEncoded, pver, hdrLen + 23, io.ErrShortWrite, io.EOF},
+		// Force error in number of stake short IDs.
+		{baseCmpctBlock, baseCmpctBlockEncoded, pver,
+			hdrLen + 23 + len(multiTxEncoded), io.ErrShortWrite, io.EOF},
+		// Force error in prefilled tx index out of range.
+		{outOfRange, outOfRangeEncoded, pver, len(outOfRangeEncoded),
+			ErrInvalidTxIndex, ErrInvalidTxIndex},
+		// Force error in prefilled tx indexes not ascending.
+		{notAscending, notAscendingEncoded, pver, len(notAscendingEncoded),
+			ErrInvalidTxIndex, ErrInvalidTxIndex},
+		// Force error with too many short IDs.
+		{tooManyIDs, tooManyIDsEncoded, pver, len(tooManyIDsEncoded),
+			ErrTooManyTxs, ErrTooManyTxs},
+		// Force error with too many transactions.
+		{tooManyTxns, tooManyTxnsEncoded, pver, len(tooManyTxnsEncoded),
+			ErrTooManyTxs, ErrTooManyTxs},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Encode to wire format.
+		w := newFixedWriter(test.max)
+		err := test.in.BtcEncode(w, test.pver)
+		if !errors.Is(err, test.writeErr) {
+			t.Errorf("BtcEncode #%d wrong error got: %v, want: %v", i, err,
+				test.writeErr)
+			continue
+		}
+
+		// Decode from wire format.
+		var msg MsgCmpctBlock
+		r := newFixedReader(test.max, test.buf)
+		err = msg.BtcDecode(r, test.pver)
+		if !errors.Is(err, test.readErr) {
+			t.Errorf("BtcDecode #%d wrong error got: %v, want: %v", i, err,
+				test.readErr)
+			continue
+		}
+	}
+}
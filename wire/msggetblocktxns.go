@@ -0,0 +1,162 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+)
+
+// MsgGetBlockTxns implements the Message interface and represents a
+// getblocktxns message.  It is used to request the transactions of a block
+// that were announced in a compact block (MsgCmpctBlock) and could not be
+// found locally.  The transactions are identified by their index in the
+// regular and stake transaction trees of the block, respectively, in ascending
+// order.
+//
+// The response is sent in a MsgBlockTxns message.
+//
+// This message was not added until protocol versions starting with
+// CmpctBlockVersion.
+type MsgGetBlockTxns struct {
+	BlockHash  chainhash.Hash
+	TxIndexes  []uint32
+	STxIndexes []uint32
+}
+
+// readTxIndexes reads a list of ascending transaction indexes from r.
+func readTxIndexes(op string, r io.Reader, pver uint32) ([]uint32, error) {
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit to max transactions per tree to prevent memory exhaustion.
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	if count > maxTxPerTree {
+		msg := fmt.Sprintf("too many transaction indexes to fit into a "+
+			"block [count %d, max %d]", count, maxTxPerTree)
+		return nil, messageError(op, ErrTooManyTxs, msg)
+	}
+
+	indexes := make([]uint32, 0, count)
+	prev := int64(-1)
+	for i := uint64(0); i < count; i++ {
+		index, err := ReadVarInt(r, pver)
+		if err != nil {
+			return nil, err
+		}
+		if index > uint64(^uint32(0)) {
+			msg := fmt.Sprintf("transaction index %d is out of range", index)
+			return nil, messageError(op, ErrInvalidTxIndex, msg)
+		}
+		err = checkTxIndex(op, uint32(index), prev, maxTxPerTree)
+		if err != nil {
+			return nil, err
+		}
+		prev = int64(index)
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
+
+// writeTxIndexes writes a list of ascending transaction indexes to w.
+func writeTxIndexes(op string, w io.Writer, pver uint32, indexes []uint32) error {
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	count := uint64(len(indexes))
+	if count > maxTxPerTree {
+		msg := fmt.Sprintf("too many transaction indexes to fit into a "+
+			"block [count %d, max %d]", count, maxTxPerTree)
+		return messageError(op, ErrTooManyTxs, msg)
+	}
+
+	err := WriteVarInt(w, pver, count)
+	if err != nil {
+		return err
+	}
+
+	prev := int64(-1)
+	for _, index := range indexes {
+		if err := checkTxIndex(op, index, prev, maxTxPerTree); err != nil {
+			return err
+		}
+		prev = int64(index)
+
+		if err := WriteVarInt(w, pver, uint64(index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BtcDecode decodes r using the protocol encoding into the receiver.  This is
+// part of the Message interface implementation.
+func (msg *MsgGetBlockTxns) BtcDecode(r io.Reader, pver uint32) error {
+	const op = "MsgGetBlockTxns.BtcDecode"
+	if pver < CmpctBlockVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	err := readElement(r, &msg.BlockHash)
+	if err != nil {
+		return err
+	}
+	msg.TxIndexes, err = readTxIndexes(op, r, pver)
+	if err != nil {
+		return err
+	}
+	msg.STxIndexes, err = readTxIndexes(op, r, pver)
+	return err
+}
+
+// BtcEncode encodes the receiver to w using the protocol encoding.  This is
+// part of the Message interface implementation.
+func (msg *MsgGetBlockTxns) BtcEncode(w io.Writer, pver uint32) error {
+	const op = "MsgGetBlockTxns.BtcEncode"
+	if pver < CmpctBlockVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	err := writeElement(w, &msg.BlockHash)
+	if err != nil {
+		return err
+	}
+	err = writeTxIndexes(op, w, pver, msg.TxIndexes)
+	if err != nil {
+		return err
+	}
+	return writeTxIndexes(op, w, pver, msg.STxIndexes)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgGetBlockTxns) Command() string {
+	return CmdGetBlockTxns
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgGetBlockTxns) MaxPayloadLength(pver uint32) uint32 {
+	// Block hash + 2 * (num indexes (varint) + max indexes * max varint
+	// size of a uint32 index).
+	maxIndexesLen := MaxVarIntPayload + uint32(MaxTxPerTxTree(pver))*5
+	return chainhash.HashSize + 2*maxIndexesLen
+}
+
+// NewMsgGetBlockTxns returns a new getblocktxns message that conforms to the
+// Message interface using the passed parameters.
+func NewMsgGetBlockTxns(blockHash *chainhash.Hash, txIndexes, stxIndexes []uint32) *MsgGetBlockTxns {
+	return &MsgGetBlockTxns{
+		BlockHash:  *blockHash,
+		TxIndexes:  txIndexes,
+		STxIndexes: stxIndexes,
+	}
+}
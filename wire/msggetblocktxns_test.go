@@ -0,0 +1,228 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/monetarium/monetarium-node/chaincfg/chainhash"
+)
+
+// baseMsgGetBlockTxns returns a MsgGetBlockTxns struct populated with mock
+// values that are used throughout tests along with its expected encoding.
+// Note that the tests will need to be updated if these values are changed
+// since they rely on the current values.
+func baseMsgGetBlockTxns(t *testing.T) (*MsgGetBlockTxns, []byte) {
+	t.Helper()
+
+	// Mock block hash.
+	hashStr := "000000000000c41019872ff7db8fd2e9bfa05f42d3f8fee8e895e8c1e5b8dcba"
+	hash, err := chainhash.NewHashFromStr(hashStr)
+	if err != nil {
+		t.Fatalf("NewHashFromStr: %v", err)
+	}
+
+	msg := NewMsgGetBlockTxns(hash, []uint32{1, 2, 300}, []uint32{0})
+	encoded := []byte{
+		0xba, 0xdc, 0xb8, 0xe5, 0xc1, 0xe8, 0x95, 0xe8,
+		0xe8, 0xfe, 0xf8, 0xd3, 0x42, 0x5f, 0xa0, 0xbf,
+		0xe9, 0xd2, 0x8f, 0xdb, 0xf7, 0x2f, 0x87, 0x19,
+		0x10, 0xc4, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Mock block hash
+		0x03,       // Varint for number of tx indexes
+		0x01, 0x02, // Tx indexes 1 and 2
+		0xfd, 0x2c, 0x01, // Tx index 300
+		0x01, // Varint for number of stake tx indexes
+		0x00, // Stake tx index 0
+	}
+	return msg, encoded
+}
+
+// TestGetBlockTxns tests the MsgGetBlockTxns API against the latest protocol
+// version.
+func TestGetBlockTxns(t *testing.T) {
+	pver := ProtocolVersion
+
+	// Ensure the command is expected value.
+	wantCmd := "getblocktxns"
+	msg, _ := baseMsgGetBlockTxns(t)
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgGetBlockTxns: wrong command - got %v want %v", cmd,
+			wantCmd)
+	}
+
+	// Ensure max payload is expected value for latest protocol version.
+	// Block hash + 2 * (num indexes varint + max indexes * 5 bytes).
+	wantPayload := uint32(32 + 2*(9+MaxTxPerTxTree(pver)*5))
+	maxPayload := msg.MaxPayloadLength(pver)
+	if maxPayload != wantPayload {
+		t.Errorf("MaxPayloadLength: wrong max payload length for protocol "+
+			"version %d - got %v, want %v", pver, maxPayload, wantPayload)
+	}
+
+	// Ensure max payload length is not more than MaxMessagePayload.
+	if maxPayload > MaxMessagePayload {
+		t.Fatalf("MaxPayloadLength: payload length (%v) for protocol version "+
+			"%d exceeds MaxMessagePayload (%v).", maxPayload, pver,
+			MaxMessagePayload)
+	}
+}
+
+// TestGetBlockTxnsPreviousProtocol tests the MsgGetBlockTxns API against the
+// protocol prior to version CmpctBlockVersion.
+func TestGetBlockTxnsPreviousProtocol(t *testing.T) {
+	// Use the protocol version just prior to CmpctBlockVersion changes.
+	pver := CmpctBlockVersion - 1
+
+	msg, _ := baseMsgGetBlockTxns(t)
+
+	// Test encode with old protocol version.
+	var buf bytes.Buffer
+	err := msg.BtcEncode(&buf, pver)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Errorf("unexpected error when encoding for protocol version %d, "+
+			"prior to message introduction - got %v, want %v", pver,
+			err, ErrMsgInvalidForPVer)
+	}
+
+	// Test decode with old protocol version.
+	var readmsg MsgGetBlockTxns
+	err = readmsg.BtcDecode(&buf, pver)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Errorf("unexpected error when decoding for protocol version %d, "+
+			"prior to message introduction - got %v, want %v", pver,
+			err, ErrMsgInvalidForPVer)
+	}
+}
+
+// TestGetBlockTxnsWire tests the MsgGetBlockTxns wire encode and decode for
+// various protocol versions.
+func TestGetBlockTxnsWire(t *testing.T) {
+	msgGetBlockTxns, msgGetBlockTxnsEncoded := baseMsgGetBlockTxns(t)
+
+	tests := []struct {
+		in   *MsgGetBlockTxns // Message to encode
+		out  *MsgGetBlockTxns // Expected decoded message
+		buf  []byte           // Wire encoding
+		pver uint32           // Protocol version for wire encoding
+	}{{
+		// Latest protocol version.
+		msgGetBlockTxns,
+		msgGetBlockTxns,
+		msgGetBlockTxnsEncoded,
+		ProtocolVersion,
+	}, {
+		// Protocol version CmpctBlockVersion.
+		msgGetBlockTxns,
+		msgGetBlockTxns,
+		msgGetBlockTxnsEncoded,
+		CmpctBlockVersion,
+	}}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Encode the message to wire format.
+		var buf bytes.Buffer
+		err := test.in.BtcEncode(&buf, test.pver)
+		if err != nil {
+			t.Errorf("BtcEncode #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("BtcEncode #%d\n got: %s want: %s", i,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.buf))
+			continue
+		}
+
+		// Decode the message from wire format.
+		var msg MsgGetBlockTxns
+		rbuf := bytes.NewReader(test.buf)
+		err = msg.BtcDecode(rbuf, test.pver)
+		if err != nil {
+			t.Errorf("BtcDecode #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(&msg, test.out) {
+			t.Errorf("BtcDecode #%d\n got: %s want: %s", i, spew.Sdump(&msg),
+				spew.Sdump(test.out))
+			continue
+		}
+	}
+}
+
+// TestGetBlockTxnsWireErrors performs negative tests against wire encode and
+// decode of MsgGetBlockTxns to confirm error paths work correctly.
+func TestGetBlockTxnsWireErrors(t *testing.T) {
+	pver := ProtocolVersion
+	baseGetBlockTxns, baseGetBlockTxnsEncoded := baseMsgGetBlockTxns(t)
+
+	// Message with tx indexes that are not ascending.
+	notAscending := NewMsgGetBlockTxns(&baseGetBlockTxns.BlockHash,
+		[]uint32{2, 1}, []uint32{})
+	notAscendingEncoded := append([]byte{}, baseGetBlockTxnsEncoded[:32]...)
+	notAscendingEncoded = append(notAscendingEncoded, 0x02, 0x02, 0x01)
+
+	// Message with more tx indexes than fit in a block.
+	maxTxPerTree := MaxTxPerTxTree(pver)
+	tooMany := NewMsgGetBlockTxns(&baseGetBlockTxns.BlockHash,
+		make([]uint32, maxTxPerTree+1), []uint32{})
+	tooManyEncoded := append([]byte{}, baseGetBlockTxnsEncoded[:32]...)
+	tooManyEncoded = append(tooManyEncoded, 0xfd, uint8(maxTxPerTree+1),
+		uint8((maxTxPerTree+1)>>8))
+
+	tests := []struct {
+		in       *MsgGetBlockTxns // Value to encode
+		buf      []byte           // Wire encoding
+		pver     uint32           // Protocol version for wire encoding
+		max      int              // Max size of fixed buffer to induce errors
+		writeErr error            // Expected write error
+		readErr  error            // Expected read error
+	}{
+		// Force error in start of block hash.
+		{baseGetBlockTxns, baseGetBlockTxnsEncoded, pver, 0, io.ErrShortWrite, io.EOF},
+		// Force error in middle of block hash.
+		{baseGetBlockTxns, baseGetBlockTxnsEncoded, pver, 8, io.ErrShortWrite, io.ErrUnexpectedEOF},
+		// Force error in number of tx indexes.
+		{baseGetBlockTxns, baseGetBlockTxnsEncoded, pver, 32, io.ErrShortWrite, io.EOF},
+		// Force error in tx index.
+		{baseGetBlockTxns, baseGetBlockTxnsEncoded, pver, 33, io.ErrShortWrite, io.EOF},
+		// Force error in number of stake tx indexes.
+		{baseGetBlockTxns, baseGetBlockTxnsEncoded, pver, 38, io.ErrShortWrite, io.EOF},
+		// Force error in stake tx index.
+		{baseGetBlockTxns, baseGetBlockTxnsEncoded, pver, 39, io.ErrShortWrite, io.EOF},
+		// Force error with tx indexes not ascending.
+		{notAscending, notAscendingEncoded, pver, len(notAscendingEncoded),
+			ErrInvalidTxIndex, ErrInvalidTxIndex},
+		// Force error with too many tx indexes.
+		{tooMany, tooManyEncoded, pver, len(tooManyEncoded), ErrTooManyTxs,
+			ErrTooManyTxs},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Encode to wire format.
+		w := newFixedWriter(test.max)
+		err := test.in.BtcEncode(w, test.pver)
+		if !errors.Is(err, test.writeErr) {
+			t.Errorf("BtcEncode #%d wrong error got: %v, want: %v", i, err,
+				test.writeErr)
+			continue
+		}
+
+		// Decode from wire format.
+		var msg MsgGetBlockTxns
+		r := newFixedReader(test.max, test.buf)
+		err = msg.BtcDecode(r, test.pver)
+		if !errors.Is(err, test.readErr) {
+			t.Errorf("BtcDecode #%d wrong error got: %v, want: %v", i, err,
+				test.readErr)
+			continue
+		}
+	}
+}
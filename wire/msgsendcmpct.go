@@ -0,0 +1,79 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"fmt"
+	"io"
+)
+
+// CmpctBlockProtocol is the version of the compact block relay protocol that
+// is implemented by this package.  It is sent in the sendcmpct message to
+// negotiate the compact block encoding with peers.
+const CmpctBlockProtocol uint64 = 1
+
+// MsgSendCmpct implements the Message interface and represents a sendcmpct
+// message.  It is used to request the peer announce new blocks by sending them
+// as compact blocks (MsgCmpctBlock) in the specified compact block protocol
+// version.
+//
+// When Announce is false, the peer is requested to announce new blocks via the
+// usual mechanisms, but still respond with compact blocks to requests for
+// them.
+//
+// This message was not added until protocol versions starting with
+// CmpctBlockVersion.
+type MsgSendCmpct struct {
+	Announce bool
+	Version  uint64
+}
+
+// BtcDecode decodes r using the protocol encoding into the receiver.  This is
+// part of the Message interface implementation.
+func (msg *MsgSendCmpct) BtcDecode(r io.Reader, pver uint32) error {
+	const op = "MsgSendCmpct.BtcDecode"
+	if pver < CmpctBlockVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	return readElements(r, &msg.Announce, &msg.Version)
+}
+
+// BtcEncode encodes the receiver to w using the protocol encoding.  This is
+// part of the Message interface implementation.
+func (msg *MsgSendCmpct) BtcEncode(w io.Writer, pver uint32) error {
+	const op = "MsgSendCmpct.BtcEncode"
+	if pver < CmpctBlockVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	return writeElements(w, msg.Announce, msg.Version)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgSendCmpct) Command() string {
+	return CmdSendCmpct
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgSendCmpct) MaxPayloadLength(pver uint32) uint32 {
+	// Announce flag 1 byte + version 8 bytes.
+	return 9
+}
+
+// NewMsgSendCmpct returns a new sendcmpct message that conforms to the Message
+// interface using the passed parameters.
+func NewMsgSendCmpct(announce bool, version uint64) *MsgSendCmpct {
+	return &MsgSendCmpct{
+		Announce: announce,
+		Version:  version,
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"fmt"
+	"io"
+)
+
+// MsgStemTx implements the Message interface and represents a stemtx message.
+// It is used to deliver a transaction that is in the stem phase of
+// Dandelion-style private transaction relay to a single peer.
+//
+// Peers that receive the message either forward the transaction to their own
+// stem peer or begin the fluff phase by announcing it to all of their peers as
+// usual.  Unlike the tx message, the transaction is never announced via an
+// inventory vector while it is in the stem phase.
+//
+// This message was not added until protocol versions starting with
+// StemTxVersion.
+type MsgStemTx struct {
+	Tx MsgTx
+}
+
+// BtcDecode decodes r using the protocol encoding into the receiver.  This is
+// part of the Message interface implementation.
+func (msg *MsgStemTx) BtcDecode(r io.Reader, pver uint32) error {
+	const op = "MsgStemTx.BtcDecode"
+	if pver < StemTxVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	return msg.Tx.BtcDecode(r, pver)
+}
+
+// BtcEncode encodes the receiver to w using the protocol encoding.  This is
+// part of the Message interface implementation.
+func (msg *MsgStemTx) BtcEncode(w io.Writer, pver uint32) error {
+	const op = "MsgStemTx.BtcEncode"
+	if pver < StemTxVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	return msg.Tx.BtcEncode(w, pver)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgStemTx) Command() string {
+	return CmdStemTx
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgStemTx) MaxPayloadLength(pver uint32) uint32 {
+	return msg.Tx.MaxPayloadLength(pver)
+}
+
+// NewMsgStemTx returns a new stemtx message that conforms to the Message
+// interface using the passed transaction.  A copy of the transaction is not
+// made, so the caller must not modify it after creating the message.
+func NewMsgStemTx(tx *MsgTx) *MsgStemTx {
+	return &MsgStemTx{Tx: *tx}
+}
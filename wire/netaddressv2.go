@@ -0,0 +1,156 @@
+// Copyright (c) 2025 The Monetarium developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// NetAddressType identifies the network a NetAddressV2 belongs to and
+// therefore how its encoded address is interpreted.
+type NetAddressType uint8
+
+// NOTE: These values match the network address types of the address manager
+// and are used in serialization, so they must not be changed or reused.  The
+// value 3 was previously used for the no longer supported TORv2 addresses.
+const (
+	UnknownAddressType NetAddressType = 0
+	IPv4Address        NetAddressType = 1
+	IPv6Address        NetAddressType = 2
+	TORv3Address       NetAddressType = 4
+)
+
+// netAddressTypeSizes houses the size of the encoded address for each known
+// network address type.
+var netAddressTypeSizes = map[NetAddressType]int{
+	IPv4Address:  4,
+	IPv6Address:  16,
+	TORv3Address: 32,
+}
+
+// String returns the network address type as a human-readable string.
+func (t NetAddressType) String() string {
+	switch t {
+	case IPv4Address:
+		return "IPv4"
+	case IPv6Address:
+		return "IPv6"
+	case TORv3Address:
+		return "TORv3"
+	}
+	return fmt.Sprintf("Unknown NetAddressType (%d)", uint8(t))
+}
+
+// MaxNetAddressV2Size is the maximum size of the encoded address of a
+// NetAddressV2.  It is larger than the size of any currently known address
+// type so new types can be added without breaking older nodes.
+const MaxNetAddressV2Size = 512
+
+// maxNetAddressV2Payload is the max payload size for a NetAddressV2.
+//
+// Timestamp 4 bytes + services 8 bytes + type 1 byte + encoded address length
+// and the max encoded address size + port 2 bytes.
+var maxNetAddressV2Payload = uint32(4 + 8 + 1 +
+	VarIntSerializeSize(MaxNetAddressV2Size) + MaxNetAddressV2Size + 2)
+
+// NetAddressV2 defines information about a peer on the network including the
+// time it was last seen, the services it supports, its network address, and
+// port.  Unlike NetAddress, it supports network address types that can't be
+// represented as an IP address such as TORv3 onion services.
+//
+// The encoded address is prefixed with its length on the wire, so addresses of
+// types unknown to the receiver are still decoded and may simply be ignored.
+type NetAddressV2 struct {
+	// Last time the address was seen.  This is encoded as a uint32 on the wire
+	// and therefore is limited to 2106.
+	Timestamp time.Time
+
+	// Bitfield which identifies the services supported by the address.
+	Services ServiceFlag
+
+	// Type is the type of network the address belongs to.
+	Type NetAddressType
+
+	// EncodedAddr is the address encoded according to its type.  It is the
+	// 4-byte address for IPv4, the 16-byte address for IPv6, and the 32-byte
+	// ed25519 public key of the onion service for TORv3.
+	EncodedAddr []byte
+
+	// Port the peer is using.  This is encoded in big endian on the wire.
+	Port uint16
+}
+
+// NewNetAddressV2 returns a new NetAddressV2 using the provided timestamp,
+// services, address type, encoded address, and port.  The timestamp is rounded
+// to single second precision.
+func NewNetAddressV2(timestamp time.Time, services ServiceFlag,
+	addrType NetAddressType, encodedAddr []byte, port uint16) *NetAddressV2 {
+
+	return &NetAddressV2{
+		Timestamp:   time.Unix(timestamp.Unix(), 0),
+		Services:    services,
+		Type:        addrType,
+		EncodedAddr: encodedAddr,
+		Port:        port,
+	}
+}
+
+// checkNetAddressV2 returns an error when the encoded address of the passed
+// network address is larger than the max allowed or is not the size required
+// by its type when the type is known.
+func checkNetAddressV2(op string, na *NetAddressV2) error {
+	if len(na.EncodedAddr) > MaxNetAddressV2Size {
+		msg := fmt.Sprintf("network address is %d bytes which is more than "+
+			"the max allowed %d", len(na.EncodedAddr), MaxNetAddressV2Size)
+		return messageError(op, ErrInvalidNetAddrType, msg)
+	}
+	size, ok := netAddressTypeSizes[na.Type]
+	if ok && len(na.EncodedAddr) != size {
+		msg := fmt.Sprintf("%v network address is %d bytes instead of %d",
+			na.Type, len(na.EncodedAddr), size)
+		return messageError(op, ErrInvalidNetAddrType, msg)
+	}
+	return nil
+}
+
+// readNetAddressV2 reads an encoded NetAddressV2 from r.
+func readNetAddressV2(op string, r io.Reader, pver uint32, na *NetAddressV2) error {
+	var addrType uint8
+	err := readElements(r, (*uint32Time)(&na.Timestamp), &na.Services,
+		&addrType)
+	if err != nil {
+		return err
+	}
+	na.Type = NetAddressType(addrType)
+	na.EncodedAddr, err = ReadVarBytes(r, pver, MaxNetAddressV2Size,
+		"network address")
+	if err != nil {
+		return err
+	}
+	if err := checkNetAddressV2(op, na); err != nil {
+		return err
+	}
+	na.Port, err = binarySerializer.Uint16(r, bigEndian)
+	return err
+}
+
+// writeNetAddressV2 serializes a NetAddressV2 to w.
+func writeNetAddressV2(op string, w io.Writer, pver uint32, na *NetAddressV2) error {
+	if err := checkNetAddressV2(op, na); err != nil {
+		return err
+	}
+	err := writeElements(w, uint32(na.Timestamp.Unix()), na.Services,
+		uint8(na.Type))
+	if err != nil {
+		return err
+	}
+	if err := WriteVarBytes(w, pver, na.EncodedAddr); err != nil {
+		return err
+	}
+	return binary.Write(w, bigEndian, na.Port)
+}
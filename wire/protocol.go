@@ -17,7 +17,7 @@ const (
 	InitialProcotolVersion uint32 = 1
 
 	// ProtocolVersion is the latest protocol version this package supports.
-	ProtocolVersion uint32 = 13
+	ProtocolVersion uint32 = 16
 
 	// NodeBloomVersion is the protocol version which added the SFNodeBloom
 	// service flag (unused).
@@ -70,6 +70,20 @@ const (
 	//   VAR: [CoinType:1][Value:8 bytes][Version:2][PkScript:var]
 	//   SKA: [CoinType:1][ValLen:1][Value:N bytes][Version:2][PkScript:var]
 	SKABigIntVersion uint32 = 13
+
+	// CmpctBlockVersion is the protocol version which adds the sendcmpct,
+	// cmpctblock, getblocktxns, and blocktxns messages for compact block
+	// relay.
+	CmpctBlockVersion uint32 = 14
+
+	// StemTxVersion is the protocol version which adds the stemtx message for
+	// Dandelion-style private transaction relay.
+	StemTxVersion uint32 = 15
+
+	// AddrV2Version is the protocol version which adds the addrv2 message for
+	// relaying network addresses of types that do not fit in the addr message
+	// such as TORv3 onion service addresses.
+	AddrV2Version uint32 = 16
 )
 
 // ServiceFlag identifies services supported by a Decred peer.